@@ -52,6 +52,8 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=operator, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("vlsingles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VLSingles().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vlogs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VLogs().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmagents"):
@@ -62,10 +64,18 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAlertmanagers().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmalertmanagerconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAlertmanagerConfigs().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmalertmanagersilences"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAlertmanagerSilences().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmauths"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAuths().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmbackuplocations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMBackupLocations().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmclusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMClusters().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmdatamigrations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMDataMigrations().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmgateways"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMGateways().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmnodescrapes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMNodeScrapes().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmpodscrapes"):
@@ -80,6 +90,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMServiceScrapes().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmsingles"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMSingles().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmsnapshots"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMSnapshots().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmstacks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMStacks().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmstaticscrapes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMStaticScrapes().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmusers"):
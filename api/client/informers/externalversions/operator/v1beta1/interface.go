@@ -23,6 +23,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// VLSingles returns a VLSingleInformer.
+	VLSingles() VLSingleInformer
 	// VLogs returns a VLogsInformer.
 	VLogs() VLogsInformer
 	// VMAgents returns a VMAgentInformer.
@@ -33,10 +35,18 @@ type Interface interface {
 	VMAlertmanagers() VMAlertmanagerInformer
 	// VMAlertmanagerConfigs returns a VMAlertmanagerConfigInformer.
 	VMAlertmanagerConfigs() VMAlertmanagerConfigInformer
+	// VMAlertmanagerSilences returns a VMAlertmanagerSilenceInformer.
+	VMAlertmanagerSilences() VMAlertmanagerSilenceInformer
 	// VMAuths returns a VMAuthInformer.
 	VMAuths() VMAuthInformer
+	// VMBackupLocations returns a VMBackupLocationInformer.
+	VMBackupLocations() VMBackupLocationInformer
 	// VMClusters returns a VMClusterInformer.
 	VMClusters() VMClusterInformer
+	// VMDataMigrations returns a VMDataMigrationInformer.
+	VMDataMigrations() VMDataMigrationInformer
+	// VMGateways returns a VMGatewayInformer.
+	VMGateways() VMGatewayInformer
 	// VMNodeScrapes returns a VMNodeScrapeInformer.
 	VMNodeScrapes() VMNodeScrapeInformer
 	// VMPodScrapes returns a VMPodScrapeInformer.
@@ -51,6 +61,10 @@ type Interface interface {
 	VMServiceScrapes() VMServiceScrapeInformer
 	// VMSingles returns a VMSingleInformer.
 	VMSingles() VMSingleInformer
+	// VMSnapshots returns a VMSnapshotInformer.
+	VMSnapshots() VMSnapshotInformer
+	// VMStacks returns a VMStackInformer.
+	VMStacks() VMStackInformer
 	// VMStaticScrapes returns a VMStaticScrapeInformer.
 	VMStaticScrapes() VMStaticScrapeInformer
 	// VMUsers returns a VMUserInformer.
@@ -68,6 +82,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// VLSingles returns a VLSingleInformer.
+func (v *version) VLSingles() VLSingleInformer {
+	return &vLSingleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VLogs returns a VLogsInformer.
 func (v *version) VLogs() VLogsInformer {
 	return &vLogsInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -93,16 +112,36 @@ func (v *version) VMAlertmanagerConfigs() VMAlertmanagerConfigInformer {
 	return &vMAlertmanagerConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMAlertmanagerSilences returns a VMAlertmanagerSilenceInformer.
+func (v *version) VMAlertmanagerSilences() VMAlertmanagerSilenceInformer {
+	return &vMAlertmanagerSilenceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VMAuths returns a VMAuthInformer.
 func (v *version) VMAuths() VMAuthInformer {
 	return &vMAuthInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMBackupLocations returns a VMBackupLocationInformer.
+func (v *version) VMBackupLocations() VMBackupLocationInformer {
+	return &vMBackupLocationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VMClusters returns a VMClusterInformer.
 func (v *version) VMClusters() VMClusterInformer {
 	return &vMClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMDataMigrations returns a VMDataMigrationInformer.
+func (v *version) VMDataMigrations() VMDataMigrationInformer {
+	return &vMDataMigrationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VMGateways returns a VMGatewayInformer.
+func (v *version) VMGateways() VMGatewayInformer {
+	return &vMGatewayInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VMNodeScrapes returns a VMNodeScrapeInformer.
 func (v *version) VMNodeScrapes() VMNodeScrapeInformer {
 	return &vMNodeScrapeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -138,6 +177,16 @@ func (v *version) VMSingles() VMSingleInformer {
 	return &vMSingleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMSnapshots returns a VMSnapshotInformer.
+func (v *version) VMSnapshots() VMSnapshotInformer {
+	return &vMSnapshotInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VMStacks returns a VMStackInformer.
+func (v *version) VMStacks() VMStackInformer {
+	return &vMStackInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VMStaticScrapes returns a VMStaticScrapeInformer.
 func (v *version) VMStaticScrapes() VMStaticScrapeInformer {
 	return &vMStaticScrapeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
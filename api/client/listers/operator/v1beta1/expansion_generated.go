@@ -17,6 +17,14 @@ limitations under the License.
 
 package v1beta1
 
+// VLSingleListerExpansion allows custom methods to be added to
+// VLSingleLister.
+type VLSingleListerExpansion interface{}
+
+// VLSingleNamespaceListerExpansion allows custom methods to be added to
+// VLSingleNamespaceLister.
+type VLSingleNamespaceListerExpansion interface{}
+
 // VLogsListerExpansion allows custom methods to be added to
 // VLogsLister.
 type VLogsListerExpansion interface{}
@@ -57,6 +65,14 @@ type VMAlertmanagerConfigListerExpansion interface{}
 // VMAlertmanagerConfigNamespaceLister.
 type VMAlertmanagerConfigNamespaceListerExpansion interface{}
 
+// VMAlertmanagerSilenceListerExpansion allows custom methods to be added to
+// VMAlertmanagerSilenceLister.
+type VMAlertmanagerSilenceListerExpansion interface{}
+
+// VMAlertmanagerSilenceNamespaceListerExpansion allows custom methods to be added to
+// VMAlertmanagerSilenceNamespaceLister.
+type VMAlertmanagerSilenceNamespaceListerExpansion interface{}
+
 // VMAuthListerExpansion allows custom methods to be added to
 // VMAuthLister.
 type VMAuthListerExpansion interface{}
@@ -65,6 +81,14 @@ type VMAuthListerExpansion interface{}
 // VMAuthNamespaceLister.
 type VMAuthNamespaceListerExpansion interface{}
 
+// VMBackupLocationListerExpansion allows custom methods to be added to
+// VMBackupLocationLister.
+type VMBackupLocationListerExpansion interface{}
+
+// VMBackupLocationNamespaceListerExpansion allows custom methods to be added to
+// VMBackupLocationNamespaceLister.
+type VMBackupLocationNamespaceListerExpansion interface{}
+
 // VMClusterListerExpansion allows custom methods to be added to
 // VMClusterLister.
 type VMClusterListerExpansion interface{}
@@ -73,6 +97,22 @@ type VMClusterListerExpansion interface{}
 // VMClusterNamespaceLister.
 type VMClusterNamespaceListerExpansion interface{}
 
+// VMDataMigrationListerExpansion allows custom methods to be added to
+// VMDataMigrationLister.
+type VMDataMigrationListerExpansion interface{}
+
+// VMDataMigrationNamespaceListerExpansion allows custom methods to be added to
+// VMDataMigrationNamespaceLister.
+type VMDataMigrationNamespaceListerExpansion interface{}
+
+// VMGatewayListerExpansion allows custom methods to be added to
+// VMGatewayLister.
+type VMGatewayListerExpansion interface{}
+
+// VMGatewayNamespaceListerExpansion allows custom methods to be added to
+// VMGatewayNamespaceLister.
+type VMGatewayNamespaceListerExpansion interface{}
+
 // VMNodeScrapeListerExpansion allows custom methods to be added to
 // VMNodeScrapeLister.
 type VMNodeScrapeListerExpansion interface{}
@@ -129,6 +169,22 @@ type VMSingleListerExpansion interface{}
 // VMSingleNamespaceLister.
 type VMSingleNamespaceListerExpansion interface{}
 
+// VMSnapshotListerExpansion allows custom methods to be added to
+// VMSnapshotLister.
+type VMSnapshotListerExpansion interface{}
+
+// VMSnapshotNamespaceListerExpansion allows custom methods to be added to
+// VMSnapshotNamespaceLister.
+type VMSnapshotNamespaceListerExpansion interface{}
+
+// VMStackListerExpansion allows custom methods to be added to
+// VMStackLister.
+type VMStackListerExpansion interface{}
+
+// VMStackNamespaceListerExpansion allows custom methods to be added to
+// VMStackNamespaceLister.
+type VMStackNamespaceListerExpansion interface{}
+
 // VMStaticScrapeListerExpansion allows custom methods to be added to
 // VMStaticScrapeLister.
 type VMStaticScrapeListerExpansion interface{}
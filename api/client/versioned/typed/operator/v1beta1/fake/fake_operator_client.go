@@ -27,6 +27,10 @@ type FakeOperatorV1beta1 struct {
 	*testing.Fake
 }
 
+func (c *FakeOperatorV1beta1) VLSingles(namespace string) v1beta1.VLSingleInterface {
+	return &FakeVLSingles{c, namespace}
+}
+
 func (c *FakeOperatorV1beta1) VLogs(namespace string) v1beta1.VLogsInterface {
 	return &FakeVLogs{c, namespace}
 }
@@ -47,14 +51,30 @@ func (c *FakeOperatorV1beta1) VMAlertmanagerConfigs(namespace string) v1beta1.VM
 	return &FakeVMAlertmanagerConfigs{c, namespace}
 }
 
+func (c *FakeOperatorV1beta1) VMAlertmanagerSilences(namespace string) v1beta1.VMAlertmanagerSilenceInterface {
+	return &FakeVMAlertmanagerSilences{c, namespace}
+}
+
 func (c *FakeOperatorV1beta1) VMAuths(namespace string) v1beta1.VMAuthInterface {
 	return &FakeVMAuths{c, namespace}
 }
 
+func (c *FakeOperatorV1beta1) VMBackupLocations(namespace string) v1beta1.VMBackupLocationInterface {
+	return &FakeVMBackupLocations{c, namespace}
+}
+
 func (c *FakeOperatorV1beta1) VMClusters(namespace string) v1beta1.VMClusterInterface {
 	return &FakeVMClusters{c, namespace}
 }
 
+func (c *FakeOperatorV1beta1) VMDataMigrations(namespace string) v1beta1.VMDataMigrationInterface {
+	return &FakeVMDataMigrations{c, namespace}
+}
+
+func (c *FakeOperatorV1beta1) VMGateways(namespace string) v1beta1.VMGatewayInterface {
+	return &FakeVMGateways{c, namespace}
+}
+
 func (c *FakeOperatorV1beta1) VMNodeScrapes(namespace string) v1beta1.VMNodeScrapeInterface {
 	return &FakeVMNodeScrapes{c, namespace}
 }
@@ -83,6 +103,14 @@ func (c *FakeOperatorV1beta1) VMSingles(namespace string) v1beta1.VMSingleInterf
 	return &FakeVMSingles{c, namespace}
 }
 
+func (c *FakeOperatorV1beta1) VMSnapshots(namespace string) v1beta1.VMSnapshotInterface {
+	return &FakeVMSnapshots{c, namespace}
+}
+
+func (c *FakeOperatorV1beta1) VMStacks(namespace string) v1beta1.VMStackInterface {
+	return &FakeVMStacks{c, namespace}
+}
+
 func (c *FakeOperatorV1beta1) VMStaticScrapes(namespace string) v1beta1.VMStaticScrapeInterface {
 	return &FakeVMStaticScrapes{c, namespace}
 }
@@ -17,6 +17,8 @@ limitations under the License.
 
 package v1beta1
 
+type VLSingleExpansion interface{}
+
 type VLogsExpansion interface{}
 
 type VMAgentExpansion interface{}
@@ -27,10 +29,18 @@ type VMAlertmanagerExpansion interface{}
 
 type VMAlertmanagerConfigExpansion interface{}
 
+type VMAlertmanagerSilenceExpansion interface{}
+
 type VMAuthExpansion interface{}
 
+type VMBackupLocationExpansion interface{}
+
 type VMClusterExpansion interface{}
 
+type VMDataMigrationExpansion interface{}
+
+type VMGatewayExpansion interface{}
+
 type VMNodeScrapeExpansion interface{}
 
 type VMPodScrapeExpansion interface{}
@@ -45,6 +55,10 @@ type VMServiceScrapeExpansion interface{}
 
 type VMSingleExpansion interface{}
 
+type VMSnapshotExpansion interface{}
+
+type VMStackExpansion interface{}
+
 type VMStaticScrapeExpansion interface{}
 
 type VMUserExpansion interface{}
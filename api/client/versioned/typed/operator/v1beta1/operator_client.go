@@ -27,13 +27,18 @@ import (
 
 type OperatorV1beta1Interface interface {
 	RESTClient() rest.Interface
+	VLSinglesGetter
 	VLogsGetter
 	VMAgentsGetter
 	VMAlertsGetter
 	VMAlertmanagersGetter
 	VMAlertmanagerConfigsGetter
+	VMAlertmanagerSilencesGetter
 	VMAuthsGetter
+	VMBackupLocationsGetter
 	VMClustersGetter
+	VMDataMigrationsGetter
+	VMGatewaysGetter
 	VMNodeScrapesGetter
 	VMPodScrapesGetter
 	VMProbesGetter
@@ -41,6 +46,8 @@ type OperatorV1beta1Interface interface {
 	VMScrapeConfigsGetter
 	VMServiceScrapesGetter
 	VMSinglesGetter
+	VMSnapshotsGetter
+	VMStacksGetter
 	VMStaticScrapesGetter
 	VMUsersGetter
 }
@@ -50,6 +57,10 @@ type OperatorV1beta1Client struct {
 	restClient rest.Interface
 }
 
+func (c *OperatorV1beta1Client) VLSingles(namespace string) VLSingleInterface {
+	return newVLSingles(c, namespace)
+}
+
 func (c *OperatorV1beta1Client) VLogs(namespace string) VLogsInterface {
 	return newVLogs(c, namespace)
 }
@@ -70,14 +81,30 @@ func (c *OperatorV1beta1Client) VMAlertmanagerConfigs(namespace string) VMAlertm
 	return newVMAlertmanagerConfigs(c, namespace)
 }
 
+func (c *OperatorV1beta1Client) VMAlertmanagerSilences(namespace string) VMAlertmanagerSilenceInterface {
+	return newVMAlertmanagerSilences(c, namespace)
+}
+
 func (c *OperatorV1beta1Client) VMAuths(namespace string) VMAuthInterface {
 	return newVMAuths(c, namespace)
 }
 
+func (c *OperatorV1beta1Client) VMBackupLocations(namespace string) VMBackupLocationInterface {
+	return newVMBackupLocations(c, namespace)
+}
+
 func (c *OperatorV1beta1Client) VMClusters(namespace string) VMClusterInterface {
 	return newVMClusters(c, namespace)
 }
 
+func (c *OperatorV1beta1Client) VMDataMigrations(namespace string) VMDataMigrationInterface {
+	return newVMDataMigrations(c, namespace)
+}
+
+func (c *OperatorV1beta1Client) VMGateways(namespace string) VMGatewayInterface {
+	return newVMGateways(c, namespace)
+}
+
 func (c *OperatorV1beta1Client) VMNodeScrapes(namespace string) VMNodeScrapeInterface {
 	return newVMNodeScrapes(c, namespace)
 }
@@ -106,6 +133,14 @@ func (c *OperatorV1beta1Client) VMSingles(namespace string) VMSingleInterface {
 	return newVMSingles(c, namespace)
 }
 
+func (c *OperatorV1beta1Client) VMSnapshots(namespace string) VMSnapshotInterface {
+	return newVMSnapshots(c, namespace)
+}
+
+func (c *OperatorV1beta1Client) VMStacks(namespace string) VMStackInterface {
+	return newVMStacks(c, namespace)
+}
+
 func (c *OperatorV1beta1Client) VMStaticScrapes(namespace string) VMStaticScrapeInterface {
 	return newVMStaticScrapes(c, namespace)
 }
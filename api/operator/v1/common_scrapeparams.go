@@ -0,0 +1,64 @@
+package v1
+
+import (
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// RelabelConfig allows dynamic rewriting of the label set
+// More info: https://docs.victoriametrics.com/#relabeling
+//
+// In contrast to v1beta1 it doesn't support snake case form of
+// source_labels and target_label fields.
+// +k8s:openapi-gen=true
+type RelabelConfig struct {
+	// The source labels select values from existing labels. Their content is concatenated
+	// using the configured separator and matched against the configured regular expression
+	// for the replace, keep, and drop actions.
+	// +optional
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+	// Separator placed between concatenated source label values. default is ';'.
+	// +optional
+	Separator *string `json:"separator,omitempty"`
+	// Label to which the resulting value is written in a replace action.
+	// It is mandatory for replace actions. Regex capture groups are available.
+	// +optional
+	TargetLabel string `json:"targetLabel,omitempty"`
+	// Regular expression against which the extracted value is matched. Default is '(.*)'
+	// victoriaMetrics supports multiline regex joined with |
+	// https://docs.victoriametrics.com/vmagent/#relabeling-enhancements
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Regex vmv1beta1.StringOrArray `json:"regex,omitempty"`
+	// Modulus to take of the hash of the source label values.
+	// +optional
+	Modulus uint64 `json:"modulus,omitempty"`
+	// Replacement value against which a regex replace is performed if the
+	// regular expression matches. Regex capture groups are available. Default is '$1'
+	// +optional
+	Replacement *string `json:"replacement,omitempty"`
+	// Action to perform based on regex matching. Default is 'replace'
+	// +optional
+	Action string `json:"action,omitempty"`
+	// If represents metricsQL match expression (or list of expressions): '{__name__=~"foo_.*"}'
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	If vmv1beta1.StringOrArray `json:"if,omitempty"`
+	// Match is used together with Labels for `action: graphite`
+	// +optional
+	Match string `json:"match,omitempty"`
+	// Labels is used together with Match for `action: graphite`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// EndpointRelabelings defines service discovery and metrics relabeling configuration for endpoints
+type EndpointRelabelings struct {
+	// MetricRelabelConfigs to apply to samples after scrapping.
+	// +optional
+	MetricRelabelConfigs []*RelabelConfig `json:"metricRelabelConfigs,omitempty"`
+	// RelabelConfigs to apply to samples during service discovery.
+	// +optional
+	RelabelConfigs []*RelabelConfig `json:"relabelConfigs,omitempty"`
+}
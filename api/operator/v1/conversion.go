@@ -0,0 +1,81 @@
+package v1
+
+import (
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func endpointRelabelingsToHub(src EndpointRelabelings) vmv1beta1.EndpointRelabelings {
+	return vmv1beta1.EndpointRelabelings{
+		MetricRelabelConfigs: relabelConfigsToHub(src.MetricRelabelConfigs),
+		RelabelConfigs:       relabelConfigsToHub(src.RelabelConfigs),
+	}
+}
+
+func endpointRelabelingsFromHub(src vmv1beta1.EndpointRelabelings) EndpointRelabelings {
+	return EndpointRelabelings{
+		MetricRelabelConfigs: relabelConfigsFromHub(src.MetricRelabelConfigs),
+		RelabelConfigs:       relabelConfigsFromHub(src.RelabelConfigs),
+	}
+}
+
+func relabelConfigsToHub(src []*RelabelConfig) []*vmv1beta1.RelabelConfig {
+	if src == nil {
+		return nil
+	}
+	dst := make([]*vmv1beta1.RelabelConfig, 0, len(src))
+	for _, rc := range src {
+		if rc == nil {
+			dst = append(dst, nil)
+			continue
+		}
+		dst = append(dst, &vmv1beta1.RelabelConfig{
+			SourceLabels: rc.SourceLabels,
+			Separator:    rc.Separator,
+			TargetLabel:  rc.TargetLabel,
+			Regex:        rc.Regex,
+			Modulus:      rc.Modulus,
+			Replacement:  rc.Replacement,
+			Action:       rc.Action,
+			If:           rc.If,
+			Match:        rc.Match,
+			Labels:       rc.Labels,
+		})
+	}
+	return dst
+}
+
+func relabelConfigsFromHub(src []*vmv1beta1.RelabelConfig) []*RelabelConfig {
+	if src == nil {
+		return nil
+	}
+	dst := make([]*RelabelConfig, 0, len(src))
+	for _, rc := range src {
+		if rc == nil {
+			dst = append(dst, nil)
+			continue
+		}
+		// v1beta1 objects stored before conversion may have only snake case form of fields
+		// camel case form has priority, the same as at v1beta1.RelabelConfig.UnmarshalJSON
+		sourceLabels := rc.SourceLabels
+		if len(sourceLabels) == 0 {
+			sourceLabels = rc.UnderScoreSourceLabels
+		}
+		targetLabel := rc.TargetLabel
+		if targetLabel == "" {
+			targetLabel = rc.UnderScoreTargetLabel
+		}
+		dst = append(dst, &RelabelConfig{
+			SourceLabels: sourceLabels,
+			Separator:    rc.Separator,
+			TargetLabel:  targetLabel,
+			Regex:        rc.Regex,
+			Modulus:      rc.Modulus,
+			Replacement:  rc.Replacement,
+			Action:       rc.Action,
+			If:           rc.If,
+			Match:        rc.Match,
+			Labels:       rc.Labels,
+		})
+	}
+	return dst
+}
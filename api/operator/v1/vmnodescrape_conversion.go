@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+var _ conversion.Convertible = &VMNodeScrape{}
+
+// ConvertTo converts VMNodeScrape to the hub v1beta1 version
+func (cr *VMNodeScrape) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*vmv1beta1.VMNodeScrape)
+	if !ok {
+		return fmt.Errorf("unexpected hub type=%T, want *v1beta1.VMNodeScrape", hub)
+	}
+	dst.ObjectMeta = cr.ObjectMeta
+	dst.Status = cr.Status
+	dst.Spec = vmv1beta1.VMNodeScrapeSpec{
+		JobLabel:             cr.Spec.JobLabel,
+		TargetLabels:         cr.Spec.TargetLabels,
+		Port:                 cr.Spec.Port,
+		EndpointRelabelings:  endpointRelabelingsToHub(cr.Spec.EndpointRelabelings),
+		EndpointAuth:         cr.Spec.EndpointAuth,
+		EndpointScrapeParams: cr.Spec.EndpointScrapeParams,
+		Selector:             cr.Spec.Selector,
+	}
+	return nil
+}
+
+// ConvertFrom converts VMNodeScrape from the hub v1beta1 version
+func (cr *VMNodeScrape) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*vmv1beta1.VMNodeScrape)
+	if !ok {
+		return fmt.Errorf("unexpected hub type=%T, want *v1beta1.VMNodeScrape", hub)
+	}
+	cr.ObjectMeta = src.ObjectMeta
+	cr.Status = src.Status
+	cr.Spec = VMNodeScrapeSpec{
+		JobLabel:             src.Spec.JobLabel,
+		TargetLabels:         src.Spec.TargetLabels,
+		Port:                 src.Spec.Port,
+		EndpointRelabelings:  endpointRelabelingsFromHub(src.Spec.EndpointRelabelings),
+		EndpointAuth:         src.Spec.EndpointAuth,
+		EndpointScrapeParams: src.Spec.EndpointScrapeParams,
+		Selector:             src.Spec.Selector,
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// VMNodeScrapeSpec defines specification for VMNodeScrape.
+type VMNodeScrapeSpec struct {
+	// The label to use to retrieve the job name from.
+	// +optional
+	JobLabel string `json:"jobLabel,omitempty"`
+	// TargetLabels transfers labels on the Kubernetes Node onto the target.
+	// +optional
+	TargetLabels []string `json:"targetLabels,omitempty"`
+	// Name of the port exposed at Node.
+	// +optional
+	Port                           string `json:"port,omitempty"`
+	EndpointRelabelings            `json:",inline"`
+	vmv1beta1.EndpointAuth         `json:",inline"`
+	vmv1beta1.EndpointScrapeParams `json:",inline"`
+
+	// Selector to select kubernetes Nodes.
+	// +optional
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// VMNodeScrape defines discovery for targets placed on kubernetes nodes,
+// usually its node-exporters and other host services.
+// InternalIP is used as __address__ for scraping.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
+// +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+type VMNodeScrape struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMNodeScrapeSpec             `json:"spec,omitempty"`
+	Status vmv1beta1.ScrapeObjectStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// VMNodeScrapeList contains a list of VMNodeScrape
+type VMNodeScrapeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMNodeScrape `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VMNodeScrape{}, &VMNodeScrapeList{})
+}
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+var _ conversion.Convertible = &VMPodScrape{}
+
+// ConvertTo converts VMPodScrape to the hub v1beta1 version
+func (cr *VMPodScrape) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*vmv1beta1.VMPodScrape)
+	if !ok {
+		return fmt.Errorf("unexpected hub type=%T, want *v1beta1.VMPodScrape", hub)
+	}
+	dst.ObjectMeta = cr.ObjectMeta
+	dst.Status = cr.Status
+	dst.Spec = vmv1beta1.VMPodScrapeSpec{
+		JobLabel:          cr.Spec.JobLabel,
+		PodTargetLabels:   cr.Spec.PodTargetLabels,
+		Selector:          cr.Spec.Selector,
+		NamespaceSelector: cr.Spec.NamespaceSelector,
+		SampleLimit:       cr.Spec.SampleLimit,
+		SeriesLimit:       cr.Spec.SeriesLimit,
+		AttachMetadata:    cr.Spec.AttachMetadata,
+	}
+	for _, ep := range cr.Spec.PodMetricsEndpoints {
+		dst.Spec.PodMetricsEndpoints = append(dst.Spec.PodMetricsEndpoints, vmv1beta1.PodMetricsEndpoint{
+			Port:                 ep.Port,
+			TargetPort:           ep.TargetPort,
+			EndpointRelabelings:  endpointRelabelingsToHub(ep.EndpointRelabelings),
+			EndpointAuth:         ep.EndpointAuth,
+			EndpointScrapeParams: ep.EndpointScrapeParams,
+			AttachMetadata:       ep.AttachMetadata,
+			FilterRunning:        ep.FilterRunning,
+		})
+	}
+	return nil
+}
+
+// ConvertFrom converts VMPodScrape from the hub v1beta1 version
+func (cr *VMPodScrape) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*vmv1beta1.VMPodScrape)
+	if !ok {
+		return fmt.Errorf("unexpected hub type=%T, want *v1beta1.VMPodScrape", hub)
+	}
+	cr.ObjectMeta = src.ObjectMeta
+	cr.Status = src.Status
+	cr.Spec = VMPodScrapeSpec{
+		JobLabel:          src.Spec.JobLabel,
+		PodTargetLabels:   src.Spec.PodTargetLabels,
+		Selector:          src.Spec.Selector,
+		NamespaceSelector: src.Spec.NamespaceSelector,
+		SampleLimit:       src.Spec.SampleLimit,
+		SeriesLimit:       src.Spec.SeriesLimit,
+		AttachMetadata:    src.Spec.AttachMetadata,
+	}
+	for _, ep := range src.Spec.PodMetricsEndpoints {
+		cr.Spec.PodMetricsEndpoints = append(cr.Spec.PodMetricsEndpoints, PodMetricsEndpoint{
+			Port:                 ep.Port,
+			TargetPort:           ep.TargetPort,
+			EndpointRelabelings:  endpointRelabelingsFromHub(ep.EndpointRelabelings),
+			EndpointAuth:         ep.EndpointAuth,
+			EndpointScrapeParams: ep.EndpointScrapeParams,
+			AttachMetadata:       ep.AttachMetadata,
+			FilterRunning:        ep.FilterRunning,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// VMPodScrapeSpec defines the desired state of VMPodScrape
+type VMPodScrapeSpec struct {
+	// The label to use to retrieve the job name from.
+	// +optional
+	JobLabel string `json:"jobLabel,omitempty"`
+	// PodTargetLabels transfers labels on the Kubernetes Pod onto the target.
+	// +optional
+	PodTargetLabels []string `json:"podTargetLabels,omitempty"`
+	// A list of endpoints allowed as part of this PodMonitor.
+	PodMetricsEndpoints []PodMetricsEndpoint `json:"podMetricsEndpoints"`
+	// Selector to select Pod objects.
+	// +optional
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+	// Selector to select which namespaces the Endpoints objects are discovered from.
+	// +optional
+	NamespaceSelector vmv1beta1.NamespaceSelector `json:"namespaceSelector,omitempty"`
+	// SampleLimit defines per-scrape limit on number of scraped samples that will be accepted.
+	// +optional
+	SampleLimit uint64 `json:"sampleLimit,omitempty"`
+	// SeriesLimit defines per-scrape limit on number of unique time series
+	// a single target can expose during all the scrapes on the time window of 24h.
+	// +optional
+	SeriesLimit uint64 `json:"seriesLimit,omitempty"`
+	// AttachMetadata configures metadata attaching from service discovery
+	// +optional
+	AttachMetadata vmv1beta1.AttachMetadata `json:"attach_metadata,omitempty"`
+}
+
+// PodMetricsEndpoint defines a scrapeable endpoint of a Kubernetes Pod serving metrics.
+type PodMetricsEndpoint struct {
+	// Name of the port exposed at Pod.
+	// +optional
+	Port string `json:"port,omitempty"`
+	// TargetPort
+	// Name or number of the pod port this endpoint refers to. Mutually exclusive with port.
+	// +optional
+	TargetPort                     *intstr.IntOrString `json:"targetPort,omitempty"`
+	EndpointRelabelings            `json:",inline"`
+	vmv1beta1.EndpointAuth         `json:",inline"`
+	vmv1beta1.EndpointScrapeParams `json:",inline"`
+	// AttachMetadata configures metadata attaching from service discovery
+	// +optional
+	AttachMetadata vmv1beta1.AttachMetadata `json:"attach_metadata,omitempty"`
+	// FilterRunning applies filter with pod status == running
+	// it prevents from scrapping metrics at failed or succeed state pods.
+	// enabled by default
+	// +optional
+	FilterRunning *bool `json:"filterRunning,omitempty"`
+}
+
+// VMPodScrape is scrape configuration for pods,
+// it generates vmagent's config for scraping pod targets
+// based on selectors.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmpodscrapes,scope=Namespaced
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
+// +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+type VMPodScrape struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMPodScrapeSpec              `json:"spec,omitempty"`
+	Status vmv1beta1.ScrapeObjectStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// VMPodScrapeList contains a list of VMPodScrape
+type VMPodScrapeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMPodScrape `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VMPodScrape{}, &VMPodScrapeList{})
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+var _ conversion.Convertible = &VMProbe{}
+
+// ConvertTo converts VMProbe to the hub v1beta1 version
+func (cr *VMProbe) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*vmv1beta1.VMProbe)
+	if !ok {
+		return fmt.Errorf("unexpected hub type=%T, want *v1beta1.VMProbe", hub)
+	}
+	dst.ObjectMeta = cr.ObjectMeta
+	dst.Status = cr.Status
+	dst.Spec = vmv1beta1.VMProbeSpec{
+		JobName:              cr.Spec.JobName,
+		VMProberSpec:         cr.Spec.VMProberSpec,
+		Module:               cr.Spec.Module,
+		MetricRelabelConfigs: relabelConfigsToHub(cr.Spec.MetricRelabelConfigs),
+		EndpointAuth:         cr.Spec.EndpointAuth,
+		EndpointScrapeParams: cr.Spec.EndpointScrapeParams,
+	}
+	if sc := cr.Spec.Targets.StaticConfig; sc != nil {
+		dst.Spec.Targets.StaticConfig = &vmv1beta1.VMProbeTargetStaticConfig{
+			Targets:        sc.Targets,
+			Labels:         sc.Labels,
+			RelabelConfigs: relabelConfigsToHub(sc.RelabelConfigs),
+		}
+	}
+	if ing := cr.Spec.Targets.Ingress; ing != nil {
+		dst.Spec.Targets.Ingress = &vmv1beta1.ProbeTargetIngress{
+			Selector:          ing.Selector,
+			NamespaceSelector: ing.NamespaceSelector,
+			RelabelConfigs:    relabelConfigsToHub(ing.RelabelConfigs),
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts VMProbe from the hub v1beta1 version
+func (cr *VMProbe) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*vmv1beta1.VMProbe)
+	if !ok {
+		return fmt.Errorf("unexpected hub type=%T, want *v1beta1.VMProbe", hub)
+	}
+	cr.ObjectMeta = src.ObjectMeta
+	cr.Status = src.Status
+	cr.Spec = VMProbeSpec{
+		JobName:              src.Spec.JobName,
+		VMProberSpec:         src.Spec.VMProberSpec,
+		Module:               src.Spec.Module,
+		MetricRelabelConfigs: relabelConfigsFromHub(src.Spec.MetricRelabelConfigs),
+		EndpointAuth:         src.Spec.EndpointAuth,
+		EndpointScrapeParams: src.Spec.EndpointScrapeParams,
+	}
+	if sc := src.Spec.Targets.StaticConfig; sc != nil {
+		cr.Spec.Targets.StaticConfig = &VMProbeTargetStaticConfig{
+			Targets:        sc.Targets,
+			Labels:         sc.Labels,
+			RelabelConfigs: relabelConfigsFromHub(sc.RelabelConfigs),
+		}
+	}
+	if ing := src.Spec.Targets.Ingress; ing != nil {
+		cr.Spec.Targets.Ingress = &ProbeTargetIngress{
+			Selector:          ing.Selector,
+			NamespaceSelector: ing.NamespaceSelector,
+			RelabelConfigs:    relabelConfigsFromHub(ing.RelabelConfigs),
+		}
+	}
+	return nil
+}
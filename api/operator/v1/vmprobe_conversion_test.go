@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestVMProbeConversion(t *testing.T) {
+	f := func(src *vmv1beta1.VMProbe, want *VMProbe) {
+		t.Helper()
+		var got VMProbe
+		assert.NoError(t, got.ConvertFrom(src))
+		assert.Equal(t, want, &got)
+
+		// convert back and check that object is stable
+		var hub vmv1beta1.VMProbe
+		assert.NoError(t, got.ConvertTo(&hub))
+		var gotAgain VMProbe
+		assert.NoError(t, gotAgain.ConvertFrom(&hub))
+		assert.Equal(t, want, &gotAgain)
+	}
+	meta := metav1.ObjectMeta{Name: "probe", Namespace: "default"}
+	prober := vmv1beta1.VMProberSpec{URL: "blackbox:9115"}
+
+	// static targets with snake case relabeling fields
+	f(&vmv1beta1.VMProbe{
+		ObjectMeta: meta,
+		Spec: vmv1beta1.VMProbeSpec{
+			VMProberSpec: prober,
+			Module:       "http_2xx",
+			MetricRelabelConfigs: []*vmv1beta1.RelabelConfig{
+				{UnderScoreSourceLabels: []string{"instance"}, UnderScoreTargetLabel: "target"},
+			},
+			Targets: vmv1beta1.VMProbeTargets{
+				StaticConfig: &vmv1beta1.VMProbeTargetStaticConfig{
+					Targets: []string{"https://example.com"},
+					RelabelConfigs: []*vmv1beta1.RelabelConfig{
+						{UnderScoreTargetLabel: "env", Replacement: ptr.To("prod")},
+					},
+				},
+			},
+		},
+	}, &VMProbe{
+		ObjectMeta: meta,
+		Spec: VMProbeSpec{
+			VMProberSpec: prober,
+			Module:       "http_2xx",
+			MetricRelabelConfigs: []*RelabelConfig{
+				{SourceLabels: []string{"instance"}, TargetLabel: "target"},
+			},
+			Targets: VMProbeTargets{
+				StaticConfig: &VMProbeTargetStaticConfig{
+					Targets: []string{"https://example.com"},
+					RelabelConfigs: []*RelabelConfig{
+						{TargetLabel: "env", Replacement: ptr.To("prod")},
+					},
+				},
+			},
+		},
+	})
+
+	// ingress targets
+	f(&vmv1beta1.VMProbe{
+		ObjectMeta: meta,
+		Spec: vmv1beta1.VMProbeSpec{
+			VMProberSpec: prober,
+			Targets: vmv1beta1.VMProbeTargets{
+				Ingress: &vmv1beta1.ProbeTargetIngress{
+					Selector:          metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					NamespaceSelector: vmv1beta1.NamespaceSelector{Any: true},
+					RelabelConfigs: []*vmv1beta1.RelabelConfig{
+						{UnderScoreSourceLabels: []string{"__meta_kubernetes_ingress_name"}, TargetLabel: "ingress"},
+					},
+				},
+			},
+		},
+	}, &VMProbe{
+		ObjectMeta: meta,
+		Spec: VMProbeSpec{
+			VMProberSpec: prober,
+			Targets: VMProbeTargets{
+				Ingress: &ProbeTargetIngress{
+					Selector:          metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					NamespaceSelector: vmv1beta1.NamespaceSelector{Any: true},
+					RelabelConfigs: []*RelabelConfig{
+						{SourceLabels: []string{"__meta_kubernetes_ingress_name"}, TargetLabel: "ingress"},
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,84 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// VMProbeSpec contains specification parameters for a Probe.
+type VMProbeSpec struct {
+	// The job name assigned to scraped metrics by default.
+	JobName string `json:"jobName,omitempty"`
+	// Specification for the prober to use for probing targets.
+	// The prober.URL parameter is required. Targets cannot be probed if left empty.
+	VMProberSpec vmv1beta1.VMProberSpec `json:"vmProberSpec"`
+	// The module to use for probing specifying how to probe the target.
+	// Example module configuring in the blackbox exporter:
+	// https://github.com/prometheus/blackbox_exporter/blob/master/example.yml
+	Module string `json:"module,omitempty"`
+	// Targets defines a set of static and/or dynamically discovered targets to be probed using the prober.
+	Targets VMProbeTargets `json:"targets,omitempty"`
+	// MetricRelabelConfigs to apply to samples after scrapping.
+	// +optional
+	MetricRelabelConfigs []*RelabelConfig `json:"metricRelabelConfigs,omitempty"`
+
+	vmv1beta1.EndpointAuth         `json:",inline"`
+	vmv1beta1.EndpointScrapeParams `json:",inline"`
+}
+
+// VMProbeTargets defines a set of static and dynamically discovered targets for the prober.
+type VMProbeTargets struct {
+	// StaticConfig defines static targets which are considers for probing.
+	StaticConfig *VMProbeTargetStaticConfig `json:"staticConfig,omitempty"`
+	// Ingress defines the set of dynamically discovered ingress objects which hosts are considered for probing.
+	Ingress *ProbeTargetIngress `json:"ingress,omitempty"`
+}
+
+// VMProbeTargetStaticConfig defines the set of static targets considered for probing.
+type VMProbeTargetStaticConfig struct {
+	// Targets is a list of URLs to probe using the configured prober.
+	Targets []string `json:"targets"`
+	// Labels assigned to all metrics scraped from the targets.
+	Labels map[string]string `json:"labels,omitempty"`
+	// RelabelConfigs to apply to samples during service discovery.
+	RelabelConfigs []*RelabelConfig `json:"relabelingConfigs,omitempty"`
+}
+
+// ProbeTargetIngress defines the set of Ingress objects considered for probing.
+type ProbeTargetIngress struct {
+	// Select Ingress objects by labels.
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+	// Select Ingress objects by namespace.
+	NamespaceSelector vmv1beta1.NamespaceSelector `json:"namespaceSelector,omitempty"`
+	// RelabelConfigs to apply to samples during service discovery.
+	RelabelConfigs []*RelabelConfig `json:"relabelingConfigs,omitempty"`
+}
+
+// VMProbe defines a probe for targets, that will be executed with prober,
+// like blackbox exporter.
+// It helps to monitor reachability of target with various checks.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
+// +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+type VMProbe struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMProbeSpec                  `json:"spec"`
+	Status vmv1beta1.ScrapeObjectStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// VMProbeList contains a list of VMProbe
+type VMProbeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMProbe `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VMProbe{}, &VMProbeList{})
+}
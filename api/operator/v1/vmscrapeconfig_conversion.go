@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+var _ conversion.Convertible = &VMScrapeConfig{}
+
+// ConvertTo converts VMScrapeConfig to the hub v1beta1 version
+func (cr *VMScrapeConfig) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*vmv1beta1.VMScrapeConfig)
+	if !ok {
+		return fmt.Errorf("unexpected hub type=%T, want *v1beta1.VMScrapeConfig", hub)
+	}
+	dst.ObjectMeta = cr.ObjectMeta
+	dst.Status = cr.Status
+	dst.Spec = vmv1beta1.VMScrapeConfigSpec{
+		StaticConfigs:         cr.Spec.StaticConfigs,
+		FileSDConfigs:         cr.Spec.FileSDConfigs,
+		HTTPSDConfigs:         cr.Spec.HTTPSDConfigs,
+		KubernetesSDConfigs:   cr.Spec.KubernetesSDConfigs,
+		ConsulSDConfigs:       cr.Spec.ConsulSDConfigs,
+		DNSSDConfigs:          cr.Spec.DNSSDConfigs,
+		EC2SDConfigs:          cr.Spec.EC2SDConfigs,
+		AzureSDConfigs:        cr.Spec.AzureSDConfigs,
+		GCESDConfigs:          cr.Spec.GCESDConfigs,
+		OpenStackSDConfigs:    cr.Spec.OpenStackSDConfigs,
+		DigitalOceanSDConfigs: cr.Spec.DigitalOceanSDConfigs,
+		EndpointScrapeParams:  cr.Spec.EndpointScrapeParams,
+		EndpointRelabelings:   endpointRelabelingsToHub(cr.Spec.EndpointRelabelings),
+		EndpointAuth:          cr.Spec.EndpointAuth,
+	}
+	return nil
+}
+
+// ConvertFrom converts VMScrapeConfig from the hub v1beta1 version
+func (cr *VMScrapeConfig) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*vmv1beta1.VMScrapeConfig)
+	if !ok {
+		return fmt.Errorf("unexpected hub type=%T, want *v1beta1.VMScrapeConfig", hub)
+	}
+	cr.ObjectMeta = src.ObjectMeta
+	cr.Status = src.Status
+	cr.Spec = VMScrapeConfigSpec{
+		StaticConfigs:         src.Spec.StaticConfigs,
+		FileSDConfigs:         src.Spec.FileSDConfigs,
+		HTTPSDConfigs:         src.Spec.HTTPSDConfigs,
+		KubernetesSDConfigs:   src.Spec.KubernetesSDConfigs,
+		ConsulSDConfigs:       src.Spec.ConsulSDConfigs,
+		DNSSDConfigs:          src.Spec.DNSSDConfigs,
+		EC2SDConfigs:          src.Spec.EC2SDConfigs,
+		AzureSDConfigs:        src.Spec.AzureSDConfigs,
+		GCESDConfigs:          src.Spec.GCESDConfigs,
+		OpenStackSDConfigs:    src.Spec.OpenStackSDConfigs,
+		DigitalOceanSDConfigs: src.Spec.DigitalOceanSDConfigs,
+		EndpointScrapeParams:  src.Spec.EndpointScrapeParams,
+		EndpointRelabelings:   endpointRelabelingsFromHub(src.Spec.EndpointRelabelings),
+		EndpointAuth:          src.Spec.EndpointAuth,
+	}
+	return nil
+}
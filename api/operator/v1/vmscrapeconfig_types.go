@@ -0,0 +1,74 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// VMScrapeConfigSpec defines the desired state of VMScrapeConfig
+type VMScrapeConfigSpec struct {
+	// StaticConfigs defines a list of static targets with a common label set.
+	// +optional
+	StaticConfigs []vmv1beta1.StaticConfig `json:"staticConfigs,omitempty"`
+	// FileSDConfigs defines a list of file service discovery configurations.
+	// +optional
+	FileSDConfigs []vmv1beta1.FileSDConfig `json:"fileSDConfigs,omitempty"`
+	// HTTPSDConfigs defines a list of HTTP service discovery configurations.
+	// +optional
+	HTTPSDConfigs []vmv1beta1.HTTPSDConfig `json:"httpSDConfigs,omitempty"`
+	// KubernetesSDConfigs defines a list of Kubernetes service discovery configurations.
+	// +optional
+	KubernetesSDConfigs []vmv1beta1.KubernetesSDConfig `json:"kubernetesSDConfigs,omitempty"`
+	// ConsulSDConfigs defines a list of Consul service discovery configurations.
+	// +optional
+	ConsulSDConfigs []vmv1beta1.ConsulSDConfig `json:"consulSDConfigs,omitempty"`
+	// DNSSDConfigs defines a list of DNS service discovery configurations.
+	// +optional
+	DNSSDConfigs []vmv1beta1.DNSSDConfig `json:"dnsSDConfigs,omitempty"`
+	// EC2SDConfigs defines a list of EC2 service discovery configurations.
+	// +optional
+	EC2SDConfigs []vmv1beta1.EC2SDConfig `json:"ec2SDConfigs,omitempty"`
+	// AzureSDConfigs defines a list of Azure service discovery configurations.
+	// +optional
+	AzureSDConfigs []vmv1beta1.AzureSDConfig `json:"azureSDConfigs,omitempty"`
+	// GCESDConfigs defines a list of GCE service discovery configurations.
+	// +optional
+	GCESDConfigs []vmv1beta1.GCESDConfig `json:"gceSDConfigs,omitempty"`
+	// OpenStackSDConfigs defines a list of OpenStack service discovery configurations.
+	// +optional
+	OpenStackSDConfigs []vmv1beta1.OpenStackSDConfig `json:"openstackSDConfigs,omitempty"`
+	// DigitalOceanSDConfigs defines a list of DigitalOcean service discovery configurations.
+	// +optional
+	DigitalOceanSDConfigs          []vmv1beta1.DigitalOceanSDConfig `json:"digitalOceanSDConfigs,omitempty"`
+	vmv1beta1.EndpointScrapeParams `json:",inline"`
+	EndpointRelabelings            `json:",inline"`
+	vmv1beta1.EndpointAuth         `json:",inline"`
+}
+
+// VMScrapeConfig specifies a set of targets and parameters describing how to scrape them.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmscrapeconfigs,scope=Namespaced
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
+// +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+type VMScrapeConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMScrapeConfigSpec           `json:"spec,omitempty"`
+	Status vmv1beta1.ScrapeObjectStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// VMScrapeConfigList contains a list of VMScrapeConfig
+type VMScrapeConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMScrapeConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VMScrapeConfig{}, &VMScrapeConfigList{})
+}
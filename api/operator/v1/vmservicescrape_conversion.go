@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+var _ conversion.Convertible = &VMServiceScrape{}
+
+// ConvertTo converts VMServiceScrape to the hub v1beta1 version
+func (cr *VMServiceScrape) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*vmv1beta1.VMServiceScrape)
+	if !ok {
+		return fmt.Errorf("unexpected hub type=%T, want *v1beta1.VMServiceScrape", hub)
+	}
+	dst.ObjectMeta = cr.ObjectMeta
+	dst.Status = cr.Status
+	dst.Spec = vmv1beta1.VMServiceScrapeSpec{
+		DiscoveryRole:     cr.Spec.DiscoveryRole,
+		JobLabel:          cr.Spec.JobLabel,
+		TargetLabels:      cr.Spec.TargetLabels,
+		PodTargetLabels:   cr.Spec.PodTargetLabels,
+		Selector:          cr.Spec.Selector,
+		NamespaceSelector: cr.Spec.NamespaceSelector,
+		SampleLimit:       cr.Spec.SampleLimit,
+		SeriesLimit:       cr.Spec.SeriesLimit,
+		AttachMetadata:    cr.Spec.AttachMetadata,
+	}
+	for _, ep := range cr.Spec.Endpoints {
+		dst.Spec.Endpoints = append(dst.Spec.Endpoints, vmv1beta1.Endpoint{
+			Port:                 ep.Port,
+			TargetPort:           ep.TargetPort,
+			EndpointRelabelings:  endpointRelabelingsToHub(ep.EndpointRelabelings),
+			EndpointAuth:         ep.EndpointAuth,
+			EndpointScrapeParams: ep.EndpointScrapeParams,
+			AttachMetadata:       ep.AttachMetadata,
+		})
+	}
+	return nil
+}
+
+// ConvertFrom converts VMServiceScrape from the hub v1beta1 version
+func (cr *VMServiceScrape) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*vmv1beta1.VMServiceScrape)
+	if !ok {
+		return fmt.Errorf("unexpected hub type=%T, want *v1beta1.VMServiceScrape", hub)
+	}
+	cr.ObjectMeta = src.ObjectMeta
+	cr.Status = src.Status
+	cr.Spec = VMServiceScrapeSpec{
+		DiscoveryRole:     src.Spec.DiscoveryRole,
+		JobLabel:          src.Spec.JobLabel,
+		TargetLabels:      src.Spec.TargetLabels,
+		PodTargetLabels:   src.Spec.PodTargetLabels,
+		Selector:          src.Spec.Selector,
+		NamespaceSelector: src.Spec.NamespaceSelector,
+		SampleLimit:       src.Spec.SampleLimit,
+		SeriesLimit:       src.Spec.SeriesLimit,
+		AttachMetadata:    src.Spec.AttachMetadata,
+	}
+	for _, ep := range src.Spec.Endpoints {
+		cr.Spec.Endpoints = append(cr.Spec.Endpoints, Endpoint{
+			Port:                 ep.Port,
+			TargetPort:           ep.TargetPort,
+			EndpointRelabelings:  endpointRelabelingsFromHub(ep.EndpointRelabelings),
+			EndpointAuth:         ep.EndpointAuth,
+			EndpointScrapeParams: ep.EndpointScrapeParams,
+			AttachMetadata:       ep.AttachMetadata,
+		})
+	}
+	return nil
+}
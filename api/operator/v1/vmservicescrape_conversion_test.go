@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestVMServiceScrapeConversion(t *testing.T) {
+	f := func(src *vmv1beta1.VMServiceScrape, want *VMServiceScrape) {
+		t.Helper()
+		var got VMServiceScrape
+		assert.NoError(t, got.ConvertFrom(src))
+		assert.Equal(t, want, &got)
+
+		// convert back and check that object is stable
+		var hub vmv1beta1.VMServiceScrape
+		assert.NoError(t, got.ConvertTo(&hub))
+		var gotAgain VMServiceScrape
+		assert.NoError(t, gotAgain.ConvertFrom(&hub))
+		assert.Equal(t, want, &gotAgain)
+	}
+	meta := metav1.ObjectMeta{Name: "svc", Namespace: "default"}
+
+	// snake case relabeling fields
+	f(&vmv1beta1.VMServiceScrape{
+		ObjectMeta: meta,
+		Spec: vmv1beta1.VMServiceScrapeSpec{
+			DiscoveryRole: "endpointslices",
+			Selector:      metav1.LabelSelector{MatchLabels: map[string]string{"app": "svc"}},
+			Endpoints: []vmv1beta1.Endpoint{
+				{
+					Port: "http",
+					EndpointRelabelings: vmv1beta1.EndpointRelabelings{
+						MetricRelabelConfigs: []*vmv1beta1.RelabelConfig{
+							{UnderScoreSourceLabels: []string{"__name__"}, Action: "drop", Regex: vmv1beta1.StringOrArray{"go_.*"}},
+						},
+						RelabelConfigs: []*vmv1beta1.RelabelConfig{
+							{UnderScoreSourceLabels: []string{"__meta_kubernetes_pod_name"}, UnderScoreTargetLabel: "pod"},
+						},
+					},
+				},
+				{TargetPort: ptr.To(intstr.FromInt32(8080))},
+			},
+		},
+	}, &VMServiceScrape{
+		ObjectMeta: meta,
+		Spec: VMServiceScrapeSpec{
+			DiscoveryRole: "endpointslices",
+			Selector:      metav1.LabelSelector{MatchLabels: map[string]string{"app": "svc"}},
+			Endpoints: []Endpoint{
+				{
+					Port: "http",
+					EndpointRelabelings: EndpointRelabelings{
+						MetricRelabelConfigs: []*RelabelConfig{
+							{SourceLabels: []string{"__name__"}, Action: "drop", Regex: vmv1beta1.StringOrArray{"go_.*"}},
+						},
+						RelabelConfigs: []*RelabelConfig{
+							{SourceLabels: []string{"__meta_kubernetes_pod_name"}, TargetLabel: "pod"},
+						},
+					},
+				},
+				{TargetPort: ptr.To(intstr.FromInt32(8080))},
+			},
+		},
+	})
+}
@@ -0,0 +1,96 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// VMServiceScrapeSpec defines the desired state of VMServiceScrape
+type VMServiceScrapeSpec struct {
+	// DiscoveryRole - defines kubernetes_sd role for objects discovery.
+	// by default, its endpoints.
+	// can be changed to service or endpointslices.
+	// note, that with service setting, you have to use port: "name"
+	// and cannot use targetPort for endpoints.
+	// +optional
+	// +kubebuilder:validation:Enum=endpoints;service;endpointslices
+	DiscoveryRole string `json:"discoveryRole,omitempty"`
+	// The label to use to retrieve the job name from.
+	// +optional
+	JobLabel string `json:"jobLabel,omitempty"`
+	// TargetLabels transfers labels on the Kubernetes Service onto the target.
+	// +optional
+	TargetLabels []string `json:"targetLabels,omitempty"`
+	// PodTargetLabels transfers labels on the Kubernetes Pod onto the target.
+	// +optional
+	PodTargetLabels []string `json:"podTargetLabels,omitempty"`
+	// A list of endpoints allowed as part of this ServiceScrape.
+	Endpoints []Endpoint `json:"endpoints"`
+	// Selector to select Endpoints objects by corresponding Service labels.
+	// +optional
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+	// Selector to select which namespaces the Endpoints objects are discovered from.
+	// +optional
+	NamespaceSelector vmv1beta1.NamespaceSelector `json:"namespaceSelector,omitempty"`
+	// SampleLimit defines per-scrape limit on number of scraped samples that will be accepted.
+	// +optional
+	SampleLimit uint64 `json:"sampleLimit,omitempty"`
+	// SeriesLimit defines per-scrape limit on number of unique time series
+	// a single target can expose during all the scrapes on the time window of 24h.
+	// +optional
+	SeriesLimit uint64 `json:"seriesLimit,omitempty"`
+	// AttachMetadata configures metadata attaching from service discovery
+	// +optional
+	AttachMetadata vmv1beta1.AttachMetadata `json:"attach_metadata,omitempty"`
+}
+
+// Endpoint defines a scrapeable endpoint serving metrics.
+type Endpoint struct {
+	// Name of the port exposed at Service.
+	// +optional
+	Port string `json:"port,omitempty"`
+	// TargetPort
+	// Name or number of the pod port this endpoint refers to. Mutually exclusive with port.
+	// +optional
+	TargetPort *intstr.IntOrString `json:"targetPort,omitempty"`
+
+	EndpointRelabelings            `json:",inline"`
+	vmv1beta1.EndpointAuth         `json:",inline"`
+	vmv1beta1.EndpointScrapeParams `json:",inline"`
+
+	// AttachMetadata configures metadata attaching from service discovery
+	// +optional
+	AttachMetadata vmv1beta1.AttachMetadata `json:"attach_metadata,omitempty"`
+}
+
+// VMServiceScrape is scrape configuration for endpoints associated with
+// kubernetes service,
+// it generates scrape configuration for vmagent based on selectors.
+// result config will scrape service endpoints
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmservicescrapes,scope=Namespaced
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
+// +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+type VMServiceScrape struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMServiceScrapeSpec          `json:"spec"`
+	Status vmv1beta1.ScrapeObjectStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// VMServiceScrapeList contains a list of VMServiceScrape
+type VMServiceScrapeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMServiceScrape `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VMServiceScrape{}, &VMServiceScrapeList{})
+}
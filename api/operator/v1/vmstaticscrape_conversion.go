@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+var _ conversion.Convertible = &VMStaticScrape{}
+
+// ConvertTo converts VMStaticScrape to the hub v1beta1 version
+func (cr *VMStaticScrape) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*vmv1beta1.VMStaticScrape)
+	if !ok {
+		return fmt.Errorf("unexpected hub type=%T, want *v1beta1.VMStaticScrape", hub)
+	}
+	dst.ObjectMeta = cr.ObjectMeta
+	dst.Status = cr.Status
+	dst.Spec = vmv1beta1.VMStaticScrapeSpec{
+		JobName:     cr.Spec.JobName,
+		SampleLimit: cr.Spec.SampleLimit,
+		SeriesLimit: cr.Spec.SeriesLimit,
+		Preset:      cr.Spec.Preset,
+	}
+	for _, te := range cr.Spec.TargetEndpoints {
+		if te == nil {
+			dst.Spec.TargetEndpoints = append(dst.Spec.TargetEndpoints, nil)
+			continue
+		}
+		dst.Spec.TargetEndpoints = append(dst.Spec.TargetEndpoints, &vmv1beta1.TargetEndpoint{
+			Targets:              te.Targets,
+			Labels:               te.Labels,
+			EndpointRelabelings:  endpointRelabelingsToHub(te.EndpointRelabelings),
+			EndpointAuth:         te.EndpointAuth,
+			EndpointScrapeParams: te.EndpointScrapeParams,
+		})
+	}
+	return nil
+}
+
+// ConvertFrom converts VMStaticScrape from the hub v1beta1 version
+func (cr *VMStaticScrape) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*vmv1beta1.VMStaticScrape)
+	if !ok {
+		return fmt.Errorf("unexpected hub type=%T, want *v1beta1.VMStaticScrape", hub)
+	}
+	cr.ObjectMeta = src.ObjectMeta
+	cr.Status = src.Status
+	cr.Spec = VMStaticScrapeSpec{
+		JobName:     src.Spec.JobName,
+		SampleLimit: src.Spec.SampleLimit,
+		SeriesLimit: src.Spec.SeriesLimit,
+		Preset:      src.Spec.Preset,
+	}
+	for _, te := range src.Spec.TargetEndpoints {
+		if te == nil {
+			cr.Spec.TargetEndpoints = append(cr.Spec.TargetEndpoints, nil)
+			continue
+		}
+		cr.Spec.TargetEndpoints = append(cr.Spec.TargetEndpoints, &TargetEndpoint{
+			Targets:              te.Targets,
+			Labels:               te.Labels,
+			EndpointRelabelings:  endpointRelabelingsFromHub(te.EndpointRelabelings),
+			EndpointAuth:         te.EndpointAuth,
+			EndpointScrapeParams: te.EndpointScrapeParams,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestVMStaticScrapeConversion(t *testing.T) {
+	f := func(src *vmv1beta1.VMStaticScrape, want *VMStaticScrape) {
+		t.Helper()
+		var got VMStaticScrape
+		assert.NoError(t, got.ConvertFrom(src))
+		assert.Equal(t, want, &got)
+
+		// convert back and check that object is stable
+		var hub vmv1beta1.VMStaticScrape
+		assert.NoError(t, got.ConvertTo(&hub))
+		var gotAgain VMStaticScrape
+		assert.NoError(t, gotAgain.ConvertFrom(&hub))
+		assert.Equal(t, want, &gotAgain)
+	}
+	meta := metav1.ObjectMeta{Name: "static", Namespace: "default"}
+
+	// snake case relabeling fields
+	f(&vmv1beta1.VMStaticScrape{
+		ObjectMeta: meta,
+		Spec: vmv1beta1.VMStaticScrapeSpec{
+			JobName: "static",
+			TargetEndpoints: []*vmv1beta1.TargetEndpoint{
+				{
+					Targets: []string{"host-1:9100"},
+					EndpointRelabelings: vmv1beta1.EndpointRelabelings{
+						RelabelConfigs: []*vmv1beta1.RelabelConfig{
+							{UnderScoreSourceLabels: []string{"__address__"}, UnderScoreTargetLabel: "instance"},
+							{SourceLabels: []string{"job"}, UnderScoreSourceLabels: []string{"team"}, TargetLabel: "dst"},
+						},
+					},
+					EndpointScrapeParams: vmv1beta1.EndpointScrapeParams{Path: "/metrics"},
+				},
+			},
+		},
+	}, &VMStaticScrape{
+		ObjectMeta: meta,
+		Spec: VMStaticScrapeSpec{
+			JobName: "static",
+			TargetEndpoints: []*TargetEndpoint{
+				{
+					Targets: []string{"host-1:9100"},
+					EndpointRelabelings: EndpointRelabelings{
+						RelabelConfigs: []*RelabelConfig{
+							{SourceLabels: []string{"__address__"}, TargetLabel: "instance"},
+							{SourceLabels: []string{"job"}, TargetLabel: "dst"},
+						},
+					},
+					EndpointScrapeParams: vmv1beta1.EndpointScrapeParams{Path: "/metrics"},
+				},
+			},
+		},
+	})
+
+	// scrape preset
+	f(&vmv1beta1.VMStaticScrape{
+		ObjectMeta: meta,
+		Spec: vmv1beta1.VMStaticScrapeSpec{
+			Preset: vmv1beta1.StaticScrapePresetPushgateway,
+			TargetEndpoints: []*vmv1beta1.TargetEndpoint{
+				{Targets: []string{"pushgateway:9091"}},
+			},
+		},
+	}, &VMStaticScrape{
+		ObjectMeta: meta,
+		Spec: VMStaticScrapeSpec{
+			Preset: vmv1beta1.StaticScrapePresetPushgateway,
+			TargetEndpoints: []*TargetEndpoint{
+				{Targets: []string{"pushgateway:9091"}},
+			},
+		},
+	})
+}
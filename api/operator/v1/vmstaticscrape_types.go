@@ -0,0 +1,67 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// VMStaticScrapeSpec defines the desired state of VMStaticScrape.
+type VMStaticScrapeSpec struct {
+	// JobName name of job.
+	JobName string `json:"jobName,omitempty"`
+	// A list of target endpoints to scrape metrics from.
+	TargetEndpoints []*TargetEndpoint `json:"targetEndpoints"`
+	// SampleLimit defines per-scrape limit on number of scraped samples that will be accepted.
+	// +optional
+	SampleLimit uint64 `json:"sampleLimit,omitempty"`
+	// SeriesLimit defines per-scrape limit on number of unique time series
+	// a single target can expose during all the scrapes on the time window of 24h.
+	// +optional
+	SeriesLimit uint64 `json:"seriesLimit,omitempty"`
+	// Preset applies predefined scrape settings for well-known target types.
+	// pushgateway - sets honor_labels: true for all endpoints, it preserves job and instance labels
+	// of pushed series. JobName is applied only to series without job label.
+	// +optional
+	// +kubebuilder:validation:Enum=pushgateway
+	Preset string `json:"preset,omitempty"`
+}
+
+// TargetEndpoint defines single static target endpoint.
+type TargetEndpoint struct {
+	// Targets static targets addresses in form of ["192.122.55.55:9100","some-name:9100"].
+	// +kubebuilder:validation:MinItems=1
+	Targets []string `json:"targets"`
+	// Labels static labels for targets.
+	// +optional
+	Labels                         map[string]string `json:"labels,omitempty"`
+	EndpointRelabelings            `json:",inline"`
+	vmv1beta1.EndpointAuth         `json:",inline"`
+	vmv1beta1.EndpointScrapeParams `json:",inline"`
+}
+
+// VMStaticScrape  defines static targets configuration for scraping.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
+// +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+type VMStaticScrape struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMStaticScrapeSpec           `json:"spec,omitempty"`
+	Status vmv1beta1.ScrapeObjectStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// VMStaticScrapeList contains a list of VMStaticScrape
+type VMStaticScrapeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMStaticScrape `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VMStaticScrape{}, &VMStaticScrapeList{})
+}
@@ -82,6 +82,10 @@ type VLogsSpec struct {
 	// ServiceSpec that will be added to vlogs service spec
 	// +optional
 	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// AdditionalServices defines a list of extra services that will be created for vlogs
+	// in addition to the default one
+	// +optional
+	AdditionalServices []AdditionalServiceSpec `json:"additionalServices,omitempty"`
 	// ServiceScrapeSpec that will be added to vlogs VMServiceScrape spec
 	// +optional
 	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
@@ -204,7 +208,7 @@ func (r *VLogs) ProbePath() string {
 }
 
 func (r *VLogs) ProbeScheme() string {
-	return strings.ToUpper(protoFromFlags(r.Spec.ExtraArgs))
+	return strings.ToUpper(protoFromFlags(r.Spec.ExtraArgsWithServerTLS()))
 }
 
 func (r *VLogs) ProbePort() string {
@@ -217,7 +221,7 @@ func (r *VLogs) ProbeNeedLiveness() bool {
 
 func (r *VLogs) AnnotationsFiltered() map[string]string {
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
-	dst := filterMapKeysByPrefixes(r.ObjectMeta.Annotations, annotationFilterPrefixes)
+	dst := filterMapKeysByPrefixes(r.ObjectMeta.Annotations, annotationFilterPrefixes, r.Spec.ManagedMetadata)
 	if r.Spec.ManagedMetadata != nil {
 		if dst == nil {
 			dst = make(map[string]string)
@@ -255,7 +259,7 @@ func (r *VLogs) AllLabels() map[string]string {
 	var result map[string]string
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
 	if r.ObjectMeta.Labels != nil {
-		result = filterMapKeysByPrefixes(r.ObjectMeta.Labels, labelFilterPrefixes)
+		result = filterMapKeysByPrefixes(r.ObjectMeta.Labels, labelFilterPrefixes, r.Spec.ManagedMetadata)
 	}
 	if r.Spec.ManagedMetadata != nil {
 		result = labels.Merge(result, r.Spec.ManagedMetadata.Labels)
@@ -274,7 +278,7 @@ func (r VLogs) GetMetricPath() string {
 
 // GetExtraArgs returns additionally configured command-line arguments
 func (r VLogs) GetExtraArgs() map[string]string {
-	return r.Spec.ExtraArgs
+	return r.Spec.ExtraArgsWithServerTLS()
 }
 
 // GetServiceScrape returns overrides for serviceScrape builder
@@ -310,7 +314,7 @@ func (r *VLogs) AsURL() string {
 			}
 		}
 	}
-	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(r.Spec.ExtraArgs), r.PrefixedName(), r.Namespace, port)
+	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(r.Spec.ExtraArgsWithServerTLS()), r.PrefixedName(), r.Namespace, port)
 }
 
 // LastAppliedSpecAsPatch return last applied vlogs spec as patch annotation
@@ -36,6 +36,7 @@ func (r *VLogs) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 // +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vlogs,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vlogs,verbs=create;update,versions=v1beta1,name=vvlogs.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-operator-victoriametrics-com-v1beta1-vlogs,mutating=true,failurePolicy=ignore,sideEffects=None,groups=operator.victoriametrics.com,resources=vlogs,verbs=create;update,versions=v1beta1,name=mvlogs.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &VLogs{}
 
@@ -43,9 +44,23 @@ func (r *VLogs) sanityCheck() error {
 	if r.Spec.ServiceSpec != nil && r.Spec.ServiceSpec.Name == r.PrefixedName() {
 		return fmt.Errorf("spec.serviceSpec.Name cannot be equal to prefixed name=%q", r.PrefixedName())
 	}
+	if err := checkAdditionalServices(r.Spec.AdditionalServices, r.PrefixedName(), r.Spec.ServiceSpec); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
+	if err := r.Spec.ServerTLS.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.serverTLS: %w", err)
+	}
+	if err := checkExtraArgs(r.Spec.ExtraArgs); err != nil {
+		return err
+	}
 	return nil
 }
 
+// extraArgsWarnings returns warnings for unknown and operator managed flags at spec.extraArgs
+func (r *VLogs) extraArgsWarnings() admission.Warnings {
+	return checkExtraArgsFlags("spec.extraArgs", r.Spec.ExtraArgs, vlogsFlags)
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *VLogs) ValidateCreate() (admission.Warnings, error) {
 	if r.Spec.ParsingError != "" {
@@ -57,7 +72,7 @@ func (r *VLogs) ValidateCreate() (admission.Warnings, error) {
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	return r.extraArgsWarnings(), nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -71,7 +86,7 @@ func (r *VLogs) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	return r.extraArgsWarnings(), nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -0,0 +1,334 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VLSingleSpec defines the desired state of VLSingle
+// +k8s:openapi-gen=true
+type VLSingleSpec struct {
+	// ParsingError contents error with context if operator was failed to parse json object from kubernetes api server
+	ParsingError string `json:"-" yaml:"-"`
+
+	// PodMetadata configures Labels and Annotations which are propagated to the VLSingle pods.
+	// +optional
+	PodMetadata *EmbeddedObjectMetadata `json:"podMetadata,omitempty"`
+	// ManagedMetadata defines metadata that will be added to the all objects
+	// created by operator for the given CustomResource
+	ManagedMetadata *ManagedObjectsMetadata `json:"managedMetadata,omitempty"`
+
+	CommonDefaultableParams           `json:",inline,omitempty"`
+	CommonApplicationDeploymentParams `json:",inline,omitempty"`
+
+	// LogLevel for VictoriaLogs to be configured with.
+	// +optional
+	// +kubebuilder:validation:Enum=INFO;WARN;ERROR;FATAL;PANIC
+	LogLevel string `json:"logLevel,omitempty"`
+	// LogFormat for VLSingle to be configured with.
+	// +optional
+	// +kubebuilder:validation:Enum=default;json
+	LogFormat string `json:"logFormat,omitempty"`
+	// StorageDataPath disables spec.storage option and overrides arg for victoria-logs binary --storageDataPath,
+	// its users responsibility to mount proper device into given path.
+	// +optional
+	StorageDataPath string `json:"storageDataPath,omitempty"`
+	// Storage is the definition of how storage will be used by the VLSingle
+	// by default it`s empty dir
+	// +optional
+	Storage *v1.PersistentVolumeClaimSpec `json:"storage,omitempty"`
+	// StorageMeta defines annotations and labels attached to PVC for given vlsingle CR
+	// +optional
+	StorageMetadata EmbeddedObjectMetadata `json:"storageMetadata,omitempty"`
+	// RemovePvcAfterDelete - if true, controller adds ownership to pvc
+	// and after VLSingle object deletion - pvc will be garbage collected
+	// by controller manager
+	// +optional
+	RemovePvcAfterDelete bool `json:"removePvcAfterDelete,omitempty"`
+	// RetentionPeriod for the stored logs
+	RetentionPeriod string `json:"retentionPeriod"`
+	// FutureRetention for the stored logs
+	// Log entries with timestamps bigger than now+futureRetention are rejected during data ingestion; see https://docs.victoriametrics.com/victorialogs/#retention
+	FutureRetention string `json:"futureRetention,omitempty"`
+	// LogNewStreams Whether to log creation of new streams; this can be useful for debugging of high cardinality issues with log streams; see https://docs.victoriametrics.com/victorialogs/keyconcepts/#stream-fields
+	LogNewStreams bool `json:"logNewStreams,omitempty"`
+	// Whether to log all the ingested log entries; this can be useful for debugging of data ingestion; see https://docs.victoriametrics.com/victorialogs/data-ingestion/
+	LogIngestedRows bool `json:"logIngestedRows,omitempty"`
+	// ServiceSpec that will be added to vlsingle service spec
+	// +optional
+	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// AdditionalServices defines a list of extra services that will be created for vlsingle
+	// in addition to the default one
+	// +optional
+	AdditionalServices []AdditionalServiceSpec `json:"additionalServices,omitempty"`
+	// ServiceScrapeSpec that will be added to vlsingle VMServiceScrape spec
+	// +optional
+	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
+	// LivenessProbe that will be added to VLSingle pod
+	*EmbeddedProbes `json:",inline"`
+
+	// ServiceAccountName is the name of the ServiceAccount to use to run the pods
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// VLSingleStatus defines the observed state of VLSingle
+type VLSingleStatus struct {
+	StatusMetadata `json:",inline"`
+}
+
+// GetStatusMetadata returns metadata for object status
+func (cr *VLSingleStatus) GetStatusMetadata() *StatusMetadata {
+	return &cr.StatusMetadata
+}
+
+// VLSingle is fast, cost-effective and scalable logs database.
+// It manages single-node version of VictoriaLogs and supersedes VLogs
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="VLSingle"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Deployment,apps"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Service,v1"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Secret,v1"
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vlsingles,scope=Namespaced
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus",description="Current status of logs instance update process"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// VLSingle is the Schema for the vlsingles API
+type VLSingle struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VLSingleSpec `json:"spec,omitempty"`
+	// ParsedLastAppliedSpec contains last-applied configuration spec
+	ParsedLastAppliedSpec *VLSingleSpec `json:"-" yaml:"-"`
+
+	Status VLSingleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VLSingleList contains a list of VLSingle
+type VLSingleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VLSingle `json:"items"`
+}
+
+func (r *VLSingle) PodAnnotations() map[string]string {
+	annotations := map[string]string{}
+	if r.Spec.PodMetadata != nil {
+		for annotation, value := range r.Spec.PodMetadata.Annotations {
+			annotations[annotation] = value
+		}
+	}
+	return annotations
+}
+
+// AsOwner returns owner references with current object as owner
+func (r *VLSingle) AsOwner() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         r.APIVersion,
+			Kind:               r.Kind,
+			Name:               r.Name,
+			UID:                r.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+func (cr *VLSingle) setLastSpec(prevSpec VLSingleSpec) {
+	cr.ParsedLastAppliedSpec = &prevSpec
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VLSingle) UnmarshalJSON(src []byte) error {
+	type pcr VLSingle
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		return err
+	}
+	if err := parseLastAppliedState(cr); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VLSingleSpec) UnmarshalJSON(src []byte) error {
+	type pcr VLSingleSpec
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		cr.ParsingError = fmt.Sprintf("cannot parse vlsingle spec: %s, err: %s", string(src), err)
+		return nil
+	}
+	return nil
+}
+
+func (r *VLSingle) Probe() *EmbeddedProbes {
+	return r.Spec.EmbeddedProbes
+}
+
+func (r *VLSingle) ProbePath() string {
+	return buildPathWithPrefixFlag(r.Spec.ExtraArgs, healthPath)
+}
+
+func (r *VLSingle) ProbeScheme() string {
+	return strings.ToUpper(protoFromFlags(r.Spec.ExtraArgsWithServerTLS()))
+}
+
+func (r *VLSingle) ProbePort() string {
+	return r.Spec.Port
+}
+
+func (r *VLSingle) ProbeNeedLiveness() bool {
+	return false
+}
+
+func (r *VLSingle) AnnotationsFiltered() map[string]string {
+	if r.Spec.ManagedMetadata == nil {
+		return nil
+	}
+	dst := make(map[string]string, len(r.Spec.ManagedMetadata.Annotations))
+	for k, v := range r.Spec.ManagedMetadata.Annotations {
+		dst[k] = v
+	}
+	return dst
+}
+
+func (r *VLSingle) SelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vlsingle",
+		"app.kubernetes.io/instance":  r.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+func (r *VLSingle) PodLabels() map[string]string {
+	lbls := r.SelectorLabels()
+	if r.Spec.PodMetadata == nil {
+		return lbls
+	}
+	return labels.Merge(r.Spec.PodMetadata.Labels, lbls)
+}
+
+func (r *VLSingle) AllLabels() map[string]string {
+	selectorLabels := r.SelectorLabels()
+	// fast path
+	if r.Spec.ManagedMetadata == nil {
+		return selectorLabels
+	}
+	return labels.Merge(r.Spec.ManagedMetadata.Labels, selectorLabels)
+}
+
+func (r VLSingle) PrefixedName() string {
+	return fmt.Sprintf("vlsingle-%s", r.Name)
+}
+
+// GetMetricPath returns prefixed path for metric requests
+func (r VLSingle) GetMetricPath() string {
+	return buildPathWithPrefixFlag(r.Spec.ExtraArgs, metricPath)
+}
+
+// GetExtraArgs returns additionally configured command-line arguments
+func (r VLSingle) GetExtraArgs() map[string]string {
+	return r.Spec.ExtraArgsWithServerTLS()
+}
+
+// GetServiceScrape returns overrides for serviceScrape builder
+func (r VLSingle) GetServiceScrape() *VMServiceScrapeSpec {
+	return r.Spec.ServiceScrapeSpec
+}
+
+func (r VLSingle) GetServiceAccountName() string {
+	if r.Spec.ServiceAccountName == "" {
+		return r.PrefixedName()
+	}
+	return r.Spec.ServiceAccountName
+}
+
+func (r VLSingle) IsOwnsServiceAccount() bool {
+	return r.Spec.ServiceAccountName == ""
+}
+
+func (r VLSingle) GetNSName() string {
+	return r.GetNamespace()
+}
+
+func (r *VLSingle) AsURL() string {
+	port := r.Spec.Port
+	if port == "" {
+		port = "9428"
+	}
+	if r.Spec.ServiceSpec != nil && r.Spec.ServiceSpec.UseAsDefault {
+		for _, svcPort := range r.Spec.ServiceSpec.Spec.Ports {
+			if svcPort.Name == "http" {
+				port = fmt.Sprintf("%d", svcPort.Port)
+				break
+			}
+		}
+	}
+	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(r.Spec.ExtraArgsWithServerTLS()), r.PrefixedName(), r.Namespace, port)
+}
+
+// LastAppliedSpecAsPatch return last applied vlsingle spec as patch annotation
+func (r *VLSingle) LastAppliedSpecAsPatch() (client.Patch, error) {
+	return lastAppliedChangesAsPatch(r.ObjectMeta, r.Spec)
+}
+
+// HasSpecChanges compares vlsingle spec with last applied vlsingle spec stored in annotation
+func (r *VLSingle) HasSpecChanges() (bool, error) {
+	return hasStateChanges(r.ObjectMeta, r.Spec)
+}
+
+func (r *VLSingle) Paused() bool {
+	return r.Spec.Paused
+}
+
+// SetStatusTo changes update status with optional reason of fail
+func (r *VLSingle) SetUpdateStatusTo(ctx context.Context, c client.Client, status UpdateStatus, maybeErr error) error {
+	return updateObjectStatus(ctx, c, &patchStatusOpts[*VLSingle, *VLSingleStatus]{
+		actualStatus: status,
+		cr:           r,
+		crStatus:     &r.Status,
+		maybeErr:     maybeErr,
+	})
+}
+
+// GetAdditionalService returns AdditionalServiceSpec settings
+func (r *VLSingle) GetAdditionalService() *AdditionalServiceSpec {
+	return r.Spec.ServiceSpec
+}
+
+func init() {
+	SchemeBuilder.Register(&VLSingle{}, &VLSingleList{})
+}
@@ -0,0 +1,95 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var vlsinglelog = logf.Log.WithName("vlsingle-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *VLSingle) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vlsingle,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vlsingles,verbs=create;update,versions=v1beta1,name=vvlsingle.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-operator-victoriametrics-com-v1beta1-vlsingle,mutating=true,failurePolicy=ignore,sideEffects=None,groups=operator.victoriametrics.com,resources=vlsingles,verbs=create;update,versions=v1beta1,name=mvlsingle.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &VLSingle{}
+
+func (r *VLSingle) sanityCheck() error {
+	if r.Spec.ServiceSpec != nil && r.Spec.ServiceSpec.Name == r.PrefixedName() {
+		return fmt.Errorf("spec.serviceSpec.Name cannot be equal to prefixed name=%q", r.PrefixedName())
+	}
+	if err := checkAdditionalServices(r.Spec.AdditionalServices, r.PrefixedName(), r.Spec.ServiceSpec); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
+	if err := r.Spec.ServerTLS.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.serverTLS: %w", err)
+	}
+	if err := checkExtraArgs(r.Spec.ExtraArgs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// extraArgsWarnings returns warnings for unknown and operator managed flags at spec.extraArgs
+func (r *VLSingle) extraArgsWarnings() admission.Warnings {
+	return checkExtraArgsFlags("spec.extraArgs", r.Spec.ExtraArgs, vlogsFlags)
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *VLSingle) ValidateCreate() (admission.Warnings, error) {
+	if r.Spec.ParsingError != "" {
+		return nil, fmt.Errorf(r.Spec.ParsingError)
+	}
+	if mustSkipValidation(r) {
+		return nil, nil
+	}
+	if err := r.sanityCheck(); err != nil {
+		return nil, err
+	}
+	return r.extraArgsWarnings(), nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *VLSingle) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	if r.Spec.ParsingError != "" {
+		return nil, fmt.Errorf(r.Spec.ParsingError)
+	}
+	if mustSkipValidation(r) {
+		return nil, nil
+	}
+	if err := r.sanityCheck(); err != nil {
+		return nil, err
+	}
+	return r.extraArgsWarnings(), nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *VLSingle) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -69,6 +71,16 @@ type VMAgentSpec struct {
 	// +optional
 	// +kubebuilder:validation:Pattern:="[0-9]+(ms|s|m|h)"
 	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+	// MaxScrapeSize defines the maximum size of scrape response in bytes to process from targets.
+	// It's applied to all scrape jobs and can be overridden per job with max_scrape_size param.
+	// Rendered into -promscrape.maxScrapeSize flag, supports KB, MB, GB, KiB, MiB, GiB suffixes
+	// +optional
+	MaxScrapeSize *string `json:"maxScrapeSize,omitempty"`
+	// ScrapeConfigFiles defines paths to files with additional scrape configs, glob patterns are supported.
+	// Rendered into scrape_config_files section of configuration,
+	// files must be mounted into vmagent container with Volumes and VolumeMounts.
+	// +optional
+	ScrapeConfigFiles []string `json:"scrapeConfigFiles,omitempty"`
 
 	// APIServerConfig allows specifying a host and auth methods to access apiserver.
 	// If left empty, VMAgent is assumed to run inside of the cluster
@@ -86,6 +98,13 @@ type VMAgentSpec struct {
 	// it doesn't affect metrics ingested directly by push API's
 	// +optional
 	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
+	// ExternalLabelsPolicy defines how ExternalLabels are merged with labels added by operator,
+	// such as vmAgentExternalLabelName label.
+	// merge - operator labels are added, ExternalLabels take precedence on conflict.
+	// replace - only ExternalLabels are used.
+	// +optional
+	// +kubebuilder:validation:Enum=merge;replace
+	ExternalLabelsPolicy string `json:"externalLabelsPolicy,omitempty"`
 	// RemoteWrite list of victoria metrics /some other remote write system
 	// for vm it must looks like: http://victoria-metrics-single:8429/api/v1/write
 	// or for cluster different url
@@ -211,12 +230,25 @@ type VMAgentSpec struct {
 	// VMAgent after the upgrade.
 	// +optional
 	AdditionalScrapeConfigs *v1.SecretKeySelector `json:"additionalScrapeConfigs,omitempty"`
+	// FederationTargets defines Prometheus servers to federate metrics from.
+	// Each target is rendered into a scrape job against /federate endpoint with honor_labels: true.
+	// It simplifies staged migrations from Prometheus to VictoriaMetrics.
+	// +optional
+	FederationTargets []VMAgentFederationTarget `json:"federationTargets,omitempty"`
 	// InsertPorts - additional listen ports for data ingestion.
 	InsertPorts *InsertPorts `json:"insertPorts,omitempty"`
 
+	// OTLP configures OpenTelemetry metrics ingestion and dedicated OTLP service
+	// +optional
+	OTLP *OTLPIngestion `json:"otlp,omitempty"`
+
 	// ServiceSpec that will be added to vmagent service spec
 	// +optional
 	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// AdditionalServices defines a list of extra services that will be created for vmagent
+	// in addition to the default one
+	// +optional
+	AdditionalServices []AdditionalServiceSpec `json:"additionalServices,omitempty"`
 	// ServiceScrapeSpec that will be added to vmagent VMServiceScrape spec
 	// +optional
 	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
@@ -227,6 +259,10 @@ type VMAgentSpec struct {
 	// see [here](https://docs.victoriametrics.com/vmagent/#scraping-big-number-of-targets)
 	// +optional
 	ShardCount *int `json:"shardCount,omitempty"`
+	// Sharding configures distribution of scrape targets among shards
+	// it's used only if shardCount is greater than 1
+	// +optional
+	Sharding *VMAgentShardingSpec `json:"sharding,omitempty"`
 
 	// UpdateStrategy - overrides default update strategy.
 	// works only for deployments, statefulset always use OnDelete.
@@ -236,6 +272,15 @@ type VMAgentSpec struct {
 	// RollingUpdate - overrides deployment update params.
 	// +optional
 	RollingUpdate *appsv1.RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+	// NetworkPolicy created by operator
+	// +optional
+	NetworkPolicy *EmbeddedNetworkPolicy `json:"networkPolicy,omitempty"`
+	// VPA defines VerticalPodAutoscaler configuration for the application container
+	// +optional
+	VPA *EmbeddedVPA `json:"vpa,omitempty"`
+	// KEDA defines KEDA ScaledObject configuration for VMAgent replicas or shards autoscaling
+	// +optional
+	KEDA *VMAgentKEDA `json:"keda,omitempty"`
 	// PodDisruptionBudget created by operator
 	// +optional
 	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
@@ -284,6 +329,16 @@ type VMAgentSpec struct {
 
 	// ClaimTemplates allows adding additional VolumeClaimTemplates for VMAgent in StatefulMode
 	ClaimTemplates []v1.PersistentVolumeClaim `json:"claimTemplates,omitempty"`
+	// PersistentVolumeClaimRetentionPolicy controls whether PersistentVolumeClaims created from StatefulSet
+	// VolumeClaimTemplates are deleted on StatefulSet deletion or scale down.
+	// See [PersistentVolumeClaim retention](https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#persistentvolumeclaim-retention)
+	// +optional
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+	// RetainPVCs defines whether PersistentVolumeClaims created for VMAgent StatefulSet are kept after VMAgent deletion.
+	// If not set, PersistentVolumeClaims are removed only if persistentVolumeClaimRetentionPolicy.whenDeleted is Delete.
+	// It takes precedence over persistentVolumeClaimRetentionPolicy.whenDeleted
+	// +optional
+	RetainPVCs *bool `json:"retainPVCs,omitempty"`
 	// IngestOnlyMode switches vmagent into unmanaged mode
 	// it disables any config generation for scraping
 	// Currently it prevents vmagent from managing tls and auth options for remote write
@@ -299,6 +354,20 @@ type VMAgentSpec struct {
 	// ServiceAccountName is the name of the ServiceAccount to use to run the pods
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// CloudIdentity configures cloud provider workload identity
+	// (AWS IRSA or GCP Workload Identity) for the generated ServiceAccount
+	// +optional
+	CloudIdentity *CloudIdentity `json:"cloudIdentity,omitempty"`
+	// GrafanaDashboard configures provisioning of the official Grafana dashboard
+	// with grafana-operator GrafanaDashboard object or ConfigMap for grafana sidecar
+	// +optional
+	GrafanaDashboard *GrafanaDashboard `json:"grafanaDashboard,omitempty"`
+	// ServiceAccountTokens defines projected service account tokens with custom audience,
+	// which are mounted into vmagent container at /var/run/secrets/vmagent/tokens/<name>/token.
+	// It could be used for scraping targets, which validate token audience,
+	// by setting bearerTokenFile at the projected token path.
+	// +optional
+	ServiceAccountTokens []VMAgentServiceAccountToken `json:"serviceAccountTokens,omitempty"`
 
 	VMAgentSecurityEnforcements       `json:",inline"`
 	CommonDefaultableParams           `json:",inline,omitempty"`
@@ -332,6 +401,133 @@ func (cr *VMAgentSpec) UnmarshalJSON(src []byte) error {
 	return nil
 }
 
+// VMAgentShardingMode defines algorithm of scrape targets distribution among VMAgent shards
+type VMAgentShardingMode string
+
+const (
+	// VMAgentShardingModeDefault distributes targets with -promscrape.cluster.* flags of vmagent
+	VMAgentShardingModeDefault VMAgentShardingMode = "Default"
+	// VMAgentShardingModeConsistentHashing distributes targets with hashmod relabeling and consistent hashing of slots
+	VMAgentShardingModeConsistentHashing VMAgentShardingMode = "ConsistentHashing"
+)
+
+// VMAgentShardingSpec defines distribution of scrape targets among VMAgent shards
+type VMAgentShardingSpec struct {
+	// Mode defines algorithm of scrape targets distribution:
+	// Default - uses -promscrape.cluster.* flags of vmagent, most of targets are moved between shards on shardCount change,
+	// ConsistentHashing - only targets of added or removed shards are moved on shardCount change.
+	// +kubebuilder:validation:Enum=Default;ConsistentHashing
+	// +optional
+	Mode VMAgentShardingMode `json:"mode,omitempty"`
+	// ReplicationFactor defines the number of shards, which scrape each target
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ReplicationFactor *int32 `json:"replicationFactor,omitempty"`
+}
+
+// IsConsistentHashing checks if targets are distributed with consistent hashing
+func (s *VMAgentShardingSpec) IsConsistentHashing() bool {
+	return s != nil && s.Mode == VMAgentShardingModeConsistentHashing
+}
+
+// GetReplicationFactor returns the number of shards, which scrape each target
+func (s *VMAgentShardingSpec) GetReplicationFactor() int {
+	if s == nil || s.ReplicationFactor == nil || *s.ReplicationFactor < 1 {
+		return 1
+	}
+	return int(*s.ReplicationFactor)
+}
+
+// VMAgentKEDA defines KEDA ScaledObject configuration for VMAgent.
+// It scales VMAgent by pending bytes of remote write persistent queue.
+// KEDA must be installed at kubernetes cluster.
+// https://keda.sh/docs/latest/concepts/scaling-deployments/
+type VMAgentKEDA struct {
+	// Target defines scaled object:
+	// replicas - scales replicas of vmagent Deployment or StatefulSet,
+	// shards - scales spec.shardCount of VMAgent.
+	// +kubebuilder:validation:Enum=replicas;shards
+	// +optional
+	Target string `json:"target,omitempty"`
+	// MinReplicaCount defines minimal number of replicas or shards
+	// +optional
+	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
+	// MaxReplicaCount defines maximal number of replicas or shards
+	MaxReplicaCount int32 `json:"maxReplicaCount"`
+	// PollingInterval defines interval in seconds for trigger checks
+	// +optional
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+	// CooldownPeriod defines period in seconds to wait after the last active trigger before scaling down
+	// +optional
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+	// ServerAddress defines Prometheus compatible querying API url of monitoring stack,
+	// e.g. http://vmselect-example.default.svc:8481/select/0/prometheus
+	ServerAddress string `json:"serverAddress"`
+	// Query returns the scaling metric value
+	// By default, it returns sum of vm_persistentqueue_bytes_pending for the given VMAgent
+	// +optional
+	Query string `json:"query,omitempty"`
+	// Threshold defines target metric value per replica or shard
+	// By default, it's 104857600 - 100MiB of pending data
+	// +optional
+	Threshold string `json:"threshold,omitempty"`
+	// AuthenticationRef defines name of KEDA TriggerAuthentication object
+	// for querying API access
+	// +optional
+	AuthenticationRef string `json:"authenticationRef,omitempty"`
+}
+
+// ScalesShards checks if KEDA manages spec.shardCount of VMAgent
+func (k *VMAgentKEDA) ScalesShards() bool {
+	return k != nil && k.Target == "shards"
+}
+
+// ScalesReplicas checks if KEDA manages replicas of VMAgent workload
+func (k *VMAgentKEDA) ScalesReplicas() bool {
+	return k != nil && k.Target != "shards"
+}
+
+// VMAgentServiceAccountToken defines projected service account token
+type VMAgentServiceAccountToken struct {
+	// Name of the token, it's used as a sub-directory name for the token mount path
+	Name string `json:"name"`
+	// Audience is the intended audience of the token.
+	// Recipient of the token must identify itself with it.
+	// Defaults to the identifier of the apiserver.
+	// +optional
+	Audience string `json:"audience,omitempty"`
+	// ExpirationSeconds is the requested duration of validity of the token.
+	// Kubelet rotates token before it expires.
+	// Defaults to 1 hour and must be at least 10 minutes.
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+// TokenPath returns path to the projected token file inside vmagent container
+func (t *VMAgentServiceAccountToken) TokenPath() string {
+	return path.Join(ServiceAccountTokensDir, t.Name, serviceAccountTokenFile)
+}
+
+func checkServiceAccountTokens(tokens []VMAgentServiceAccountToken) error {
+	uniqNames := make(map[string]struct{}, len(tokens))
+	for idx, t := range tokens {
+		if t.Name == "" {
+			return fmt.Errorf("serviceAccountTokens[%d].name cannot be empty", idx)
+		}
+		if errs := validation.IsDNS1123Label(t.Name); len(errs) > 0 {
+			return fmt.Errorf("serviceAccountTokens[%d].name=%q is not valid: %s", idx, t.Name, strings.Join(errs, ","))
+		}
+		if _, ok := uniqNames[t.Name]; ok {
+			return fmt.Errorf("serviceAccountTokens[%d].name=%q is duplicated", idx, t.Name)
+		}
+		uniqNames[t.Name] = struct{}{}
+		if t.ExpirationSeconds != nil && *t.ExpirationSeconds < 600 {
+			return fmt.Errorf("serviceAccountTokens[%d].expirationSeconds=%d must be at least 600", idx, *t.ExpirationSeconds)
+		}
+	}
+	return nil
+}
+
 // VMAgentRemoteWriteSettings - defines global settings for all remoteWrite urls.
 type VMAgentRemoteWriteSettings struct {
 	// The maximum size in bytes of unpacked request to send to remote storage
@@ -339,9 +535,11 @@ type VMAgentRemoteWriteSettings struct {
 	MaxBlockSize *int32 `json:"maxBlockSize,omitempty"`
 
 	// The maximum file-based buffer size in bytes at -remoteWrite.tmpDataPath
+	// It's applied to each -remoteWrite.url, use remoteWrite.maxDiskUsage for per target override
 	// +optional
 	MaxDiskUsagePerURL *int64 `json:"maxDiskUsagePerURL,omitempty"`
-	// The number of concurrent queues
+	// The number of concurrent queues to each -remoteWrite.url
+	// Operator scales it down with shardCount for sharded VMAgent, if it's not set
 	// +optional
 	Queues *int32 `json:"queues,omitempty"`
 	// Whether to show -remoteWrite.url in the exported metrics. It is hidden by default, since it can contain sensitive auth info
@@ -393,6 +591,10 @@ type VMAgentRemoteWriteSpec struct {
 	// +optional
 	// +kubebuilder:validation:Pattern:="[0-9]+(ms|s|m|h)"
 	SendTimeout *string `json:"sendTimeout,omitempty"`
+	// ProxyURL defines optional proxy URL for writing data to -remoteWrite.url
+	// Supported proxies: http, https, socks5. Example: socks5://proxy:1234
+	// +optional
+	ProxyURL *string `json:"proxyURL,omitempty"`
 	// Headers allow configuring custom http headers
 	// Must be in form of semicolon separated header with value
 	// e.g.
@@ -404,6 +606,7 @@ type VMAgentRemoteWriteSpec struct {
 	// +optional
 	StreamAggrConfig *StreamAggrConfig `json:"streamAggrConfig,omitempty"`
 	// MaxDiskUsage defines the maximum file-based buffer size in bytes for -remoteWrite.url
+	// It overrides remoteWriteSettings.maxDiskUsagePerURL for the target, supports KB, MB, GB, KiB, MiB, GiB suffixes
 	// +optional
 	MaxDiskUsage *string `json:"maxDiskUsage,omitempty"`
 	// ForceVMProto forces using VictoriaMetrics protocol for sending data to -remoteWrite.url
@@ -411,6 +614,47 @@ type VMAgentRemoteWriteSpec struct {
 	ForceVMProto bool `json:"forceVMProto,omitempty"`
 }
 
+// VMAgentFederationTarget defines Prometheus servers to scrape series from with /federate endpoint
+type VMAgentFederationTarget struct {
+	// Name of federation target, must be unique. It's used at scrape job_name
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Targets Prometheus servers addresses in form of ["prometheus-0:9090","prometheus-1:9090"].
+	// +kubebuilder:validation:MinItems=1
+	Targets []string `json:"targets"`
+	// Match defines series selectors passed as match[] params to federation endpoint,
+	// e.g. ['{job="node-exporter"}','{__name__=~"job:.*"}']
+	// +kubebuilder:validation:MinItems=1
+	Match []string `json:"match"`
+	// Path of federation endpoint, /federate by default
+	// +optional
+	Path string `json:"path,omitempty"`
+	// HTTP scheme to use for scraping.
+	// +optional
+	// +kubebuilder:validation:Enum=http;https;HTTPS;HTTP
+	Scheme string `json:"scheme,omitempty"`
+	// Interval at which series should be federated
+	// +optional
+	Interval string `json:"interval,omitempty"`
+	// Timeout after which the scrape is ended
+	// +optional
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+	// Labels static labels added to federated series.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// MetricRelabelConfigs to apply to samples after federation.
+	// +optional
+	MetricRelabelConfigs []*RelabelConfig `json:"metricRelabelConfigs,omitempty"`
+	EndpointAuth         `json:",inline"`
+}
+
+const (
+	// ExternalLabelsPolicyMerge adds labels managed by operator to VMAgent external labels
+	ExternalLabelsPolicyMerge = "merge"
+	// ExternalLabelsPolicyReplace uses only VMAgent external labels
+	ExternalLabelsPolicyReplace = "replace"
+)
+
 // AsMapKey key for internal cache map
 func (rw *VMAgentRemoteWriteSpec) AsMapKey() string {
 	return fmt.Sprintf("remoteWrite-%s", rw.URL)
@@ -441,7 +685,46 @@ type VMAgentStatus struct {
 	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
 	// deprecated and will be removed at v0.52.0
 	UnavailableReplicas int32 `json:"unavailableReplicas,omitempty"`
-	StatusMetadata      `json:",inline"`
+	// RemoteWrite reflects health of remote write targets
+	// it's updated only if remote write status check is enabled at operator configuration
+	// +optional
+	RemoteWrite []VMAgentRemoteWriteStatus `json:"remoteWrite,omitempty"`
+	// ShardTargets reflects the number of scrape targets per shard
+	// it's updated only if shard status check is enabled at operator configuration
+	// +optional
+	ShardTargets   []VMAgentShardTargetsStatus `json:"shardTargets,omitempty"`
+	StatusMetadata `json:",inline"`
+}
+
+// VMAgentShardTargetsStatus defines the number of scrape targets of VMAgent shard
+// it's built from vm_promscrape_targets metric of shard pods
+type VMAgentShardTargetsStatus struct {
+	// Shard is the shard number
+	Shard int32 `json:"shard"`
+	// Targets is the number of scrape targets of the shard
+	Targets int64 `json:"targets"`
+	// Hot is true if the shard has at least twice more targets than the average
+	// +optional
+	Hot bool `json:"hot,omitempty"`
+}
+
+// VMAgentRemoteWriteStatus defines health of remote write target
+// it's built from vmagent_remotewrite_* metrics of all vmagent pods
+type VMAgentRemoteWriteStatus struct {
+	// URL of remote write target without credentials and query params
+	URL string `json:"url"`
+	// Healthy is false if remote write requests failed since the previous check
+	Healthy bool `json:"healthy"`
+	// Blocked is true if no data was delivered since the previous check,
+	// while pending data wasn't decreased
+	// +optional
+	Blocked bool `json:"blocked,omitempty"`
+	// LastError describes the last detected delivery problem
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// LastTransitionTime is the time of the last health change
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
 // GetStatusMetadata returns metadata for object status
@@ -513,7 +796,7 @@ func (cr *VMAgent) PodAnnotations() map[string]string {
 
 func (cr *VMAgent) AnnotationsFiltered() map[string]string {
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
-	dst := filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes)
+	dst := filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes, cr.Spec.ManagedMetadata)
 	if cr.Spec.ManagedMetadata != nil {
 		if dst == nil {
 			dst = make(map[string]string)
@@ -553,7 +836,7 @@ func (cr *VMAgent) AllLabels() map[string]string {
 	var result map[string]string
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
 	if cr.ObjectMeta.Labels != nil {
-		result = filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes)
+		result = filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes, cr.Spec.ManagedMetadata)
 	}
 	if cr.Spec.ManagedMetadata != nil {
 		result = labels.Merge(result, cr.Spec.ManagedMetadata.Labels)
@@ -561,6 +844,16 @@ func (cr *VMAgent) AllLabels() map[string]string {
 	return labels.Merge(result, selectorLabels)
 }
 
+// GrafanaDashboardName returns name of the grafana dashboard object
+func (cr *VMAgent) GrafanaDashboardName() string {
+	return fmt.Sprintf("grafana-dashboard-%s", cr.PrefixedName())
+}
+
+// OTLPServiceName returns name of the dedicated OTLP service
+func (cr *VMAgent) OTLPServiceName() string {
+	return fmt.Sprintf("%s-otlp", cr.PrefixedName())
+}
+
 func (cr *VMAgent) PrefixedName() string {
 	return fmt.Sprintf("vmagent-%s", cr.Name)
 }
@@ -586,9 +879,48 @@ func (cr *VMAgent) GetMetricPath() string {
 	return buildPathWithPrefixFlag(cr.Spec.ExtraArgs, metricPath)
 }
 
+var (
+	vmagentStreamAggrDropInputLabelsVersion      = ImageVersion{Major: 1, Minor: 100}
+	vmagentStreamAggrIgnoreFirstIntervalsVersion = ImageVersion{Major: 1, Minor: 101}
+)
+
+// SupportsStreamAggrDropInputLabels checks if vmagent image supports dropInputLabels of stream aggregation
+func (cr *VMAgent) SupportsStreamAggrDropInputLabels() bool {
+	return ImageTagSupports(cr.Spec.Image.Tag, vmagentStreamAggrDropInputLabelsVersion)
+}
+
+// SupportsStreamAggrIgnoreFirstIntervals checks if vmagent image supports per remoteWrite ignoreFirstIntervals of stream aggregation
+func (cr *VMAgent) SupportsStreamAggrIgnoreFirstIntervals() bool {
+	return ImageTagSupports(cr.Spec.Image.Tag, vmagentStreamAggrIgnoreFirstIntervalsVersion)
+}
+
+// UnsupportedFeatures returns spec fields, which are not supported by vmagent image version
+// operator doesn't generate flags for such fields
+func (cr *VMAgent) UnsupportedFeatures() []string {
+	var unsupported []string
+	if !cr.SupportsStreamAggrDropInputLabels() {
+		if cr.Spec.StreamAggrConfig != nil && len(cr.Spec.StreamAggrConfig.DropInputLabels) > 0 {
+			unsupported = append(unsupported, fmt.Sprintf("spec.streamAggrConfig.dropInputLabels requires %s", vmagentStreamAggrDropInputLabelsVersion))
+		}
+		for i, rw := range cr.Spec.RemoteWrite {
+			if rw.StreamAggrConfig != nil && len(rw.StreamAggrConfig.DropInputLabels) > 0 {
+				unsupported = append(unsupported, fmt.Sprintf("spec.remoteWrite[%d].streamAggrConfig.dropInputLabels requires %s", i, vmagentStreamAggrDropInputLabelsVersion))
+			}
+		}
+	}
+	if !cr.SupportsStreamAggrIgnoreFirstIntervals() {
+		for i, rw := range cr.Spec.RemoteWrite {
+			if rw.StreamAggrConfig != nil && rw.StreamAggrConfig.IgnoreFirstIntervals > 0 {
+				unsupported = append(unsupported, fmt.Sprintf("spec.remoteWrite[%d].streamAggrConfig.ignoreFirstIntervals requires %s", i, vmagentStreamAggrIgnoreFirstIntervalsVersion))
+			}
+		}
+	}
+	return unsupported
+}
+
 // ExtraArgs returns additionally configured command-line arguments
 func (cr *VMAgent) GetExtraArgs() map[string]string {
-	return cr.Spec.ExtraArgs
+	return cr.Spec.ExtraArgsWithServerTLS()
 }
 
 // ServiceScrape returns overrides for serviceScrape builder
@@ -608,6 +940,11 @@ func (cr *VMAgent) IsOwnsServiceAccount() bool {
 	return cr.Spec.ServiceAccountName == ""
 }
 
+// GetCloudIdentity returns cloud identity configuration for the ServiceAccount
+func (cr *VMAgent) GetCloudIdentity() *CloudIdentity {
+	return cr.Spec.CloudIdentity
+}
+
 func (cr *VMAgent) GetClusterRoleName() string {
 	return fmt.Sprintf("monitoring:%s:vmagent-%s", cr.Namespace, cr.Name)
 }
@@ -631,7 +968,7 @@ func (cr *VMAgent) AsURL() string {
 			}
 		}
 	}
-	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(cr.Spec.ExtraArgs), cr.PrefixedName(), cr.Namespace, port)
+	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(cr.Spec.ExtraArgsWithServerTLS()), cr.PrefixedName(), cr.Namespace, port)
 }
 
 // AsCRDOwner implements interface
@@ -648,7 +985,7 @@ func (cr *VMAgent) ProbePath() string {
 }
 
 func (cr *VMAgent) ProbeScheme() string {
-	return strings.ToUpper(protoFromFlags(cr.Spec.ExtraArgs))
+	return strings.ToUpper(protoFromFlags(cr.Spec.ExtraArgsWithServerTLS()))
 }
 
 func (cr *VMAgent) ProbePort() string {
@@ -795,6 +1132,7 @@ func (cr *VMAgent) SetUpdateStatusTo(ctx context.Context, r client.Client, statu
 			vs.Replicas = replicaCount
 			vs.Shards = shardCnt
 			vs.Selector = labels.SelectorFromSet(cr.SelectorLabels()).String()
+			setUnsupportedFeaturesCondition(&vs.StatusMetadata, cr.UnsupportedFeatures(), cr.GetGeneration())
 		},
 	})
 }
@@ -829,3 +1167,8 @@ type APIServerConfig struct {
 func init() {
 	SchemeBuilder.Register(&VMAgent{}, &VMAgentList{})
 }
+
+// PVCRetentionPolicy returns persistentVolumeClaimRetentionPolicy for VMAgent StatefulSet
+func (cr *VMAgent) PVCRetentionPolicy() *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy {
+	return pvcRetentionPolicy(cr.Spec.RetainPVCs, cr.Spec.PersistentVolumeClaimRetentionPolicy)
+}
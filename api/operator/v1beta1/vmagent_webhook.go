@@ -18,8 +18,11 @@ package v1beta1
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/envtemplate"
+	"github.com/VictoriaMetrics/VictoriaMetrics/lib/flagutil"
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/promrelabel"
 	"gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -36,6 +39,7 @@ func (r *VMAgent) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 // +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmagent,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmagents,verbs=create;update,versions=v1beta1,name=vvmagent.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-operator-victoriametrics-com-v1beta1-vmagent,mutating=true,failurePolicy=ignore,sideEffects=None,groups=operator.victoriametrics.com,resources=vmagents,verbs=create;update,versions=v1beta1,name=mvmagent.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &VMAgent{}
 
@@ -63,10 +67,80 @@ func checkRelabelConfigs(src []RelabelConfig) error {
 	return nil
 }
 
+// vmagentPrevChecks validates params, which could be already set incorrectly at existing objects
+var vmagentPrevChecks = []func(r *VMAgent) error{
+	func(r *VMAgent) error {
+		if err := checkRollingUpdate(r.Spec.UpdateStrategy, r.Spec.RollingUpdate); err != nil {
+			return fmt.Errorf("incorrect spec.rollingUpdate: %w", err)
+		}
+		return nil
+	},
+	func(r *VMAgent) error {
+		if err := r.Spec.InsertPorts.sanityCheck(r.Spec.Port); err != nil {
+			return fmt.Errorf("incorrect spec.insertPorts: %w", err)
+		}
+		return nil
+	},
+	func(r *VMAgent) error {
+		if err := checkClaimTemplates(r.Spec.ClaimTemplates, "persistent-queue-data"); err != nil {
+			return fmt.Errorf("incorrect spec.claimTemplates: %w", err)
+		}
+		return nil
+	},
+}
+
 func (r *VMAgent) sanityCheck() error {
 	if r.Spec.ServiceSpec != nil && r.Spec.ServiceSpec.Name == r.PrefixedName() {
 		return fmt.Errorf("spec.serviceSpec.Name cannot be equal to prefixed name=%q", r.PrefixedName())
 	}
+	if err := checkAdditionalServices(r.Spec.AdditionalServices, r.PrefixedName(), r.Spec.ServiceSpec); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
+	if err := r.Spec.ServerTLS.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.serverTLS: %w", err)
+	}
+	if err := checkServiceAccountTokens(r.Spec.ServiceAccountTokens); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
+	if err := r.Spec.CloudIdentity.sanityCheck(r.Spec.ServiceAccountName, nil); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
+	if err := r.Spec.GrafanaDashboard.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
+	if err := checkExtraArgs(r.Spec.ExtraArgs); err != nil {
+		return err
+	}
+	if err := r.Spec.PodDisruptionBudget.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.podDisruptionBudget: %w", err)
+	}
+	if err := r.Spec.VPA.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.vpa: %w", err)
+	}
+	if r.Spec.VPA != nil && r.Spec.ShardCount != nil && *r.Spec.ShardCount > 1 {
+		return fmt.Errorf("spec.vpa cannot be used with spec.shardCount")
+	}
+	if r.Spec.Sharding != nil && r.Spec.ShardCount != nil && !r.Spec.KEDA.ScalesShards() {
+		if rf := r.Spec.Sharding.GetReplicationFactor(); rf > *r.Spec.ShardCount {
+			return fmt.Errorf("spec.sharding.replicationFactor=%d cannot be greater than spec.shardCount=%d", rf, *r.Spec.ShardCount)
+		}
+	}
+	if k := r.Spec.KEDA; k != nil {
+		if k.ServerAddress == "" {
+			return fmt.Errorf("spec.keda.serverAddress cannot be empty")
+		}
+		if k.MinReplicaCount != nil && *k.MinReplicaCount > k.MaxReplicaCount {
+			return fmt.Errorf("spec.keda.minReplicaCount cannot be greater than maxReplicaCount")
+		}
+		if k.Threshold != "" {
+			if _, err := strconv.ParseFloat(k.Threshold, 64); err != nil {
+				return fmt.Errorf("cannot parse spec.keda.threshold: %w", err)
+			}
+		}
+		if k.ScalesReplicas() && r.Spec.ShardCount != nil && *r.Spec.ShardCount > 1 {
+			return fmt.Errorf("spec.keda with target: replicas cannot be used with spec.shardCount, use target: shards instead")
+		}
+	}
 	if len(r.Spec.RemoteWrite) == 0 {
 		return fmt.Errorf("spec.remoteWrite cannot be empty array, provide at least one remoteWrite")
 	}
@@ -81,6 +155,33 @@ func (r *VMAgent) sanityCheck() error {
 			return err
 		}
 	}
+	if r.Spec.MaxScrapeSize != nil {
+		var b flagutil.Bytes
+		if err := b.Set(*r.Spec.MaxScrapeSize); err != nil {
+			return fmt.Errorf("cannot parse spec.maxScrapeSize: %w", err)
+		}
+	}
+	for idx, f := range r.Spec.ScrapeConfigFiles {
+		if f == "" {
+			return fmt.Errorf("spec.scrapeConfigFiles cannot contain empty path at idx: %d", idx)
+		}
+	}
+	federationNames := make(map[string]struct{}, len(r.Spec.FederationTargets))
+	for idx, ft := range r.Spec.FederationTargets {
+		if ft.Name == "" {
+			return fmt.Errorf("spec.federationTargets.name cannot be empty at idx: %d", idx)
+		}
+		if _, ok := federationNames[ft.Name]; ok {
+			return fmt.Errorf("spec.federationTargets.name=%q must be unique", ft.Name)
+		}
+		federationNames[ft.Name] = struct{}{}
+		if len(ft.Targets) == 0 {
+			return fmt.Errorf("spec.federationTargets.targets cannot be empty for name=%q", ft.Name)
+		}
+		if len(ft.Match) == 0 {
+			return fmt.Errorf("spec.federationTargets.match must have at least 1 selector for name=%q", ft.Name)
+		}
+	}
 	for idx, rw := range r.Spec.RemoteWrite {
 		if rw.URL == "" {
 			return fmt.Errorf("remoteWrite.url cannot be empty at idx: %d", idx)
@@ -95,6 +196,49 @@ func (r *VMAgent) sanityCheck() error {
 	return nil
 }
 
+// remoteWriteWarnings returns warnings for incorrect remote write tuning values
+// such values are not rejected, since objects created before validation could already have it
+func (r *VMAgent) remoteWriteWarnings() admission.Warnings {
+	var warnings admission.Warnings
+	if rws := r.Spec.RemoteWriteSettings; rws != nil {
+		if rws.Queues != nil && *rws.Queues < 1 {
+			warnings = append(warnings, fmt.Sprintf("spec.remoteWriteSettings.queues must be greater than 0, got: %d", *rws.Queues))
+		}
+		if rws.MaxBlockSize != nil && *rws.MaxBlockSize < 1 {
+			warnings = append(warnings, fmt.Sprintf("spec.remoteWriteSettings.maxBlockSize must be greater than 0, got: %d", *rws.MaxBlockSize))
+		}
+		if rws.MaxDiskUsagePerURL != nil && *rws.MaxDiskUsagePerURL < 0 {
+			warnings = append(warnings, fmt.Sprintf("spec.remoteWriteSettings.maxDiskUsagePerURL cannot be negative, got: %d", *rws.MaxDiskUsagePerURL))
+		}
+		if rws.FlushInterval != nil {
+			d, err := time.ParseDuration(*rws.FlushInterval)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("cannot parse spec.remoteWriteSettings.flushInterval: %s", err))
+			} else if d <= 0 {
+				warnings = append(warnings, fmt.Sprintf("spec.remoteWriteSettings.flushInterval must be positive, got: %s", *rws.FlushInterval))
+			}
+		}
+	}
+	for idx, rw := range r.Spec.RemoteWrite {
+		if rw.MaxDiskUsage != nil {
+			var b flagutil.Bytes
+			if err := b.Set(*rw.MaxDiskUsage); err != nil {
+				warnings = append(warnings, fmt.Sprintf("cannot parse spec.remoteWrite.maxDiskUsage at idx: %d, err: %s", idx, err))
+			}
+		}
+	}
+	return warnings
+}
+
+// extraArgsWarnings returns warnings for unknown and operator managed flags at spec.extraArgs
+func (r *VMAgent) extraArgsWarnings() admission.Warnings {
+	var managedFlags []string
+	if !r.Spec.IngestOnlyMode {
+		managedFlags = append(managedFlags, "promscrape.config")
+	}
+	return checkExtraArgsFlags("spec.extraArgs", r.Spec.ExtraArgs, vmagentFlags, managedFlags...)
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *VMAgent) ValidateCreate() (admission.Warnings, error) {
 	if r.Spec.ParsingError != "" {
@@ -106,7 +250,12 @@ func (r *VMAgent) ValidateCreate() (admission.Warnings, error) {
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	warnings, err := checkWithPrev(r, nil, vmagentPrevChecks...)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, r.extraArgsWarnings()...)
+	return append(warnings, r.remoteWriteWarnings()...), nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -120,7 +269,13 @@ func (r *VMAgent) ValidateUpdate(old runtime.Object) (admission.Warnings, error)
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	prev, _ := old.(*VMAgent)
+	warnings, err := checkWithPrev(r, prev, vmagentPrevChecks...)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, r.extraArgsWarnings()...)
+	return append(warnings, r.remoteWriteWarnings()...), nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -2,6 +2,10 @@ package v1beta1
 
 import (
 	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 )
 
 func TestVMAgent_sanityCheck(t *testing.T) {
@@ -38,6 +42,26 @@ func TestVMAgent_sanityCheck(t *testing.T) {
 				InlineScrapeConfig: `key: value`,
 			},
 		},
+		{
+			name: "replication factor greater than shard count",
+			spec: VMAgentSpec{
+				RemoteWrite: []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+				ShardCount:  ptr.To(2),
+				Sharding:    &VMAgentShardingSpec{ReplicationFactor: ptr.To[int32](3)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "consistent hashing with replication",
+			spec: VMAgentSpec{
+				RemoteWrite: []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+				ShardCount:  ptr.To(3),
+				Sharding: &VMAgentShardingSpec{
+					Mode:              VMAgentShardingModeConsistentHashing,
+					ReplicationFactor: ptr.To[int32](2),
+				},
+			},
+		},
 		{
 			name: "invalid relabeling",
 			spec: VMAgentSpec{
@@ -75,6 +99,86 @@ func TestVMAgent_sanityCheck(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "invalid maxScrapeSize",
+			spec: VMAgentSpec{
+				RemoteWrite:   []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+				MaxScrapeSize: ptr.To("10 apples"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid global scrape knobs",
+			spec: VMAgentSpec{
+				RemoteWrite:       []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+				MaxScrapeSize:     ptr.To("64MiB"),
+				ScrapeConfigFiles: []string{"/etc/vmagent/extra/*.yaml"},
+			},
+		},
+		{
+			name: "valid federationTargets",
+			spec: VMAgentSpec{
+				RemoteWrite: []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+				FederationTargets: []VMAgentFederationTarget{
+					{Name: "prom", Targets: []string{"prometheus:9090"}, Match: []string{`{job!=""}`}},
+				},
+			},
+		},
+		{
+			name: "federationTargets without match",
+			spec: VMAgentSpec{
+				RemoteWrite: []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+				FederationTargets: []VMAgentFederationTarget{
+					{Name: "prom", Targets: []string{"prometheus:9090"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate federationTargets names",
+			spec: VMAgentSpec{
+				RemoteWrite: []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+				FederationTargets: []VMAgentFederationTarget{
+					{Name: "prom", Targets: []string{"prometheus-0:9090"}, Match: []string{`{job!=""}`}},
+					{Name: "prom", Targets: []string{"prometheus-1:9090"}, Match: []string{`{job!=""}`}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid remote write queue tuning",
+			spec: VMAgentSpec{
+				RemoteWrite: []VMAgentRemoteWriteSpec{
+					{URL: "http://some-rw", MaxDiskUsage: ptr.To("5GiB")},
+					{URL: "http://other-rw", MaxDiskUsage: ptr.To("1073741824")},
+				},
+				RemoteWriteSettings: &VMAgentRemoteWriteSettings{
+					Queues:             ptr.To[int32](4),
+					MaxBlockSize:       ptr.To[int32](8388608),
+					MaxDiskUsagePerURL: ptr.To[int64](1073741824),
+					FlushInterval:      ptr.To("2s"),
+				},
+			},
+		},
+		{
+			name: "extraArgs with managed flag",
+			spec: VMAgentSpec{
+				RemoteWrite: []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+				CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+					ExtraArgs: map[string]string{"promscrape.config": "/etc/vmagent/scrape.yaml"},
+				},
+			},
+		},
+		{
+			name: "extraArgs with incorrect flag name",
+			spec: VMAgentSpec{
+				RemoteWrite: []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+				CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+					ExtraArgs: map[string]string{"remoteWrite.tmpDataPath=/tmp": ""},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -87,3 +191,90 @@ func TestVMAgent_sanityCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestVMAgent_remoteWriteWarnings(t *testing.T) {
+	f := func(spec VMAgentSpec, wantWarnings int) {
+		t.Helper()
+		cr := &VMAgent{Spec: spec}
+		got := cr.remoteWriteWarnings()
+		if len(got) != wantWarnings {
+			t.Fatalf("unexpected warnings count, got: %d, want: %d, warnings: %v", len(got), wantWarnings, got)
+		}
+	}
+
+	// valid settings
+	f(VMAgentSpec{
+		RemoteWrite: []VMAgentRemoteWriteSpec{{URL: "http://some-rw", MaxDiskUsage: ptr.To("5GiB")}},
+		RemoteWriteSettings: &VMAgentRemoteWriteSettings{
+			Queues:        ptr.To[int32](4),
+			MaxBlockSize:  ptr.To[int32](8388608),
+			FlushInterval: ptr.To("2s"),
+		},
+	}, 0)
+
+	// invalid queues and flushInterval
+	f(VMAgentSpec{
+		RemoteWrite:         []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+		RemoteWriteSettings: &VMAgentRemoteWriteSettings{Queues: ptr.To[int32](0), FlushInterval: ptr.To("1x")},
+	}, 2)
+
+	// invalid maxDiskUsage
+	f(VMAgentSpec{
+		RemoteWrite: []VMAgentRemoteWriteSpec{{URL: "http://some-rw", MaxDiskUsage: ptr.To("10 apples")}},
+	}, 1)
+}
+
+func TestVMAgentPrevChecks(t *testing.T) {
+	f := func(spec VMAgentSpec, prevSpec *VMAgentSpec, wantWarnings int, wantErr bool) {
+		t.Helper()
+		r := &VMAgent{Spec: spec}
+		var prev *VMAgent
+		if prevSpec != nil {
+			prev = &VMAgent{Spec: *prevSpec}
+		}
+		got, err := checkWithPrev(r, prev, vmagentPrevChecks...)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if len(got) != wantWarnings {
+			t.Fatalf("unexpected warnings: %v, want: %d", got, wantWarnings)
+		}
+	}
+	recreate := ptr.To(appsv1.RecreateDeploymentStrategyType)
+	ru := &appsv1.RollingUpdateDeployment{MaxSurge: ptr.To(intstr.FromInt(1))}
+
+	// rollingUpdate with Recreate strategy on creation
+	f(VMAgentSpec{UpdateStrategy: recreate, RollingUpdate: ru}, nil, 0, true)
+
+	// existing object already has rollingUpdate with Recreate strategy
+	f(VMAgentSpec{UpdateStrategy: recreate, RollingUpdate: ru}, &VMAgentSpec{UpdateStrategy: recreate, RollingUpdate: ru}, 1, false)
+
+	// update adds Recreate strategy to rollingUpdate
+	f(VMAgentSpec{UpdateStrategy: recreate, RollingUpdate: ru}, &VMAgentSpec{RollingUpdate: ru}, 0, true)
+
+	// update fixes the problem
+	f(VMAgentSpec{RollingUpdate: ru}, &VMAgentSpec{UpdateStrategy: recreate, RollingUpdate: ru}, 0, false)
+
+	// valid insertPorts
+	f(VMAgentSpec{InsertPorts: &InsertPorts{GraphitePort: "2003", InfluxPort: "8089", OpenTSDBPort: "4242"}}, nil, 0, false)
+
+	// invalid insertPorts value
+	f(VMAgentSpec{InsertPorts: &InsertPorts{InfluxPort: "influx"}}, nil, 0, true)
+
+	// insertPorts conflicts with http port
+	f(VMAgentSpec{
+		CommonDefaultableParams: CommonDefaultableParams{Port: "8429"},
+		InsertPorts:             &InsertPorts{OpenTSDBHTTPPort: "8429"},
+	}, nil, 0, true)
+
+	// duplicate insertPorts
+	f(VMAgentSpec{InsertPorts: &InsertPorts{GraphitePort: "2003", OpenTSDBPort: "2003"}}, nil, 0, true)
+
+	// existing object already has conflicting insertPorts
+	f(VMAgentSpec{InsertPorts: &InsertPorts{GraphitePort: "2003", OpenTSDBPort: "2003", InfluxPort: "8089"}},
+		&VMAgentSpec{InsertPorts: &InsertPorts{GraphitePort: "2003", OpenTSDBPort: "2003"}}, 1, false)
+
+	// update introduces conflicting insertPorts
+	f(VMAgentSpec{InsertPorts: &InsertPorts{GraphitePort: "2003", OpenTSDBPort: "2003"}},
+		&VMAgentSpec{InsertPorts: &InsertPorts{GraphitePort: "2003"}}, 0, true)
+}
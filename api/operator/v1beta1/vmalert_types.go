@@ -123,6 +123,10 @@ type VMAlertSpec struct {
 	// ServiceSpec that will be added to vmalert service spec
 	// +optional
 	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// AdditionalServices defines a list of extra services that will be created for vmalert
+	// in addition to the default one
+	// +optional
+	AdditionalServices []AdditionalServiceSpec `json:"additionalServices,omitempty"`
 	// ServiceScrapeSpec that will be added to vmalert VMServiceScrape spec
 	// +optional
 	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
@@ -134,6 +138,12 @@ type VMAlertSpec struct {
 	// RollingUpdate - overrides deployment update params.
 	// +optional
 	RollingUpdate *appsv1.RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+	// NetworkPolicy created by operator
+	// +optional
+	NetworkPolicy *EmbeddedNetworkPolicy `json:"networkPolicy,omitempty"`
+	// VPA defines VerticalPodAutoscaler configuration for the application container
+	// +optional
+	VPA *EmbeddedVPA `json:"vpa,omitempty"`
 	// PodDisruptionBudget created by operator
 	// +optional
 	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
@@ -147,6 +157,10 @@ type VMAlertSpec struct {
 	// ServiceAccountName is the name of the ServiceAccount to use to run the pods
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// GrafanaDashboard configures provisioning of the official Grafana dashboard
+	// with grafana-operator GrafanaDashboard object or ConfigMap for grafana sidecar
+	// +optional
+	GrafanaDashboard *GrafanaDashboard `json:"grafanaDashboard,omitempty"`
 
 	CommonDefaultableParams           `json:",inline,omitempty"`
 	CommonConfigReloaderParams        `json:",inline,omitempty"`
@@ -255,7 +269,40 @@ type VMAlertStatus struct {
 	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
 	// Deprecated
 	UnavailableReplicas int32 `json:"unavailableReplicas,omitempty"`
-	StatusMetadata      `json:",inline"`
+	// RuleSelection contains VMRule objects selected at the last reconcile
+	// +optional
+	RuleSelection *VMAlertRuleSelectionStatus `json:"ruleSelection,omitempty"`
+	// RuleConfigMaps defines layout of ConfigMaps with rule files mounted to vmalert
+	// +optional
+	RuleConfigMaps []VMAlertRuleConfigMapStatus `json:"ruleConfigMaps,omitempty"`
+	StatusMetadata `json:",inline"`
+}
+
+// VMAlertRuleConfigMapStatus defines ConfigMap with rule files
+type VMAlertRuleConfigMapStatus struct {
+	// Name of the ConfigMap
+	Name string `json:"name"`
+	// Files defines count of rule files stored at ConfigMap
+	Files int32 `json:"files"`
+	// Size defines total size of rule files in bytes
+	Size int32 `json:"size"`
+}
+
+// VMAlertRuleSelectionStatus defines VMRule objects matched by ruleSelector and ruleNamespaceSelector
+type VMAlertRuleSelectionStatus struct {
+	// Selected defines count of VMRule objects matched by selectors
+	Selected int32 `json:"selected"`
+	// Invalid defines count of selected VMRule objects, which failed validation and weren't loaded
+	Invalid int32 `json:"invalid"`
+	// Rules defines namespace/name of loaded VMRule objects
+	// it's limited to the first 100 items
+	// +optional
+	Rules []string `json:"rules,omitempty"`
+	// InvalidRules defines namespace/name of invalid VMRule objects
+	// validation error is reported at the status of VMRule
+	// it's limited to the first 100 items
+	// +optional
+	InvalidRules []string `json:"invalidRules,omitempty"`
 }
 
 // GetStatusMetadata returns metadata for object status
@@ -297,7 +344,7 @@ func (cr *VMAlert) ProbePath() string {
 }
 
 func (cr *VMAlert) ProbeScheme() string {
-	return strings.ToUpper(protoFromFlags(cr.Spec.ExtraArgs))
+	return strings.ToUpper(protoFromFlags(cr.Spec.ExtraArgsWithServerTLS()))
 }
 
 func (cr *VMAlert) ProbePort() string {
@@ -343,7 +390,7 @@ func (cr *VMAlert) PodAnnotations() map[string]string {
 
 func (cr *VMAlert) AnnotationsFiltered() map[string]string {
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
-	dst := filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes)
+	dst := filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes, cr.Spec.ManagedMetadata)
 	if cr.Spec.ManagedMetadata != nil {
 		if dst == nil {
 			dst = make(map[string]string)
@@ -381,7 +428,7 @@ func (cr *VMAlert) AllLabels() map[string]string {
 	var result map[string]string
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
 	if cr.ObjectMeta.Labels != nil {
-		result = filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes)
+		result = filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes, cr.Spec.ManagedMetadata)
 	}
 	if cr.Spec.ManagedMetadata != nil {
 		result = labels.Merge(result, cr.Spec.ManagedMetadata.Labels)
@@ -389,6 +436,11 @@ func (cr *VMAlert) AllLabels() map[string]string {
 	return labels.Merge(result, selectorLabels)
 }
 
+// GrafanaDashboardName returns name of the grafana dashboard object
+func (cr *VMAlert) GrafanaDashboardName() string {
+	return fmt.Sprintf("grafana-dashboard-%s", cr.PrefixedName())
+}
+
 func (cr *VMAlert) PrefixedName() string {
 	return fmt.Sprintf("vmalert-%s", cr.Name)
 }
@@ -404,7 +456,7 @@ func (cr *VMAlert) GetMetricPath() string {
 
 // GetExtraArgs returns additionally configured command-line arguments
 func (cr *VMAlert) GetExtraArgs() map[string]string {
-	return cr.Spec.ExtraArgs
+	return cr.Spec.ExtraArgsWithServerTLS()
 }
 
 // GetServiceScrape returns overrides for serviceScrape builder
@@ -452,7 +504,7 @@ func (cr *VMAlert) AsURL() string {
 			}
 		}
 	}
-	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(cr.Spec.ExtraArgs), cr.PrefixedName(), cr.Namespace, port)
+	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(cr.Spec.ExtraArgsWithServerTLS()), cr.PrefixedName(), cr.Namespace, port)
 }
 
 // AsCRDOwner implements interface
@@ -33,13 +33,42 @@ func (r *VMAlert) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 // +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmalert,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmalerts,verbs=create;update,versions=v1beta1,name=vvmalert.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-operator-victoriametrics-com-v1beta1-vmalert,mutating=true,failurePolicy=ignore,sideEffects=None,groups=operator.victoriametrics.com,resources=vmalerts,verbs=create;update,versions=v1beta1,name=mvmalert.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &VMAlert{}
 
+// vmalertPrevChecks validates params, which could be already set incorrectly at existing objects
+var vmalertPrevChecks = []func(r *VMAlert) error{
+	func(r *VMAlert) error {
+		if err := checkRollingUpdate(r.Spec.UpdateStrategy, r.Spec.RollingUpdate); err != nil {
+			return fmt.Errorf("incorrect spec.rollingUpdate: %w", err)
+		}
+		return nil
+	},
+}
+
 func (r *VMAlert) sanityCheck() error {
 	if r.Spec.ServiceSpec != nil && r.Spec.ServiceSpec.Name == r.PrefixedName() {
 		return fmt.Errorf("spec.serviceSpec.Name cannot be equal to prefixed name=%q", r.PrefixedName())
 	}
+	if err := checkAdditionalServices(r.Spec.AdditionalServices, r.PrefixedName(), r.Spec.ServiceSpec); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
+	if err := r.Spec.ServerTLS.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.serverTLS: %w", err)
+	}
+	if err := r.Spec.GrafanaDashboard.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
+	if err := checkExtraArgs(r.Spec.ExtraArgs); err != nil {
+		return err
+	}
+	if err := r.Spec.PodDisruptionBudget.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.podDisruptionBudget: %w", err)
+	}
+	if err := r.Spec.VPA.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.vpa: %w", err)
+	}
 	if r.Spec.Datasource.URL == "" {
 		return fmt.Errorf("spec.datasource.url cannot be empty")
 	}
@@ -63,6 +92,79 @@ func (r *VMAlert) sanityCheck() error {
 	return nil
 }
 
+// httpAuthCheck validates auth params of datasource, remoteRead, remoteWrite and notifiers
+// prev must be nil on object creation.
+// Errors are returned as warnings, if prev object has the same problem,
+// it allows to update existing objects created before validation was added
+func (r *VMAlert) httpAuthCheck(prev *VMAlert) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	check := func(name string, ha, prevHA *HTTPAuth) error {
+		err := ha.validate()
+		if err == nil {
+			return nil
+		}
+		err = fmt.Errorf("incorrect %s: %w", name, err)
+		if prevHA == nil || prevHA.validate() == nil {
+			return err
+		}
+		warnings = append(warnings, err.Error())
+		return nil
+	}
+	var prevSpec VMAlertSpec
+	if prev != nil {
+		prevSpec = prev.Spec
+	}
+	if err := check("spec.datasource", &r.Spec.Datasource.HTTPAuth, &prevSpec.Datasource.HTTPAuth); err != nil {
+		return nil, err
+	}
+	if r.Spec.RemoteRead != nil {
+		var prevHA *HTTPAuth
+		if prevSpec.RemoteRead != nil {
+			prevHA = &prevSpec.RemoteRead.HTTPAuth
+		}
+		if err := check("spec.remoteRead", &r.Spec.RemoteRead.HTTPAuth, prevHA); err != nil {
+			return nil, err
+		}
+	}
+	if r.Spec.RemoteWrite != nil {
+		var prevHA *HTTPAuth
+		if prevSpec.RemoteWrite != nil {
+			prevHA = &prevSpec.RemoteWrite.HTTPAuth
+		}
+		if err := check("spec.remoteWrite", &r.Spec.RemoteWrite.HTTPAuth, prevHA); err != nil {
+			return nil, err
+		}
+	}
+	if r.Spec.Notifier != nil {
+		var prevHA *HTTPAuth
+		if prevSpec.Notifier != nil {
+			prevHA = &prevSpec.Notifier.HTTPAuth
+		}
+		if err := check("spec.notifier", &r.Spec.Notifier.HTTPAuth, prevHA); err != nil {
+			return nil, err
+		}
+	}
+	for idx := range r.Spec.Notifiers {
+		var prevHA *HTTPAuth
+		if idx < len(prevSpec.Notifiers) {
+			prevHA = &prevSpec.Notifiers[idx].HTTPAuth
+		}
+		if err := check(fmt.Sprintf("spec.notifiers at idx: %d", idx), &r.Spec.Notifiers[idx].HTTPAuth, prevHA); err != nil {
+			return nil, err
+		}
+	}
+	return warnings, nil
+}
+
+// extraArgsWarnings returns warnings for unknown and operator managed flags at spec.extraArgs
+func (r *VMAlert) extraArgsWarnings() admission.Warnings {
+	managedFlags := []string{"datasource.url"}
+	if r.Spec.NotifierConfigRef != nil {
+		managedFlags = append(managedFlags, "notifier.config")
+	}
+	return checkExtraArgsFlags("spec.extraArgs", r.Spec.ExtraArgs, vmalertFlags, managedFlags...)
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *VMAlert) ValidateCreate() (admission.Warnings, error) {
 	if r.Spec.ParsingError != "" {
@@ -74,7 +176,16 @@ func (r *VMAlert) ValidateCreate() (admission.Warnings, error) {
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	warnings, err := checkWithPrev(r, nil, vmalertPrevChecks...)
+	if err != nil {
+		return nil, err
+	}
+	authWarnings, err := r.httpAuthCheck(nil)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, authWarnings...)
+	return append(warnings, r.extraArgsWarnings()...), nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -88,7 +199,17 @@ func (r *VMAlert) ValidateUpdate(old runtime.Object) (admission.Warnings, error)
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	prev, _ := old.(*VMAlert)
+	warnings, err := checkWithPrev(r, prev, vmalertPrevChecks...)
+	if err != nil {
+		return nil, err
+	}
+	authWarnings, err := r.httpAuthCheck(prev)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, authWarnings...)
+	return append(warnings, r.extraArgsWarnings()...), nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -49,3 +49,68 @@ func TestVMAlert_sanityCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestVMAlert_httpAuthCheck(t *testing.T) {
+	f := func(spec VMAlertSpec, prevSpec *VMAlertSpec, wantWarnings int, wantErr bool) {
+		t.Helper()
+		r := &VMAlert{Spec: spec}
+		var prev *VMAlert
+		if prevSpec != nil {
+			prev = &VMAlert{Spec: *prevSpec}
+		}
+		got, err := r.httpAuthCheck(prev)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if len(got) != wantWarnings {
+			t.Fatalf("unexpected warnings: %v, want: %d", got, wantWarnings)
+		}
+	}
+	multipleAuth := HTTPAuth{
+		BasicAuth:  &BasicAuth{},
+		BearerAuth: &BearerAuth{TokenFilePath: "/path/to/token"},
+	}
+
+	// datasource with multiple auth methods
+	f(VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{URL: "http://some-url", HTTPAuth: multipleAuth},
+	}, nil, 0, true)
+
+	// notifier with incorrect oauth2
+	f(VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{URL: "http://some-url"},
+		Notifiers: []VMAlertNotifierSpec{
+			{URL: "http://some-url"},
+			{URL: "http://other-url", HTTPAuth: HTTPAuth{OAuth2: &OAuth2{}}},
+		},
+	}, nil, 0, true)
+
+	// separate auth per url
+	f(VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{
+			URL:      "http://some-url",
+			HTTPAuth: HTTPAuth{BearerAuth: &BearerAuth{TokenFilePath: "/path/to/token"}},
+		},
+		RemoteWrite: &VMAlertRemoteWriteSpec{
+			URL:      "http://some-url",
+			HTTPAuth: HTTPAuth{BasicAuth: &BasicAuth{}, TLSConfig: &TLSConfig{CAFile: "/path/to/ca"}},
+		},
+	}, nil, 0, false)
+
+	// existing object with the same problems is updated with warnings
+	f(VMAlertSpec{
+		Datasource:  VMAlertDatasourceSpec{URL: "http://some-url", HTTPAuth: multipleAuth},
+		RemoteWrite: &VMAlertRemoteWriteSpec{URL: "http://some-url", HTTPAuth: multipleAuth},
+	}, &VMAlertSpec{
+		Datasource:  VMAlertDatasourceSpec{URL: "http://some-url", HTTPAuth: multipleAuth},
+		RemoteWrite: &VMAlertRemoteWriteSpec{URL: "http://some-url", HTTPAuth: HTTPAuth{OAuth2: &OAuth2{}}},
+	}, 2, false)
+
+	// problem introduced by update
+	f(VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{URL: "http://some-url", HTTPAuth: multipleAuth},
+		RemoteRead: &VMAlertRemoteReadSpec{URL: "http://some-url", HTTPAuth: multipleAuth},
+	}, &VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{URL: "http://some-url", HTTPAuth: multipleAuth},
+	}, 0, true)
+}
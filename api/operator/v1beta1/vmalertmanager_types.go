@@ -132,9 +132,19 @@ type VMAlertmanagerSpec struct {
 	// ServiceSpec that will be added to vmalertmanager service spec
 	// +optional
 	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// AdditionalServices defines a list of extra services that will be created for vmalertmanager
+	// in addition to the default one
+	// +optional
+	AdditionalServices []AdditionalServiceSpec `json:"additionalServices,omitempty"`
 	// ServiceScrapeSpec that will be added to vmalertmanager VMServiceScrape spec
 	// +optional
 	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
+	// NetworkPolicy created by operator
+	// +optional
+	NetworkPolicy *EmbeddedNetworkPolicy `json:"networkPolicy,omitempty"`
+	// VPA defines VerticalPodAutoscaler configuration for the application container
+	// +optional
+	VPA *EmbeddedVPA `json:"vpa,omitempty"`
 	// PodDisruptionBudget created by operator
 	// +optional
 	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
@@ -165,6 +175,11 @@ type VMAlertmanagerSpec struct {
 	// +optional
 	DisableNamespaceMatcher bool `json:"disableNamespaceMatcher,omitempty"`
 
+	// NamespaceMatcher defines namespace label matcher injection policy for top route and inhibit rules of VMAlertmanagerConfig
+	// It has priority over disableNamespaceMatcher
+	// +optional
+	NamespaceMatcher *VMAlertmanagerNamespaceMatcher `json:"namespaceMatcher,omitempty"`
+
 	// DisableRouteContinueEnforce cancel the behavior for VMAlertmanagerConfig that always enforce first-level route continue to true
 	// +optional
 	DisableRouteContinueEnforce bool `json:"disableRouteContinueEnforce,omitempty"`
@@ -175,6 +190,13 @@ type VMAlertmanagerSpec struct {
 	// https://prometheus.io/docs/alerting/latest/configuration/#matcher
 	EnforcedTopRouteMatchers []string `json:"enforcedTopRouteMatchers,omitempty"`
 
+	// ConfigParentRoute defines sub-route of the root route for VMAlertmanagerConfig routes.
+	// If set, VMAlertmanagerConfig routes are nested into this sub-route instead of the root route.
+	// The sub-route is added after routes defined at configSecret or configRawYaml, so user defined routes take precedence.
+	// It allows to gradually migrate from raw configuration to VMAlertmanagerConfig objects.
+	// +optional
+	ConfigParentRoute *VMAlertmanagerConfigParentRoute `json:"configParentRoute,omitempty"`
+
 	// RollingUpdateStrategy defines strategy for application updates
 	// Default is OnDelete, in this case operator handles update process
 	// Can be changed for RollingUpdate
@@ -182,6 +204,16 @@ type VMAlertmanagerSpec struct {
 	RollingUpdateStrategy appsv1.StatefulSetUpdateStrategyType `json:"rollingUpdateStrategy,omitempty"`
 	// ClaimTemplates allows adding additional VolumeClaimTemplates for StatefulSet
 	ClaimTemplates []v1.PersistentVolumeClaim `json:"claimTemplates,omitempty"`
+	// PersistentVolumeClaimRetentionPolicy controls whether PersistentVolumeClaims created from StatefulSet
+	// VolumeClaimTemplates are deleted on StatefulSet deletion or scale down.
+	// See [PersistentVolumeClaim retention](https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#persistentvolumeclaim-retention)
+	// +optional
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+	// RetainPVCs defines whether PersistentVolumeClaims created for VMAlertmanager StatefulSet are kept after VMAlertmanager deletion.
+	// If not set, PersistentVolumeClaims are removed only if persistentVolumeClaimRetentionPolicy.whenDeleted is Delete.
+	// It takes precedence over persistentVolumeClaimRetentionPolicy.whenDeleted
+	// +optional
+	RetainPVCs *bool `json:"retainPVCs,omitempty"`
 
 	// WebConfig defines configuration for webserver
 	// https://github.com/prometheus/alertmanager/blob/main/docs/https.md
@@ -200,6 +232,41 @@ type VMAlertmanagerSpec struct {
 	CommonApplicationDeploymentParams `json:",inline,omitempty"`
 }
 
+// namespace matcher policies for VMAlertmanagerConfig
+const (
+	NamespaceMatcherPolicyEnforce = "enforce"
+	NamespaceMatcherPolicyNone    = "none"
+	NamespaceMatcherPolicyCustom  = "custom"
+)
+
+// VMAlertmanagerNamespaceMatcher defines namespace label matcher injection policy for VMAlertmanagerConfig
+type VMAlertmanagerNamespaceMatcher struct {
+	// Policy defines how namespace matcher is added:
+	// enforce - adds namespace label matcher with VMAlertmanagerConfig namespace, it's default
+	// none - doesn't add namespace matcher
+	// custom - adds matcher for the label defined at labelName
+	// +kubebuilder:validation:Enum=enforce;none;custom
+	// +optional
+	Policy string `json:"policy,omitempty"`
+	// LabelName defines name of the label with alert namespace for custom policy,
+	// e.g. exported_namespace or k8s_namespace
+	// +optional
+	LabelName string `json:"labelName,omitempty"`
+}
+
+// VMAlertmanagerConfigParentRoute defines parent route for VMAlertmanagerConfig routes
+type VMAlertmanagerConfigParentRoute struct {
+	// Matchers defines label matchers of the route
+	// https://prometheus.io/docs/alerting/latest/configuration/#matcher
+	// +optional
+	Matchers []string `json:"matchers,omitempty"`
+	// Receiver defines name of receiver from configSecret or configRawYaml
+	// for alerts, which don't match any VMAlertmanagerConfig route.
+	// If not set, receiver of the root route is used
+	// +optional
+	Receiver string `json:"receiver,omitempty"`
+}
+
 func (cr *VMAlertmanager) setLastSpec(prevSpec VMAlertmanagerSpec) {
 	cr.ParsedLastAppliedSpec = &prevSpec
 }
@@ -276,7 +343,7 @@ func (cr *VMAlertmanager) PodAnnotations() map[string]string {
 
 func (cr *VMAlertmanager) AnnotationsFiltered() map[string]string {
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
-	dst := filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes)
+	dst := filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes, cr.Spec.ManagedMetadata)
 	if cr.Spec.ManagedMetadata != nil {
 		if dst == nil {
 			dst = make(map[string]string)
@@ -314,7 +381,7 @@ func (cr *VMAlertmanager) AllLabels() map[string]string {
 	var result map[string]string
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
 	if cr.ObjectMeta.Labels != nil {
-		result = filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes)
+		result = filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes, cr.Spec.ManagedMetadata)
 	}
 	if cr.Spec.ManagedMetadata != nil {
 		result = labels.Merge(result, cr.Spec.ManagedMetadata.Labels)
@@ -463,6 +530,23 @@ func (cr *VMAlertmanager) ProbeNeedLiveness() bool {
 	return true
 }
 
+// ConfigNamespaceMatcher returns label matcher, which must be added to top route and inhibit rules
+// of VMAlertmanagerConfig at the given namespace. Returns empty string if matcher must not be added
+func (cr *VMAlertmanager) ConfigNamespaceMatcher(namespace string) string {
+	labelName := "namespace"
+	if nm := cr.Spec.NamespaceMatcher; nm != nil {
+		switch nm.Policy {
+		case NamespaceMatcherPolicyNone:
+			return ""
+		case NamespaceMatcherPolicyCustom:
+			labelName = nm.LabelName
+		}
+	} else if cr.Spec.DisableNamespaceMatcher {
+		return ""
+	}
+	return fmt.Sprintf("%s = %q", labelName, namespace)
+}
+
 // IsUnmanaged checks if alertmanager should managed any alertmanager config objects
 func (cr *VMAlertmanager) IsUnmanaged() bool {
 	return !cr.Spec.SelectAllByDefault && cr.Spec.ConfigSelector == nil && cr.Spec.ConfigNamespaceSelector == nil
@@ -533,3 +617,8 @@ func (cr *VMAlertmanager) GetAdditionalService() *AdditionalServiceSpec {
 func init() {
 	SchemeBuilder.Register(&VMAlertmanager{}, &VMAlertmanagerList{})
 }
+
+// PVCRetentionPolicy returns persistentVolumeClaimRetentionPolicy for VMAlertmanager StatefulSet
+func (cr *VMAlertmanager) PVCRetentionPolicy() *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy {
+	return pvcRetentionPolicy(cr.Spec.RetainPVCs, cr.Spec.PersistentVolumeClaimRetentionPolicy)
+}
@@ -38,19 +38,56 @@ func (r *VMAlertmanager) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 // +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmalertmanager,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmalertmanagers,verbs=create;update,versions=v1beta1,name=vvmalertmanager.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-operator-victoriametrics-com-v1beta1-vmalertmanager,mutating=true,failurePolicy=ignore,sideEffects=None,groups=operator.victoriametrics.com,resources=vmalertmanagers,verbs=create;update,versions=v1beta1,name=mvmalertmanager.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &VMAlertmanager{}
 
+// vmalertmanagerPrevChecks validates params, which could be already set incorrectly at existing objects
+var vmalertmanagerPrevChecks = []func(r *VMAlertmanager) error{
+	func(r *VMAlertmanager) error {
+		if err := checkClaimTemplates(r.Spec.ClaimTemplates, r.GetVolumeName()); err != nil {
+			return fmt.Errorf("incorrect spec.claimTemplates: %w", err)
+		}
+		return nil
+	},
+}
+
 func (r *VMAlertmanager) sanityCheck() error {
 	if r.Spec.ServiceSpec != nil && r.Spec.ServiceSpec.Name == r.PrefixedName() {
 		return fmt.Errorf("spec.serviceSpec.Name cannot be equal to prefixed name=%q", r.PrefixedName())
 	}
+	if err := checkAdditionalServices(r.Spec.AdditionalServices, r.PrefixedName(), r.Spec.ServiceSpec); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
+	if r.Spec.ServerTLS != nil {
+		return fmt.Errorf("spec.serverTLS is not supported, use spec.webConfig.tls_server_config instead")
+	}
+	if err := checkExtraArgs(r.Spec.ExtraArgs); err != nil {
+		return err
+	}
+	if err := r.Spec.PodDisruptionBudget.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.podDisruptionBudget: %w", err)
+	}
+	if err := r.Spec.VPA.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.vpa: %w", err)
+	}
 	for idx, matchers := range r.Spec.EnforcedTopRouteMatchers {
 		_, err := labels.ParseMatchers(matchers)
 		if err != nil {
 			fmt.Errorf("incorrect EnforcedTopRouteMatchers=%q at idx=%d: %w", matchers, idx, err)
 		}
 	}
+	if nm := r.Spec.NamespaceMatcher; nm != nil {
+		switch nm.Policy {
+		case "", NamespaceMatcherPolicyEnforce, NamespaceMatcherPolicyNone:
+		case NamespaceMatcherPolicyCustom:
+			if nm.LabelName == "" {
+				return fmt.Errorf("spec.namespaceMatcher.labelName must be set for policy=%q", nm.Policy)
+			}
+		default:
+			return fmt.Errorf("unsupported spec.namespaceMatcher.policy=%q, supported values are: %s, %s, %s", nm.Policy, NamespaceMatcherPolicyEnforce, NamespaceMatcherPolicyNone, NamespaceMatcherPolicyCustom)
+		}
+	}
 
 	if len(r.Spec.ConfigRawYaml) > 0 {
 		if err := ValidateAlertmanagerConfigSpec([]byte(r.Spec.ConfigRawYaml)); err != nil {
@@ -109,6 +146,11 @@ func (r *VMAlertmanager) sanityCheck() error {
 	return nil
 }
 
+// extraArgsWarnings returns warnings for unknown and operator managed flags at spec.extraArgs
+func (r *VMAlertmanager) extraArgsWarnings() admission.Warnings {
+	return checkExtraArgsFlags("spec.extraArgs", r.Spec.ExtraArgs, vmalertmanagerFlags, "config.file")
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *VMAlertmanager) ValidateCreate() (admission.Warnings, error) {
 	vmalertmanagerlog.Info("validate create", "name", r.Name)
@@ -121,7 +163,11 @@ func (r *VMAlertmanager) ValidateCreate() (admission.Warnings, error) {
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	warnings, err := checkWithPrev(r, nil, vmalertmanagerPrevChecks...)
+	if err != nil {
+		return nil, err
+	}
+	return append(warnings, r.extraArgsWarnings()...), nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -135,7 +181,12 @@ func (r *VMAlertmanager) ValidateUpdate(old runtime.Object) (admission.Warnings,
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	prev, _ := old.(*VMAlertmanager)
+	warnings, err := checkWithPrev(r, prev, vmalertmanagerPrevChecks...)
+	if err != nil {
+		return nil, err
+	}
+	return append(warnings, r.extraArgsWarnings()...), nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -0,0 +1,243 @@
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VMAlertmanagerSilenceSpec defines the desired state of VMAlertmanagerSilence
+// +k8s:openapi-gen=true
+type VMAlertmanagerSilenceSpec struct {
+	// ParsingError contents error with context if operator was failed to parse json object from kubernetes api server
+	ParsingError string `json:"-" yaml:"-"`
+	// AlertmanagerRef defines VMAlertmanager at the same namespace, which silence must be created at
+	AlertmanagerRef VMAlertmanagerSilenceRef `json:"alertmanagerRef"`
+	// Matchers defines alerts matched by silence
+	// +kubebuilder:validation:MinItems=1
+	Matchers []VMAlertmanagerSilenceMatcher `json:"matchers"`
+	// StartsAt defines silence start time
+	// if not set, silence starts at object creation time
+	// +optional
+	StartsAt *metav1.Time `json:"startsAt,omitempty"`
+	// EndsAt defines silence end time
+	// either endsAt or duration must be set
+	// +optional
+	EndsAt *metav1.Time `json:"endsAt,omitempty"`
+	// Duration defines silence duration relative to its start time, e.g. 2h or 30m
+	// it's ignored if endsAt is set
+	// +optional
+	Duration string `json:"duration,omitempty"`
+	// Comment defines silence description
+	// +kubebuilder:validation:MinLength=1
+	Comment string `json:"comment"`
+	// CreatedBy defines silence author
+	// if not set, object namespace and name is used
+	// +optional
+	CreatedBy string `json:"createdBy,omitempty"`
+	// BasicAuth defines credentials for alertmanager api access
+	// it must be set if VMAlertmanager webConfig defines basic_auth_users
+	// +optional
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+	// TLSConfig defines client TLS configuration for alertmanager api access
+	// it's used if VMAlertmanager webConfig defines tls_server_config
+	// only secret and configmap references are supported
+	// +optional
+	TLSConfig *TLSConfig `json:"tlsConfig,omitempty"`
+	// Paused If set to true all actions on the underlying managed objects are not
+	// going to be performed, except for delete actions.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// VMAlertmanagerSilenceRef references VMAlertmanager object
+type VMAlertmanagerSilenceRef struct {
+	// Name of the VMAlertmanager at the same namespace
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// VMAlertmanagerSilenceMatcher defines label matcher of silence
+type VMAlertmanagerSilenceMatcher struct {
+	// Name defines label name
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Value defines label value or regular expression
+	Value string `json:"value"`
+	// IsRegex defines if value must be used as regular expression
+	// +optional
+	IsRegex bool `json:"isRegex,omitempty"`
+	// IsEqual defines if label value must match, it's true by default
+	// set it to false for negative matching
+	// +optional
+	IsEqual *bool `json:"isEqual,omitempty"`
+}
+
+// VMAlertmanagerSilenceStatus defines the observed state of VMAlertmanagerSilence
+type VMAlertmanagerSilenceStatus struct {
+	// SilenceID defines identifier of silence at Alertmanager
+	// +optional
+	SilenceID string `json:"silenceID,omitempty"`
+	// State defines silence state reported by Alertmanager: pending, active or expired
+	// +optional
+	State string `json:"state,omitempty"`
+	// EndsAt defines silence end time
+	// +optional
+	EndsAt         *metav1.Time `json:"endsAt,omitempty"`
+	StatusMetadata `json:",inline"`
+}
+
+// GetStatusMetadata returns metadata for object status
+func (cr *VMAlertmanagerSilenceStatus) GetStatusMetadata() *StatusMetadata {
+	return &cr.StatusMetadata
+}
+
+// VMAlertmanagerSilence defines silence synchronized to VMAlertmanager
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="VMAlertmanagerSilence"
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmalertmanagersilences,scope=Namespaced
+// +kubebuilder:printcolumn:name="Alertmanager",type="string",JSONPath=".spec.alertmanagerRef.name"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Ends At",type="date",JSONPath=".status.endsAt"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus",description="Current status of silence sync"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// VMAlertmanagerSilence is the Schema for the vmalertmanagersilences API
+type VMAlertmanagerSilence struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VMAlertmanagerSilenceSpec `json:"spec,omitempty"`
+	// ParsedLastAppliedSpec contains last-applied configuration spec
+	ParsedLastAppliedSpec *VMAlertmanagerSilenceSpec `json:"-" yaml:"-"`
+
+	Status VMAlertmanagerSilenceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VMAlertmanagerSilenceList contains a list of VMAlertmanagerSilence
+type VMAlertmanagerSilenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMAlertmanagerSilence `json:"items"`
+}
+
+// AsOwner returns owner references with current object as owner
+func (cr *VMAlertmanagerSilence) AsOwner() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         cr.APIVersion,
+			Kind:               cr.Kind,
+			Name:               cr.Name,
+			UID:                cr.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+func (cr *VMAlertmanagerSilence) setLastSpec(prevSpec VMAlertmanagerSilenceSpec) {
+	cr.ParsedLastAppliedSpec = &prevSpec
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMAlertmanagerSilence) UnmarshalJSON(src []byte) error {
+	type pcr VMAlertmanagerSilence
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		return err
+	}
+	if err := parseLastAppliedState(cr); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMAlertmanagerSilenceSpec) UnmarshalJSON(src []byte) error {
+	type pcr VMAlertmanagerSilenceSpec
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		cr.ParsingError = fmt.Sprintf("cannot parse vmalertmanagersilence spec: %s, err: %s", string(src), err)
+		return nil
+	}
+	return nil
+}
+
+// TimeRange returns silence start and end time
+func (cr *VMAlertmanagerSilence) TimeRange() (time.Time, time.Time, error) {
+	startsAt := cr.CreationTimestamp.Time
+	if cr.Spec.StartsAt != nil {
+		startsAt = cr.Spec.StartsAt.Time
+	}
+	if cr.Spec.EndsAt != nil {
+		if !cr.Spec.EndsAt.After(startsAt) {
+			return startsAt, startsAt, fmt.Errorf("endsAt=%s must be after silence start time=%s", cr.Spec.EndsAt.Format(time.RFC3339), startsAt.Format(time.RFC3339))
+		}
+		return startsAt, cr.Spec.EndsAt.Time, nil
+	}
+	if cr.Spec.Duration == "" {
+		return startsAt, startsAt, fmt.Errorf("either endsAt or duration must be set")
+	}
+	d, err := time.ParseDuration(cr.Spec.Duration)
+	if err != nil {
+		return startsAt, startsAt, fmt.Errorf("cannot parse duration=%q: %w", cr.Spec.Duration, err)
+	}
+	if d <= 0 {
+		return startsAt, startsAt, fmt.Errorf("duration=%q must be positive", cr.Spec.Duration)
+	}
+	return startsAt, startsAt.Add(d), nil
+}
+
+// CreatedBy returns silence author
+func (cr *VMAlertmanagerSilence) CreatedBy() string {
+	if cr.Spec.CreatedBy != "" {
+		return cr.Spec.CreatedBy
+	}
+	return fmt.Sprintf("%s/%s", cr.Namespace, cr.Name)
+}
+
+// SilencesURL returns url of silences api at given alertmanager
+func (cr *VMAlertmanagerSilence) SilencesURL(am *VMAlertmanager) string {
+	return am.AsURL() + path.Join("/", am.Spec.RoutePrefix, "/api/v2/silences")
+}
+
+// SilenceURL returns url of the silence with given id at given alertmanager
+func (cr *VMAlertmanagerSilence) SilenceURL(am *VMAlertmanager, id string) string {
+	return am.AsURL() + path.Join("/", am.Spec.RoutePrefix, "/api/v2/silence", id)
+}
+
+// LastAppliedSpecAsPatch return last applied vmalertmanagersilence spec as patch annotation
+func (cr *VMAlertmanagerSilence) LastAppliedSpecAsPatch() (client.Patch, error) {
+	return lastAppliedChangesAsPatch(cr.ObjectMeta, cr.Spec)
+}
+
+// HasSpecChanges compares vmalertmanagersilence spec with last applied vmalertmanagersilence spec stored in annotation
+func (cr *VMAlertmanagerSilence) HasSpecChanges() (bool, error) {
+	return hasStateChanges(cr.ObjectMeta, cr.Spec)
+}
+
+func (cr *VMAlertmanagerSilence) Paused() bool {
+	return cr.Spec.Paused
+}
+
+// SetUpdateStatusTo changes update status with optional reason of fail
+func (cr *VMAlertmanagerSilence) SetUpdateStatusTo(ctx context.Context, c client.Client, status UpdateStatus, maybeErr error) error {
+	return updateObjectStatus(ctx, c, &patchStatusOpts[*VMAlertmanagerSilence, *VMAlertmanagerSilenceStatus]{
+		actualStatus: status,
+		cr:           cr,
+		crStatus:     &cr.Status,
+		maybeErr:     maybeErr,
+	})
+}
+
+func init() {
+	SchemeBuilder.Register(&VMAlertmanagerSilence{}, &VMAlertmanagerSilenceList{})
+}
@@ -7,7 +7,7 @@ import (
 	"net/url"
 	"strings"
 
-	v12 "k8s.io/api/networking/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/utils/ptr"
@@ -55,14 +55,34 @@ type VMAuthSpec struct {
 	// ServiceSpec that will be added to vmsingle service spec
 	// +optional
 	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty" yaml:"serviceSpec,omitempty"`
+	// AdditionalServices defines a list of extra services that will be created for vmauth
+	// in addition to the default one
+	// +optional
+	AdditionalServices []AdditionalServiceSpec `json:"additionalServices,omitempty"`
 	// ServiceScrapeSpec that will be added to vmauth VMServiceScrape spec
 	// +optional
 	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty" yaml:"serviceScrapeSpec,omitempty"`
 	// PodDisruptionBudget created by operator
 	// +optional
 	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty" yaml:"podDisruptionBudget,omitempty"`
+	// UpdateStrategy - overrides default update strategy.
+	// +kubebuilder:validation:Enum=Recreate;RollingUpdate
+	// +optional
+	UpdateStrategy *appsv1.DeploymentStrategyType `json:"updateStrategy,omitempty" yaml:"updateStrategy,omitempty"`
+	// RollingUpdate - overrides deployment update params.
+	// +optional
+	RollingUpdate *appsv1.RollingUpdateDeployment `json:"rollingUpdate,omitempty" yaml:"rollingUpdate,omitempty"`
+	// NetworkPolicy created by operator
+	// +optional
+	NetworkPolicy *EmbeddedNetworkPolicy `json:"networkPolicy,omitempty" yaml:"networkPolicy,omitempty"`
+	// VPA defines VerticalPodAutoscaler configuration for the application container
+	// +optional
+	VPA *EmbeddedVPA `json:"vpa,omitempty" yaml:"vpa,omitempty"`
 	// Ingress enables ingress configuration for VMAuth.
 	Ingress *EmbeddedIngress `json:"ingress,omitempty"`
+	// Route defines OpenShift Route configuration for external access to VMAuth service
+	// +optional
+	Route *EmbeddedRoute `json:"route,omitempty"`
 	// LivenessProbe that will be added to VMAuth pod
 	*EmbeddedProbes `json:",inline"`
 	// UnauthorizedAccessConfig configures access for un authorized users
@@ -78,6 +98,8 @@ type VMAuthSpec struct {
 	UnauthorizedUserAccessSpec *VMAuthUnauthorizedUserAccessSpec `json:"unauthorizedUserAccessSpec,omitempty" yaml:"unauthorizedUserAccessSpec,omitempty"`
 	// IPFilters global access ip filters
 	// supported only with enterprise version of [vmauth](https://docs.victoriametrics.com/vmauth/#ip-filters)
+	// it's defined by inlined VMUserConfigOptions.IPFilters and applied to all users and unauthorized_user without own ip_filters
+	// if deprecated unauthorizedAccessConfig is used, it's applied only to unauthorized_user
 	// +optional
 	// will be added after removal of VMUserConfigOptions
 	// currently it has collision with inlined fields
@@ -103,6 +125,14 @@ type VMAuthSpec struct {
 	// If it's defined, configuration for vmauth becomes unmanaged and operator'll not create any related secrets/config-reloaders
 	// +optional
 	ExternalConfig `json:"externalConfig,omitempty" yaml:"externalConfig,omitempty"`
+	// ConfigCheckInterval defines interval for vmauth configuration file changes check.
+	// Changes are applied without pods restart.
+	// It's 1m by default for externalConfig, set it to 0 in order to disable checks.
+	// Operator generated configuration is reloaded by config-reloader sidecar via vmauth reload endpoint,
+	// this option could be used as a fallback for it.
+	// +kubebuilder:validation:Pattern:="[0-9]+(ms|s|m|h)?"
+	// +optional
+	ConfigCheckInterval string `json:"configCheckInterval,omitempty" yaml:"configCheckInterval,omitempty"`
 	// ServiceAccountName is the name of the ServiceAccount to use to run the pods
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty" yaml:"serviceAccountName,omitempty"`
@@ -179,7 +209,7 @@ type UnauthorizedAccessConfigURLMap struct {
 
 // Validate performs syntax logic validation
 func (uac *UnauthorizedAccessConfigURLMap) Validate() error {
-	if len(uac.SrcPaths) == 0 && len(uac.SrcHosts) == 0 && len(uac.SrcQueryArgs) == 0 && len(uac.URLMapCommon.SrcQueryArgs) == 0 {
+	if len(uac.SrcPaths) == 0 && len(uac.SrcHosts) == 0 && len(uac.SrcQueryArgs) == 0 && len(uac.SrcHeaders) == 0 {
 		return fmt.Errorf("incorrect url_map config at least of one src_paths,src_hosts,src_query_args or src_headers must be defined")
 	}
 	if len(uac.URLPrefix) == 0 {
@@ -277,7 +307,7 @@ type VMUserConfigOptions struct {
 	// ["header_key: value1,value2"]
 	// it's available since 1.68.0 version of vmauth
 	// +optional
-	Headers []string `json:"headers,omitempty"`
+	Headers []string `json:"headers,omitempty" yaml:"headers,omitempty"`
 	// ResponseHeaders represent additional http headers, that vmauth adds for request response
 	// in form of ["header_key: header_value"]
 	// multiple values for header key:
@@ -293,7 +323,9 @@ type VMUserConfigOptions struct {
 
 	// MaxConcurrentRequests defines max concurrent requests per user
 	// 300 is default value for vmauth
+	// it can be changed globally with -maxConcurrentPerUserRequests flag at VMAuth.spec.extraArgs
 	// +optional
+	// +kubebuilder:validation:Minimum=1
 	MaxConcurrentRequests *int `json:"max_concurrent_requests,omitempty" yaml:"max_concurrent_requests,omitempty"`
 
 	// LoadBalancingPolicy defines load balancing policy to use for backend urls.
@@ -314,7 +346,7 @@ type VMUserConfigOptions struct {
 	//
 	// available since v1.107.0 vmauth version
 	// +optional
-	DumpRequestOnErrors *bool `json:"dump_request_on_errors,omitempty"`
+	DumpRequestOnErrors *bool `json:"dump_request_on_errors,omitempty" yaml:"dump_request_on_errors,omitempty"`
 }
 
 // Validate performs semantic syntax validation
@@ -376,33 +408,6 @@ func (cr *VMAuthSpec) UnmarshalJSON(src []byte) error {
 	return nil
 }
 
-// EmbeddedIngress describes ingress configuration options.
-type EmbeddedIngress struct {
-	// ClassName defines ingress class name for VMAuth
-	// +optional
-	ClassName *string `json:"class_name,omitempty" yaml:"class_name,omitempty"`
-	//  EmbeddedObjectMetadata adds labels and annotations for object.
-	EmbeddedObjectMetadata `json:",inline"`
-	// TlsHosts configures TLS access for ingress, tlsSecretName must be defined for it.
-	TlsHosts []string `json:"tlsHosts,omitempty" yaml:"tlsHosts,omitempty"`
-	// TlsSecretName defines secretname at the VMAuth namespace with cert and key
-	// https://kubernetes.io/docs/concepts/services-networking/ingress/#tls
-	// +optional
-	TlsSecretName string `json:"tlsSecretName,omitempty" yaml:"tlsSecretName,omitempty"`
-	// ExtraRules - additional rules for ingress,
-	// must be checked for correctness by user.
-	// +optional
-	ExtraRules []v12.IngressRule `json:"extraRules,omitempty" yaml:"extraRules,omitempty"`
-	// ExtraTLS - additional TLS configuration for ingress
-	// must be checked for correctness by user.
-	// +optional
-	ExtraTLS []v12.IngressTLS `json:"extraTls,omitempty" yaml:"extraTls,omitempty"`
-	// Host defines ingress host parameter for default rule
-	// It will be used, only if TlsHosts is empty
-	// +optional
-	Host string `json:"host,omitempty"`
-}
-
 // VMAuthStatus defines the observed state of VMAuth
 type VMAuthStatus struct {
 	StatusMetadata `json:",inline"`
@@ -442,7 +447,7 @@ func (cr *VMAuth) ProbePath() string {
 }
 
 func (cr *VMAuth) ProbeScheme() string {
-	return strings.ToUpper(protoFromFlags(cr.Spec.ExtraArgs))
+	return strings.ToUpper(protoFromFlags(cr.Spec.ExtraArgsWithServerTLS()))
 }
 
 func (cr *VMAuth) ProbePort() string {
@@ -488,7 +493,7 @@ func (cr *VMAuth) PodAnnotations() map[string]string {
 
 func (cr *VMAuth) AnnotationsFiltered() map[string]string {
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
-	dst := filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes)
+	dst := filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes, cr.Spec.ManagedMetadata)
 	if cr.Spec.ManagedMetadata != nil {
 		if dst == nil {
 			dst = make(map[string]string)
@@ -526,7 +531,7 @@ func (cr *VMAuth) AllLabels() map[string]string {
 	var result map[string]string
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
 	if cr.ObjectMeta.Labels != nil {
-		result = filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes)
+		result = filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes, cr.Spec.ManagedMetadata)
 	}
 	if cr.Spec.ManagedMetadata != nil {
 		result = labels.Merge(result, cr.Spec.ManagedMetadata.Labels)
@@ -549,7 +554,7 @@ func (cr *VMAuth) GetMetricPath() string {
 
 // GetExtraArgs returns additionally configured command-line arguments
 func (cr *VMAuth) GetExtraArgs() map[string]string {
-	return cr.Spec.ExtraArgs
+	return cr.Spec.ExtraArgsWithServerTLS()
 }
 
 // GetServiceScrape returns overrides for serviceScrape builder
@@ -35,20 +35,40 @@ func (r *VMAuth) SetupWebhookWithManager(mgr ctrl.Manager) error {
 		Complete()
 }
 
+// vmauthPrevChecks validates params, which could be already set incorrectly at existing objects
+var vmauthPrevChecks = []func(r *VMAuth) error{
+	func(r *VMAuth) error {
+		if err := checkRollingUpdate(r.Spec.UpdateStrategy, r.Spec.RollingUpdate); err != nil {
+			return fmt.Errorf("incorrect spec.rollingUpdate: %w", err)
+		}
+		return nil
+	},
+}
+
 func (r *VMAuth) sanityCheck() error {
 	if r.Spec.ServiceSpec != nil && r.Spec.ServiceSpec.Name == r.PrefixedName() {
 		return fmt.Errorf("spec.serviceSpec.Name cannot be equal to prefixed name=%q", r.PrefixedName())
 	}
-	if r.Spec.Ingress != nil {
-		// check ingress
-		// TlsHosts and TlsSecretName are both needed if one of them is used
-		ing := r.Spec.Ingress
-		if len(ing.TlsHosts) > 0 && ing.TlsSecretName == "" {
-			return fmt.Errorf("spec.ingress.tlsSecretName cannot be empty with non-empty spec.ingress.tlsHosts")
-		}
-		if ing.TlsSecretName != "" && len(ing.TlsHosts) == 0 {
-			return fmt.Errorf("spec.ingress.tlsHosts cannot be empty with non-empty spec.ingress.tlsSecretName")
-		}
+	if err := checkAdditionalServices(r.Spec.AdditionalServices, r.PrefixedName(), r.Spec.ServiceSpec); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
+	if err := r.Spec.ServerTLS.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.serverTLS: %w", err)
+	}
+	if err := checkExtraArgs(r.Spec.ExtraArgs); err != nil {
+		return err
+	}
+	if err := r.Spec.PodDisruptionBudget.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.podDisruptionBudget: %w", err)
+	}
+	if err := r.Spec.VPA.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.vpa: %w", err)
+	}
+	if err := r.Spec.Ingress.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.ingress: %w", err)
+	}
+	if err := r.Spec.Route.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.route: %w", err)
 	}
 	if r.Spec.ConfigSecret != "" && r.Spec.ExternalConfig.SecretRef != nil {
 		return fmt.Errorf("spec.configSecret and spec.externalConfig.secretRef cannot be used at the same time")
@@ -89,9 +109,41 @@ func (r *VMAuth) sanityCheck() error {
 }
 
 // +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmauth,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmauths,verbs=create;update,versions=v1beta1,name=vvmauth.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-operator-victoriametrics-com-v1beta1-vmauth,mutating=true,failurePolicy=ignore,sideEffects=None,groups=operator.victoriametrics.com,resources=vmauths,verbs=create;update,versions=v1beta1,name=mvmauth.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &VMAuth{}
 
+// extraArgsWarnings returns warnings for unknown and operator managed flags at spec.extraArgs
+func (r *VMAuth) extraArgsWarnings() admission.Warnings {
+	// use spec.externalConfig.localPath instead of auth.config flag
+	return checkExtraArgsFlags("spec.extraArgs", r.Spec.ExtraArgs, vmauthFlags, "auth.config")
+}
+
+// ipFiltersCheck validates spec.ip_filters and spec.unauthorizedUserAccessSpec.ip_filters
+// prev must be nil on object creation.
+func (r *VMAuth) ipFiltersCheck(prev *VMAuth) (admission.Warnings, error) {
+	var prevFilters *VMUserIPFilters
+	if prev != nil {
+		prevFilters = &prev.Spec.IPFilters
+	}
+	warnings, err := r.Spec.IPFilters.validate(prevFilters)
+	if err != nil {
+		return nil, fmt.Errorf("incorrect spec.ip_filters: %w", err)
+	}
+	if r.Spec.UnauthorizedUserAccessSpec != nil {
+		prevFilters = nil
+		if prev != nil && prev.Spec.UnauthorizedUserAccessSpec != nil {
+			prevFilters = &prev.Spec.UnauthorizedUserAccessSpec.IPFilters
+		}
+		uaWarnings, err := r.Spec.UnauthorizedUserAccessSpec.IPFilters.validate(prevFilters)
+		if err != nil {
+			return nil, fmt.Errorf("incorrect spec.unauthorizedUserAccessSpec.ip_filters: %w", err)
+		}
+		warnings = append(warnings, uaWarnings...)
+	}
+	return warnings, nil
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *VMAuth) ValidateCreate() (admission.Warnings, error) {
 	if r.Spec.ParsingError != "" {
@@ -103,7 +155,16 @@ func (r *VMAuth) ValidateCreate() (admission.Warnings, error) {
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	warnings, err := checkWithPrev(r, nil, vmauthPrevChecks...)
+	if err != nil {
+		return nil, err
+	}
+	ipWarnings, err := r.ipFiltersCheck(nil)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, ipWarnings...)
+	return append(warnings, r.extraArgsWarnings()...), nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -117,7 +178,17 @@ func (r *VMAuth) ValidateUpdate(old runtime.Object) (admission.Warnings, error)
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	prev, _ := old.(*VMAuth)
+	warnings, err := checkWithPrev(r, prev, vmauthPrevChecks...)
+	if err != nil {
+		return nil, err
+	}
+	ipWarnings, err := r.ipFiltersCheck(prev)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, ipWarnings...)
+	return append(warnings, r.extraArgsWarnings()...), nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
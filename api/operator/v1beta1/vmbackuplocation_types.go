@@ -0,0 +1,56 @@
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VMBackupLocationSpec defines object storage used for backups
+type VMBackupLocationSpec struct {
+	// Destination defines base url of object storage
+	// supported schemes are s3://, gs:// and azblob://
+	// e.g. s3://bucket/backups
+	// +kubebuilder:validation:Pattern=`^(s3|gs|azblob|fs)://.+`
+	Destination string `json:"destination"`
+	// Custom S3 endpoint for use with S3-compatible storages (e.g. MinIO). S3 is used if not set
+	// +optional
+	CustomS3Endpoint *string `json:"customS3Endpoint,omitempty"`
+	// CredentialsSecret is secret in the same namespace for access to remote storage
+	// The secret is mounted into /etc/vm/creds.
+	// +optional
+	CredentialsSecret *v1.SecretKeySelector `json:"credentialsSecret,omitempty"`
+	// ExtraArgs defines additional flags for vmbackupmanager and vmrestore
+	// e.g. s3StorageClass, s3ForcePathStyle or encryption related flags
+	// flags defined at VMBackup.extraArgs have higher priority
+	// +optional
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+}
+
+// VMBackupLocation describes object storage, which can be referenced by backup settings of VMSingle and VMCluster
+// It allows to define storage credentials once and share it between multiple backup configurations
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=vmbackuplocations,scope=Namespaced
+// +kubebuilder:printcolumn:name="Destination",type="string",JSONPath=".spec.destination"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// VMBackupLocation is the Schema for the vmbackuplocations API
+type VMBackupLocation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VMBackupLocationSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VMBackupLocationList contains a list of VMBackupLocation
+type VMBackupLocationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMBackupLocation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VMBackupLocation{}, &VMBackupLocationList{})
+}
@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/VictoriaMetrics/VictoriaMetrics/lib/flagutil"
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -30,11 +34,24 @@ type VMClusterSpec struct {
 	// distinct storage nodes
 	// +optional
 	ReplicationFactor *int32 `json:"replicationFactor,omitempty"`
+	// DisableAutoTuning disables vminsert and vmselect flags derived by operator
+	// from cluster topology and resources: -maxConcurrentInserts, -search.maxConcurrentRequests and -vmstorageDialTimeout
+	// Flags defined at extraArgs have priority over derived values
+	// +optional
+	DisableAutoTuning bool `json:"disableAutoTuning,omitempty"`
 
 	// ServiceAccountName is the name of the ServiceAccount to use to run the
 	// VMSelect, VMStorage and VMInsert Pods.
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// CloudIdentity configures cloud provider workload identity
+	// (AWS IRSA or GCP Workload Identity) for the generated ServiceAccount
+	// +optional
+	CloudIdentity *CloudIdentity `json:"cloudIdentity,omitempty"`
+	// GrafanaDashboard configures provisioning of the official Grafana dashboard
+	// with grafana-operator GrafanaDashboard object or ConfigMap for grafana sidecar
+	// +optional
+	GrafanaDashboard *GrafanaDashboard `json:"grafanaDashboard,omitempty"`
 
 	// ClusterVersion defines default images tag for all components.
 	// it can be overwritten with component specific image.tag value.
@@ -79,6 +96,9 @@ type VMClusterSpec struct {
 	// it helps to evenly spread load across pods
 	// usually it's not possible with kubernetes TCP based service
 	RequestsLoadBalancer VMAuthLoadBalancer `json:"requestsLoadBalancer,omitempty"`
+	// NetworkPolicy created by operator for vmstorage, vmselect and vminsert
+	// +optional
+	NetworkPolicy *EmbeddedNetworkPolicy `json:"networkPolicy,omitempty"`
 	// ManagedMetadata defines metadata that will be added to the all objects
 	// created by operator for the given CustomResource
 	ManagedMetadata *ManagedObjectsMetadata `json:"managedMetadata,omitempty"`
@@ -177,6 +197,46 @@ type VMClusterStatus struct {
 	StatusMetadata `json:",inline"`
 	// LegacyStatus is deprecated and will be removed at v0.52.0 version
 	LegacyStatus UpdateStatus `json:"clusterStatus,omitempty"`
+	// VMStorageRestore reports progress of restore from spec.vmstorage.restoreFrom
+	// +optional
+	VMStorageRestore *VMRestoreFromStatus `json:"vmStorageRestore,omitempty"`
+	// VMStorageBackupVerification reports state of spec.vmstorage.vmBackup.verification
+	// +optional
+	VMStorageBackupVerification *VMBackupVerificationStatus `json:"vmStorageBackupVerification,omitempty"`
+	// Recommendations contains advisory sizing of cluster components built from cardinality, ingestion and resource usage metrics
+	// it's updated only if resource recommendations are enabled at operator configuration
+	// and it's never applied by operator
+	// +optional
+	Recommendations *VMClusterRecommendations `json:"recommendations,omitempty"`
+}
+
+// VMClusterRecommendations defines advisory sizing of vmstorage nodes
+// it's built from vm_cache_entries and vm_rows_added_to_storage_total metrics of all vmstorage pods
+type VMClusterRecommendations struct {
+	// ActiveTimeSeries is the number of time series with samples for the last hour at all vmstorage nodes
+	// including replicated copies, rounded to 2 significant digits
+	// +optional
+	ActiveTimeSeries int64 `json:"activeTimeSeries,omitempty"`
+	// IngestionRate is the number of samples per second added to all vmstorage nodes
+	// including replicated copies, rounded to 2 significant digits
+	// +optional
+	IngestionRate int64 `json:"ingestionRate,omitempty"`
+	// VMStorageResources defines recommended resources for each vmstorage node
+	// with the current number of replicas
+	// +optional
+	VMStorageResources v1.ResourceRequirements `json:"vmstorageResources,omitempty"`
+	// VMStorageReplicaCount defines recommended number of vmstorage nodes, which fits
+	// the current vmstorage memory limit. It's empty if memory limit isn't defined
+	// +optional
+	VMStorageReplicaCount *int32 `json:"vmstorageReplicaCount,omitempty"`
+	// VMSelectResources defines recommended resources for each vmselect pod
+	// based on the peak CPU and memory usage of running pods
+	// +optional
+	VMSelectResources v1.ResourceRequirements `json:"vmselectResources,omitempty"`
+	// VMInsertResources defines recommended resources for each vminsert pod
+	// based on the peak CPU and memory usage of running pods
+	// +optional
+	VMInsertResources v1.ResourceRequirements `json:"vminsertResources,omitempty"`
 }
 
 // GetStatusMetadata returns metadata for object status
@@ -224,6 +284,16 @@ type VMSelect struct {
 	// its needed for persistent cache
 	// +optional
 	StorageSpec *StorageSpec `json:"storage,omitempty"`
+	// RetainPVCs defines whether PersistentVolumeClaims created for VMSelect are kept after VMCluster deletion.
+	// If not set, PersistentVolumeClaims are removed only if persistentVolumeClaimRetentionPolicy.whenDeleted is Delete.
+	// It takes precedence over persistentVolumeClaimRetentionPolicy.whenDeleted
+	// +optional
+	RetainPVCs *bool `json:"retainPVCs,omitempty"`
+	// DisableDefaultAntiAffinity disables preferred pod anti-affinity,
+	// which is added by operator in order to schedule VMSelect pods on different nodes.
+	// It isn't added if affinity is defined
+	// +optional
+	DisableDefaultAntiAffinity bool `json:"disableDefaultAntiAffinity,omitempty"`
 	// ClusterNativePort for multi-level cluster setup.
 	// More [details](https://docs.victoriametrics.com/Cluster-VictoriaMetrics#multi-level-cluster-setup)
 	// +optional
@@ -232,9 +302,16 @@ type VMSelect struct {
 	// ServiceSpec that will be added to vmselect service spec
 	// +optional
 	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// AdditionalServices defines a list of extra services that will be created for vmselect
+	// in addition to the default one
+	// +optional
+	AdditionalServices []AdditionalServiceSpec `json:"additionalServices,omitempty"`
 	// ServiceScrapeSpec that will be added to vmselect VMServiceScrape spec
 	// +optional
 	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
+	// QueryLogging configures slow queries logging and query execution limits
+	// +optional
+	QueryLogging *QueryLogging `json:"queryLogging,omitempty"`
 	// PodDisruptionBudget created by operator
 	// +optional
 	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
@@ -243,6 +320,15 @@ type VMSelect struct {
 	// Note, enabling this option disables vmselect to vmselect communication. In most cases it's not an issue.
 	// +optional
 	HPA *EmbeddedHPA `json:"hpa,omitempty"`
+	// VPA defines VerticalPodAutoscaler configuration for the application container
+	// +optional
+	VPA *EmbeddedVPA `json:"vpa,omitempty"`
+	// Ingress defines Ingress configuration for external access to vmselect service
+	// +optional
+	Ingress *EmbeddedIngress `json:"ingress,omitempty"`
+	// Route defines OpenShift Route configuration for external access to vmselect service
+	// +optional
+	Route *EmbeddedRoute `json:"route,omitempty"`
 	// RollingUpdateStrategy defines strategy for application updates
 	// Default is OnDelete, in this case operator handles update process
 	// Can be changed for RollingUpdate
@@ -250,6 +336,11 @@ type VMSelect struct {
 	RollingUpdateStrategy appsv1.StatefulSetUpdateStrategyType `json:"rollingUpdateStrategy,omitempty"`
 	// ClaimTemplates allows adding additional VolumeClaimTemplates for StatefulSet
 	ClaimTemplates []v1.PersistentVolumeClaim `json:"claimTemplates,omitempty"`
+	// PersistentVolumeClaimRetentionPolicy controls whether PersistentVolumeClaims created from StatefulSet
+	// VolumeClaimTemplates are deleted on StatefulSet deletion or scale down.
+	// See [PersistentVolumeClaim retention](https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#persistentvolumeclaim-retention)
+	// +optional
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
 
 	CommonDefaultableParams           `json:",inline"`
 	CommonApplicationDeploymentParams `json:",inline"`
@@ -279,6 +370,142 @@ type InsertPorts struct {
 	OpenTSDBPort string `json:"openTSDBPort,omitempty"`
 }
 
+// sanityCheck verifies that insert ports are valid port numbers
+// and don't conflict with each other and with the given http port
+func (ip *InsertPorts) sanityCheck(httpPort string) error {
+	if ip == nil {
+		return nil
+	}
+	usedBy := make(map[string]string)
+	if httpPort != "" {
+		usedBy[httpPort] = "port"
+	}
+	for _, p := range []struct {
+		name  string
+		value string
+	}{
+		{name: "graphitePort", value: ip.GraphitePort},
+		{name: "influxPort", value: ip.InfluxPort},
+		{name: "openTSDBPort", value: ip.OpenTSDBPort},
+		{name: "openTSDBHTTPPort", value: ip.OpenTSDBHTTPPort},
+	} {
+		if p.value == "" {
+			continue
+		}
+		port, err := strconv.Atoi(p.value)
+		if err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("%s=%q must be a port number in range 1-65535", p.name, p.value)
+		}
+		if other, ok := usedBy[p.value]; ok {
+			return fmt.Errorf("%s=%q conflicts with %s", p.name, p.value, other)
+		}
+		usedBy[p.value] = p.name
+	}
+	return nil
+}
+
+// OTLPIngestion configures OpenTelemetry metrics ingestion via OTLP/HTTP protocol.
+// Application accepts OTLP requests at /opentelemetry/v1/metrics path of http port,
+// operator creates dedicated service with otlp-http port for OpenTelemetry collectors.
+type OTLPIngestion struct {
+	// Port defines port of the dedicated OTLP service, 4318 by default
+	// it targets http port of the application
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// MaxRequestSize defines the maximum size in bytes of a single OTLP request, e.g. 64MB
+	// +optional
+	MaxRequestSize string `json:"maxRequestSize,omitempty"`
+	// UsePrometheusNaming converts metric names and labels into Prometheus-compatible format
+	// +optional
+	UsePrometheusNaming bool `json:"usePrometheusNaming,omitempty"`
+}
+
+// GetPort returns port of the dedicated OTLP service
+func (o *OTLPIngestion) GetPort() int32 {
+	if o.Port == 0 {
+		return 4318
+	}
+	return o.Port
+}
+
+// DatadogIngestion configures ingestion of metrics sent by DataDog agent.
+// Application accepts DataDog requests at /datadog path of http port.
+type DatadogIngestion struct {
+	// MaxRequestSize defines the maximum size in bytes of a single DataDog request, e.g. 64MB
+	// +optional
+	MaxRequestSize string `json:"maxRequestSize,omitempty"`
+	// SanitizeMetricName controls sanitizing of metric names according to DataDog rules, enabled by default
+	// +optional
+	SanitizeMetricName *bool `json:"sanitizeMetricName,omitempty"`
+}
+
+// NewRelicIngestion configures ingestion of metrics sent by NewRelic infrastructure agent.
+// Application accepts NewRelic requests at /newrelic path of http port.
+type NewRelicIngestion struct {
+	// MaxRequestSize defines the maximum size in bytes of a single NewRelic request, e.g. 64MB
+	// +optional
+	MaxRequestSize string `json:"maxRequestSize,omitempty"`
+}
+
+// QueryLogging configures logging of slow and memory intensive queries and query execution limits
+type QueryLogging struct {
+	// LogSlowQueryDuration defines query execution time after which query is logged as slow, e.g. 5s.
+	// Zero value disables slow queries logging
+	// +optional
+	LogSlowQueryDuration string `json:"logSlowQueryDuration,omitempty"`
+	// LogQueryMemoryUsage defines memory usage in bytes after which query is logged as memory intensive, e.g. 1GB
+	// +optional
+	LogQueryMemoryUsage string `json:"logQueryMemoryUsage,omitempty"`
+	// MaxQueryDuration defines the maximum duration for query execution, e.g. 30s
+	// +optional
+	MaxQueryDuration string `json:"maxQueryDuration,omitempty"`
+	// MaxQueueDuration defines the maximum time the query waits for execution
+	// when MaxConcurrentRequests limit is reached, e.g. 10s
+	// +optional
+	MaxQueueDuration string `json:"maxQueueDuration,omitempty"`
+	// MaxConcurrentRequests defines the maximum number of concurrently executed queries
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentRequests *int32 `json:"maxConcurrentRequests,omitempty"`
+}
+
+func (ql *QueryLogging) sanityCheck() error {
+	if ql == nil {
+		return nil
+	}
+	for _, d := range []struct {
+		name  string
+		value string
+	}{
+		{name: "logSlowQueryDuration", value: ql.LogSlowQueryDuration},
+		{name: "maxQueryDuration", value: ql.MaxQueryDuration},
+		{name: "maxQueueDuration", value: ql.MaxQueueDuration},
+	} {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil {
+			return fmt.Errorf("cannot parse %s: %w", d.name, err)
+		}
+		if v < 0 {
+			return fmt.Errorf("%s=%q cannot be negative", d.name, d.value)
+		}
+	}
+	if ql.LogQueryMemoryUsage != "" {
+		var b flagutil.Bytes
+		if err := b.Set(ql.LogQueryMemoryUsage); err != nil {
+			return fmt.Errorf("cannot parse logQueryMemoryUsage: %w", err)
+		}
+	}
+	if ql.MaxConcurrentRequests != nil && *ql.MaxConcurrentRequests < 1 {
+		return fmt.Errorf("maxConcurrentRequests must be greater than 0, got: %d", *ql.MaxConcurrentRequests)
+	}
+	return nil
+}
+
 type VMInsert struct {
 	// PodMetadata configures Labels and Annotations which are propagated to the VMInsert pods.
 	PodMetadata *EmbeddedObjectMetadata `json:"podMetadata,omitempty"`
@@ -295,6 +522,16 @@ type VMInsert struct {
 	// InsertPorts - additional listen ports for data ingestion.
 	InsertPorts *InsertPorts `json:"insertPorts,omitempty"`
 
+	// OTLP configures OpenTelemetry metrics ingestion and dedicated OTLP service
+	// +optional
+	OTLP *OTLPIngestion `json:"otlp,omitempty"`
+	// Datadog configures ingestion of metrics sent by DataDog agent
+	// +optional
+	Datadog *DatadogIngestion `json:"datadog,omitempty"`
+	// NewRelic configures ingestion of metrics sent by NewRelic infrastructure agent
+	// +optional
+	NewRelic *NewRelicIngestion `json:"newrelic,omitempty"`
+
 	// ClusterNativePort for multi-level cluster setup.
 	// More [details](https://docs.victoriametrics.com/Cluster-VictoriaMetrics#multi-level-cluster-setup)
 	// +optional
@@ -303,6 +540,10 @@ type VMInsert struct {
 	// ServiceSpec that will be added to vminsert service spec
 	// +optional
 	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// AdditionalServices defines a list of extra services that will be created for vminsert
+	// in addition to the default one
+	// +optional
+	AdditionalServices []AdditionalServiceSpec `json:"additionalServices,omitempty"`
 	// ServiceScrapeSpec that will be added to vminsert VMServiceScrape spec
 	// +optional
 	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
@@ -320,6 +561,9 @@ type VMInsert struct {
 	*EmbeddedProbes     `json:",inline"`
 	// HPA defines kubernetes PodAutoScaling configuration version 2.
 	HPA *EmbeddedHPA `json:"hpa,omitempty"`
+	// VPA defines VerticalPodAutoscaler configuration for the application container
+	// +optional
+	VPA *EmbeddedVPA `json:"vpa,omitempty"`
 
 	CommonDefaultableParams           `json:",inline"`
 	CommonApplicationDeploymentParams `json:",inline"`
@@ -339,7 +583,7 @@ func (cr *VMInsert) ProbePath() string {
 }
 
 func (cr *VMInsert) ProbeScheme() string {
-	return strings.ToUpper(protoFromFlags(cr.ExtraArgs))
+	return strings.ToUpper(protoFromFlags(cr.ExtraArgsWithServerTLS()))
 }
 
 func (cr *VMInsert) ProbePort() string {
@@ -360,10 +604,20 @@ func (cr *VMCluster) GetVMSelectName() string {
 	return prefixedName(cr.Name, "vmselect")
 }
 
+// GetVMInsertOTLPName returns name of vminsert OTLP service
+func (cr *VMCluster) GetVMInsertOTLPName() string {
+	return prefixedName(cr.Name, "vminsert-otlp")
+}
+
 func (cr *VMCluster) GetVMStorageName() string {
 	return prefixedName(cr.Name, "vmstorage")
 }
 
+// GetVMStorageBackupVerificationName returns name of CronJob for vmstorage backup verification
+func (cr *VMCluster) GetVMStorageBackupVerificationName() string {
+	return prefixedName(cr.Name, "vmstorage-backup-verify")
+}
+
 type VMStorage struct {
 	// PodMetadata configures Labels and Annotations which are propagated to the VMStorage pods.
 	PodMetadata *EmbeddedObjectMetadata `json:"podMetadata,omitempty"`
@@ -383,6 +637,16 @@ type VMStorage struct {
 	// its useful for persistent cache
 	// +optional
 	Storage *StorageSpec `json:"storage,omitempty"`
+	// RetainPVCs defines whether PersistentVolumeClaims created for VMStorage are kept after VMCluster deletion.
+	// If not set, PersistentVolumeClaims are removed only if persistentVolumeClaimRetentionPolicy.whenDeleted is Delete.
+	// It takes precedence over persistentVolumeClaimRetentionPolicy.whenDeleted
+	// +optional
+	RetainPVCs *bool `json:"retainPVCs,omitempty"`
+	// DisableDefaultAntiAffinity disables preferred pod anti-affinity,
+	// which is added by operator in order to schedule VMStorage pods on different nodes.
+	// It isn't added if affinity is defined
+	// +optional
+	DisableDefaultAntiAffinity bool `json:"disableDefaultAntiAffinity,omitempty"`
 
 	// VMInsertPort for VMInsert connections
 	// +optional
@@ -395,12 +659,23 @@ type VMStorage struct {
 	// VMBackup configuration for backup
 	// +optional
 	VMBackup *VMBackup `json:"vmBackup,omitempty"`
+	// RestoreFrom defines backup source for restoring storage data with vmrestore init container
+	// restore is performed only once for the given source, progress is reported at status.vmStorageRestore
+	// +optional
+	RestoreFrom *VMRestoreFrom `json:"restoreFrom,omitempty"`
 	// ServiceSpec that will be create additional service for vmstorage
 	// +optional
 	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// AdditionalServices defines a list of extra services that will be created for vmstorage
+	// in addition to the default one
+	// +optional
+	AdditionalServices []AdditionalServiceSpec `json:"additionalServices,omitempty"`
 	// ServiceScrapeSpec that will be added to vmstorage VMServiceScrape spec
 	// +optional
 	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
+	// VPA defines VerticalPodAutoscaler configuration for the application container
+	// +optional
+	VPA *EmbeddedVPA `json:"vpa,omitempty"`
 	// PodDisruptionBudget created by operator
 	// +optional
 	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
@@ -421,6 +696,11 @@ type VMStorage struct {
 
 	// ClaimTemplates allows adding additional VolumeClaimTemplates for StatefulSet
 	ClaimTemplates []v1.PersistentVolumeClaim `json:"claimTemplates,omitempty"`
+	// PersistentVolumeClaimRetentionPolicy controls whether PersistentVolumeClaims created from StatefulSet
+	// VolumeClaimTemplates are deleted on StatefulSet deletion or scale down.
+	// See [PersistentVolumeClaim retention](https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#persistentvolumeclaim-retention)
+	// +optional
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
 
 	CommonDefaultableParams           `json:",inline"`
 	CommonApplicationDeploymentParams `json:",inline"`
@@ -441,7 +721,13 @@ type VMBackup struct {
 	// Defines number of concurrent workers. Higher concurrency may reduce backup duration (default 10)
 	// +optional
 	Concurrency *int32 `json:"concurrency,omitempty"`
+	// BackupLocationName defines name of VMBackupLocation object at the same namespace
+	// it provides destination, endpoint, credentials and extra args for backup
+	// fields defined at VMBackup have higher priority
+	// +optional
+	BackupLocationName string `json:"backupLocationName,omitempty"`
 	// Defines destination for backup
+	// if backupLocationName is set, it may contain relative path, which is appended to the location destination
 	Destination string `json:"destination,omitempty"`
 	// DestinationDisableSuffixAdd - disables suffix adding for cluster version backups
 	// each vmstorage backup must have unique backup folder
@@ -502,6 +788,34 @@ type VMBackup struct {
 	// Read [more](https://docs.victoriametrics.com/vmbackupmanager#restore-commands)
 	// +optional
 	Restore *VMRestore `json:"restore,omitempty"`
+	// Verification defines periodic verification of created backups
+	// operator creates CronJob, which lists backups with vmbackupmanager API
+	// and reports the last successful verification time at the object status
+	// +optional
+	Verification *VMBackupVerification `json:"verification,omitempty"`
+}
+
+// VMBackupVerification defines scheduled verification of backups made by vmbackupmanager
+type VMBackupVerification struct {
+	// Schedule defines verification schedule in cron format, e.g. "0 */6 * * *"
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// ActiveDeadlineSeconds defines duration of verification job, after which it's marked as failed
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// Resources container resource request and limits, https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// VMBackupVerificationStatus defines the observed state of backup verification
+type VMBackupVerificationStatus struct {
+	// LastScheduleTime defines time of the last verification job start
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastSuccessfulTime defines time of the last successful verification
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
 }
 
 func (cr *VMBackup) sanityCheck(l *License) error {
@@ -530,6 +844,56 @@ type VMRestoreOnStartConfig struct {
 	Enabled bool `json:"enabled,omitempty"`
 }
 
+// VMRestoreFrom defines configuration for restoring storage data from the backup with vmrestore
+type VMRestoreFrom struct {
+	// Source defines backup location, e.g. s3://bucket/path, gs://bucket/path or azblob://container/path
+	// +kubebuilder:validation:MinLength=1
+	Source string `json:"source"`
+	// SourceDisableSuffixAdd disables suffix adding for cluster version restore
+	// by default, operator adds POD_NAME as suffix for source folder, same as vmbackupmanager does for destination
+	// +optional
+	SourceDisableSuffixAdd bool `json:"sourceDisableSuffixAdd,omitempty"`
+	// Image - docker image settings for vmrestore
+	// +optional
+	Image Image `json:"image,omitempty"`
+	// Custom S3 endpoint for use with S3-compatible storages (e.g. MinIO). S3 is used if not set
+	// +optional
+	CustomS3Endpoint *string `json:"customS3Endpoint,omitempty"`
+	// CredentialsSecret is secret in the same namespace for access to remote storage
+	// The secret is mounted into /etc/vm/restore-creds.
+	// +optional
+	CredentialsSecret *v1.SecretKeySelector `json:"credentialsSecret,omitempty"`
+	// Defines number of concurrent workers. Higher concurrency may reduce restore duration (default 10)
+	// +optional
+	Concurrency *int32 `json:"concurrency,omitempty"`
+	// Resources container resource request and limits, https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// ExtraArgs passed to vmrestore
+	// +optional
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+	// +optional
+	ExtraEnvs []v1.EnvVar `json:"extraEnvs,omitempty"`
+}
+
+// RestoreStatus defines state of the restore process
+type RestoreStatus string
+
+const (
+	RestoreStatusInProgress RestoreStatus = "InProgress"
+	RestoreStatusCompleted  RestoreStatus = "Completed"
+)
+
+// VMRestoreFromStatus defines restore progress
+type VMRestoreFromStatus struct {
+	// Source defines restored backup location
+	Source string `json:"source"`
+	// Status defines current state of the restore
+	Status RestoreStatus `json:"status"`
+	// LastTransitionTime defines time of the last status change
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
 // GetStorageVolumeName returns formatted name for vmstorage volume
 func (cr *VMStorage) GetStorageVolumeName() string {
 	if cr.Storage != nil && cr.Storage.VolumeClaimTemplate.Name != "" {
@@ -647,7 +1011,7 @@ func (cr *VMCluster) FinalLabels(selectorLabels map[string]string) map[string]st
 	var result map[string]string
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
 	if cr.ObjectMeta.Labels != nil {
-		result = filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes)
+		result = filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes, cr.Spec.ManagedMetadata)
 	}
 	if cr.Spec.ManagedMetadata != nil {
 		result = labels.Merge(result, cr.Spec.ManagedMetadata.Labels)
@@ -682,7 +1046,7 @@ func (cr *VMCluster) VMStoragePodAnnotations() map[string]string {
 // AnnotationsFiltered returns global annotations to be applied by objects generate for vmcluster
 func (cr *VMCluster) AnnotationsFiltered() map[string]string {
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
-	dst := filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes)
+	dst := filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes, cr.Spec.ManagedMetadata)
 	if cr.Spec.ManagedMetadata != nil {
 		if dst == nil {
 			dst = make(map[string]string)
@@ -705,6 +1069,23 @@ func (cr *VMCluster) HasSpecChanges() (bool, error) {
 	return hasStateChanges(cr.ObjectMeta, cr.Spec)
 }
 
+// CheckRetentionDecrease returns RetentionDecreaseError if retentionPeriod is smaller than the last applied one
+// and decrease isn't confirmed with RetentionDecreaseConfirmAnnotation
+func (cr *VMCluster) CheckRetentionDecrease() error {
+	if cr.ParsedLastAppliedSpec == nil {
+		return nil
+	}
+	return checkRetentionDecrease(cr.Annotations, cr.ParsedLastAppliedSpec.retentionPeriod(), cr.Spec.retentionPeriod())
+}
+
+// retentionPeriod returns retentionPeriod with vmstorage extraArgs override
+func (cr *VMClusterSpec) retentionPeriod() string {
+	if cr.VMStorage == nil {
+		return cr.RetentionPeriod
+	}
+	return retentionPeriodWithFlags(cr.RetentionPeriod, cr.VMStorage.ExtraArgs)
+}
+
 func (cr *VMCluster) Paused() bool {
 	return cr.Spec.Paused
 }
@@ -719,7 +1100,7 @@ func (cr *VMSelect) GetMetricPath() string {
 
 // ExtraArgs returns additionally configured command-line arguments
 func (cr *VMSelect) GetExtraArgs() map[string]string {
-	return cr.ExtraArgs
+	return cr.ExtraArgsWithServerTLS()
 }
 
 // ServiceScrape returns overrides for serviceScrape builder
@@ -737,7 +1118,7 @@ func (cr *VMInsert) GetMetricPath() string {
 
 // ExtraArgs returns additionally configured command-line arguments
 func (cr *VMInsert) GetExtraArgs() map[string]string {
-	return cr.ExtraArgs
+	return cr.ExtraArgsWithServerTLS()
 }
 
 // ServiceScrape returns overrides for serviceScrape builder
@@ -755,7 +1136,7 @@ func (cr *VMStorage) GetMetricPath() string {
 
 // ExtraArgs returns additionally configured command-line arguments
 func (cr *VMStorage) GetExtraArgs() map[string]string {
-	return cr.ExtraArgs
+	return cr.ExtraArgsWithServerTLS()
 }
 
 // ServiceScrape returns overrides for serviceScrape builder
@@ -764,13 +1145,15 @@ func (cr *VMStorage) GetServiceScrape() *VMServiceScrapeSpec {
 }
 
 // SnapshotCreatePathWithFlags returns url for accessing vmbackupmanager component
+// extraArgs must include tls flag of serverTLS, see CommonApplicationDeploymentParams.ExtraArgsWithServerTLS
 func (cr *VMBackup) SnapshotCreatePathWithFlags(port string, extraArgs map[string]string) string {
-	return joinBackupAuthKey(fmt.Sprintf("http://localhost:%s%s", port, path.Join(buildPathWithPrefixFlag(extraArgs, snapshotCreate))), extraArgs)
+	return joinBackupAuthKey(fmt.Sprintf("%s://localhost:%s%s", protoFromFlags(extraArgs), port, path.Join(buildPathWithPrefixFlag(extraArgs, snapshotCreate))), extraArgs)
 }
 
 // SnapshotDeletePathWithFlags returns url for accessing vmbackupmanager component
+// extraArgs must include tls flag of serverTLS, see CommonApplicationDeploymentParams.ExtraArgsWithServerTLS
 func (cr *VMBackup) SnapshotDeletePathWithFlags(port string, extraArgs map[string]string) string {
-	return joinBackupAuthKey(fmt.Sprintf("http://localhost:%s%s", port, path.Join(buildPathWithPrefixFlag(extraArgs, snapshotDelete))), extraArgs)
+	return joinBackupAuthKey(fmt.Sprintf("%s://localhost:%s%s", protoFromFlags(extraArgs), port, path.Join(buildPathWithPrefixFlag(extraArgs, snapshotDelete))), extraArgs)
 }
 
 func joinBackupAuthKey(urlPath string, extraArgs map[string]string) string {
@@ -798,6 +1181,16 @@ func (cr *VMCluster) IsOwnsServiceAccount() bool {
 	return cr.Spec.ServiceAccountName == ""
 }
 
+// GetCloudIdentity returns cloud identity configuration for the ServiceAccount
+func (cr *VMCluster) GetCloudIdentity() *CloudIdentity {
+	return cr.Spec.CloudIdentity
+}
+
+// GrafanaDashboardName returns name of the grafana dashboard object
+func (cr *VMCluster) GrafanaDashboardName() string {
+	return fmt.Sprintf("grafana-dashboard-%s", cr.PrefixedName())
+}
+
 // PrefixedName format name of the component with hard-coded prefix
 func (cr *VMCluster) PrefixedName() string {
 	return fmt.Sprintf("vmcluster-%s", cr.Name)
@@ -833,7 +1226,16 @@ func (cr *VMCluster) VMSelectURL() string {
 			}
 		}
 	}
-	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(cr.Spec.VMSelect.ExtraArgs), cr.GetVMSelectName(), cr.Namespace, port)
+	return fmt.Sprintf("%s://%s.%s.svc:%s", cr.serviceProto(&cr.Spec.VMSelect.CommonApplicationDeploymentParams, cr.Spec.RequestsLoadBalancer.DisableSelectBalancing), cr.GetVMSelectName(), cr.Namespace, port)
+}
+
+// serviceProto returns protocol of component service,
+// which is served by requests load-balancer if balancing is enabled for it
+func (cr *VMCluster) serviceProto(component *CommonApplicationDeploymentParams, balancingDisabled bool) string {
+	if cr.Spec.RequestsLoadBalancer.Enabled && !balancingDisabled {
+		return protoFromFlags(cr.Spec.RequestsLoadBalancer.Spec.ExtraArgsWithServerTLS())
+	}
+	return protoFromFlags(component.ExtraArgsWithServerTLS())
 }
 
 func (cr *VMCluster) VMInsertURL() string {
@@ -851,7 +1253,7 @@ func (cr *VMCluster) VMInsertURL() string {
 			}
 		}
 	}
-	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(cr.Spec.VMInsert.ExtraArgs), cr.GetVMInsertName(), cr.Namespace, port)
+	return fmt.Sprintf("%s://%s.%s.svc:%s", cr.serviceProto(&cr.Spec.VMInsert.CommonApplicationDeploymentParams, cr.Spec.RequestsLoadBalancer.DisableInsertBalancing), cr.GetVMInsertName(), cr.Namespace, port)
 }
 
 func (cr *VMCluster) VMStorageURL() string {
@@ -869,7 +1271,7 @@ func (cr *VMCluster) VMStorageURL() string {
 			}
 		}
 	}
-	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(cr.Spec.VMStorage.ExtraArgs), cr.GetVMStorageName(), cr.Namespace, port)
+	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(cr.Spec.VMStorage.ExtraArgsWithServerTLS()), cr.GetVMStorageName(), cr.Namespace, port)
 }
 
 // AsCRDOwner implements interface
@@ -891,7 +1293,7 @@ func (cr *VMSelect) ProbePath() string {
 }
 
 func (cr *VMSelect) ProbeScheme() string {
-	return strings.ToUpper(protoFromFlags(cr.ExtraArgs))
+	return strings.ToUpper(protoFromFlags(cr.ExtraArgsWithServerTLS()))
 }
 
 func (cr *VMSelect) ProbePort() string {
@@ -911,7 +1313,7 @@ func (cr *VMStorage) ProbePath() string {
 }
 
 func (cr *VMStorage) ProbeScheme() string {
-	return strings.ToUpper(protoFromFlags(cr.ExtraArgs))
+	return strings.ToUpper(protoFromFlags(cr.ExtraArgsWithServerTLS()))
 }
 
 func (cr *VMStorage) ProbePort() string {
@@ -931,6 +1333,16 @@ func (cr *VMCluster) SetUpdateStatusTo(ctx context.Context, r client.Client, sta
 	})
 }
 
+// PVCRetentionPolicy returns persistentVolumeClaimRetentionPolicy for VMSelect StatefulSet
+func (cr *VMSelect) PVCRetentionPolicy() *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy {
+	return pvcRetentionPolicy(cr.RetainPVCs, cr.PersistentVolumeClaimRetentionPolicy)
+}
+
+// PVCRetentionPolicy returns persistentVolumeClaimRetentionPolicy for VMStorage StatefulSet
+func (cr *VMStorage) PVCRetentionPolicy() *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy {
+	return pvcRetentionPolicy(cr.RetainPVCs, cr.PersistentVolumeClaimRetentionPolicy)
+}
+
 // GetAdditionalService returns AdditionalServiceSpec settings
 func (cr *VMSelect) GetAdditionalService() *AdditionalServiceSpec {
 	return cr.ServiceSpec
@@ -1012,7 +1424,7 @@ func (cr *VMAuthLoadBalancerSpec) ProbePath() string {
 
 // ProbeScheme returns scheme for probe requests
 func (cr *VMAuthLoadBalancerSpec) ProbeScheme() string {
-	return strings.ToUpper(protoFromFlags(cr.ExtraArgs))
+	return strings.ToUpper(protoFromFlags(cr.ExtraArgsWithServerTLS()))
 }
 
 // GetServiceScrape implements build.serviceScrapeBuilder interface
@@ -1022,7 +1434,7 @@ func (cr *VMAuthLoadBalancerSpec) GetServiceScrape() *VMServiceScrapeSpec {
 
 // GetExtraArgs implements build.serviceScrapeBuilder interface
 func (cr *VMAuthLoadBalancerSpec) GetExtraArgs() map[string]string {
-	return cr.ExtraArgs
+	return cr.ExtraArgsWithServerTLS()
 }
 
 // GetMetricPath implements build.serviceScrapeBuilder interface
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 )
 
 func TestVMBackup_SnapshotDeletePathWithFlags(t *testing.T) {
@@ -85,6 +86,16 @@ func TestVMBackup_SnapshotCreatePathWithFlags(t *testing.T) {
 			},
 			want: "http://localhost:8429/prefix/custom/snapshot/create?authKey=some-auth-key",
 		},
+		{
+			name: "with tls",
+			args: args{
+				port: "8429",
+				extraArgs: map[string]string{
+					"tls": "true",
+				},
+			},
+			want: "https://localhost:8429/snapshot/create",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -94,3 +105,25 @@ func TestVMBackup_SnapshotCreatePathWithFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryLoggingSanityCheck(t *testing.T) {
+	f := func(ql *QueryLogging, wantErr bool) {
+		t.Helper()
+		if err := ql.sanityCheck(); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	f(nil, false)
+	f(&QueryLogging{}, false)
+	f(&QueryLogging{
+		LogSlowQueryDuration:  "0s",
+		LogQueryMemoryUsage:   "1GB",
+		MaxQueryDuration:      "1m",
+		MaxQueueDuration:      "10s",
+		MaxConcurrentRequests: ptr.To[int32](16),
+	}, false)
+	f(&QueryLogging{LogSlowQueryDuration: "5"}, true)
+	f(&QueryLogging{MaxQueryDuration: "-30s"}, true)
+	f(&QueryLogging{LogQueryMemoryUsage: "1 apple"}, true)
+	f(&QueryLogging{MaxConcurrentRequests: ptr.To[int32](0)}, true)
+}
@@ -18,7 +18,11 @@ package v1beta1
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/VictoriaMetrics/VictoriaMetrics/lib/flagutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -37,15 +41,125 @@ func (r *VMCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 // +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmclusters,verbs=create;update,versions=v1beta1,name=vvmcluster.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-operator-victoriametrics-com-v1beta1-vmcluster,mutating=true,failurePolicy=ignore,sideEffects=None,groups=operator.victoriametrics.com,resources=vmclusters,verbs=create;update,versions=v1beta1,name=mvmcluster.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &VMCluster{}
 
+// vmclusterSizingChecks validates storage sizing params
+// these checks were added after objects could be already created with incorrect values
+var vmclusterSizingChecks = []func(r *VMCluster) error{
+	func(r *VMCluster) error {
+		if err := checkRetentionPeriod(r.Spec.RetentionPeriod); err != nil {
+			return fmt.Errorf("incorrect spec.retentionPeriod: %w", err)
+		}
+		return nil
+	},
+	func(r *VMCluster) error {
+		rf := r.Spec.ReplicationFactor
+		if rf == nil {
+			return nil
+		}
+		if *rf < 1 {
+			return fmt.Errorf("spec.replicationFactor=%d must be greater than 0", *rf)
+		}
+		if r.Spec.VMStorage != nil && r.Spec.VMStorage.ReplicaCount != nil && *rf > *r.Spec.VMStorage.ReplicaCount {
+			return fmt.Errorf("spec.replicationFactor=%d cannot exceed spec.vmstorage.replicaCount=%d", *rf, *r.Spec.VMStorage.ReplicaCount)
+		}
+		return nil
+	},
+}
+
+// sizingCheck validates storage sizing params
+// prev must be nil on object creation.
+// Errors are returned as warnings, if prev object has the same problem,
+// it allows to update existing objects created before validation was added
+func (r *VMCluster) sizingCheck(prev *VMCluster) (admission.Warnings, error) {
+	return checkWithPrev(r, prev, vmclusterSizingChecks...)
+}
+
+// vmclusterPrevChecks validates params, which could be already set incorrectly at existing objects
+var vmclusterPrevChecks = []func(r *VMCluster) error{
+	func(r *VMCluster) error {
+		if r.Spec.VMInsert == nil {
+			return nil
+		}
+		if err := checkRollingUpdate(r.Spec.VMInsert.UpdateStrategy, r.Spec.VMInsert.RollingUpdate); err != nil {
+			return fmt.Errorf("incorrect spec.vminsert.rollingUpdate: %w", err)
+		}
+		return nil
+	},
+	func(r *VMCluster) error {
+		if r.Spec.VMInsert == nil {
+			return nil
+		}
+		if err := r.Spec.VMInsert.InsertPorts.sanityCheck(r.Spec.VMInsert.Port); err != nil {
+			return fmt.Errorf("incorrect spec.vminsert.insertPorts: %w", err)
+		}
+		return nil
+	},
+	func(r *VMCluster) error {
+		if r.Spec.VMSelect == nil {
+			return nil
+		}
+		if err := checkClaimTemplates(r.Spec.VMSelect.ClaimTemplates, r.Spec.VMSelect.GetCacheMountVolumeName()); err != nil {
+			return fmt.Errorf("incorrect spec.vmselect.claimTemplates: %w", err)
+		}
+		return nil
+	},
+	func(r *VMCluster) error {
+		if r.Spec.VMStorage == nil {
+			return nil
+		}
+		if err := checkClaimTemplates(r.Spec.VMStorage.ClaimTemplates, r.Spec.VMStorage.GetStorageVolumeName()); err != nil {
+			return fmt.Errorf("incorrect spec.vmstorage.claimTemplates: %w", err)
+		}
+		return nil
+	},
+}
+
 func (r *VMCluster) sanityCheck() error {
+	var backup *VMBackup
+	if r.Spec.VMStorage != nil {
+		backup = r.Spec.VMStorage.VMBackup
+	}
+	if err := r.Spec.CloudIdentity.sanityCheck(r.Spec.ServiceAccountName, backup); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
+	if err := r.Spec.GrafanaDashboard.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
 	if r.Spec.VMSelect != nil {
 		vms := r.Spec.VMSelect
+		if err := checkExtraArgs(vms.ExtraArgs); err != nil {
+			return fmt.Errorf("incorrect spec.vmselect: %w", err)
+		}
+		if err := vms.PodDisruptionBudget.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vmselect.podDisruptionBudget: %w", err)
+		}
+		if err := vms.VPA.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vmselect.vpa: %w", err)
+		}
+		if err := vms.QueryLogging.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vmselect.queryLogging: %w", err)
+		}
+		if err := vms.Ingress.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vmselect.ingress: %w", err)
+		}
+		if err := vms.Route.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vmselect.route: %w", err)
+		}
+		if vms.HPA != nil && vms.VPA.IsActive() {
+			return fmt.Errorf("spec.vmselect.vpa conflicts with spec.vmselect.hpa, use vpa with updateMode: Off or remove hpa")
+		}
 		if vms.ServiceSpec != nil && vms.ServiceSpec.Name == r.GetVMSelectName() {
 			return fmt.Errorf(".serviceSpec.Name cannot be equal to prefixed name=%q", r.GetVMSelectName())
 		}
+		if err := checkAdditionalServices(vms.AdditionalServices, r.GetVMSelectName(), vms.ServiceSpec); err != nil {
+			return fmt.Errorf("incorrect spec.vmselect: %w", err)
+		}
+		if err := vms.ServerTLS.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vmselect.serverTLS: %w", err)
+		}
 		if vms.HPA != nil {
 			if err := vms.HPA.sanityCheck(); err != nil {
 				return err
@@ -57,9 +171,27 @@ func (r *VMCluster) sanityCheck() error {
 	}
 	if r.Spec.VMInsert != nil {
 		vmi := r.Spec.VMInsert
+		if err := checkExtraArgs(vmi.ExtraArgs); err != nil {
+			return fmt.Errorf("incorrect spec.vminsert: %w", err)
+		}
+		if err := vmi.PodDisruptionBudget.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vminsert.podDisruptionBudget: %w", err)
+		}
+		if err := vmi.VPA.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vminsert.vpa: %w", err)
+		}
+		if vmi.HPA != nil && vmi.VPA.IsActive() {
+			return fmt.Errorf("spec.vminsert.vpa conflicts with spec.vminsert.hpa, use vpa with updateMode: Off or remove hpa")
+		}
 		if vmi.ServiceSpec != nil && vmi.ServiceSpec.Name == r.GetVMInsertName() {
 			return fmt.Errorf(".serviceSpec.Name cannot be equal to prefixed name=%q", r.GetVMInsertName())
 		}
+		if err := checkAdditionalServices(vmi.AdditionalServices, r.GetVMInsertName(), vmi.ServiceSpec); err != nil {
+			return fmt.Errorf("incorrect spec.vminsert: %w", err)
+		}
+		if err := vmi.ServerTLS.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vminsert.serverTLS: %w", err)
+		}
 		if vmi.HPA != nil {
 			if err := vmi.HPA.sanityCheck(); err != nil {
 				return err
@@ -68,9 +200,24 @@ func (r *VMCluster) sanityCheck() error {
 	}
 	if r.Spec.VMStorage != nil {
 		vms := r.Spec.VMStorage
+		if err := checkExtraArgs(vms.ExtraArgs); err != nil {
+			return fmt.Errorf("incorrect spec.vmstorage: %w", err)
+		}
+		if err := vms.PodDisruptionBudget.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vmstorage.podDisruptionBudget: %w", err)
+		}
+		if err := vms.VPA.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vmstorage.vpa: %w", err)
+		}
 		if vms.ServiceSpec != nil && vms.ServiceSpec.Name == r.GetVMInsertName() {
 			return fmt.Errorf(".serviceSpec.Name cannot be equal to prefixed name=%q", r.GetVMStorageName())
 		}
+		if err := checkAdditionalServices(vms.AdditionalServices, r.GetVMStorageName(), vms.ServiceSpec); err != nil {
+			return fmt.Errorf("incorrect spec.vmstorage: %w", err)
+		}
+		if err := vms.ServerTLS.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vmstorage.serverTLS: %w", err)
+		}
 		if r.Spec.VMStorage.VMBackup != nil {
 			if err := r.Spec.VMStorage.VMBackup.sanityCheck(r.Spec.License); err != nil {
 				return err
@@ -82,11 +229,105 @@ func (r *VMCluster) sanityCheck() error {
 		if rlb.AdditionalServiceSpec != nil && rlb.AdditionalServiceSpec.Name == r.GetVMAuthLBName() {
 			return fmt.Errorf(".serviceSpec.Name cannot be equal to prefixed name=%q", r.GetVMAuthLBName())
 		}
+		if err := rlb.ServerTLS.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.requestsLoadBalancer.spec.serverTLS: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// sanityWarnings returns warnings for risky, but legal configuration
+// prev must be nil on object creation
+func (r *VMCluster) sanityWarnings(prev *VMCluster) admission.Warnings {
+	var warnings admission.Warnings
+	if r.Spec.VMSelect != nil {
+		warnings = append(warnings, checkExtraArgsFlags("spec.vmselect.extraArgs", r.Spec.VMSelect.ExtraArgs, vmselectFlags)...)
+	}
+	if r.Spec.VMInsert != nil {
+		warnings = append(warnings, checkExtraArgsFlags("spec.vminsert.extraArgs", r.Spec.VMInsert.ExtraArgs, vminsertFlags)...)
+	}
+	if r.Spec.VMStorage != nil {
+		warnings = append(warnings, checkExtraArgsFlags("spec.vmstorage.extraArgs", r.Spec.VMStorage.ExtraArgs, vmstorageFlags)...)
+	}
+	var storageReplicas int32
+	if r.Spec.VMStorage != nil && r.Spec.VMStorage.ReplicaCount != nil {
+		storageReplicas = *r.Spec.VMStorage.ReplicaCount
+	}
+	if rf := r.Spec.ReplicationFactor; rf != nil && *rf > 1 && storageReplicas > 0 && storageReplicas < 2**rf-1 {
+		warnings = append(warnings, fmt.Sprintf("spec.vmstorage.replicaCount=%d is less than 2*replicationFactor-1=%d, "+
+			"cluster cannot keep replicationFactor=%d if %d vmstorage nodes are unavailable", storageReplicas, 2**rf-1, *rf, *rf-1))
+	}
+	if r.Spec.VMSelect != nil && r.Spec.VMStorage != nil {
+		selectDedup, storageDedup := r.dedupInterval(r.Spec.VMSelect.ExtraArgs), r.dedupInterval(r.Spec.VMStorage.ExtraArgs)
+		if selectDedup != storageDedup {
+			warnings = append(warnings, fmt.Sprintf("dedup.minScrapeInterval=%q of vmselect differs from dedup.minScrapeInterval=%q of vmstorage, "+
+				"it's recommended to use the same value, which is equal to scrape interval", selectDedup, storageDedup))
+		}
+	}
+	if prev != nil {
+		if err := checkRetentionDecrease(r.Annotations, prev.Spec.retentionPeriod(), r.Spec.retentionPeriod()); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s, operator doesn't apply changes until confirmation", err))
+		}
+	}
+	if prev == nil || prev.Spec.VMStorage == nil || r.Spec.VMStorage == nil {
+		return warnings
+	}
+	var prevStorageReplicas int32
+	if prev.Spec.VMStorage.ReplicaCount != nil {
+		prevStorageReplicas = *prev.Spec.VMStorage.ReplicaCount
+	}
+	if storageReplicas < prevStorageReplicas {
+		warnings = append(warnings, fmt.Sprintf("spec.vmstorage.replicaCount is decreased from %d to %d, "+
+			"data stored at removed vmstorage nodes will be unavailable for queries", prevStorageReplicas, storageReplicas))
+	}
+	prevSize, newSize := storageRequest(prev.Spec.VMStorage.Storage), storageRequest(r.Spec.VMStorage.Storage)
+	if prevSize != nil && newSize != nil && newSize.Cmp(*prevSize) < 0 {
+		warnings = append(warnings, fmt.Sprintf("spec.vmstorage.storage size is decreased from %s to %s, "+
+			"size of existing PersistentVolumeClaims cannot be decreased and will not be changed", prevSize.String(), newSize.String()))
+	}
+	return warnings
+}
+
+// dedupInterval returns dedup.minScrapeInterval used by vmselect or vmstorage with given extraArgs
+func (r *VMCluster) dedupInterval(extraArgs map[string]string) string {
+	if v, ok := extraArgs["dedup.minScrapeInterval"]; ok {
+		return v
+	}
+	if r.Spec.ReplicationFactor != nil && *r.Spec.ReplicationFactor > 1 {
+		// added by operator
+		return "1ms"
+	}
+	return ""
+}
+
+func storageRequest(ss *StorageSpec) *resource.Quantity {
+	if ss == nil {
+		return nil
+	}
+	size, ok := ss.VolumeClaimTemplate.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok {
+		return nil
+	}
+	return &size
+}
+
+// checkRetentionPeriod validates retentionPeriod in the same way as VictoriaMetrics does
+// it must be either number of months or duration with suffix
+func checkRetentionPeriod(value string) error {
+	if value == "" {
+		return nil
+	}
+	var d flagutil.RetentionDuration
+	if err := d.Set(value); err != nil {
+		return fmt.Errorf("cannot parse retention period=%q: %w", value, err)
+	}
+	if d.Milliseconds() < (24 * time.Hour).Milliseconds() {
+		return fmt.Errorf("retention period=%q cannot be smaller than a day", value)
+	}
+	return nil
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *VMCluster) ValidateCreate() (admission.Warnings, error) {
 	if r.Spec.ParsingError != "" {
@@ -98,7 +339,16 @@ func (r *VMCluster) ValidateCreate() (admission.Warnings, error) {
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	warnings, err := r.sizingCheck(nil)
+	if err != nil {
+		return nil, err
+	}
+	prevWarnings, err := checkWithPrev(r, nil, vmclusterPrevChecks...)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, prevWarnings...)
+	return append(warnings, r.sanityWarnings(nil)...), nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -112,7 +362,17 @@ func (r *VMCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, erro
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	prev, _ := old.(*VMCluster)
+	warnings, err := r.sizingCheck(prev)
+	if err != nil {
+		return nil, err
+	}
+	prevWarnings, err := checkWithPrev(r, prev, vmclusterPrevChecks...)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, prevWarnings...)
+	return append(warnings, r.sanityWarnings(prev)...), nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
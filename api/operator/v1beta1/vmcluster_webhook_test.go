@@ -17,31 +17,179 @@ limitations under the License.
 package v1beta1
 
 import (
-	. "github.com/onsi/ginkgo/v2"
-)
-
-var _ = Describe("VMCluster Webhook", func() {
-
-	Context("When creating VMCluster under Validating Webhook", func() {
-		It("Should deny if a required field is empty", func() {
-
-			// TODO(user): Add your logic here
-
-		})
-
-		It("Should admit if all required fields are provided", func() {
+	"testing"
 
-			// TODO(user): Add your logic here
-
-		})
-	})
-
-	Context("When creating VMCluster under Conversion Webhook", func() {
-		It("Should get the converted version of VMCluster", func() {
-
-			// TODO(user): Add your logic here
-
-		})
-	})
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
 
-})
+func TestVMCluster_sizingCheck(t *testing.T) {
+	f := func(spec VMClusterSpec, prevSpec *VMClusterSpec, wantWarnings int, wantErr bool) {
+		t.Helper()
+		cr := &VMCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+			Spec:       spec,
+		}
+		var prev *VMCluster
+		if prevSpec != nil {
+			prev = &VMCluster{Spec: *prevSpec}
+		}
+		got, err := cr.sizingCheck(prev)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		assert.Len(t, got, wantWarnings, "unexpected warnings: %v", got)
+	}
+	storage := func(replicas int32) *VMStorage {
+		return &VMStorage{CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{ReplicaCount: ptr.To(replicas)}}
+	}
+
+	// empty spec
+	f(VMClusterSpec{}, nil, 0, false)
+
+	// retention in months
+	f(VMClusterSpec{RetentionPeriod: "3"}, nil, 0, false)
+
+	// retention with duration suffix
+	f(VMClusterSpec{RetentionPeriod: "2w"}, nil, 0, false)
+	f(VMClusterSpec{RetentionPeriod: "1y6d"}, nil, 0, false)
+
+	// retention with ambiguous months suffix
+	f(VMClusterSpec{RetentionPeriod: "3m"}, nil, 0, true)
+
+	// retention is too small
+	f(VMClusterSpec{RetentionPeriod: "12h"}, nil, 0, true)
+
+	// retention with unknown suffix
+	f(VMClusterSpec{RetentionPeriod: "1month"}, nil, 0, true)
+
+	// negative retention
+	f(VMClusterSpec{RetentionPeriod: "-1"}, nil, 0, true)
+
+	// replicationFactor fits vmstorage replicas
+	f(VMClusterSpec{ReplicationFactor: ptr.To[int32](2), VMStorage: storage(3)}, nil, 0, false)
+
+	// replicationFactor exceeds vmstorage replicas
+	f(VMClusterSpec{ReplicationFactor: ptr.To[int32](3), VMStorage: storage(2)}, nil, 0, true)
+
+	// zero replicationFactor
+	f(VMClusterSpec{ReplicationFactor: ptr.To[int32](0)}, nil, 0, true)
+
+	// existing object with the same problems is updated with warnings
+	f(VMClusterSpec{RetentionPeriod: "3m", ReplicationFactor: ptr.To[int32](3), VMStorage: storage(1)},
+		&VMClusterSpec{RetentionPeriod: "12h", ReplicationFactor: ptr.To[int32](3), VMStorage: storage(2)}, 2, false)
+
+	// problem introduced by update
+	f(VMClusterSpec{RetentionPeriod: "3m", ReplicationFactor: ptr.To[int32](3), VMStorage: storage(2)},
+		&VMClusterSpec{RetentionPeriod: "3", ReplicationFactor: ptr.To[int32](3), VMStorage: storage(2)}, 0, true)
+}
+
+func TestVMCluster_prevChecks(t *testing.T) {
+	f := func(spec VMClusterSpec, prevSpec *VMClusterSpec, wantWarnings int, wantErr bool) {
+		t.Helper()
+		cr := &VMCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+			Spec:       spec,
+		}
+		var prev *VMCluster
+		if prevSpec != nil {
+			prev = &VMCluster{Spec: *prevSpec}
+		}
+		got, err := checkWithPrev(cr, prev, vmclusterPrevChecks...)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		assert.Len(t, got, wantWarnings, "unexpected warnings: %v", got)
+	}
+	storage := func(claims ...string) *VMStorage {
+		vms := &VMStorage{}
+		for _, name := range claims {
+			vms.ClaimTemplates = append(vms.ClaimTemplates, corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		}
+		return vms
+	}
+
+	// empty spec
+	f(VMClusterSpec{}, nil, 0, false)
+
+	// extra claim template
+	f(VMClusterSpec{VMStorage: storage("extra")}, nil, 0, false)
+
+	// claim template with reserved name
+	f(VMClusterSpec{VMStorage: storage("vmstorage-db")}, nil, 0, true)
+
+	// existing object already uses reserved name
+	f(VMClusterSpec{VMStorage: storage("vmstorage-db", "extra")}, &VMClusterSpec{VMStorage: storage("vmstorage-db")}, 1, false)
+
+	// reserved name introduced by update
+	f(VMClusterSpec{VMStorage: storage("vmstorage-db")}, &VMClusterSpec{VMStorage: storage("extra")}, 0, true)
+}
+
+func TestVMCluster_sanityWarnings(t *testing.T) {
+	f := func(spec VMClusterSpec, prevSpec *VMClusterSpec, wantWarnings int) {
+		t.Helper()
+		cr := &VMCluster{Spec: spec}
+		var prev *VMCluster
+		if prevSpec != nil {
+			prev = &VMCluster{Spec: *prevSpec}
+		}
+		got := cr.sanityWarnings(prev)
+		assert.Len(t, got, wantWarnings, "unexpected warnings: %v", got)
+	}
+	storage := func(replicas int32, size string) *VMStorage {
+		s := &VMStorage{CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{ReplicaCount: ptr.To(replicas)}}
+		if size != "" {
+			s.Storage = &StorageSpec{VolumeClaimTemplate: EmbeddedPersistentVolumeClaim{
+				Spec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+					},
+				},
+			}}
+		}
+		return s
+	}
+
+	// no warnings
+	f(VMClusterSpec{
+		ReplicationFactor: ptr.To[int32](2),
+		VMStorage:         storage(3, "10Gi"),
+		VMSelect:          &VMSelect{},
+	}, nil, 0)
+
+	// not enough vmstorage replicas to tolerate failures
+	f(VMClusterSpec{
+		ReplicationFactor: ptr.To[int32](2),
+		VMStorage:         storage(2, ""),
+	}, nil, 1)
+
+	// different dedup intervals
+	f(VMClusterSpec{
+		VMStorage: storage(1, ""),
+		VMSelect: &VMSelect{CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+			ExtraArgs: map[string]string{"dedup.minScrapeInterval": "30s"},
+		}},
+	}, nil, 1)
+
+	// same dedup intervals
+	f(VMClusterSpec{
+		VMStorage: &VMStorage{CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+			ExtraArgs: map[string]string{"dedup.minScrapeInterval": "30s"},
+		}},
+		VMSelect: &VMSelect{CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+			ExtraArgs: map[string]string{"dedup.minScrapeInterval": "30s"},
+		}},
+	}, nil, 0)
+
+	// storage size and replicas decreased
+	f(VMClusterSpec{VMStorage: storage(2, "5Gi")}, &VMClusterSpec{VMStorage: storage(3, "10Gi")}, 2)
+
+	// storage size increased
+	f(VMClusterSpec{VMStorage: storage(3, "20Gi")}, &VMClusterSpec{VMStorage: storage(3, "10Gi")}, 0)
+
+	// retention decreased
+	f(VMClusterSpec{RetentionPeriod: "2w"}, &VMClusterSpec{RetentionPeriod: "1"}, 1)
+}
@@ -0,0 +1,248 @@
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DataMigrationModeRemoteRead migrates data from Prometheus or Thanos with remote read protocol
+	DataMigrationModeRemoteRead = "remote-read"
+	// DataMigrationModeInflux migrates data from InfluxDB
+	DataMigrationModeInflux = "influx"
+	// DataMigrationModeVMNative migrates data from another VictoriaMetrics with native protocol
+	DataMigrationModeVMNative = "vm-native"
+)
+
+// VMDataMigrationSpec defines the desired state of VMDataMigration
+// +k8s:openapi-gen=true
+type VMDataMigrationSpec struct {
+	// ParsingError contents error with context if operator was failed to parse json object from kubernetes api server
+	ParsingError string `json:"-" yaml:"-"`
+	// Mode defines vmctl migration mode
+	// remote-read - migrates data from Prometheus or Thanos with remote read protocol
+	// influx - migrates data from InfluxDB
+	// vm-native - migrates data from another VictoriaMetrics instance with native protocol
+	// +kubebuilder:validation:Enum=remote-read;influx;vm-native
+	Mode string `json:"mode"`
+	// Source defines data source for migration
+	Source VMDataMigrationSource `json:"source"`
+	// DestinationRef defines VMSingle or VMCluster, which receives migrated data
+	DestinationRef VMDataMigrationDestinationRef `json:"destinationRef"`
+	// TimeStart defines start of migrated time range in RFC3339 format, e.g. 2024-01-01T00:00:00Z
+	// it's required for remote-read and vm-native modes
+	// +optional
+	TimeStart string `json:"timeStart,omitempty"`
+	// TimeEnd defines end of migrated time range in RFC3339 format
+	// current time is used by default
+	// +optional
+	TimeEnd string `json:"timeEnd,omitempty"`
+	// Match defines series selector for vm-native mode, e.g. {job="node-exporter"}
+	// all series are migrated by default
+	// +optional
+	Match string `json:"match,omitempty"`
+	// RateLimit defines limit of bytes per second for data transfer
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RateLimit *int64 `json:"rateLimit,omitempty"`
+	// Concurrency defines number of concurrent import workers
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Concurrency *int32 `json:"concurrency,omitempty"`
+	// Image - docker image settings for vmctl
+	// +optional
+	Image Image `json:"image,omitempty"`
+	// Resources container resource request and limits, https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// ExtraArgs defines additional vmctl flags, e.g. remote-read-step-interval: hour
+	// +optional
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+	// ExtraEnvs defines additional environment variables for vmctl container
+	// +optional
+	ExtraEnvs []v1.EnvVar `json:"extraEnvs,omitempty"`
+	// Paused If set to true all actions on the underlying managed objects are not
+	// going to be performed, except for delete actions.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// VMDataMigrationSource defines data source for migration
+type VMDataMigrationSource struct {
+	// URL defines address of the source, e.g.
+	// http://prometheus:9090 for remote-read mode,
+	// http://influxdb:8086 for influx mode,
+	// http://vmselect:8481/select/0/prometheus for vm-native mode
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+	// Database defines InfluxDB database name, it's required for influx mode
+	// +optional
+	Database string `json:"database,omitempty"`
+	// BasicAuth allow an endpoint to authenticate over basic authentication
+	// +optional
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+}
+
+// VMDataMigrationDestinationRef references storage object for migrated data
+type VMDataMigrationDestinationRef struct {
+	// Kind of the storage object
+	// +kubebuilder:validation:Enum=VMSingle;VMCluster
+	Kind string `json:"kind"`
+	// Name of the storage object at the same namespace
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// TenantID defines tenant for VMCluster, 0 by default
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+}
+
+// DataMigrationPhase defines state of data migration
+type DataMigrationPhase string
+
+const (
+	DataMigrationPhasePending   DataMigrationPhase = "Pending"
+	DataMigrationPhaseRunning   DataMigrationPhase = "Running"
+	DataMigrationPhaseSucceeded DataMigrationPhase = "Succeeded"
+	DataMigrationPhaseFailed    DataMigrationPhase = "Failed"
+)
+
+// VMDataMigrationStatus defines the observed state of VMDataMigration
+type VMDataMigrationStatus struct {
+	// Phase defines state of migration job
+	// +optional
+	Phase DataMigrationPhase `json:"phase,omitempty"`
+	// StartTime defines time of migration job start
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime defines time of migration job completion
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	StatusMetadata `json:",inline"`
+}
+
+// GetStatusMetadata returns metadata for object status
+func (cr *VMDataMigrationStatus) GetStatusMetadata() *StatusMetadata {
+	return &cr.StatusMetadata
+}
+
+// VMDataMigration migrates data into VMSingle or VMCluster with vmctl
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="VMDataMigration"
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmdatamigrations,scope=Namespaced
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode"
+// +kubebuilder:printcolumn:name="Destination",type="string",JSONPath=".spec.destinationRef.name"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current state of migration"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus",description="Current status of update rollout"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// VMDataMigration is the Schema for the vmdatamigrations API
+type VMDataMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VMDataMigrationSpec `json:"spec,omitempty"`
+	// ParsedLastAppliedSpec contains last-applied configuration spec
+	ParsedLastAppliedSpec *VMDataMigrationSpec `json:"-" yaml:"-"`
+
+	Status VMDataMigrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VMDataMigrationList contains a list of VMDataMigration
+type VMDataMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMDataMigration `json:"items"`
+}
+
+// PrefixedName returns name of migration job
+func (cr *VMDataMigration) PrefixedName() string {
+	return prefixedName(cr.Name, "vmdatamigration")
+}
+
+// SelectorLabels returns selector labels for migration job pods
+func (cr *VMDataMigration) SelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vmdatamigration",
+		"app.kubernetes.io/instance":  cr.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+// AsOwner returns owner references with current object as owner
+func (cr *VMDataMigration) AsOwner() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         cr.APIVersion,
+			Kind:               cr.Kind,
+			Name:               cr.Name,
+			UID:                cr.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+func (cr *VMDataMigration) setLastSpec(prevSpec VMDataMigrationSpec) {
+	cr.ParsedLastAppliedSpec = &prevSpec
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMDataMigration) UnmarshalJSON(src []byte) error {
+	type pcr VMDataMigration
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		return err
+	}
+	if err := parseLastAppliedState(cr); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMDataMigrationSpec) UnmarshalJSON(src []byte) error {
+	type pcr VMDataMigrationSpec
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		cr.ParsingError = fmt.Sprintf("cannot parse vmdatamigration spec: %s, err: %s", string(src), err)
+		return nil
+	}
+	return nil
+}
+
+// LastAppliedSpecAsPatch return last applied vmdatamigration spec as patch annotation
+func (cr *VMDataMigration) LastAppliedSpecAsPatch() (client.Patch, error) {
+	return lastAppliedChangesAsPatch(cr.ObjectMeta, cr.Spec)
+}
+
+// HasSpecChanges compares vmdatamigration spec with last applied vmdatamigration spec stored in annotation
+func (cr *VMDataMigration) HasSpecChanges() (bool, error) {
+	return hasStateChanges(cr.ObjectMeta, cr.Spec)
+}
+
+func (cr *VMDataMigration) Paused() bool {
+	return cr.Spec.Paused
+}
+
+// SetUpdateStatusTo changes update status with optional reason of fail
+func (cr *VMDataMigration) SetUpdateStatusTo(ctx context.Context, c client.Client, status UpdateStatus, maybeErr error) error {
+	return updateObjectStatus(ctx, c, &patchStatusOpts[*VMDataMigration, *VMDataMigrationStatus]{
+		actualStatus: status,
+		cr:           cr,
+		crStatus:     &cr.Status,
+		maybeErr:     maybeErr,
+	})
+}
+
+func init() {
+	SchemeBuilder.Register(&VMDataMigration{}, &VMDataMigrationList{})
+}
@@ -0,0 +1,141 @@
+package v1beta1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// knownFlags holds flag names supported by the application
+// names ending with '.' or '*' match all flags with the given prefix
+//
+// lists are not exhaustive and cover flags of the latest application releases,
+// unknown flags produce admission warnings only
+type knownFlags []string
+
+func (kf knownFlags) has(name string) bool {
+	for _, known := range kf {
+		switch {
+		case strings.HasSuffix(known, "*"):
+			if strings.HasPrefix(name, strings.TrimSuffix(known, "*")) {
+				return true
+			}
+		case strings.HasSuffix(known, "."):
+			if strings.HasPrefix(name, known) {
+				return true
+			}
+		case name == known:
+			return true
+		}
+	}
+	return false
+}
+
+// commonVMFlags are supported by all VictoriaMetrics and VictoriaLogs applications
+var commonVMFlags = knownFlags{
+	"blockcache.", "configAuthKey", "dryRun", "enableTCP6", "envflag.", "eula", "filestream.", "flagsAuthKey", "fs.",
+	"http*", "internStringCacheExpireDuration", "internStringDisableCache", "internStringMaxLen", "license*",
+	"logger*", "memory.", "metrics*", "mtls*", "pprofAuthKey", "pushmetrics.", "reloadAuthKey", "s3.", "secret.",
+	"tls*", "version",
+}
+
+var vmIngestFlags = knownFlags{
+	"csvTrimTimestamp", "datadog.", "graphite*", "import.", "influx*", "insert.", "maxConcurrentInserts",
+	"maxInsertRequestSize", "maxLabelValueLen", "maxLabelsPerTimeseries", "newrelic.", "opentelemetry.", "opentsdb*",
+	"relabelConfig", "sortLabels", "streamAggr.", "usePromCompatibleNaming",
+}
+
+var vmStorageFlags = knownFlags{
+	"bigMergeConcurrency", "cacheExpireDuration", "dedup.", "denyQueriesOutsideRetention", "downsampling.",
+	"finalMergeDelay", "forceFlushAuthKey", "forceMergeAuthKey", "inmemoryDataFlushInterval", "logNewSeries*",
+	"maxDailySeries", "maxHourlySeries", "precisionBits", "retentionFilter*", "retentionPeriod", "retentionTimezoneOffset",
+	"search.", "smallMergeConcurrency", "snapshot*", "storage.", "storageDataPath",
+}
+
+var vmSelectFlags = knownFlags{
+	"cacheDataPath", "cacheExpireDuration", "dedup.", "deleteAuthKey", "denyQueryTracing", "downsampling.",
+	"logSlowQueryDuration", "search.", "vmalert.proxyURL", "vmui.",
+}
+
+var vmClusterFlags = knownFlags{
+	"clusternative*", "disableRerouting*", "dropSamplesOnOverload", "globalReplicationFactor", "replicationFactor",
+	"rpc.", "selectNode", "storageNode*", "vmstorageDialTimeout", "vmstorageUserTimeout",
+}
+
+var (
+	vmagentFlags = joinKnownFlags(commonVMFlags, vmIngestFlags, knownFlags{
+		"cacheExpireDuration", "dedup.", "enableMultitenantHandlers", "gcp.", "kafka.", "maxDailySeries", "maxHourlySeries",
+		"maxIngestionRate", "promscrape.", "remoteWrite.",
+	})
+	vmsingleFlags = joinKnownFlags(commonVMFlags, vmIngestFlags, vmStorageFlags, vmSelectFlags, knownFlags{
+		"deleteAuthKey", "promscrape.", "selfScrape*", "vmui.",
+	})
+	vminsertFlags  = joinKnownFlags(commonVMFlags, vmIngestFlags, vmClusterFlags)
+	vmselectFlags  = joinKnownFlags(commonVMFlags, vmSelectFlags, vmClusterFlags)
+	vmstorageFlags = joinKnownFlags(commonVMFlags, vmStorageFlags, knownFlags{
+		"rpc.", "vminsertAddr", "vmselectAddr",
+	})
+	vmalertFlags = joinKnownFlags(commonVMFlags, knownFlags{
+		"clusterMode", "configCheckInterval", "datasource.", "defaultTenant.", "disableAlertgroupLabel",
+		"evaluationInterval", "external.", "notifier.", "remoteRead.", "remoteWrite.", "replay.", "rule*",
+		"s3.", "search.", "vmalert.",
+	})
+	vmauthFlags = joinKnownFlags(commonVMFlags, knownFlags{
+		"auth.", "backend.", "configCheckInterval", "discoverBackendIPs", "dropOriginalPathPrefix", "failTimeout",
+		"idleConnTimeout", "loadBalancingPolicy", "logInvalidAuthTokens", "maxConcurrentPerUserRequests",
+		"maxConcurrentRequests", "maxIdleConnsPerBackend", "maxRequestBodySizeToRetry", "removeXFFHTTPHeaderValue",
+		"responseTimeout", "retryStatusCodes",
+	})
+	vmgatewayFlags = joinKnownFlags(commonVMFlags, knownFlags{
+		"auth.", "clusterMode", "datasource.", "enable.", "ratelimit.", "read.", "write.",
+	})
+	vlogsFlags = joinKnownFlags(commonVMFlags, knownFlags{
+		"defaultMsgValue", "elasticsearch.", "futureRetention", "inmemoryDataFlushInterval", "insert.", "journald.",
+		"logIngestedRows", "logNewStreams", "loki.", "opentelemetry.", "partitionManageAuthKey", "retention.",
+		"retentionPeriod", "search.", "select.", "storage.", "storageDataPath", "syslog.",
+	})
+	vmalertmanagerFlags = knownFlags{
+		"alerts.", "auto-gomaxprocs", "auto-gomemlimit", "cluster.", "config.", "data.", "dispatch.", "enable-feature",
+		"log.", "silences.", "storage.", "web.",
+	}
+)
+
+func joinKnownFlags(src ...knownFlags) knownFlags {
+	var dst knownFlags
+	for _, kf := range src {
+		dst = append(dst, kf...)
+	}
+	return dst
+}
+
+// checkExtraArgsFlags returns warnings for extraArgs flags unknown to the application
+// and for flags, which values are managed by operator
+//
+// it doesn't reject such flags, since applications of other versions may support them
+// and existing objects must remain updatable
+func checkExtraArgsFlags(field string, extraArgs map[string]string, known knownFlags, managedFlags ...string) admission.Warnings {
+	var warnings admission.Warnings
+	for name := range extraArgs {
+		flagName := strings.TrimLeft(name, "-")
+		if flagName == "" {
+			continue
+		}
+		var isManaged bool
+		for _, mf := range managedFlags {
+			if flagName == mf {
+				isManaged = true
+				break
+			}
+		}
+		switch {
+		case isManaged:
+			warnings = append(warnings, fmt.Sprintf("%s flag name=%q conflicts with flag managed by operator, it overrides operator generated configuration", field, name))
+		case !known.has(flagName):
+			warnings = append(warnings, fmt.Sprintf("%s flag name=%q is unknown for application and may prevent it from start", field, name))
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
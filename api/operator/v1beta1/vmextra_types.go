@@ -3,23 +3,32 @@ package v1beta1
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"path"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/VictoriaMetrics/VictoriaMetrics/lib/flagutil"
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
 	"gopkg.in/yaml.v2"
 
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // UpdateStatus defines status for application
@@ -50,8 +59,11 @@ const (
 	SkipValidationValue      = "true"
 	AdditionalServiceLabel   = "operator.victoriametrics.com/additional-service"
 	// PVCExpandableLabel controls checks for storageClass
-	PVCExpandableLabel            = "operator.victoriametrics.com/pvc-allow-volume-expansion"
-	lastAppliedSpecAnnotationName = "operator.victoriametrics/last-applied-spec"
+	PVCExpandableLabel = "operator.victoriametrics.com/pvc-allow-volume-expansion"
+	// RetentionDecreaseConfirmAnnotation confirms decrease of retentionPeriod for VMSingle and VMCluster.
+	// Its value must be equal to the new retentionPeriod, otherwise the change isn't applied
+	RetentionDecreaseConfirmAnnotation = "operator.victoriametrics.com/confirm-retention-decrease"
+	lastAppliedSpecAnnotationName      = "operator.victoriametrics/last-applied-spec"
 )
 
 const (
@@ -60,13 +72,53 @@ const (
 	TemplatesDir        = "/etc/vm/templates"
 	StreamAggrConfigDir = "/etc/vm/stream-aggr"
 	RelabelingConfigDir = "/etc/vm/relabeling"
+	ServerTLSDir        = "/etc/vm/server-tls"
+	// ServiceAccountTokensDir defines root directory for projected service account tokens
+	ServiceAccountTokensDir = "/var/run/secrets/vmagent/tokens"
 )
 
+const (
+	serverTLSVolumeName     = "server-tls"
+	serviceAccountTokenFile = "token"
+)
+
+// ServerTLSCAKey defines key of serverTLS secret with CA certificate
+// it's used by clients of the application http server for certificate verification
+const ServerTLSCAKey = "ca.crt"
+
 const (
 	// ConditionParsingReason defines reason for child objects
 	ConditionParsingReason = "ConfigParsedAndApplied"
 	// ConditionDomainTypeAppliedSuffix defines type suffix for ConditionParsingReason reason
 	ConditionDomainTypeAppliedSuffix = ".victoriametrics.com/Applied"
+
+	// ConditionTypeAvailable indicates that the last reconcile succeeded and the application is ready
+	ConditionTypeAvailable = "Available"
+	// ConditionTypeProgressing indicates that the application update is in progress
+	ConditionTypeProgressing = "Progressing"
+	// ConditionTypeDegraded indicates that the last reconcile failed
+	ConditionTypeDegraded = "Degraded"
+
+	// ConditionReasonOperational defines reason for successfully reconciled object
+	ConditionReasonOperational = "Operational"
+	// ConditionReasonExpanding defines reason for object with update in progress
+	ConditionReasonExpanding = "Expanding"
+	// ConditionReasonFailed defines reason for object with failed reconcile
+	ConditionReasonFailed = "ReconcileFailed"
+	// ConditionReasonPaused defines reason for paused object
+	ConditionReasonPaused = "Paused"
+	// ConditionReasonRetentionDecreaseNotConfirmed defines reason for object with retentionPeriod decrease,
+	// which wasn't confirmed with RetentionDecreaseConfirmAnnotation
+	ConditionReasonRetentionDecreaseNotConfirmed = "RetentionDecreaseNotConfirmed"
+	// ConditionReasonFinalizeFailed defines reason for deleted object, which objects cannot be removed by operator
+	ConditionReasonFinalizeFailed = "FinalizeFailed"
+
+	// ConditionTypeUnsupportedFeatures indicates that spec uses features, which are not supported by the application image version
+	ConditionTypeUnsupportedFeatures = "UnsupportedFeatures"
+	// ConditionReasonImageVersionTooOld defines reason for features not supported by the application image version
+	ConditionReasonImageVersionTooOld = "ImageVersionTooOld"
+	// ConditionReasonImageVersionSupported defines reason for features supported by the application image version
+	ConditionReasonImageVersionSupported = "ImageVersionSupported"
 )
 
 // SchemeGroupVersion is group version used to register these objects
@@ -78,6 +130,8 @@ var (
 	// default ignored annotations
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
 	annotationFilterPrefixes = []string{"kubectl.kubernetes.io/", "operator.victoriametrics.com/", "operator.victoriametrics/"}
+	// default value for spec.managedMetadata.propagateCRMetadata
+	disableCRMetadataPropagation bool
 )
 
 // SetLabelAndAnnotationPrefixes configures global filtering for child labels and annotations
@@ -88,8 +142,18 @@ func SetLabelAndAnnotationPrefixes(labelPrefixes, annotationPrefixes []string) {
 	annotationFilterPrefixes = append(annotationFilterPrefixes, annotationPrefixes...)
 }
 
+// SetCRMetadataPropagation configures default propagation of CR labels and annotations to child objects
+// if disabled, only spec.managedMetadata is applied to child objects of CRs without spec.managedMetadata.propagateCRMetadata
+// cannot be used concurrently and should be called only once at lib init
+func SetCRMetadataPropagation(disabled bool) {
+	disableCRMetadataPropagation = disabled
+}
+
 // TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
-func filterMapKeysByPrefixes(src map[string]string, prefixes []string) map[string]string {
+func filterMapKeysByPrefixes(src map[string]string, prefixes []string, mm *ManagedObjectsMetadata) map[string]string {
+	if !mm.propagatesCRMetadata() {
+		return make(map[string]string)
+	}
 	dst := make(map[string]string, len(src))
 OUTER:
 	for key, value := range src {
@@ -108,6 +172,25 @@ func mustSkipValidation(cr client.Object) bool {
 	return cr.GetAnnotations()[SkipValidationAnnotation] == SkipValidationValue
 }
 
+// checkWithPrev runs given checks against the object
+// prev must be nil on object creation.
+// Errors are returned as warnings, if prev object has the same problem,
+// it allows to update existing objects created before validation was added
+func checkWithPrev[T any](r, prev *T, checks ...func(r *T) error) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	for _, check := range checks {
+		err := check(r)
+		if err == nil {
+			continue
+		}
+		if prev == nil || check(prev) == nil {
+			return nil, err
+		}
+		warnings = append(warnings, err.Error())
+	}
+	return warnings, nil
+}
+
 // AddFinalizer conditionally adds vm-operator finalizer to the dst object
 // respectfully merges exist finalizers from src to dst
 func AddFinalizer(dst, src client.Object) {
@@ -311,6 +394,31 @@ type HTTPAuth struct {
 	Headers []string `json:"headers,omitempty"`
 }
 
+func (ha *HTTPAuth) validate() error {
+	var authMethods int
+	if ha.BasicAuth != nil {
+		authMethods++
+	}
+	if ha.OAuth2 != nil {
+		authMethods++
+	}
+	if ha.BearerAuth != nil && (ha.TokenSecret != nil || ha.TokenFilePath != "") {
+		authMethods++
+	}
+	if authMethods > 1 {
+		return fmt.Errorf("at most one of basicAuth, oauth2, bearerTokenSecret or bearerTokenFile must be configured")
+	}
+	if err := ha.OAuth2.validate(); err != nil {
+		return fmt.Errorf("incorrect oauth2: %w", err)
+	}
+	if ha.TLSConfig != nil {
+		if err := ha.TLSConfig.Validate(); err != nil {
+			return fmt.Errorf("incorrect tlsConfig: %w", err)
+		}
+	}
+	return nil
+}
+
 // BearerAuth defines auth with bearer token
 type BearerAuth struct {
 	// Path to bearer token file
@@ -373,6 +481,50 @@ func (ss *AdditionalServiceSpec) NameOrDefault(defaultName string) string {
 	return defaultName + "-additional-service"
 }
 
+func (asc *AdditionalServiceSpec) sanityCheck() error {
+	if asc == nil {
+		return nil
+	}
+	if asc.Spec.SessionAffinityConfig != nil && asc.Spec.SessionAffinity != v1.ServiceAffinityClientIP {
+		return fmt.Errorf("spec.sessionAffinityConfig requires spec.sessionAffinity=%q", v1.ServiceAffinityClientIP)
+	}
+	if asc.Spec.ClusterIP == v1.ClusterIPNone && asc.Spec.SessionAffinity == v1.ServiceAffinityClientIP {
+		return fmt.Errorf("spec.sessionAffinity=%q is not supported for headless service", v1.ServiceAffinityClientIP)
+	}
+	return nil
+}
+
+// checkAdditionalServices validates list of additional services
+// each service must have unique name, which doesn't conflict with the main or serviceSpec service
+func checkAdditionalServices(services []AdditionalServiceSpec, defaultName string, serviceSpec *AdditionalServiceSpec) error {
+	reserved := map[string]struct{}{
+		defaultName: {},
+	}
+	if err := serviceSpec.sanityCheck(); err != nil {
+		return fmt.Errorf("serviceSpec: %w", err)
+	}
+	_ = serviceSpec.IsSomeAndThen(func(s *AdditionalServiceSpec) error {
+		reserved[s.NameOrDefault(defaultName)] = struct{}{}
+		return nil
+	})
+	for idx, svc := range services {
+		if err := svc.sanityCheck(); err != nil {
+			return fmt.Errorf("additionalServices[%d]: %w", idx, err)
+		}
+		if svc.UseAsDefault {
+			return fmt.Errorf("additionalServices[%d]: useAsDefault is not supported, use serviceSpec instead", idx)
+		}
+		if svc.Name == "" {
+			return fmt.Errorf("additionalServices[%d]: metadata.name cannot be empty", idx)
+		}
+		if _, ok := reserved[svc.Name]; ok {
+			return fmt.Errorf("additionalServices[%d]: metadata.name=%q conflicts with another service name", idx, svc.Name)
+		}
+		reserved[svc.Name] = struct{}{}
+	}
+	return nil
+}
+
 // MaybeEnableProxyProtocol conditionally adds proxy protocol for custom config-reloader image
 // useful for vmagent and vmauth
 func MaybeEnableProxyProtocol(args []string, extaArgs map[string]string) []string {
@@ -389,6 +541,84 @@ func BuildReloadPathWithPort(extraArgs map[string]string, port string) string {
 	return fmt.Sprintf("%s://localhost:%s%s", proto, port, urlPath)
 }
 
+// ImageVersion defines version of application parsed from image tag
+type ImageVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// String implements Stringer interface
+func (v ImageVersion) String() string {
+	return fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Less checks if version is lower than the given version
+func (v ImageVersion) Less(other ImageVersion) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
+
+// ParseImageVersion parses version from image tag in form of v1.107.0, v1.107.0-cluster or v1.107.0@sha256:digest
+// returns false if tag doesn't contain version, e.g. latest or stable
+func ParseImageVersion(tag string) (ImageVersion, bool) {
+	var v ImageVersion
+	if idx := strings.IndexByte(tag, '@'); idx >= 0 {
+		tag = tag[:idx]
+	}
+	if idx := strings.IndexByte(tag, '-'); idx >= 0 {
+		tag = tag[:idx]
+	}
+	tag = strings.TrimPrefix(tag, "v")
+	parts := strings.Split(tag, ".")
+	if len(parts) != 3 {
+		return v, false
+	}
+	dst := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		*dst[i] = n
+	}
+	return v, true
+}
+
+// ImageTagSupports checks if image with the given tag supports feature added at minVersion
+// images without version at tag are considered as supported
+func ImageTagSupports(tag string, minVersion ImageVersion) bool {
+	v, ok := ParseImageVersion(tag)
+	if !ok {
+		return true
+	}
+	return !v.Less(minVersion)
+}
+
+// setUnsupportedFeaturesCondition reports features, which are not supported by application image version
+func setUnsupportedFeaturesCondition(stm *StatusMetadata, unsupported []string, generation int64) {
+	cond := Condition{
+		Type:               ConditionTypeUnsupportedFeatures,
+		Status:             metav1.ConditionFalse,
+		Reason:             ConditionReasonImageVersionSupported,
+		ObservedGeneration: generation,
+	}
+	if len(unsupported) > 0 {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = ConditionReasonImageVersionTooOld
+		cond.Message = fmt.Sprintf("features are ignored, image version is too old: %s", strings.Join(unsupported, "; "))
+	} else if getCondition(stm.Conditions, ConditionTypeUnsupportedFeatures) == nil {
+		// do not add condition to objects, which never used unsupported features
+		return
+	}
+	stm.Conditions = setCondition(stm.Conditions, cond)
+}
+
 func buildPathWithPrefixFlag(flags map[string]string, defaultPath string) string {
 	if prefix, ok := flags[vmPathPrefixFlagName]; ok {
 		return path.Join(prefix, defaultPath)
@@ -436,6 +666,35 @@ type EmbeddedPodDisruptionBudgetSpec struct {
 	SelectorLabels map[string]string `json:"selectorLabels,omitempty"`
 }
 
+func (epdbs *EmbeddedPodDisruptionBudgetSpec) sanityCheck() error {
+	if epdbs == nil {
+		return nil
+	}
+	if epdbs.MinAvailable != nil && epdbs.MaxUnavailable != nil {
+		return fmt.Errorf("minAvailable and maxUnavailable are mutually exclusive, only one of them can be set")
+	}
+	return nil
+}
+
+// checkClaimTemplates verifies that additional claim templates have unique names
+// and do not override volumes managed by operator
+func checkClaimTemplates(claims []v1.PersistentVolumeClaim, reservedNames ...string) error {
+	names := make(map[string]struct{}, len(claims))
+	for idx, claim := range claims {
+		if claim.Name == "" {
+			return fmt.Errorf("claim template name cannot be empty at idx: %d", idx)
+		}
+		if slices.Contains(reservedNames, claim.Name) {
+			return fmt.Errorf("claim template name=%q is reserved for the storage volume managed by operator", claim.Name)
+		}
+		if _, ok := names[claim.Name]; ok {
+			return fmt.Errorf("duplicate claim template name=%q", claim.Name)
+		}
+		names[claim.Name] = struct{}{}
+	}
+	return nil
+}
+
 // SelectorLabelsWithDefaults return defaultSelector or replaced selector defined by user
 func (epdbs *EmbeddedPodDisruptionBudgetSpec) SelectorLabelsWithDefaults(defaultSelector map[string]string) map[string]string {
 	if epdbs == nil || epdbs.SelectorLabels == nil {
@@ -444,6 +703,48 @@ func (epdbs *EmbeddedPodDisruptionBudgetSpec) SelectorLabelsWithDefaults(default
 	return epdbs.SelectorLabels
 }
 
+// EmbeddedNetworkPolicy defines NetworkPolicy created by operator for the component pods
+type EmbeddedNetworkPolicy struct {
+	// Enabled creates NetworkPolicy, which allows ingress traffic to the component pods
+	// only from operator, other components managed by operator and sources defined at IngressFrom
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// IngressFrom defines additional sources allowed to access all ports of the component pods
+	// +optional
+	IngressFrom []networkingv1.NetworkPolicyPeer `json:"ingressFrom,omitempty"`
+}
+
+// IsEnabled checks if NetworkPolicy must be created
+func (enp *EmbeddedNetworkPolicy) IsEnabled() bool {
+	return enp != nil && enp.Enabled
+}
+
+const (
+	// SpreadPolicyNode spreads pods across cluster nodes
+	SpreadPolicyNode = "node"
+	// SpreadPolicyZone spreads pods across availability zones
+	SpreadPolicyZone = "zone"
+)
+
+// SpreadPolicy defines high-level preset for pods placement
+type SpreadPolicy struct {
+	// Type of spreading, node or zone
+	// +kubebuilder:validation:Enum=node;zone
+	Type string `json:"type"`
+	// Required forbids scheduling of pods, which violate spreading.
+	// By default, scheduler prefers spreading, but still schedules pods if it's not possible.
+	// +optional
+	Required bool `json:"required,omitempty"`
+}
+
+// TopologyKey returns node label used for spreading
+func (sp *SpreadPolicy) TopologyKey() string {
+	if sp.Type == SpreadPolicyZone {
+		return v1.LabelTopologyZone
+	}
+	return v1.LabelHostname
+}
+
 // EmbeddedProbes - it allows to override some probe params.
 // its not necessary to specify all options,
 // operator will replace missing spec with default values.
@@ -478,6 +779,174 @@ func (cr *EmbeddedHPA) sanityCheck() error {
 	return nil
 }
 
+// EmbeddedIngress describes ingress configuration options.
+type EmbeddedIngress struct {
+	// ClassName defines ingress class name
+	// +optional
+	ClassName *string `json:"class_name,omitempty" yaml:"class_name,omitempty"`
+	//  EmbeddedObjectMetadata adds labels and annotations for object.
+	EmbeddedObjectMetadata `json:",inline"`
+	// TlsHosts configures TLS access for ingress, tlsSecretName must be defined for it.
+	TlsHosts []string `json:"tlsHosts,omitempty" yaml:"tlsHosts,omitempty"`
+	// TlsSecretName defines secretname at the object namespace with cert and key
+	// it could be issued by cert-manager with annotations, e.g. cert-manager.io/cluster-issuer
+	// https://kubernetes.io/docs/concepts/services-networking/ingress/#tls
+	// +optional
+	TlsSecretName string `json:"tlsSecretName,omitempty" yaml:"tlsSecretName,omitempty"`
+	// ExtraRules - additional rules for ingress,
+	// must be checked for correctness by user.
+	// +optional
+	ExtraRules []networkingv1.IngressRule `json:"extraRules,omitempty" yaml:"extraRules,omitempty"`
+	// ExtraTLS - additional TLS configuration for ingress
+	// must be checked for correctness by user.
+	// +optional
+	ExtraTLS []networkingv1.IngressTLS `json:"extraTls,omitempty" yaml:"extraTls,omitempty"`
+	// Host defines ingress host parameter for default rule
+	// It will be used, only if TlsHosts is empty
+	// +optional
+	Host string `json:"host,omitempty"`
+}
+
+func (ing *EmbeddedIngress) sanityCheck() error {
+	if ing == nil {
+		return nil
+	}
+	// TlsHosts and TlsSecretName are both needed if one of them is used
+	if len(ing.TlsHosts) > 0 && ing.TlsSecretName == "" {
+		return fmt.Errorf("tlsSecretName cannot be empty with non-empty tlsHosts")
+	}
+	if ing.TlsSecretName != "" && len(ing.TlsHosts) == 0 {
+		return fmt.Errorf("tlsHosts cannot be empty with non-empty tlsSecretName")
+	}
+	return nil
+}
+
+// EmbeddedRoute describes OpenShift Route configuration options.
+// Route API is served only by OpenShift clusters.
+// https://docs.openshift.com/container-platform/latest/networking/routes/route-configuration.html
+type EmbeddedRoute struct {
+	//  EmbeddedObjectMetadata adds labels and annotations for object.
+	EmbeddedObjectMetadata `json:",inline"`
+	// Host defines route host, OpenShift generates it if it's empty
+	// +optional
+	Host string `json:"host,omitempty"`
+	// Path defines path based routing
+	// +optional
+	Path string `json:"path,omitempty"`
+	// TLSTermination enables TLS termination with the given type
+	// certificate of OpenShift router is used for edge and reencrypt termination
+	// +kubebuilder:validation:Enum=edge;passthrough;reencrypt
+	// +optional
+	TLSTermination string `json:"tlsTermination,omitempty"`
+	// InsecureEdgeTerminationPolicy defines behavior for insecure connections with enabled TLS termination
+	// +kubebuilder:validation:Enum=None;Allow;Redirect
+	// +optional
+	InsecureEdgeTerminationPolicy string `json:"insecureEdgeTerminationPolicy,omitempty"`
+}
+
+func (r *EmbeddedRoute) sanityCheck() error {
+	if r == nil {
+		return nil
+	}
+	if r.InsecureEdgeTerminationPolicy != "" && r.TLSTermination == "" {
+		return fmt.Errorf("insecureEdgeTerminationPolicy requires tlsTermination")
+	}
+	if r.TLSTermination == "passthrough" {
+		if r.Path != "" {
+			return fmt.Errorf("path cannot be used with passthrough tlsTermination")
+		}
+		if r.InsecureEdgeTerminationPolicy == "Allow" {
+			return fmt.Errorf("insecureEdgeTerminationPolicy=Allow cannot be used with passthrough tlsTermination")
+		}
+	}
+	return nil
+}
+
+// EmbeddedVPA defines VerticalPodAutoscaler configuration for the application container.
+// It requires VerticalPodAutoscaler CRDs and controllers installed at kubernetes cluster.
+// https://github.com/kubernetes/autoscaler/tree/master/vertical-pod-autoscaler
+type EmbeddedVPA struct {
+	// UpdateMode defines how recommended resources are applied to the pods
+	// Off - only provides recommendations, Initial - applies resources at pod creation,
+	// Recreate and Auto - evicts pods with outdated resources
+	// +kubebuilder:validation:Enum=Off;Initial;Recreate;Auto
+	// +optional
+	UpdateMode string `json:"updateMode,omitempty"`
+	// MinAllowed defines the lower bound for recommended resources
+	// +optional
+	MinAllowed v1.ResourceList `json:"minAllowed,omitempty"`
+	// MaxAllowed defines the upper bound for recommended resources
+	// +optional
+	MaxAllowed v1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+func (cr *EmbeddedVPA) sanityCheck() error {
+	if cr == nil {
+		return nil
+	}
+	for name, maxValue := range cr.MaxAllowed {
+		if minValue, ok := cr.MinAllowed[name]; ok && minValue.Cmp(maxValue) > 0 {
+			return fmt.Errorf("minAllowed %s cannot be greater than maxAllowed", name)
+		}
+	}
+	return nil
+}
+
+// IsActive checks if VPA applies recommendations to the pods
+func (cr *EmbeddedVPA) IsActive() bool {
+	return cr != nil && cr.UpdateMode != "Off"
+}
+
+// checkExtraArgs validates flag names at extraArgs
+// unknown and operator managed flags are reported as warnings by checkExtraArgsFlags
+func checkExtraArgs(extraArgs map[string]string) error {
+	for name := range extraArgs {
+		flagName := strings.TrimLeft(name, "-")
+		if flagName == "" {
+			return fmt.Errorf("spec.extraArgs cannot have empty flag name=%q", name)
+		}
+		if strings.ContainsAny(flagName, "= \t\n") {
+			return fmt.Errorf("spec.extraArgs flag name=%q cannot contain whitespaces or '=' symbol", name)
+		}
+	}
+	return nil
+}
+
+// checkRollingUpdate validates rollingUpdate params of deployment update strategy
+func checkRollingUpdate(updateStrategy *appsv1.DeploymentStrategyType, ru *appsv1.RollingUpdateDeployment) error {
+	if ru == nil {
+		return nil
+	}
+	if updateStrategy != nil && *updateStrategy == appsv1.RecreateDeploymentStrategyType {
+		return fmt.Errorf("rollingUpdate cannot be used with updateStrategy=%s", *updateStrategy)
+	}
+	isZero := func(name string, v *intstr.IntOrString) (bool, error) {
+		if v == nil {
+			return false, nil
+		}
+		value, err := intstr.GetScaledValueFromIntOrPercent(v, 100, true)
+		if err != nil {
+			return false, fmt.Errorf("cannot parse %s=%q: %w", name, v.String(), err)
+		}
+		if value < 0 {
+			return false, fmt.Errorf("%s=%q cannot be negative", name, v.String())
+		}
+		return value == 0, nil
+	}
+	zeroSurge, err := isZero("maxSurge", ru.MaxSurge)
+	if err != nil {
+		return err
+	}
+	zeroUnavailable, err := isZero("maxUnavailable", ru.MaxUnavailable)
+	if err != nil {
+		return err
+	}
+	if zeroSurge && zeroUnavailable {
+		return fmt.Errorf("maxSurge and maxUnavailable cannot be both zero")
+	}
+	return nil
+}
+
 // DiscoverySelector can be used at CRD components discovery
 type DiscoverySelector struct {
 	Namespace *NamespaceSelector    `json:"namespaceSelector,omitempty"`
@@ -724,6 +1193,112 @@ func (m *StringOrArray) UnmarshalJSON(data []byte) error {
 	}
 }
 
+const (
+	awsRoleARNAnnotation        = "eks.amazonaws.com/role-arn"
+	gcpServiceAccountAnnotation = "iam.gke.io/gcp-service-account"
+)
+
+// CloudIdentity configures cloud provider workload identity for the generated ServiceAccount.
+// If it's used, static credentials for backups are not mounted into pods.
+type CloudIdentity struct {
+	// AWSRoleARN defines IAM role ARN for [AWS IRSA](https://docs.aws.amazon.com/eks/latest/userguide/iam-roles-for-service-accounts.html)
+	// it's added to the ServiceAccount as eks.amazonaws.com/role-arn annotation
+	// +optional
+	AWSRoleARN string `json:"awsRoleARN,omitempty"`
+	// GCPServiceAccount defines GCP service account email for [GKE Workload Identity](https://cloud.google.com/kubernetes-engine/docs/how-to/workload-identity)
+	// it's added to the ServiceAccount as iam.gke.io/gcp-service-account annotation
+	// +optional
+	GCPServiceAccount string `json:"gcpServiceAccount,omitempty"`
+}
+
+// IsEnabled checks if any cloud identity is configured
+func (ci *CloudIdentity) IsEnabled() bool {
+	return ci != nil && (ci.AWSRoleARN != "" || ci.GCPServiceAccount != "")
+}
+
+// ServiceAccountAnnotations returns annotations for the ServiceAccount
+func (ci *CloudIdentity) ServiceAccountAnnotations() map[string]string {
+	if !ci.IsEnabled() {
+		return nil
+	}
+	annotations := make(map[string]string, 2)
+	if ci.AWSRoleARN != "" {
+		annotations[awsRoleARNAnnotation] = ci.AWSRoleARN
+	}
+	if ci.GCPServiceAccount != "" {
+		annotations[gcpServiceAccountAnnotation] = ci.GCPServiceAccount
+	}
+	return annotations
+}
+
+func (ci *CloudIdentity) sanityCheck(serviceAccountName string, backup *VMBackup) error {
+	if !ci.IsEnabled() {
+		return nil
+	}
+	if serviceAccountName != "" {
+		return fmt.Errorf("cloudIdentity cannot be used with serviceAccountName=%q, annotate the ServiceAccount directly instead", serviceAccountName)
+	}
+	if backup != nil && backup.CredentialsSecret != nil {
+		return fmt.Errorf("vmBackup.credentialsSecret cannot be used with cloudIdentity")
+	}
+	return nil
+}
+
+const (
+	// GrafanaDashboardModeCR generates GrafanaDashboard object of grafana-operator
+	GrafanaDashboardModeCR = "GrafanaDashboard"
+	// GrafanaDashboardModeConfigMap generates ConfigMap for grafana dashboards sidecar
+	GrafanaDashboardModeConfigMap = "ConfigMap"
+)
+
+// GrafanaDashboard configures provisioning of the official Grafana dashboard for the application.
+// Dashboard is downloaded from VictoriaMetrics repository at the version of the application image.
+type GrafanaDashboard struct {
+	// Mode defines kind of generated object:
+	// GrafanaDashboard - [grafana-operator](https://grafana.github.io/grafana-operator/) GrafanaDashboard object,
+	// ConfigMap - ConfigMap with dashboard url for [grafana sidecar](https://github.com/kiwigrid/k8s-sidecar).
+	// By default, GrafanaDashboard is used.
+	// +kubebuilder:validation:Enum=GrafanaDashboard;ConfigMap
+	// +optional
+	Mode string `json:"mode,omitempty"`
+	// InstanceSelector selects Grafana instances of grafana-operator for the dashboard
+	// required for GrafanaDashboard mode
+	// +optional
+	InstanceSelector *metav1.LabelSelector `json:"instanceSelector,omitempty"`
+	// Folder defines Grafana folder for the dashboard
+	// for ConfigMap mode it's set with grafana_folder annotation
+	// +optional
+	Folder string `json:"folder,omitempty"`
+	// ConfigMapLabels defines labels for ConfigMap watched by grafana sidecar
+	// By default, grafana_dashboard: "1" label is used
+	// +optional
+	ConfigMapLabels map[string]string `json:"configMapLabels,omitempty"`
+}
+
+// UseConfigMap checks if dashboard must be provisioned with ConfigMap
+func (gd *GrafanaDashboard) UseConfigMap() bool {
+	return gd != nil && gd.Mode == GrafanaDashboardModeConfigMap
+}
+
+func (gd *GrafanaDashboard) sanityCheck() error {
+	if gd == nil {
+		return nil
+	}
+	if gd.UseConfigMap() {
+		if gd.InstanceSelector != nil {
+			return fmt.Errorf("grafanaDashboard.instanceSelector cannot be used with mode=%s", GrafanaDashboardModeConfigMap)
+		}
+		return nil
+	}
+	if gd.InstanceSelector == nil {
+		return fmt.Errorf("grafanaDashboard.instanceSelector is required for mode=%s", GrafanaDashboardModeCR)
+	}
+	if len(gd.ConfigMapLabels) > 0 {
+		return fmt.Errorf("grafanaDashboard.configMapLabels can be used only with mode=%s", GrafanaDashboardModeConfigMap)
+	}
+	return nil
+}
+
 // License holds license key for enterprise features.
 // Using license key is supported starting from VictoriaMetrics v1.94.0.
 // See [here](https://docs.victoriametrics.com/enterprise)
@@ -845,6 +1420,31 @@ type TLSConfig struct {
 	// Disable target certificate validation.
 	// +optional
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty" yaml:"insecure_skip_verify,omitempty"`
+	// MinVersion defines minimum acceptable TLS version for the targets.
+	// It's supported by tls_config of generated configuration files and operator requests
+	// +kubebuilder:validation:Enum=TLS10;TLS11;TLS12;TLS13
+	// +optional
+	MinVersion string `json:"minVersion,omitempty" yaml:"min_version,omitempty"`
+}
+
+var tlsVersions = map[string]uint16{
+	"TLS10": tls.VersionTLS10,
+	"TLS11": tls.VersionTLS11,
+	"TLS12": tls.VersionTLS12,
+	"TLS13": tls.VersionTLS13,
+}
+
+// ParseTLSVersion returns crypto/tls version for the given name, e.g. TLS12
+// empty name returns 0, which means default minimum version of crypto/tls
+func ParseTLSVersion(name string) (uint16, error) {
+	if name == "" {
+		return 0, nil
+	}
+	v, ok := tlsVersions[name]
+	if !ok {
+		return 0, fmt.Errorf("unsupported TLS version=%q, supported values: TLS10, TLS11, TLS12, TLS13", name)
+	}
+	return v, nil
 }
 
 func (c *TLSConfig) AsArgs(args []string, prefix, pathPrefix string) []string {
@@ -1125,6 +1725,11 @@ type CommonDefaultableParams struct {
 	// Has priority over `VM_DISABLESELFSERVICESCRAPECREATION` operator env variable
 	// +optional
 	DisableSelfServiceScrape *bool `json:"disableSelfServiceScrape,omitempty"`
+	// SelfMonitoringRules controls creation of VMRule with alerting rules
+	// for the application health by operator.
+	// Has priority over `VM_ENABLESELFMONITORINGRULES` operator env variable
+	// +optional
+	SelfMonitoringRules *bool `json:"selfMonitoringRules,omitempty"`
 }
 
 type CommonConfigReloaderParams struct {
@@ -1201,12 +1806,18 @@ type CommonApplicationDeploymentParams struct {
 	// https://kubernetes.io/docs/concepts/workloads/pods/pod-topology-spread-constraints/
 	// +optional
 	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// SpreadPolicy defines preset for pods spreading across nodes or zones
+	// it's converted into topologySpreadConstraints,
+	// constraint defined at topologySpreadConstraints for the same topology key has priority
+	// +optional
+	SpreadPolicy *SpreadPolicy `json:"spreadPolicy,omitempty"`
 	// ImagePullSecrets An optional list of references to secrets in the same namespace
 	// to use for pulling images from registries
 	// see https://kubernetes.io/docs/concepts/containers/images/#referring-to-an-imagepullsecrets-on-a-pod
 	// +optional
 	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 	// TerminationGracePeriodSeconds period for container graceful termination
+	// +kubebuilder:validation:Minimum=0
 	// +optional
 	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
 	// ReadinessGates defines pod readiness gates
@@ -1214,6 +1825,7 @@ type CommonApplicationDeploymentParams struct {
 	// MinReadySeconds defines a minimum number of seconds to wait before starting update next pod
 	// if previous in healthy state
 	// Has no effect for VLogs and VMSingle
+	// +kubebuilder:validation:Minimum=0
 	// +optional
 	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
 	// ReplicaCount is the expected size of the Application.
@@ -1236,6 +1848,13 @@ type CommonApplicationDeploymentParams struct {
 	// More info: https://kubernetes.io/docs/concepts/workloads/pods/init-containers/
 	// +optional
 	InitContainers []v1.Container `json:"initContainers,omitempty"`
+	// Sidecars allows adding sidecar containers to the pod definition.
+	// Since kubernetes 1.29 sidecars are added as native sidecars - initContainers with restartPolicy: Always,
+	// which are started before and stopped after application containers.
+	// For older kubernetes versions sidecars are appended to the pod containers.
+	// More info: https://kubernetes.io/docs/concepts/workloads/pods/sidecar-containers/
+	// +optional
+	Sidecars []v1.Container `json:"sidecars,omitempty"`
 	// Secrets is a list of Secrets in the same namespace as the Application
 	// object, which shall be mounted into the Application container
 	// at /etc/vm/secrets/SECRET_NAME folder
@@ -1261,10 +1880,132 @@ type CommonApplicationDeploymentParams struct {
 	// ExtraEnvs that will be passed to the application container
 	// +optional
 	ExtraEnvs []v1.EnvVar `json:"extraEnvs,omitempty"`
+	// Lifecycle defines lifecycle hooks for the main application container,
+	// e.g. preStop hook with sleep gives time for load balancers to deregister pod before shutdown
+	// +optional
+	Lifecycle *v1.Lifecycle `json:"lifecycle,omitempty"`
 	// Paused If set to true all actions on the underlying managed objects are not
 	// going to be performed, except for delete actions.
 	// +optional
 	Paused bool `json:"paused,omitempty"`
+	// PodTemplatePatches defines patches applied in the given order to the pod template of generated Deployment or StatefulSet.
+	// It allows to set pod fields, which are not supported by operator API yet.
+	// +optional
+	PodTemplatePatches []PodTemplatePatch `json:"podTemplatePatches,omitempty"`
+	// ServerTLS enables TLS for the application http server.
+	// Generated scrape objects, probes and urls of the component are switched to https.
+	// +optional
+	ServerTLS *ServerTLS `json:"serverTLS,omitempty"`
+}
+
+// ServerTLS defines TLS configuration for the application http server
+type ServerTLS struct {
+	// SecretName defines name of kubernetes.io/tls secret at the object namespace
+	// with tls.crt and tls.key entries. For instance, secret issued by cert-manager Certificate
+	SecretName string `json:"secretName"`
+	// MinVersion defines minimum supported TLS version
+	// +kubebuilder:validation:Enum=TLS10;TLS11;TLS12;TLS13
+	// +optional
+	MinVersion string `json:"minVersion,omitempty"`
+	// CipherSuites defines list of supported cipher suites for TLS versions up to TLS 1.2
+	// https://golang.org/pkg/crypto/tls/#pkg-constants
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+	// SelfSigned enables certificate provisioning by operator.
+	// Operator creates secret with SecretName, which contains self-signed certificate
+	// for the application service DNS names and localhost, and renews it before expiration
+	// +optional
+	SelfSigned bool `json:"selfSigned,omitempty"`
+}
+
+// MaybeAddToArgs conditionally adds tls flags to the given args
+func (st *ServerTLS) MaybeAddToArgs(args []string, certDir string) []string {
+	if st == nil {
+		return args
+	}
+	args = append(args,
+		"-tls=true",
+		fmt.Sprintf("-tlsCertFile=%s", path.Join(certDir, v1.TLSCertKey)),
+		fmt.Sprintf("-tlsKeyFile=%s", path.Join(certDir, v1.TLSPrivateKeyKey)),
+	)
+	if st.MinVersion != "" {
+		args = append(args, fmt.Sprintf("-tlsMinVersion=%s", st.MinVersion))
+	}
+	if len(st.CipherSuites) > 0 {
+		args = append(args, fmt.Sprintf("-tlsCipherSuites=%s", strings.Join(st.CipherSuites, ",")))
+	}
+	return args
+}
+
+// MaybeAddToVolumes conditionally mounts secret with tls certificate into given volumes and mounts
+func (st *ServerTLS) MaybeAddToVolumes(volumes []v1.Volume, mounts []v1.VolumeMount, certDir string) ([]v1.Volume, []v1.VolumeMount) {
+	if st == nil {
+		return volumes, mounts
+	}
+	volumes = append(volumes, v1.Volume{
+		Name: serverTLSVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: st.SecretName,
+			},
+		},
+	})
+	mounts = append(mounts, v1.VolumeMount{
+		Name:      serverTLSVolumeName,
+		ReadOnly:  true,
+		MountPath: certDir,
+	})
+	return volumes, mounts
+}
+
+func (st *ServerTLS) sanityCheck() error {
+	if st == nil {
+		return nil
+	}
+	if st.SecretName == "" {
+		return fmt.Errorf("secretName cannot be empty")
+	}
+	if err := checkCipherSuites(st.CipherSuites); err != nil {
+		return fmt.Errorf("incorrect cipherSuites: %w", err)
+	}
+	return nil
+}
+
+// checkCipherSuites verifies that given cipher suites are supported by go crypto/tls
+func checkCipherSuites(suites []string) error {
+	for _, name := range suites {
+		if !slices.ContainsFunc(tls.CipherSuites(), func(cs *tls.CipherSuite) bool { return cs.Name == name }) &&
+			!slices.ContainsFunc(tls.InsecureCipherSuites(), func(cs *tls.CipherSuite) bool { return cs.Name == name }) {
+			return fmt.Errorf("unsupported cipher suite=%q", name)
+		}
+	}
+	return nil
+}
+
+// ExtraArgsWithServerTLS returns extraArgs with enabled tls flag, if serverTLS is defined.
+// It allows to detect protocol of the application http server in the same way for both settings
+func (cp *CommonApplicationDeploymentParams) ExtraArgsWithServerTLS() map[string]string {
+	if cp.ServerTLS == nil {
+		return cp.ExtraArgs
+	}
+	args := make(map[string]string, len(cp.ExtraArgs)+1)
+	for k, v := range cp.ExtraArgs {
+		args[k] = v
+	}
+	args["tls"] = "true"
+	return args
+}
+
+// PodTemplatePatch defines patch for pod template
+type PodTemplatePatch struct {
+	// Type of the patch, strategic merge patch is used by default
+	// +kubebuilder:validation:Enum=strategic;json
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Patch defines content of the patch in JSON or YAML format.
+	// RFC 6902 JSON patch must be a list of operations with paths relative to the pod template,
+	// e.g. [{"op": "add", "path": "/spec/hostUsers", "value": false}]
+	Patch string `json:"patch"`
 }
 
 // SecurityContext extends PodSecurityContext with ContainerSecurityContext
@@ -1345,6 +2086,7 @@ func updateObjectStatus[T client.Object, ST any](ctx context.Context, rclient cl
 	}
 
 	currMeta.ObservedGeneration = opts.cr.GetGeneration()
+	setUpdateStatusConditions(currMeta, newUpdateStatus, failedConditionReason(opts.maybeErr), opts.cr.GetGeneration())
 	if opts.mutateCurrentBeforeCompare != nil {
 		opts.mutateCurrentBeforeCompare(opts.crStatus.(ST))
 	}
@@ -1355,7 +2097,9 @@ func updateObjectStatus[T client.Object, ST any](ctx context.Context, rclient cl
 	}
 	currMeta.UpdateStatus = newUpdateStatus
 
-	pr, err := buildStatusPatch(currentStatus)
+	// reconcile of the outdated object could finish after reconcile of the newer one
+	// patch tests generation of the reconciled object and it's rejected in this case, since it'll override the actual status
+	pr, err := buildStatusPatch(currentStatus, opts.cr.GetGeneration())
 	if err != nil {
 		return err
 	}
@@ -1364,6 +2108,9 @@ func updateObjectStatus[T client.Object, ST any](ctx context.Context, rclient cl
 	// which is not desired behaviour
 	objecToUpdate := opts.cr.DeepCopy()
 	if err := rclient.Status().Patch(ctx, objecToUpdate, pr); err != nil {
+		if isPatchTestFailed(err) {
+			return nil
+		}
 		return fmt.Errorf("cannot update resource status with patch: %w", err)
 	}
 	// Update ResourceVersion in order to resolve future conflicts
@@ -1372,13 +2119,192 @@ func updateObjectStatus[T client.Object, ST any](ctx context.Context, rclient cl
 	return nil
 }
 
-func buildStatusPatch(currentStatus interface{}) (client.Patch, error) {
+// isPatchTestFailed checks if json patch was rejected by failed test operation
+func isPatchTestFailed(err error) bool {
+	return errors.Is(err, jsonpatch.ErrTestFailed) || (k8serrors.IsInvalid(err) && strings.Contains(err.Error(), jsonpatch.ErrTestFailed.Error()))
+}
+
+// setUpdateStatusConditions reflects update status as standard Available, Progressing and Degraded conditions
+// it allows to use kubectl wait --for=condition=Available
+// failedReason is used as conditions reason for UpdateStatusFailed
+func setUpdateStatusConditions(stm *StatusMetadata, status UpdateStatus, failedReason string, generation int64) {
+	newCond := func(condType string, condStatus metav1.ConditionStatus, reason, message string) Condition {
+		return Condition{
+			Type:               condType,
+			Status:             condStatus,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: generation,
+		}
+	}
+	switch status {
+	case UpdateStatusOperational:
+		stm.Conditions = setCondition(stm.Conditions, newCond(ConditionTypeAvailable, metav1.ConditionTrue, ConditionReasonOperational, ""))
+		stm.Conditions = setCondition(stm.Conditions, newCond(ConditionTypeProgressing, metav1.ConditionFalse, ConditionReasonOperational, ""))
+		stm.Conditions = setCondition(stm.Conditions, newCond(ConditionTypeDegraded, metav1.ConditionFalse, ConditionReasonOperational, ""))
+	case UpdateStatusExpanding:
+		// application is still available during rolling update,
+		// so keep previous availability
+		if getCondition(stm.Conditions, ConditionTypeAvailable) == nil {
+			stm.Conditions = setCondition(stm.Conditions, newCond(ConditionTypeAvailable, metav1.ConditionFalse, ConditionReasonExpanding, "application is not ready yet"))
+		}
+		stm.Conditions = setCondition(stm.Conditions, newCond(ConditionTypeProgressing, metav1.ConditionTrue, ConditionReasonExpanding, ""))
+	case UpdateStatusFailed:
+		stm.Conditions = setCondition(stm.Conditions, newCond(ConditionTypeAvailable, metav1.ConditionFalse, failedReason, stm.Reason))
+		stm.Conditions = setCondition(stm.Conditions, newCond(ConditionTypeProgressing, metav1.ConditionFalse, failedReason, stm.Reason))
+		stm.Conditions = setCondition(stm.Conditions, newCond(ConditionTypeDegraded, metav1.ConditionTrue, failedReason, stm.Reason))
+	case UpdateStatusPaused:
+		stm.Conditions = setCondition(stm.Conditions, newCond(ConditionTypeProgressing, metav1.ConditionFalse, ConditionReasonPaused, "reconcile is paused"))
+	}
+}
+
+// conditionReasoner is implemented by errors with own condition reason
+type conditionReasoner interface {
+	ConditionReason() string
+}
+
+// failedConditionReason returns conditions reason for the given reconcile error
+func failedConditionReason(err error) string {
+	var rde *RetentionDecreaseError
+	if errors.As(err, &rde) {
+		return ConditionReasonRetentionDecreaseNotConfirmed
+	}
+	var cr conditionReasoner
+	if errors.As(err, &cr) {
+		return cr.ConditionReason()
+	}
+	return ConditionReasonFailed
+}
+
+// RetentionDecreaseError occurs if retentionPeriod is decreased without confirmation
+// decreased retention removes data outside of the new retention period, so it must not be applied accidentally
+type RetentionDecreaseError struct {
+	Prev string
+	New  string
+}
+
+func (rde *RetentionDecreaseError) Error() string {
+	return fmt.Sprintf("retentionPeriod decrease from %q to %q will delete data outside of the new retention period and must be confirmed with annotation %s=%q",
+		rde.Prev, rde.New, RetentionDecreaseConfirmAnnotation, rde.New)
+}
+
+// defaultRetentionPeriod is used by VictoriaMetrics if -retentionPeriod flag is empty
+const defaultRetentionPeriod = "1"
+
+// isRetentionDecreased checks if newRetention is smaller than prevRetention
+// values which cannot be parsed are never treated as decreased, they're rejected by validation
+func isRetentionDecreased(prevRetention, newRetention string) bool {
+	parse := func(value string) (int64, bool) {
+		if value == "" {
+			value = defaultRetentionPeriod
+		}
+		var d flagutil.RetentionDuration
+		if err := d.Set(value); err != nil {
+			return 0, false
+		}
+		return d.Milliseconds(), true
+	}
+	prev, ok := parse(prevRetention)
+	if !ok {
+		return false
+	}
+	curr, ok := parse(newRetention)
+	if !ok {
+		return false
+	}
+	return curr < prev
+}
+
+// pvcRetentionPolicy returns StatefulSet persistentVolumeClaimRetentionPolicy with applied retainPVCs setting
+// retainPVCs overrides whenDeleted value of the policy
+func pvcRetentionPolicy(retainPVCs *bool, policy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy) *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy {
+	if retainPVCs == nil {
+		return policy
+	}
+	result := &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+		WhenScaled: appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+	}
+	if policy != nil {
+		*result = *policy
+	}
+	result.WhenDeleted = appsv1.DeletePersistentVolumeClaimRetentionPolicyType
+	if *retainPVCs {
+		result.WhenDeleted = appsv1.RetainPersistentVolumeClaimRetentionPolicyType
+	}
+	return result
+}
+
+// IsPVCDeletedWithOwner checks if PersistentVolumeClaims must be removed on StatefulSet owner deletion
+func IsPVCDeletedWithOwner(policy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy) bool {
+	return policy != nil && policy.WhenDeleted == appsv1.DeletePersistentVolumeClaimRetentionPolicyType
+}
+
+// retentionPeriodWithFlags returns retentionPeriod applied to the storage
+// retentionPeriod flag defined at extraArgs overrides value from spec
+func retentionPeriodWithFlags(retentionPeriod string, extraArgs map[string]string) string {
+	if v, ok := extraArgs["retentionPeriod"]; ok {
+		return v
+	}
+	return retentionPeriod
+}
+
+// checkRetentionDecrease returns RetentionDecreaseError if newRetention is smaller than prevRetention
+// and it isn't confirmed with RetentionDecreaseConfirmAnnotation
+func checkRetentionDecrease(annotations map[string]string, prevRetention, newRetention string) error {
+	if !isRetentionDecreased(prevRetention, newRetention) {
+		return nil
+	}
+	if v, ok := annotations[RetentionDecreaseConfirmAnnotation]; ok && v == newRetention {
+		return nil
+	}
+	return &RetentionDecreaseError{Prev: prevRetention, New: newRetention}
+}
+
+func getCondition(src []Condition, condType string) *Condition {
+	for idx := range src {
+		if src[idx].Type == condType {
+			return &src[idx]
+		}
+	}
+	return nil
+}
+
+// setCondition adds or updates condition with the same type
+// timestamps are changed only if condition content was changed
+// it allows to skip status update requests for the same state
+func setCondition(dst []Condition, cond Condition) []Condition {
+	now := metav1.Now()
+	prev := getCondition(dst, cond.Type)
+	if prev == nil {
+		cond.LastTransitionTime = now
+		cond.LastUpdateTime = now
+		return append(dst, cond)
+	}
+	if prev.Status == cond.Status && prev.Reason == cond.Reason &&
+		prev.Message == cond.Message && prev.ObservedGeneration == cond.ObservedGeneration {
+		return dst
+	}
+	cond.LastTransitionTime = prev.LastTransitionTime
+	if prev.Status != cond.Status {
+		cond.LastTransitionTime = now
+	}
+	cond.LastUpdateTime = now
+	*prev = cond
+	return dst
+}
+
+func buildStatusPatch(currentStatus interface{}, generation int64) (client.Patch, error) {
 	type patch struct {
 		OP    string      `json:"op"`
 		Path  string      `json:"path"`
 		Value interface{} `json:"value"`
 	}
 	ops := []patch{
+		{
+			OP:    "test",
+			Path:  "/metadata/generation",
+			Value: generation,
+		},
 		{
 			OP:    "replace",
 			Path:  "/status",
@@ -1439,6 +2365,20 @@ type ManagedObjectsMetadata struct {
 	// queryable and should be preserved when modifying objects.
 	// More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations
 	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// PropagateCRMetadata defines whether labels and annotations of the custom resource itself
+	// are copied to the generated objects. Labels and annotations defined at managedMetadata are always applied.
+	// Operator-wide VM_DISABLECRMETADATAPROPAGATION setting is used if not set
+	// +optional
+	PropagateCRMetadata *bool `json:"propagateCRMetadata,omitempty"`
+}
+
+// propagatesCRMetadata checks if labels and annotations of the custom resource must be copied to the generated objects
+func (mm *ManagedObjectsMetadata) propagatesCRMetadata() bool {
+	if mm != nil && mm.PropagateCRMetadata != nil {
+		return *mm.PropagateCRMetadata
+	}
+	return !disableCRMetadataPropagation
 }
 
 // Image defines docker image settings
@@ -1,6 +1,8 @@
 package v1beta1
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"reflect"
 	"slices"
@@ -9,7 +11,15 @@ import (
 	"gopkg.in/yaml.v2"
 	"k8s.io/utils/ptr"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 func Test_buildPathWithPrefixFlag(t *testing.T) {
@@ -174,3 +184,639 @@ func TestLicense_MaybeAddToArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestSetUpdateStatusConditions(t *testing.T) {
+	type condState struct {
+		status metav1.ConditionStatus
+		reason string
+	}
+	f := func(stm *StatusMetadata, status UpdateStatus, want map[string]condState) {
+		t.Helper()
+		setUpdateStatusConditions(stm, status, ConditionReasonFailed, 2)
+		got := make(map[string]condState, len(stm.Conditions))
+		for _, c := range stm.Conditions {
+			if c.ObservedGeneration != 2 {
+				t.Fatalf("unexpected observedGeneration=%d for condition=%q", c.ObservedGeneration, c.Type)
+			}
+			got[c.Type] = condState{status: c.Status, reason: c.Reason}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected conditions\ngot:  %v\nwant: %v", got, want)
+		}
+	}
+
+	// initial rollout
+	f(&StatusMetadata{}, UpdateStatusExpanding, map[string]condState{
+		ConditionTypeAvailable:   {metav1.ConditionFalse, ConditionReasonExpanding},
+		ConditionTypeProgressing: {metav1.ConditionTrue, ConditionReasonExpanding},
+	})
+
+	// rollout finished
+	stm := &StatusMetadata{}
+	f(stm, UpdateStatusOperational, map[string]condState{
+		ConditionTypeAvailable:   {metav1.ConditionTrue, ConditionReasonOperational},
+		ConditionTypeProgressing: {metav1.ConditionFalse, ConditionReasonOperational},
+		ConditionTypeDegraded:    {metav1.ConditionFalse, ConditionReasonOperational},
+	})
+
+	// next rollout keeps availability
+	f(stm, UpdateStatusExpanding, map[string]condState{
+		ConditionTypeAvailable:   {metav1.ConditionTrue, ConditionReasonOperational},
+		ConditionTypeProgressing: {metav1.ConditionTrue, ConditionReasonExpanding},
+		ConditionTypeDegraded:    {metav1.ConditionFalse, ConditionReasonOperational},
+	})
+
+	// failed reconcile
+	stm.Reason = "cannot create deployment"
+	f(stm, UpdateStatusFailed, map[string]condState{
+		ConditionTypeAvailable:   {metav1.ConditionFalse, ConditionReasonFailed},
+		ConditionTypeProgressing: {metav1.ConditionFalse, ConditionReasonFailed},
+		ConditionTypeDegraded:    {metav1.ConditionTrue, ConditionReasonFailed},
+	})
+	if c := getCondition(stm.Conditions, ConditionTypeDegraded); c.Message != stm.Reason {
+		t.Fatalf("unexpected degraded message: %q", c.Message)
+	}
+
+	// the same state doesn't change timestamps
+	prev := slices.Clone(stm.Conditions)
+	setUpdateStatusConditions(stm, UpdateStatusFailed, ConditionReasonFailed, 2)
+	if !reflect.DeepEqual(prev, stm.Conditions) {
+		t.Fatalf("conditions must not change for the same state")
+	}
+}
+
+func TestCheckRetentionDecrease(t *testing.T) {
+	f := func(annotations map[string]string, prevRetention, newRetention string, wantErr bool) {
+		t.Helper()
+		err := checkRetentionDecrease(annotations, prevRetention, newRetention)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if err != nil && failedConditionReason(err) != ConditionReasonRetentionDecreaseNotConfirmed {
+			t.Fatalf("unexpected condition reason for error: %v", err)
+		}
+	}
+	confirmed := func(value string) map[string]string {
+		return map[string]string{RetentionDecreaseConfirmAnnotation: value}
+	}
+
+	// not changed
+	f(nil, "1", "1", false)
+	f(nil, "", "1", false)
+
+	// increased
+	f(nil, "1", "2", false)
+	f(nil, "2w", "1", false)
+	f(nil, "", "1y", false)
+
+	// decreased
+	f(nil, "3", "2", true)
+	f(nil, "1y", "30d", true)
+	f(nil, "", "2w", true)
+
+	// decrease confirmed
+	f(confirmed("2"), "3", "2", false)
+	f(confirmed("30d"), "1y", "30d", false)
+
+	// confirmation for another value
+	f(confirmed("1"), "3", "2", true)
+
+	// incorrect values are checked by validation
+	f(nil, "3", "1month", false)
+	f(nil, "1month", "3", false)
+}
+
+func TestRetentionPeriodWithExtraArgs(t *testing.T) {
+	f := func(prev, cr *VMClusterSpec, annotations map[string]string, wantErr bool) {
+		t.Helper()
+		cluster := &VMCluster{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}, Spec: *cr, ParsedLastAppliedSpec: prev}
+		err := cluster.CheckRetentionDecrease()
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	withFlag := func(retention, flag string) *VMClusterSpec {
+		spec := &VMClusterSpec{RetentionPeriod: retention, VMStorage: &VMStorage{}}
+		if flag != "" {
+			spec.VMStorage.ExtraArgs = map[string]string{"retentionPeriod": flag}
+		}
+		return spec
+	}
+
+	// decreased with extraArgs
+	f(withFlag("1y", ""), withFlag("1y", "30d"), nil, true)
+
+	// decrease confirmed
+	f(withFlag("1y", ""), withFlag("1y", "30d"), map[string]string{RetentionDecreaseConfirmAnnotation: "30d"}, false)
+
+	// extraArgs removed
+	f(withFlag("1y", "30d"), withFlag("1y", ""), nil, false)
+	f(withFlag("1", "1y"), withFlag("1", ""), nil, true)
+
+	// spec changes are overridden by extraArgs
+	f(withFlag("1y", "1y"), withFlag("30d", "1y"), nil, false)
+}
+
+func TestSetUpdateStatusToSkipsStaleStatus(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("cannot build scheme: %s", err)
+	}
+	newCR := func(generation int64) *VMSingle {
+		return &VMSingle{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default", Generation: generation},
+		}
+	}
+	actual := newCR(3)
+	actual.Status.ObservedGeneration = 3
+	actual.Status.UpdateStatus = UpdateStatusOperational
+	rclient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&VMSingle{}).
+		WithObjects(actual).
+		Build()
+	getStatus := func() VMSingleStatus {
+		t.Helper()
+		var got VMSingle
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "main"}, &got); err != nil {
+			t.Fatalf("cannot get object: %s", err)
+		}
+		return got.Status
+	}
+
+	// reconcile of the outdated generation must not override status
+	if err := newCR(2).SetUpdateStatusTo(ctx, rclient, UpdateStatusFailed, fmt.Errorf("outdated error")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if st := getStatus(); st.UpdateStatus != UpdateStatusOperational || st.ObservedGeneration != 3 {
+		t.Fatalf("unexpected status update for outdated generation: %v", st)
+	}
+
+	// reconcile of the actual generation updates status
+	if err := newCR(3).SetUpdateStatusTo(ctx, rclient, UpdateStatusFailed, fmt.Errorf("actual error")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if st := getStatus(); st.UpdateStatus != UpdateStatusFailed || st.Reason != "actual error" {
+		t.Fatalf("expected status update for actual generation: %v", st)
+	}
+}
+
+func TestCheckExtraArgs(t *testing.T) {
+	f := func(extraArgs map[string]string, wantErr bool) {
+		t.Helper()
+		if err := checkExtraArgs(extraArgs); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	f(nil, false)
+	f(map[string]string{"remoteWrite.tmpDataPath": "/tmp", "-loggerLevel": "WARN"}, false)
+	f(map[string]string{"promscrape.config": "/etc/scrape.yaml"}, false)
+	f(map[string]string{"": "value"}, true)
+	f(map[string]string{"--": "value"}, true)
+	f(map[string]string{"loggerLevel=WARN": ""}, true)
+	f(map[string]string{"loggerLevel WARN": ""}, true)
+}
+
+func TestCheckExtraArgsFlags(t *testing.T) {
+	f := func(extraArgs map[string]string, known knownFlags, managedFlags []string, want admission.Warnings) {
+		t.Helper()
+		got := checkExtraArgsFlags("spec.extraArgs", extraArgs, known, managedFlags...)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected warnings\ngot:  %v\nwant: %v", got, want)
+		}
+	}
+	f(nil, vmagentFlags, []string{"promscrape.config"}, nil)
+	f(map[string]string{
+		"remoteWrite.tmpDataPath":  "/tmp",
+		"-loggerLevel":             "WARN",
+		"httpListenAddr":           ":8429",
+		"promscrape.maxScrapeSize": "16MiB",
+		"influxSkipSingleField":    "true",
+	}, vmagentFlags, []string{"promscrape.config"}, nil)
+	f(map[string]string{"promscrape.config": "/etc/scrape.yaml"}, vmagentFlags, []string{"promscrape.config"}, admission.Warnings{
+		`spec.extraArgs flag name="promscrape.config" conflicts with flag managed by operator, it overrides operator generated configuration`,
+	})
+	f(map[string]string{"--config.file": "/etc/am.yaml", "web.route-prefix": "/am"}, vmalertmanagerFlags, []string{"config.file"}, admission.Warnings{
+		`spec.extraArgs flag name="--config.file" conflicts with flag managed by operator, it overrides operator generated configuration`,
+	})
+	f(map[string]string{"remoteWrite.url": "http://vminsert", "storageDataPath": "/data"}, vmselectFlags, nil, admission.Warnings{
+		`spec.extraArgs flag name="remoteWrite.url" is unknown for application and may prevent it from start`,
+		`spec.extraArgs flag name="storageDataPath" is unknown for application and may prevent it from start`,
+	})
+	f(map[string]string{"retentionPeriod": "1y", "search.maxUniqueTimeseries": "1000000"}, vmstorageFlags, nil, nil)
+}
+
+func TestParseImageVersion(t *testing.T) {
+	f := func(tag string, want ImageVersion, wantOk bool) {
+		t.Helper()
+		got, ok := ParseImageVersion(tag)
+		if ok != wantOk {
+			t.Fatalf("unexpected parse result for tag=%q, got: %v, want: %v", tag, ok, wantOk)
+		}
+		if ok && got != want {
+			t.Fatalf("unexpected version for tag=%q, got: %s, want: %s", tag, got, want)
+		}
+	}
+	f("v1.107.0", ImageVersion{Major: 1, Minor: 107}, true)
+	f("v1.102.1-cluster", ImageVersion{Major: 1, Minor: 102, Patch: 1}, true)
+	f("v1.93.16-enterprise-cluster", ImageVersion{Major: 1, Minor: 93, Patch: 16}, true)
+	f("1.100.0@sha256:4fc8b5a9", ImageVersion{Major: 1, Minor: 100}, true)
+	f("latest", ImageVersion{}, false)
+	f("v1.107", ImageVersion{}, false)
+	f("", ImageVersion{}, false)
+}
+
+func TestVMAgentUnsupportedFeatures(t *testing.T) {
+	f := func(tag string, want []string) {
+		t.Helper()
+		cr := &VMAgent{
+			Spec: VMAgentSpec{
+				CommonDefaultableParams: CommonDefaultableParams{Image: Image{Tag: tag}},
+				StreamAggrConfig:        &StreamAggrConfig{DropInputLabels: []string{"pod"}},
+				RemoteWrite: []VMAgentRemoteWriteSpec{
+					{URL: "http://vminsert", StreamAggrConfig: &StreamAggrConfig{IgnoreFirstIntervals: 1}},
+				},
+			},
+		}
+		got := cr.UnsupportedFeatures()
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected unsupported features\ngot:  %v\nwant: %v", got, want)
+		}
+		var st StatusMetadata
+		setUnsupportedFeaturesCondition(&st, got, 1)
+		if len(want) > 0 && getCondition(st.Conditions, ConditionTypeUnsupportedFeatures) == nil {
+			t.Fatalf("expected %s condition", ConditionTypeUnsupportedFeatures)
+		}
+		if len(want) == 0 && len(st.Conditions) > 0 {
+			t.Fatalf("unexpected conditions: %v", st.Conditions)
+		}
+	}
+	f("v1.107.0", nil)
+	f("latest", nil)
+	f("v1.100.0", []string{"spec.remoteWrite[0].streamAggrConfig.ignoreFirstIntervals requires v1.101.0"})
+	f("v1.99.0-scratch", []string{
+		"spec.streamAggrConfig.dropInputLabels requires v1.100.0",
+		"spec.remoteWrite[0].streamAggrConfig.ignoreFirstIntervals requires v1.101.0",
+	})
+}
+
+func TestEmbeddedPodDisruptionBudgetSpecSanityCheck(t *testing.T) {
+	f := func(spec *EmbeddedPodDisruptionBudgetSpec, wantErr bool) {
+		t.Helper()
+		if err := spec.sanityCheck(); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	f(nil, false)
+	f(&EmbeddedPodDisruptionBudgetSpec{MinAvailable: ptr.To(intstr.FromInt32(1))}, false)
+	f(&EmbeddedPodDisruptionBudgetSpec{MaxUnavailable: ptr.To(intstr.FromString("25%"))}, false)
+	f(&EmbeddedPodDisruptionBudgetSpec{MinAvailable: ptr.To(intstr.FromInt32(1)), MaxUnavailable: ptr.To(intstr.FromInt32(1))}, true)
+}
+
+func TestCheckClaimTemplates(t *testing.T) {
+	f := func(claims []v1.PersistentVolumeClaim, wantErr bool) {
+		t.Helper()
+		if err := checkClaimTemplates(claims, "vmstorage-db"); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	claim := func(name string) v1.PersistentVolumeClaim {
+		return v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	f(nil, false)
+	f([]v1.PersistentVolumeClaim{claim("audit-logs"), claim("cache")}, false)
+	f([]v1.PersistentVolumeClaim{claim("")}, true)
+	f([]v1.PersistentVolumeClaim{claim("vmstorage-db")}, true)
+	f([]v1.PersistentVolumeClaim{claim("cache"), claim("cache")}, true)
+}
+
+func TestEmbeddedIngressSanityCheck(t *testing.T) {
+	f := func(spec *EmbeddedIngress, wantErr bool) {
+		t.Helper()
+		if err := spec.sanityCheck(); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	f(nil, false)
+	f(&EmbeddedIngress{Host: "vm.example.com"}, false)
+	f(&EmbeddedIngress{TlsHosts: []string{"vm.example.com"}, TlsSecretName: "vm-tls"}, false)
+	f(&EmbeddedIngress{TlsHosts: []string{"vm.example.com"}}, true)
+	f(&EmbeddedIngress{TlsSecretName: "vm-tls"}, true)
+}
+
+func TestEmbeddedRouteSanityCheck(t *testing.T) {
+	f := func(spec *EmbeddedRoute, wantErr bool) {
+		t.Helper()
+		if err := spec.sanityCheck(); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	f(nil, false)
+	f(&EmbeddedRoute{Host: "vm.apps.example.com", Path: "/"}, false)
+	f(&EmbeddedRoute{TLSTermination: "edge", InsecureEdgeTerminationPolicy: "Redirect"}, false)
+	f(&EmbeddedRoute{InsecureEdgeTerminationPolicy: "Redirect"}, true)
+	f(&EmbeddedRoute{TLSTermination: "passthrough", Path: "/select"}, true)
+	f(&EmbeddedRoute{TLSTermination: "passthrough", InsecureEdgeTerminationPolicy: "Allow"}, true)
+}
+
+func TestEmbeddedVPASanityCheck(t *testing.T) {
+	f := func(spec *EmbeddedVPA, wantErr bool) {
+		t.Helper()
+		if err := spec.sanityCheck(); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	f(nil, false)
+	f(&EmbeddedVPA{UpdateMode: "Auto"}, false)
+	f(&EmbeddedVPA{
+		MinAllowed: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+		MaxAllowed: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1"), v1.ResourceMemory: resource.MustParse("1Gi")},
+	}, false)
+	f(&EmbeddedVPA{
+		MinAllowed: v1.ResourceList{v1.ResourceMemory: resource.MustParse("2Gi")},
+		MaxAllowed: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+	}, true)
+}
+
+func TestCheckAdditionalServices(t *testing.T) {
+	f := func(services []AdditionalServiceSpec, serviceSpec *AdditionalServiceSpec, wantErr bool) {
+		t.Helper()
+		if err := checkAdditionalServices(services, "vmauth-main", serviceSpec); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	svc := func(name string) AdditionalServiceSpec {
+		return AdditionalServiceSpec{EmbeddedObjectMetadata: EmbeddedObjectMetadata{Name: name}}
+	}
+	f(nil, nil, false)
+	f([]AdditionalServiceSpec{svc("internal"), svc("external")}, nil, false)
+	f([]AdditionalServiceSpec{svc("vmauth-main-additional-service")}, &AdditionalServiceSpec{UseAsDefault: true}, false)
+	f([]AdditionalServiceSpec{svc("")}, nil, true)
+	f([]AdditionalServiceSpec{svc("internal"), svc("internal")}, nil, true)
+	f([]AdditionalServiceSpec{svc("vmauth-main")}, nil, true)
+	f([]AdditionalServiceSpec{svc("vmauth-main-additional-service")}, &AdditionalServiceSpec{}, true)
+	f([]AdditionalServiceSpec{{UseAsDefault: true, EmbeddedObjectMetadata: EmbeddedObjectMetadata{Name: "internal"}}}, nil, true)
+	f(nil, &AdditionalServiceSpec{UseAsDefault: true, Spec: v1.ServiceSpec{
+		SessionAffinity:       v1.ServiceAffinityClientIP,
+		SessionAffinityConfig: &v1.SessionAffinityConfig{ClientIP: &v1.ClientIPConfig{TimeoutSeconds: ptr.To[int32](600)}},
+	}}, false)
+	f(nil, &AdditionalServiceSpec{UseAsDefault: true, Spec: v1.ServiceSpec{
+		SessionAffinityConfig: &v1.SessionAffinityConfig{ClientIP: &v1.ClientIPConfig{TimeoutSeconds: ptr.To[int32](600)}},
+	}}, true)
+	f([]AdditionalServiceSpec{{
+		EmbeddedObjectMetadata: EmbeddedObjectMetadata{Name: "headless"},
+		Spec:                   v1.ServiceSpec{ClusterIP: v1.ClusterIPNone, SessionAffinity: v1.ServiceAffinityClientIP},
+	}}, nil, true)
+}
+
+func TestServerTLS(t *testing.T) {
+	var st *ServerTLS
+	args := st.MaybeAddToArgs([]string{"-httpListenAddr=:8429"}, ServerTLSDir)
+	if len(args) != 1 {
+		t.Fatalf("unexpected args for nil serverTLS: %v", args)
+	}
+	st = &ServerTLS{SecretName: "vmsingle-tls", MinVersion: "TLS13"}
+	args = st.MaybeAddToArgs(nil, ServerTLSDir)
+	wantArgs := []string{
+		"-tls=true",
+		"-tlsCertFile=/etc/vm/server-tls/tls.crt",
+		"-tlsKeyFile=/etc/vm/server-tls/tls.key",
+		"-tlsMinVersion=TLS13",
+	}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Fatalf("unexpected args, got: %v, want: %v", args, wantArgs)
+	}
+	volumes, mounts := st.MaybeAddToVolumes(nil, nil, ServerTLSDir)
+	if len(volumes) != 1 || volumes[0].Secret == nil || volumes[0].Secret.SecretName != "vmsingle-tls" {
+		t.Fatalf("unexpected volumes: %v", volumes)
+	}
+	if len(mounts) != 1 || mounts[0].MountPath != ServerTLSDir || mounts[0].Name != volumes[0].Name {
+		t.Fatalf("unexpected mounts: %v", mounts)
+	}
+
+	cr := &VMSingle{
+		ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "default"},
+		Spec: VMSingleSpec{
+			CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+				ExtraArgs: map[string]string{"search.maxUniqueTimeseries": "100"},
+			},
+		},
+	}
+	cr.Spec.Port = "8429"
+	if got := cr.ProbeScheme(); got != "HTTP" {
+		t.Fatalf("unexpected probe scheme: %q", got)
+	}
+	cr.Spec.ServerTLS = st
+	if got := cr.ProbeScheme(); got != "HTTPS" {
+		t.Fatalf("unexpected probe scheme: %q", got)
+	}
+	if got := cr.AsURL(); got != "https://vmsingle-single.default.svc:8429" {
+		t.Fatalf("unexpected url: %q", got)
+	}
+	if cr.GetExtraArgs()["tls"] != "true" {
+		t.Fatalf("tls flag must be set for extraArgs: %v", cr.GetExtraArgs())
+	}
+	if _, ok := cr.Spec.ExtraArgs["tls"]; ok {
+		t.Fatalf("spec extraArgs must not be modified")
+	}
+
+	f := func(spec *ServerTLS, wantErr bool) {
+		t.Helper()
+		if err := spec.sanityCheck(); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	f(nil, false)
+	f(st, false)
+	f(&ServerTLS{}, true)
+	f(&ServerTLS{SecretName: "tls", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"}}, false)
+	f(&ServerTLS{SecretName: "tls", CipherSuites: []string{"TLS_UNKNOWN_SUITE"}}, true)
+
+	st.CipherSuites = []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"}
+	args = st.MaybeAddToArgs(nil, ServerTLSDir)
+	if !slices.Contains(args, "-tlsCipherSuites=TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384") {
+		t.Fatalf("cipher suites flag is missing: %v", args)
+	}
+}
+
+func TestCheckServiceAccountTokens(t *testing.T) {
+	f := func(tokens []VMAgentServiceAccountToken, wantErr bool) {
+		t.Helper()
+		if err := checkServiceAccountTokens(tokens); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	f(nil, false)
+	f([]VMAgentServiceAccountToken{{Name: "apiserver", Audience: "https://kubernetes.default.svc"}, {Name: "metrics-server", ExpirationSeconds: ptr.To[int64](600)}}, false)
+	f([]VMAgentServiceAccountToken{{Audience: "vmagent"}}, true)
+	f([]VMAgentServiceAccountToken{{Name: "Bad_Name"}}, true)
+	f([]VMAgentServiceAccountToken{{Name: "apiserver"}, {Name: "apiserver"}}, true)
+	f([]VMAgentServiceAccountToken{{Name: "apiserver", ExpirationSeconds: ptr.To[int64](60)}}, true)
+
+	tk := VMAgentServiceAccountToken{Name: "apiserver"}
+	if got := tk.TokenPath(); got != "/var/run/secrets/vmagent/tokens/apiserver/token" {
+		t.Fatalf("unexpected token path: %q", got)
+	}
+}
+
+func TestCloudIdentity(t *testing.T) {
+	f := func(ci *CloudIdentity, serviceAccountName string, backup *VMBackup, wantAnnotations map[string]string, wantErr bool) {
+		t.Helper()
+		if err := ci.sanityCheck(serviceAccountName, backup); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if got := ci.ServiceAccountAnnotations(); !reflect.DeepEqual(got, wantAnnotations) {
+			t.Fatalf("unexpected annotations, got: %v, want: %v", got, wantAnnotations)
+		}
+	}
+	f(nil, "custom", &VMBackup{CredentialsSecret: &v1.SecretKeySelector{Key: "creds"}}, nil, false)
+	f(&CloudIdentity{}, "custom", nil, nil, false)
+	f(&CloudIdentity{AWSRoleARN: "arn:aws:iam::123456789012:role/vm"}, "", &VMBackup{}, map[string]string{
+		"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/vm",
+	}, false)
+	f(&CloudIdentity{AWSRoleARN: "arn:aws:iam::123456789012:role/vm", GCPServiceAccount: "vm@project.iam.gserviceaccount.com"}, "", nil, map[string]string{
+		"eks.amazonaws.com/role-arn":     "arn:aws:iam::123456789012:role/vm",
+		"iam.gke.io/gcp-service-account": "vm@project.iam.gserviceaccount.com",
+	}, false)
+	f(&CloudIdentity{GCPServiceAccount: "vm@project.iam.gserviceaccount.com"}, "custom", nil, map[string]string{
+		"iam.gke.io/gcp-service-account": "vm@project.iam.gserviceaccount.com",
+	}, true)
+	f(&CloudIdentity{GCPServiceAccount: "vm@project.iam.gserviceaccount.com"}, "", &VMBackup{CredentialsSecret: &v1.SecretKeySelector{Key: "creds"}}, map[string]string{
+		"iam.gke.io/gcp-service-account": "vm@project.iam.gserviceaccount.com",
+	}, true)
+}
+
+func TestGrafanaDashboardSanityCheck(t *testing.T) {
+	f := func(gd *GrafanaDashboard, wantErr bool) {
+		t.Helper()
+		if err := gd.sanityCheck(); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}}
+	f(nil, false)
+	f(&GrafanaDashboard{InstanceSelector: selector, Folder: "vm"}, false)
+	f(&GrafanaDashboard{Mode: GrafanaDashboardModeConfigMap}, false)
+	f(&GrafanaDashboard{Mode: GrafanaDashboardModeConfigMap, ConfigMapLabels: map[string]string{"dashboard": "true"}}, false)
+	f(&GrafanaDashboard{}, true)
+	f(&GrafanaDashboard{Mode: GrafanaDashboardModeCR, InstanceSelector: selector, ConfigMapLabels: map[string]string{"dashboard": "true"}}, true)
+	f(&GrafanaDashboard{Mode: GrafanaDashboardModeConfigMap, InstanceSelector: selector}, true)
+}
+
+func TestCheckRollingUpdate(t *testing.T) {
+	f := func(updateStrategy *appsv1.DeploymentStrategyType, ru *appsv1.RollingUpdateDeployment, wantErr bool) {
+		t.Helper()
+		if err := checkRollingUpdate(updateStrategy, ru); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	intVal := func(v int) *intstr.IntOrString {
+		return ptr.To(intstr.FromInt(v))
+	}
+	strVal := func(v string) *intstr.IntOrString {
+		return ptr.To(intstr.FromString(v))
+	}
+	recreate := ptr.To(appsv1.RecreateDeploymentStrategyType)
+	f(nil, nil, false)
+	f(recreate, nil, false)
+	f(nil, &appsv1.RollingUpdateDeployment{MaxSurge: intVal(0), MaxUnavailable: intVal(1)}, false)
+	f(ptr.To(appsv1.RollingUpdateDeploymentStrategyType), &appsv1.RollingUpdateDeployment{MaxSurge: strVal("10%")}, false)
+	f(nil, &appsv1.RollingUpdateDeployment{MaxSurge: strVal("0%")}, false)
+	f(recreate, &appsv1.RollingUpdateDeployment{MaxSurge: intVal(1)}, true)
+	f(nil, &appsv1.RollingUpdateDeployment{MaxSurge: intVal(0), MaxUnavailable: strVal("0%")}, true)
+	f(nil, &appsv1.RollingUpdateDeployment{MaxSurge: intVal(-1)}, true)
+	f(nil, &appsv1.RollingUpdateDeployment{MaxUnavailable: strVal("ten")}, true)
+}
+
+func TestCRMetadataPropagation(t *testing.T) {
+	cr := &VMAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "example",
+			Labels:      map[string]string{"team": "infra"},
+			Annotations: map[string]string{"cert-manager.io/issuer": "ca", "kubectl.kubernetes.io/last-applied-configuration": "{}"},
+		},
+		Spec: VMAgentSpec{
+			ManagedMetadata: &ManagedObjectsMetadata{
+				Labels:      map[string]string{"env": "prod"},
+				Annotations: map[string]string{"owner": "monitoring"},
+			},
+		},
+	}
+	f := func(disabled bool, propagate *bool, wantAnnotations map[string]string, wantLabel string) {
+		t.Helper()
+		cr.Spec.ManagedMetadata.PropagateCRMetadata = propagate
+		SetCRMetadataPropagation(disabled)
+		defer SetCRMetadataPropagation(false)
+		if got := cr.AnnotationsFiltered(); !reflect.DeepEqual(got, wantAnnotations) {
+			t.Fatalf("unexpected annotations, got: %v, want: %v", got, wantAnnotations)
+		}
+		if got := cr.AllLabels()["team"]; got != wantLabel {
+			t.Fatalf("unexpected team label, got: %q, want: %q", got, wantLabel)
+		}
+		if got := cr.AllLabels()["env"]; got != "prod" {
+			t.Fatalf("managed label must be always propagated, got: %q", got)
+		}
+	}
+
+	// CR metadata propagated
+	f(false, nil, map[string]string{"cert-manager.io/issuer": "ca", "owner": "monitoring"}, "infra")
+
+	// only managed metadata
+	f(true, nil, map[string]string{"owner": "monitoring"}, "")
+
+	// propagation disabled per CR
+	f(false, ptr.To(false), map[string]string{"owner": "monitoring"}, "")
+
+	// propagation enabled per CR
+	f(true, ptr.To(true), map[string]string{"cert-manager.io/issuer": "ca", "owner": "monitoring"}, "infra")
+}
+
+func TestPVCRetentionPolicy(t *testing.T) {
+	f := func(retainPVCs *bool, policy, want *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy, wantDeleted bool) {
+		t.Helper()
+		got := pvcRetentionPolicy(retainPVCs, policy)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected policy, got: %v, want: %v", got, want)
+		}
+		if IsPVCDeletedWithOwner(got) != wantDeleted {
+			t.Fatalf("unexpected pvc deletion, want: %v", wantDeleted)
+		}
+	}
+	policy := func(whenDeleted, whenScaled appsv1.PersistentVolumeClaimRetentionPolicyType) *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy {
+		return &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{WhenDeleted: whenDeleted, WhenScaled: whenScaled}
+	}
+	retain, del := appsv1.RetainPersistentVolumeClaimRetentionPolicyType, appsv1.DeletePersistentVolumeClaimRetentionPolicyType
+
+	// not configured
+	f(nil, nil, nil, false)
+
+	// statefulset policy only
+	f(nil, policy(del, retain), policy(del, retain), true)
+	f(nil, policy(retain, del), policy(retain, del), false)
+
+	// retainPVCs only
+	f(ptr.To(false), nil, policy(del, retain), true)
+	f(ptr.To(true), nil, policy(retain, retain), false)
+
+	// retainPVCs overrides whenDeleted
+	f(ptr.To(true), policy(del, del), policy(retain, del), false)
+	f(ptr.To(false), policy(retain, del), policy(del, del), true)
+}
+
+func TestParseTLSVersion(t *testing.T) {
+	f := func(name string, want uint16, wantErr bool) {
+		t.Helper()
+		got, err := ParseTLSVersion(name)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, want error: %v", err, wantErr)
+		}
+		if got != want {
+			t.Fatalf("unexpected version, got: %d, want: %d", got, want)
+		}
+	}
+	f("", 0, false)
+	f("TLS12", tls.VersionTLS12, false)
+	f("TLS13", tls.VersionTLS13, false)
+	f("TLS1.3", 0, true)
+}
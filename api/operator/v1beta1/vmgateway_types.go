@@ -0,0 +1,365 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VMGatewaySpec defines the desired state of VMGateway
+// +k8s:openapi-gen=true
+type VMGatewaySpec struct {
+	// ParsingError contents error with context if operator was failed to parse json object from kubernetes api server
+	ParsingError string `json:"-" yaml:"-"`
+
+	// PodMetadata configures Labels and Annotations which are propagated to the VMGateway pods.
+	// +optional
+	PodMetadata *EmbeddedObjectMetadata `json:"podMetadata,omitempty"`
+	// ManagedMetadata defines metadata that will be added to the all objects
+	// created by operator for the given CustomResource
+	ManagedMetadata *ManagedObjectsMetadata `json:"managedMetadata,omitempty"`
+
+	CommonDefaultableParams           `json:",inline,omitempty"`
+	CommonApplicationDeploymentParams `json:",inline,omitempty"`
+
+	// LogLevel for VMGateway to be configured with.
+	// +optional
+	// +kubebuilder:validation:Enum=INFO;WARN;ERROR;FATAL;PANIC
+	LogLevel string `json:"logLevel,omitempty"`
+	// LogFormat for VMGateway to be configured with.
+	// +optional
+	// +kubebuilder:validation:Enum=default;json
+	LogFormat string `json:"logFormat,omitempty"`
+	// ClusterRef defines VMCluster at the same namespace,
+	// requests are proxied to its vmselect and vminsert services
+	ClusterRef v1.LocalObjectReference `json:"clusterRef"`
+	// Auth configures JWT tokens verification,
+	// tenant for the request is extracted from vm_access claim of the token
+	// +optional
+	Auth *VMGatewayAuth `json:"auth,omitempty"`
+	// RateLimits defines limits for tenants,
+	// gateway uses vmselect of the cluster as datasource for its own metrics
+	// +optional
+	RateLimits []VMGatewayRateLimit `json:"rateLimits,omitempty"`
+	// License allows to configure license key to be used for enterprise features.
+	// vmgateway is available only at enterprise version of VictoriaMetrics
+	// see [here](https://docs.victoriametrics.com/enterprise)
+	// +optional
+	License *License `json:"license,omitempty"`
+	// ServiceSpec that will be added to vmgateway service spec
+	// +optional
+	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// AdditionalServices defines a list of extra services that will be created for vmgateway
+	// in addition to the default one
+	// +optional
+	AdditionalServices []AdditionalServiceSpec `json:"additionalServices,omitempty"`
+	// ServiceScrapeSpec that will be added to vmgateway VMServiceScrape spec
+	// +optional
+	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
+	// PodDisruptionBudget created by operator
+	// +optional
+	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	// LivenessProbe that will be added to VMGateway pod
+	*EmbeddedProbes `json:",inline"`
+
+	// ServiceAccountName is the name of the ServiceAccount to use to run the pods
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// VMGatewayAuth defines JWT verification settings for vmgateway
+type VMGatewayAuth struct {
+	// PublicKeys defines PEM encoded public keys for token signature verification
+	// +optional
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// OIDCDiscoveryEndpoints defines OpenID Connect discovery endpoints for signing keys fetching
+	// +optional
+	OIDCDiscoveryEndpoints []string `json:"oidcDiscoveryEndpoints,omitempty"`
+	// JWKSEndpoints defines JSON Web Key Set endpoints for signing keys fetching
+	// +optional
+	JWKSEndpoints []string `json:"jwksEndpoints,omitempty"`
+}
+
+// VMGatewayRateLimit defines single rate limit rule
+type VMGatewayRateLimit struct {
+	// Type of the limit
+	// +kubebuilder:validation:Enum=queries;rows_inserted;new_series;active_series
+	Type string `json:"type" yaml:"type"`
+	// Value defines max number of events per resolution
+	// +kubebuilder:validation:Minimum=1
+	Value int64 `json:"value" yaml:"value"`
+	// Resolution of the limit
+	// +kubebuilder:validation:Enum=minute;hour;day
+	Resolution string `json:"resolution" yaml:"resolution"`
+	// AccountID limits rule to the given tenant
+	// rule is applied to all tenants if omitted
+	// +optional
+	AccountID *int32 `json:"accountID,omitempty" yaml:"account_id,omitempty"`
+	// ProjectID limits rule to the given tenant project
+	// +optional
+	ProjectID *int32 `json:"projectID,omitempty" yaml:"project_id,omitempty"`
+}
+
+// VMGatewayStatus defines the observed state of VMGateway
+type VMGatewayStatus struct {
+	StatusMetadata `json:",inline"`
+}
+
+// GetStatusMetadata returns metadata for object status
+func (cr *VMGatewayStatus) GetStatusMetadata() *StatusMetadata {
+	return &cr.StatusMetadata
+}
+
+// VMGateway is enterprise proxy for VMCluster with rate limiting and JWT based multitenancy
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="VMGateway"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Deployment,apps"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Service,v1"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Secret,v1"
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmgateways,scope=Namespaced
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus",description="Current status of gateway update process"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// VMGateway is the Schema for the vmgateways API
+type VMGateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VMGatewaySpec `json:"spec,omitempty"`
+	// ParsedLastAppliedSpec contains last-applied configuration spec
+	ParsedLastAppliedSpec *VMGatewaySpec `json:"-" yaml:"-"`
+
+	Status VMGatewayStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VMGatewayList contains a list of VMGateway
+type VMGatewayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMGateway `json:"items"`
+}
+
+func (r *VMGateway) PodAnnotations() map[string]string {
+	annotations := map[string]string{}
+	if r.Spec.PodMetadata != nil {
+		for annotation, value := range r.Spec.PodMetadata.Annotations {
+			annotations[annotation] = value
+		}
+	}
+	return annotations
+}
+
+// AsOwner returns owner references with current object as owner
+func (r *VMGateway) AsOwner() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         r.APIVersion,
+			Kind:               r.Kind,
+			Name:               r.Name,
+			UID:                r.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+func (cr *VMGateway) setLastSpec(prevSpec VMGatewaySpec) {
+	cr.ParsedLastAppliedSpec = &prevSpec
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMGateway) UnmarshalJSON(src []byte) error {
+	type pcr VMGateway
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		return err
+	}
+	if err := parseLastAppliedState(cr); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMGatewaySpec) UnmarshalJSON(src []byte) error {
+	type pcr VMGatewaySpec
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		cr.ParsingError = fmt.Sprintf("cannot parse vmgateway spec: %s, err: %s", string(src), err)
+		return nil
+	}
+	return nil
+}
+
+func (r *VMGateway) Probe() *EmbeddedProbes {
+	return r.Spec.EmbeddedProbes
+}
+
+func (r *VMGateway) ProbePath() string {
+	return buildPathWithPrefixFlag(r.Spec.ExtraArgs, healthPath)
+}
+
+func (r *VMGateway) ProbeScheme() string {
+	return strings.ToUpper(protoFromFlags(r.Spec.ExtraArgsWithServerTLS()))
+}
+
+func (r *VMGateway) ProbePort() string {
+	return r.Spec.Port
+}
+
+func (r *VMGateway) ProbeNeedLiveness() bool {
+	return false
+}
+
+func (r *VMGateway) AnnotationsFiltered() map[string]string {
+	if r.Spec.ManagedMetadata == nil {
+		return nil
+	}
+	dst := make(map[string]string, len(r.Spec.ManagedMetadata.Annotations))
+	for k, v := range r.Spec.ManagedMetadata.Annotations {
+		dst[k] = v
+	}
+	return dst
+}
+
+func (r *VMGateway) SelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vmgateway",
+		"app.kubernetes.io/instance":  r.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+func (r *VMGateway) PodLabels() map[string]string {
+	lbls := r.SelectorLabels()
+	if r.Spec.PodMetadata == nil {
+		return lbls
+	}
+	return labels.Merge(r.Spec.PodMetadata.Labels, lbls)
+}
+
+func (r *VMGateway) AllLabels() map[string]string {
+	selectorLabels := r.SelectorLabels()
+	// fast path
+	if r.Spec.ManagedMetadata == nil {
+		return selectorLabels
+	}
+	return labels.Merge(r.Spec.ManagedMetadata.Labels, selectorLabels)
+}
+
+func (r VMGateway) PrefixedName() string {
+	return fmt.Sprintf("vmgateway-%s", r.Name)
+}
+
+// GetMetricPath returns prefixed path for metric requests
+func (r VMGateway) GetMetricPath() string {
+	return buildPathWithPrefixFlag(r.Spec.ExtraArgs, metricPath)
+}
+
+// GetExtraArgs returns additionally configured command-line arguments
+func (r VMGateway) GetExtraArgs() map[string]string {
+	return r.Spec.ExtraArgsWithServerTLS()
+}
+
+// GetServiceScrape returns overrides for serviceScrape builder
+func (r VMGateway) GetServiceScrape() *VMServiceScrapeSpec {
+	return r.Spec.ServiceScrapeSpec
+}
+
+func (r VMGateway) GetServiceAccountName() string {
+	if r.Spec.ServiceAccountName == "" {
+		return r.PrefixedName()
+	}
+	return r.Spec.ServiceAccountName
+}
+
+func (r VMGateway) IsOwnsServiceAccount() bool {
+	return r.Spec.ServiceAccountName == ""
+}
+
+func (r VMGateway) GetNSName() string {
+	return r.GetNamespace()
+}
+
+func (r *VMGateway) AsURL() string {
+	port := r.Spec.Port
+	if port == "" {
+		port = "8431"
+	}
+	if r.Spec.ServiceSpec != nil && r.Spec.ServiceSpec.UseAsDefault {
+		for _, svcPort := range r.Spec.ServiceSpec.Spec.Ports {
+			if svcPort.Name == "http" {
+				port = fmt.Sprintf("%d", svcPort.Port)
+				break
+			}
+		}
+	}
+	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(r.Spec.ExtraArgsWithServerTLS()), r.PrefixedName(), r.Namespace, port)
+}
+
+// ConfigSecretName returns name of secret with rate limits config
+func (r *VMGateway) ConfigSecretName() string {
+	return fmt.Sprintf("vmgateway-config-%s", r.Name)
+}
+
+// LastAppliedSpecAsPatch return last applied vmgateway spec as patch annotation
+func (r *VMGateway) LastAppliedSpecAsPatch() (client.Patch, error) {
+	return lastAppliedChangesAsPatch(r.ObjectMeta, r.Spec)
+}
+
+// HasSpecChanges compares vmgateway spec with last applied vmgateway spec stored in annotation
+func (r *VMGateway) HasSpecChanges() (bool, error) {
+	return hasStateChanges(r.ObjectMeta, r.Spec)
+}
+
+func (r *VMGateway) Paused() bool {
+	return r.Spec.Paused
+}
+
+// SetStatusTo changes update status with optional reason of fail
+func (r *VMGateway) SetUpdateStatusTo(ctx context.Context, c client.Client, status UpdateStatus, maybeErr error) error {
+	return updateObjectStatus(ctx, c, &patchStatusOpts[*VMGateway, *VMGatewayStatus]{
+		actualStatus: status,
+		cr:           r,
+		crStatus:     &r.Status,
+		maybeErr:     maybeErr,
+	})
+}
+
+// GetAdditionalService returns AdditionalServiceSpec settings
+func (r *VMGateway) GetAdditionalService() *AdditionalServiceSpec {
+	return r.Spec.ServiceSpec
+}
+
+func init() {
+	SchemeBuilder.Register(&VMGateway{}, &VMGatewayList{})
+}
@@ -38,6 +38,7 @@ type VMNodeScrapeSpec struct {
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
 // +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+// +kubebuilder:storageversion
 // +genclient
 type VMNodeScrape struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -71,6 +72,9 @@ func (cr *VMNodeScrape) GetStatusMetadata() *StatusMetadata {
 	return &cr.Status.StatusMetadata
 }
 
+// Hub marks v1beta1 as conversion hub for VMNodeScrape
+func (*VMNodeScrape) Hub() {}
+
 func init() {
 	SchemeBuilder.Register(&VMNodeScrape{}, &VMNodeScrapeList{})
 }
@@ -49,6 +49,7 @@ type VMPodScrapeSpec struct {
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
 // +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+// +kubebuilder:storageversion
 // +genclient
 type VMPodScrape struct {
 	metav1.TypeMeta `json:",inline"`
@@ -118,6 +119,9 @@ func (cr *VMPodScrape) GetStatusMetadata() *StatusMetadata {
 	return &cr.Status.StatusMetadata
 }
 
+// Hub marks v1beta1 as conversion hub for VMPodScrape
+func (*VMPodScrape) Hub() {}
+
 func init() {
 	SchemeBuilder.Register(&VMPodScrape{}, &VMPodScrapeList{})
 }
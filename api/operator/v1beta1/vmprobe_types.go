@@ -98,6 +98,7 @@ type VMProberSpec struct {
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
 // +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+// +kubebuilder:storageversion
 // +genclient
 // +k8s:openapi-gen=true
 type VMProbe struct {
@@ -132,6 +133,9 @@ func (cr *VMProbe) GetStatusMetadata() *StatusMetadata {
 	return &cr.Status.StatusMetadata
 }
 
+// Hub marks v1beta1 as conversion hub for VMProbe
+func (*VMProbe) Hub() {}
+
 func init() {
 	SchemeBuilder.Register(&VMProbe{}, &VMProbeList{})
 }
@@ -31,6 +31,7 @@ import (
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
 // +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+// +kubebuilder:storageversion
 // +genclient
 type VMScrapeConfig struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -518,6 +519,9 @@ func (cr *VMScrapeConfig) GetStatusMetadata() *StatusMetadata {
 	return &cr.Status.StatusMetadata
 }
 
+// Hub marks v1beta1 as conversion hub for VMScrapeConfig
+func (*VMScrapeConfig) Hub() {}
+
 func init() {
 	SchemeBuilder.Register(&VMScrapeConfig{}, &VMScrapeConfigList{})
 }
@@ -61,6 +61,7 @@ type VMServiceScrapeSpec struct {
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
 // +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+// +kubebuilder:storageversion
 // +genclient
 type VMServiceScrape struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -145,6 +146,9 @@ func (cr *VMServiceScrape) GetStatusMetadata() *StatusMetadata {
 	return &cr.Status.StatusMetadata
 }
 
+// Hub marks v1beta1 as conversion hub for VMServiceScrape
+func (*VMServiceScrape) Hub() {}
+
 func init() {
 	SchemeBuilder.Register(&VMServiceScrape{}, &VMServiceScrapeList{})
 }
@@ -50,6 +50,19 @@ type VMSingleSpec struct {
 
 	// InsertPorts - additional listen ports for data ingestion.
 	InsertPorts *InsertPorts `json:"insertPorts,omitempty"`
+
+	// OTLP configures OpenTelemetry metrics ingestion and dedicated OTLP service
+	// +optional
+	OTLP *OTLPIngestion `json:"otlp,omitempty"`
+	// Datadog configures ingestion of metrics sent by DataDog agent
+	// +optional
+	Datadog *DatadogIngestion `json:"datadog,omitempty"`
+	// NewRelic configures ingestion of metrics sent by NewRelic infrastructure agent
+	// +optional
+	NewRelic *NewRelicIngestion `json:"newrelic,omitempty"`
+	// QueryLogging configures slow queries logging and query execution limits
+	// +optional
+	QueryLogging *QueryLogging `json:"queryLogging,omitempty"`
 	// RemovePvcAfterDelete - if true, controller adds ownership to pvc
 	// and after VMSingle object deletion - pvc will be garbage collected
 	// by controller manager
@@ -64,6 +77,10 @@ type VMSingleSpec struct {
 	// VMBackup configuration for backup
 	// +optional
 	VMBackup *VMBackup `json:"vmBackup,omitempty"`
+	// RestoreFrom defines backup source for restoring storage data with vmrestore init container
+	// restore is performed only once for the given source, progress is reported at status.restore
+	// +optional
+	RestoreFrom *VMRestoreFrom `json:"restoreFrom,omitempty"`
 	// License allows to configure license key to be used for enterprise features.
 	// Using license key is supported starting from VictoriaMetrics v1.94.0.
 	// See [here](https://docs.victoriametrics.com/enterprise)
@@ -72,6 +89,22 @@ type VMSingleSpec struct {
 	// ServiceSpec that will be added to vmsingle service spec
 	// +optional
 	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// AdditionalServices defines a list of extra services that will be created for vmsingle
+	// in addition to the default one
+	// +optional
+	AdditionalServices []AdditionalServiceSpec `json:"additionalServices,omitempty"`
+	// NetworkPolicy created by operator
+	// +optional
+	NetworkPolicy *EmbeddedNetworkPolicy `json:"networkPolicy,omitempty"`
+	// VPA defines VerticalPodAutoscaler configuration for the application container
+	// +optional
+	VPA *EmbeddedVPA `json:"vpa,omitempty"`
+	// Ingress defines Ingress configuration for external access to VMSingle service
+	// +optional
+	Ingress *EmbeddedIngress `json:"ingress,omitempty"`
+	// Route defines OpenShift Route configuration for external access to VMSingle service
+	// +optional
+	Route *EmbeddedRoute `json:"route,omitempty"`
 	// ServiceScrapeSpec that will be added to vmsingle VMServiceScrape spec
 	// +optional
 	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
@@ -83,6 +116,10 @@ type VMSingleSpec struct {
 	// ServiceAccountName is the name of the ServiceAccount to use to run the pods
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// CloudIdentity configures cloud provider workload identity
+	// (AWS IRSA or GCP Workload Identity) for the generated ServiceAccount
+	// +optional
+	CloudIdentity *CloudIdentity `json:"cloudIdentity,omitempty"`
 
 	CommonDefaultableParams           `json:",inline"`
 	CommonApplicationDeploymentParams `json:",inline"`
@@ -134,6 +171,12 @@ type VMSingleStatus struct {
 	StatusMetadata      `json:",inline"`
 	// LegacyStatus is deprecated and will be removed at v0.52.0 version
 	LegacyStatus UpdateStatus `json:"singleStatus,omitempty"`
+	// Restore reports progress of restore from spec.restoreFrom
+	// +optional
+	Restore *VMRestoreFromStatus `json:"restore,omitempty"`
+	// BackupVerification reports state of spec.vmBackup.verification
+	// +optional
+	BackupVerification *VMBackupVerificationStatus `json:"backupVerification,omitempty"`
 }
 
 // VMSingle  is fast, cost-effective and scalable time-series database.
@@ -169,7 +212,7 @@ func (cr *VMSingle) ProbePath() string {
 }
 
 func (cr *VMSingle) ProbeScheme() string {
-	return strings.ToUpper(protoFromFlags(cr.Spec.ExtraArgs))
+	return strings.ToUpper(protoFromFlags(cr.Spec.ExtraArgsWithServerTLS()))
 }
 
 func (cr *VMSingle) ProbePort() string {
@@ -214,7 +257,7 @@ func (cr *VMSingle) PodAnnotations() map[string]string {
 
 func (cr *VMSingle) AnnotationsFiltered() map[string]string {
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
-	dst := filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes)
+	dst := filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes, cr.Spec.ManagedMetadata)
 	if cr.Spec.ManagedMetadata != nil {
 		if dst == nil {
 			dst = make(map[string]string)
@@ -253,7 +296,7 @@ func (cr *VMSingle) AllLabels() map[string]string {
 	var result map[string]string
 	// TODO: @f41gh7 deprecated at will be removed at v0.52.0 release
 	if cr.ObjectMeta.Labels != nil {
-		result = filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes)
+		result = filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes, cr.Spec.ManagedMetadata)
 	}
 	if cr.Spec.ManagedMetadata != nil {
 		result = labels.Merge(result, cr.Spec.ManagedMetadata.Labels)
@@ -261,6 +304,11 @@ func (cr *VMSingle) AllLabels() map[string]string {
 	return labels.Merge(result, selectorLabels)
 }
 
+// OTLPServiceName returns name of the dedicated OTLP service
+func (cr *VMSingle) OTLPServiceName() string {
+	return fmt.Sprintf("%s-otlp", cr.PrefixedName())
+}
+
 func (cr *VMSingle) PrefixedName() string {
 	return fmt.Sprintf("vmsingle-%s", cr.Name)
 }
@@ -269,6 +317,11 @@ func (cr *VMSingle) StreamAggrConfigName() string {
 	return fmt.Sprintf("stream-aggr-vmsingle-%s", cr.Name)
 }
 
+// BackupVerificationName returns name of CronJob for backup verification
+func (cr *VMSingle) BackupVerificationName() string {
+	return fmt.Sprintf("vmsingle-backup-verify-%s", cr.Name)
+}
+
 // GetMetricPath returns prefixed path for metric requests
 func (cr *VMSingle) GetMetricPath() string {
 	return buildPathWithPrefixFlag(cr.Spec.ExtraArgs, metricPath)
@@ -276,7 +329,7 @@ func (cr *VMSingle) GetMetricPath() string {
 
 // ExtraArgs returns additionally configured command-line arguments
 func (cr *VMSingle) GetExtraArgs() map[string]string {
-	return cr.Spec.ExtraArgs
+	return cr.Spec.ExtraArgsWithServerTLS()
 }
 
 // ServiceScrape returns overrides for serviceScrape builder
@@ -295,6 +348,11 @@ func (cr *VMSingle) IsOwnsServiceAccount() bool {
 	return cr.Spec.ServiceAccountName == ""
 }
 
+// GetCloudIdentity returns cloud identity configuration for the ServiceAccount
+func (cr *VMSingle) GetCloudIdentity() *CloudIdentity {
+	return cr.Spec.CloudIdentity
+}
+
 // GetNSName implements build.builderOpts interface
 func (cr *VMSingle) GetNSName() string {
 	return cr.GetNamespace()
@@ -313,7 +371,7 @@ func (cr *VMSingle) AsURL() string {
 			}
 		}
 	}
-	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(cr.Spec.ExtraArgs), cr.PrefixedName(), cr.Namespace, port)
+	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(cr.Spec.ExtraArgsWithServerTLS()), cr.PrefixedName(), cr.Namespace, port)
 }
 
 // AsCRDOwner implements interface
@@ -331,6 +389,20 @@ func (cr *VMSingle) HasSpecChanges() (bool, error) {
 	return hasStateChanges(cr.ObjectMeta, cr.Spec)
 }
 
+// CheckRetentionDecrease returns RetentionDecreaseError if retentionPeriod is smaller than the last applied one
+// and decrease isn't confirmed with RetentionDecreaseConfirmAnnotation
+func (cr *VMSingle) CheckRetentionDecrease() error {
+	if cr.ParsedLastAppliedSpec == nil {
+		return nil
+	}
+	return checkRetentionDecrease(cr.Annotations, cr.ParsedLastAppliedSpec.retentionPeriod(), cr.Spec.retentionPeriod())
+}
+
+// retentionPeriod returns retentionPeriod with extraArgs override
+func (cr *VMSingleSpec) retentionPeriod() string {
+	return retentionPeriodWithFlags(cr.RetentionPeriod, cr.ExtraArgs)
+}
+
 func (cr *VMSingle) Paused() bool {
 	return cr.Spec.Paused
 }
@@ -37,19 +37,54 @@ func (r *VMSingle) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 // +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmsingle,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmsingles,verbs=create;update,versions=v1beta1,name=vvmsingle.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-operator-victoriametrics-com-v1beta1-vmsingle,mutating=true,failurePolicy=ignore,sideEffects=None,groups=operator.victoriametrics.com,resources=vmsingles,verbs=create;update,versions=v1beta1,name=mvmsingle.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &VMSingle{}
 
+// vmsinglePrevChecks validates params, which could be already set incorrectly at existing objects
+var vmsinglePrevChecks = []func(r *VMSingle) error{
+	func(r *VMSingle) error {
+		if err := r.Spec.InsertPorts.sanityCheck(r.Spec.Port); err != nil {
+			return fmt.Errorf("incorrect spec.insertPorts: %w", err)
+		}
+		return nil
+	},
+}
+
 func (r *VMSingle) sanityCheck() error {
 	if r.Spec.ServiceSpec != nil && r.Spec.ServiceSpec.Name == r.PrefixedName() {
 		return fmt.Errorf("spec.serviceSpec.Name cannot be equal to prefixed name=%q", r.PrefixedName())
 	}
+	if err := checkAdditionalServices(r.Spec.AdditionalServices, r.PrefixedName(), r.Spec.ServiceSpec); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
+	if err := r.Spec.ServerTLS.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.serverTLS: %w", err)
+	}
+	if err := checkExtraArgs(r.Spec.ExtraArgs); err != nil {
+		return err
+	}
+	if err := r.Spec.VPA.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.vpa: %w", err)
+	}
+	if err := r.Spec.QueryLogging.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.queryLogging: %w", err)
+	}
+	if err := r.Spec.Ingress.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.ingress: %w", err)
+	}
+	if err := r.Spec.Route.sanityCheck(); err != nil {
+		return fmt.Errorf("incorrect spec.route: %w", err)
+	}
 
 	if r.Spec.VMBackup != nil {
 		if err := r.Spec.VMBackup.sanityCheck(r.Spec.License); err != nil {
 			return err
 		}
 	}
+	if err := r.Spec.CloudIdentity.sanityCheck(r.Spec.ServiceAccountName, r.Spec.VMBackup); err != nil {
+		return fmt.Errorf("incorrect spec: %w", err)
+	}
 	if r.Spec.StorageDataPath != "" {
 		if len(r.Spec.Volumes) == 0 {
 			return fmt.Errorf("spec.volumes must have at least 1 value for spec.storageDataPath=%q", r.Spec.StorageDataPath)
@@ -74,6 +109,11 @@ func (r *VMSingle) sanityCheck() error {
 	return nil
 }
 
+// extraArgsWarnings returns warnings for unknown and operator managed flags at spec.extraArgs
+func (r *VMSingle) extraArgsWarnings() admission.Warnings {
+	return checkExtraArgsFlags("spec.extraArgs", r.Spec.ExtraArgs, vmsingleFlags)
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *VMSingle) ValidateCreate() (admission.Warnings, error) {
 	if r.Spec.ParsingError != "" {
@@ -85,7 +125,11 @@ func (r *VMSingle) ValidateCreate() (admission.Warnings, error) {
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	warnings, err := checkWithPrev(r, nil, vmsinglePrevChecks...)
+	if err != nil {
+		return nil, err
+	}
+	return append(warnings, r.extraArgsWarnings()...), nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -100,7 +144,18 @@ func (r *VMSingle) ValidateUpdate(old runtime.Object) (admission.Warnings, error
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	prev, _ := old.(*VMSingle)
+	warnings, err := checkWithPrev(r, prev, vmsinglePrevChecks...)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, r.extraArgsWarnings()...)
+	if prev != nil {
+		if err := checkRetentionDecrease(r.Annotations, prev.Spec.retentionPeriod(), r.Spec.retentionPeriod()); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s, operator doesn't apply changes until confirmation", err))
+		}
+	}
+	return warnings, nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
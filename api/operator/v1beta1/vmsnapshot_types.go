@@ -0,0 +1,192 @@
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VMSnapshotSpec defines the desired state of VMSnapshot
+// +k8s:openapi-gen=true
+type VMSnapshotSpec struct {
+	// ParsingError contents error with context if operator was failed to parse json object from kubernetes api server
+	ParsingError string `json:"-" yaml:"-"`
+	// SourceRef defines VMSingle or VMCluster, which storage must be snapshotted
+	// for VMCluster snapshot is created at each vmstorage pod
+	SourceRef VMSnapshotSourceRef `json:"sourceRef"`
+	// Schedule defines snapshot creation schedule in cron format, e.g. "0 */6 * * *"
+	// if empty, only a single on-demand snapshot is created
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// KeepLastSnapshots defines how many snapshots must be kept
+	// older snapshots are deleted from the storage
+	// if not set or 0, all snapshots are kept
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	KeepLastSnapshots *int32 `json:"keepLastSnapshots,omitempty"`
+	// DeleteSnapshotsOnRemove defines if all snapshots tracked by the object
+	// must be deleted from the storage on VMSnapshot removal
+	// +optional
+	DeleteSnapshotsOnRemove bool `json:"deleteSnapshotsOnRemove,omitempty"`
+	// Paused If set to true all actions on the underlying managed objects are not
+	// going to be performed, except for delete actions.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// VMSnapshotSourceRef references storage object for snapshot
+type VMSnapshotSourceRef struct {
+	// Kind of the storage object
+	// +kubebuilder:validation:Enum=VMSingle;VMCluster
+	Kind string `json:"kind"`
+	// Name of the storage object at the same namespace
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// VMSnapshotStatus defines the observed state of VMSnapshot
+type VMSnapshotStatus struct {
+	// LastSnapshotTime defines time of the last successfully created snapshot
+	// +optional
+	LastSnapshotTime *metav1.Time `json:"lastSnapshotTime,omitempty"`
+	// Snapshots contains snapshots created by operator, ordered from oldest to newest
+	// +optional
+	Snapshots      []VMSnapshotRecord `json:"snapshots,omitempty"`
+	StatusMetadata `json:",inline"`
+}
+
+// VMSnapshotRecord defines snapshot created at the given time
+type VMSnapshotRecord struct {
+	// CreatedAt defines snapshot creation time
+	CreatedAt metav1.Time `json:"createdAt"`
+	// Nodes contains snapshot names per storage node
+	Nodes []VMSnapshotNode `json:"nodes"`
+}
+
+// VMSnapshotNode defines snapshot created at the single storage node
+type VMSnapshotNode struct {
+	// Node defines storage node address
+	Node string `json:"node"`
+	// Name defines snapshot name returned by storage node
+	Name string `json:"name"`
+}
+
+// GetStatusMetadata returns metadata for object status
+func (cr *VMSnapshotStatus) GetStatusMetadata() *StatusMetadata {
+	return &cr.StatusMetadata
+}
+
+// VMSnapshot creates snapshots of VMSingle or VMCluster storage
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="VMSnapshot"
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmsnapshots,scope=Namespaced
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.sourceRef.name"
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Last Snapshot",type="date",JSONPath=".status.lastSnapshotTime"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus",description="Current status of snapshot process"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// VMSnapshot is the Schema for the vmsnapshots API
+type VMSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VMSnapshotSpec `json:"spec,omitempty"`
+	// ParsedLastAppliedSpec contains last-applied configuration spec
+	ParsedLastAppliedSpec *VMSnapshotSpec `json:"-" yaml:"-"`
+
+	Status VMSnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VMSnapshotList contains a list of VMSnapshot
+type VMSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMSnapshot `json:"items"`
+}
+
+// AsOwner returns owner references with current object as owner
+func (cr *VMSnapshot) AsOwner() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         cr.APIVersion,
+			Kind:               cr.Kind,
+			Name:               cr.Name,
+			UID:                cr.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+func (cr *VMSnapshot) setLastSpec(prevSpec VMSnapshotSpec) {
+	cr.ParsedLastAppliedSpec = &prevSpec
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMSnapshot) UnmarshalJSON(src []byte) error {
+	type pcr VMSnapshot
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		return err
+	}
+	if err := parseLastAppliedState(cr); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMSnapshotSpec) UnmarshalJSON(src []byte) error {
+	type pcr VMSnapshotSpec
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		cr.ParsingError = fmt.Sprintf("cannot parse vmsnapshot spec: %s, err: %s", string(src), err)
+		return nil
+	}
+	return nil
+}
+
+// SnapshotCreateURL returns url for snapshot creation at given storage node base url
+func (cr *VMSnapshot) SnapshotCreateURL(baseURL string, extraArgs map[string]string) string {
+	return joinBackupAuthKey(baseURL+buildPathWithPrefixFlag(extraArgs, snapshotCreate), extraArgs)
+}
+
+// SnapshotDeleteURL returns url for snapshot deletion at given storage node base url
+func (cr *VMSnapshot) SnapshotDeleteURL(baseURL, snapshotName string, extraArgs map[string]string) string {
+	return joinBackupAuthKey(baseURL+buildPathWithPrefixFlag(extraArgs, snapshotDelete)+"?snapshot="+snapshotName, extraArgs)
+}
+
+// LastAppliedSpecAsPatch return last applied vmsnapshot spec as patch annotation
+func (cr *VMSnapshot) LastAppliedSpecAsPatch() (client.Patch, error) {
+	return lastAppliedChangesAsPatch(cr.ObjectMeta, cr.Spec)
+}
+
+// HasSpecChanges compares vmsnapshot spec with last applied vmsnapshot spec stored in annotation
+func (cr *VMSnapshot) HasSpecChanges() (bool, error) {
+	return hasStateChanges(cr.ObjectMeta, cr.Spec)
+}
+
+func (cr *VMSnapshot) Paused() bool {
+	return cr.Spec.Paused
+}
+
+// SetUpdateStatusTo changes update status with optional reason of fail
+func (cr *VMSnapshot) SetUpdateStatusTo(ctx context.Context, c client.Client, status UpdateStatus, maybeErr error) error {
+	return updateObjectStatus(ctx, c, &patchStatusOpts[*VMSnapshot, *VMSnapshotStatus]{
+		actualStatus: status,
+		cr:           cr,
+		crStatus:     &cr.Status,
+		maybeErr:     maybeErr,
+	})
+}
+
+func init() {
+	SchemeBuilder.Register(&VMSnapshot{}, &VMSnapshotList{})
+}
@@ -0,0 +1,237 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// StackStorageModeSingle uses VMSingle as stack storage
+	StackStorageModeSingle = "single"
+	// StackStorageModeCluster uses VMCluster as stack storage
+	StackStorageModeCluster = "cluster"
+)
+
+// VMStackSpec defines the desired state of VMStack
+// +k8s:openapi-gen=true
+type VMStackSpec struct {
+	// ParsingError contents error with context if operator was failed to parse json object from kubernetes api server
+	ParsingError string `json:"-" yaml:"-"`
+	// StorageMode defines storage for the stack
+	// single - VMSingle is used as storage
+	// cluster - small VMCluster with 2 vmstorage, 1 vmselect and 1 vminsert replicas is used as storage
+	// +kubebuilder:validation:Enum=single;cluster
+	// +optional
+	StorageMode string `json:"storageMode,omitempty"`
+	// RetentionPeriod for the stored metrics
+	// Note VictoriaMetrics has data/ and indexdb/ folders
+	// metrics from data/ removed eventually as soon as partition leaves retention period
+	// reverse index data at indexdb rotates once at the half of configured
+	// [retention period](https://docs.victoriametrics.com/Single-server-VictoriaMetrics.html#retention)
+	// it's used if storage spec doesn't define own retentionPeriod, 1 (month) by default
+	// +optional
+	RetentionPeriod string `json:"retentionPeriod,omitempty"`
+	// VMSingle defines VMSingle spec, which is used as base for the stack storage at single mode
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	VMSingle *VMSingleSpec `json:"vmsingle,omitempty"`
+	// VMCluster defines VMCluster spec, which is used as base for the stack storage at cluster mode
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	VMCluster *VMClusterSpec `json:"vmcluster,omitempty"`
+	// VMAgent defines VMAgent spec, which is used as base for the stack vmagent
+	// remote write to the stack storage is added to it
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	VMAgent *VMAgentSpec `json:"vmagent,omitempty"`
+	// VMAlert defines VMAlert spec, which is used as base for the stack vmalert
+	// datasource, remote read, remote write and notifiers are set to the stack components if not defined
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	VMAlert *VMAlertSpec `json:"vmalert,omitempty"`
+	// VMAlertmanager defines VMAlertmanager spec, which is used as base for the stack vmalertmanager
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	VMAlertmanager *VMAlertmanagerSpec `json:"vmalertmanager,omitempty"`
+	// DisableDefaultRules disables creation of VMRule with default alerting rules for the stack components
+	// +optional
+	DisableDefaultRules bool `json:"disableDefaultRules,omitempty"`
+	// Paused If set to true all actions on the underlying managed objects are not
+	// going to be performed, except for delete actions.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// componentsParsingError returns parsing error of component specs
+func (cr *VMStackSpec) componentsParsingError() error {
+	switch {
+	case cr.VMSingle != nil && cr.VMSingle.ParsingError != "":
+		return fmt.Errorf("incorrect spec.vmsingle: %s", cr.VMSingle.ParsingError)
+	case cr.VMCluster != nil && cr.VMCluster.ParsingError != "":
+		return fmt.Errorf("incorrect spec.vmcluster: %s", cr.VMCluster.ParsingError)
+	case cr.VMAgent != nil && cr.VMAgent.ParsingError != "":
+		return fmt.Errorf("incorrect spec.vmagent: %s", cr.VMAgent.ParsingError)
+	case cr.VMAlert != nil && cr.VMAlert.ParsingError != "":
+		return fmt.Errorf("incorrect spec.vmalert: %s", cr.VMAlert.ParsingError)
+	case cr.VMAlertmanager != nil && cr.VMAlertmanager.ParsingError != "":
+		return fmt.Errorf("incorrect spec.vmalertmanager: %s", cr.VMAlertmanager.ParsingError)
+	}
+	return nil
+}
+
+// VMStackStatus defines the observed state of VMStack
+type VMStackStatus struct {
+	StatusMetadata `json:",inline"`
+}
+
+// GetStatusMetadata returns metadata for object status
+func (cr *VMStackStatus) GetStatusMetadata() *StatusMetadata {
+	return &cr.StatusMetadata
+}
+
+// VMStack provisions opinionated monitoring stack from a single object:
+// VMSingle or VMCluster, VMAgent, VMAlert, VMAlertmanager and default alerting rules for them
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="VMStack"
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmstacks,scope=Namespaced
+// +kubebuilder:printcolumn:name="Storage",type="string",JSONPath=".spec.storageMode"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus",description="Current status of update rollout"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// VMStack is the Schema for the vmstacks API
+type VMStack struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VMStackSpec `json:"spec,omitempty"`
+	// ParsedLastAppliedSpec contains last-applied configuration spec
+	ParsedLastAppliedSpec *VMStackSpec `json:"-" yaml:"-"`
+
+	Status VMStackStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VMStackList contains a list of VMStack
+type VMStackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMStack `json:"items"`
+}
+
+// GetStorageMode returns storage mode of the stack
+func (cr *VMStack) GetStorageMode() string {
+	if cr.Spec.StorageMode == "" {
+		return StackStorageModeSingle
+	}
+	return cr.Spec.StorageMode
+}
+
+// SelectorLabels returns labels for objects managed by the stack
+func (cr *VMStack) SelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vmstack",
+		"app.kubernetes.io/instance":  cr.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+// AsOwner returns owner references with current object as owner
+func (cr *VMStack) AsOwner() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         cr.APIVersion,
+			Kind:               cr.Kind,
+			Name:               cr.Name,
+			UID:                cr.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+func (cr *VMStack) setLastSpec(prevSpec VMStackSpec) {
+	cr.ParsedLastAppliedSpec = &prevSpec
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMStack) UnmarshalJSON(src []byte) error {
+	type pcr VMStack
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		return err
+	}
+	if err := parseLastAppliedState(cr); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMStackSpec) UnmarshalJSON(src []byte) error {
+	type pcr VMStackSpec
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		cr.ParsingError = fmt.Sprintf("cannot parse vmstack spec: %s, err: %s", string(src), err)
+		return nil
+	}
+	if err := cr.componentsParsingError(); err != nil {
+		cr.ParsingError = err.Error()
+	}
+	return nil
+}
+
+// LastAppliedSpecAsPatch return last applied vmstack spec as patch annotation
+func (cr *VMStack) LastAppliedSpecAsPatch() (client.Patch, error) {
+	return lastAppliedChangesAsPatch(cr.ObjectMeta, cr.Spec)
+}
+
+// HasSpecChanges compares vmstack spec with last applied vmstack spec stored in annotation
+func (cr *VMStack) HasSpecChanges() (bool, error) {
+	return hasStateChanges(cr.ObjectMeta, cr.Spec)
+}
+
+func (cr *VMStack) Paused() bool {
+	return cr.Spec.Paused
+}
+
+// SetUpdateStatusTo changes update status with optional reason of fail
+func (cr *VMStack) SetUpdateStatusTo(ctx context.Context, c client.Client, status UpdateStatus, maybeErr error) error {
+	return updateObjectStatus(ctx, c, &patchStatusOpts[*VMStack, *VMStackStatus]{
+		actualStatus: status,
+		cr:           cr,
+		crStatus:     &cr.Status,
+		maybeErr:     maybeErr,
+	})
+}
+
+func init() {
+	SchemeBuilder.Register(&VMStack{}, &VMStackList{})
+}
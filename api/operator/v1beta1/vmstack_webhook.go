@@ -0,0 +1,87 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var vmstacklog = logf.Log.WithName("vmstack-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *VMStack) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmstack,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmstacks,verbs=create;update,versions=v1beta1,name=vvmstack.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &VMStack{}
+
+func (r *VMStack) sanityCheck() error {
+	switch r.GetStorageMode() {
+	case StackStorageModeSingle:
+		if r.Spec.VMCluster != nil {
+			return fmt.Errorf("spec.vmcluster cannot be used with storageMode=%q", StackStorageModeSingle)
+		}
+	case StackStorageModeCluster:
+		if r.Spec.VMSingle != nil {
+			return fmt.Errorf("spec.vmsingle cannot be used with storageMode=%q", StackStorageModeCluster)
+		}
+	}
+	return nil
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *VMStack) ValidateCreate() (admission.Warnings, error) {
+	if r.Spec.ParsingError != "" {
+		return nil, fmt.Errorf(r.Spec.ParsingError)
+	}
+	if mustSkipValidation(r) {
+		return nil, nil
+	}
+	if err := r.sanityCheck(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *VMStack) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	if r.Spec.ParsingError != "" {
+		return nil, fmt.Errorf(r.Spec.ParsingError)
+	}
+	if mustSkipValidation(r) {
+		return nil, nil
+	}
+	if err := r.sanityCheck(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *VMStack) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
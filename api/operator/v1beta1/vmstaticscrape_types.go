@@ -19,8 +19,17 @@ type VMStaticScrapeSpec struct {
 	// a single target can expose during all the scrapes on the time window of 24h.
 	// +optional
 	SeriesLimit uint64 `json:"seriesLimit,omitempty"`
+	// Preset applies predefined scrape settings for well-known target types.
+	// pushgateway - sets honor_labels: true for all endpoints, it preserves job and instance labels
+	// of pushed series. JobName is applied only to series without job label.
+	// +optional
+	// +kubebuilder:validation:Enum=pushgateway
+	Preset string `json:"preset,omitempty"`
 }
 
+// StaticScrapePresetPushgateway defines scrape settings for Pushgateway-style targets
+const StaticScrapePresetPushgateway = "pushgateway"
+
 // TargetEndpoint defines single static target endpoint.
 type TargetEndpoint struct {
 	// Targets static targets addresses in form of ["192.122.55.55:9100","some-name:9100"].
@@ -40,6 +49,7 @@ type TargetEndpoint struct {
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
 // +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+// +kubebuilder:storageversion
 // +genclient
 type VMStaticScrape struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -72,6 +82,9 @@ func (cr *VMStaticScrape) GetStatusMetadata() *StatusMetadata {
 	return &cr.Status.StatusMetadata
 }
 
+// Hub marks v1beta1 as conversion hub for VMStaticScrape
+func (*VMStaticScrape) Hub() {}
+
 func init() {
 	SchemeBuilder.Register(&VMStaticScrape{}, &VMStaticScrapeList{})
 }
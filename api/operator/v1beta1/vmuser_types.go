@@ -2,12 +2,17 @@ package v1beta1
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"slices"
 	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // VMUserSpec defines the desired state of VMUser
@@ -32,9 +37,18 @@ type VMUserSpec struct {
 	// if spec.password if empty.
 	// +optional
 	GeneratePassword bool `json:"generatePassword,omitempty"`
+	// PasswordRotation defines rotation policy for password generated by operator
+	// it requires generatePassword and vmuser secret creation
+	// +optional
+	PasswordRotation *VMUserPasswordRotation `json:"passwordRotation,omitempty"`
 	// BearerToken Authorization header value for accessing protected endpoint.
 	// +optional
 	BearerToken *string `json:"bearerToken,omitempty"`
+	// JWT enables authentication with JSON Web Tokens issued by external identity provider
+	// it cannot be used with username, password or bearerToken
+	// available only at enterprise version of vmauth
+	// +optional
+	JWT *VMUserJWT `json:"jwt,omitempty"`
 	// TargetRefs - reference to endpoints, which user may access.
 	TargetRefs []TargetRef `json:"targetRefs"`
 
@@ -48,6 +62,92 @@ type VMUserSpec struct {
 	DisableSecretCreation bool `json:"disable_secret_creation,omitempty"`
 }
 
+// VMUserJWT defines verification settings for JSON Web Tokens
+type VMUserJWT struct {
+	// Issuer defines OIDC issuer url, vmauth discovers signing keys from its configuration endpoint
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+	// Audience defines list of allowed token audiences
+	// +optional
+	Audience []string `json:"audience,omitempty"`
+	// JWKSURL defines url of JSON Web Key Set with token signing keys
+	// +optional
+	JWKSURL string `json:"jwksURL,omitempty"`
+	// PublicKeys defines PEM encoded public keys for token signature verification
+	// +optional
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// PublicKeySecrets defines secret keys with PEM encoded public keys at VMUser namespace
+	// +optional
+	PublicKeySecrets []v1.SecretKeySelector `json:"publicKeySecrets,omitempty"`
+	// SkipVerify disables token signature verification
+	// it must be used only for testing purposes
+	// +optional
+	SkipVerify bool `json:"skipVerify,omitempty"`
+}
+
+// Validate performs syntax validation of jwt settings
+func (jwt *VMUserJWT) Validate() error {
+	if jwt.SkipVerify {
+		return nil
+	}
+	if jwt.Issuer == "" && jwt.JWKSURL == "" && len(jwt.PublicKeys) == 0 && len(jwt.PublicKeySecrets) == 0 {
+		return fmt.Errorf("at least one of issuer, jwksURL, publicKeys or publicKeySecrets must be set")
+	}
+	if jwt.Issuer != "" {
+		if _, err := url.Parse(jwt.Issuer); err != nil {
+			return fmt.Errorf("cannot parse issuer: %w", err)
+		}
+	}
+	if jwt.JWKSURL != "" {
+		if _, err := url.Parse(jwt.JWKSURL); err != nil {
+			return fmt.Errorf("cannot parse jwksURL: %w", err)
+		}
+	}
+	return nil
+}
+
+// VMUserPasswordRotation defines schedule for generated password regeneration
+type VMUserPasswordRotation struct {
+	// RotationPeriod defines how often password must be regenerated, e.g. 720h
+	// +kubebuilder:validation:Pattern:="[0-9]+(ms|s|m|h)"
+	RotationPeriod string `json:"rotationPeriod"`
+	// GracePeriod defines how long previous password remains valid after rotation, e.g. 1h
+	// previous password is revoked immediately if omitted
+	// +kubebuilder:validation:Pattern:="[0-9]+(ms|s|m|h)"
+	// +optional
+	GracePeriod string `json:"gracePeriod,omitempty"`
+}
+
+// Validate performs syntax validation of rotation durations
+func (pr *VMUserPasswordRotation) Validate() error {
+	rp, err := time.ParseDuration(pr.RotationPeriod)
+	if err != nil {
+		return fmt.Errorf("cannot parse rotationPeriod: %w", err)
+	}
+	if rp <= 0 {
+		return fmt.Errorf("rotationPeriod must be positive, got: %q", pr.RotationPeriod)
+	}
+	if pr.GracePeriod != "" {
+		gp, err := time.ParseDuration(pr.GracePeriod)
+		if err != nil {
+			return fmt.Errorf("cannot parse gracePeriod: %w", err)
+		}
+		if gp >= rp {
+			return fmt.Errorf("gracePeriod=%q must be less than rotationPeriod=%q", pr.GracePeriod, pr.RotationPeriod)
+		}
+	}
+	return nil
+}
+
+// Periods returns parsed rotation and grace periods
+func (pr *VMUserPasswordRotation) Periods() (rotation, grace time.Duration) {
+	rotation, _ = time.ParseDuration(pr.RotationPeriod)
+	if pr.GracePeriod != "" {
+		grace, _ = time.ParseDuration(pr.GracePeriod)
+	}
+	return rotation, grace
+}
+
 // TargetRef describes target for user traffic forwarding.
 // one of target types can be chosen:
 // crd or static per targetRef.
@@ -85,16 +185,66 @@ type VMUserIPFilters struct {
 	AllowList []string `json:"allow_list,omitempty"`
 }
 
+// IsEmpty checks if filters are not defined
+func (ipf *VMUserIPFilters) IsEmpty() bool {
+	return len(ipf.DenyList) == 0 && len(ipf.AllowList) == 0
+}
+
+// validate checks that filters contain only IP addresses or CIDR networks
+// prev must be nil on object creation.
+// Errors are returned as warnings, if prev filters have the same entry,
+// it allows to update existing objects created before validation was added
+func (ipf *VMUserIPFilters) validate(prev *VMUserIPFilters) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	f := func(list, prevList []string, name string) error {
+		for _, v := range list {
+			var err error
+			if strings.Contains(v, "/") {
+				if _, _, perr := net.ParseCIDR(v); perr != nil {
+					err = fmt.Errorf("incorrect CIDR=%q at %s: %w", v, name, perr)
+				}
+			} else if net.ParseIP(v) == nil {
+				err = fmt.Errorf("incorrect IP address=%q at %s", v, name)
+			}
+			if err == nil {
+				continue
+			}
+			if !slices.Contains(prevList, v) {
+				return err
+			}
+			warnings = append(warnings, err.Error())
+		}
+		return nil
+	}
+	var prevAllowList, prevDenyList []string
+	if prev != nil {
+		prevAllowList, prevDenyList = prev.AllowList, prev.DenyList
+	}
+	if err := f(ipf.AllowList, prevAllowList, "allow_list"); err != nil {
+		return nil, err
+	}
+	if err := f(ipf.DenyList, prevDenyList, "deny_list"); err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
+
 // CRDRef describe CRD target reference.
 type CRDRef struct {
 	// Kind one of:
-	// VMAgent,VMAlert, VMSingle, VMCluster/vmselect, VMCluster/vmstorage,VMCluster/vminsert  or VMAlertManager
-	// +kubebuilder:validation:Enum=VMAgent;VMAlert;VMSingle;VMAlertManager;VMAlertmanager;VMCluster/vmselect;VMCluster/vmstorage;VMCluster/vminsert
+	// VMAgent,VMAlert, VMSingle, VMCluster, VMCluster/vmselect, VMCluster/vmstorage,VMCluster/vminsert  or VMAlertManager
+	// VMCluster routes read requests to vmselect and write requests to vminsert for the given tenant
+	// +kubebuilder:validation:Enum=VMAgent;VMAlert;VMSingle;VMAlertManager;VMAlertmanager;VMCluster;VMCluster/vmselect;VMCluster/vmstorage;VMCluster/vminsert
 	Kind string `json:"kind"`
 	// Name target CRD object name
 	Name string `json:"name"`
 	// Namespace target CRD object namespace.
 	Namespace string `json:"namespace"`
+	// Tenant defines VMCluster tenant in form of accountID or accountID:projectID
+	// operator adds /select/<tenant> and /insert/<tenant> prefixes to vmselect and vminsert urls
+	// it's supported only for VMCluster kind, 0 is used by default
+	// +optional
+	Tenant string `json:"tenant,omitempty"`
 }
 
 // AddRefToObj adds reference to given object and return it.
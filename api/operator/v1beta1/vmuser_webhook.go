@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -26,8 +27,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+var tenantRegexp = regexp.MustCompile(`^\d+(:\d+)?$`)
+
 var supportedCRDKinds = []string{
-	"VMAgent", "VMAlert", "VMAlertmanager", "VMSingle", "VMCluster/vmselect", "VMCluster/vminsert", "VMCluster/vmstorage",
+	"VMAgent", "VMAlert", "VMAlertmanager", "VMSingle", "VMCluster", "VMCluster/vmselect", "VMCluster/vminsert", "VMCluster/vmstorage",
 }
 
 // SetupWebhookWithManager will setup the manager to manage the webhooks
@@ -48,6 +51,26 @@ func (r *VMUser) sanityCheck() error {
 	if r.Spec.PasswordRef != nil && r.Spec.Password != nil {
 		return fmt.Errorf("one of spec.password or spec.passwordRef must be used for user, got both")
 	}
+	if r.Spec.JWT != nil {
+		if r.Spec.UserName != nil || r.Spec.Password != nil || r.Spec.PasswordRef != nil || r.Spec.GeneratePassword ||
+			r.Spec.BearerToken != nil || r.Spec.TokenRef != nil {
+			return fmt.Errorf("spec.jwt cannot be used with username, password, passwordRef, generatePassword, bearerToken or tokenRef")
+		}
+		if err := r.Spec.JWT.Validate(); err != nil {
+			return fmt.Errorf("incorrect spec.jwt: %w", err)
+		}
+	}
+	if r.Spec.PasswordRotation != nil {
+		if !r.Spec.GeneratePassword || r.Spec.Password != nil || r.Spec.PasswordRef != nil {
+			return fmt.Errorf("spec.passwordRotation can be used only with spec.generatePassword and without spec.password or spec.passwordRef")
+		}
+		if r.Spec.DisableSecretCreation {
+			return fmt.Errorf("spec.passwordRotation cannot be used with spec.disable_secret_creation, rotation state is stored at vmuser secret")
+		}
+		if err := r.Spec.PasswordRotation.Validate(); err != nil {
+			return fmt.Errorf("incorrect spec.passwordRotation: %w", err)
+		}
+	}
 	if len(r.Spec.TargetRefs) == 0 {
 		return fmt.Errorf("at least 1 TargetRef must be provided for spec.targetRefs")
 	}
@@ -77,13 +100,23 @@ func (r *VMUser) sanityCheck() error {
 		}
 		if targetRef.CRD != nil {
 			switch targetRef.CRD.Kind {
-			case "VMAgent", "VMAlert", "VMAlertmanager", "VMSingle", "VMCluster/vmselect", "VMCluster/vminsert", "VMCluster/vmstorage":
+			case "VMAgent", "VMAlert", "VMAlertmanager", "VMSingle", "VMCluster", "VMCluster/vmselect", "VMCluster/vminsert", "VMCluster/vmstorage":
 			default:
 				return fmt.Errorf("unsupported crd.kind for target ref, got: `%s`, want one of: `%s`", targetRef.CRD.Kind, strings.Join(supportedCRDKinds, ","))
 			}
 			if targetRef.CRD.Namespace == "" || targetRef.CRD.Name == "" {
 				return fmt.Errorf("crd.name and crd.namespace cannot be empty")
 			}
+			if targetRef.CRD.Kind == "VMCluster" {
+				if len(targetRef.Paths) > 0 || targetRef.TargetPathSuffix != "" {
+					return fmt.Errorf("paths and target_path_suffix cannot be used with crd.kind=VMCluster at idx=%d, operator generates them for crd.tenant", i)
+				}
+				if targetRef.CRD.Tenant != "" && !tenantRegexp.MatchString(targetRef.CRD.Tenant) {
+					return fmt.Errorf("incorrect crd.tenant=%q at idx=%d, must match pattern=%q", targetRef.CRD.Tenant, i, tenantRegexp)
+				}
+			} else if targetRef.CRD.Tenant != "" {
+				return fmt.Errorf("crd.tenant is supported only for crd.kind=VMCluster at idx=%d", i)
+			}
 		}
 		if err := validateHTTPHeaders(targetRef.ResponseHeaders); err != nil {
 			return fmt.Errorf("failed to parse targetRef response headers :%w", err)
@@ -117,7 +150,11 @@ func (r *VMUser) ValidateCreate() (admission.Warnings, error) {
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	warnings, err := r.Spec.IPFilters.validate(nil)
+	if err != nil {
+		return nil, fmt.Errorf("incorrect vmuser ip_filters: %w", err)
+	}
+	return warnings, nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -128,7 +165,15 @@ func (r *VMUser) ValidateUpdate(old runtime.Object) (admission.Warnings, error)
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	var prevFilters *VMUserIPFilters
+	if prev, ok := old.(*VMUser); ok {
+		prevFilters = &prev.Spec.IPFilters
+	}
+	warnings, err := r.Spec.IPFilters.validate(prevFilters)
+	if err != nil {
+		return nil, fmt.Errorf("incorrect vmuser ip_filters: %w", err)
+	}
+	return warnings, nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
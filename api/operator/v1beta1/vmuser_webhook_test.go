@@ -151,3 +151,40 @@ func TestVMUser_sanityCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestVMUserIPFilters_validate(t *testing.T) {
+	f := func(ipf VMUserIPFilters, prev *VMUserIPFilters, wantWarnings int, wantErr bool) {
+		t.Helper()
+		got, err := ipf.validate(prev)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if len(got) != wantWarnings {
+			t.Fatalf("unexpected warnings: %v, want: %d", got, wantWarnings)
+		}
+	}
+
+	// empty filters
+	f(VMUserIPFilters{}, nil, 0, false)
+
+	// ip addresses and networks
+	f(VMUserIPFilters{AllowList: []string{"10.0.0.1", "192.168.0.0/16"}, DenyList: []string{"::1", "fd00::/8"}}, nil, 0, false)
+
+	// incorrect ip address
+	f(VMUserIPFilters{AllowList: []string{"10.0.0.300"}}, nil, 0, true)
+
+	// incorrect network
+	f(VMUserIPFilters{DenyList: []string{"10.0.0.0/33"}}, nil, 0, true)
+
+	// existing object with the same entries is updated with warnings
+	f(VMUserIPFilters{AllowList: []string{"10.0.0.300", "10.0.0.1"}, DenyList: []string{"10.0.0.0/33"}},
+		&VMUserIPFilters{AllowList: []string{"10.0.0.300"}, DenyList: []string{"10.0.0.0/33"}}, 2, false)
+
+	// incorrect entry introduced by update
+	f(VMUserIPFilters{AllowList: []string{"10.0.0.300", "host"}},
+		&VMUserIPFilters{AllowList: []string{"10.0.0.300"}}, 0, true)
+
+	// entry moved to another list
+	f(VMUserIPFilters{DenyList: []string{"10.0.0.300"}},
+		&VMUserIPFilters{AllowList: []string{"10.0.0.300"}}, 0, true)
+}
@@ -340,6 +340,21 @@ func (in *Certs) DeepCopy() *Certs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudIdentity) DeepCopyInto(out *CloudIdentity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudIdentity.
+func (in *CloudIdentity) DeepCopy() *CloudIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonApplicationDeploymentParams) DeepCopyInto(out *CommonApplicationDeploymentParams) {
 	*out = *in
@@ -398,6 +413,11 @@ func (in *CommonApplicationDeploymentParams) DeepCopyInto(out *CommonApplication
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SpreadPolicy != nil {
+		in, out := &in.SpreadPolicy, &out.SpreadPolicy
+		*out = new(SpreadPolicy)
+		**out = **in
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]v1.LocalObjectReference, len(*in))
@@ -437,6 +457,13 @@ func (in *CommonApplicationDeploymentParams) DeepCopyInto(out *CommonApplication
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Secrets != nil {
 		in, out := &in.Secrets, &out.Secrets
 		*out = make([]string, len(*in))
@@ -475,6 +502,21 @@ func (in *CommonApplicationDeploymentParams) DeepCopyInto(out *CommonApplication
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(v1.Lifecycle)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodTemplatePatches != nil {
+		in, out := &in.PodTemplatePatches, &out.PodTemplatePatches
+		*out = make([]PodTemplatePatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServerTLS != nil {
+		in, out := &in.ServerTLS, &out.ServerTLS
+		*out = new(ServerTLS)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonApplicationDeploymentParams.
@@ -535,6 +577,11 @@ func (in *CommonDefaultableParams) DeepCopyInto(out *CommonDefaultableParams) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SelfMonitoringRules != nil {
+		in, out := &in.SelfMonitoringRules, &out.SelfMonitoringRules
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonDefaultableParams.
@@ -752,6 +799,26 @@ func (in *DNSSDConfig) DeepCopy() *DNSSDConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogIngestion) DeepCopyInto(out *DatadogIngestion) {
+	*out = *in
+	if in.SanitizeMetricName != nil {
+		in, out := &in.SanitizeMetricName, &out.SanitizeMetricName
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogIngestion.
+func (in *DatadogIngestion) DeepCopy() *DatadogIngestion {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogIngestion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DigitalOceanSDConfig) DeepCopyInto(out *DigitalOceanSDConfig) {
 	*out = *in
@@ -1052,6 +1119,28 @@ func (in *EmbeddedIngress) DeepCopy() *EmbeddedIngress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedNetworkPolicy) DeepCopyInto(out *EmbeddedNetworkPolicy) {
+	*out = *in
+	if in.IngressFrom != nil {
+		in, out := &in.IngressFrom, &out.IngressFrom
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedNetworkPolicy.
+func (in *EmbeddedNetworkPolicy) DeepCopy() *EmbeddedNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmbeddedObjectMetadata) DeepCopyInto(out *EmbeddedObjectMetadata) {
 	*out = *in
@@ -1162,6 +1251,51 @@ func (in *EmbeddedProbes) DeepCopy() *EmbeddedProbes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedRoute) DeepCopyInto(out *EmbeddedRoute) {
+	*out = *in
+	in.EmbeddedObjectMetadata.DeepCopyInto(&out.EmbeddedObjectMetadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedRoute.
+func (in *EmbeddedRoute) DeepCopy() *EmbeddedRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedVPA) DeepCopyInto(out *EmbeddedVPA) {
+	*out = *in
+	if in.MinAllowed != nil {
+		in, out := &in.MinAllowed, &out.MinAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxAllowed != nil {
+		in, out := &in.MaxAllowed, &out.MaxAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedVPA.
+func (in *EmbeddedVPA) DeepCopy() *EmbeddedVPA {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedVPA)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 	*out = *in
@@ -1384,6 +1518,33 @@ func (in *GCESDConfig) DeepCopy() *GCESDConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaDashboard) DeepCopyInto(out *GrafanaDashboard) {
+	*out = *in
+	if in.InstanceSelector != nil {
+		in, out := &in.InstanceSelector, &out.InstanceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapLabels != nil {
+		in, out := &in.ConfigMapLabels, &out.ConfigMapLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaDashboard.
+func (in *GrafanaDashboard) DeepCopy() *GrafanaDashboard {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDashboard)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPAuth) DeepCopyInto(out *HTTPAuth) {
 	*out = *in
@@ -1534,6 +1695,21 @@ func (in *ImageConfig) DeepCopy() *ImageConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVersion) DeepCopyInto(out *ImageVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVersion.
+func (in *ImageVersion) DeepCopy() *ImageVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InhibitRule) DeepCopyInto(out *InhibitRule) {
 	*out = *in
@@ -1762,6 +1938,11 @@ func (in *ManagedObjectsMetadata) DeepCopyInto(out *ManagedObjectsMetadata) {
 			(*out)[key] = val
 		}
 	}
+	if in.PropagateCRMetadata != nil {
+		in, out := &in.PropagateCRMetadata, &out.PropagateCRMetadata
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedObjectsMetadata.
@@ -1852,6 +2033,36 @@ func (in *OAuth2) DeepCopy() *OAuth2 {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NewRelicIngestion) DeepCopyInto(out *NewRelicIngestion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NewRelicIngestion.
+func (in *NewRelicIngestion) DeepCopy() *NewRelicIngestion {
+	if in == nil {
+		return nil
+	}
+	out := new(NewRelicIngestion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OTLPIngestion) DeepCopyInto(out *OTLPIngestion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OTLPIngestion.
+func (in *OTLPIngestion) DeepCopy() *OTLPIngestion {
+	if in == nil {
+		return nil
+	}
+	out := new(OTLPIngestion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenStackSDConfig) DeepCopyInto(out *OpenStackSDConfig) {
 	*out = *in
@@ -2080,6 +2291,21 @@ func (in *PodMetricsEndpoint) DeepCopy() *PodMetricsEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplatePatch) DeepCopyInto(out *PodTemplatePatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplatePatch.
+func (in *PodTemplatePatch) DeepCopy() *PodTemplatePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplatePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProbeTargetIngress) DeepCopyInto(out *ProbeTargetIngress) {
 	*out = *in
@@ -2173,6 +2399,26 @@ func (in *PushoverConfig) DeepCopy() *PushoverConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryLogging) DeepCopyInto(out *QueryLogging) {
+	*out = *in
+	if in.MaxConcurrentRequests != nil {
+		in, out := &in.MaxConcurrentRequests, &out.MaxConcurrentRequests
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryLogging.
+func (in *QueryLogging) DeepCopy() *QueryLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Receiver) DeepCopyInto(out *Receiver) {
 	*out = *in
@@ -2571,6 +2817,26 @@ func (in *SecurityContext) DeepCopy() *SecurityContext {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerTLS) DeepCopyInto(out *ServerTLS) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerTLS.
+func (in *ServerTLS) DeepCopy() *ServerTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Sigv4Config) DeepCopyInto(out *Sigv4Config) {
 	*out = *in
@@ -2737,6 +3003,21 @@ func (in *SnsConfig) DeepCopy() *SnsConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpreadPolicy) DeepCopyInto(out *SpreadPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpreadPolicy.
+func (in *SpreadPolicy) DeepCopy() *SpreadPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SpreadPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticConfig) DeepCopyInto(out *StaticConfig) {
 	*out = *in
@@ -3385,31 +3666,31 @@ func (in *UnauthorizedAccessConfigURLMap) DeepCopy() *UnauthorizedAccessConfigUR
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VLogs) DeepCopyInto(out *VLogs) {
+func (in *VLSingle) DeepCopyInto(out *VLSingle) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	if in.ParsedLastAppliedSpec != nil {
 		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
-		*out = new(VLogsSpec)
+		*out = new(VLSingleSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogs.
-func (in *VLogs) DeepCopy() *VLogs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLSingle.
+func (in *VLSingle) DeepCopy() *VLSingle {
 	if in == nil {
 		return nil
 	}
-	out := new(VLogs)
+	out := new(VLSingle)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VLogs) DeepCopyObject() runtime.Object {
+func (in *VLSingle) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3417,31 +3698,31 @@ func (in *VLogs) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VLogsList) DeepCopyInto(out *VLogsList) {
+func (in *VLSingleList) DeepCopyInto(out *VLSingleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VLogs, len(*in))
+		*out = make([]VLSingle, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogsList.
-func (in *VLogsList) DeepCopy() *VLogsList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLSingleList.
+func (in *VLSingleList) DeepCopy() *VLSingleList {
 	if in == nil {
 		return nil
 	}
-	out := new(VLogsList)
+	out := new(VLSingleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VLogsList) DeepCopyObject() runtime.Object {
+func (in *VLSingleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3449,7 +3730,7 @@ func (in *VLogsList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VLogsSpec) DeepCopyInto(out *VLogsSpec) {
+func (in *VLSingleSpec) DeepCopyInto(out *VLSingleSpec) {
 	*out = *in
 	if in.PodMetadata != nil {
 		in, out := &in.PodMetadata, &out.PodMetadata
@@ -3474,6 +3755,13 @@ func (in *VLogsSpec) DeepCopyInto(out *VLogsSpec) {
 		*out = new(AdditionalServiceSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalServices != nil {
+		in, out := &in.AdditionalServices, &out.AdditionalServices
+		*out = make([]AdditionalServiceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ServiceScrapeSpec != nil {
 		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
 		*out = new(VMServiceScrapeSpec)
@@ -3486,58 +3774,58 @@ func (in *VLogsSpec) DeepCopyInto(out *VLogsSpec) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogsSpec.
-func (in *VLogsSpec) DeepCopy() *VLogsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLSingleSpec.
+func (in *VLSingleSpec) DeepCopy() *VLSingleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VLogsSpec)
+	out := new(VLSingleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VLogsStatus) DeepCopyInto(out *VLogsStatus) {
+func (in *VLSingleStatus) DeepCopyInto(out *VLSingleStatus) {
 	*out = *in
 	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogsStatus.
-func (in *VLogsStatus) DeepCopy() *VLogsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLSingleStatus.
+func (in *VLSingleStatus) DeepCopy() *VLSingleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VLogsStatus)
+	out := new(VLSingleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAgent) DeepCopyInto(out *VMAgent) {
+func (in *VLogs) DeepCopyInto(out *VLogs) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	if in.ParsedLastAppliedSpec != nil {
 		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
-		*out = new(VMAgentSpec)
+		*out = new(VLogsSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgent.
-func (in *VMAgent) DeepCopy() *VMAgent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogs.
+func (in *VLogs) DeepCopy() *VLogs {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAgent)
+	out := new(VLogs)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMAgent) DeepCopyObject() runtime.Object {
+func (in *VLogs) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3545,31 +3833,31 @@ func (in *VMAgent) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAgentList) DeepCopyInto(out *VMAgentList) {
+func (in *VLogsList) DeepCopyInto(out *VLogsList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VMAgent, len(*in))
+		*out = make([]VLogs, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentList.
-func (in *VMAgentList) DeepCopy() *VMAgentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogsList.
+func (in *VLogsList) DeepCopy() *VLogsList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAgentList)
+	out := new(VLogsList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMAgentList) DeepCopyObject() runtime.Object {
+func (in *VLogsList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3577,17 +3865,226 @@ func (in *VMAgentList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAgentRemoteWriteSettings) DeepCopyInto(out *VMAgentRemoteWriteSettings) {
+func (in *VLogsSpec) DeepCopyInto(out *VLogsSpec) {
 	*out = *in
-	if in.MaxBlockSize != nil {
-		in, out := &in.MaxBlockSize, &out.MaxBlockSize
-		*out = new(int32)
-		**out = **in
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(EmbeddedObjectMetadata)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.MaxDiskUsagePerURL != nil {
-		in, out := &in.MaxDiskUsagePerURL, &out.MaxDiskUsagePerURL
-		*out = new(int64)
-		**out = **in
+	if in.ManagedMetadata != nil {
+		in, out := &in.ManagedMetadata, &out.ManagedMetadata
+		*out = new(ManagedObjectsMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(v1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.StorageMetadata.DeepCopyInto(&out.StorageMetadata)
+	if in.ServiceSpec != nil {
+		in, out := &in.ServiceSpec, &out.ServiceSpec
+		*out = new(AdditionalServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalServices != nil {
+		in, out := &in.AdditionalServices, &out.AdditionalServices
+		*out = make([]AdditionalServiceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceScrapeSpec != nil {
+		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
+		*out = new(VMServiceScrapeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogsSpec.
+func (in *VLogsSpec) DeepCopy() *VLogsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VLogsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VLogsStatus) DeepCopyInto(out *VLogsStatus) {
+	*out = *in
+	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogsStatus.
+func (in *VLogsStatus) DeepCopy() *VLogsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VLogsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgent) DeepCopyInto(out *VMAgent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ParsedLastAppliedSpec != nil {
+		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
+		*out = new(VMAgentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgent.
+func (in *VMAgent) DeepCopy() *VMAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAgent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentFederationTarget) DeepCopyInto(out *VMAgentFederationTarget) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MetricRelabelConfigs != nil {
+		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	in.EndpointAuth.DeepCopyInto(&out.EndpointAuth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentFederationTarget.
+func (in *VMAgentFederationTarget) DeepCopy() *VMAgentFederationTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentFederationTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentKEDA) DeepCopyInto(out *VMAgentKEDA) {
+	*out = *in
+	if in.MinReplicaCount != nil {
+		in, out := &in.MinReplicaCount, &out.MinReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentKEDA.
+func (in *VMAgentKEDA) DeepCopy() *VMAgentKEDA {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentKEDA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentList) DeepCopyInto(out *VMAgentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMAgent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentList.
+func (in *VMAgentList) DeepCopy() *VMAgentList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAgentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentRemoteWriteSettings) DeepCopyInto(out *VMAgentRemoteWriteSettings) {
+	*out = *in
+	if in.MaxBlockSize != nil {
+		in, out := &in.MaxBlockSize, &out.MaxBlockSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDiskUsagePerURL != nil {
+		in, out := &in.MaxDiskUsagePerURL, &out.MaxDiskUsagePerURL
+		*out = new(int64)
+		**out = **in
 	}
 	if in.Queues != nil {
 		in, out := &in.Queues, &out.Queues
@@ -3668,6 +4165,11 @@ func (in *VMAgentRemoteWriteSpec) DeepCopyInto(out *VMAgentRemoteWriteSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ProxyURL != nil {
+		in, out := &in.ProxyURL, &out.ProxyURL
+		*out = new(string)
+		**out = **in
+	}
 	if in.Headers != nil {
 		in, out := &in.Headers, &out.Headers
 		*out = make([]string, len(*in))
@@ -3695,6 +4197,22 @@ func (in *VMAgentRemoteWriteSpec) DeepCopy() *VMAgentRemoteWriteSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentRemoteWriteStatus) DeepCopyInto(out *VMAgentRemoteWriteStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentRemoteWriteStatus.
+func (in *VMAgentRemoteWriteStatus) DeepCopy() *VMAgentRemoteWriteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentRemoteWriteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAgentSecurityEnforcements) DeepCopyInto(out *VMAgentSecurityEnforcements) {
 	*out = *in
@@ -3711,6 +4229,61 @@ func (in *VMAgentSecurityEnforcements) DeepCopy() *VMAgentSecurityEnforcements {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentServiceAccountToken) DeepCopyInto(out *VMAgentServiceAccountToken) {
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentServiceAccountToken.
+func (in *VMAgentServiceAccountToken) DeepCopy() *VMAgentServiceAccountToken {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentServiceAccountToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentShardTargetsStatus) DeepCopyInto(out *VMAgentShardTargetsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentShardTargetsStatus.
+func (in *VMAgentShardTargetsStatus) DeepCopy() *VMAgentShardTargetsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentShardTargetsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentShardingSpec) DeepCopyInto(out *VMAgentShardingSpec) {
+	*out = *in
+	if in.ReplicationFactor != nil {
+		in, out := &in.ReplicationFactor, &out.ReplicationFactor
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentShardingSpec.
+func (in *VMAgentShardingSpec) DeepCopy() *VMAgentShardingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentShardingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAgentSpec) DeepCopyInto(out *VMAgentSpec) {
 	*out = *in
@@ -3724,8 +4297,18 @@ func (in *VMAgentSpec) DeepCopyInto(out *VMAgentSpec) {
 		*out = new(ManagedObjectsMetadata)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.APIServerConfig != nil {
-		in, out := &in.APIServerConfig, &out.APIServerConfig
+	if in.MaxScrapeSize != nil {
+		in, out := &in.MaxScrapeSize, &out.MaxScrapeSize
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScrapeConfigFiles != nil {
+		in, out := &in.ScrapeConfigFiles, &out.ScrapeConfigFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.APIServerConfig != nil {
+		in, out := &in.APIServerConfig, &out.APIServerConfig
 		*out = new(APIServerConfig)
 		(*in).DeepCopyInto(*out)
 	}
@@ -3835,16 +4418,35 @@ func (in *VMAgentSpec) DeepCopyInto(out *VMAgentSpec) {
 		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FederationTargets != nil {
+		in, out := &in.FederationTargets, &out.FederationTargets
+		*out = make([]VMAgentFederationTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.InsertPorts != nil {
 		in, out := &in.InsertPorts, &out.InsertPorts
 		*out = new(InsertPorts)
 		**out = **in
 	}
+	if in.OTLP != nil {
+		in, out := &in.OTLP, &out.OTLP
+		*out = new(OTLPIngestion)
+		**out = **in
+	}
 	if in.ServiceSpec != nil {
 		in, out := &in.ServiceSpec, &out.ServiceSpec
 		*out = new(AdditionalServiceSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalServices != nil {
+		in, out := &in.AdditionalServices, &out.AdditionalServices
+		*out = make([]AdditionalServiceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ServiceScrapeSpec != nil {
 		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
 		*out = new(VMServiceScrapeSpec)
@@ -3855,6 +4457,11 @@ func (in *VMAgentSpec) DeepCopyInto(out *VMAgentSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Sharding != nil {
+		in, out := &in.Sharding, &out.Sharding
+		*out = new(VMAgentShardingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.UpdateStrategy != nil {
 		in, out := &in.UpdateStrategy, &out.UpdateStrategy
 		*out = new(appsv1.DeploymentStrategyType)
@@ -3865,6 +4472,21 @@ func (in *VMAgentSpec) DeepCopyInto(out *VMAgentSpec) {
 		*out = new(appsv1.RollingUpdateDeployment)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(EmbeddedNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(EmbeddedVPA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KEDA != nil {
+		in, out := &in.KEDA, &out.KEDA
+		*out = new(VMAgentKEDA)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodDisruptionBudget != nil {
 		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
 		*out = new(EmbeddedPodDisruptionBudgetSpec)
@@ -3963,11 +4585,38 @@ func (in *VMAgentSpec) DeepCopyInto(out *VMAgentSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PersistentVolumeClaimRetentionPolicy != nil {
+		in, out := &in.PersistentVolumeClaimRetentionPolicy, &out.PersistentVolumeClaimRetentionPolicy
+		*out = new(appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy)
+		**out = **in
+	}
+	if in.RetainPVCs != nil {
+		in, out := &in.RetainPVCs, &out.RetainPVCs
+		*out = new(bool)
+		**out = **in
+	}
 	if in.License != nil {
 		in, out := &in.License, &out.License
 		*out = new(License)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CloudIdentity != nil {
+		in, out := &in.CloudIdentity, &out.CloudIdentity
+		*out = new(CloudIdentity)
+		**out = **in
+	}
+	if in.GrafanaDashboard != nil {
+		in, out := &in.GrafanaDashboard, &out.GrafanaDashboard
+		*out = new(GrafanaDashboard)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountTokens != nil {
+		in, out := &in.ServiceAccountTokens, &out.ServiceAccountTokens
+		*out = make([]VMAgentServiceAccountToken, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.VMAgentSecurityEnforcements = in.VMAgentSecurityEnforcements
 	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
 	in.CommonConfigReloaderParams.DeepCopyInto(&out.CommonConfigReloaderParams)
@@ -3987,6 +4636,18 @@ func (in *VMAgentSpec) DeepCopy() *VMAgentSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAgentStatus) DeepCopyInto(out *VMAgentStatus) {
 	*out = *in
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = make([]VMAgentRemoteWriteStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ShardTargets != nil {
+		in, out := &in.ShardTargets, &out.ShardTargets
+		*out = make([]VMAgentShardTargetsStatus, len(*in))
+		copy(*out, *in)
+	}
 	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
 }
 
@@ -4158,6 +4819,46 @@ func (in *VMAlertRemoteWriteSpec) DeepCopy() *VMAlertRemoteWriteSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertRuleConfigMapStatus) DeepCopyInto(out *VMAlertRuleConfigMapStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertRuleConfigMapStatus.
+func (in *VMAlertRuleConfigMapStatus) DeepCopy() *VMAlertRuleConfigMapStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertRuleConfigMapStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertRuleSelectionStatus) DeepCopyInto(out *VMAlertRuleSelectionStatus) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InvalidRules != nil {
+		in, out := &in.InvalidRules, &out.InvalidRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertRuleSelectionStatus.
+func (in *VMAlertRuleSelectionStatus) DeepCopy() *VMAlertRuleSelectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertRuleSelectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAlertSpec) DeepCopyInto(out *VMAlertSpec) {
 	*out = *in
@@ -4226,6 +4927,13 @@ func (in *VMAlertSpec) DeepCopyInto(out *VMAlertSpec) {
 		*out = new(AdditionalServiceSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalServices != nil {
+		in, out := &in.AdditionalServices, &out.AdditionalServices
+		*out = make([]AdditionalServiceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ServiceScrapeSpec != nil {
 		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
 		*out = new(VMServiceScrapeSpec)
@@ -4241,6 +4949,16 @@ func (in *VMAlertSpec) DeepCopyInto(out *VMAlertSpec) {
 		*out = new(appsv1.RollingUpdateDeployment)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(EmbeddedNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(EmbeddedVPA)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodDisruptionBudget != nil {
 		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
 		*out = new(EmbeddedPodDisruptionBudgetSpec)
@@ -4256,6 +4974,11 @@ func (in *VMAlertSpec) DeepCopyInto(out *VMAlertSpec) {
 		*out = new(License)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GrafanaDashboard != nil {
+		in, out := &in.GrafanaDashboard, &out.GrafanaDashboard
+		*out = new(GrafanaDashboard)
+		(*in).DeepCopyInto(*out)
+	}
 	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
 	in.CommonConfigReloaderParams.DeepCopyInto(&out.CommonConfigReloaderParams)
 	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
@@ -4274,6 +4997,16 @@ func (in *VMAlertSpec) DeepCopy() *VMAlertSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAlertStatus) DeepCopyInto(out *VMAlertStatus) {
 	*out = *in
+	if in.RuleSelection != nil {
+		in, out := &in.RuleSelection, &out.RuleSelection
+		*out = new(VMAlertRuleSelectionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuleConfigMaps != nil {
+		in, out := &in.RuleConfigMaps, &out.RuleConfigMaps
+		*out = make([]VMAlertRuleConfigMapStatus, len(*in))
+		copy(*out, *in)
+	}
 	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
 }
 
@@ -4378,6 +5111,26 @@ func (in *VMAlertmanagerConfigList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerConfigParentRoute) DeepCopyInto(out *VMAlertmanagerConfigParentRoute) {
+	*out = *in
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfigParentRoute.
+func (in *VMAlertmanagerConfigParentRoute) DeepCopy() *VMAlertmanagerConfigParentRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerConfigParentRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAlertmanagerConfigSpec) DeepCopyInto(out *VMAlertmanagerConfigSpec) {
 	*out = *in
@@ -4467,6 +5220,181 @@ func (in *VMAlertmanagerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerNamespaceMatcher) DeepCopyInto(out *VMAlertmanagerNamespaceMatcher) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerNamespaceMatcher.
+func (in *VMAlertmanagerNamespaceMatcher) DeepCopy() *VMAlertmanagerNamespaceMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerNamespaceMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerSilence) DeepCopyInto(out *VMAlertmanagerSilence) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ParsedLastAppliedSpec != nil {
+		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
+		*out = new(VMAlertmanagerSilenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerSilence.
+func (in *VMAlertmanagerSilence) DeepCopy() *VMAlertmanagerSilence {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerSilence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAlertmanagerSilence) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerSilenceList) DeepCopyInto(out *VMAlertmanagerSilenceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMAlertmanagerSilence, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerSilenceList.
+func (in *VMAlertmanagerSilenceList) DeepCopy() *VMAlertmanagerSilenceList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerSilenceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAlertmanagerSilenceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerSilenceMatcher) DeepCopyInto(out *VMAlertmanagerSilenceMatcher) {
+	*out = *in
+	if in.IsEqual != nil {
+		in, out := &in.IsEqual, &out.IsEqual
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerSilenceMatcher.
+func (in *VMAlertmanagerSilenceMatcher) DeepCopy() *VMAlertmanagerSilenceMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerSilenceMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerSilenceRef) DeepCopyInto(out *VMAlertmanagerSilenceRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerSilenceRef.
+func (in *VMAlertmanagerSilenceRef) DeepCopy() *VMAlertmanagerSilenceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerSilenceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerSilenceSpec) DeepCopyInto(out *VMAlertmanagerSilenceSpec) {
+	*out = *in
+	out.AlertmanagerRef = in.AlertmanagerRef
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]VMAlertmanagerSilenceMatcher, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartsAt != nil {
+		in, out := &in.StartsAt, &out.StartsAt
+		*out = (*in).DeepCopy()
+	}
+	if in.EndsAt != nil {
+		in, out := &in.EndsAt, &out.EndsAt
+		*out = (*in).DeepCopy()
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerSilenceSpec.
+func (in *VMAlertmanagerSilenceSpec) DeepCopy() *VMAlertmanagerSilenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerSilenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerSilenceStatus) DeepCopyInto(out *VMAlertmanagerSilenceStatus) {
+	*out = *in
+	if in.EndsAt != nil {
+		in, out := &in.EndsAt, &out.EndsAt
+		*out = (*in).DeepCopy()
+	}
+	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerSilenceStatus.
+func (in *VMAlertmanagerSilenceStatus) DeepCopy() *VMAlertmanagerSilenceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerSilenceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAlertmanagerSpec) DeepCopyInto(out *VMAlertmanagerSpec) {
 	*out = *in
@@ -4500,11 +5428,28 @@ func (in *VMAlertmanagerSpec) DeepCopyInto(out *VMAlertmanagerSpec) {
 		*out = new(AdditionalServiceSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalServices != nil {
+		in, out := &in.AdditionalServices, &out.AdditionalServices
+		*out = make([]AdditionalServiceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ServiceScrapeSpec != nil {
 		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
 		*out = new(VMServiceScrapeSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(EmbeddedNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(EmbeddedVPA)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodDisruptionBudget != nil {
 		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
 		*out = new(EmbeddedPodDisruptionBudgetSpec)
@@ -4525,11 +5470,21 @@ func (in *VMAlertmanagerSpec) DeepCopyInto(out *VMAlertmanagerSpec) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NamespaceMatcher != nil {
+		in, out := &in.NamespaceMatcher, &out.NamespaceMatcher
+		*out = new(VMAlertmanagerNamespaceMatcher)
+		**out = **in
+	}
 	if in.EnforcedTopRouteMatchers != nil {
 		in, out := &in.EnforcedTopRouteMatchers, &out.EnforcedTopRouteMatchers
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConfigParentRoute != nil {
+		in, out := &in.ConfigParentRoute, &out.ConfigParentRoute
+		*out = new(VMAlertmanagerConfigParentRoute)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ClaimTemplates != nil {
 		in, out := &in.ClaimTemplates, &out.ClaimTemplates
 		*out = make([]v1.PersistentVolumeClaim, len(*in))
@@ -4537,6 +5492,16 @@ func (in *VMAlertmanagerSpec) DeepCopyInto(out *VMAlertmanagerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PersistentVolumeClaimRetentionPolicy != nil {
+		in, out := &in.PersistentVolumeClaimRetentionPolicy, &out.PersistentVolumeClaimRetentionPolicy
+		*out = new(appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy)
+		**out = **in
+	}
+	if in.RetainPVCs != nil {
+		in, out := &in.RetainPVCs, &out.RetainPVCs
+		*out = new(bool)
+		**out = **in
+	}
 	if in.WebConfig != nil {
 		in, out := &in.WebConfig, &out.WebConfig
 		*out = new(AlertmanagerWebConfig)
@@ -4728,8 +5693,15 @@ func (in *VMAuthSpec) DeepCopyInto(out *VMAuthSpec) {
 		*out = new(AdditionalServiceSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ServiceScrapeSpec != nil {
-		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
+	if in.AdditionalServices != nil {
+		in, out := &in.AdditionalServices, &out.AdditionalServices
+		*out = make([]AdditionalServiceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceScrapeSpec != nil {
+		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
 		*out = new(VMServiceScrapeSpec)
 		(*in).DeepCopyInto(*out)
 	}
@@ -4738,11 +5710,36 @@ func (in *VMAuthSpec) DeepCopyInto(out *VMAuthSpec) {
 		*out = new(EmbeddedPodDisruptionBudgetSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(appsv1.DeploymentStrategyType)
+		**out = **in
+	}
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(appsv1.RollingUpdateDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(EmbeddedNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(EmbeddedVPA)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Ingress != nil {
 		in, out := &in.Ingress, &out.Ingress
 		*out = new(EmbeddedIngress)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(EmbeddedRoute)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EmbeddedProbes != nil {
 		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
 		*out = new(EmbeddedProbes)
@@ -4909,6 +5906,11 @@ func (in *VMBackup) DeepCopyInto(out *VMBackup) {
 		*out = new(VMRestore)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(VMBackupVerification)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBackup.
@@ -4921,6 +5923,140 @@ func (in *VMBackup) DeepCopy() *VMBackup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMBackupLocation) DeepCopyInto(out *VMBackupLocation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBackupLocation.
+func (in *VMBackupLocation) DeepCopy() *VMBackupLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(VMBackupLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMBackupLocation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMBackupLocationList) DeepCopyInto(out *VMBackupLocationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMBackupLocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBackupLocationList.
+func (in *VMBackupLocationList) DeepCopy() *VMBackupLocationList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMBackupLocationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMBackupLocationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMBackupLocationSpec) DeepCopyInto(out *VMBackupLocationSpec) {
+	*out = *in
+	if in.CustomS3Endpoint != nil {
+		in, out := &in.CustomS3Endpoint, &out.CustomS3Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBackupLocationSpec.
+func (in *VMBackupLocationSpec) DeepCopy() *VMBackupLocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMBackupLocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMBackupVerification) DeepCopyInto(out *VMBackupVerification) {
+	*out = *in
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBackupVerification.
+func (in *VMBackupVerification) DeepCopy() *VMBackupVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(VMBackupVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMBackupVerificationStatus) DeepCopyInto(out *VMBackupVerificationStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBackupVerificationStatus.
+func (in *VMBackupVerificationStatus) DeepCopy() *VMBackupVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMBackupVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMCluster) DeepCopyInto(out *VMCluster) {
 	*out = *in
@@ -4985,6 +6121,29 @@ func (in *VMClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMClusterRecommendations) DeepCopyInto(out *VMClusterRecommendations) {
+	*out = *in
+	in.VMStorageResources.DeepCopyInto(&out.VMStorageResources)
+	if in.VMStorageReplicaCount != nil {
+		in, out := &in.VMStorageReplicaCount, &out.VMStorageReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	in.VMSelectResources.DeepCopyInto(&out.VMSelectResources)
+	in.VMInsertResources.DeepCopyInto(&out.VMInsertResources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterRecommendations.
+func (in *VMClusterRecommendations) DeepCopy() *VMClusterRecommendations {
+	if in == nil {
+		return nil
+	}
+	out := new(VMClusterRecommendations)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMClusterSpec) DeepCopyInto(out *VMClusterSpec) {
 	*out = *in
@@ -4993,6 +6152,16 @@ func (in *VMClusterSpec) DeepCopyInto(out *VMClusterSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.CloudIdentity != nil {
+		in, out := &in.CloudIdentity, &out.CloudIdentity
+		*out = new(CloudIdentity)
+		**out = **in
+	}
+	if in.GrafanaDashboard != nil {
+		in, out := &in.GrafanaDashboard, &out.GrafanaDashboard
+		*out = new(GrafanaDashboard)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]v1.LocalObjectReference, len(*in))
@@ -5024,6 +6193,11 @@ func (in *VMClusterSpec) DeepCopyInto(out *VMClusterSpec) {
 		**out = **in
 	}
 	in.RequestsLoadBalancer.DeepCopyInto(&out.RequestsLoadBalancer)
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(EmbeddedNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ManagedMetadata != nil {
 		in, out := &in.ManagedMetadata, &out.ManagedMetadata
 		*out = new(ManagedObjectsMetadata)
@@ -5045,101 +6219,59 @@ func (in *VMClusterSpec) DeepCopy() *VMClusterSpec {
 func (in *VMClusterStatus) DeepCopyInto(out *VMClusterStatus) {
 	*out = *in
 	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterStatus.
-func (in *VMClusterStatus) DeepCopy() *VMClusterStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(VMClusterStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMInsert) DeepCopyInto(out *VMInsert) {
-	*out = *in
-	if in.PodMetadata != nil {
-		in, out := &in.PodMetadata, &out.PodMetadata
-		*out = new(EmbeddedObjectMetadata)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.InsertPorts != nil {
-		in, out := &in.InsertPorts, &out.InsertPorts
-		*out = new(InsertPorts)
-		**out = **in
-	}
-	if in.ServiceSpec != nil {
-		in, out := &in.ServiceSpec, &out.ServiceSpec
-		*out = new(AdditionalServiceSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ServiceScrapeSpec != nil {
-		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
-		*out = new(VMServiceScrapeSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.UpdateStrategy != nil {
-		in, out := &in.UpdateStrategy, &out.UpdateStrategy
-		*out = new(appsv1.DeploymentStrategyType)
-		**out = **in
-	}
-	if in.RollingUpdate != nil {
-		in, out := &in.RollingUpdate, &out.RollingUpdate
-		*out = new(appsv1.RollingUpdateDeployment)
+	if in.VMStorageRestore != nil {
+		in, out := &in.VMStorageRestore, &out.VMStorageRestore
+		*out = new(VMRestoreFromStatus)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.PodDisruptionBudget != nil {
-		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
-		*out = new(EmbeddedPodDisruptionBudgetSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.EmbeddedProbes != nil {
-		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
-		*out = new(EmbeddedProbes)
+	if in.VMStorageBackupVerification != nil {
+		in, out := &in.VMStorageBackupVerification, &out.VMStorageBackupVerification
+		*out = new(VMBackupVerificationStatus)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.HPA != nil {
-		in, out := &in.HPA, &out.HPA
-		*out = new(EmbeddedHPA)
+	if in.Recommendations != nil {
+		in, out := &in.Recommendations, &out.Recommendations
+		*out = new(VMClusterRecommendations)
 		(*in).DeepCopyInto(*out)
 	}
-	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
-	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMInsert.
-func (in *VMInsert) DeepCopy() *VMInsert {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterStatus.
+func (in *VMClusterStatus) DeepCopy() *VMClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMInsert)
+	out := new(VMClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMNodeScrape) DeepCopyInto(out *VMNodeScrape) {
+func (in *VMDataMigration) DeepCopyInto(out *VMDataMigration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ParsedLastAppliedSpec != nil {
+		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
+		*out = new(VMDataMigrationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMNodeScrape.
-func (in *VMNodeScrape) DeepCopy() *VMNodeScrape {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMDataMigration.
+func (in *VMDataMigration) DeepCopy() *VMDataMigration {
 	if in == nil {
 		return nil
 	}
-	out := new(VMNodeScrape)
+	out := new(VMDataMigration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMNodeScrape) DeepCopyObject() runtime.Object {
+func (in *VMDataMigration) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5147,31 +6279,46 @@ func (in *VMNodeScrape) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMNodeScrapeList) DeepCopyInto(out *VMNodeScrapeList) {
+func (in *VMDataMigrationDestinationRef) DeepCopyInto(out *VMDataMigrationDestinationRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMDataMigrationDestinationRef.
+func (in *VMDataMigrationDestinationRef) DeepCopy() *VMDataMigrationDestinationRef {
+	if in == nil {
+		return nil
+	}
+	out := new(VMDataMigrationDestinationRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMDataMigrationList) DeepCopyInto(out *VMDataMigrationList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VMNodeScrape, len(*in))
+		*out = make([]VMDataMigration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMNodeScrapeList.
-func (in *VMNodeScrapeList) DeepCopy() *VMNodeScrapeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMDataMigrationList.
+func (in *VMDataMigrationList) DeepCopy() *VMDataMigrationList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMNodeScrapeList)
+	out := new(VMDataMigrationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMNodeScrapeList) DeepCopyObject() runtime.Object {
+func (in *VMDataMigrationList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5179,139 +6326,118 @@ func (in *VMNodeScrapeList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMNodeScrapeSpec) DeepCopyInto(out *VMNodeScrapeSpec) {
+func (in *VMDataMigrationSource) DeepCopyInto(out *VMDataMigrationSource) {
 	*out = *in
-	if in.TargetLabels != nil {
-		in, out := &in.TargetLabels, &out.TargetLabels
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		(*in).DeepCopyInto(*out)
 	}
-	in.EndpointRelabelings.DeepCopyInto(&out.EndpointRelabelings)
-	in.EndpointAuth.DeepCopyInto(&out.EndpointAuth)
-	in.EndpointScrapeParams.DeepCopyInto(&out.EndpointScrapeParams)
-	in.Selector.DeepCopyInto(&out.Selector)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMNodeScrapeSpec.
-func (in *VMNodeScrapeSpec) DeepCopy() *VMNodeScrapeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMDataMigrationSource.
+func (in *VMDataMigrationSource) DeepCopy() *VMDataMigrationSource {
 	if in == nil {
 		return nil
 	}
-	out := new(VMNodeScrapeSpec)
+	out := new(VMDataMigrationSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMPodScrape) DeepCopyInto(out *VMPodScrape) {
+func (in *VMDataMigrationSpec) DeepCopyInto(out *VMDataMigrationSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMPodScrape.
-func (in *VMPodScrape) DeepCopy() *VMPodScrape {
-	if in == nil {
-		return nil
+	in.Source.DeepCopyInto(&out.Source)
+	out.DestinationRef = in.DestinationRef
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(int64)
+		**out = **in
 	}
-	out := new(VMPodScrape)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMPodScrape) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
 	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMPodScrapeList) DeepCopyInto(out *VMPodScrapeList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]VMPodScrape, len(*in))
+	out.Image = in.Image
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExtraEnvs != nil {
+		in, out := &in.ExtraEnvs, &out.ExtraEnvs
+		*out = make([]v1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMPodScrapeList.
-func (in *VMPodScrapeList) DeepCopy() *VMPodScrapeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMDataMigrationSpec.
+func (in *VMDataMigrationSpec) DeepCopy() *VMDataMigrationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMPodScrapeList)
+	out := new(VMDataMigrationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMPodScrapeList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMPodScrapeSpec) DeepCopyInto(out *VMPodScrapeSpec) {
+func (in *VMDataMigrationStatus) DeepCopyInto(out *VMDataMigrationStatus) {
 	*out = *in
-	if in.PodTargetLabels != nil {
-		in, out := &in.PodTargetLabels, &out.PodTargetLabels
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
 	}
-	if in.PodMetricsEndpoints != nil {
-		in, out := &in.PodMetricsEndpoints, &out.PodMetricsEndpoints
-		*out = make([]PodMetricsEndpoint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
-	in.Selector.DeepCopyInto(&out.Selector)
-	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
-	in.AttachMetadata.DeepCopyInto(&out.AttachMetadata)
+	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMPodScrapeSpec.
-func (in *VMPodScrapeSpec) DeepCopy() *VMPodScrapeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMDataMigrationStatus.
+func (in *VMDataMigrationStatus) DeepCopy() *VMDataMigrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMPodScrapeSpec)
+	out := new(VMDataMigrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMProbe) DeepCopyInto(out *VMProbe) {
+func (in *VMGateway) DeepCopyInto(out *VMGateway) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ParsedLastAppliedSpec != nil {
+		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
+		*out = new(VMGatewaySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProbe.
-func (in *VMProbe) DeepCopy() *VMProbe {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMGateway.
+func (in *VMGateway) DeepCopy() *VMGateway {
 	if in == nil {
 		return nil
 	}
-	out := new(VMProbe)
+	out := new(VMGateway)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMProbe) DeepCopyObject() runtime.Object {
+func (in *VMGateway) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5319,31 +6445,61 @@ func (in *VMProbe) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMProbeList) DeepCopyInto(out *VMProbeList) {
+func (in *VMGatewayAuth) DeepCopyInto(out *VMGatewayAuth) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OIDCDiscoveryEndpoints != nil {
+		in, out := &in.OIDCDiscoveryEndpoints, &out.OIDCDiscoveryEndpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.JWKSEndpoints != nil {
+		in, out := &in.JWKSEndpoints, &out.JWKSEndpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMGatewayAuth.
+func (in *VMGatewayAuth) DeepCopy() *VMGatewayAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VMGatewayAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMGatewayList) DeepCopyInto(out *VMGatewayList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VMProbe, len(*in))
+		*out = make([]VMGateway, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProbeList.
-func (in *VMProbeList) DeepCopy() *VMProbeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMGatewayList.
+func (in *VMGatewayList) DeepCopy() *VMGatewayList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMProbeList)
+	out := new(VMGatewayList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMProbeList) DeepCopyObject() runtime.Object {
+func (in *VMGatewayList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5351,150 +6507,209 @@ func (in *VMProbeList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMProbeSpec) DeepCopyInto(out *VMProbeSpec) {
+func (in *VMGatewayRateLimit) DeepCopyInto(out *VMGatewayRateLimit) {
 	*out = *in
-	out.VMProberSpec = in.VMProberSpec
-	in.Targets.DeepCopyInto(&out.Targets)
-	if in.MetricRelabelConfigs != nil {
-		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
-		*out = make([]*RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
+	if in.AccountID != nil {
+		in, out := &in.AccountID, &out.AccountID
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProjectID != nil {
+		in, out := &in.ProjectID, &out.ProjectID
+		*out = new(int32)
+		**out = **in
 	}
-	in.EndpointAuth.DeepCopyInto(&out.EndpointAuth)
-	in.EndpointScrapeParams.DeepCopyInto(&out.EndpointScrapeParams)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProbeSpec.
-func (in *VMProbeSpec) DeepCopy() *VMProbeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMGatewayRateLimit.
+func (in *VMGatewayRateLimit) DeepCopy() *VMGatewayRateLimit {
 	if in == nil {
 		return nil
 	}
-	out := new(VMProbeSpec)
+	out := new(VMGatewayRateLimit)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMProbeTargetStaticConfig) DeepCopyInto(out *VMProbeTargetStaticConfig) {
+func (in *VMGatewaySpec) DeepCopyInto(out *VMGatewaySpec) {
 	*out = *in
-	if in.Targets != nil {
-		in, out := &in.Targets, &out.Targets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(EmbeddedObjectMetadata)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.ManagedMetadata != nil {
+		in, out := &in.ManagedMetadata, &out.ManagedMetadata
+		*out = new(ManagedObjectsMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
+	out.ClusterRef = in.ClusterRef
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(VMGatewayAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = make([]VMGatewayRateLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.RelabelConfigs != nil {
-		in, out := &in.RelabelConfigs, &out.RelabelConfigs
-		*out = make([]*RelabelConfig, len(*in))
+	if in.License != nil {
+		in, out := &in.License, &out.License
+		*out = new(License)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceSpec != nil {
+		in, out := &in.ServiceSpec, &out.ServiceSpec
+		*out = new(AdditionalServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalServices != nil {
+		in, out := &in.AdditionalServices, &out.AdditionalServices
+		*out = make([]AdditionalServiceSpec, len(*in))
 		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProbeTargetStaticConfig.
-func (in *VMProbeTargetStaticConfig) DeepCopy() *VMProbeTargetStaticConfig {
-	if in == nil {
-		return nil
+	if in.ServiceScrapeSpec != nil {
+		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
+		*out = new(VMServiceScrapeSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(VMProbeTargetStaticConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMProbeTargets) DeepCopyInto(out *VMProbeTargets) {
-	*out = *in
-	if in.StaticConfig != nil {
-		in, out := &in.StaticConfig, &out.StaticConfig
-		*out = new(VMProbeTargetStaticConfig)
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(EmbeddedPodDisruptionBudgetSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Ingress != nil {
-		in, out := &in.Ingress, &out.Ingress
-		*out = new(ProbeTargetIngress)
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProbeTargets.
-func (in *VMProbeTargets) DeepCopy() *VMProbeTargets {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMGatewaySpec.
+func (in *VMGatewaySpec) DeepCopy() *VMGatewaySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMProbeTargets)
+	out := new(VMGatewaySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMProberSpec) DeepCopyInto(out *VMProberSpec) {
+func (in *VMGatewayStatus) DeepCopyInto(out *VMGatewayStatus) {
 	*out = *in
+	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProberSpec.
-func (in *VMProberSpec) DeepCopy() *VMProberSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMGatewayStatus.
+func (in *VMGatewayStatus) DeepCopy() *VMGatewayStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMProberSpec)
+	out := new(VMGatewayStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMRestore) DeepCopyInto(out *VMRestore) {
+func (in *VMInsert) DeepCopyInto(out *VMInsert) {
 	*out = *in
-	if in.OnStart != nil {
-		in, out := &in.OnStart, &out.OnStart
-		*out = new(VMRestoreOnStartConfig)
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(EmbeddedObjectMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InsertPorts != nil {
+		in, out := &in.InsertPorts, &out.InsertPorts
+		*out = new(InsertPorts)
 		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRestore.
-func (in *VMRestore) DeepCopy() *VMRestore {
-	if in == nil {
-		return nil
+	if in.OTLP != nil {
+		in, out := &in.OTLP, &out.OTLP
+		*out = new(OTLPIngestion)
+		**out = **in
 	}
-	out := new(VMRestore)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMRestoreOnStartConfig) DeepCopyInto(out *VMRestoreOnStartConfig) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRestoreOnStartConfig.
-func (in *VMRestoreOnStartConfig) DeepCopy() *VMRestoreOnStartConfig {
-	if in == nil {
-		return nil
+	if in.Datadog != nil {
+		in, out := &in.Datadog, &out.Datadog
+		*out = new(DatadogIngestion)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(VMRestoreOnStartConfig)
-	in.DeepCopyInto(out)
-	return out
-}
+	if in.NewRelic != nil {
+		in, out := &in.NewRelic, &out.NewRelic
+		*out = new(NewRelicIngestion)
+		**out = **in
+	}
+	if in.ServiceSpec != nil {
+		in, out := &in.ServiceSpec, &out.ServiceSpec
+		*out = new(AdditionalServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalServices != nil {
+		in, out := &in.AdditionalServices, &out.AdditionalServices
+		*out = make([]AdditionalServiceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceScrapeSpec != nil {
+		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
+		*out = new(VMServiceScrapeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(appsv1.DeploymentStrategyType)
+		**out = **in
+	}
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(appsv1.RollingUpdateDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(EmbeddedPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(EmbeddedHPA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(EmbeddedVPA)
+		(*in).DeepCopyInto(*out)
+	}
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMInsert.
+func (in *VMInsert) DeepCopy() *VMInsert {
+	if in == nil {
+		return nil
+	}
+	out := new(VMInsert)
+	in.DeepCopyInto(out)
+	return out
+}
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMRule) DeepCopyInto(out *VMRule) {
+func (in *VMNodeScrape) DeepCopyInto(out *VMNodeScrape) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -5502,18 +6717,18 @@ func (in *VMRule) DeepCopyInto(out *VMRule) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRule.
-func (in *VMRule) DeepCopy() *VMRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMNodeScrape.
+func (in *VMNodeScrape) DeepCopy() *VMNodeScrape {
 	if in == nil {
 		return nil
 	}
-	out := new(VMRule)
+	out := new(VMNodeScrape)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMRule) DeepCopyObject() runtime.Object {
+func (in *VMNodeScrape) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5521,35 +6736,31 @@ func (in *VMRule) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMRuleList) DeepCopyInto(out *VMRuleList) {
+func (in *VMNodeScrapeList) DeepCopyInto(out *VMNodeScrapeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]*VMRule, len(*in))
+		*out = make([]VMNodeScrape, len(*in))
 		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(VMRule)
-				(*in).DeepCopyInto(*out)
-			}
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleList.
-func (in *VMRuleList) DeepCopy() *VMRuleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMNodeScrapeList.
+func (in *VMNodeScrapeList) DeepCopy() *VMNodeScrapeList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMRuleList)
+	out := new(VMNodeScrapeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMRuleList) DeepCopyObject() runtime.Object {
+func (in *VMNodeScrapeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5557,45 +6768,120 @@ func (in *VMRuleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMRuleSpec) DeepCopyInto(out *VMRuleSpec) {
+func (in *VMNodeScrapeSpec) DeepCopyInto(out *VMNodeScrapeSpec) {
 	*out = *in
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
-		*out = make([]RuleGroup, len(*in))
+	if in.TargetLabels != nil {
+		in, out := &in.TargetLabels, &out.TargetLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.EndpointRelabelings.DeepCopyInto(&out.EndpointRelabelings)
+	in.EndpointAuth.DeepCopyInto(&out.EndpointAuth)
+	in.EndpointScrapeParams.DeepCopyInto(&out.EndpointScrapeParams)
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMNodeScrapeSpec.
+func (in *VMNodeScrapeSpec) DeepCopy() *VMNodeScrapeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMNodeScrapeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMPodScrape) DeepCopyInto(out *VMPodScrape) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMPodScrape.
+func (in *VMPodScrape) DeepCopy() *VMPodScrape {
+	if in == nil {
+		return nil
+	}
+	out := new(VMPodScrape)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMPodScrape) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMPodScrapeList) DeepCopyInto(out *VMPodScrapeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMPodScrape, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleSpec.
-func (in *VMRuleSpec) DeepCopy() *VMRuleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMPodScrapeList.
+func (in *VMPodScrapeList) DeepCopy() *VMPodScrapeList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMRuleSpec)
+	out := new(VMPodScrapeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMPodScrapeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMRuleStatus) DeepCopyInto(out *VMRuleStatus) {
+func (in *VMPodScrapeSpec) DeepCopyInto(out *VMPodScrapeSpec) {
 	*out = *in
-	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
+	if in.PodTargetLabels != nil {
+		in, out := &in.PodTargetLabels, &out.PodTargetLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodMetricsEndpoints != nil {
+		in, out := &in.PodMetricsEndpoints, &out.PodMetricsEndpoints
+		*out = make([]PodMetricsEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Selector.DeepCopyInto(&out.Selector)
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.AttachMetadata.DeepCopyInto(&out.AttachMetadata)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleStatus.
-func (in *VMRuleStatus) DeepCopy() *VMRuleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMPodScrapeSpec.
+func (in *VMPodScrapeSpec) DeepCopy() *VMPodScrapeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMRuleStatus)
+	out := new(VMPodScrapeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMScrapeConfig) DeepCopyInto(out *VMScrapeConfig) {
+func (in *VMProbe) DeepCopyInto(out *VMProbe) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -5603,18 +6889,18 @@ func (in *VMScrapeConfig) DeepCopyInto(out *VMScrapeConfig) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMScrapeConfig.
-func (in *VMScrapeConfig) DeepCopy() *VMScrapeConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProbe.
+func (in *VMProbe) DeepCopy() *VMProbe {
 	if in == nil {
 		return nil
 	}
-	out := new(VMScrapeConfig)
+	out := new(VMProbe)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMScrapeConfig) DeepCopyObject() runtime.Object {
+func (in *VMProbe) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5622,31 +6908,31 @@ func (in *VMScrapeConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMScrapeConfigList) DeepCopyInto(out *VMScrapeConfigList) {
+func (in *VMProbeList) DeepCopyInto(out *VMProbeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VMScrapeConfig, len(*in))
+		*out = make([]VMProbe, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMScrapeConfigList.
-func (in *VMScrapeConfigList) DeepCopy() *VMScrapeConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProbeList.
+func (in *VMProbeList) DeepCopy() *VMProbeList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMScrapeConfigList)
+	out := new(VMProbeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMScrapeConfigList) DeepCopyObject() runtime.Object {
+func (in *VMProbeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5654,32 +6940,397 @@ func (in *VMScrapeConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMScrapeConfigSpec) DeepCopyInto(out *VMScrapeConfigSpec) {
+func (in *VMProbeSpec) DeepCopyInto(out *VMProbeSpec) {
 	*out = *in
-	if in.StaticConfigs != nil {
-		in, out := &in.StaticConfigs, &out.StaticConfigs
-		*out = make([]StaticConfig, len(*in))
+	out.VMProberSpec = in.VMProberSpec
+	in.Targets.DeepCopyInto(&out.Targets)
+	if in.MetricRelabelConfigs != nil {
+		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
+		*out = make([]*RelabelConfig, len(*in))
 		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
 		}
 	}
-	if in.FileSDConfigs != nil {
-		in, out := &in.FileSDConfigs, &out.FileSDConfigs
-		*out = make([]FileSDConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.EndpointAuth.DeepCopyInto(&out.EndpointAuth)
+	in.EndpointScrapeParams.DeepCopyInto(&out.EndpointScrapeParams)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProbeSpec.
+func (in *VMProbeSpec) DeepCopy() *VMProbeSpec {
+	if in == nil {
+		return nil
 	}
-	if in.HTTPSDConfigs != nil {
-		in, out := &in.HTTPSDConfigs, &out.HTTPSDConfigs
-		*out = make([]HTTPSDConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(VMProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMProbeTargetStaticConfig) DeepCopyInto(out *VMProbeTargetStaticConfig) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.KubernetesSDConfigs != nil {
-		in, out := &in.KubernetesSDConfigs, &out.KubernetesSDConfigs
-		*out = make([]KubernetesSDConfig, len(*in))
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RelabelConfigs != nil {
+		in, out := &in.RelabelConfigs, &out.RelabelConfigs
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProbeTargetStaticConfig.
+func (in *VMProbeTargetStaticConfig) DeepCopy() *VMProbeTargetStaticConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VMProbeTargetStaticConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMProbeTargets) DeepCopyInto(out *VMProbeTargets) {
+	*out = *in
+	if in.StaticConfig != nil {
+		in, out := &in.StaticConfig, &out.StaticConfig
+		*out = new(VMProbeTargetStaticConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(ProbeTargetIngress)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProbeTargets.
+func (in *VMProbeTargets) DeepCopy() *VMProbeTargets {
+	if in == nil {
+		return nil
+	}
+	out := new(VMProbeTargets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMProberSpec) DeepCopyInto(out *VMProberSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProberSpec.
+func (in *VMProberSpec) DeepCopy() *VMProberSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMProberSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRestore) DeepCopyInto(out *VMRestore) {
+	*out = *in
+	if in.OnStart != nil {
+		in, out := &in.OnStart, &out.OnStart
+		*out = new(VMRestoreOnStartConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRestore.
+func (in *VMRestore) DeepCopy() *VMRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRestoreFrom) DeepCopyInto(out *VMRestoreFrom) {
+	*out = *in
+	out.Image = in.Image
+	if in.CustomS3Endpoint != nil {
+		in, out := &in.CustomS3Endpoint, &out.CustomS3Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExtraEnvs != nil {
+		in, out := &in.ExtraEnvs, &out.ExtraEnvs
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRestoreFrom.
+func (in *VMRestoreFrom) DeepCopy() *VMRestoreFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRestoreFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRestoreFromStatus) DeepCopyInto(out *VMRestoreFromStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRestoreFromStatus.
+func (in *VMRestoreFromStatus) DeepCopy() *VMRestoreFromStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRestoreFromStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRestoreOnStartConfig) DeepCopyInto(out *VMRestoreOnStartConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRestoreOnStartConfig.
+func (in *VMRestoreOnStartConfig) DeepCopy() *VMRestoreOnStartConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRestoreOnStartConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRule) DeepCopyInto(out *VMRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRule.
+func (in *VMRule) DeepCopy() *VMRule {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRuleList) DeepCopyInto(out *VMRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]*VMRule, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(VMRule)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleList.
+func (in *VMRuleList) DeepCopy() *VMRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRuleSpec) DeepCopyInto(out *VMRuleSpec) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]RuleGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleSpec.
+func (in *VMRuleSpec) DeepCopy() *VMRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRuleStatus) DeepCopyInto(out *VMRuleStatus) {
+	*out = *in
+	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleStatus.
+func (in *VMRuleStatus) DeepCopy() *VMRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMScrapeConfig) DeepCopyInto(out *VMScrapeConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMScrapeConfig.
+func (in *VMScrapeConfig) DeepCopy() *VMScrapeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VMScrapeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMScrapeConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMScrapeConfigList) DeepCopyInto(out *VMScrapeConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMScrapeConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMScrapeConfigList.
+func (in *VMScrapeConfigList) DeepCopy() *VMScrapeConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMScrapeConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMScrapeConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMScrapeConfigSpec) DeepCopyInto(out *VMScrapeConfigSpec) {
+	*out = *in
+	if in.StaticConfigs != nil {
+		in, out := &in.StaticConfigs, &out.StaticConfigs
+		*out = make([]StaticConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FileSDConfigs != nil {
+		in, out := &in.FileSDConfigs, &out.FileSDConfigs
+		*out = make([]FileSDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HTTPSDConfigs != nil {
+		in, out := &in.HTTPSDConfigs, &out.HTTPSDConfigs
+		*out = make([]HTTPSDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KubernetesSDConfigs != nil {
+		in, out := &in.KubernetesSDConfigs, &out.KubernetesSDConfigs
+		*out = make([]KubernetesSDConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -5698,132 +7349,459 @@ func (in *VMScrapeConfigSpec) DeepCopyInto(out *VMScrapeConfigSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.EC2SDConfigs != nil {
-		in, out := &in.EC2SDConfigs, &out.EC2SDConfigs
-		*out = make([]EC2SDConfig, len(*in))
+	if in.EC2SDConfigs != nil {
+		in, out := &in.EC2SDConfigs, &out.EC2SDConfigs
+		*out = make([]EC2SDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AzureSDConfigs != nil {
+		in, out := &in.AzureSDConfigs, &out.AzureSDConfigs
+		*out = make([]AzureSDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GCESDConfigs != nil {
+		in, out := &in.GCESDConfigs, &out.GCESDConfigs
+		*out = make([]GCESDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OpenStackSDConfigs != nil {
+		in, out := &in.OpenStackSDConfigs, &out.OpenStackSDConfigs
+		*out = make([]OpenStackSDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DigitalOceanSDConfigs != nil {
+		in, out := &in.DigitalOceanSDConfigs, &out.DigitalOceanSDConfigs
+		*out = make([]DigitalOceanSDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.EndpointScrapeParams.DeepCopyInto(&out.EndpointScrapeParams)
+	in.EndpointRelabelings.DeepCopyInto(&out.EndpointRelabelings)
+	in.EndpointAuth.DeepCopyInto(&out.EndpointAuth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMScrapeConfigSpec.
+func (in *VMScrapeConfigSpec) DeepCopy() *VMScrapeConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMScrapeConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMScrapeParams) DeepCopyInto(out *VMScrapeParams) {
+	*out = *in
+	if in.DisableCompression != nil {
+		in, out := &in.DisableCompression, &out.DisableCompression
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableKeepAlive != nil {
+		in, out := &in.DisableKeepAlive, &out.DisableKeepAlive
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableStaleMarkers != nil {
+		in, out := &in.DisableStaleMarkers, &out.DisableStaleMarkers
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StreamParse != nil {
+		in, out := &in.StreamParse, &out.StreamParse
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ScrapeAlignInterval != nil {
+		in, out := &in.ScrapeAlignInterval, &out.ScrapeAlignInterval
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScrapeOffset != nil {
+		in, out := &in.ScrapeOffset, &out.ScrapeOffset
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProxyClientConfig != nil {
+		in, out := &in.ProxyClientConfig, &out.ProxyClientConfig
+		*out = new(ProxyAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMScrapeParams.
+func (in *VMScrapeParams) DeepCopy() *VMScrapeParams {
+	if in == nil {
+		return nil
+	}
+	out := new(VMScrapeParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSelect) DeepCopyInto(out *VMSelect) {
+	*out = *in
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(EmbeddedObjectMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageSpec != nil {
+		in, out := &in.StorageSpec, &out.StorageSpec
+		*out = new(StorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetainPVCs != nil {
+		in, out := &in.RetainPVCs, &out.RetainPVCs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ServiceSpec != nil {
+		in, out := &in.ServiceSpec, &out.ServiceSpec
+		*out = new(AdditionalServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalServices != nil {
+		in, out := &in.AdditionalServices, &out.AdditionalServices
+		*out = make([]AdditionalServiceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceScrapeSpec != nil {
+		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
+		*out = new(VMServiceScrapeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QueryLogging != nil {
+		in, out := &in.QueryLogging, &out.QueryLogging
+		*out = new(QueryLogging)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(EmbeddedPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(EmbeddedHPA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(EmbeddedVPA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(EmbeddedIngress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(EmbeddedRoute)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClaimTemplates != nil {
+		in, out := &in.ClaimTemplates, &out.ClaimTemplates
+		*out = make([]v1.PersistentVolumeClaim, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.AzureSDConfigs != nil {
-		in, out := &in.AzureSDConfigs, &out.AzureSDConfigs
-		*out = make([]AzureSDConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.PersistentVolumeClaimRetentionPolicy != nil {
+		in, out := &in.PersistentVolumeClaimRetentionPolicy, &out.PersistentVolumeClaimRetentionPolicy
+		*out = new(appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy)
+		**out = **in
 	}
-	if in.GCESDConfigs != nil {
-		in, out := &in.GCESDConfigs, &out.GCESDConfigs
-		*out = make([]GCESDConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSelect.
+func (in *VMSelect) DeepCopy() *VMSelect {
+	if in == nil {
+		return nil
 	}
-	if in.OpenStackSDConfigs != nil {
-		in, out := &in.OpenStackSDConfigs, &out.OpenStackSDConfigs
-		*out = make([]OpenStackSDConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(VMSelect)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMServiceScrape) DeepCopyInto(out *VMServiceScrape) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMServiceScrape.
+func (in *VMServiceScrape) DeepCopy() *VMServiceScrape {
+	if in == nil {
+		return nil
 	}
-	if in.DigitalOceanSDConfigs != nil {
-		in, out := &in.DigitalOceanSDConfigs, &out.DigitalOceanSDConfigs
-		*out = make([]DigitalOceanSDConfig, len(*in))
+	out := new(VMServiceScrape)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMServiceScrape) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMServiceScrapeList) DeepCopyInto(out *VMServiceScrapeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMServiceScrape, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.EndpointScrapeParams.DeepCopyInto(&out.EndpointScrapeParams)
-	in.EndpointRelabelings.DeepCopyInto(&out.EndpointRelabelings)
-	in.EndpointAuth.DeepCopyInto(&out.EndpointAuth)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMScrapeConfigSpec.
-func (in *VMScrapeConfigSpec) DeepCopy() *VMScrapeConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMServiceScrapeList.
+func (in *VMServiceScrapeList) DeepCopy() *VMServiceScrapeList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMScrapeConfigSpec)
+	out := new(VMServiceScrapeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMServiceScrapeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMScrapeParams) DeepCopyInto(out *VMScrapeParams) {
+func (in *VMServiceScrapeSpec) DeepCopyInto(out *VMServiceScrapeSpec) {
 	*out = *in
-	if in.DisableCompression != nil {
-		in, out := &in.DisableCompression, &out.DisableCompression
-		*out = new(bool)
-		**out = **in
+	if in.TargetLabels != nil {
+		in, out := &in.TargetLabels, &out.TargetLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.DisableKeepAlive != nil {
-		in, out := &in.DisableKeepAlive, &out.DisableKeepAlive
-		*out = new(bool)
-		**out = **in
+	if in.PodTargetLabels != nil {
+		in, out := &in.PodTargetLabels, &out.PodTargetLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.DisableStaleMarkers != nil {
-		in, out := &in.DisableStaleMarkers, &out.DisableStaleMarkers
-		*out = new(bool)
-		**out = **in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]Endpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.StreamParse != nil {
-		in, out := &in.StreamParse, &out.StreamParse
-		*out = new(bool)
-		**out = **in
+	in.Selector.DeepCopyInto(&out.Selector)
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.AttachMetadata.DeepCopyInto(&out.AttachMetadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMServiceScrapeSpec.
+func (in *VMServiceScrapeSpec) DeepCopy() *VMServiceScrapeSpec {
+	if in == nil {
+		return nil
 	}
-	if in.ScrapeAlignInterval != nil {
-		in, out := &in.ScrapeAlignInterval, &out.ScrapeAlignInterval
-		*out = new(string)
-		**out = **in
+	out := new(VMServiceScrapeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSingle) DeepCopyInto(out *VMSingle) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ParsedLastAppliedSpec != nil {
+		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
+		*out = new(VMSingleSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ScrapeOffset != nil {
-		in, out := &in.ScrapeOffset, &out.ScrapeOffset
-		*out = new(string)
-		**out = **in
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSingle.
+func (in *VMSingle) DeepCopy() *VMSingle {
+	if in == nil {
+		return nil
 	}
-	if in.ProxyClientConfig != nil {
-		in, out := &in.ProxyClientConfig, &out.ProxyClientConfig
-		*out = new(ProxyAuth)
-		(*in).DeepCopyInto(*out)
+	out := new(VMSingle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMSingle) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.Headers != nil {
-		in, out := &in.Headers, &out.Headers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSingleList) DeepCopyInto(out *VMSingleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMSingle, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMScrapeParams.
-func (in *VMScrapeParams) DeepCopy() *VMScrapeParams {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSingleList.
+func (in *VMSingleList) DeepCopy() *VMSingleList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMScrapeParams)
+	out := new(VMSingleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMSingleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMSelect) DeepCopyInto(out *VMSelect) {
+func (in *VMSingleSpec) DeepCopyInto(out *VMSingleSpec) {
 	*out = *in
 	if in.PodMetadata != nil {
 		in, out := &in.PodMetadata, &out.PodMetadata
 		*out = new(EmbeddedObjectMetadata)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ManagedMetadata != nil {
+		in, out := &in.ManagedMetadata, &out.ManagedMetadata
+		*out = new(ManagedObjectsMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Storage != nil {
 		in, out := &in.Storage, &out.Storage
-		*out = new(StorageSpec)
+		*out = new(v1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.StorageMetadata.DeepCopyInto(&out.StorageMetadata)
+	if in.InsertPorts != nil {
+		in, out := &in.InsertPorts, &out.InsertPorts
+		*out = new(InsertPorts)
+		**out = **in
+	}
+	if in.OTLP != nil {
+		in, out := &in.OTLP, &out.OTLP
+		*out = new(OTLPIngestion)
+		**out = **in
+	}
+	if in.Datadog != nil {
+		in, out := &in.Datadog, &out.Datadog
+		*out = new(DatadogIngestion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NewRelic != nil {
+		in, out := &in.NewRelic, &out.NewRelic
+		*out = new(NewRelicIngestion)
+		**out = **in
+	}
+	if in.QueryLogging != nil {
+		in, out := &in.QueryLogging, &out.QueryLogging
+		*out = new(QueryLogging)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMBackup != nil {
+		in, out := &in.VMBackup, &out.VMBackup
+		*out = new(VMBackup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(VMRestoreFrom)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.License != nil {
+		in, out := &in.License, &out.License
+		*out = new(License)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceSpec != nil {
+		in, out := &in.ServiceSpec, &out.ServiceSpec
+		*out = new(AdditionalServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalServices != nil {
+		in, out := &in.AdditionalServices, &out.AdditionalServices
+		*out = make([]AdditionalServiceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(EmbeddedNetworkPolicy)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.StorageSpec != nil {
-		in, out := &in.StorageSpec, &out.StorageSpec
-		*out = new(StorageSpec)
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(EmbeddedVPA)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ServiceSpec != nil {
-		in, out := &in.ServiceSpec, &out.ServiceSpec
-		*out = new(AdditionalServiceSpec)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(EmbeddedIngress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(EmbeddedRoute)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ServiceScrapeSpec != nil {
@@ -5831,63 +7809,87 @@ func (in *VMSelect) DeepCopyInto(out *VMSelect) {
 		*out = new(VMServiceScrapeSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.PodDisruptionBudget != nil {
-		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
-		*out = new(EmbeddedPodDisruptionBudgetSpec)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.EmbeddedProbes != nil {
 		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
 		*out = new(EmbeddedProbes)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.HPA != nil {
-		in, out := &in.HPA, &out.HPA
-		*out = new(EmbeddedHPA)
+	if in.StreamAggrConfig != nil {
+		in, out := &in.StreamAggrConfig, &out.StreamAggrConfig
+		*out = new(StreamAggrConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ClaimTemplates != nil {
-		in, out := &in.ClaimTemplates, &out.ClaimTemplates
-		*out = make([]v1.PersistentVolumeClaim, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.CloudIdentity != nil {
+		in, out := &in.CloudIdentity, &out.CloudIdentity
+		*out = new(CloudIdentity)
+		**out = **in
 	}
 	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
 	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSelect.
-func (in *VMSelect) DeepCopy() *VMSelect {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSingleSpec.
+func (in *VMSingleSpec) DeepCopy() *VMSingleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMSelect)
+	out := new(VMSingleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMServiceScrape) DeepCopyInto(out *VMServiceScrape) {
+func (in *VMSingleStatus) DeepCopyInto(out *VMSingleStatus) {
+	*out = *in
+	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(VMRestoreFromStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackupVerification != nil {
+		in, out := &in.BackupVerification, &out.BackupVerification
+		*out = new(VMBackupVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSingleStatus.
+func (in *VMSingleStatus) DeepCopy() *VMSingleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMSingleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSnapshot) DeepCopyInto(out *VMSnapshot) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ParsedLastAppliedSpec != nil {
+		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
+		*out = new(VMSnapshotSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMServiceScrape.
-func (in *VMServiceScrape) DeepCopy() *VMServiceScrape {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSnapshot.
+func (in *VMSnapshot) DeepCopy() *VMSnapshot {
 	if in == nil {
 		return nil
 	}
-	out := new(VMServiceScrape)
+	out := new(VMSnapshot)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMServiceScrape) DeepCopyObject() runtime.Object {
+func (in *VMSnapshot) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5895,31 +7897,31 @@ func (in *VMServiceScrape) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMServiceScrapeList) DeepCopyInto(out *VMServiceScrapeList) {
+func (in *VMSnapshotList) DeepCopyInto(out *VMSnapshotList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VMServiceScrape, len(*in))
+		*out = make([]VMSnapshot, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMServiceScrapeList.
-func (in *VMServiceScrapeList) DeepCopy() *VMServiceScrapeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSnapshotList.
+func (in *VMSnapshotList) DeepCopy() *VMSnapshotList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMServiceScrapeList)
+	out := new(VMSnapshotList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMServiceScrapeList) DeepCopyObject() runtime.Object {
+func (in *VMSnapshotList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5927,66 +7929,130 @@ func (in *VMServiceScrapeList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMServiceScrapeSpec) DeepCopyInto(out *VMServiceScrapeSpec) {
+func (in *VMSnapshotNode) DeepCopyInto(out *VMSnapshotNode) {
 	*out = *in
-	if in.TargetLabels != nil {
-		in, out := &in.TargetLabels, &out.TargetLabels
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSnapshotNode.
+func (in *VMSnapshotNode) DeepCopy() *VMSnapshotNode {
+	if in == nil {
+		return nil
 	}
-	if in.PodTargetLabels != nil {
-		in, out := &in.PodTargetLabels, &out.PodTargetLabels
-		*out = make([]string, len(*in))
+	out := new(VMSnapshotNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSnapshotRecord) DeepCopyInto(out *VMSnapshotRecord) {
+	*out = *in
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]VMSnapshotNode, len(*in))
 		copy(*out, *in)
 	}
-	if in.Endpoints != nil {
-		in, out := &in.Endpoints, &out.Endpoints
-		*out = make([]Endpoint, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSnapshotRecord.
+func (in *VMSnapshotRecord) DeepCopy() *VMSnapshotRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(VMSnapshotRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSnapshotSourceRef) DeepCopyInto(out *VMSnapshotSourceRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSnapshotSourceRef.
+func (in *VMSnapshotSourceRef) DeepCopy() *VMSnapshotSourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(VMSnapshotSourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSnapshotSpec) DeepCopyInto(out *VMSnapshotSpec) {
+	*out = *in
+	out.SourceRef = in.SourceRef
+	if in.KeepLastSnapshots != nil {
+		in, out := &in.KeepLastSnapshots, &out.KeepLastSnapshots
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSnapshotSpec.
+func (in *VMSnapshotSpec) DeepCopy() *VMSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSnapshotStatus) DeepCopyInto(out *VMSnapshotStatus) {
+	*out = *in
+	if in.LastSnapshotTime != nil {
+		in, out := &in.LastSnapshotTime, &out.LastSnapshotTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]VMSnapshotRecord, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.Selector.DeepCopyInto(&out.Selector)
-	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
-	in.AttachMetadata.DeepCopyInto(&out.AttachMetadata)
+	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMServiceScrapeSpec.
-func (in *VMServiceScrapeSpec) DeepCopy() *VMServiceScrapeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSnapshotStatus.
+func (in *VMSnapshotStatus) DeepCopy() *VMSnapshotStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMServiceScrapeSpec)
+	out := new(VMSnapshotStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMSingle) DeepCopyInto(out *VMSingle) {
+func (in *VMStack) DeepCopyInto(out *VMStack) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	if in.ParsedLastAppliedSpec != nil {
 		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
-		*out = new(VMSingleSpec)
+		*out = new(VMStackSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSingle.
-func (in *VMSingle) DeepCopy() *VMSingle {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMStack.
+func (in *VMStack) DeepCopy() *VMStack {
 	if in == nil {
 		return nil
 	}
-	out := new(VMSingle)
+	out := new(VMStack)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMSingle) DeepCopyObject() runtime.Object {
+func (in *VMStack) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5994,31 +8060,31 @@ func (in *VMSingle) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMSingleList) DeepCopyInto(out *VMSingleList) {
+func (in *VMStackList) DeepCopyInto(out *VMStackList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VMSingle, len(*in))
+		*out = make([]VMStack, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSingleList.
-func (in *VMSingleList) DeepCopy() *VMSingleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMStackList.
+func (in *VMStackList) DeepCopy() *VMStackList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMSingleList)
+	out := new(VMStackList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMSingleList) DeepCopyObject() runtime.Object {
+func (in *VMStackList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6026,85 +8092,57 @@ func (in *VMSingleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMSingleSpec) DeepCopyInto(out *VMSingleSpec) {
+func (in *VMStackSpec) DeepCopyInto(out *VMStackSpec) {
 	*out = *in
-	if in.PodMetadata != nil {
-		in, out := &in.PodMetadata, &out.PodMetadata
-		*out = new(EmbeddedObjectMetadata)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ManagedMetadata != nil {
-		in, out := &in.ManagedMetadata, &out.ManagedMetadata
-		*out = new(ManagedObjectsMetadata)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Storage != nil {
-		in, out := &in.Storage, &out.Storage
-		*out = new(v1.PersistentVolumeClaimSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	in.StorageMetadata.DeepCopyInto(&out.StorageMetadata)
-	if in.InsertPorts != nil {
-		in, out := &in.InsertPorts, &out.InsertPorts
-		*out = new(InsertPorts)
-		**out = **in
-	}
-	if in.VMBackup != nil {
-		in, out := &in.VMBackup, &out.VMBackup
-		*out = new(VMBackup)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.License != nil {
-		in, out := &in.License, &out.License
-		*out = new(License)
+	if in.VMSingle != nil {
+		in, out := &in.VMSingle, &out.VMSingle
+		*out = new(VMSingleSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ServiceSpec != nil {
-		in, out := &in.ServiceSpec, &out.ServiceSpec
-		*out = new(AdditionalServiceSpec)
+	if in.VMCluster != nil {
+		in, out := &in.VMCluster, &out.VMCluster
+		*out = new(VMClusterSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ServiceScrapeSpec != nil {
-		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
-		*out = new(VMServiceScrapeSpec)
+	if in.VMAgent != nil {
+		in, out := &in.VMAgent, &out.VMAgent
+		*out = new(VMAgentSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.EmbeddedProbes != nil {
-		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
-		*out = new(EmbeddedProbes)
+	if in.VMAlert != nil {
+		in, out := &in.VMAlert, &out.VMAlert
+		*out = new(VMAlertSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.StreamAggrConfig != nil {
-		in, out := &in.StreamAggrConfig, &out.StreamAggrConfig
-		*out = new(StreamAggrConfig)
+	if in.VMAlertmanager != nil {
+		in, out := &in.VMAlertmanager, &out.VMAlertmanager
+		*out = new(VMAlertmanagerSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
-	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSingleSpec.
-func (in *VMSingleSpec) DeepCopy() *VMSingleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMStackSpec.
+func (in *VMStackSpec) DeepCopy() *VMStackSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMSingleSpec)
+	out := new(VMStackSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMSingleStatus) DeepCopyInto(out *VMSingleStatus) {
+func (in *VMStackStatus) DeepCopyInto(out *VMStackStatus) {
 	*out = *in
 	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSingleStatus.
-func (in *VMSingleStatus) DeepCopy() *VMSingleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMStackStatus.
+func (in *VMStackStatus) DeepCopy() *VMStackStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMSingleStatus)
+	out := new(VMStackStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -6207,21 +8245,43 @@ func (in *VMStorage) DeepCopyInto(out *VMStorage) {
 		*out = new(StorageSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RetainPVCs != nil {
+		in, out := &in.RetainPVCs, &out.RetainPVCs
+		*out = new(bool)
+		**out = **in
+	}
 	if in.VMBackup != nil {
 		in, out := &in.VMBackup, &out.VMBackup
 		*out = new(VMBackup)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(VMRestoreFrom)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ServiceSpec != nil {
 		in, out := &in.ServiceSpec, &out.ServiceSpec
 		*out = new(AdditionalServiceSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalServices != nil {
+		in, out := &in.AdditionalServices, &out.AdditionalServices
+		*out = make([]AdditionalServiceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ServiceScrapeSpec != nil {
 		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
 		*out = new(VMServiceScrapeSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(EmbeddedVPA)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodDisruptionBudget != nil {
 		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
 		*out = new(EmbeddedPodDisruptionBudgetSpec)
@@ -6249,6 +8309,11 @@ func (in *VMStorage) DeepCopyInto(out *VMStorage) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PersistentVolumeClaimRetentionPolicy != nil {
+		in, out := &in.PersistentVolumeClaimRetentionPolicy, &out.PersistentVolumeClaimRetentionPolicy
+		*out = new(appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy)
+		**out = **in
+	}
 	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
 	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
 }
@@ -6381,6 +8446,38 @@ func (in *VMUserIPFilters) DeepCopy() *VMUserIPFilters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMUserJWT) DeepCopyInto(out *VMUserJWT) {
+	*out = *in
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PublicKeySecrets != nil {
+		in, out := &in.PublicKeySecrets, &out.PublicKeySecrets
+		*out = make([]v1.SecretKeySelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUserJWT.
+func (in *VMUserJWT) DeepCopy() *VMUserJWT {
+	if in == nil {
+		return nil
+	}
+	out := new(VMUserJWT)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMUserList) DeepCopyInto(out *VMUserList) {
 	*out = *in
@@ -6413,6 +8510,21 @@ func (in *VMUserList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMUserPasswordRotation) DeepCopyInto(out *VMUserPasswordRotation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUserPasswordRotation.
+func (in *VMUserPasswordRotation) DeepCopy() *VMUserPasswordRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(VMUserPasswordRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMUserSpec) DeepCopyInto(out *VMUserSpec) {
 	*out = *in
@@ -6441,11 +8553,21 @@ func (in *VMUserSpec) DeepCopyInto(out *VMUserSpec) {
 		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(VMUserPasswordRotation)
+		**out = **in
+	}
 	if in.BearerToken != nil {
 		in, out := &in.BearerToken, &out.BearerToken
 		*out = new(string)
 		**out = **in
 	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(VMUserJWT)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TargetRefs != nil {
 		in, out := &in.TargetRefs, &out.TargetRefs
 		*out = make([]TargetRef, len(*in))
@@ -23,6 +23,7 @@ import (
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/logger"
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/procutil"
 	"github.com/VictoriaMetrics/metrics"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pires/go-proxyproto"
 )
 
@@ -312,7 +313,10 @@ func newConfigWatcher(ctx context.Context) (watcher, error) {
 	return w, nil
 }
 
-var firstGzipBytes = []byte{0x1f, 0x8b, 0x08}
+var (
+	firstGzipBytes = []byte{0x1f, 0x8b, 0x08}
+	firstZstdBytes = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
 
 func writeNewContent(data []byte) error {
 	// fast path.
@@ -330,6 +334,17 @@ func writeNewContent(data []byte) error {
 		if err != nil {
 			return fmt.Errorf("cannot ungzip data: %w", err)
 		}
+	} else if len(data) > 4 && bytes.Equal(data[0:4], firstZstdBytes) {
+		// its zstd compressed data
+		zr, err := zstd.NewReader(nil)
+		if err != nil {
+			return fmt.Errorf("cannot create zstd reader: %w", err)
+		}
+		defer zr.Close()
+		data, err = zr.DecodeAll(data, nil)
+		if err != nil {
+			return fmt.Errorf("cannot decompress zstd data: %w", err)
+		}
 	}
 	tmpDst := *configFileDst + ".tmp"
 	if err := os.WriteFile(tmpDst, data, 0644); err != nil {
@@ -4,19 +4,24 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"text/tabwriter"
 	"time"
 
 	version "github.com/hashicorp/go-version"
 	"github.com/kelseyhightower/envconfig"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 var (
-	opConf   *BaseOperatorConf
+	opConf   atomic.Pointer[operatorConf]
 	initConf sync.Once
 
 	opNamespace   []string
@@ -28,6 +33,13 @@ const (
 	UnLimitedResource = "unlimited"
 )
 
+// Supported compressions of generated configuration
+const (
+	ConfigCompressionGzip = "gzip"
+	ConfigCompressionZstd = "zstd"
+	ConfigCompressionNone = "none"
+)
+
 // WatchNamespaceEnvVar is the constant for env variable WATCH_NAMESPACE
 // which specifies the Namespace to watch.
 // An empty value means the operator is running with cluster scope.
@@ -74,10 +86,28 @@ type BaseOperatorConf struct {
 	// it should speed-up config reloading process.
 	UseCustomConfigReloader bool `default:"false"`
 	// container registry name prefix, e.g. docker.io
-	ContainerRegistry                string `default:""`
-	CustomConfigReloaderImage        string `default:"victoriametrics/operator:config-reloader-v0.48.4"`
+	ContainerRegistry string `default:""`
+	// replaces registry host of component images with ContainerRegistry
+	// instead of adding it as prefix, e.g. docker.io/victoriametrics/vmagent -> registry.example.com/victoriametrics/vmagent
+	ContainerRegistryOverride bool `default:"false"`
+	// comma-separated list of secret names, added as imagePullSecrets to all generated workloads
+	ImagePullSecrets                 []string `default:""`
+	CustomConfigReloaderImage        string   `default:"victoriametrics/operator:config-reloader-v0.48.4"`
 	parsedConfigReloaderImageVersion *version.Version
 	PSPAutoCreateEnabled             bool `default:"false"`
+	// namespace of operator pods, it's allowed to access components with enabled networkPolicy
+	// usually it's set from pod metadata with downward API
+	OperatorNamespace string `default:""`
+	// name of kubernetes cluster, it's added as external label to generated vmagent scrape configuration and vmalert
+	// it allows to distinguish metrics and alerts of multiple clusters without per object changes
+	ClusterName string `default:""`
+	// name of external label with ClusterName value
+	ClusterLabelName string `default:"cluster"`
+	// log format applied to all components without explicitly defined logFormat, supported values: default, json
+	// vmalertmanager uses json or its own default logfmt format
+	ComponentsLogFormat string `default:""`
+	// log level applied to all components without explicitly defined logLevel, supported values: INFO, WARN, ERROR, FATAL, PANIC
+	ComponentsLogLevel string `default:""`
 
 	VLogsDefault struct {
 		Image   string `default:"victoriametrics/victoria-logs"`
@@ -101,6 +131,28 @@ type BaseOperatorConf struct {
 		// ignored
 		ConfigReloaderMemory string `ignored:"true"`
 	}
+	VLSingleDefault struct {
+		Image   string `default:"victoriametrics/victoria-logs"`
+		Version string `default:"v1.3.2-victorialogs"`
+		// ignored
+		ConfigReloadImage   string `ignored:"true"`
+		Port                string `default:"9428"`
+		UseDefaultResources bool   `default:"true"`
+		Resource            struct {
+			Limit struct {
+				Mem string `default:"1500Mi"`
+				Cpu string `default:"1200m"`
+			}
+			Request struct {
+				Mem string `default:"500Mi"`
+				Cpu string `default:"150m"`
+			}
+		}
+		// ignored
+		ConfigReloaderCPU string `ignored:"true"`
+		// ignored
+		ConfigReloaderMemory string `ignored:"true"`
+	}
 
 	VMAlertDefault struct {
 		Image               string `default:"victoriametrics/vmalert"`
@@ -128,6 +180,17 @@ type BaseOperatorConf struct {
 		EnforceEndpointslices bool `default:"false"`
 	}
 
+	VMAgentScrapeDefault struct {
+		// Default scrape interval for VMAgent, if spec.scrapeInterval is not set.
+		ScrapeInterval string `default:"30s"`
+		// Compression of generated scrape configuration stored at secret. Supported values: gzip, zstd and none.
+		// zstd requires custom config reloader, gzip is used with prometheus-config-reloader.
+		ConfigCompression string `default:"gzip"`
+		// Level of config compression, 0 means default level of compression algorithm.
+		// Supported values are from 1 to 9 for gzip and from 1 to 22 for zstd.
+		ConfigCompressionLevel int `default:"0"`
+	}
+
 	VMAgentDefault struct {
 		Image               string `default:"victoriametrics/vmagent"`
 		Version             string `default:"v1.109.0"`
@@ -243,7 +306,15 @@ type BaseOperatorConf struct {
 	}
 
 	DisableSelfServiceScrapeCreation bool `default:"false"`
-	VMBackup                         struct {
+	// EnableSelfMonitoringRules enables creation of VMRule with alerting rules for health of each managed component
+	// it could be overridden with selfMonitoringRules field of component
+	EnableSelfMonitoringRules bool `default:"false"`
+	// EnableOperatorServiceScrape enables creation of VMServiceScrape for operator metrics service at OperatorNamespace
+	EnableOperatorServiceScrape bool `default:"false"`
+	// OperatorServiceSelector defines label selector of operator metrics service for generated VMServiceScrape
+	OperatorServiceSelector string `default:"app.kubernetes.io/name=vm-operator"`
+
+	VMBackup struct {
 		Image               string `default:"victoriametrics/vmbackupmanager"`
 		Version             string `default:"v1.109.0-enterprise"`
 		Port                string `default:"8300"`
@@ -259,6 +330,14 @@ type BaseOperatorConf struct {
 			}
 		}
 	}
+	VMRestore struct {
+		Image   string `default:"victoriametrics/vmrestore"`
+		Version string `default:"v1.109.0"`
+	}
+	VMCtl struct {
+		Image   string `default:"victoriametrics/vmctl"`
+		Version string `default:"v1.109.0"`
+	}
 	VMAuthDefault struct {
 		Image               string `default:"victoriametrics/vmauth"`
 		Version             string `default:"v1.109.0"`
@@ -278,6 +357,28 @@ type BaseOperatorConf struct {
 		ConfigReloaderCPU    string `default:"100m"`
 		ConfigReloaderMemory string `default:"25Mi"`
 	}
+	VMGatewayDefault struct {
+		Image   string `default:"victoriametrics/vmgateway"`
+		Version string `default:"v1.109.0-enterprise"`
+		// ignored
+		ConfigReloadImage   string `ignored:"true"`
+		Port                string `default:"8431"`
+		UseDefaultResources bool   `default:"true"`
+		Resource            struct {
+			Limit struct {
+				Mem string `default:"300Mi"`
+				Cpu string `default:"200m"`
+			}
+			Request struct {
+				Mem string `default:"100Mi"`
+				Cpu string `default:"50m"`
+			}
+		}
+		// ignored
+		ConfigReloaderCPU string `ignored:"true"`
+		// ignored
+		ConfigReloaderMemory string `ignored:"true"`
+	}
 
 	EnabledPrometheusConverter struct {
 		PodMonitor         bool `default:"true"`
@@ -289,10 +390,22 @@ type BaseOperatorConf struct {
 	}
 	FilterChildLabelPrefixes      []string `default:""`
 	FilterChildAnnotationPrefixes []string `default:""`
+	// disables propagation of CR labels and annotations to child objects.
+	// Only spec.managedMetadata is applied to child objects
+	DisableCRMetadataPropagation bool `default:"false"`
+	// labels with matched prefix added to child objects by 3rd party tools are kept during updates
+	PreservedChildLabelPrefixes []string `default:""`
+	// fields of generated Deployment, StatefulSet and Service, which are managed by 3rd party tools and must not be overwritten.
+	// Each field is defined as Kind:/json/pointer, e.g. Deployment:/spec/replicas
+	PreservedChildFields []string `default:""`
 	// adds compare-options and sync-options for prometheus objects converted by operator.
 	// It helps to properly use converter with ArgoCD
 	PrometheusConverterAddArgoCDIgnoreAnnotations bool `default:"false"`
-	EnabledPrometheusConverterOwnerReferences     bool `default:"false"`
+	// adds OwnerReferences to converted objects, so they are deleted by garbage collector together with prometheus-operator objects
+	EnabledPrometheusConverterOwnerReferences bool `default:"true"`
+	// name of ConfigMap at operator namespace for prometheus converter sync status.
+	// Status is not written if empty
+	PrometheusConverterStatusConfigMap string `default:""`
 	// allows filtering for converted labels, labels with matched prefix will be ignored
 	FilterPrometheusConverterLabelPrefixes []string `default:""`
 	// allows filtering for converted annotations, annotations with matched prefix will be ignored
@@ -312,6 +425,18 @@ type BaseOperatorConf struct {
 	PodWaitReadyIntervalCheck time.Duration `default:"5s"`
 	// configures force resync interval for VMAgent, VMAlert, VMAlertmanager and VMAuth.
 	ForceResyncInterval time.Duration `default:"60s"`
+	// configures interval for VMAgent remote write targets health check, which results are reported at status.remoteWrite
+	// zero value disables the check
+	VMAgentRemoteWriteStatusCheckInterval time.Duration `default:"0s"`
+	// configures interval for VMAgent shards check, which results are reported at status.shardTargets
+	// zero value disables the check
+	VMAgentShardStatusCheckInterval time.Duration `default:"0s"`
+	// configures interval for VMCluster components sizing check, which results are reported at status.recommendations
+	// zero value disables the check
+	VMClusterResourceRecommendationsInterval time.Duration `default:"0s"`
+	// overrides -controller.maxConcurrentReconciles for the given controllers.
+	// comma separated list of controller:workers pairs, e.g. vmservicescrape:10,vmrule:10
+	ControllerMaxConcurrentReconciles map[string]int `default:""`
 	// EnableStrictSecurity will add default `securityContext` to pods and containers created by operator
 	// Default PodSecurityContext include:
 	// 1. RunAsNonRoot: true
@@ -334,6 +459,63 @@ type BaseOperatorConf struct {
 	//        - all
 	// turn off `EnableStrictSecurity` by default, see https://github.com/VictoriaMetrics/operator/issues/749 for details
 	EnableStrictSecurity bool `default:"false"`
+	// StrictSecurityRunAsUser defines runAsUser, runAsGroup and fsGroup for pods and containers with enabled strict security.
+	// Empty value omits ids and allows platform to assign it, e.g. OpenShift restricted-v2 SecurityContextConstraints
+	StrictSecurityRunAsUser string `default:"65534"`
+	// StrictSecuritySeccompProfile defines seccomp profile for pods with enabled strict security.
+	// Supported values: RuntimeDefault and Localhost/<path to profile>
+	StrictSecuritySeccompProfile string `default:"RuntimeDefault"`
+	// StrictSecurityReadOnlyRootFilesystem mounts root filesystem of containers with enabled strict security as read-only
+	StrictSecurityReadOnlyRootFilesystem bool `default:"true"`
+	// OpenShift enables OpenShift support: Route generation and security context defaults compatible with restricted SecurityContextConstraints.
+	// Supported values: auto, true, false. With auto operator checks if route.openshift.io API is served by kubernetes API server
+	OpenShift string `default:"auto"`
+	// ServiceIPFamilyPolicy defines ipFamilyPolicy for services created by operator.
+	// Supported values: SingleStack, PreferDualStack, RequireDualStack. Empty value uses cluster default
+	ServiceIPFamilyPolicy string `default:""`
+	// ServiceIPFamilies defines comma separated list of ipFamilies for services created by operator, e.g. IPv6,IPv4.
+	// The first family is used as primary. Empty value uses cluster default
+	ServiceIPFamilies []string `default:""`
+}
+
+// ServiceIPFamiliesPolicy returns ipFamilies and ipFamilyPolicy for services created by operator
+func (boc *BaseOperatorConf) ServiceIPFamiliesPolicy() ([]corev1.IPFamily, *corev1.IPFamilyPolicy) {
+	var families []corev1.IPFamily
+	for _, family := range boc.ServiceIPFamilies {
+		families = append(families, corev1.IPFamily(family))
+	}
+	var policy *corev1.IPFamilyPolicy
+	if boc.ServiceIPFamilyPolicy != "" {
+		p := corev1.IPFamilyPolicy(boc.ServiceIPFamilyPolicy)
+		policy = &p
+	}
+	return families, policy
+}
+
+// StrictSecurityUser returns parsed StrictSecurityRunAsUser or nil if it's empty
+func (boc *BaseOperatorConf) StrictSecurityUser() *int64 {
+	if boc.StrictSecurityRunAsUser == "" {
+		return nil
+	}
+	uid, err := strconv.ParseInt(boc.StrictSecurityRunAsUser, 10, 64)
+	if err != nil {
+		// must be checked at Validate
+		panic(fmt.Sprintf("BUG: unexpected StrictSecurityRunAsUser=%q: %s", boc.StrictSecurityRunAsUser, err))
+	}
+	return &uid
+}
+
+// StrictSecuritySeccomp returns seccomp profile for StrictSecuritySeccompProfile
+func (boc *BaseOperatorConf) StrictSecuritySeccomp() *corev1.SeccompProfile {
+	if profile, ok := strings.CutPrefix(boc.StrictSecuritySeccompProfile, "Localhost/"); ok {
+		return &corev1.SeccompProfile{
+			Type:             corev1.SeccompProfileTypeLocalhost,
+			LocalhostProfile: &profile,
+		}
+	}
+	return &corev1.SeccompProfile{
+		Type: corev1.SeccompProfileTypeRuntimeDefault,
+	}
 }
 
 // ResyncAfterDuration returns requeue duration for object period reconcile
@@ -429,6 +611,85 @@ func (boc BaseOperatorConf) Validate() error {
 	if err := validateResource("vlogs", Resource(boc.VLogsDefault.Resource)); err != nil {
 		return err
 	}
+	if err := validateResource("vlsingle", Resource(boc.VLSingleDefault.Resource)); err != nil {
+		return err
+	}
+	if err := validateResource("vmgateway", Resource(boc.VMGatewayDefault.Resource)); err != nil {
+		return err
+	}
+	if _, err := time.ParseDuration(boc.VMAgentScrapeDefault.ScrapeInterval); err != nil {
+		return fmt.Errorf("cannot parse vmagent default scrape interval: %w", err)
+	}
+	if boc.StrictSecurityRunAsUser != "" {
+		uid, err := strconv.ParseInt(boc.StrictSecurityRunAsUser, 10, 64)
+		if err != nil {
+			return fmt.Errorf("cannot parse strict security runAsUser: %w", err)
+		}
+		if uid <= 0 {
+			return fmt.Errorf("strict security runAsUser must be a non-root user id, got: %d", uid)
+		}
+	}
+	if boc.StrictSecuritySeccompProfile != string(corev1.SeccompProfileTypeRuntimeDefault) {
+		profile, ok := strings.CutPrefix(boc.StrictSecuritySeccompProfile, "Localhost/")
+		if !ok || profile == "" {
+			return fmt.Errorf("unsupported strict security seccomp profile=%q, supported values: RuntimeDefault, Localhost/<path to profile>", boc.StrictSecuritySeccompProfile)
+		}
+	}
+	switch boc.ComponentsLogFormat {
+	case "", "default", "json":
+	default:
+		return fmt.Errorf("unsupported components log format=%q, supported values: default, json", boc.ComponentsLogFormat)
+	}
+	switch boc.ComponentsLogLevel {
+	case "", "INFO", "WARN", "ERROR", "FATAL", "PANIC":
+	default:
+		return fmt.Errorf("unsupported components log level=%q, supported values: INFO, WARN, ERROR, FATAL, PANIC", boc.ComponentsLogLevel)
+	}
+	switch boc.OpenShift {
+	case "auto", "true", "false":
+	default:
+		return fmt.Errorf("unsupported openshift mode=%q, supported values: auto, true, false", boc.OpenShift)
+	}
+	switch corev1.IPFamilyPolicy(boc.ServiceIPFamilyPolicy) {
+	case "", corev1.IPFamilyPolicySingleStack, corev1.IPFamilyPolicyPreferDualStack, corev1.IPFamilyPolicyRequireDualStack:
+	default:
+		return fmt.Errorf("unsupported service ipFamilyPolicy=%q, supported values: SingleStack, PreferDualStack, RequireDualStack", boc.ServiceIPFamilyPolicy)
+	}
+	if len(boc.ServiceIPFamilies) > 2 {
+		return fmt.Errorf("service ipFamilies must contain at most 2 families, got: %d", len(boc.ServiceIPFamilies))
+	}
+	for idx, family := range boc.ServiceIPFamilies {
+		switch corev1.IPFamily(family) {
+		case corev1.IPv4Protocol, corev1.IPv6Protocol:
+		default:
+			return fmt.Errorf("unsupported service ipFamily=%q, supported values: IPv4, IPv6", family)
+		}
+		if idx > 0 && family == boc.ServiceIPFamilies[0] {
+			return fmt.Errorf("service ipFamilies cannot contain duplicate family=%q", family)
+		}
+	}
+	if len(boc.ServiceIPFamilies) == 2 && boc.ServiceIPFamilyPolicy == string(corev1.IPFamilyPolicySingleStack) {
+		return fmt.Errorf("service ipFamilies must contain single family with SingleStack ipFamilyPolicy")
+	}
+	compressionLevel := boc.VMAgentScrapeDefault.ConfigCompressionLevel
+	switch boc.VMAgentScrapeDefault.ConfigCompression {
+	case ConfigCompressionGzip:
+		if compressionLevel < 0 || compressionLevel > 9 {
+			return fmt.Errorf("unsupported vmagent gzip config compression level=%d, supported values: 1-9", compressionLevel)
+		}
+	case ConfigCompressionZstd:
+		if compressionLevel < 0 || compressionLevel > 22 {
+			return fmt.Errorf("unsupported vmagent zstd config compression level=%d, supported values: 1-22", compressionLevel)
+		}
+	case ConfigCompressionNone:
+	default:
+		return fmt.Errorf("unsupported vmagent config compression=%q, supported values: gzip, zstd, none", boc.VMAgentScrapeDefault.ConfigCompression)
+	}
+	for name, workers := range boc.ControllerMaxConcurrentReconciles {
+		if workers <= 0 {
+			return fmt.Errorf("max concurrent reconciles for controller=%q must be greater than 0, got: %d", name, workers)
+		}
+	}
 
 	return nil
 }
@@ -460,24 +721,233 @@ func (boc BaseOperatorConf) PrintDefaults(format string) error {
 	return err
 }
 
+// operatorConf holds operator configuration and its per namespace overrides
+type operatorConf struct {
+	base        *BaseOperatorConf
+	byNamespace map[string]*BaseOperatorConf
+}
+
 // MustGetBaseConfig returns operator configuration with default values populated from env variables
 func MustGetBaseConfig() *BaseOperatorConf {
+	return mustGetOperatorConf().base
+}
+
+// MustGetBaseConfigForNamespace returns operator configuration for objects at the given namespace
+// it returns MustGetBaseConfig if there are no overrides for the namespace
+func MustGetBaseConfigForNamespace(namespace string) *BaseOperatorConf {
+	oc := mustGetOperatorConf()
+	if c, ok := oc.byNamespace[namespace]; ok {
+		return c
+	}
+	return oc.base
+}
+
+func mustGetOperatorConf() *operatorConf {
 	initConf.Do(func() {
-		c := &BaseOperatorConf{}
-		err := envconfig.Process(prefixVar, c)
+		c, err := loadBaseConfig(nil)
 		if err != nil {
 			panic(err)
 		}
+		opConf.Store(&operatorConf{base: c})
+	})
+	return opConf.Load()
+}
 
-		if err := c.Validate(); err != nil {
-			panic(err)
+// ReloadBaseConfig re-reads operator configuration from env variables and files at the given directory
+// file name must be equal to the env variable name, e.g. VM_VMAGENTDEFAULT_VERSION. It allows to mount ConfigMap as directory.
+// values from files have priority over env variables.
+// file name prefixed with namespace, e.g. team-a.VM_VMAGENTDEFAULT_VERSION, overrides variable only for objects at this namespace.
+// returns true if configuration was changed
+func ReloadBaseConfig(dir string) (bool, error) {
+	overrides, nsOverrides, err := readConfigDir(dir)
+	if err != nil {
+		return false, err
+	}
+	c, err := loadBaseConfig(overrides)
+	if err != nil {
+		return false, err
+	}
+	oc := &operatorConf{base: c}
+	for namespace, vars := range nsOverrides {
+		merged := make(map[string]string, len(overrides)+len(vars))
+		for name, value := range overrides {
+			merged[name] = value
 		}
-		if err := parseAndSetCustomerConfigReloadImageVersion(c); err != nil {
-			panic(err)
+		for name, value := range vars {
+			merged[name] = value
 		}
-		opConf = c
-	})
-	return opConf
+		nsc, err := loadBaseConfig(merged)
+		if err != nil {
+			return false, fmt.Errorf("incorrect configuration for namespace=%q: %w", namespace, err)
+		}
+		if oc.byNamespace == nil {
+			oc.byNamespace = make(map[string]*BaseOperatorConf)
+		}
+		oc.byNamespace[namespace] = nsc
+	}
+	if reflect.DeepEqual(mustGetOperatorConf(), oc) {
+		return false, nil
+	}
+	opConf.Store(oc)
+	return true, nil
+}
+
+func loadBaseConfig(overrides map[string]string) (*BaseOperatorConf, error) {
+	c := &BaseOperatorConf{}
+	if err := envconfig.Process(prefixVar, c); err != nil {
+		return nil, err
+	}
+	if err := applyOverrides(prefixVar, reflect.ValueOf(c).Elem(), overrides); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if err := parseAndSetCustomerConfigReloadImageVersion(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// applyOverrides sets struct fields from the given variables
+// variable names are built the same way as envconfig does, e.g. VM_VMAGENTDEFAULT_VERSION
+// it allows to load configuration from files without modification of process env variables
+func applyOverrides(prefix string, v reflect.Value, overrides map[string]string) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		ft := t.Field(i)
+		if !f.CanSet() || ft.Tag.Get("ignored") == "true" {
+			continue
+		}
+		key := strings.ToUpper(prefix + "_" + ft.Name)
+		if f.Kind() == reflect.Struct {
+			if err := applyOverrides(key, f, overrides); err != nil {
+				return err
+			}
+			continue
+		}
+		value, ok := overrides[key]
+		if !ok {
+			continue
+		}
+		if err := setFieldValue(f, value); err != nil {
+			return fmt.Errorf("cannot parse value=%q of variable=%q: %w", value, key, err)
+		}
+	}
+	return nil
+}
+
+func setFieldValue(f reflect.Value, value string) error {
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		if f.Type() == reflect.TypeOf(time.Duration(0)) {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return err
+			}
+			f.SetInt(int64(d))
+			return nil
+		}
+		n, err := strconv.ParseInt(value, 0, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Slice:
+		sl := reflect.MakeSlice(f.Type(), 0, 0)
+		if len(strings.TrimSpace(value)) > 0 {
+			items := strings.Split(value, ",")
+			sl = reflect.MakeSlice(f.Type(), len(items), len(items))
+			for i, item := range items {
+				if err := setFieldValue(sl.Index(i), item); err != nil {
+					return err
+				}
+			}
+		}
+		f.Set(sl)
+	case reflect.Map:
+		m := reflect.MakeMap(f.Type())
+		if len(strings.TrimSpace(value)) > 0 {
+			for _, pair := range strings.Split(value, ",") {
+				k, val, ok := strings.Cut(pair, ":")
+				if !ok {
+					return fmt.Errorf("invalid map item: %q", pair)
+				}
+				mk := reflect.New(f.Type().Key()).Elem()
+				if err := setFieldValue(mk, k); err != nil {
+					return err
+				}
+				mv := reflect.New(f.Type().Elem()).Elem()
+				if err := setFieldValue(mv, val); err != nil {
+					return err
+				}
+				m.SetMapIndex(mk, mv)
+			}
+		}
+		f.Set(m)
+	default:
+		return fmt.Errorf("unsupported field type=%s", f.Type())
+	}
+	return nil
+}
+
+// readConfigDir reads operator configuration variables from files at the given directory
+// and returns global variables and variables overrides per namespace
+func readConfigDir(dir string) (map[string]string, map[string]map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read config dir: %w", err)
+	}
+	vars := make(map[string]string)
+	nsVars := make(map[string]map[string]string)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		namespace, name, ok := strings.Cut(fileName, ".")
+		if !ok {
+			namespace, name = "", fileName
+		}
+		// skip hidden files and ..data symlinks created by kubelet for mounted ConfigMap
+		if !strings.HasPrefix(name, prefixVar+"_") {
+			continue
+		}
+		if namespace != "" && validNamespaceRegex.FindString(namespace) != namespace {
+			return nil, nil, fmt.Errorf("incorrect namespace=%q at config file=%q", namespace, fileName)
+		}
+		path := filepath.Join(dir, fileName)
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot stat config file: %w", err)
+		}
+		if fi.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot read config file: %w", err)
+		}
+		value := strings.TrimSpace(string(data))
+		if namespace == "" {
+			vars[name] = value
+			continue
+		}
+		if nsVars[namespace] == nil {
+			nsVars[namespace] = make(map[string]string)
+		}
+		nsVars[namespace][name] = value
+	}
+	return vars, nsVars, nil
 }
 
 var validNamespaceRegex = regexp.MustCompile(`[a-z0-9]([-a-z0-9]*[a-z0-9])?`)
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReloadBaseConfig(t *testing.T) {
+	dir := t.TempDir()
+	defaultVersion := MustGetBaseConfig().VMAgentDefault.Version
+	defer func() {
+		if _, err := ReloadBaseConfig(t.TempDir()); err != nil {
+			t.Fatalf("cannot restore config: %s", err)
+		}
+	}()
+	writeFile := func(name, data string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatalf("cannot write file: %s", err)
+		}
+	}
+	f := func(wantChanged bool, wantVersion string, wantErr bool) {
+		t.Helper()
+		changed, err := ReloadBaseConfig(dir)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if changed != wantChanged {
+			t.Fatalf("unexpected changed result, got: %v, want: %v", changed, wantChanged)
+		}
+		if got := MustGetBaseConfig().VMAgentDefault.Version; got != wantVersion {
+			t.Fatalf("unexpected vmagent version, got: %q, want: %q", got, wantVersion)
+		}
+		if _, ok := os.LookupEnv("VM_VMAGENTDEFAULT_VERSION"); ok {
+			t.Fatalf("config reload must not modify env variables")
+		}
+	}
+
+	// empty dir
+	f(false, defaultVersion, false)
+
+	// override default version, hidden and unrelated files are ignored
+	writeFile("VM_VMAGENTDEFAULT_VERSION", "v1.100.0\n")
+	writeFile("..data", "VM_VMAGENTDEFAULT_VERSION=v1.99.0")
+	writeFile("README", "operator config")
+	f(true, "v1.100.0", false)
+
+	// the same content
+	f(false, "v1.100.0", false)
+
+	// incorrect value keeps previous config
+	writeFile("VM_VMAGENTDEFAULT_RESOURCE_LIMIT_MEM", "not-a-quantity")
+	f(false, "v1.100.0", true)
+	if err := os.Remove(filepath.Join(dir, "VM_VMAGENTDEFAULT_RESOURCE_LIMIT_MEM")); err != nil {
+		t.Fatalf("cannot remove file: %s", err)
+	}
+
+	// incorrect value type
+	writeFile("VM_ENABLESTRICTSECURITY", "not-a-bool")
+	f(false, "v1.100.0", true)
+}
+
+func TestReloadBaseConfigNamespaceOverrides(t *testing.T) {
+	dir := t.TempDir()
+	defer func() {
+		if _, err := ReloadBaseConfig(t.TempDir()); err != nil {
+			t.Fatalf("cannot restore config: %s", err)
+		}
+	}()
+	writeFile := func(name, data string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatalf("cannot write file: %s", err)
+		}
+	}
+	writeFile("VM_CONTAINERREGISTRY", "registry.example.com")
+	writeFile("VM_VMAGENTSCRAPEDEFAULT_SCRAPEINTERVAL", "1m")
+	writeFile("team-a.VM_CONTAINERREGISTRY", "mirror.team-a.example.com")
+	writeFile("team-a.VM_VMAGENTDEFAULT_RESOURCE_LIMIT_MEM", "1Gi")
+	if _, err := ReloadBaseConfig(dir); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	f := func(namespace, wantRegistry, wantScrapeInterval, wantMem string) {
+		t.Helper()
+		c := MustGetBaseConfigForNamespace(namespace)
+		if c.ContainerRegistry != wantRegistry {
+			t.Fatalf("unexpected container registry for namespace=%q, got: %q, want: %q", namespace, c.ContainerRegistry, wantRegistry)
+		}
+		if c.VMAgentScrapeDefault.ScrapeInterval != wantScrapeInterval {
+			t.Fatalf("unexpected scrape interval for namespace=%q, got: %q, want: %q", namespace, c.VMAgentScrapeDefault.ScrapeInterval, wantScrapeInterval)
+		}
+		if c.VMAgentDefault.Resource.Limit.Mem != wantMem {
+			t.Fatalf("unexpected memory limit for namespace=%q, got: %q, want: %q", namespace, c.VMAgentDefault.Resource.Limit.Mem, wantMem)
+		}
+	}
+	defaultMem := MustGetBaseConfig().VMAgentDefault.Resource.Limit.Mem
+
+	// namespace override
+	f("team-a", "mirror.team-a.example.com", "1m", "1Gi")
+
+	// namespace without overrides uses global config
+	f("team-b", "registry.example.com", "1m", defaultMem)
+
+	// incorrect namespace name
+	writeFile("Team_A.VM_CONTAINERREGISTRY", "mirror.example.com")
+	if _, err := ReloadBaseConfig(dir); err == nil {
+		t.Fatalf("expected error for incorrect namespace name")
+	}
+}
+
+func TestValidateStrictSecurity(t *testing.T) {
+	f := func(runAsUser, seccompProfile string, wantErr bool) {
+		t.Helper()
+		c := *MustGetBaseConfig()
+		c.StrictSecurityRunAsUser = runAsUser
+		c.StrictSecuritySeccompProfile = seccompProfile
+		if err := c.Validate(); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	f("65534", "RuntimeDefault", false)
+	f("", "Localhost/profiles/vm.json", false)
+	f("0", "RuntimeDefault", true)
+	f("nobody", "RuntimeDefault", true)
+	f("65534", "Unconfined", true)
+	f("65534", "Localhost/", true)
+}
+
+func TestValidateOpenShift(t *testing.T) {
+	f := func(mode string, wantErr bool) {
+		t.Helper()
+		c := *MustGetBaseConfig()
+		c.OpenShift = mode
+		if err := c.Validate(); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	f("auto", false)
+	f("true", false)
+	f("false", false)
+	f("", true)
+	f("enabled", true)
+}
+
+func TestValidateServiceIPFamilies(t *testing.T) {
+	f := func(policy string, families []string, wantErr bool) {
+		t.Helper()
+		c := *MustGetBaseConfig()
+		c.ServiceIPFamilyPolicy = policy
+		c.ServiceIPFamilies = families
+		if err := c.Validate(); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	f("", nil, false)
+	f("SingleStack", []string{"IPv6"}, false)
+	f("PreferDualStack", []string{"IPv6", "IPv4"}, false)
+	f("DualStack", nil, true)
+	f("", []string{"ipv6"}, true)
+	f("", []string{"IPv4", "IPv4"}, true)
+	f("SingleStack", []string{"IPv4", "IPv6"}, true)
+}
+
+func TestValidateVMAgentConfigCompression(t *testing.T) {
+	f := func(compression string, level int, wantErr bool) {
+		t.Helper()
+		c := *MustGetBaseConfig()
+		c.VMAgentScrapeDefault.ConfigCompression = compression
+		c.VMAgentScrapeDefault.ConfigCompressionLevel = level
+		if err := c.Validate(); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	f("gzip", 0, false)
+	f("gzip", 1, false)
+	f("zstd", 19, false)
+	f("none", 0, false)
+	f("gzip", 10, true)
+	f("zstd", 23, true)
+	f("lz4", 0, true)
+	f("", 0, true)
+}
+
+func TestValidateComponentsLogging(t *testing.T) {
+	f := func(format, level string, wantErr bool) {
+		t.Helper()
+		c := *MustGetBaseConfig()
+		c.ComponentsLogFormat = format
+		c.ComponentsLogLevel = level
+		if err := c.Validate(); (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	f("", "", false)
+	f("json", "WARN", false)
+	f("default", "PANIC", false)
+	f("logfmt", "", true)
+	f("", "info", true)
+	f("json", "DEBUG", true)
+}
+
+func TestApplyOverrides(t *testing.T) {
+	f := func(overrides map[string]string, check func(c *BaseOperatorConf) bool, wantErr bool) {
+		t.Helper()
+		c := *MustGetBaseConfig()
+		err := applyOverrides(prefixVar, reflect.ValueOf(&c).Elem(), overrides)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if !wantErr && !check(&c) {
+			t.Fatalf("unexpected config after overrides=%v", overrides)
+		}
+	}
+
+	// nested struct, duration and slice
+	f(map[string]string{
+		"VM_VMALERTDEFAULT_VERSION":      "v1.100.0",
+		"VM_APPREADYTIMEOUT":             "2m",
+		"VM_FILTERCHILDLABELPREFIXES":    "team.io/,policy.io/",
+		"VM_PRESERVEDCHILDLABELPREFIXES": "",
+		"VM_UNKNOWN_VARIABLE_IS_IGNORED": "value",
+	}, func(c *BaseOperatorConf) bool {
+		return c.VMAlertDefault.Version == "v1.100.0" &&
+			c.AppReadyTimeout == 2*time.Minute &&
+			reflect.DeepEqual(c.FilterChildLabelPrefixes, []string{"team.io/", "policy.io/"}) &&
+			len(c.PreservedChildLabelPrefixes) == 0
+	}, false)
+
+	// incorrect duration
+	f(map[string]string{"VM_APPREADYTIMEOUT": "2 minutes"}, nil, true)
+}
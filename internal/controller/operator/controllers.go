@@ -6,11 +6,10 @@ import (
 	"flag"
 	"fmt"
 	"reflect"
-	"sync"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -21,10 +20,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	operatorreconcile "github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
 )
@@ -33,16 +34,21 @@ import (
 func BindFlags(f *flag.FlagSet) {
 	cacheSyncTimeout = f.Duration("controller.cacheSyncTimeout", *cacheSyncTimeout, "controls timeout for caches to be synced.")
 	maxConcurrency = f.Int("controller.maxConcurrentReconciles", *maxConcurrency, "Configures number of concurrent reconciles. It should improve performance for clusters with many objects.")
+	rateLimiterBaseDelay = f.Duration("controller.rateLimiterBaseDelay", *rateLimiterBaseDelay, "Configures initial delay for requeue of failed object reconcile. Delay grows exponentially up to -controller.rateLimiterMaxDelay")
+	rateLimiterMaxDelay = f.Duration("controller.rateLimiterMaxDelay", *rateLimiterMaxDelay, "Configures maximum delay for requeue of failed object reconcile")
+	rateLimiterQPS = f.Float64("controller.rateLimiterQPS", *rateLimiterQPS, "Configures overall rate of requeues per controller. It prevents requeue storms on mass objects update. Zero value disables the limit")
+	rateLimiterBurst = f.Int("controller.rateLimiterBurst", *rateLimiterBurst, "Configures bucket size for -controller.rateLimiterQPS")
+	shardCount = f.Int("controller.shardCount", *shardCount, "Configures number of operator shards. Each shard reconciles only its own part of objects selected by hash of object namespace and name")
+	shardNum = f.Int("controller.shardNum", *shardNum, "Configures number of the current operator shard, it must be in range [0, controller.shardCount)")
 }
 
 var (
-	cacheSyncTimeout = ptr.To(3 * time.Minute)
-	maxConcurrency   = ptr.To(5)
-)
-
-var (
-	optionsInit    sync.Once
-	defaultOptions *controller.Options
+	cacheSyncTimeout     = ptr.To(3 * time.Minute)
+	maxConcurrency       = ptr.To(5)
+	rateLimiterBaseDelay = ptr.To(2 * time.Second)
+	rateLimiterMaxDelay  = ptr.To(2 * time.Minute)
+	rateLimiterQPS       = ptr.To(0.0)
+	rateLimiterBurst     = ptr.To(100)
 )
 
 var (
@@ -69,15 +75,34 @@ func init() {
 	metrics.Registry.MustRegister(parseObjectErrorsTotal, getObjectsErrorsTotal, conflictErrorsTotal, contextCancelErrorsTotal)
 }
 
-func getDefaultOptions() controller.Options {
-	optionsInit.Do(func() {
-		defaultOptions = &controller.Options{
-			RateLimiter:             workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](2*time.Second, 2*time.Minute),
-			CacheSyncTimeout:        *cacheSyncTimeout,
-			MaxConcurrentReconciles: *maxConcurrency,
-		}
-	})
-	return *defaultOptions
+// skipStatusUpdates filters out events of object status updates
+// it prevents reconcile of the object after status changes made by periodic checks
+var skipStatusUpdates = predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{})
+
+// getDefaultOptions returns options for the given controller
+// number of concurrent reconciles could be overridden per controller with VM_CONTROLLERMAXCONCURRENTRECONCILES env var
+func getDefaultOptions(controllerName string) controller.Options {
+	opts := controller.Options{
+		RateLimiter:             newRateLimiter(),
+		CacheSyncTimeout:        *cacheSyncTimeout,
+		MaxConcurrentReconciles: *maxConcurrency,
+	}
+	if workers, ok := config.MustGetBaseConfig().ControllerMaxConcurrentReconciles[controllerName]; ok {
+		opts.MaxConcurrentReconciles = workers
+	}
+	return opts
+}
+
+// newRateLimiter returns workqueue rate limiter configured with -controller.rateLimiter* flags
+// each controller must have own instance, since rate limiter tracks requeues of objects
+func newRateLimiter() workqueue.TypedRateLimiter[reconcile.Request] {
+	rl := workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](*rateLimiterBaseDelay, *rateLimiterMaxDelay)
+	if *rateLimiterQPS <= 0 {
+		return rl
+	}
+	return workqueue.NewTypedMaxOfRateLimiter(rl,
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(*rateLimiterQPS), max(*rateLimiterBurst, 1))},
+	)
 }
 
 // parsingError usually occurs in case of x-preserve-unknow-fields option enable to CRD
@@ -92,6 +117,27 @@ func (pe *parsingError) Error() string {
 		pe.controller, pe.origin)
 }
 
+// finalizeError occurs if operator cannot remove objects created for deleted CR
+// it's reported at object status, otherwise the reason of stuck object termination isn't visible
+type finalizeError struct {
+	origin     error
+	controller string
+}
+
+// Unwrap implemnets errors.Unwrap interface
+func (fe *finalizeError) Unwrap() error {
+	return fe.origin
+}
+
+func (fe *finalizeError) Error() string {
+	return fmt.Sprintf("cannot finalize object deletion for controller=%q: %s", fe.controller, fe.origin)
+}
+
+// ConditionReason implements conditionReasoner interface
+func (fe *finalizeError) ConditionReason() string {
+	return vmv1beta1.ConditionReasonFinalizeFailed
+}
+
 // getError could usually occur at following cases:
 // - not enough k8s permissions
 // - object was deleted and due to race condition queue by operator cache
@@ -116,6 +162,8 @@ func handleReconcileErr(ctx context.Context, rclient client.Client, object objec
 	}
 	var ge *getError
 	var pe *parsingError
+	var fe *finalizeError
+	var rde *vmv1beta1.RetentionDecreaseError
 	switch {
 	case errors.Is(err, context.Canceled):
 		contextCancelErrorsTotal.Inc()
@@ -141,31 +189,26 @@ func handleReconcileErr(ctx context.Context, rclient client.Client, object objec
 		}
 		conflictErrorsTotal.WithLabelValues(controller, namespacedName).Inc()
 		return ctrl.Result{RequeueAfter: time.Second * 5}, nil
-	}
-	if object != nil && !reflect.ValueOf(object).IsNil() && object.GetNamespace() != "" {
-		errEvent := &corev1.Event{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "victoria-metrics-operator-" + uuid.New().String(),
-				Namespace: object.GetNamespace(),
-			},
-			Type:    corev1.EventTypeWarning,
-			Reason:  "ReconcilationError",
-			Message: err.Error(),
-			Source: corev1.EventSource{
-				Component: "victoria-metrics-operator",
-			},
-			LastTimestamp: metav1.NewTime(time.Now()),
-			InvolvedObject: corev1.ObjectReference{
-				Kind:            object.GetObjectKind().GroupVersionKind().Kind,
-				Namespace:       object.GetNamespace(),
-				Name:            object.GetName(),
-				UID:             object.GetUID(),
-				ResourceVersion: object.GetResourceVersion(),
-			},
+	case errors.As(err, &fe):
+		if object != nil && !reflect.ValueOf(object).IsNil() {
+			if err := object.SetUpdateStatusTo(ctx, rclient, vmv1beta1.UpdateStatusFailed, err); err != nil {
+				logger.WithContext(ctx).Error(err, "failed to update status with finalize error")
+			}
 		}
-		if err := rclient.Create(ctx, errEvent); err != nil {
-			logger.WithContext(ctx).Error(err, "failed to create error event at kubernetes API during reconciliation error")
+	case errors.As(err, &rde):
+		// unconfirmed decrease cannot be fixed by retries,
+		// object is reconciled again after spec or annotations change
+		if object == nil || reflect.ValueOf(object).IsNil() {
+			return originResult, nil
+		}
+		if err := object.SetUpdateStatusTo(ctx, rclient, vmv1beta1.UpdateStatusFailed, err); err != nil {
+			logger.WithContext(ctx).Error(err, "failed to update status with retention decrease error")
 		}
+		events.Warning(object, events.ReasonReconcileError, err.Error())
+		return originResult, nil
+	}
+	if object != nil && !reflect.ValueOf(object).IsNil() && object.GetNamespace() != "" {
+		events.Warning(object, events.ReasonReconcileError, err.Error())
 	}
 
 	return originResult, err
@@ -245,33 +288,6 @@ type objectWithStatusTrack interface {
 	Paused() bool
 }
 
-func createGenericEventForObject(ctx context.Context, c client.Client, object client.Object, message string) error {
-	ev := &corev1.Event{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "victoria-metrics-operator-" + uuid.New().String(),
-			Namespace: object.GetNamespace(),
-		},
-		Type:    corev1.EventTypeNormal,
-		Reason:  "ReconcileEvent",
-		Message: message,
-		Source: corev1.EventSource{
-			Component: "victoria-metrics-operator",
-		},
-		LastTimestamp: metav1.NewTime(time.Now()),
-		InvolvedObject: corev1.ObjectReference{
-			Kind:            object.GetObjectKind().GroupVersionKind().Kind,
-			Namespace:       object.GetNamespace(),
-			Name:            object.GetName(),
-			UID:             object.GetUID(),
-			ResourceVersion: object.GetResourceVersion(),
-		},
-	}
-	if err := c.Create(ctx, ev); err != nil {
-		return fmt.Errorf("cannot create generic event at k8s api for object: %q: %w", object.GetObjectKind().GroupVersionKind().GroupKind(), err)
-	}
-	return nil
-}
-
 // TODO :@f41gh7 replace object with generic type
 // it allows to use DeepClone method to prevent hidden object updates
 // made by controller-runtime client
@@ -311,9 +327,7 @@ func reconcileAndTrackStatus(ctx context.Context, c client.Client, object object
 			resultErr = fmt.Errorf("cannot update cluster with last applied spec: %w", err)
 			return
 		}
-		if err := createGenericEventForObject(ctx, c, object, "starting object update"); err != nil {
-			logger.WithContext(ctx).Error(err, " cannot create k8s api event")
-		}
+		events.Normal(object, events.ReasonReconcile, "starting object update")
 		logger.WithContext(ctx).Info("object has changes with previous state, applying changes")
 	}
 
@@ -336,9 +350,7 @@ func reconcileAndTrackStatus(ctx context.Context, c client.Client, object object
 		return result, err
 	}
 	if specChanged {
-		if err := createGenericEventForObject(ctx, c, object, "reconcile of object finished successfully"); err != nil {
-			logger.WithContext(ctx).Error(err, " cannot create k8s api event")
-		}
+		events.Normal(object, events.ReasonReconcile, "reconcile of object finished successfully")
 		logger.WithContext(ctx).Info("object was successfully reconciled")
 	}
 	if err := object.SetUpdateStatusTo(ctx, c, vmv1beta1.UpdateStatusOperational, nil); err != nil {
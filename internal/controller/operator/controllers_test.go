@@ -2,15 +2,21 @@ package operator
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
-	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 )
 
 func TestIsSelectorsMatchesTargetCRD(t *testing.T) {
@@ -312,7 +318,7 @@ func TestIsSelectorsMatchesTargetCRD(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			matches, err := isSelectorsMatchesTargetCRD(context.Background(), fclient, tt.sourceCRD, tt.targetCRD, tt.selector, tt.namespaceSelector, tt.selectAll)
 			if err != nil {
 				t.Fatal(err)
@@ -323,3 +329,142 @@ func TestIsSelectorsMatchesTargetCRD(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDefaultOptions(t *testing.T) {
+	cfg := config.MustGetBaseConfig()
+	defaultCfg := *cfg
+	t.Cleanup(func() {
+		*cfg = defaultCfg
+	})
+	cfg.ControllerMaxConcurrentReconciles = map[string]int{"vmservicescrape": 20}
+
+	f := func(controllerName string, want int) {
+		t.Helper()
+		if got := getDefaultOptions(controllerName).MaxConcurrentReconciles; got != want {
+			t.Fatalf("unexpected max concurrent reconciles for controller=%q, got: %d, want: %d", controllerName, got, want)
+		}
+	}
+
+	// per controller override
+	f("vmservicescrape", 20)
+
+	// global default
+	f("vmrule", *maxConcurrency)
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	prevQPS, prevBurst := *rateLimiterQPS, *rateLimiterBurst
+	defer func() {
+		*rateLimiterQPS, *rateLimiterBurst = prevQPS, prevBurst
+	}()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test"}}
+
+	f := func(qps float64, burst int, wantDelays []time.Duration) {
+		t.Helper()
+		*rateLimiterQPS, *rateLimiterBurst = qps, burst
+		rl := newRateLimiter()
+		for i, want := range wantDelays {
+			got := rl.When(req)
+			// bucket limiter delay depends on time passed since previous call
+			if got < want-100*time.Millisecond || got > want {
+				t.Fatalf("unexpected delay at idx=%d, got: %s, want: %s", i, got, want)
+			}
+		}
+	}
+
+	// exponential backoff only
+	f(0, 100, []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second})
+
+	// bucket limit exceeds exponential backoff
+	f(0.1, 1, []time.Duration{2 * time.Second, 10 * time.Second, 20 * time.Second})
+}
+
+func TestChildObjectReconcileSkipsPausedParent(t *testing.T) {
+	f := func(paused bool, wantSecret bool) {
+		t.Helper()
+		vmagent := &vmv1beta1.VMAgent{
+			ObjectMeta: metav1.ObjectMeta{Name: "paused-test", Namespace: "default"},
+			Spec: vmv1beta1.VMAgentSpec{
+				SelectAllByDefault: true,
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					Paused: paused,
+				},
+			},
+		}
+		vmss := &vmv1beta1.VMServiceScrape{
+			ObjectMeta: metav1.ObjectMeta{Name: "scrape", Namespace: "default"},
+		}
+		fclient := testutil.GetTestClientWithObjects([]runtime.Object{vmagent, vmss})
+		r := &VMServiceScrapeReconciler{Client: fclient, OriginScheme: fclient.Scheme()}
+		if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "scrape"}}); err != nil {
+			t.Fatalf("unexpected reconcile error: %s", err)
+		}
+		var secret corev1.Secret
+		err := fclient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: vmagent.PrefixedName()}, &secret)
+		if gotSecret := err == nil; gotSecret != wantSecret {
+			t.Fatalf("unexpected config secret presence, got: %v, want: %v, err: %v", gotSecret, wantSecret, err)
+		}
+	}
+
+	// paused vmagent must not be updated
+	f(true, false)
+
+	// regular vmagent
+	f(false, true)
+}
+
+func TestHandleReconcileErrFinalize(t *testing.T) {
+	cr := &vmv1beta1.VMSingle{
+		ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "default", Generation: 1},
+	}
+	fclient := testutil.GetTestClientWithObjects([]runtime.Object{cr})
+	ctx := context.Background()
+	finalizeErr := &finalizeError{fmt.Errorf("cannot remove pvc"), "vmsingle"}
+	if _, err := handleReconcileErr(ctx, fclient, cr, reconcile.Result{}, finalizeErr); err == nil {
+		t.Fatalf("expected finalize error to be returned")
+	}
+	var got vmv1beta1.VMSingle
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "single"}, &got); err != nil {
+		t.Fatalf("cannot get vmsingle: %s", err)
+	}
+	if got.Status.UpdateStatus != vmv1beta1.UpdateStatusFailed {
+		t.Fatalf("unexpected update status: %q", got.Status.UpdateStatus)
+	}
+	if !strings.Contains(got.Status.Reason, "cannot remove pvc") {
+		t.Fatalf("unexpected status reason: %q", got.Status.Reason)
+	}
+	var degradedReason string
+	for _, cond := range got.Status.Conditions {
+		if cond.Type == vmv1beta1.ConditionTypeDegraded {
+			degradedReason = cond.Reason
+		}
+	}
+	if degradedReason != vmv1beta1.ConditionReasonFinalizeFailed {
+		t.Fatalf("unexpected degraded condition reason: %q", degradedReason)
+	}
+}
+
+func TestHandleReconcileErrRetentionDecrease(t *testing.T) {
+	cr := &vmv1beta1.VMSingle{
+		ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "default", Generation: 1},
+	}
+	fclient := testutil.GetTestClientWithObjects([]runtime.Object{cr})
+	ctx := context.Background()
+	rde := &vmv1beta1.RetentionDecreaseError{Prev: "1y", New: "30d"}
+	if _, err := handleReconcileErr(ctx, fclient, cr, reconcile.Result{}, rde); err != nil {
+		t.Fatalf("retention decrease error must not be retried: %s", err)
+	}
+	var got vmv1beta1.VMSingle
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "single"}, &got); err != nil {
+		t.Fatalf("cannot get vmsingle: %s", err)
+	}
+	if got.Status.UpdateStatus != vmv1beta1.UpdateStatusFailed {
+		t.Fatalf("unexpected update status: %q", got.Status.UpdateStatus)
+	}
+
+	// object wasn't found
+	var nilCR *vmv1beta1.VMSingle
+	if _, err := handleReconcileErr(ctx, fclient, nilCR, reconcile.Result{}, rde); err != nil {
+		t.Fatalf("unexpected error for nil object: %s", err)
+	}
+}
@@ -519,3 +519,43 @@ func TestConvertProbe(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertOwnerReferences(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "example", Namespace: "default", UID: "a6c3b0f2-7cf1-4b2e-9a37-4b7e1c0d2f11"}
+	f := func(enabled bool, wantKind string, convert func(conf *config.BaseOperatorConf) metav1.Object) {
+		t.Helper()
+		conf := &config.BaseOperatorConf{EnabledPrometheusConverterOwnerReferences: enabled}
+		got := convert(conf).GetOwnerReferences()
+		if !enabled {
+			if len(got) > 0 {
+				t.Fatalf("unexpected owner references: %v", got)
+			}
+			return
+		}
+		want := []metav1.OwnerReference{{
+			APIVersion:         promv1.SchemeGroupVersion.String(),
+			Kind:               wantKind,
+			Name:               meta.Name,
+			UID:                meta.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		}}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected owner references, got: %v, want: %v", got, want)
+		}
+	}
+	for _, enabled := range []bool{false, true} {
+		f(enabled, promv1.PrometheusRuleKind, func(conf *config.BaseOperatorConf) metav1.Object {
+			return ConvertPromRule(&promv1.PrometheusRule{ObjectMeta: meta}, conf)
+		})
+		f(enabled, promv1.ServiceMonitorsKind, func(conf *config.BaseOperatorConf) metav1.Object {
+			return ConvertServiceMonitor(&promv1.ServiceMonitor{ObjectMeta: meta}, conf)
+		})
+		f(enabled, promv1.PodMonitorsKind, func(conf *config.BaseOperatorConf) metav1.Object {
+			return ConvertPodMonitor(&promv1.PodMonitor{ObjectMeta: meta}, conf)
+		})
+		f(enabled, promv1.ProbesKind, func(conf *config.BaseOperatorConf) metav1.Object {
+			return ConvertProbe(&promv1.Probe{ObjectMeta: meta}, conf)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// sync statuses of prometheus objects processed by converter
+const (
+	converterSyncConverted = "converted"
+	converterSyncSkipped   = "skipped"
+	converterSyncConflict  = "conflict"
+	converterSyncFailed    = "failed"
+)
+
+const (
+	converterStatusKey            = "status.json"
+	converterStatusUpdateInterval = 30 * time.Second
+	// limits size of status ConfigMap, which cannot exceed 1MiB
+	maxConverterStatusIssues = 1000
+)
+
+var (
+	converterSyncTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "operator_prometheus_converter_sync_total",
+		Help: "Counts sync attempts of prometheus objects by kind and status",
+	}, []string{"kind", "status"})
+	converterObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "operator_prometheus_converter_objects",
+		Help: "Number of prometheus objects by kind and status of the last sync",
+	}, []string{"kind", "status"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(converterSyncTotal, converterObjects)
+}
+
+// converterSyncRecord holds the last sync result of prometheus object
+type converterSyncRecord struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+	// LastTransitionTime is the time of the last status change
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// converterStatusReport is written into status ConfigMap
+type converterStatusReport struct {
+	// Summary contains number of objects by kind and status
+	Summary map[string]map[string]int `json:"summary"`
+	// Issues contains objects, which were not converted
+	Issues          []converterSyncRecord `json:"issues,omitempty"`
+	IssuesTruncated bool                  `json:"issuesTruncated,omitempty"`
+}
+
+// converterSyncStatus tracks sync status of prometheus objects
+type converterSyncStatus struct {
+	mu      sync.Mutex
+	records map[string]*converterSyncRecord
+	changed bool
+}
+
+func newConverterSyncStatus() *converterSyncStatus {
+	return &converterSyncStatus{records: map[string]*converterSyncRecord{}}
+}
+
+func converterSyncKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// record updates sync status of the given prometheus object
+func (s *converterSyncStatus) record(kind string, src metav1.Object, status, reason string) {
+	converterSyncTotal.WithLabelValues(kind, status).Inc()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := converterSyncKey(kind, src.GetNamespace(), src.GetName())
+	prev, ok := s.records[key]
+	if ok {
+		if prev.Status == status && prev.Reason == reason {
+			return
+		}
+		converterObjects.WithLabelValues(kind, prev.Status).Dec()
+	}
+	converterObjects.WithLabelValues(kind, status).Inc()
+	s.records[key] = &converterSyncRecord{
+		Kind:               kind,
+		Namespace:          src.GetNamespace(),
+		Name:               src.GetName(),
+		Status:             status,
+		Reason:             reason,
+		LastTransitionTime: time.Now(),
+	}
+	s.changed = true
+}
+
+// forget removes sync status of the deleted prometheus object
+func (s *converterSyncStatus) forget(kind string, obj interface{}) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	src, ok := obj.(metav1.Object)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := converterSyncKey(kind, src.GetNamespace(), src.GetName())
+	prev, ok := s.records[key]
+	if !ok {
+		return
+	}
+	converterObjects.WithLabelValues(kind, prev.Status).Dec()
+	delete(s.records, key)
+	s.changed = true
+}
+
+// report builds status report if sync status was changed since the previous call
+func (s *converterSyncStatus) report() (*converterStatusReport, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.changed {
+		return nil, false
+	}
+	s.changed = false
+	r := &converterStatusReport{Summary: map[string]map[string]int{}}
+	for _, rec := range s.records {
+		if r.Summary[rec.Kind] == nil {
+			r.Summary[rec.Kind] = map[string]int{}
+		}
+		r.Summary[rec.Kind][rec.Status]++
+		if rec.Status != converterSyncConverted {
+			r.Issues = append(r.Issues, *rec)
+		}
+	}
+	sort.Slice(r.Issues, func(i, j int) bool {
+		return converterSyncKey(r.Issues[i].Kind, r.Issues[i].Namespace, r.Issues[i].Name) <
+			converterSyncKey(r.Issues[j].Kind, r.Issues[j].Namespace, r.Issues[j].Name)
+	})
+	if len(r.Issues) > maxConverterStatusIssues {
+		r.Issues = r.Issues[:maxConverterStatusIssues]
+		r.IssuesTruncated = true
+	}
+	return r, true
+}
+
+// recordSyncResult records sync status of the prometheus object by the result of VM object create or update
+func (c *ConverterController) recordSyncResult(kind string, src metav1.Object, err error) {
+	if err != nil {
+		c.syncStatus.record(kind, src, converterSyncFailed, err.Error())
+		return
+	}
+	c.syncStatus.record(kind, src, converterSyncConverted, "")
+}
+
+// recordSyncConflict records prometheus object, which target VM object is controlled by another owner
+func (c *ConverterController) recordSyncConflict(kind string, src metav1.Object, reason string) {
+	c.syncStatus.record(kind, src, converterSyncConflict, reason)
+}
+
+// ownershipConflict returns non-empty reason if existing VM object is controlled by an object
+// other than the given prometheus object, e.g. it was created by operator for VMAgent or by another converter source.
+// Objects without controller are converted, since they could be created by converter with disabled owner references.
+func ownershipConflict(existing metav1.Object, kind string, src metav1.Object) string {
+	ref := metav1.GetControllerOfNoCopy(existing)
+	if ref == nil {
+		return ""
+	}
+	// owner reference could have stale UID if prometheus object was re-created before garbage collection
+	if ref.Kind == kind && ref.Name == src.GetName() {
+		return ""
+	}
+	return fmt.Sprintf("object is controlled by %s=%s", ref.Kind, ref.Name)
+}
+
+// recordSyncSkipped records prometheus object, which syncing was disabled by the given annotation
+func (c *ConverterController) recordSyncSkipped(kind string, src metav1.Object, annotation string) {
+	c.syncStatus.record(kind, src, converterSyncSkipped, fmt.Sprintf("syncing is disabled by %s annotation", annotation))
+}
+
+// deleteHandler returns informer handler, which removes sync status of deleted prometheus object
+func (c *ConverterController) deleteHandler(kind string) func(obj interface{}) {
+	return func(obj interface{}) {
+		c.syncStatus.forget(kind, obj)
+	}
+}
+
+// runStatusWriter periodically writes sync status into ConfigMap at operator namespace
+func (c *ConverterController) runStatusWriter(ctx context.Context) {
+	name := c.baseConf.PrometheusConverterStatusConfigMap
+	namespace := c.baseConf.OperatorNamespace
+	if name == "" {
+		return
+	}
+	if namespace == "" {
+		converterLogger.Info("VM_OPERATORNAMESPACE is not set, skipping prometheus converter status ConfigMap creation")
+		return
+	}
+	t := time.NewTicker(converterStatusUpdateInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			r, ok := c.syncStatus.report()
+			if !ok {
+				continue
+			}
+			if err := writeConverterStatus(ctx, c.rclient, namespace, name, r); err != nil {
+				converterLogger.Error(err, "cannot write prometheus converter status", "configmap", name, "namespace", namespace)
+				// retry at the next tick
+				c.syncStatus.mu.Lock()
+				c.syncStatus.changed = true
+				c.syncStatus.mu.Unlock()
+			}
+		}
+	}
+}
+
+// writeConverterStatus writes status into ConfigMap without reading it,
+// since ConfigMap could be missing at cache restricted by label selector
+func writeConverterStatus(ctx context.Context, rclient client.Client, namespace, name string, r *converterStatusReport) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal status: %w", err)
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"managed-by": "vm-operator"},
+		},
+		Data: map[string]string{converterStatusKey: string(data)},
+	}
+	patch, err := json.Marshal(map[string]any{"data": cm.Data})
+	if err != nil {
+		return fmt.Errorf("cannot marshal patch: %w", err)
+	}
+	err = rclient.Patch(ctx, cm.DeepCopy(), client.RawPatch(types.MergePatchType, patch))
+	if errors.IsNotFound(err) {
+		return rclient.Create(ctx, cm)
+	}
+	return err
+}
@@ -0,0 +1,191 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConverterSyncStatus(t *testing.T) {
+	c := &ConverterController{syncStatus: newConverterSyncStatus()}
+	rule := func(name string) *promv1.PrometheusRule {
+		return &promv1.PrometheusRule{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+	f := func(wantSummary map[string]int, wantIssues []string) {
+		t.Helper()
+		r, ok := c.syncStatus.report()
+		if !ok {
+			t.Fatalf("expected changed status report")
+		}
+		if got := r.Summary[promv1.PrometheusRuleKind]; fmt.Sprint(got) != fmt.Sprint(wantSummary) {
+			t.Fatalf("unexpected summary, got: %v, want: %v", got, wantSummary)
+		}
+		var gotIssues []string
+		for _, is := range r.Issues {
+			gotIssues = append(gotIssues, is.Name+":"+is.Status)
+		}
+		if fmt.Sprint(gotIssues) != fmt.Sprint(wantIssues) {
+			t.Fatalf("unexpected issues, got: %v, want: %v", gotIssues, wantIssues)
+		}
+		if _, ok := c.syncStatus.report(); ok {
+			t.Fatalf("expected unchanged status after report")
+		}
+	}
+
+	c.recordSyncResult(promv1.PrometheusRuleKind, rule("rule-a"), nil)
+	c.recordSyncSkipped(promv1.PrometheusRuleKind, rule("rule-c"), IgnoreConversionLabel)
+	c.recordSyncConflict(promv1.PrometheusRuleKind, rule("rule-b"), "object is controlled by VMAgent=main")
+	f(map[string]int{"conflict": 1, "converted": 1, "skipped": 1}, []string{"rule-b:conflict", "rule-c:skipped"})
+
+	// the same status doesn't change report
+	c.recordSyncResult(promv1.PrometheusRuleKind, rule("rule-a"), nil)
+	if _, ok := c.syncStatus.report(); ok {
+		t.Fatalf("expected unchanged status after the same sync result")
+	}
+
+	// resolved conflict and deleted object
+	c.recordSyncResult(promv1.PrometheusRuleKind, rule("rule-b"), nil)
+	c.deleteHandler(promv1.PrometheusRuleKind)(cache.DeletedFinalStateUnknown{Key: "default/rule-c", Obj: rule("rule-c")})
+	c.recordSyncResult(promv1.PrometheusRuleKind, rule("rule-d"), fmt.Errorf("admission webhook denied the request"))
+	// update conflict is a failed sync, not ownership conflict
+	conflictErr := k8serrors.NewConflict(schema.GroupResource{Resource: "vmrules"}, "rule-e", fmt.Errorf("object was modified"))
+	c.recordSyncResult(promv1.PrometheusRuleKind, rule("rule-e"), conflictErr)
+	f(map[string]int{"converted": 2, "failed": 2}, []string{"rule-d:failed", "rule-e:failed"})
+}
+
+func TestConverterOwnershipConflict(t *testing.T) {
+	f := func(existing *vmv1beta1.VMServiceScrape, wantStatus string) {
+		t.Helper()
+		src := &promv1.ServiceMonitor{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default", UID: "uid-1"}}
+		fclient := fake.NewClientBuilder().WithScheme(testutil.GetTestClientWithObjects(nil).Scheme()).WithObjects(existing).Build()
+		c := &ConverterController{
+			rclient:    fclient,
+			baseConf:   &config.BaseOperatorConf{EnabledPrometheusConverterOwnerReferences: true},
+			syncStatus: newConverterSyncStatus(),
+		}
+		c.UpdateServiceMonitor(nil, src)
+		c.syncStatus.mu.Lock()
+		got := c.syncStatus.records[converterSyncKey(promv1.ServiceMonitorsKind, "default", "example")]
+		c.syncStatus.mu.Unlock()
+		if got == nil || got.Status != wantStatus {
+			t.Fatalf("unexpected sync record: %+v, want status: %s", got, wantStatus)
+		}
+		var updated vmv1beta1.VMServiceScrape
+		if err := fclient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "example"}, &updated); err != nil {
+			t.Fatalf("cannot get VMServiceScrape: %s", err)
+		}
+		ref := metav1.GetControllerOf(&updated)
+		if wantStatus == converterSyncConflict {
+			if ref == nil || ref.Kind != existing.OwnerReferences[0].Kind {
+				t.Fatalf("object with conflicting owner must not be updated, got owner: %v", ref)
+			}
+			return
+		}
+		if ref == nil || ref.Kind != promv1.ServiceMonitorsKind || ref.UID != src.UID {
+			t.Fatalf("unexpected owner of converted object: %v", ref)
+		}
+	}
+	scrape := func(refs ...metav1.OwnerReference) *vmv1beta1.VMServiceScrape {
+		return &vmv1beta1.VMServiceScrape{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default", OwnerReferences: refs},
+		}
+	}
+
+	// object without controller
+	f(scrape(), converterSyncConverted)
+
+	// object converted from re-created ServiceMonitor
+	f(scrape(metav1.OwnerReference{Kind: promv1.ServiceMonitorsKind, Name: "example", UID: "uid-0", Controller: ptr.To(true)}), converterSyncConverted)
+
+	// object controlled by VMAgent
+	f(scrape(metav1.OwnerReference{Kind: "VMAgent", Name: "example", UID: "uid-2", Controller: ptr.To(true)}), converterSyncConflict)
+}
+
+func TestConverterSyncStatusIssuesLimit(t *testing.T) {
+	s := newConverterSyncStatus()
+	for i := 0; i < maxConverterStatusIssues+10; i++ {
+		s.record(promv1.ProbesKind, &promv1.Probe{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("probe-%05d", i), Namespace: "default"}}, converterSyncFailed, "cannot create VMProbe")
+	}
+	r, ok := s.report()
+	if !ok {
+		t.Fatalf("expected changed status report")
+	}
+	if len(r.Issues) != maxConverterStatusIssues || !r.IssuesTruncated {
+		t.Fatalf("unexpected issues: %d, truncated: %v", len(r.Issues), r.IssuesTruncated)
+	}
+	if r.Summary[promv1.ProbesKind][converterSyncFailed] != maxConverterStatusIssues+10 {
+		t.Fatalf("unexpected summary: %v", r.Summary)
+	}
+	if r.Issues[0].Name != "probe-00000" {
+		t.Fatalf("unexpected first issue: %s", r.Issues[0].Name)
+	}
+}
+
+func TestWriteConverterStatus(t *testing.T) {
+	f := func(predefinedObjects []runtime.Object) {
+		t.Helper()
+		fclient := testutil.GetTestClientWithObjects(predefinedObjects)
+		ctx := context.Background()
+		r := &converterStatusReport{Summary: map[string]map[string]int{promv1.PrometheusRuleKind: {converterSyncConverted: 2}}}
+		if err := writeConverterStatus(ctx, fclient, "monitoring", "converter-status", r); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var cm corev1.ConfigMap
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: "monitoring", Name: "converter-status"}, &cm); err != nil {
+			t.Fatalf("cannot get status ConfigMap: %s", err)
+		}
+		var got converterStatusReport
+		if err := json.Unmarshal([]byte(cm.Data[converterStatusKey]), &got); err != nil {
+			t.Fatalf("cannot parse status: %s", err)
+		}
+		if got.Summary[promv1.PrometheusRuleKind][converterSyncConverted] != 2 {
+			t.Fatalf("unexpected status: %s", cm.Data[converterStatusKey])
+		}
+	}
+
+	// create new ConfigMap
+	f(nil)
+
+	// update existing ConfigMap
+	f([]runtime.Object{&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "converter-status", Namespace: "monitoring"},
+		Data:       map[string]string{converterStatusKey: "{}"},
+	}})
+}
+
+func TestConverterSkipConversion(t *testing.T) {
+	// rclient is not set, converter must not access api server for skipped objects
+	c := &ConverterController{syncStatus: newConverterSyncStatus()}
+	meta := metav1.ObjectMeta{Name: "example", Namespace: "default", Annotations: map[string]string{SkipConversionLabel: IgnoreConversion}}
+	c.CreateServiceMonitor(&promv1.ServiceMonitor{ObjectMeta: meta})
+	c.UpdatePrometheusRule(nil, &promv1.PrometheusRule{ObjectMeta: meta})
+	c.CreatePodMonitor(&promv1.PodMonitor{ObjectMeta: meta})
+	c.UpdateProbe(nil, &promv1.Probe{ObjectMeta: meta})
+	r, ok := c.syncStatus.report()
+	if !ok {
+		t.Fatalf("expected changed status report")
+	}
+	if len(r.Issues) != 4 {
+		t.Fatalf("unexpected issues count: %d", len(r.Issues))
+	}
+	for _, is := range r.Issues {
+		if is.Status != converterSyncSkipped {
+			t.Fatalf("unexpected status for %s: %s", is.Kind, is.Status)
+		}
+	}
+}
@@ -6,9 +6,12 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -68,6 +71,11 @@ func CreateOrUpdateAlertManager(ctx context.Context, cr *vmv1beta1.VMAlertmanage
 			return err
 		}
 	}
+	if ptr.Deref(cr.Spec.SelfMonitoringRules, false) {
+		if err := reconcile.VMRuleForCRD(ctx, rclient, build.SelfMonitoringVMRule(service, service.Name, "vmalertmanager")); err != nil {
+			return fmt.Errorf("cannot create self-monitoring VMRule for vmalertmanager: %w", err)
+		}
+	}
 
 	if cr.Spec.PodDisruptionBudget != nil {
 		var prevPDB *policyv1.PodDisruptionBudget
@@ -78,6 +86,24 @@ func CreateOrUpdateAlertManager(ctx context.Context, cr *vmv1beta1.VMAlertmanage
 			return err
 		}
 	}
+	if cr.Spec.NetworkPolicy.IsEnabled() {
+		var prevNP *networkingv1.NetworkPolicy
+		if prevCR != nil && prevCR.Spec.NetworkPolicy.IsEnabled() {
+			prevNP = buildNetworkPolicy(prevCR)
+		}
+		if err := reconcile.NetworkPolicy(ctx, rclient, buildNetworkPolicy(cr), prevNP); err != nil {
+			return fmt.Errorf("cannot update network policy for vmalertmanager: %w", err)
+		}
+	}
+	if cr.Spec.VPA != nil {
+		var prevVPA *unstructured.Unstructured
+		if prevCR != nil && prevCR.Spec.VPA != nil {
+			prevVPA = buildVPA(prevCR)
+		}
+		if err := reconcile.VPA(ctx, rclient, buildVPA(cr), prevVPA); err != nil {
+			return fmt.Errorf("cannot update vertical pod autoscaler for vmalertmanager: %w", err)
+		}
+	}
 	var prevSts *appsv1.StatefulSet
 	if prevCR != nil {
 		var err error
@@ -106,6 +132,9 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAlertmanager,
 	if err := reconcile.AdditionalServices(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevSvc, currSvc); err != nil {
 		return fmt.Errorf("cannot remove additional service: %w", err)
 	}
+	if err := reconcile.AdditionalServiceList(ctx, rclient, cr.Namespace, cr.ParsedLastAppliedSpec.AdditionalServices, cr.Spec.AdditionalServices); err != nil {
+		return fmt.Errorf("cannot remove additional services: %w", err)
+	}
 
 	objMeta := metav1.ObjectMeta{Name: cr.PrefixedName(), Namespace: cr.Namespace}
 	if cr.Spec.PodDisruptionBudget == nil && cr.ParsedLastAppliedSpec.PodDisruptionBudget != nil {
@@ -113,11 +142,49 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAlertmanager,
 			return fmt.Errorf("cannot delete PDB from prev state: %w", err)
 		}
 	}
+	if !cr.Spec.NetworkPolicy.IsEnabled() && cr.ParsedLastAppliedSpec.NetworkPolicy.IsEnabled() {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &networkingv1.NetworkPolicy{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot delete NetworkPolicy from prev state: %w", err)
+		}
+	}
+	if cr.Spec.VPA == nil && cr.ParsedLastAppliedSpec.VPA != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, build.NewVPA(cr.PrefixedName(), cr.Namespace)); err != nil {
+			return fmt.Errorf("cannot delete VPA from prev state: %w", err)
+		}
+	}
 	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(cr.ParsedLastAppliedSpec.DisableSelfServiceScrape, false) {
 		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta}); err != nil {
 			return fmt.Errorf("cannot remove serviceScrape: %w", err)
 		}
 	}
+	if !ptr.Deref(cr.Spec.SelfMonitoringRules, false) && ptr.Deref(cr.ParsedLastAppliedSpec.SelfMonitoringRules, false) {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMRule{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot remove self-monitoring VMRule: %w", err)
+		}
+	}
 
 	return nil
 }
+
+// buildNetworkPolicy allows access to web port and gossip traffic between alertmanager replicas
+func buildNetworkPolicy(cr *vmv1beta1.VMAlertmanager) *networkingv1.NetworkPolicy {
+	gossipPorts := build.NetworkPolicyPorts(corev1.ProtocolTCP, "9094")
+	gossipPorts = append(gossipPorts, build.NetworkPolicyPorts(corev1.ProtocolUDP, "9094")...)
+	gossipRule := networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{
+			{PodSelector: &metav1.LabelSelector{MatchLabels: cr.SelectorLabels()}},
+		},
+		Ports: gossipPorts,
+	}
+	return build.NetworkPolicy(cr, cr.Spec.NetworkPolicy, build.NetworkPolicyPorts(corev1.ProtocolTCP, cr.Spec.Port), gossipRule)
+}
+
+// buildVPA creates VerticalPodAutoscaler for vmalertmanager StatefulSet
+func buildVPA(cr *vmv1beta1.VMAlertmanager) *unstructured.Unstructured {
+	targetRef := autoscalingv2.CrossVersionObjectReference{
+		Name:       cr.PrefixedName(),
+		Kind:       "StatefulSet",
+		APIVersion: "apps/v1",
+	}
+	return build.VPA(cr, targetRef, "alertmanager", cr.Spec.VPA)
+}
@@ -41,23 +41,9 @@ func buildConfig(ctx context.Context, rclient client.Client, alertmanagerCR *vmv
 		baseYAMlCfg.Route = &route{
 			Receiver: "blackhole",
 		}
-		var isBlackholeDefined bool
-		for _, recv := range baseYAMlCfg.Receivers {
-			var recvName string
-			for _, entry := range recv {
-				if entry.Key == "name" {
-					s, ok := entry.Value.(string)
-					if !ok {
-						return nil, fmt.Errorf("incorrect base configuration=%q, expected receiver name=%v to be a string", string(baseCfg), entry.Value)
-					}
-					recvName = s
-					break
-				}
-			}
-			if recvName == "blackhole" {
-				isBlackholeDefined = true
-				break
-			}
+		isBlackholeDefined, err := hasReceiver(baseYAMlCfg.Receivers, "blackhole")
+		if err != nil {
+			return nil, fmt.Errorf("incorrect base configuration=%q: %w", string(baseCfg), err)
 		}
 		// conditionally add blackhole as default route path
 		// alertmanager config must have some default route
@@ -118,7 +104,7 @@ OUTER:
 
 		baseYAMlCfg.Receivers = append(baseYAMlCfg.Receivers, receiverCfgs...)
 		for _, rule := range amcKey.Spec.InhibitRules {
-			baseYAMlCfg.InhibitRules = append(baseYAMlCfg.InhibitRules, buildInhibitRule(amcKey.Namespace, rule, !alertmanagerCR.Spec.DisableNamespaceMatcher))
+			baseYAMlCfg.InhibitRules = append(baseYAMlCfg.InhibitRules, buildInhibitRule(rule, alertmanagerCR.ConfigNamespaceMatcher(amcKey.Namespace)))
 		}
 		if len(mtis) > 0 {
 			timeIntervals = append(timeIntervals, mtis...)
@@ -130,7 +116,15 @@ OUTER:
 	amcfgs = amcfgs[:cnt]
 
 	if len(subRoutes) > 0 {
-		baseYAMlCfg.Route.Routes = append(baseYAMlCfg.Route.Routes, subRoutes...)
+		if pr := alertmanagerCR.Spec.ConfigParentRoute; pr != nil {
+			parentRoute, err := buildParentRoute(pr, baseYAMlCfg.Receivers, subRoutes)
+			if err != nil {
+				return nil, fmt.Errorf("incorrect configParentRoute: %w", err)
+			}
+			baseYAMlCfg.Route.Routes = append(baseYAMlCfg.Route.Routes, parentRoute)
+		} else {
+			baseYAMlCfg.Route.Routes = append(baseYAMlCfg.Route.Routes, subRoutes...)
+		}
 	}
 	if len(timeIntervals) > 0 {
 		baseYAMlCfg.TimeIntervals = append(baseYAMlCfg.TimeIntervals, timeIntervals...)
@@ -145,6 +139,48 @@ OUTER:
 	return &result, nil
 }
 
+// buildParentRoute builds sub-route of the root route with nested VMAlertmanagerConfig routes
+func buildParentRoute(pr *vmv1beta1.VMAlertmanagerConfigParentRoute, receivers []yaml.MapSlice, subRoutes []yaml.MapSlice) (yaml.MapSlice, error) {
+	r := yaml.MapSlice{
+		{Key: "routes", Value: subRoutes},
+	}
+	if len(pr.Matchers) > 0 {
+		r = append(r, yaml.MapItem{Key: "matchers", Value: pr.Matchers})
+	}
+	if pr.Receiver != "" {
+		ok, err := hasReceiver(receivers, pr.Receiver)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("receiver=%q is not defined at base configuration", pr.Receiver)
+		}
+		r = append(r, yaml.MapItem{Key: "receiver", Value: pr.Receiver})
+	}
+	r = append(r, yaml.MapItem{Key: "continue", Value: false})
+	return r, nil
+}
+
+// hasReceiver checks if receiver with the given name is defined at receivers
+func hasReceiver(receivers []yaml.MapSlice, name string) (bool, error) {
+	for _, recv := range receivers {
+		for _, entry := range recv {
+			if entry.Key != "name" {
+				continue
+			}
+			s, ok := entry.Value.(string)
+			if !ok {
+				return false, fmt.Errorf("expected receiver name=%v to be a string", entry.Value)
+			}
+			if s == name {
+				return true, nil
+			}
+			break
+		}
+	}
+	return false, nil
+}
+
 // addConfigTemplates adds external templates to the given based configuration
 func addConfigTemplates(baseCfg []byte, templates []string) ([]byte, error) {
 	if len(templates) == 0 {
@@ -235,8 +271,8 @@ func buildRoute(cr *vmv1beta1.VMAlertmanagerConfig, cfgRoute *vmv1beta1.Route, t
 		if !alertmanagerCR.Spec.DisableRouteContinueEnforce {
 			continueSetting = true
 		}
-		if !alertmanagerCR.Spec.DisableNamespaceMatcher {
-			matchers = append(matchers, fmt.Sprintf("namespace = %q", cr.Namespace))
+		if nsMatcher := alertmanagerCR.ConfigNamespaceMatcher(cr.Namespace); nsMatcher != "" {
+			matchers = append(matchers, nsMatcher)
 		}
 		if len(alertmanagerCR.Spec.EnforcedTopRouteMatchers) > 0 {
 			matchers = append(matchers, alertmanagerCR.Spec.EnforcedTopRouteMatchers...)
@@ -291,12 +327,11 @@ func buildRoute(cr *vmv1beta1.VMAlertmanagerConfig, cfgRoute *vmv1beta1.Route, t
 	return r, nil
 }
 
-func buildInhibitRule(namespace string, rule vmv1beta1.InhibitRule, mustAddNamespaceMatcher bool) yaml.MapSlice {
+func buildInhibitRule(rule vmv1beta1.InhibitRule, namespaceMatcher string) yaml.MapSlice {
 	var r yaml.MapSlice
-	if mustAddNamespaceMatcher {
-		namespaceMatch := fmt.Sprintf("namespace = %q", namespace)
-		rule.SourceMatchers = append(rule.SourceMatchers, namespaceMatch)
-		rule.TargetMatchers = append(rule.TargetMatchers, namespaceMatch)
+	if namespaceMatcher != "" {
+		rule.SourceMatchers = append(rule.SourceMatchers, namespaceMatcher)
+		rule.TargetMatchers = append(rule.TargetMatchers, namespaceMatcher)
 	}
 	toYaml := func(key string, src []string) {
 		if len(src) > 0 {
@@ -17,6 +17,7 @@ import (
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 )
 
 func TestBuildConfig(t *testing.T) {
@@ -926,7 +927,7 @@ templates: []
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			testClient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			testClient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			if tt.args.amCR == nil {
 				tt.args.amCR = &vmv1beta1.VMAlertmanager{}
 			}
@@ -943,6 +944,197 @@ templates: []
 	}
 }
 
+func TestBuildConfigWithParentRoute(t *testing.T) {
+	f := func(parentRoute *vmv1beta1.VMAlertmanagerConfigParentRoute, baseCfg, want string, wantErr bool) {
+		t.Helper()
+		amCR := &vmv1beta1.VMAlertmanager{
+			Spec: vmv1beta1.VMAlertmanagerSpec{
+				ConfigParentRoute: parentRoute,
+			},
+		}
+		amcfgs := []*vmv1beta1.VMAlertmanagerConfig{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "team",
+					Namespace: "default",
+				},
+				Spec: vmv1beta1.VMAlertmanagerConfigSpec{
+					Receivers: []vmv1beta1.Receiver{{Name: "webhook", WebhookConfigs: []vmv1beta1.WebhookConfig{{URL: ptr.To("http://webhook")}}}},
+					Route:     &vmv1beta1.Route{Receiver: "webhook"},
+				},
+			},
+		}
+		testClient := testutil.GetTestClientWithObjects(nil)
+		got, err := buildConfig(context.Background(), testClient, amCR, []byte(baseCfg), amcfgs, map[string]string{})
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if wantErr {
+			return
+		}
+		assert.Equal(t, want, string(got.data))
+	}
+	baseCfg := `route:
+  receiver: default
+  routes:
+  - matchers:
+    - team="legacy"
+    receiver: legacy
+receivers:
+- name: default
+- name: legacy
+`
+
+	// user defined routes take precedence over VMAlertmanagerConfig routes
+	f(&vmv1beta1.VMAlertmanagerConfigParentRoute{
+		Matchers: []string{`managed_by="vmalertmanagerconfig"`},
+		Receiver: "legacy",
+	}, baseCfg, `route:
+  receiver: default
+  routes:
+  - matchers:
+    - team="legacy"
+    receiver: legacy
+  - routes:
+    - matchers:
+      - namespace = "default"
+      receiver: default-team-webhook
+      continue: true
+    matchers:
+    - managed_by="vmalertmanagerconfig"
+    receiver: legacy
+    continue: false
+receivers:
+- name: default
+- name: legacy
+- name: default-team-webhook
+  webhook_configs:
+  - url: http://webhook
+templates: []
+`, false)
+
+	// parent route without matchers and receiver
+	f(&vmv1beta1.VMAlertmanagerConfigParentRoute{}, baseCfg, `route:
+  receiver: default
+  routes:
+  - matchers:
+    - team="legacy"
+    receiver: legacy
+  - routes:
+    - matchers:
+      - namespace = "default"
+      receiver: default-team-webhook
+      continue: true
+    continue: false
+receivers:
+- name: default
+- name: legacy
+- name: default-team-webhook
+  webhook_configs:
+  - url: http://webhook
+templates: []
+`, false)
+
+	// receiver is missing at base config
+	f(&vmv1beta1.VMAlertmanagerConfigParentRoute{Receiver: "missing"}, baseCfg, "", true)
+}
+
+func TestBuildConfigWithNamespaceMatcher(t *testing.T) {
+	f := func(nm *vmv1beta1.VMAlertmanagerNamespaceMatcher, disableNamespaceMatcher bool, want string) {
+		t.Helper()
+		amCR := &vmv1beta1.VMAlertmanager{
+			Spec: vmv1beta1.VMAlertmanagerSpec{
+				NamespaceMatcher:        nm,
+				DisableNamespaceMatcher: disableNamespaceMatcher,
+			},
+		}
+		amcfgs := []*vmv1beta1.VMAlertmanagerConfig{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "team",
+					Namespace: "default",
+				},
+				Spec: vmv1beta1.VMAlertmanagerConfigSpec{
+					Receivers:    []vmv1beta1.Receiver{{Name: "webhook", WebhookConfigs: []vmv1beta1.WebhookConfig{{URL: ptr.To("http://webhook")}}}},
+					Route:        &vmv1beta1.Route{Receiver: "webhook"},
+					InhibitRules: []vmv1beta1.InhibitRule{{SourceMatchers: []string{`severity="critical"`}, TargetMatchers: []string{`severity="warning"`}}},
+				},
+			},
+		}
+		testClient := testutil.GetTestClientWithObjects(nil)
+		got, err := buildConfig(context.Background(), testClient, amCR, []byte("route:\n  receiver: default\nreceivers:\n- name: default\n"), amcfgs, map[string]string{})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		assert.Equal(t, want, string(got.data))
+	}
+
+	// custom label
+	f(&vmv1beta1.VMAlertmanagerNamespaceMatcher{Policy: "custom", LabelName: "exported_namespace"}, false, `route:
+  receiver: default
+  routes:
+  - matchers:
+    - exported_namespace = "default"
+    receiver: default-team-webhook
+    continue: true
+inhibit_rules:
+- target_matchers:
+  - severity="warning"
+  - exported_namespace = "default"
+  source_matchers:
+  - severity="critical"
+  - exported_namespace = "default"
+receivers:
+- name: default
+- name: default-team-webhook
+  webhook_configs:
+  - url: http://webhook
+templates: []
+`)
+
+	// no matcher
+	f(&vmv1beta1.VMAlertmanagerNamespaceMatcher{Policy: "none"}, false, `route:
+  receiver: default
+  routes:
+  - receiver: default-team-webhook
+    continue: true
+inhibit_rules:
+- target_matchers:
+  - severity="warning"
+  source_matchers:
+  - severity="critical"
+receivers:
+- name: default
+- name: default-team-webhook
+  webhook_configs:
+  - url: http://webhook
+templates: []
+`)
+
+	// enforce policy has priority over disableNamespaceMatcher
+	f(&vmv1beta1.VMAlertmanagerNamespaceMatcher{Policy: "enforce"}, true, `route:
+  receiver: default
+  routes:
+  - matchers:
+    - namespace = "default"
+    receiver: default-team-webhook
+    continue: true
+inhibit_rules:
+- target_matchers:
+  - severity="warning"
+  - namespace = "default"
+  source_matchers:
+  - severity="critical"
+  - namespace = "default"
+receivers:
+- name: default
+- name: default-team-webhook
+  webhook_configs:
+  - url: http://webhook
+templates: []
+`)
+}
+
 func TestAddConfigTemplates(t *testing.T) {
 	type args struct {
 		config    []byte
@@ -1414,7 +1606,7 @@ authorization:
 			cb := &configBuilder{
 				TLSConfigBuilder: build.TLSConfigBuilder{
 					Ctx:                context.Background(),
-					Client:             k8stools.GetTestClientWithObjects(tt.args.predefinedObjects),
+					Client:             testutil.GetTestClientWithObjects(tt.args.predefinedObjects),
 					SecretCache:        tt.fields.secretCache,
 					ConfigmapCache:     tt.fields.configmapCache,
 					TLSAssets:          map[string]string{},
@@ -1515,7 +1707,7 @@ func Test_UpdateDefaultAMConfig(t *testing.T) {
 	assert.Nil(t, os.Setenv("WATCH_NAMESPACE", "default"))
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 
 			// Create secret with alert manager config
 			if err := CreateAMConfig(tt.args.ctx, tt.args.cr, fclient); (err != nil) != tt.wantErr {
@@ -1780,7 +1972,7 @@ tls_server_config:
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			tlsAssets := make(map[string]string)
 			cfg, err := buildWebServerConfigYAML(tt.args.ctx, fclient, &tt.args.vmaCR, tlsAssets)
 			if (err != nil) != tt.wantErr {
@@ -1845,7 +2037,7 @@ tls_client_config:
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			tlsAssets := make(map[string]string)
 			cfg, err := buildGossipConfigYAML(tt.args.ctx, fclient, &tt.args.vmaCR, tlsAssets)
 			if (err != nil) != tt.wantErr {
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -21,9 +22,11 @@ import (
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/configstat"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/tracing"
 )
 
 const (
@@ -72,6 +75,9 @@ func newStsForAlertManager(cr *vmv1beta1.VMAlertmanager) (*appsv1.StatefulSet, e
 	cr.Spec.Storage.IntoSTSVolume(cr.GetVolumeName(), &statefulset.Spec)
 	statefulset.Spec.Template.Spec.Volumes = append(statefulset.Spec.Template.Spec.Volumes, cr.Spec.Volumes...)
 
+	if err := build.PodTemplateAddPatches(&statefulset.Spec.Template, cr.Spec.PodTemplatePatches); err != nil {
+		return nil, err
+	}
 	return statefulset, nil
 }
 
@@ -99,6 +105,7 @@ func createOrUpdateAlertManagerService(ctx context.Context, rclient client.Clien
 		)
 	})
 	var prevService, prevAdditionalService *corev1.Service
+	var prevAdditionalServices []*corev1.Service
 	if prevCR != nil {
 		prevPort, err := strconv.ParseInt(prevCR.Port(), 10, 32)
 		if err != nil {
@@ -123,6 +130,7 @@ func createOrUpdateAlertManagerService(ctx context.Context, rclient client.Clien
 			)
 		})
 		prevAdditionalService = build.AdditionalServiceFromDefault(prevService, cr.Spec.ServiceSpec)
+		prevAdditionalServices = build.AdditionalServicesFromDefault(prevService, prevCR.Spec.AdditionalServices)
 	}
 
 	if err := cr.Spec.ServiceSpec.IsSomeAndThen(func(s *vmv1beta1.AdditionalServiceSpec) error {
@@ -137,6 +145,10 @@ func createOrUpdateAlertManagerService(ctx context.Context, rclient client.Clien
 	}); err != nil {
 		return nil, err
 	}
+	if err := reconcile.Services(ctx, rclient, build.AdditionalServicesFromDefault(newService, cr.Spec.AdditionalServices), prevAdditionalServices); err != nil {
+		return nil, fmt.Errorf("cannot reconcile additional services for vmalertmanager: %w", err)
+	}
+
 	if err := reconcile.Service(ctx, rclient, newService, prevService); err != nil {
 		return nil, fmt.Errorf("cannot reconcile service for vmalertmanager: %w", err)
 	}
@@ -379,7 +391,7 @@ func makeStatefulSetSpec(cr *vmv1beta1.VMAlertmanager) (*appsv1.StatefulSetSpec,
 	useStrictSecurity := ptr.Deref(cr.Spec.UseStrictSecurity, false)
 
 	initContainers = append(initContainers, buildInitConfigContainer(cr)...)
-	build.AddStrictSecuritySettingsToContainers(cr.Spec.SecurityContext, initContainers, useStrictSecurity)
+	build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.SecurityContext, initContainers, useStrictSecurity)
 
 	ic, err := k8stools.MergePatchContainers(initContainers, cr.Spec.InitContainers)
 	if err != nil {
@@ -395,17 +407,19 @@ func makeStatefulSetSpec(cr *vmv1beta1.VMAlertmanager) (*appsv1.StatefulSetSpec,
 		VolumeMounts:             amVolumeMounts,
 		Resources:                cr.Spec.Resources,
 		Env:                      envs,
+		Lifecycle:                cr.Spec.Lifecycle,
 		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 	}
 	vmaContainer = build.Probe(vmaContainer, cr)
 	operatorContainers := []corev1.Container{vmaContainer}
 	operatorContainers = append(operatorContainers, buildVMAlertmanagerConfigReloader(cr, crVolumeMounts))
 
-	build.AddStrictSecuritySettingsToContainers(cr.Spec.SecurityContext, operatorContainers, useStrictSecurity)
+	build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.SecurityContext, operatorContainers, useStrictSecurity)
 	containers, err := k8stools.MergePatchContainers(operatorContainers, cr.Spec.Containers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge containers spec: %w", err)
 	}
+	ic, containers = build.AddSidecars(ic, containers, cr.Spec.Sidecars)
 
 	for i := range cr.Spec.TopologySpreadConstraints {
 		if cr.Spec.TopologySpreadConstraints[i].LabelSelector == nil {
@@ -422,7 +436,8 @@ func makeStatefulSetSpec(cr *vmv1beta1.VMAlertmanager) (*appsv1.StatefulSetSpec,
 		Selector: &metav1.LabelSelector{
 			MatchLabels: cr.SelectorLabels(),
 		},
-		VolumeClaimTemplates: cr.Spec.ClaimTemplates,
+		VolumeClaimTemplates:                 cr.Spec.ClaimTemplates,
+		PersistentVolumeClaimRetentionPolicy: cr.PVCRetentionPolicy(),
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
 				Labels:      cr.PodLabels(),
@@ -641,7 +656,10 @@ func getSecretContentForAlertmanager(ctx context.Context, rclient client.Client,
 	return nil, fmt.Errorf("cannot find alertmanager config key: %q at secret: %q", alertmanagerSecretConfigKey, secretName)
 }
 
-func buildAlertmanagerConfigWithCRDs(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlertmanager, originConfig []byte, l logr.Logger, tlsAssets map[string]string) ([]byte, error) {
+func buildAlertmanagerConfigWithCRDs(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlertmanager, originConfig []byte, l logr.Logger, tlsAssets map[string]string) (_ []byte, resultErr error) {
+	ctx, span := tracing.StartForObject(ctx, "vmalertmanager.generateConfig", cr)
+	defer func() { tracing.End(span, resultErr) }()
+	startTime := time.Now()
 	var amCfgs []*vmv1beta1.VMAlertmanagerConfig
 	var badCfgs []*vmv1beta1.VMAlertmanagerConfig
 	if err := k8stools.VisitObjectsForSelectorsAtNs(ctx, rclient, cr.Spec.ConfigNamespaceSelector, cr.Spec.ConfigSelector, cr.Namespace, cr.Spec.SelectAllByDefault,
@@ -683,6 +701,8 @@ func buildAlertmanagerConfigWithCRDs(ctx context.Context, rclient client.Client,
 		return nil, fmt.Errorf("failed to update broken vmalertmanagerConfigs statuses: %w", err)
 	}
 	badConfigsTotal.Add(float64(len(badCfgs)))
+	configstat.ObserveGeneration(ctx, "vmalertmanager", cr, startTime, len(parsedCfg.data))
+	configstat.SetSelectedObjects("vmalertmanager", cr, "VMAlertmanagerConfig", len(parsedCfg.amcfgs))
 	return parsedCfg.data, nil
 }
 
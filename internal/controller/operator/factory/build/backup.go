@@ -3,25 +3,68 @@ package build
 import (
 	"context"
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const vmBackuperCreds = "/etc/vm/creds"
 
+// ApplyVMBackupLocation fills backup settings with values from referenced VMBackupLocation
+// values defined at VMBackup have higher priority
+// credentialsSecret of location is skipped if cloud identity is used for the ServiceAccount
+func ApplyVMBackupLocation(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMBackup, namespace string, cloudIdentity *vmv1beta1.CloudIdentity) error {
+	if cr == nil || cr.BackupLocationName == "" {
+		return nil
+	}
+	var location vmv1beta1.VMBackupLocation
+	nsn := types.NamespacedName{Namespace: namespace, Name: cr.BackupLocationName}
+	if err := rclient.Get(ctx, nsn, &location); err != nil {
+		return fmt.Errorf("cannot get VMBackupLocation=%q: %w", nsn, err)
+	}
+	switch {
+	case cr.Destination == "":
+		cr.Destination = location.Spec.Destination
+	case !strings.Contains(cr.Destination, "://"):
+		cr.Destination = strings.TrimSuffix(location.Spec.Destination, "/") + "/" + strings.TrimPrefix(cr.Destination, "/")
+	}
+	if cr.CustomS3Endpoint == nil {
+		cr.CustomS3Endpoint = location.Spec.CustomS3Endpoint
+	}
+	if cr.CredentialsSecret == nil && !cloudIdentity.IsEnabled() {
+		cr.CredentialsSecret = location.Spec.CredentialsSecret
+	}
+	if len(location.Spec.ExtraArgs) > 0 {
+		extraArgs := make(map[string]string, len(location.Spec.ExtraArgs)+len(cr.ExtraArgs))
+		for k, v := range location.Spec.ExtraArgs {
+			extraArgs[k] = v
+		}
+		for k, v := range cr.ExtraArgs {
+			extraArgs[k] = v
+		}
+		cr.ExtraArgs = extraArgs
+	}
+	return nil
+}
+
 // VMBackupManager conditionally creates vmbackupmanager container
 func VMBackupManager(
 	ctx context.Context,
 	cr *vmv1beta1.VMBackup,
 	port string,
 	storagePath, dataVolumeName string,
-	extraArgs map[string]string,
+	app *vmv1beta1.CommonApplicationDeploymentParams,
 	isCluster bool,
 	license *vmv1beta1.License,
 ) (*corev1.Container, error) {
@@ -30,6 +73,8 @@ func VMBackupManager(
 			" Follow https://docs.victoriametrics.com/enterprise.html for further instructions.")
 		return nil, nil
 	}
+	// snapshot urls must use https, if application http server has tls enabled
+	extraArgs := app.ExtraArgsWithServerTLS()
 	snapshotCreateURL := cr.SnapshotCreateURL
 	snapshotDeleteURL := cr.SnapShotDeleteURL
 	if snapshotCreateURL == "" {
@@ -103,6 +148,12 @@ func VMBackupManager(
 		args = append(args, fmt.Sprintf("-credsFilePath=%s/%s", vmBackuperCreds, cr.CredentialsSecret.Key))
 	}
 
+	if app.ServerTLS != nil {
+		// volume is added by application container
+		_, mounts = app.ServerTLS.MaybeAddToVolumes(nil, mounts, vmv1beta1.ServerTLSDir)
+		args = append(args, fmt.Sprintf("-snapshot.tlsCAFile=%s", path.Join(vmv1beta1.ServerTLSDir, vmv1beta1.ServerTLSCAKey)))
+	}
+
 	_, mounts = license.MaybeAddToVolumes(nil, mounts, vmv1beta1.SecretsDir)
 	args = license.MaybeAddToArgs(args, vmv1beta1.SecretsDir)
 
@@ -235,3 +286,173 @@ func VMRestore(
 	}
 	return vmRestore, nil
 }
+
+const vmRestoreCreds = "/etc/vm/restore-creds"
+
+// IsVMRestoreFromNeeded checks if restore from the backup source wasn't completed yet
+func IsVMRestoreFromNeeded(cr *vmv1beta1.VMRestoreFrom, status *vmv1beta1.VMRestoreFromStatus) bool {
+	if cr == nil {
+		return false
+	}
+	return status == nil || status.Source != cr.Source || status.Status != vmv1beta1.RestoreStatusCompleted
+}
+
+// VMRestoreFrom creates vmrestore init container and volumes required by it
+func VMRestoreFrom(
+	cr *vmv1beta1.VMRestoreFrom,
+	storagePath, dataVolumeName string,
+	isCluster bool,
+) (*corev1.Container, []corev1.Volume) {
+	src := cr.Source
+	// add suffix with pod name for cluster restore
+	// it matches backup destination created by vmbackupmanager
+	if isCluster && !cr.SourceDisableSuffixAdd {
+		src = strings.TrimSuffix(src, "/") + "/$(POD_NAME)/"
+	}
+	args := []string{
+		fmt.Sprintf("-storageDataPath=%s", storagePath),
+		fmt.Sprintf("-src=%s", src),
+	}
+	for arg, value := range cr.ExtraArgs {
+		args = append(args, fmt.Sprintf("-%s=%s", arg, value))
+	}
+	if cr.Concurrency != nil {
+		args = append(args, fmt.Sprintf("-concurrency=%d", *cr.Concurrency))
+	}
+	if cr.CustomS3Endpoint != nil {
+		args = append(args, fmt.Sprintf("-customS3Endpoint=%s", *cr.CustomS3Endpoint))
+	}
+
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      dataVolumeName,
+			MountPath: storagePath,
+		},
+	}
+	var volumes []corev1.Volume
+	if cr.CredentialsSecret != nil {
+		volumeName := k8stools.SanitizeVolumeName("restore-secret-" + cr.CredentialsSecret.Name)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: cr.CredentialsSecret.Name,
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: vmRestoreCreds,
+			ReadOnly:  true,
+		})
+		args = append(args, fmt.Sprintf("-credsFilePath=%s/%s", vmRestoreCreds, cr.CredentialsSecret.Key))
+	}
+	extraEnvs := cr.ExtraEnvs
+	if len(cr.ExtraEnvs) > 0 {
+		args = append(args, "-envflag.enable=true")
+	}
+	if isCluster {
+		extraEnvs = append(extraEnvs, corev1.EnvVar{
+			Name: "POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.name",
+				},
+			},
+		})
+	}
+	sort.Strings(args)
+
+	vmRestore := &corev1.Container{
+		Name:                     "vmrestore",
+		Image:                    fmt.Sprintf("%s:%s", cr.Image.Repository, cr.Image.Tag),
+		ImagePullPolicy:          cr.Image.PullPolicy,
+		Args:                     args,
+		Env:                      extraEnvs,
+		VolumeMounts:             mounts,
+		Resources:                cr.Resources,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	return vmRestore, volumes
+}
+
+// IsVMBackupVerificationEnabled checks if backup verification must be created for the given backup settings
+func IsVMBackupVerificationEnabled(cr *vmv1beta1.VMBackup, license *vmv1beta1.License) bool {
+	if cr == nil || cr.Verification == nil {
+		return false
+	}
+	// vmbackupmanager isn't added without license
+	return cr.AcceptEULA || license.IsProvided()
+}
+
+// VMBackupVerificationCronJob creates CronJob, which periodically lists backups with vmbackupmanager API
+// each of apiURLs is verified by a dedicated container, so job fails if any of vmbackupmanager cannot list backups
+func VMBackupVerificationCronJob(
+	cr *vmv1beta1.VMBackup,
+	objMeta metav1.ObjectMeta,
+	apiURLs []string,
+	license *vmv1beta1.License,
+	useStrictSecurity bool,
+) *batchv1.CronJob {
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+	volumes, mounts = license.MaybeAddToVolumes(volumes, mounts, vmv1beta1.SecretsDir)
+	containers := make([]corev1.Container, 0, len(apiURLs))
+	for i, apiURL := range apiURLs {
+		args := []string{
+			"backup",
+			"list",
+			fmt.Sprintf("-apiURL=%s", apiURL),
+			"-eula",
+		}
+		args = license.MaybeAddToArgs(args, vmv1beta1.SecretsDir)
+		containers = append(containers, corev1.Container{
+			Name:                     fmt.Sprintf("verify-%d", i),
+			Image:                    fmt.Sprintf("%s:%s", cr.Image.Repository, cr.Image.Tag),
+			ImagePullPolicy:          cr.Image.PullPolicy,
+			Args:                     args,
+			VolumeMounts:             mounts,
+			Resources:                cr.Verification.Resources,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		})
+	}
+	AddStrictSecuritySettingsToContainers(objMeta.Namespace, nil, containers, useStrictSecurity)
+
+	// job pods must not match selector of the storage service
+	podLabels := make(map[string]string, len(objMeta.Labels)+1)
+	for k, v := range objMeta.Labels {
+		podLabels[k] = v
+	}
+	podLabels["app.kubernetes.io/component"] = "backup-verification"
+
+	return &batchv1.CronJob{
+		ObjectMeta: objMeta,
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   cr.Verification.Schedule,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: ptr.To[int32](1),
+			FailedJobsHistoryLimit:     ptr.To[int32](1),
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: podLabels,
+				},
+				Spec: batchv1.JobSpec{
+					BackoffLimit:          ptr.To[int32](0),
+					ActiveDeadlineSeconds: cr.Verification.ActiveDeadlineSeconds,
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: podLabels,
+						},
+						Spec: corev1.PodSpec{
+							RestartPolicy:    corev1.RestartPolicyNever,
+							Containers:       containers,
+							Volumes:          volumes,
+							SecurityContext:  AddStrictSecuritySettingsToPod(objMeta.Namespace, nil, useStrictSecurity),
+							ImagePullSecrets: ImagePullSecrets(objMeta.Namespace),
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,194 @@
+package build
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+)
+
+func TestIsVMRestoreFromNeeded(t *testing.T) {
+	f := func(cr *vmv1beta1.VMRestoreFrom, status *vmv1beta1.VMRestoreFromStatus, want bool) {
+		t.Helper()
+		if got := IsVMRestoreFromNeeded(cr, status); got != want {
+			t.Fatalf("unexpected result, got: %v, want: %v", got, want)
+		}
+	}
+	f(nil, nil, false)
+	f(&vmv1beta1.VMRestoreFrom{Source: "s3://bucket/path"}, nil, true)
+	f(&vmv1beta1.VMRestoreFrom{Source: "s3://bucket/path"},
+		&vmv1beta1.VMRestoreFromStatus{Source: "s3://bucket/path", Status: vmv1beta1.RestoreStatusInProgress}, true)
+	f(&vmv1beta1.VMRestoreFrom{Source: "s3://bucket/path"},
+		&vmv1beta1.VMRestoreFromStatus{Source: "s3://bucket/path", Status: vmv1beta1.RestoreStatusCompleted}, false)
+	f(&vmv1beta1.VMRestoreFrom{Source: "s3://bucket/new-path"},
+		&vmv1beta1.VMRestoreFromStatus{Source: "s3://bucket/path", Status: vmv1beta1.RestoreStatusCompleted}, true)
+}
+
+func TestVMRestoreFrom(t *testing.T) {
+	f := func(cr *vmv1beta1.VMRestoreFrom, isCluster bool, wantArgs []string, wantVolumes int, wantEnvs int) {
+		t.Helper()
+		container, volumes := VMRestoreFrom(cr, "/storage", "data", isCluster)
+		if !reflect.DeepEqual(container.Args, wantArgs) {
+			t.Fatalf("unexpected args, got: %v, want: %v", container.Args, wantArgs)
+		}
+		if len(volumes) != wantVolumes {
+			t.Fatalf("unexpected volumes count, got: %d, want: %d", len(volumes), wantVolumes)
+		}
+		if len(container.Env) != wantEnvs {
+			t.Fatalf("unexpected envs count, got: %d, want: %d", len(container.Env), wantEnvs)
+		}
+	}
+	// single
+	f(&vmv1beta1.VMRestoreFrom{
+		Source: "gs://bucket/path",
+		Image:  vmv1beta1.Image{Repository: "victoriametrics/vmrestore", Tag: "v1.109.0"},
+	}, false, []string{"-src=gs://bucket/path", "-storageDataPath=/storage"}, 0, 0)
+
+	// cluster with credentials
+	f(&vmv1beta1.VMRestoreFrom{
+		Source:           "s3://bucket/path/",
+		CustomS3Endpoint: ptr.To("http://minio:9000"),
+		Concurrency:      ptr.To[int32](5),
+		CredentialsSecret: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "s3-creds"},
+			Key:                  "creds",
+		},
+	}, true, []string{
+		"-concurrency=5",
+		"-credsFilePath=/etc/vm/restore-creds/creds",
+		"-customS3Endpoint=http://minio:9000",
+		"-src=s3://bucket/path/$(POD_NAME)/",
+		"-storageDataPath=/storage",
+	}, 1, 1)
+
+	// cluster without suffix
+	f(&vmv1beta1.VMRestoreFrom{
+		Source:                 "s3://bucket/path",
+		SourceDisableSuffixAdd: true,
+	}, true, []string{"-src=s3://bucket/path", "-storageDataPath=/storage"}, 0, 1)
+}
+
+func TestApplyVMBackupLocation(t *testing.T) {
+	location := &vmv1beta1.VMBackupLocation{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3", Namespace: "default"},
+		Spec: vmv1beta1.VMBackupLocationSpec{
+			Destination:      "s3://bucket/backups/",
+			CustomS3Endpoint: ptr.To("http://minio:9000"),
+			CredentialsSecret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "s3-creds"},
+				Key:                  "creds",
+			},
+			ExtraArgs: map[string]string{"s3StorageClass": "STANDARD_IA", "concurrency": "5"},
+		},
+	}
+	f := func(cr, want *vmv1beta1.VMBackup, cloudIdentity *vmv1beta1.CloudIdentity, wantErr bool) {
+		t.Helper()
+		fclient := testutil.GetTestClientWithObjects([]runtime.Object{location})
+		err := ApplyVMBackupLocation(context.Background(), fclient, cr, "default", cloudIdentity)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if !reflect.DeepEqual(cr, want) {
+			t.Fatalf("unexpected backup spec\ngot:  %#v\nwant: %#v", cr, want)
+		}
+	}
+	// without location
+	f(&vmv1beta1.VMBackup{Destination: "s3://other"}, &vmv1beta1.VMBackup{Destination: "s3://other"}, nil, false)
+
+	// destination from location
+	f(&vmv1beta1.VMBackup{BackupLocationName: "s3"}, &vmv1beta1.VMBackup{
+		BackupLocationName: "s3",
+		Destination:        "s3://bucket/backups/",
+		CustomS3Endpoint:   location.Spec.CustomS3Endpoint,
+		CredentialsSecret:  location.Spec.CredentialsSecret,
+		ExtraArgs:          map[string]string{"s3StorageClass": "STANDARD_IA", "concurrency": "5"},
+	}, nil, false)
+
+	// cloud identity skips location credentials
+	f(&vmv1beta1.VMBackup{BackupLocationName: "s3"}, &vmv1beta1.VMBackup{
+		BackupLocationName: "s3",
+		Destination:        "s3://bucket/backups/",
+		CustomS3Endpoint:   location.Spec.CustomS3Endpoint,
+		ExtraArgs:          map[string]string{"s3StorageClass": "STANDARD_IA", "concurrency": "5"},
+	}, &vmv1beta1.CloudIdentity{AWSRoleARN: "arn:aws:iam::123456789012:role/vmbackup"}, false)
+
+	// relative destination and overrides
+	f(&vmv1beta1.VMBackup{
+		BackupLocationName: "s3",
+		Destination:        "vmsingle-main",
+		ExtraArgs:          map[string]string{"concurrency": "2"},
+		CredentialsSecret: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "own-creds"},
+			Key:                  "creds",
+		},
+	}, &vmv1beta1.VMBackup{
+		BackupLocationName: "s3",
+		Destination:        "s3://bucket/backups/vmsingle-main",
+		CustomS3Endpoint:   location.Spec.CustomS3Endpoint,
+		CredentialsSecret: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "own-creds"},
+			Key:                  "creds",
+		},
+		ExtraArgs: map[string]string{"s3StorageClass": "STANDARD_IA", "concurrency": "2"},
+	}, nil, false)
+
+	// missing location
+	f(&vmv1beta1.VMBackup{BackupLocationName: "missing"}, &vmv1beta1.VMBackup{BackupLocationName: "missing"}, nil, true)
+}
+
+func TestVMBackupVerificationCronJob(t *testing.T) {
+	f := func(cr *vmv1beta1.VMBackup, apiURLs []string, license *vmv1beta1.License, wantArgs [][]string, wantVolumes int) {
+		t.Helper()
+		objMeta := metav1.ObjectMeta{
+			Name:      "verify",
+			Namespace: "default",
+			Labels:    map[string]string{"app.kubernetes.io/name": "vmstorage", "app.kubernetes.io/component": "monitoring"},
+		}
+		cj := VMBackupVerificationCronJob(cr, objMeta, apiURLs, license, false)
+		if cj.Spec.Schedule != cr.Verification.Schedule {
+			t.Fatalf("unexpected schedule, got: %q, want: %q", cj.Spec.Schedule, cr.Verification.Schedule)
+		}
+		podSpec := cj.Spec.JobTemplate.Spec.Template
+		if podSpec.Labels["app.kubernetes.io/component"] != "backup-verification" {
+			t.Fatalf("unexpected pod labels: %v", podSpec.Labels)
+		}
+		var gotArgs [][]string
+		for _, c := range podSpec.Spec.Containers {
+			gotArgs = append(gotArgs, c.Args)
+		}
+		if !reflect.DeepEqual(gotArgs, wantArgs) {
+			t.Fatalf("unexpected args\ngot:  %v\nwant: %v", gotArgs, wantArgs)
+		}
+		if len(podSpec.Spec.Volumes) != wantVolumes {
+			t.Fatalf("unexpected volumes count, got: %d, want: %d", len(podSpec.Spec.Volumes), wantVolumes)
+		}
+	}
+	cr := &vmv1beta1.VMBackup{
+		AcceptEULA:   true,
+		Image:        vmv1beta1.Image{Repository: "victoriametrics/vmbackupmanager", Tag: "v1.109.0-enterprise"},
+		Verification: &vmv1beta1.VMBackupVerification{Schedule: "0 */6 * * *"},
+	}
+
+	// single node
+	f(cr, []string{"http://vmsingle-main.default.svc:8300"}, nil, [][]string{
+		{"backup", "list", "-apiURL=http://vmsingle-main.default.svc:8300", "-eula"},
+	}, 0)
+
+	// cluster with license
+	f(cr, []string{"http://vmstorage-main-0.vmstorage-main.default:8300", "http://vmstorage-main-1.vmstorage-main.default:8300"}, &vmv1beta1.License{
+		KeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "license"},
+			Key:                  "key",
+		},
+	}, [][]string{
+		{"backup", "list", "-apiURL=http://vmstorage-main-0.vmstorage-main.default:8300", "-eula", "-licenseFile=/etc/vm/secrets/license/key"},
+		{"backup", "list", "-apiURL=http://vmstorage-main-1.vmstorage-main.default:8300", "-eula", "-licenseFile=/etc/vm/secrets/license/key"},
+	}, 1)
+}
@@ -7,6 +7,7 @@ import (
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -65,6 +66,9 @@ func (cb *TLSConfigBuilder) BuildTLSConfig(tlsCfg *vmv1beta1.TLSConfig, tlsAsset
 	if tlsCfg.ServerName != "" {
 		result["server_name"] = tlsCfg.ServerName
 	}
+	if tlsCfg.MinVersion != "" {
+		result["min_version"] = tlsCfg.MinVersion
+	}
 	if tlsCfg.InsecureSkipVerify {
 		result["insecure_skip_verify"] = tlsCfg.InsecureSkipVerify
 	}
@@ -76,9 +80,15 @@ func (cb *TLSConfigBuilder) fetchSecretWithAssets(ss *corev1.SecretKeySelector,
 	if ss != nil {
 		var s corev1.Secret
 		if v, ok := cb.SecretCache[ss.Name]; ok {
+			if v == nil {
+				return fmt.Errorf("cannot fetch secret=%q for tlsAsset, err=%w", ss.Name, k8serrors.NewNotFound(corev1.Resource("secrets"), ss.Name))
+			}
 			s = *v
 		} else {
 			if err := cb.Client.Get(cb.Ctx, types.NamespacedName{Namespace: cb.CurrentCRNamespace, Name: ss.Name}, &s); err != nil {
+				if k8serrors.IsNotFound(err) {
+					cb.SecretCache[ss.Name] = nil
+				}
 				return fmt.Errorf("cannot fetch secret=%q for tlsAsset, err=%w", ss.Name, err)
 			}
 			cb.SecretCache[ss.Name] = &s
@@ -88,11 +98,18 @@ func (cb *TLSConfigBuilder) fetchSecretWithAssets(ss *corev1.SecretKeySelector,
 	if cs != nil {
 		var c corev1.ConfigMap
 		if v, ok := cb.ConfigmapCache[cs.Name]; ok {
+			if v == nil {
+				return fmt.Errorf("cannot fetch configmap=%q for tlsAssert, err=%w", cs.Name, k8serrors.NewNotFound(corev1.Resource("configmaps"), cs.Name))
+			}
 			c = *v
 		} else {
 			if err := cb.Client.Get(cb.Ctx, types.NamespacedName{Namespace: cb.CurrentCRNamespace, Name: cs.Name}, &c); err != nil {
+				if k8serrors.IsNotFound(err) {
+					cb.ConfigmapCache[cs.Name] = nil
+				}
 				return fmt.Errorf("cannot fetch configmap=%q for tlsAssert, err=%w", cs.Name, err)
 			}
+			cb.ConfigmapCache[cs.Name] = &c
 		}
 		value = c.Data[cs.Key]
 	}
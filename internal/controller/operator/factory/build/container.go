@@ -2,13 +2,16 @@ package build
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 )
 
 const probeTimeoutSeconds int32 = 5
@@ -196,6 +199,28 @@ func AddExtraArgsOverrideDefaults(args []string, extraArgs map[string]string, da
 	return args
 }
 
+// formatImage returns container image with registry from operator configuration
+func formatImage(c *config.BaseOperatorConf, containerImage string) string {
+	if c.ContainerRegistryOverride && c.ContainerRegistry != "" {
+		containerImage = trimImageRegistry(containerImage)
+	}
+	return formatContainerImage(c.ContainerRegistry, containerImage)
+}
+
+// trimImageRegistry removes registry host from the given image
+// first image path component is a registry host if it contains dot, port or it's localhost
+func trimImageRegistry(containerImage string) string {
+	idx := strings.IndexByte(containerImage, '/')
+	if idx <= 0 {
+		return containerImage
+	}
+	host := containerImage[:idx]
+	if !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return containerImage
+	}
+	return containerImage[idx+1:]
+}
+
 // formatContainerImage returns container image with registry prefix if needed.
 func formatContainerImage(globalRepo string, containerImage string) string {
 	if globalRepo == "" {
@@ -291,6 +316,68 @@ func AppendArgsForInsertPorts(args []string, ip *vmv1beta1.InsertPorts) []string
 	return args
 }
 
+// AppendArgsForOTLP conditionally appends OpenTelemetry ingestion flags to the given args
+func AppendArgsForOTLP(args []string, otlp *vmv1beta1.OTLPIngestion) []string {
+	if otlp == nil {
+		return args
+	}
+	if otlp.MaxRequestSize != "" {
+		args = append(args, fmt.Sprintf("--opentelemetry.maxRequestSize=%s", otlp.MaxRequestSize))
+	}
+	if otlp.UsePrometheusNaming {
+		args = append(args, "--opentelemetry.usePrometheusNaming=true")
+	}
+	return args
+}
+
+// AppendArgsForDatadog conditionally appends DataDog ingestion flags to the given args
+func AppendArgsForDatadog(args []string, dd *vmv1beta1.DatadogIngestion) []string {
+	if dd == nil {
+		return args
+	}
+	if dd.MaxRequestSize != "" {
+		args = append(args, fmt.Sprintf("-datadog.maxInsertRequestSize=%s", dd.MaxRequestSize))
+	}
+	if dd.SanitizeMetricName != nil {
+		args = append(args, fmt.Sprintf("-datadog.sanitizeMetricName=%t", *dd.SanitizeMetricName))
+	}
+	return args
+}
+
+// AppendArgsForNewRelic conditionally appends NewRelic ingestion flags to the given args
+func AppendArgsForNewRelic(args []string, nr *vmv1beta1.NewRelicIngestion) []string {
+	if nr == nil {
+		return args
+	}
+	if nr.MaxRequestSize != "" {
+		args = append(args, fmt.Sprintf("-newrelic.maxInsertRequestSize=%s", nr.MaxRequestSize))
+	}
+	return args
+}
+
+// AppendArgsForQueryLogging conditionally appends query logging and limits flags to the given args
+func AppendArgsForQueryLogging(args []string, ql *vmv1beta1.QueryLogging) []string {
+	if ql == nil {
+		return args
+	}
+	if ql.LogSlowQueryDuration != "" {
+		args = append(args, fmt.Sprintf("-search.logSlowQueryDuration=%s", ql.LogSlowQueryDuration))
+	}
+	if ql.LogQueryMemoryUsage != "" {
+		args = append(args, fmt.Sprintf("-search.logQueryMemoryUsage=%s", ql.LogQueryMemoryUsage))
+	}
+	if ql.MaxQueryDuration != "" {
+		args = append(args, fmt.Sprintf("-search.maxQueryDuration=%s", ql.MaxQueryDuration))
+	}
+	if ql.MaxQueueDuration != "" {
+		args = append(args, fmt.Sprintf("-search.maxQueueDuration=%s", ql.MaxQueueDuration))
+	}
+	if ql.MaxConcurrentRequests != nil {
+		args = append(args, fmt.Sprintf("-search.maxConcurrentRequests=%d", *ql.MaxConcurrentRequests))
+	}
+	return args
+}
+
 var (
 	configReloaderDefaultPort    = 8435
 	configReloaderContainerProbe = corev1.ProbeHandler{
@@ -329,3 +416,29 @@ func AddsPortProbesToConfigReloaderContainer(useCustomConfigReloader bool, crCon
 		ProbeHandler:        configReloaderContainerProbe,
 	}
 }
+
+// AddSidecars adds user defined sidecars to the given pod containers and returns updated init containers and containers
+// Since kubernetes 1.29 sidecars are appended to the init containers with restartPolicy Always,
+// so they start after operator and user defined init containers and before application containers.
+// For older versions sidecars are appended to the containers.
+func AddSidecars(initContainers, containers, sidecars []corev1.Container) ([]corev1.Container, []corev1.Container) {
+	if len(sidecars) == 0 {
+		return initContainers, containers
+	}
+	if k8stools.IsNativeSidecarSupported() {
+		ic := slices.Clone(initContainers)
+		for _, sidecar := range sidecars {
+			sidecar = *sidecar.DeepCopy()
+			sidecar.RestartPolicy = ptr.To(corev1.ContainerRestartPolicyAlways)
+			ic = append(ic, sidecar)
+		}
+		return ic, containers
+	}
+	dst := slices.Clone(containers)
+	for _, sidecar := range sidecars {
+		sidecar = *sidecar.DeepCopy()
+		sidecar.RestartPolicy = nil
+		dst = append(dst, sidecar)
+	}
+	return initContainers, dst
+}
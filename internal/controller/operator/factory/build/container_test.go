@@ -5,8 +5,12 @@ import (
 	"testing"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/utils/ptr"
 )
 
 type testBuildProbeCR struct {
@@ -274,3 +278,133 @@ func TestFormatContainerImage(t *testing.T) {
 	// correct behaviour, user must fix image naming
 	f("private.github.io", "my-private.registry/victoria-metrics/storage", "private.github.io/my-private.registry/victoria-metrics/storage")
 }
+
+func TestFormatImage(t *testing.T) {
+	f := func(registry string, override bool, image, wantImage string) {
+		t.Helper()
+		c := &config.BaseOperatorConf{
+			ContainerRegistry:         registry,
+			ContainerRegistryOverride: override,
+		}
+		gotImage := formatImage(c, image)
+		if gotImage != wantImage {
+			t.Errorf("unexpected container image, got: \n%s\nwant: \n%s", gotImage, wantImage)
+		}
+	}
+	f("", true, "docker.io/victoriametrics/vmagent", "docker.io/victoriametrics/vmagent")
+	f("mirror.example.com", false, "docker.io/victoriametrics/vmagent", "mirror.example.com/docker.io/victoriametrics/vmagent")
+	// replace registry host
+	f("mirror.example.com", true, "docker.io/victoriametrics/vmagent", "mirror.example.com/victoriametrics/vmagent")
+	f("mirror.example.com", true, "victoriametrics/vmagent", "mirror.example.com/victoriametrics/vmagent")
+	f("mirror.example.com", true, "quay.io/prometheus-operator/prometheus-config-reloader:v0.48.1", "mirror.example.com/prometheus-operator/prometheus-config-reloader:v0.48.1")
+	f("mirror.example.com", true, "localhost/victoriametrics/vmagent", "mirror.example.com/victoriametrics/vmagent")
+	f("mirror.example.com", true, "registry:5000/victoriametrics/vmagent", "mirror.example.com/victoriametrics/vmagent")
+	f("mirror.example.com", true, "vmagent", "mirror.example.com/vmagent")
+	// already formatted image must not change
+	f("mirror.example.com", true, "mirror.example.com/victoriametrics/vmagent", "mirror.example.com/victoriametrics/vmagent")
+}
+
+func TestAppendImagePullSecrets(t *testing.T) {
+	f := func(secrets []string, dst, want []corev1.LocalObjectReference) {
+		t.Helper()
+		c := &config.BaseOperatorConf{
+			ImagePullSecrets: secrets,
+		}
+		assert.Equal(t, want, appendImagePullSecrets(c, dst))
+	}
+	f(nil, nil, nil)
+	f(nil, []corev1.LocalObjectReference{{Name: "user"}}, []corev1.LocalObjectReference{{Name: "user"}})
+	f([]string{"mirror", ""}, nil, []corev1.LocalObjectReference{{Name: "mirror"}})
+	f([]string{"mirror", "user"}, []corev1.LocalObjectReference{{Name: "user"}}, []corev1.LocalObjectReference{{Name: "user"}, {Name: "mirror"}})
+}
+
+func TestAddSidecars(t *testing.T) {
+	f := func(minorVersion string, sidecars, wantInitContainers, wantContainers []corev1.Container) {
+		t.Helper()
+		if err := k8stools.SetKubernetesVersionWithDefaults(&version.Info{Major: "1", Minor: minorVersion}, 0, 0); err != nil {
+			t.Fatalf("cannot set k8s version for testing: %q", err)
+		}
+		defer func() {
+			restoreVersion := version.Info{Major: "0", Minor: "0"}
+			if err := k8stools.SetKubernetesVersionWithDefaults(&restoreVersion, 0, 0); err != nil {
+				t.Fatalf("cannot set k8s version for testing: %q", err)
+			}
+		}()
+		initContainers := []corev1.Container{{Name: "config-init"}}
+		containers := []corev1.Container{{Name: "vmagent"}}
+		gotInitContainers, gotContainers := AddSidecars(initContainers, containers, sidecars)
+		assert.Equal(t, wantInitContainers, gotInitContainers)
+		assert.Equal(t, wantContainers, gotContainers)
+		// source slices must not be modified
+		assert.Equal(t, []corev1.Container{{Name: "config-init"}}, initContainers)
+		assert.Equal(t, []corev1.Container{{Name: "vmagent"}}, containers)
+	}
+	always := corev1.ContainerRestartPolicyAlways
+
+	// no sidecars
+	f("30", nil, []corev1.Container{{Name: "config-init"}}, []corev1.Container{{Name: "vmagent"}})
+
+	// native sidecars
+	f("29", []corev1.Container{{Name: "proxy"}},
+		[]corev1.Container{{Name: "config-init"}, {Name: "proxy", RestartPolicy: &always}},
+		[]corev1.Container{{Name: "vmagent"}})
+
+	// legacy kubernetes version
+	f("28", []corev1.Container{{Name: "proxy", RestartPolicy: &always}},
+		[]corev1.Container{{Name: "config-init"}},
+		[]corev1.Container{{Name: "vmagent"}, {Name: "proxy"}})
+}
+
+func TestAppendArgsForOTLP(t *testing.T) {
+	f := func(otlp *vmv1beta1.OTLPIngestion, want []string) {
+		t.Helper()
+		assert.Equal(t, want, AppendArgsForOTLP([]string{"-httpListenAddr=:8429"}, otlp))
+	}
+	f(nil, []string{"-httpListenAddr=:8429"})
+	f(&vmv1beta1.OTLPIngestion{Port: 4318}, []string{"-httpListenAddr=:8429"})
+	f(&vmv1beta1.OTLPIngestion{MaxRequestSize: "128MB", UsePrometheusNaming: true}, []string{
+		"-httpListenAddr=:8429",
+		"--opentelemetry.maxRequestSize=128MB",
+		"--opentelemetry.usePrometheusNaming=true",
+	})
+}
+
+func TestAppendArgsForDatadogAndNewRelic(t *testing.T) {
+	f := func(dd *vmv1beta1.DatadogIngestion, nr *vmv1beta1.NewRelicIngestion, want []string) {
+		t.Helper()
+		args := AppendArgsForDatadog([]string{"-httpListenAddr=:8429"}, dd)
+		args = AppendArgsForNewRelic(args, nr)
+		assert.Equal(t, want, args)
+	}
+	f(nil, nil, []string{"-httpListenAddr=:8429"})
+	f(&vmv1beta1.DatadogIngestion{}, &vmv1beta1.NewRelicIngestion{}, []string{"-httpListenAddr=:8429"})
+	f(&vmv1beta1.DatadogIngestion{MaxRequestSize: "128MB", SanitizeMetricName: ptr.To(false)}, &vmv1beta1.NewRelicIngestion{MaxRequestSize: "32MB"}, []string{
+		"-httpListenAddr=:8429",
+		"-datadog.maxInsertRequestSize=128MB",
+		"-datadog.sanitizeMetricName=false",
+		"-newrelic.maxInsertRequestSize=32MB",
+	})
+}
+
+func TestAppendArgsForQueryLogging(t *testing.T) {
+	f := func(ql *vmv1beta1.QueryLogging, want []string) {
+		t.Helper()
+		assert.Equal(t, want, AppendArgsForQueryLogging([]string{"-httpListenAddr=:8481"}, ql))
+	}
+	f(nil, []string{"-httpListenAddr=:8481"})
+	f(&vmv1beta1.QueryLogging{}, []string{"-httpListenAddr=:8481"})
+	f(&vmv1beta1.QueryLogging{
+		LogSlowQueryDuration:  "10s",
+		LogQueryMemoryUsage:   "1GB",
+		MaxQueryDuration:      "1m",
+		MaxQueueDuration:      "20s",
+		MaxConcurrentRequests: ptr.To[int32](32),
+	}, []string{
+		"-httpListenAddr=:8481",
+		"-search.logSlowQueryDuration=10s",
+		"-search.logQueryMemoryUsage=1GB",
+		"-search.maxQueryDuration=1m",
+		"-search.maxQueueDuration=20s",
+		"-search.maxConcurrentRequests=32",
+	})
+}
@@ -1,6 +1,9 @@
 package build
 
 import (
+	"slices"
+	"strings"
+
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
 
@@ -12,8 +15,8 @@ import (
 	"k8s.io/utils/ptr"
 )
 
-func getCfg() *config.BaseOperatorConf {
-	return config.MustGetBaseConfig()
+func getCfg(namespace string) *config.BaseOperatorConf {
+	return config.MustGetBaseConfigForNamespace(namespace)
 }
 
 // AddDefaults adds defaulting functions to the runtimeScheme
@@ -28,7 +31,10 @@ func AddDefaults(scheme *runtime.Scheme) {
 	scheme.AddTypeDefaultingFunc(&vmv1beta1.VMAlertmanager{}, addVMAlertmanagerDefaults)
 	scheme.AddTypeDefaultingFunc(&vmv1beta1.VMCluster{}, addVMClusterDefaults)
 	scheme.AddTypeDefaultingFunc(&vmv1beta1.VLogs{}, addVlogsDefaults)
+	scheme.AddTypeDefaultingFunc(&vmv1beta1.VLSingle{}, addVLSingleDefaults)
+	scheme.AddTypeDefaultingFunc(&vmv1beta1.VMGateway{}, addVMGatewayDefaults)
 	scheme.AddTypeDefaultingFunc(&vmv1beta1.VMServiceScrape{}, addVMServiceScrapeDefaults)
+	scheme.AddTypeDefaultingFunc(&vmv1beta1.VMDataMigration{}, addVMDataMigrationDefaults)
 }
 
 // defaults according to
@@ -178,7 +184,7 @@ func isExternallyAccessible(service *corev1.Service) bool {
 
 func addVMAuthDefaults(objI interface{}) {
 	cr := objI.(*vmv1beta1.VMAuth)
-	c := getCfg()
+	c := getCfg(cr.Namespace)
 
 	if cr.Spec.ConfigSecret != "" {
 		// Removed if later with ConfigSecret field later
@@ -190,17 +196,21 @@ func addVMAuthDefaults(objI interface{}) {
 		}
 	}
 	cv := config.ApplicationDefaults(c.VMAuthDefault)
-	addDefaultsToCommonParams(&cr.Spec.CommonDefaultableParams, &cv)
-	addDefaluesToConfigReloader(&cr.Spec.CommonConfigReloaderParams, ptr.Deref(cr.Spec.UseDefaultResources, false), &cv)
+	addDefaultsToCommonParams(c, &cr.Spec.CommonDefaultableParams, &cv)
+	addDefaultsToLogging(c, &cr.Spec.LogFormat, &cr.Spec.LogLevel)
+	cr.Spec.ImagePullSecrets = appendImagePullSecrets(c, cr.Spec.ImagePullSecrets)
+	addDefaluesToConfigReloader(c, &cr.Spec.CommonConfigReloaderParams, ptr.Deref(cr.Spec.UseDefaultResources, false), &cv)
 }
 
 func addVMAlertDefaults(objI interface{}) {
 	cr := objI.(*vmv1beta1.VMAlert)
-	c := getCfg()
+	c := getCfg(cr.Namespace)
 
 	cv := config.ApplicationDefaults(c.VMAlertDefault)
-	addDefaultsToCommonParams(&cr.Spec.CommonDefaultableParams, &cv)
-	addDefaluesToConfigReloader(&cr.Spec.CommonConfigReloaderParams, ptr.Deref(cr.Spec.UseDefaultResources, false), &cv)
+	addDefaultsToCommonParams(c, &cr.Spec.CommonDefaultableParams, &cv)
+	addDefaultsToLogging(c, &cr.Spec.LogFormat, &cr.Spec.LogLevel)
+	cr.Spec.ImagePullSecrets = appendImagePullSecrets(c, cr.Spec.ImagePullSecrets)
+	addDefaluesToConfigReloader(c, &cr.Spec.CommonConfigReloaderParams, ptr.Deref(cr.Spec.UseDefaultResources, false), &cv)
 	if cr.Spec.ConfigReloaderImageTag == "" {
 		panic("cannot be empty")
 	}
@@ -208,19 +218,23 @@ func addVMAlertDefaults(objI interface{}) {
 
 func addVMAgentDefaults(objI interface{}) {
 	cr := objI.(*vmv1beta1.VMAgent)
-	c := getCfg()
+	c := getCfg(cr.Namespace)
 
 	cv := config.ApplicationDefaults(c.VMAgentDefault)
-	addDefaultsToCommonParams(&cr.Spec.CommonDefaultableParams, &cv)
-	addDefaluesToConfigReloader(&cr.Spec.CommonConfigReloaderParams, ptr.Deref(cr.Spec.UseDefaultResources, false), &cv)
+	addDefaultsToCommonParams(c, &cr.Spec.CommonDefaultableParams, &cv)
+	addDefaultsToLogging(c, &cr.Spec.LogFormat, &cr.Spec.LogLevel)
+	cr.Spec.ImagePullSecrets = appendImagePullSecrets(c, cr.Spec.ImagePullSecrets)
+	addDefaluesToConfigReloader(c, &cr.Spec.CommonConfigReloaderParams, ptr.Deref(cr.Spec.UseDefaultResources, false), &cv)
 }
 
 func addVMSingleDefaults(objI interface{}) {
 	cr := objI.(*vmv1beta1.VMSingle)
-	c := getCfg()
+	c := getCfg(cr.Namespace)
 	useBackupDefaultResources := c.VMBackup.UseDefaultResources
 	cv := config.ApplicationDefaults(c.VMSingleDefault)
-	addDefaultsToCommonParams(&cr.Spec.CommonDefaultableParams, &cv)
+	addDefaultsToCommonParams(c, &cr.Spec.CommonDefaultableParams, &cv)
+	addDefaultsToLogging(c, &cr.Spec.LogFormat, &cr.Spec.LogLevel)
+	cr.Spec.ImagePullSecrets = appendImagePullSecrets(c, cr.Spec.ImagePullSecrets)
 	if cr.Spec.UseDefaultResources != nil {
 		useBackupDefaultResources = *cr.Spec.UseDefaultResources
 	}
@@ -240,20 +254,43 @@ func addVMSingleDefaults(objI interface{}) {
 			}
 		}(c.VMBackup.Resource),
 	}
-	addDefaultsToVMBackup(cr.Spec.VMBackup, useBackupDefaultResources, backupDefaults)
+	addDefaultsToVMBackup(c, cr.Spec.VMBackup, useBackupDefaultResources, backupDefaults)
+	addDefaultsToVMRestoreFrom(c, cr.Spec.RestoreFrom)
 }
 
 func addVlogsDefaults(objI interface{}) {
 	cr := objI.(*vmv1beta1.VLogs)
-	c := getCfg()
+	c := getCfg(cr.Namespace)
 
 	cv := config.ApplicationDefaults(c.VLogsDefault)
-	addDefaultsToCommonParams(&cr.Spec.CommonDefaultableParams, &cv)
+	addDefaultsToCommonParams(c, &cr.Spec.CommonDefaultableParams, &cv)
+	addDefaultsToLogging(c, &cr.Spec.LogFormat, &cr.Spec.LogLevel)
+	cr.Spec.ImagePullSecrets = appendImagePullSecrets(c, cr.Spec.ImagePullSecrets)
+}
+
+func addVLSingleDefaults(objI interface{}) {
+	cr := objI.(*vmv1beta1.VLSingle)
+	c := getCfg(cr.Namespace)
+
+	cv := config.ApplicationDefaults(c.VLSingleDefault)
+	addDefaultsToCommonParams(c, &cr.Spec.CommonDefaultableParams, &cv)
+	addDefaultsToLogging(c, &cr.Spec.LogFormat, &cr.Spec.LogLevel)
+	cr.Spec.ImagePullSecrets = appendImagePullSecrets(c, cr.Spec.ImagePullSecrets)
+}
+
+func addVMGatewayDefaults(objI interface{}) {
+	cr := objI.(*vmv1beta1.VMGateway)
+	c := getCfg(cr.Namespace)
+
+	cv := config.ApplicationDefaults(c.VMGatewayDefault)
+	addDefaultsToCommonParams(c, &cr.Spec.CommonDefaultableParams, &cv)
+	addDefaultsToLogging(c, &cr.Spec.LogFormat, &cr.Spec.LogLevel)
+	cr.Spec.ImagePullSecrets = appendImagePullSecrets(c, cr.Spec.ImagePullSecrets)
 }
 
 func addVMAlertmanagerDefaults(objI interface{}) {
 	cr := objI.(*vmv1beta1.VMAlertmanager)
-	c := getCfg()
+	c := getCfg(cr.Namespace)
 
 	if cr.Spec.ClusterDomainName == "" {
 		cr.Spec.ClusterDomainName = c.ClusterDomainName
@@ -290,8 +327,10 @@ func addVMAlertmanagerDefaults(objI interface{}) {
 	if cr.Spec.TerminationGracePeriodSeconds == nil {
 		cr.Spec.TerminationGracePeriodSeconds = ptr.To[int64](120)
 	}
-	addDefaultsToCommonParams(&cr.Spec.CommonDefaultableParams, &cv)
-	addDefaluesToConfigReloader(&cr.Spec.CommonConfigReloaderParams, ptr.Deref(cr.Spec.UseDefaultResources, false), &cv)
+	addDefaultsToCommonParams(c, &cr.Spec.CommonDefaultableParams, &cv)
+	addAlertmanagerLoggingDefaults(c, cr)
+	cr.Spec.ImagePullSecrets = appendImagePullSecrets(c, cr.Spec.ImagePullSecrets)
+	addDefaluesToConfigReloader(c, &cr.Spec.CommonConfigReloaderParams, ptr.Deref(cr.Spec.UseDefaultResources, false), &cv)
 }
 
 const (
@@ -302,7 +341,7 @@ var defaultTerminationGracePeriod = int64(30)
 
 func addVMClusterDefaults(objI interface{}) {
 	cr := objI.(*vmv1beta1.VMCluster)
-	c := getCfg()
+	c := getCfg(cr.Namespace)
 
 	// cluster is tricky is has main strictSecurity and per app
 	useStrictSecurity := c.EnableStrictSecurity
@@ -312,6 +351,7 @@ func addVMClusterDefaults(objI interface{}) {
 	if cr.Spec.ClusterDomainName == "" {
 		cr.Spec.ClusterDomainName = c.ClusterDomainName
 	}
+	cr.Spec.ImagePullSecrets = appendImagePullSecrets(c, cr.Spec.ImagePullSecrets)
 
 	if cr.Spec.VMStorage != nil {
 		if cr.Spec.VMStorage.UseStrictSecurity == nil {
@@ -320,6 +360,9 @@ func addVMClusterDefaults(objI interface{}) {
 		if cr.Spec.VMStorage.DisableSelfServiceScrape == nil {
 			cr.Spec.VMStorage.DisableSelfServiceScrape = &c.DisableSelfServiceScrapeCreation
 		}
+		if cr.Spec.VMStorage.SelfMonitoringRules == nil {
+			cr.Spec.VMStorage.SelfMonitoringRules = &c.EnableSelfMonitoringRules
+		}
 		cr.Spec.VMStorage.ImagePullSecrets = append(cr.Spec.VMStorage.ImagePullSecrets, cr.Spec.ImagePullSecrets...)
 
 		useBackupDefaultResources := c.VMBackup.UseDefaultResources
@@ -342,10 +385,11 @@ func addVMClusterDefaults(objI interface{}) {
 				}
 			}(c.VMBackup.Resource),
 		}
+		addDefaultsToVMRestoreFrom(c, cr.Spec.VMStorage.RestoreFrom)
 		if cr.Spec.VMStorage.Image.Repository == "" {
 			cr.Spec.VMStorage.Image.Repository = c.VMClusterDefault.VMStorageDefault.Image
 		}
-		cr.Spec.VMStorage.Image.Repository = formatContainerImage(c.ContainerRegistry, cr.Spec.VMStorage.Image.Repository)
+		cr.Spec.VMStorage.Image.Repository = formatImage(c, cr.Spec.VMStorage.Image.Repository)
 
 		if cr.Spec.VMStorage.Image.Tag == "" {
 			if cr.Spec.ClusterVersion != "" {
@@ -386,7 +430,8 @@ func addVMClusterDefaults(objI interface{}) {
 			config.Resource(c.VMClusterDefault.VMStorageDefault.Resource),
 			*cr.Spec.VMStorage.UseDefaultResources,
 		)
-		addDefaultsToVMBackup(cr.Spec.VMStorage.VMBackup, useBackupDefaultResources, backupDefaults)
+		addDefaultsToLogging(c, &cr.Spec.VMStorage.LogFormat, &cr.Spec.VMStorage.LogLevel)
+		addDefaultsToVMBackup(c, cr.Spec.VMStorage.VMBackup, useBackupDefaultResources, backupDefaults)
 	}
 
 	if cr.Spec.VMInsert != nil {
@@ -396,12 +441,15 @@ func addVMClusterDefaults(objI interface{}) {
 		if cr.Spec.VMInsert.DisableSelfServiceScrape == nil {
 			cr.Spec.VMInsert.DisableSelfServiceScrape = &c.DisableSelfServiceScrapeCreation
 		}
+		if cr.Spec.VMInsert.SelfMonitoringRules == nil {
+			cr.Spec.VMInsert.SelfMonitoringRules = &c.EnableSelfMonitoringRules
+		}
 		cr.Spec.VMInsert.ImagePullSecrets = append(cr.Spec.VMInsert.ImagePullSecrets, cr.Spec.ImagePullSecrets...)
 
 		if cr.Spec.VMInsert.Image.Repository == "" {
 			cr.Spec.VMInsert.Image.Repository = c.VMClusterDefault.VMInsertDefault.Image
 		}
-		cr.Spec.VMInsert.Image.Repository = formatContainerImage(c.ContainerRegistry, cr.Spec.VMInsert.Image.Repository)
+		cr.Spec.VMInsert.Image.Repository = formatImage(c, cr.Spec.VMInsert.Image.Repository)
 		if cr.Spec.VMInsert.Image.Tag == "" {
 			if cr.Spec.ClusterVersion != "" {
 				cr.Spec.VMInsert.Image.Tag = cr.Spec.ClusterVersion
@@ -419,7 +467,7 @@ func addVMClusterDefaults(objI interface{}) {
 			config.Resource(c.VMClusterDefault.VMInsertDefault.Resource),
 			*cr.Spec.VMInsert.UseDefaultResources,
 		)
-
+		addDefaultsToLogging(c, &cr.Spec.VMInsert.LogFormat, &cr.Spec.VMInsert.LogLevel)
 	}
 	if cr.Spec.VMSelect != nil {
 		if cr.Spec.VMSelect.UseStrictSecurity == nil {
@@ -428,13 +476,16 @@ func addVMClusterDefaults(objI interface{}) {
 		if cr.Spec.VMSelect.DisableSelfServiceScrape == nil {
 			cr.Spec.VMSelect.DisableSelfServiceScrape = &c.DisableSelfServiceScrapeCreation
 		}
+		if cr.Spec.VMSelect.SelfMonitoringRules == nil {
+			cr.Spec.VMSelect.SelfMonitoringRules = &c.EnableSelfMonitoringRules
+		}
 
 		cr.Spec.VMSelect.ImagePullSecrets = append(cr.Spec.VMSelect.ImagePullSecrets, cr.Spec.ImagePullSecrets...)
 
 		if cr.Spec.VMSelect.Image.Repository == "" {
 			cr.Spec.VMSelect.Image.Repository = c.VMClusterDefault.VMSelectDefault.Image
 		}
-		cr.Spec.VMSelect.Image.Repository = formatContainerImage(c.ContainerRegistry, cr.Spec.VMSelect.Image.Repository)
+		cr.Spec.VMSelect.Image.Repository = formatImage(c, cr.Spec.VMSelect.Image.Repository)
 		if cr.Spec.VMSelect.Image.Tag == "" {
 			if cr.Spec.ClusterVersion != "" {
 				cr.Spec.VMSelect.Image.Tag = cr.Spec.ClusterVersion
@@ -466,6 +517,7 @@ func addVMClusterDefaults(objI interface{}) {
 			config.Resource(c.VMClusterDefault.VMSelectDefault.Resource),
 			*cr.Spec.VMSelect.UseDefaultResources,
 		)
+		addDefaultsToLogging(c, &cr.Spec.VMSelect.LogFormat, &cr.Spec.VMSelect.LogLevel)
 	}
 	if cr.Spec.RequestsLoadBalancer.Enabled {
 		if cr.Spec.RequestsLoadBalancer.Spec.UseStrictSecurity == nil {
@@ -479,8 +531,9 @@ func addVMClusterDefaults(objI interface{}) {
 			cr.Spec.RequestsLoadBalancer.Spec.Image.Tag = cr.Spec.ClusterVersion
 		}
 		cv := config.ApplicationDefaults(c.VMAuthDefault)
-		addDefaultsToCommonParams(&cr.Spec.RequestsLoadBalancer.Spec.CommonDefaultableParams, &cv)
+		addDefaultsToCommonParams(c, &cr.Spec.RequestsLoadBalancer.Spec.CommonDefaultableParams, &cv)
 		spec := &cr.Spec.RequestsLoadBalancer.Spec
+		addDefaultsToLogging(c, &spec.LogFormat, &spec.LogLevel)
 		if spec.EmbeddedProbes == nil {
 			spec.EmbeddedProbes = &vmv1beta1.EmbeddedProbes{}
 		}
@@ -497,8 +550,7 @@ func addVMClusterDefaults(objI interface{}) {
 	}
 }
 
-func addDefaultsToCommonParams(common *vmv1beta1.CommonDefaultableParams, appDefaults *config.ApplicationDefaults) {
-	c := getCfg()
+func addDefaultsToCommonParams(c *config.BaseOperatorConf, common *vmv1beta1.CommonDefaultableParams, appDefaults *config.ApplicationDefaults) {
 
 	if common.Image.Repository == "" {
 		common.Image.Repository = appDefaults.Image
@@ -506,7 +558,10 @@ func addDefaultsToCommonParams(common *vmv1beta1.CommonDefaultableParams, appDef
 	if common.DisableSelfServiceScrape == nil {
 		common.DisableSelfServiceScrape = &c.DisableSelfServiceScrapeCreation
 	}
-	common.Image.Repository = formatContainerImage(c.ContainerRegistry, common.Image.Repository)
+	if common.SelfMonitoringRules == nil {
+		common.SelfMonitoringRules = &c.EnableSelfMonitoringRules
+	}
+	common.Image.Repository = formatImage(c, common.Image.Repository)
 	if common.Image.Tag == "" {
 		common.Image.Tag = appDefaults.Version
 	}
@@ -528,8 +583,24 @@ func addDefaultsToCommonParams(common *vmv1beta1.CommonDefaultableParams, appDef
 	common.Resources = Resources(common.Resources, config.Resource(appDefaults.Resource), ptr.Deref(common.UseDefaultResources, false))
 }
 
-func addDefaluesToConfigReloader(common *vmv1beta1.CommonConfigReloaderParams, useDefaultResources bool, appDefaults *config.ApplicationDefaults) {
-	c := getCfg()
+// appendImagePullSecrets adds image pull secrets from operator configuration
+// to the given list, already present secrets are skipped
+func appendImagePullSecrets(c *config.BaseOperatorConf, dst []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	for _, name := range c.ImagePullSecrets {
+		if name == "" || slices.ContainsFunc(dst, func(ref corev1.LocalObjectReference) bool { return ref.Name == name }) {
+			continue
+		}
+		dst = append(dst, corev1.LocalObjectReference{Name: name})
+	}
+	return dst
+}
+
+// ImagePullSecrets returns image pull secrets from operator configuration for the given namespace
+func ImagePullSecrets(namespace string) []corev1.LocalObjectReference {
+	return appendImagePullSecrets(getCfg(namespace), nil)
+}
+
+func addDefaluesToConfigReloader(c *config.BaseOperatorConf, common *vmv1beta1.CommonConfigReloaderParams, useDefaultResources bool, appDefaults *config.ApplicationDefaults) {
 	if common.UseVMConfigReloader == nil && c.UseCustomConfigReloader {
 		common.UseVMConfigReloader = &c.UseCustomConfigReloader
 	}
@@ -541,7 +612,7 @@ func addDefaluesToConfigReloader(common *vmv1beta1.CommonConfigReloaderParams, u
 		}
 	}
 
-	common.ConfigReloaderImageTag = formatContainerImage(c.ContainerRegistry, common.ConfigReloaderImageTag)
+	common.ConfigReloaderImageTag = formatImage(c, common.ConfigReloaderImageTag)
 	common.ConfigReloaderResources = Resources(common.ConfigReloaderResources, config.Resource{
 		Limit: struct {
 			Mem string
@@ -560,16 +631,15 @@ func addDefaluesToConfigReloader(common *vmv1beta1.CommonConfigReloaderParams, u
 	}, useDefaultResources)
 }
 
-func addDefaultsToVMBackup(cr *vmv1beta1.VMBackup, useDefaultResources bool, appDefaults *config.ApplicationDefaults) {
+func addDefaultsToVMBackup(c *config.BaseOperatorConf, cr *vmv1beta1.VMBackup, useDefaultResources bool, appDefaults *config.ApplicationDefaults) {
 	if cr == nil {
 		return
 	}
-	c := getCfg()
 
 	if cr.Image.Repository == "" {
 		cr.Image.Repository = appDefaults.Image
 	}
-	cr.Image.Repository = formatContainerImage(c.ContainerRegistry, cr.Image.Repository)
+	cr.Image.Repository = formatImage(c, cr.Image.Repository)
 	if cr.Image.Tag == "" {
 		cr.Image.Tag = appDefaults.Version
 	}
@@ -581,6 +651,73 @@ func addDefaultsToVMBackup(cr *vmv1beta1.VMBackup, useDefaultResources bool, app
 	}
 
 	cr.Resources = Resources(cr.Resources, config.Resource(appDefaults.Resource), useDefaultResources)
+	if cr.LogFormat == nil && c.ComponentsLogFormat != "" {
+		cr.LogFormat = ptr.To(c.ComponentsLogFormat)
+	}
+	if cr.LogLevel == nil && c.ComponentsLogLevel != "" {
+		cr.LogLevel = ptr.To(c.ComponentsLogLevel)
+	}
+}
+
+// addDefaultsToLogging sets log format and level from operator configuration
+// if they are not defined at component spec
+func addDefaultsToLogging(c *config.BaseOperatorConf, logFormat, logLevel *string) {
+	if *logFormat == "" {
+		*logFormat = c.ComponentsLogFormat
+	}
+	if *logLevel == "" {
+		*logLevel = c.ComponentsLogLevel
+	}
+}
+
+// addAlertmanagerLoggingDefaults converts operator logging configuration into alertmanager log flag values
+func addAlertmanagerLoggingDefaults(c *config.BaseOperatorConf, cr *vmv1beta1.VMAlertmanager) {
+	// alertmanager doesn't have default format, its logfmt is used instead
+	if cr.Spec.LogFormat == "" && c.ComponentsLogFormat == "json" {
+		cr.Spec.LogFormat = c.ComponentsLogFormat
+	}
+	if cr.Spec.LogLevel == "" {
+		switch c.ComponentsLogLevel {
+		case "":
+		case "FATAL", "PANIC":
+			cr.Spec.LogLevel = "error"
+		default:
+			cr.Spec.LogLevel = strings.ToLower(c.ComponentsLogLevel)
+		}
+	}
+}
+
+func addDefaultsToVMRestoreFrom(c *config.BaseOperatorConf, cr *vmv1beta1.VMRestoreFrom) {
+	if cr == nil {
+		return
+	}
+
+	if cr.Image.Repository == "" {
+		cr.Image.Repository = c.VMRestore.Image
+	}
+	cr.Image.Repository = formatImage(c, cr.Image.Repository)
+	if cr.Image.Tag == "" {
+		cr.Image.Tag = c.VMRestore.Version
+	}
+	if cr.Image.PullPolicy == "" {
+		cr.Image.PullPolicy = corev1.PullIfNotPresent
+	}
+}
+
+func addVMDataMigrationDefaults(objI interface{}) {
+	cr := objI.(*vmv1beta1.VMDataMigration)
+	c := getCfg(cr.Namespace)
+
+	if cr.Spec.Image.Repository == "" {
+		cr.Spec.Image.Repository = c.VMCtl.Image
+	}
+	cr.Spec.Image.Repository = formatImage(c, cr.Spec.Image.Repository)
+	if cr.Spec.Image.Tag == "" {
+		cr.Spec.Image.Tag = c.VMCtl.Version
+	}
+	if cr.Spec.Image.PullPolicy == "" {
+		cr.Spec.Image.PullPolicy = corev1.PullIfNotPresent
+	}
 }
 
 func addVMServiceScrapeDefaults(objI interface{}) {
@@ -588,7 +725,7 @@ func addVMServiceScrapeDefaults(objI interface{}) {
 	if cr == nil {
 		return
 	}
-	c := getCfg()
+	c := getCfg(cr.Namespace)
 	if cr.Spec.DiscoveryRole == "" && c.VMServiceScrapeDefault.EnforceEndpointslices {
 		cr.Spec.DiscoveryRole = "endpointslices"
 	}
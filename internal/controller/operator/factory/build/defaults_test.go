@@ -0,0 +1,27 @@
+package build
+
+import (
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+)
+
+func TestAddAlertmanagerLoggingDefaults(t *testing.T) {
+	f := func(format, level string, spec vmv1beta1.VMAlertmanagerSpec, wantFormat, wantLevel string) {
+		t.Helper()
+		c := &config.BaseOperatorConf{ComponentsLogFormat: format, ComponentsLogLevel: level}
+		cr := &vmv1beta1.VMAlertmanager{Spec: spec}
+		addAlertmanagerLoggingDefaults(c, cr)
+		if cr.Spec.LogFormat != wantFormat {
+			t.Fatalf("unexpected log format, got: %q, want: %q", cr.Spec.LogFormat, wantFormat)
+		}
+		if cr.Spec.LogLevel != wantLevel {
+			t.Fatalf("unexpected log level, got: %q, want: %q", cr.Spec.LogLevel, wantLevel)
+		}
+	}
+	f("", "", vmv1beta1.VMAlertmanagerSpec{}, "", "")
+	f("default", "WARN", vmv1beta1.VMAlertmanagerSpec{}, "", "warn")
+	f("json", "PANIC", vmv1beta1.VMAlertmanagerSpec{}, "json", "error")
+	f("json", "ERROR", vmv1beta1.VMAlertmanagerSpec{LogFormat: "logfmt", LogLevel: "debug"}, "logfmt", "debug")
+}
@@ -21,9 +21,10 @@ func DeploymentAddCommonParams(dst *appsv1.Deployment, useStrictSecurity bool, p
 	dst.Spec.Template.Spec.DNSPolicy = params.DNSPolicy
 	dst.Spec.Template.Spec.DNSConfig = params.DNSConfig
 	dst.Spec.Template.Spec.NodeSelector = params.NodeSelector
-	dst.Spec.Template.Spec.SecurityContext = AddStrictSecuritySettingsToPod(params.SecurityContext, useStrictSecurity)
+	dst.Spec.Template.Spec.SecurityContext = AddStrictSecuritySettingsToPod(dst.Namespace, params.SecurityContext, useStrictSecurity)
 	dst.Spec.Template.Spec.TerminationGracePeriodSeconds = params.TerminationGracePeriodSeconds
 	dst.Spec.Template.Spec.TopologySpreadConstraints = params.TopologySpreadConstraints
+	addSpreadPolicy(&dst.Spec.Template.Spec, params.SpreadPolicy, dst.Spec.Selector)
 	dst.Spec.Template.Spec.ImagePullSecrets = params.ImagePullSecrets
 	dst.Spec.Template.Spec.TerminationGracePeriodSeconds = params.TerminationGracePeriodSeconds
 	dst.Spec.Template.Spec.ReadinessGates = params.ReadinessGates
@@ -0,0 +1,135 @@
+package build
+
+import (
+	"fmt"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+const (
+	grafanaDashboardURLFormat     = "https://raw.githubusercontent.com/VictoriaMetrics/VictoriaMetrics/%s/dashboards/%s.json"
+	grafanaDashboardDefaultRef    = "master"
+	grafanaDashboardFolderAnnoKey = "grafana_folder"
+)
+
+var (
+	grafanaDashboardReleaseRe      = regexp.MustCompile(`^v\d+\.\d+\.\d+`)
+	grafanaDashboardDefaultCMLabel = map[string]string{"grafana_dashboard": "1"}
+)
+
+// GrafanaDashboardGroupVersionKind defines grafana-operator GrafanaDashboard kind
+// operator doesn't depend on grafana-operator client and manages it as unstructured object
+var GrafanaDashboardGroupVersionKind = schema.GroupVersionKind{
+	Group:   "grafana.integreatly.org",
+	Version: "v1beta1",
+	Kind:    "GrafanaDashboard",
+}
+
+// NewGrafanaDashboard returns empty grafana-operator GrafanaDashboard with the given name
+func NewGrafanaDashboard(name, namespace string) *unstructured.Unstructured {
+	gd := &unstructured.Unstructured{}
+	gd.SetGroupVersionKind(GrafanaDashboardGroupVersionKind)
+	gd.SetName(name)
+	gd.SetNamespace(namespace)
+	return gd
+}
+
+// NewGrafanaDashboardObject returns empty object of the kind defined by spec mode
+func NewGrafanaDashboardObject(spec *vmv1beta1.GrafanaDashboard, name, namespace string) client.Object {
+	if spec.UseConfigMap() {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+	return NewGrafanaDashboard(name, namespace)
+}
+
+// GrafanaDashboardURL returns url of VictoriaMetrics dashboard for the given application image tag
+// release version is extracted from the tag, master branch is used for non-release tags
+func GrafanaDashboardURL(dashboard, imageTag string) string {
+	ref := grafanaDashboardReleaseRe.FindString(imageTag)
+	if ref == "" {
+		ref = grafanaDashboardDefaultRef
+	}
+	return fmt.Sprintf(grafanaDashboardURLFormat, ref, dashboard)
+}
+
+// GrafanaDashboard creates either grafana-operator GrafanaDashboard or ConfigMap for grafana sidecar
+// with url of the given dashboard versioned by application image tag
+func GrafanaDashboard(opts builderOpts, name string, spec *vmv1beta1.GrafanaDashboard, dashboard, imageTag string) client.Object {
+	url := GrafanaDashboardURL(dashboard, imageTag)
+	if spec.UseConfigMap() {
+		cmLabels := spec.ConfigMapLabels
+		if len(cmLabels) == 0 {
+			cmLabels = grafanaDashboardDefaultCMLabel
+		}
+		annotations := opts.AnnotationsFiltered()
+		if spec.Folder != "" {
+			annotations = labels.Merge(annotations, map[string]string{grafanaDashboardFolderAnnoKey: spec.Folder})
+		}
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       opts.GetNamespace(),
+				Labels:          labels.Merge(opts.AllLabels(), cmLabels),
+				Annotations:     annotations,
+				OwnerReferences: opts.AsOwner(),
+			},
+			// grafana sidecar downloads content of files with .url suffix
+			Data: map[string]string{
+				dashboard + ".json.url": url,
+			},
+		}
+	}
+	gd := NewGrafanaDashboard(name, opts.GetNamespace())
+	gd.SetAnnotations(opts.AnnotationsFiltered())
+	gd.SetLabels(opts.AllLabels())
+	gd.SetOwnerReferences(opts.AsOwner())
+	gdSpec := map[string]any{
+		"url": url,
+	}
+	if spec.InstanceSelector != nil {
+		gdSpec["instanceSelector"] = labelSelectorToUnstructured(spec.InstanceSelector)
+	}
+	if spec.Folder != "" {
+		gdSpec["folder"] = spec.Folder
+	}
+	gd.Object["spec"] = gdSpec
+	return gd
+}
+
+func labelSelectorToUnstructured(ls *metav1.LabelSelector) map[string]any {
+	selector := map[string]any{}
+	if len(ls.MatchLabels) > 0 {
+		matchLabels := make(map[string]any, len(ls.MatchLabels))
+		for k, v := range ls.MatchLabels {
+			matchLabels[k] = v
+		}
+		selector["matchLabels"] = matchLabels
+	}
+	if len(ls.MatchExpressions) > 0 {
+		exprs := make([]any, 0, len(ls.MatchExpressions))
+		for _, e := range ls.MatchExpressions {
+			expr := map[string]any{
+				"key":      e.Key,
+				"operator": string(e.Operator),
+			}
+			if len(e.Values) > 0 {
+				values := make([]any, 0, len(e.Values))
+				for _, v := range e.Values {
+					values = append(values, v)
+				}
+				expr["values"] = values
+			}
+			exprs = append(exprs, expr)
+		}
+		selector["matchExpressions"] = exprs
+	}
+	return selector
+}
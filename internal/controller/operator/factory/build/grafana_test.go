@@ -0,0 +1,85 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestGrafanaDashboardURL(t *testing.T) {
+	f := func(imageTag, want string) {
+		t.Helper()
+		assert.Equal(t, want, GrafanaDashboardURL("vmagent", imageTag))
+	}
+	f("v1.110.0", "https://raw.githubusercontent.com/VictoriaMetrics/VictoriaMetrics/v1.110.0/dashboards/vmagent.json")
+	f("v1.110.0-enterprise-cluster", "https://raw.githubusercontent.com/VictoriaMetrics/VictoriaMetrics/v1.110.0/dashboards/vmagent.json")
+	f("latest", "https://raw.githubusercontent.com/VictoriaMetrics/VictoriaMetrics/master/dashboards/vmagent.json")
+	f("", "https://raw.githubusercontent.com/VictoriaMetrics/VictoriaMetrics/master/dashboards/vmagent.json")
+}
+
+func TestGrafanaDashboard(t *testing.T) {
+	cr := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "agent",
+			Namespace: "default",
+		},
+	}
+	wantURL := "https://raw.githubusercontent.com/VictoriaMetrics/VictoriaMetrics/v1.110.0/dashboards/vmagent.json"
+
+	// grafana-operator dashboard
+	got := GrafanaDashboard(cr, cr.GrafanaDashboardName(), &vmv1beta1.GrafanaDashboard{
+		InstanceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"dashboards": "grafana"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"prod"}},
+			},
+		},
+		Folder: "VictoriaMetrics",
+	}, "vmagent", "v1.110.0")
+	gd, ok := got.(*unstructured.Unstructured)
+	if !ok {
+		t.Fatalf("unexpected object type: %T", got)
+	}
+	assert.Equal(t, GrafanaDashboardGroupVersionKind, gd.GroupVersionKind())
+	assert.Equal(t, "grafana-dashboard-vmagent-agent", gd.GetName())
+	assert.Equal(t, cr.Namespace, gd.GetNamespace())
+	assert.Equal(t, map[string]any{
+		"url":    wantURL,
+		"folder": "VictoriaMetrics",
+		"instanceSelector": map[string]any{
+			"matchLabels": map[string]any{"dashboards": "grafana"},
+			"matchExpressions": []any{
+				map[string]any{"key": "env", "operator": "In", "values": []any{"prod"}},
+			},
+		},
+	}, gd.Object["spec"])
+
+	// sidecar configmap with default labels
+	got = GrafanaDashboard(cr, cr.GrafanaDashboardName(), &vmv1beta1.GrafanaDashboard{
+		Mode:   vmv1beta1.GrafanaDashboardModeConfigMap,
+		Folder: "VictoriaMetrics",
+	}, "vmagent", "v1.110.0")
+	cm, ok := got.(*corev1.ConfigMap)
+	if !ok {
+		t.Fatalf("unexpected object type: %T", got)
+	}
+	assert.Equal(t, "grafana-dashboard-vmagent-agent", cm.Name)
+	assert.Equal(t, "1", cm.Labels["grafana_dashboard"])
+	assert.Equal(t, "VictoriaMetrics", cm.Annotations["grafana_folder"])
+	assert.Equal(t, map[string]string{"vmagent.json.url": wantURL}, cm.Data)
+
+	// sidecar configmap with custom labels
+	got = GrafanaDashboard(cr, cr.GrafanaDashboardName(), &vmv1beta1.GrafanaDashboard{
+		Mode:            vmv1beta1.GrafanaDashboardModeConfigMap,
+		ConfigMapLabels: map[string]string{"dashboard": "vm"},
+	}, "vmagent", "v1.110.0")
+	cm = got.(*corev1.ConfigMap)
+	assert.Equal(t, "vm", cm.Labels["dashboard"])
+	assert.NotContains(t, cm.Labels, "grafana_dashboard")
+	assert.NotContains(t, cm.Annotations, "grafana_folder")
+}
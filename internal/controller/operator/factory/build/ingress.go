@@ -0,0 +1,67 @@
+package build
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+var defaultPathType = networkingv1.PathTypePrefix
+
+// Ingress creates Ingress for the main service of the given object
+// service must have port with name http
+func Ingress(cr builderOpts, spec *vmv1beta1.EmbeddedIngress) *networkingv1.Ingress {
+	defaultRule := networkingv1.IngressRule{
+		Host: spec.Host,
+		IngressRuleValue: networkingv1.IngressRuleValue{
+			HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{
+					{
+						Path: "/",
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: cr.PrefixedName(),
+								Port: networkingv1.ServiceBackendPort{Name: "http"},
+							},
+						},
+						PathType: &defaultPathType,
+					},
+				},
+			},
+		},
+	}
+	ingSpec := networkingv1.IngressSpec{
+		Rules:            []networkingv1.IngressRule{},
+		IngressClassName: spec.ClassName,
+	}
+	if spec.TlsSecretName != "" {
+		ingSpec.TLS = []networkingv1.IngressTLS{
+			{
+				SecretName: spec.TlsSecretName,
+				Hosts:      spec.TlsHosts,
+			},
+		}
+		for _, host := range spec.TlsHosts {
+			hostRule := defaultRule.DeepCopy()
+			hostRule.Host = host
+			ingSpec.Rules = append(ingSpec.Rules, *hostRule)
+		}
+	} else {
+		ingSpec.Rules = append(ingSpec.Rules, defaultRule)
+	}
+	// add user defined routes.
+	ingSpec.Rules = append(ingSpec.Rules, spec.ExtraRules...)
+	ingSpec.TLS = append(ingSpec.TLS, spec.ExtraTLS...)
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.PrefixedName(),
+			Namespace:       cr.GetNSName(),
+			Labels:          labels.Merge(spec.Labels, cr.SelectorLabels()),
+			Annotations:     spec.Annotations,
+			OwnerReferences: cr.AsOwner(),
+		},
+		Spec: ingSpec,
+	}
+}
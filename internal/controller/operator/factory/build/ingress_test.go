@@ -0,0 +1,68 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestIngress(t *testing.T) {
+	cr := &vmv1beta1.VMSingle{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "single",
+			Namespace: "default",
+		},
+	}
+	f := func(spec *vmv1beta1.EmbeddedIngress, wantHosts []string, wantTLS []networkingv1.IngressTLS) {
+		t.Helper()
+		got := Ingress(cr, spec)
+		assert.Equal(t, cr.PrefixedName(), got.Name)
+		assert.Equal(t, cr.Namespace, got.Namespace)
+		assert.Equal(t, spec.ClassName, got.Spec.IngressClassName)
+		assert.Equal(t, spec.Annotations, got.Annotations)
+		for k, v := range cr.SelectorLabels() {
+			assert.Equal(t, v, got.Labels[k])
+		}
+		var gotHosts []string
+		for _, rule := range got.Spec.Rules {
+			gotHosts = append(gotHosts, rule.Host)
+			if rule.HTTP == nil {
+				continue
+			}
+			backend := rule.HTTP.Paths[0].Backend.Service
+			assert.Equal(t, cr.PrefixedName(), backend.Name)
+			assert.Equal(t, "http", backend.Port.Name)
+		}
+		assert.Equal(t, wantHosts, gotHosts)
+		assert.Equal(t, wantTLS, got.Spec.TLS)
+	}
+
+	// default rule
+	f(&vmv1beta1.EmbeddedIngress{
+		ClassName: ptr.To("nginx"),
+		Host:      "vm.example.com",
+	}, []string{"vm.example.com"}, nil)
+
+	// tls hosts with cert-manager annotation
+	f(&vmv1beta1.EmbeddedIngress{
+		EmbeddedObjectMetadata: vmv1beta1.EmbeddedObjectMetadata{
+			Annotations: map[string]string{"cert-manager.io/cluster-issuer": "letsencrypt"},
+		},
+		Host:          "ignored.example.com",
+		TlsHosts:      []string{"vm-1.example.com", "vm-2.example.com"},
+		TlsSecretName: "vm-tls",
+	}, []string{"vm-1.example.com", "vm-2.example.com"}, []networkingv1.IngressTLS{
+		{SecretName: "vm-tls", Hosts: []string{"vm-1.example.com", "vm-2.example.com"}},
+	})
+
+	// extra rules
+	f(&vmv1beta1.EmbeddedIngress{
+		ExtraRules: []networkingv1.IngressRule{{Host: "extra.example.com"}},
+		ExtraTLS:   []networkingv1.IngressTLS{{SecretName: "extra-tls"}},
+	}, []string{"", "extra.example.com"}, []networkingv1.IngressTLS{{SecretName: "extra-tls"}})
+}
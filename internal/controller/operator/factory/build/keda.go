@@ -0,0 +1,79 @@
+package build
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+const defaultKEDAThreshold = "104857600"
+
+// ScaledObjectGroupVersionKind defines KEDA ScaledObject kind
+// operator doesn't depend on KEDA client and manages it as unstructured object
+var ScaledObjectGroupVersionKind = schema.GroupVersionKind{
+	Group:   "keda.sh",
+	Version: "v1alpha1",
+	Kind:    "ScaledObject",
+}
+
+// NewScaledObject returns empty KEDA ScaledObject with the given name
+func NewScaledObject(name, namespace string) *unstructured.Unstructured {
+	so := &unstructured.Unstructured{}
+	so.SetGroupVersionKind(ScaledObjectGroupVersionKind)
+	so.SetName(name)
+	so.SetNamespace(namespace)
+	return so
+}
+
+// ScaledObject creates KEDA ScaledObject with prometheus trigger for the given scale target
+// defaultQuery is used if spec doesn't define custom query
+func ScaledObject(opts builderOpts, targetRef autoscalingv2.CrossVersionObjectReference, spec *vmv1beta1.VMAgentKEDA, defaultQuery string) *unstructured.Unstructured {
+	so := NewScaledObject(opts.PrefixedName(), opts.GetNamespace())
+	so.SetAnnotations(opts.AnnotationsFiltered())
+	so.SetLabels(opts.AllLabels())
+	so.SetOwnerReferences(opts.AsOwner())
+
+	query := spec.Query
+	if query == "" {
+		query = defaultQuery
+	}
+	threshold := spec.Threshold
+	if threshold == "" {
+		threshold = defaultKEDAThreshold
+	}
+	trigger := map[string]any{
+		"type": "prometheus",
+		"metadata": map[string]any{
+			"serverAddress": spec.ServerAddress,
+			"query":         query,
+			"threshold":     threshold,
+		},
+	}
+	if spec.AuthenticationRef != "" {
+		trigger["authenticationRef"] = map[string]any{
+			"name": spec.AuthenticationRef,
+		}
+	}
+	soSpec := map[string]any{
+		"scaleTargetRef": map[string]any{
+			"apiVersion": targetRef.APIVersion,
+			"kind":       targetRef.Kind,
+			"name":       targetRef.Name,
+		},
+		"maxReplicaCount": int64(spec.MaxReplicaCount),
+		"triggers":        []any{trigger},
+	}
+	if spec.MinReplicaCount != nil {
+		soSpec["minReplicaCount"] = int64(*spec.MinReplicaCount)
+	}
+	if spec.PollingInterval != nil {
+		soSpec["pollingInterval"] = int64(*spec.PollingInterval)
+	}
+	if spec.CooldownPeriod != nil {
+		soSpec["cooldownPeriod"] = int64(*spec.CooldownPeriod)
+	}
+	so.Object["spec"] = soSpec
+	return so
+}
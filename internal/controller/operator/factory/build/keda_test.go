@@ -0,0 +1,89 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestScaledObject(t *testing.T) {
+	cr := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "agent",
+			Namespace: "default",
+		},
+	}
+	targetRef := autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       cr.PrefixedName(),
+	}
+	wantTargetRef := map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"name":       "vmagent-agent",
+	}
+	f := func(spec *vmv1beta1.VMAgentKEDA, want map[string]any) {
+		t.Helper()
+		got := ScaledObject(cr, targetRef, spec, "default_query")
+		assert.Equal(t, ScaledObjectGroupVersionKind, got.GroupVersionKind())
+		assert.Equal(t, cr.PrefixedName(), got.GetName())
+		assert.Equal(t, cr.Namespace, got.GetNamespace())
+		assert.Equal(t, want, got.Object["spec"])
+	}
+
+	// default query and threshold
+	f(&vmv1beta1.VMAgentKEDA{
+		MaxReplicaCount: 5,
+		ServerAddress:   "http://vmsingle:8429",
+	}, map[string]any{
+		"scaleTargetRef":  wantTargetRef,
+		"maxReplicaCount": int64(5),
+		"triggers": []any{
+			map[string]any{
+				"type": "prometheus",
+				"metadata": map[string]any{
+					"serverAddress": "http://vmsingle:8429",
+					"query":         "default_query",
+					"threshold":     "104857600",
+				},
+			},
+		},
+	})
+
+	// all settings
+	f(&vmv1beta1.VMAgentKEDA{
+		MinReplicaCount:   ptr.To[int32](1),
+		MaxReplicaCount:   10,
+		PollingInterval:   ptr.To[int32](15),
+		CooldownPeriod:    ptr.To[int32](600),
+		ServerAddress:     "http://vmselect:8481/select/0/prometheus",
+		Query:             "sum(custom_metric)",
+		Threshold:         "1000",
+		AuthenticationRef: "vm-auth",
+	}, map[string]any{
+		"scaleTargetRef":  wantTargetRef,
+		"minReplicaCount": int64(1),
+		"maxReplicaCount": int64(10),
+		"pollingInterval": int64(15),
+		"cooldownPeriod":  int64(600),
+		"triggers": []any{
+			map[string]any{
+				"type": "prometheus",
+				"metadata": map[string]any{
+					"serverAddress": "http://vmselect:8481/select/0/prometheus",
+					"query":         "sum(custom_metric)",
+					"threshold":     "1000",
+				},
+				"authenticationRef": map[string]any{
+					"name": "vm-auth",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package build
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+)
+
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// NetworkPolicy creates NetworkPolicy object for given CRD
+// it allows ingress traffic to the given ports from operator namespace and pods managed by operator,
+// sources defined at spec.IngressFrom are allowed to access all ports.
+// empty ports allow access to all ports of the component
+func NetworkPolicy(cr builderOpts, spec *vmv1beta1.EmbeddedNetworkPolicy, ports []networkingv1.NetworkPolicyPort, extraRules ...networkingv1.NetworkPolicyIngressRule) *networkingv1.NetworkPolicy {
+	peers := []networkingv1.NetworkPolicyPeer{
+		{
+			// components managed by operator scrape metrics, write data and query each other
+			NamespaceSelector: &metav1.LabelSelector{},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"managed-by": "vm-operator"},
+			},
+		},
+	}
+	if ns := config.MustGetBaseConfig().OperatorNamespace; ns != "" {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{namespaceNameLabel: ns},
+			},
+		})
+	}
+	rules := []networkingv1.NetworkPolicyIngressRule{
+		{
+			From:  peers,
+			Ports: ports,
+		},
+	}
+	rules = append(rules, extraRules...)
+	if len(spec.IngressFrom) > 0 {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+			From: spec.IngressFrom,
+		})
+	}
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.PrefixedName(),
+			Annotations:     cr.AnnotationsFiltered(),
+			Labels:          cr.AllLabels(),
+			OwnerReferences: cr.AsOwner(),
+			Namespace:       cr.GetNSName(),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: cr.SelectorLabels(),
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     rules,
+		},
+	}
+}
+
+// NetworkPolicyPorts converts given container ports into NetworkPolicy ports with the given protocol
+func NetworkPolicyPorts(protocol corev1.Protocol, ports ...string) []networkingv1.NetworkPolicyPort {
+	npps := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		if port == "" {
+			continue
+		}
+		p := intstr.Parse(port)
+		npps = append(npps, networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &p,
+		})
+	}
+	return npps
+}
@@ -0,0 +1,64 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	cr := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "agent",
+			Namespace: "default",
+		},
+	}
+	managedPeer := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{},
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"managed-by": "vm-operator"},
+		},
+	}
+	f := func(spec *vmv1beta1.EmbeddedNetworkPolicy, ports []networkingv1.NetworkPolicyPort, extraRules []networkingv1.NetworkPolicyIngressRule, want []networkingv1.NetworkPolicyIngressRule) {
+		t.Helper()
+		got := NetworkPolicy(cr, spec, ports, extraRules...)
+		assert.Equal(t, cr.PrefixedName(), got.Name)
+		assert.Equal(t, cr.Namespace, got.Namespace)
+		assert.Equal(t, cr.SelectorLabels(), got.Spec.PodSelector.MatchLabels)
+		assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, got.Spec.PolicyTypes)
+		assert.Equal(t, want, got.Spec.Ingress)
+	}
+	// default rule for all ports
+	f(&vmv1beta1.EmbeddedNetworkPolicy{Enabled: true}, nil, nil, []networkingv1.NetworkPolicyIngressRule{
+		{From: []networkingv1.NetworkPolicyPeer{managedPeer}},
+	})
+
+	// restricted ports with extra rule and ingress sources
+	tcp := corev1.ProtocolTCP
+	webPort := intstr.FromInt32(8429)
+	meshPort := intstr.FromInt32(9094)
+	extraPeer := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "grafana"}},
+	}
+	extraRule := networkingv1.NetworkPolicyIngressRule{
+		From:  []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: cr.SelectorLabels()}}},
+		Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &meshPort}},
+	}
+	f(&vmv1beta1.EmbeddedNetworkPolicy{Enabled: true, IngressFrom: []networkingv1.NetworkPolicyPeer{extraPeer}},
+		NetworkPolicyPorts(corev1.ProtocolTCP, "8429", ""),
+		[]networkingv1.NetworkPolicyIngressRule{extraRule},
+		[]networkingv1.NetworkPolicyIngressRule{
+			{
+				From:  []networkingv1.NetworkPolicyPeer{managedPeer},
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &webPort}},
+			},
+			extraRule,
+			{From: []networkingv1.NetworkPolicyPeer{extraPeer}},
+		})
+}
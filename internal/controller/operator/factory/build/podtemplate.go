@@ -0,0 +1,52 @@
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// PodTemplateAddPatches applies user defined patches to the given pod template
+// it must be called after all operator defined modifications of pod template
+func PodTemplateAddPatches(dst *corev1.PodTemplateSpec, patches []vmv1beta1.PodTemplatePatch) error {
+	if len(patches) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(dst)
+	if err != nil {
+		return fmt.Errorf("cannot marshal pod template: %w", err)
+	}
+	for i, p := range patches {
+		patch, err := yaml.YAMLToJSON([]byte(p.Patch))
+		if err != nil {
+			return fmt.Errorf("cannot parse podTemplatePatches[%d]: %w", i, err)
+		}
+		switch p.Type {
+		case "", "strategic":
+			data, err = strategicpatch.StrategicMergePatch(data, patch, corev1.PodTemplateSpec{})
+		case "json":
+			var jp jsonpatch.Patch
+			jp, err = jsonpatch.DecodePatch(patch)
+			if err == nil {
+				data, err = jp.Apply(data)
+			}
+		default:
+			err = fmt.Errorf("unsupported patch type=%q, want strategic or json", p.Type)
+		}
+		if err != nil {
+			return fmt.Errorf("cannot apply podTemplatePatches[%d]: %w", i, err)
+		}
+	}
+	var patched corev1.PodTemplateSpec
+	if err := json.Unmarshal(data, &patched); err != nil {
+		return fmt.Errorf("cannot unmarshal patched pod template: %w", err)
+	}
+	*dst = patched
+	return nil
+}
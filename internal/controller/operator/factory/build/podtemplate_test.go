@@ -0,0 +1,71 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestPodTemplateAddPatches(t *testing.T) {
+	f := func(patches []vmv1beta1.PodTemplatePatch, want *corev1.PodTemplateSpec, wantErr bool) {
+		t.Helper()
+		dst := &corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "vmagent", Image: "victoriametrics/vmagent"},
+					{Name: "config-reloader", Image: "victoriametrics/operator"},
+				},
+			},
+		}
+		err := PodTemplateAddPatches(dst, patches)
+		if wantErr {
+			assert.Error(t, err)
+			return
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, want, dst)
+	}
+
+	// no patches
+	f(nil, &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "vmagent", Image: "victoriametrics/vmagent"},
+				{Name: "config-reloader", Image: "victoriametrics/operator"},
+			},
+		},
+	}, false)
+
+	// strategic merge patch in yaml and json patch
+	f([]vmv1beta1.PodTemplatePatch{
+		{Patch: `
+spec:
+  containers:
+  - name: config-reloader
+    stdin: true
+`},
+		{Type: "json", Patch: `[{"op": "add", "path": "/spec/hostUsers", "value": false}]`},
+	}, &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			HostUsers: ptr.To(false),
+			Containers: []corev1.Container{
+				{Name: "vmagent", Image: "victoriametrics/vmagent"},
+				{Name: "config-reloader", Image: "victoriametrics/operator", Stdin: true},
+			},
+		},
+	}, false)
+
+	// incorrect json patch
+	f([]vmv1beta1.PodTemplatePatch{
+		{Type: "json", Patch: `{"spec": {"hostUsers": false}}`},
+	}, nil, true)
+
+	// unsupported patch type
+	f([]vmv1beta1.PodTemplatePatch{
+		{Type: "merge", Patch: `{"spec": {"hostUsers": false}}`},
+	}, nil, true)
+}
@@ -0,0 +1,62 @@
+package build
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// RouteGroupVersionKind defines OpenShift Route kind
+// operator doesn't depend on OpenShift client and manages it as unstructured object
+var RouteGroupVersionKind = schema.GroupVersionKind{
+	Group:   "route.openshift.io",
+	Version: "v1",
+	Kind:    "Route",
+}
+
+// NewRoute returns empty OpenShift Route with the given name
+func NewRoute(name, namespace string) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(RouteGroupVersionKind)
+	route.SetName(name)
+	route.SetNamespace(namespace)
+	return route
+}
+
+// Route creates OpenShift Route for the main service of the given object
+// service must have port with name http
+func Route(cr builderOpts, spec *vmv1beta1.EmbeddedRoute) *unstructured.Unstructured {
+	route := NewRoute(cr.PrefixedName(), cr.GetNSName())
+	route.SetAnnotations(spec.Annotations)
+	route.SetLabels(labels.Merge(spec.Labels, cr.SelectorLabels()))
+	route.SetOwnerReferences(cr.AsOwner())
+
+	routeSpec := map[string]any{
+		"to": map[string]any{
+			"kind": "Service",
+			"name": cr.PrefixedName(),
+		},
+		"port": map[string]any{
+			"targetPort": "http",
+		},
+	}
+	if spec.Host != "" {
+		routeSpec["host"] = spec.Host
+	}
+	if spec.Path != "" {
+		routeSpec["path"] = spec.Path
+	}
+	if spec.TLSTermination != "" {
+		tls := map[string]any{
+			"termination": spec.TLSTermination,
+		}
+		if spec.InsecureEdgeTerminationPolicy != "" {
+			tls["insecureEdgeTerminationPolicy"] = spec.InsecureEdgeTerminationPolicy
+		}
+		routeSpec["tls"] = tls
+	}
+	route.Object["spec"] = routeSpec
+	return route
+}
@@ -0,0 +1,57 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestRoute(t *testing.T) {
+	cr := &vmv1beta1.VMAuth{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "auth",
+			Namespace: "default",
+		},
+	}
+	f := func(spec *vmv1beta1.EmbeddedRoute, want map[string]any) {
+		t.Helper()
+		got := Route(cr, spec)
+		assert.Equal(t, RouteGroupVersionKind, got.GroupVersionKind())
+		assert.Equal(t, cr.PrefixedName(), got.GetName())
+		assert.Equal(t, cr.Namespace, got.GetNamespace())
+		assert.Equal(t, want, got.Object["spec"])
+	}
+	wantTo := map[string]any{
+		"kind": "Service",
+		"name": "vmauth-auth",
+	}
+	wantPort := map[string]any{
+		"targetPort": "http",
+	}
+
+	// generated host
+	f(&vmv1beta1.EmbeddedRoute{}, map[string]any{
+		"to":   wantTo,
+		"port": wantPort,
+	})
+
+	// host with edge termination
+	f(&vmv1beta1.EmbeddedRoute{
+		Host:                          "vmauth.apps.example.com",
+		Path:                          "/",
+		TLSTermination:                "edge",
+		InsecureEdgeTerminationPolicy: "Redirect",
+	}, map[string]any{
+		"to":   wantTo,
+		"port": wantPort,
+		"host": "vmauth.apps.example.com",
+		"path": "/",
+		"tls": map[string]any{
+			"termination":                   "edge",
+			"insecureEdgeTerminationPolicy": "Redirect",
+		},
+	})
+}
@@ -2,6 +2,7 @@ package build
 
 import (
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 
 	corev1 "k8s.io/api/core/v1"
@@ -41,19 +42,53 @@ var (
 )
 
 // AddStrictSecuritySettingsToContainers conditionally adds Security settings to given containers
-func AddStrictSecuritySettingsToContainers(p *vmv1beta1.SecurityContext, containers []corev1.Container, enableStrictSecurity bool) {
+// strict security settings are taken from operator configuration for the given namespace
+func AddStrictSecuritySettingsToContainers(namespace string, p *vmv1beta1.SecurityContext, containers []corev1.Container, enableStrictSecurity bool) {
 	if !enableStrictSecurity && p == nil {
 		return
 	}
 	for idx := range containers {
 		container := &containers[idx]
-		container.SecurityContext = containerSecurityContext(p)
+		container.SecurityContext = containerSecurityContext(namespace, p)
 	}
 }
 
-func containerSecurityContext(p *vmv1beta1.SecurityContext) *corev1.SecurityContext {
+// strictSecurityUser returns user id for strict security context
+// OpenShift restricted SecurityContextConstraints assign ids from the namespace range,
+// so ids must be omitted
+func strictSecurityUser(namespace string) *int64 {
+	if k8stools.IsOpenShift() {
+		return nil
+	}
+	return config.MustGetBaseConfigForNamespace(namespace).StrictSecurityUser()
+}
+
+// strictSecurityContext returns default container security context
+// adjusted by operator strict security configuration
+func strictSecurityContext(namespace string) *corev1.SecurityContext {
+	c := config.MustGetBaseConfigForNamespace(namespace)
+	sc := defaultSecurityContext.DeepCopy()
+	sc.RunAsUser = strictSecurityUser(namespace)
+	sc.RunAsGroup = strictSecurityUser(namespace)
+	sc.ReadOnlyRootFilesystem = ptr.To(c.StrictSecurityReadOnlyRootFilesystem)
+	return sc
+}
+
+// strictPodSecurityContext returns default pod security context
+// adjusted by operator strict security configuration
+func strictPodSecurityContext(namespace string) *corev1.PodSecurityContext {
+	c := config.MustGetBaseConfigForNamespace(namespace)
+	sc := defaultPodSecurityContext.DeepCopy()
+	sc.RunAsUser = strictSecurityUser(namespace)
+	sc.RunAsGroup = strictSecurityUser(namespace)
+	sc.FSGroup = strictSecurityUser(namespace)
+	sc.SeccompProfile = c.StrictSecuritySeccomp()
+	return sc
+}
+
+func containerSecurityContext(namespace string, p *vmv1beta1.SecurityContext) *corev1.SecurityContext {
 	if p == nil {
-		return defaultSecurityContext
+		return strictSecurityContext(namespace)
 	}
 	var sc corev1.SecurityContext
 	if p.ContainerSecurityContext != nil {
@@ -75,15 +110,16 @@ func containerSecurityContext(p *vmv1beta1.SecurityContext) *corev1.SecurityCont
 }
 
 // AddStrictSecuritySettingsToPod conditionally creates security context for pod or returns predefined one
-func AddStrictSecuritySettingsToPod(p *vmv1beta1.SecurityContext, enableStrictSecurity bool) *corev1.PodSecurityContext {
+// strict security settings are taken from operator configuration for the given namespace
+func AddStrictSecuritySettingsToPod(namespace string, p *vmv1beta1.SecurityContext, enableStrictSecurity bool) *corev1.PodSecurityContext {
 	if p != nil {
 		return p.PodSecurityContext
 	}
 	if !enableStrictSecurity {
 		return nil
 	}
-	securityContext := defaultPodSecurityContext.DeepCopy()
-	if k8stools.IsFSGroupChangePolicySupported() {
+	securityContext := strictPodSecurityContext(namespace)
+	if securityContext.FSGroup != nil && k8stools.IsFSGroupChangePolicySupported() {
 		onRootMismatch := corev1.FSGroupChangeOnRootMismatch
 		securityContext.FSGroupChangePolicy = &onRootMismatch
 	}
@@ -1,9 +1,12 @@
 package build
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/stretchr/testify/assert"
 
@@ -78,7 +81,7 @@ func TestAddStrictSecuritySettingsToPod(t *testing.T) {
 				t.Fatalf("cannot set k8s version for testing: %q", err)
 			}
 		}()
-		res := AddStrictSecuritySettingsToPod(tt.args.podSecurityPolicy, tt.args.enableStrictSecurity)
+		res := AddStrictSecuritySettingsToPod("default", tt.args.podSecurityPolicy, tt.args.enableStrictSecurity)
 		if diff := deep.Equal(res, tt.args.exp); len(diff) > 0 {
 			t.Fatalf("got unexpected result: %v, expect: %v", res, tt.args.exp)
 		}
@@ -353,8 +356,89 @@ func TestAddStrictSecuritySettingsToContainers(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			AddStrictSecuritySettingsToContainers(tt.args.sc, tt.args.containers, tt.args.useStrictSecurity)
+			AddStrictSecuritySettingsToContainers("default", tt.args.sc, tt.args.containers, tt.args.useStrictSecurity)
 			assert.Equal(t, tt.expected, tt.args.containers)
 		})
 	}
 }
+
+func TestStrictSecurityConfig(t *testing.T) {
+	cfg := config.MustGetBaseConfig()
+	defaultCfg := *cfg
+	t.Cleanup(func() {
+		*cfg = defaultCfg
+	})
+	cfg.StrictSecurityRunAsUser = ""
+	cfg.StrictSecuritySeccompProfile = "Localhost/profiles/vm.json"
+	cfg.StrictSecurityReadOnlyRootFilesystem = false
+
+	assert.Equal(t, &corev1.PodSecurityContext{
+		RunAsNonRoot: ptr.To(true),
+		SeccompProfile: &corev1.SeccompProfile{
+			Type:             corev1.SeccompProfileTypeLocalhost,
+			LocalhostProfile: ptr.To("profiles/vm.json"),
+		},
+	}, AddStrictSecuritySettingsToPod("default", nil, true))
+
+	containers := []corev1.Container{{Name: "c1"}}
+	AddStrictSecuritySettingsToContainers("default", nil, containers, true)
+	assert.Equal(t, &corev1.SecurityContext{
+		RunAsNonRoot:             ptr.To(true),
+		Privileged:               ptr.To(false),
+		ReadOnlyRootFilesystem:   ptr.To(false),
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+	}, containers[0].SecurityContext)
+}
+
+func TestStrictSecurityConfigNamespaceOverrides(t *testing.T) {
+	dir := t.TempDir()
+	t.Cleanup(func() {
+		if _, err := config.ReloadBaseConfig(t.TempDir()); err != nil {
+			t.Fatalf("cannot restore config: %s", err)
+		}
+	})
+	if err := os.WriteFile(filepath.Join(dir, "team-a.VM_STRICTSECURITYRUNASUSER"), []byte("1000"), 0o644); err != nil {
+		t.Fatalf("cannot write file: %s", err)
+	}
+	if _, err := config.ReloadBaseConfig(dir); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// namespace override
+	psc := AddStrictSecuritySettingsToPod("team-a", nil, true)
+	assert.Equal(t, ptr.To[int64](1000), psc.RunAsUser)
+	assert.Equal(t, ptr.To[int64](1000), psc.FSGroup)
+	containers := []corev1.Container{{Name: "c1"}}
+	AddStrictSecuritySettingsToContainers("team-a", nil, containers, true)
+	assert.Equal(t, ptr.To[int64](1000), containers[0].SecurityContext.RunAsUser)
+
+	// namespace without overrides uses global config
+	psc = AddStrictSecuritySettingsToPod("team-b", nil, true)
+	assert.Equal(t, ptr.To[int64](65534), psc.RunAsUser)
+}
+
+func TestOpenShiftStrictSecurity(t *testing.T) {
+	if err := k8stools.SetOpenShift(nil, "true"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer func() {
+		_ = k8stools.SetOpenShift(nil, "false")
+	}()
+
+	// ids are assigned by restricted SecurityContextConstraints
+	psc := AddStrictSecuritySettingsToPod("default", nil, true)
+	assert.Nil(t, psc.RunAsUser)
+	assert.Nil(t, psc.RunAsGroup)
+	assert.Nil(t, psc.FSGroup)
+	assert.Nil(t, psc.FSGroupChangePolicy)
+	assert.Equal(t, ptr.To(true), psc.RunAsNonRoot)
+
+	containers := []corev1.Container{{Name: "c1"}}
+	AddStrictSecuritySettingsToContainers("default", nil, containers, true)
+	assert.Nil(t, containers[0].SecurityContext.RunAsUser)
+	assert.Nil(t, containers[0].SecurityContext.RunAsGroup)
+	assert.Equal(t, ptr.To(false), containers[0].SecurityContext.AllowPrivilegeEscalation)
+}
@@ -2,6 +2,7 @@ package build
 
 import (
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -33,10 +34,24 @@ func AdditionalServiceFromDefault(defaultSvc *corev1.Service, svcSpec *vmv1beta1
 	if result.Spec.Type == "" {
 		result.Spec.Type = defaultSvc.Spec.Type
 	}
+	inheritIPFamilies(&result.Spec, &defaultSvc.Spec)
 	// note clusterIP not checked, its users responsibility.
 	return result
 }
 
+// AdditionalServicesFromDefault builds additional services for each given spec
+// based on default service definition
+func AdditionalServicesFromDefault(defaultSvc *corev1.Service, svcSpecs []vmv1beta1.AdditionalServiceSpec) []*corev1.Service {
+	if len(svcSpecs) == 0 {
+		return nil
+	}
+	result := make([]*corev1.Service, 0, len(svcSpecs))
+	for i := range svcSpecs {
+		result = append(result, AdditionalServiceFromDefault(defaultSvc, &svcSpecs[i]))
+	}
+	return result
+}
+
 // Service builds service for the given args and applies optional callback for it
 func Service(cr builderOpts, defaultPort string, setOptions func(svc *corev1.Service)) *corev1.Service {
 	svc := &corev1.Service{
@@ -61,6 +76,7 @@ func Service(cr builderOpts, defaultPort string, setOptions func(svc *corev1.Ser
 			},
 		},
 	}
+	svc.Spec.IPFamilies, svc.Spec.IPFamilyPolicy = config.MustGetBaseConfigForNamespace(cr.GetNSName()).ServiceIPFamiliesPolicy()
 	if setOptions != nil {
 		setOptions(svc)
 	}
@@ -85,6 +101,11 @@ func Service(cr builderOpts, defaultPort string, setOptions func(svc *corev1.Ser
 		if serviceOverrides.Spec.ClusterIP == "" && serviceOverrides.Spec.Type == svc.Spec.Type {
 			serviceOverrides.Spec.ClusterIP = svc.Spec.ClusterIP
 		}
+		inheritIPFamilies(&serviceOverrides.Spec, &svc.Spec)
+		if serviceOverrides.Spec.SessionAffinity == "" {
+			serviceOverrides.Spec.SessionAffinity = svc.Spec.SessionAffinity
+			serviceOverrides.Spec.SessionAffinityConfig = svc.Spec.SessionAffinityConfig
+		}
 
 		serviceOverrides.Spec.Selector = svc.Spec.Selector
 		if len(serviceOverrides.Labels) > 0 {
@@ -100,6 +121,17 @@ func Service(cr builderOpts, defaultPort string, setOptions func(svc *corev1.Ser
 	return svc
 }
 
+// inheritIPFamilies sets ip families configuration of default service,
+// if it's not defined by user
+func inheritIPFamilies(dst, defaultSpec *corev1.ServiceSpec) {
+	if len(dst.IPFamilies) == 0 {
+		dst.IPFamilies = defaultSpec.IPFamilies
+	}
+	if dst.IPFamilyPolicy == nil {
+		dst.IPFamilyPolicy = defaultSpec.IPFamilyPolicy
+	}
+}
+
 // AppendInsertPortsToService conditionally appends insert ports to the given service definition
 func AppendInsertPortsToService(ip *vmv1beta1.InsertPorts, svc *corev1.Service) {
 	if ip == nil || svc == nil {
@@ -172,3 +204,38 @@ func AppendInsertPortsToService(ip *vmv1beta1.InsertPorts, svc *corev1.Service)
 			})
 	}
 }
+
+// OTLPServiceFromDefault builds dedicated service for OpenTelemetry ingestion from the given default service
+// it exposes otlp-http port, which targets http port of the application
+func OTLPServiceFromDefault(defaultSvc *corev1.Service, name string, otlp *vmv1beta1.OTLPIngestion) *corev1.Service {
+	if otlp == nil {
+		return nil
+	}
+	targetPort := intstr.FromString("http")
+	for _, port := range defaultSvc.Spec.Ports {
+		if port.Name == "http" {
+			targetPort = port.TargetPort
+			break
+		}
+	}
+	result := &corev1.Service{
+		ObjectMeta: *defaultSvc.ObjectMeta.DeepCopy(),
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: defaultSvc.Spec.Selector,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "otlp-http",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       otlp.GetPort(),
+					TargetPort: targetPort,
+				},
+			},
+		},
+	}
+	result.Name = name
+	// exclude service from the default VMServiceScrape selector
+	result.Labels = labels.Merge(result.Labels, map[string]string{vmv1beta1.AdditionalServiceLabel: "managed"})
+	inheritIPFamilies(&result.Spec, &defaultSvc.Spec)
+	return result
+}
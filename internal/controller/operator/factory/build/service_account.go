@@ -4,6 +4,7 @@ import (
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 type objectForServiceAccountBuilder interface {
@@ -16,14 +17,24 @@ type objectForServiceAccountBuilder interface {
 	PrefixedName() string
 }
 
+type objectWithCloudIdentity interface {
+	GetCloudIdentity() *vmv1beta1.CloudIdentity
+}
+
 // ServiceAccount builds service account for CRD
 func ServiceAccount(cr objectForServiceAccountBuilder) *v1.ServiceAccount {
+	annotations := cr.AnnotationsFiltered()
+	if ci, ok := cr.(objectWithCloudIdentity); ok {
+		if ciAnnotations := ci.GetCloudIdentity().ServiceAccountAnnotations(); len(ciAnnotations) > 0 {
+			annotations = labels.Merge(annotations, ciAnnotations)
+		}
+	}
 	return &v1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            cr.GetServiceAccountName(),
 			Namespace:       cr.GetNSName(),
 			Labels:          cr.AllLabels(),
-			Annotations:     cr.AnnotationsFiltered(),
+			Annotations:     annotations,
 			OwnerReferences: cr.AsOwner(),
 			Finalizers:      []string{vmv1beta1.FinalizerName},
 		},
@@ -5,9 +5,12 @@ import (
 	"testing"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func Test_mergeServiceSpec(t *testing.T) {
@@ -129,3 +132,151 @@ func Test_mergeServiceSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceIPFamilies(t *testing.T) {
+	cfg := config.MustGetBaseConfig()
+	defaultCfg := *cfg
+	t.Cleanup(func() {
+		*cfg = defaultCfg
+	})
+	cfg.ServiceIPFamilyPolicy = string(corev1.IPFamilyPolicyPreferDualStack)
+	cfg.ServiceIPFamilies = []string{"IPv6", "IPv4"}
+	wantFamilies := []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}
+	wantPolicy := ptr.To(corev1.IPFamilyPolicyPreferDualStack)
+
+	cr := &vmv1beta1.VMSingle{
+		ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "default"},
+	}
+	// headless service
+	svc := Service(cr, "8429", func(svc *corev1.Service) {
+		svc.Spec.ClusterIP = "None"
+	})
+	assert.Equal(t, wantFamilies, svc.Spec.IPFamilies)
+	assert.Equal(t, wantPolicy, svc.Spec.IPFamilyPolicy)
+
+	// additional service inherits ip families
+	additional := AdditionalServiceFromDefault(svc, &vmv1beta1.AdditionalServiceSpec{
+		Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort},
+	})
+	assert.Equal(t, wantFamilies, additional.Spec.IPFamilies)
+	assert.Equal(t, wantPolicy, additional.Spec.IPFamilyPolicy)
+
+	// user defined ip families have priority
+	cr.Spec.ServiceSpec = &vmv1beta1.AdditionalServiceSpec{
+		UseAsDefault: true,
+		Spec: corev1.ServiceSpec{
+			IPFamilies:     []corev1.IPFamily{corev1.IPv4Protocol},
+			IPFamilyPolicy: ptr.To(corev1.IPFamilyPolicySingleStack),
+		},
+	}
+	svc = Service(cr, "8429", nil)
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv4Protocol}, svc.Spec.IPFamilies)
+	assert.Equal(t, ptr.To(corev1.IPFamilyPolicySingleStack), svc.Spec.IPFamilyPolicy)
+}
+
+func TestAdditionalServicesFromDefault(t *testing.T) {
+	cr := &vmv1beta1.VMAuth{
+		ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: "default"},
+	}
+	svc := Service(cr, "8427", nil)
+	assert.Nil(t, AdditionalServicesFromDefault(svc, nil))
+
+	services := AdditionalServicesFromDefault(svc, []vmv1beta1.AdditionalServiceSpec{
+		{
+			EmbeddedObjectMetadata: vmv1beta1.EmbeddedObjectMetadata{Name: "auth-internal"},
+		},
+		{
+			EmbeddedObjectMetadata: vmv1beta1.EmbeddedObjectMetadata{
+				Name:        "auth-external",
+				Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"},
+			},
+			Spec: corev1.ServiceSpec{
+				Type:  corev1.ServiceTypeLoadBalancer,
+				Ports: []corev1.ServicePort{{Name: "https", Port: 443}},
+			},
+		},
+	})
+	assert.Len(t, services, 2)
+	assert.Equal(t, "auth-internal", services[0].Name)
+	assert.Equal(t, svc.Spec.Type, services[0].Spec.Type)
+	assert.Equal(t, svc.Spec.Ports, services[0].Spec.Ports)
+	assert.Equal(t, "auth-external", services[1].Name)
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, services[1].Spec.Type)
+	assert.Equal(t, []corev1.ServicePort{{Name: "https", Port: 443}}, services[1].Spec.Ports)
+	assert.Equal(t, "nlb", services[1].Annotations["service.beta.kubernetes.io/aws-load-balancer-type"])
+	assert.Equal(t, svc.Spec.Selector, services[1].Spec.Selector)
+	for _, s := range services {
+		assert.Equal(t, "managed", s.Labels[vmv1beta1.AdditionalServiceLabel])
+	}
+}
+
+func TestServicePublishNotReadyAndSessionAffinity(t *testing.T) {
+	cr := &vmv1beta1.VMAlertmanager{
+		ObjectMeta: metav1.ObjectMeta{Name: "am", Namespace: "default"},
+	}
+	setOptions := func(svc *corev1.Service) {
+		svc.Spec.ClusterIP = "None"
+		svc.Spec.SessionAffinity = corev1.ServiceAffinityNone
+	}
+	// not ready addresses are not published by default
+	svc := Service(cr, "9093", setOptions)
+	assert.False(t, svc.Spec.PublishNotReadyAddresses)
+
+	// default settings are kept for useAsDefault overrides
+	cr.Spec.ServiceSpec = &vmv1beta1.AdditionalServiceSpec{
+		UseAsDefault: true,
+		Spec: corev1.ServiceSpec{
+			PublishNotReadyAddresses: true,
+		},
+	}
+	svc = Service(cr, "9093", setOptions)
+	assert.True(t, svc.Spec.PublishNotReadyAddresses)
+	assert.Equal(t, corev1.ServiceAffinityNone, svc.Spec.SessionAffinity)
+	assert.Equal(t, "None", svc.Spec.ClusterIP)
+
+	sessionAffinityConfig := &corev1.SessionAffinityConfig{ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: ptr.To[int32](300)}}
+	single := &vmv1beta1.VMSingle{
+		ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "default"},
+		Spec: vmv1beta1.VMSingleSpec{
+			ServiceSpec: &vmv1beta1.AdditionalServiceSpec{
+				UseAsDefault: true,
+				Spec: corev1.ServiceSpec{
+					PublishNotReadyAddresses: true,
+					SessionAffinity:          corev1.ServiceAffinityClientIP,
+					SessionAffinityConfig:    sessionAffinityConfig,
+				},
+			},
+		},
+	}
+	svc = Service(single, "8429", nil)
+	assert.True(t, svc.Spec.PublishNotReadyAddresses)
+	assert.Equal(t, corev1.ServiceAffinityClientIP, svc.Spec.SessionAffinity)
+	assert.Equal(t, sessionAffinityConfig, svc.Spec.SessionAffinityConfig)
+}
+
+func TestOTLPServiceFromDefault(t *testing.T) {
+	cr := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+	}
+	svc := Service(cr, "8429", func(svc *corev1.Service) {
+		svc.Spec.ClusterIP = "None"
+	})
+	assert.Nil(t, OTLPServiceFromDefault(svc, cr.OTLPServiceName(), nil))
+
+	otlpSvc := OTLPServiceFromDefault(svc, cr.OTLPServiceName(), &vmv1beta1.OTLPIngestion{})
+	assert.Equal(t, "vmagent-agent-otlp", otlpSvc.Name)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, otlpSvc.Spec.Type)
+	assert.Empty(t, otlpSvc.Spec.ClusterIP)
+	assert.Equal(t, svc.Spec.Selector, otlpSvc.Spec.Selector)
+	assert.Equal(t, "managed", otlpSvc.Labels[vmv1beta1.AdditionalServiceLabel])
+	assert.NotContains(t, svc.Labels, vmv1beta1.AdditionalServiceLabel)
+	assert.Equal(t, []corev1.ServicePort{{
+		Name:       "otlp-http",
+		Protocol:   corev1.ProtocolTCP,
+		Port:       4318,
+		TargetPort: svc.Spec.Ports[0].TargetPort,
+	}}, otlpSvc.Spec.Ports)
+
+	otlpSvc = OTLPServiceFromDefault(svc, cr.OTLPServiceName(), &vmv1beta1.OTLPIngestion{Port: 14318})
+	assert.Equal(t, int32(14318), otlpSvc.Spec.Ports[0].Port)
+}
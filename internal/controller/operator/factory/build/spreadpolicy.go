@@ -0,0 +1,56 @@
+package build
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// addSpreadPolicy converts spread policy preset into topology spread constraint for the given pod selector
+// constraint explicitly defined for the same topology key has priority over preset
+func addSpreadPolicy(dst *corev1.PodSpec, policy *vmv1beta1.SpreadPolicy, selector *metav1.LabelSelector) {
+	if policy == nil {
+		return
+	}
+	topologyKey := policy.TopologyKey()
+	if slices.ContainsFunc(dst.TopologySpreadConstraints, func(tsc corev1.TopologySpreadConstraint) bool {
+		return tsc.TopologyKey == topologyKey
+	}) {
+		return
+	}
+	whenUnsatisfiable := corev1.ScheduleAnyway
+	if policy.Required {
+		whenUnsatisfiable = corev1.DoNotSchedule
+	}
+	// copy constraints in order to not modify CR spec
+	dst.TopologySpreadConstraints = append(slices.Clone(dst.TopologySpreadConstraints), corev1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       topologyKey,
+		WhenUnsatisfiable: whenUnsatisfiable,
+		LabelSelector:     selector.DeepCopy(),
+	})
+}
+
+// AddDefaultPodAntiAffinity adds preferred pod anti-affinity for pods with the given selector
+// it prevents scheduling of all replicas on a single node, affinity explicitly defined for pod has priority
+func AddDefaultPodAntiAffinity(dst *corev1.PodSpec, selector *metav1.LabelSelector) {
+	if dst.Affinity != nil {
+		return
+	}
+	dst.Affinity = &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: selector.DeepCopy(),
+						TopologyKey:   corev1.LabelHostname,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestAddSpreadPolicy(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "vmagent"}}
+	f := func(policy *vmv1beta1.SpreadPolicy, tscs, want []corev1.TopologySpreadConstraint) {
+		t.Helper()
+		spec := &corev1.PodSpec{TopologySpreadConstraints: tscs}
+		addSpreadPolicy(spec, policy, selector)
+		assert.Equal(t, want, spec.TopologySpreadConstraints)
+	}
+	userTSC := corev1.TopologySpreadConstraint{
+		MaxSkew:           2,
+		TopologyKey:       corev1.LabelHostname,
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+	}
+
+	// no policy
+	f(nil, nil, nil)
+	f(nil, []corev1.TopologySpreadConstraint{userTSC}, []corev1.TopologySpreadConstraint{userTSC})
+
+	// preferred zone spreading
+	f(&vmv1beta1.SpreadPolicy{Type: vmv1beta1.SpreadPolicyZone}, nil, []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelTopologyZone,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     selector,
+		},
+	})
+
+	// required node spreading
+	f(&vmv1beta1.SpreadPolicy{Type: vmv1beta1.SpreadPolicyNode, Required: true}, nil, []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelHostname,
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector:     selector,
+		},
+	})
+
+	// user defined constraint has priority
+	f(&vmv1beta1.SpreadPolicy{Type: vmv1beta1.SpreadPolicyNode}, []corev1.TopologySpreadConstraint{userTSC}, []corev1.TopologySpreadConstraint{userTSC})
+
+	// user defined constraint for the other key
+	f(&vmv1beta1.SpreadPolicy{Type: vmv1beta1.SpreadPolicyZone}, []corev1.TopologySpreadConstraint{userTSC}, []corev1.TopologySpreadConstraint{
+		userTSC,
+		{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelTopologyZone,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     selector,
+		},
+	})
+}
+
+func TestAddDefaultPodAntiAffinity(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "vmstorage"}}
+	f := func(affinity, want *corev1.Affinity) {
+		t.Helper()
+		spec := &corev1.PodSpec{Affinity: affinity}
+		AddDefaultPodAntiAffinity(spec, selector)
+		assert.Equal(t, want, spec.Affinity)
+	}
+
+	// default anti-affinity
+	f(nil, &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: selector,
+						TopologyKey:   corev1.LabelHostname,
+					},
+				},
+			},
+		},
+	})
+
+	// user defined affinity has priority
+	userAffinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "disktype", Operator: corev1.NodeSelectorOpIn, Values: []string{"ssd"}}}},
+				},
+			},
+		},
+	}
+	f(userAffinity, userAffinity)
+}
@@ -0,0 +1,135 @@
+package build
+
+import (
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SelfMonitoringVMRule builds VMRule with alerting rules for health of the application behind given service
+// rules select application metrics by job and namespace labels,
+// job label is set to the service name by generated VMServiceScrape, unless jobLabel is defined
+func SelfMonitoringVMRule(service *v1.Service, job, component string) *vmv1beta1.VMRule {
+	selector := fmt.Sprintf(`job=%q,namespace=%q`, job, service.Namespace)
+	rules := []vmv1beta1.Rule{
+		{
+			Alert:  "ServiceDown",
+			Expr:   fmt.Sprintf(`up{%s} == 0`, selector),
+			For:    "2m",
+			Labels: map[string]string{"severity": "critical"},
+			Annotations: map[string]string{
+				"summary":     "Service {{ $labels.job }} is down on {{ $labels.instance }}",
+				"description": "{{ $labels.instance }} of job {{ $labels.job }} has been down for more than 2 minutes.",
+			},
+		},
+		{
+			Alert:  "TooManyRestarts",
+			Expr:   fmt.Sprintf(`changes(process_start_time_seconds{%s}[15m]) > 2`, selector),
+			Labels: map[string]string{"severity": "critical"},
+			Annotations: map[string]string{
+				"summary":     "{{ $labels.job }} too many restarts (instance {{ $labels.instance }})",
+				"description": "Job {{ $labels.job }} (instance {{ $labels.instance }}) has restarted more than twice in the last 15 minutes.",
+			},
+		},
+	}
+	if component != "vmalertmanager" {
+		rules = append(rules, vmv1beta1.Rule{
+			Alert:  "TooManyLogs",
+			Expr:   fmt.Sprintf(`sum(increase(vm_log_messages_total{%s,level="error"}[5m])) without (app_version, location) > 0`, selector),
+			For:    "15m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "Too many logs printed for job {{ $labels.job }} ({{ $labels.instance }})",
+				"description": "Logging rate for job {{ $labels.job }} ({{ $labels.instance }}) is {{ $value }} for last 15m.",
+			},
+		})
+	}
+	switch component {
+	case "vmsingle", "vmstorage":
+		rules = append(rules, vmv1beta1.Rule{
+			Alert: "DiskRunsOutOfSpace",
+			Expr: fmt.Sprintf(`sum(vm_data_size_bytes{%[1]s}) by (job, instance) /
+(
+ sum(vm_free_disk_space_bytes{%[1]s}) by (job, instance) +
+ sum(vm_data_size_bytes{%[1]s}) by (job, instance)
+) > 0.8`, selector),
+			For:    "30m",
+			Labels: map[string]string{"severity": "critical"},
+			Annotations: map[string]string{
+				"summary":     "Instance {{ $labels.instance }} will run out of disk space soon",
+				"description": "Disk utilisation on instance {{ $labels.instance }} is more than 80%.",
+			},
+		})
+	case "vmagent":
+		rules = append(rules, vmv1beta1.Rule{
+			Alert:  "PersistentQueueIsDroppingData",
+			Expr:   fmt.Sprintf(`sum(increase(vm_persistentqueue_bytes_dropped_total{%s}[5m])) without (path) > 0`, selector),
+			For:    "10m",
+			Labels: map[string]string{"severity": "critical"},
+			Annotations: map[string]string{
+				"summary":     "Instance {{ $labels.instance }} is dropping data from persistent queue",
+				"description": "Vmagent dropped {{ $value | humanize1024 }} from persistent queue on instance {{ $labels.instance }} for the last 10m.",
+			},
+		}, vmv1beta1.Rule{
+			Alert:  "RemoteWriteDroppedPackets",
+			Expr:   fmt.Sprintf(`sum(increase(vmagent_remotewrite_packets_dropped_total{%s}[5m])) by (job, instance) > 0`, selector),
+			For:    "15m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "Job {{ $labels.job }} on instance {{ $labels.instance }} drops the rejected by remote-write server data blocks",
+				"description": "Check the logs to find the reason for rejects.",
+			},
+		})
+	case "vmalert":
+		rules = append(rules, vmv1beta1.Rule{
+			Alert:  "AlertingRulesError",
+			Expr:   fmt.Sprintf(`sum(increase(vmalert_alerting_rules_errors_total{%s}[5m])) without (alertname, id) > 0`, selector),
+			For:    "5m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "Alerting rules are failing for vmalert instance {{ $labels.instance }}",
+				"description": "Alerting rules execution is failing for group {{ $labels.group }}.",
+			},
+		}, vmv1beta1.Rule{
+			Alert:  "RecordingRulesError",
+			Expr:   fmt.Sprintf(`sum(increase(vmalert_recording_rules_errors_total{%s}[5m])) without (recording, id) > 0`, selector),
+			For:    "5m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "Recording rules are failing for vmalert instance {{ $labels.instance }}",
+				"description": "Recording rules execution is failing for group {{ $labels.group }}.",
+			},
+		})
+	case "vmalertmanager":
+		rules = append(rules, vmv1beta1.Rule{
+			Alert: "AlertmanagerFailedToSendAlerts",
+			Expr: fmt.Sprintf(`rate(alertmanager_notifications_failed_total{%[1]s}[5m])
+/
+ignoring (reason) group_left rate(alertmanager_notifications_total{%[1]s}[5m]) > 0.01`, selector),
+			For:    "5m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "Alertmanager instance {{ $labels.instance }} failed to send notifications",
+				"description": "Alertmanager {{ $labels.instance }} failed to send {{ $value | humanizePercentage }} of notifications to {{ $labels.integration }}.",
+			},
+		})
+	}
+	return &vmv1beta1.VMRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            job,
+			Namespace:       service.Namespace,
+			OwnerReferences: service.OwnerReferences,
+			Labels:          service.Labels,
+		},
+		Spec: vmv1beta1.VMRuleSpec{
+			Groups: []vmv1beta1.RuleGroup{
+				{
+					Name:  component + "-health",
+					Rules: rules,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelfMonitoringVMRule(t *testing.T) {
+	f := func(job, component string, wantAlerts []string) {
+		t.Helper()
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "vmselect-main-lb",
+				Namespace:       "monitoring",
+				Labels:          map[string]string{"app.kubernetes.io/name": component},
+				OwnerReferences: []metav1.OwnerReference{{Name: "main", Kind: "VMCluster"}},
+			},
+		}
+		got := SelfMonitoringVMRule(svc, job, component)
+		assert.Equal(t, job, got.Name)
+		assert.Equal(t, "monitoring", got.Namespace)
+		assert.Equal(t, svc.Labels, got.Labels)
+		assert.Equal(t, svc.OwnerReferences, got.OwnerReferences)
+		if len(got.Spec.Groups) != 1 {
+			t.Fatalf("expected 1 group, got: %d", len(got.Spec.Groups))
+		}
+		assert.Equal(t, component+"-health", got.Spec.Groups[0].Name)
+		var gotAlerts []string
+		for _, r := range got.Spec.Groups[0].Rules {
+			gotAlerts = append(gotAlerts, r.Alert)
+			assert.Contains(t, r.Expr, `job="`+job+`",namespace="monitoring"`)
+		}
+		assert.Equal(t, wantAlerts, gotAlerts)
+	}
+	f("vmselect-main", "vmselect", []string{"ServiceDown", "TooManyRestarts", "TooManyLogs"})
+	f("vmstorage-main", "vmstorage", []string{"ServiceDown", "TooManyRestarts", "TooManyLogs", "DiskRunsOutOfSpace"})
+	f("vmagent-main", "vmagent", []string{"ServiceDown", "TooManyRestarts", "TooManyLogs", "PersistentQueueIsDroppingData", "RemoteWriteDroppedPackets"})
+	f("vmalert-main", "vmalert", []string{"ServiceDown", "TooManyRestarts", "TooManyLogs", "AlertingRulesError", "RecordingRulesError"})
+	f("vmalertmanager-main", "vmalertmanager", []string{"ServiceDown", "TooManyRestarts", "AlertmanagerFailedToSendAlerts"})
+}
@@ -1,6 +1,9 @@
 package build
 
 import (
+	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
@@ -14,6 +17,77 @@ type serviceScrapeBuilder interface {
 	GetMetricPath() string
 }
 
+// ServiceScrapeDefaults defines default params for generated VMServiceScrape endpoints
+// it's applied to user defined endpoints with the same port, if corresponding param is not set
+type ServiceScrapeDefaults struct {
+	// Scheme overrides scheme detected from tls flag
+	Scheme string
+	// TLSConfig overrides insecure TLSConfig used for tls flag
+	TLSConfig            *vmv1beta1.TLSConfig
+	Interval             string
+	MetricRelabelConfigs []*vmv1beta1.RelabelConfig
+	// Ports filters service ports for generated endpoints
+	// in addition to filterPortNames
+	Ports ServicePortFilter
+}
+
+// WithServerTLS returns defaults for the application with given serverTLS
+//
+// certificate provisioned by operator with serverTLS.selfSigned is verified with its CA and service DNS name.
+// Certificates issued by external CA could have arbitrary DNS names and skip verification,
+// it could be changed with tlsConfig of user defined endpoint
+func (d ServiceScrapeDefaults) WithServerTLS(st *vmv1beta1.ServerTLS, service *v1.Service) ServiceScrapeDefaults {
+	if st == nil {
+		return d
+	}
+	d.Scheme = "https"
+	if !st.SelfSigned {
+		return d
+	}
+	d.TLSConfig = &vmv1beta1.TLSConfig{
+		CA: vmv1beta1.SecretOrConfigMap{
+			Secret: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: st.SecretName},
+				Key:                  vmv1beta1.ServerTLSCAKey,
+			},
+		},
+		ServerName: fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace),
+	}
+	return d
+}
+
+// ServicePortFilter selects service ports by name or appProtocol
+// port is selected if it matches any of defined conditions
+type ServicePortFilter struct {
+	Names        []string
+	NameRegex    *regexp.Regexp
+	AppProtocols []string
+	// Exclude skips matched ports, even if they were selected
+	Exclude *ServicePortFilter
+}
+
+func (pf *ServicePortFilter) isEmpty() bool {
+	return len(pf.Names) == 0 && pf.NameRegex == nil && len(pf.AppProtocols) == 0
+}
+
+func (pf *ServicePortFilter) matches(port v1.ServicePort) bool {
+	if slices.Contains(pf.Names, port.Name) {
+		return true
+	}
+	if pf.NameRegex != nil && pf.NameRegex.MatchString(port.Name) {
+		return true
+	}
+	return port.AppProtocol != nil && slices.Contains(pf.AppProtocols, *port.AppProtocol)
+}
+
+// allows checks if port must be used for generated endpoint
+func (pf *ServicePortFilter) allows(port v1.ServicePort) bool {
+	if !pf.isEmpty() && !pf.matches(port) {
+		return false
+	}
+	return pf.Exclude == nil || !pf.Exclude.matches(port)
+}
+
 // VMServiceScrapeForServiceWithSpec build VMServiceScrape for VMAlertmanager
 func VMServiceScrapeForAlertmanager(service *v1.Service, amCR *vmv1beta1.VMAlertmanager) *vmv1beta1.VMServiceScrape {
 	var extraArgs map[string]string
@@ -26,17 +100,23 @@ func VMServiceScrapeForAlertmanager(service *v1.Service, amCR *vmv1beta1.VMAlert
 			"tls": "true",
 		}
 	}
-	return vmServiceScrapeForServiceWithSpec(service, amCR.GetServiceScrape(), extraArgs, amCR.GetMetricPath(), "http")
+	return vmServiceScrapeForServiceWithSpec(service, amCR.GetServiceScrape(), extraArgs, amCR.GetMetricPath(), ServiceScrapeDefaults{}, "http")
 }
 
 func VMServiceScrapeForServiceWithSpec(service *v1.Service, builder serviceScrapeBuilder, filterPortNames ...string) *vmv1beta1.VMServiceScrape {
+	return VMServiceScrapeForServiceWithDefaults(service, builder, ServiceScrapeDefaults{}, filterPortNames...)
+}
+
+// VMServiceScrapeForServiceWithDefaults build VMServiceScrape for given service
+// and applies given defaults to generated endpoints
+func VMServiceScrapeForServiceWithDefaults(service *v1.Service, builder serviceScrapeBuilder, defaults ServiceScrapeDefaults, filterPortNames ...string) *vmv1beta1.VMServiceScrape {
 	serviceScrapeSpec, extraArgs, metricPath := builder.GetServiceScrape(), builder.GetExtraArgs(), builder.GetMetricPath()
-	return vmServiceScrapeForServiceWithSpec(service, serviceScrapeSpec, extraArgs, metricPath, filterPortNames...)
+	return vmServiceScrapeForServiceWithSpec(service, serviceScrapeSpec, extraArgs, metricPath, defaults, filterPortNames...)
 }
 
 // VMServiceScrapeForServiceWithSpec build VMServiceScrape for given service with optional spec
 // optionally could filter out ports from service
-func vmServiceScrapeForServiceWithSpec(service *v1.Service, serviceScrapeSpec *vmv1beta1.VMServiceScrapeSpec, extraArgs map[string]string, metricPath string, filterPortNames ...string) *vmv1beta1.VMServiceScrape {
+func vmServiceScrapeForServiceWithSpec(service *v1.Service, serviceScrapeSpec *vmv1beta1.VMServiceScrapeSpec, extraArgs map[string]string, metricPath string, defaults ServiceScrapeDefaults, filterPortNames ...string) *vmv1beta1.VMServiceScrape {
 	var endPoints []vmv1beta1.Endpoint
 	var isTLS bool
 	v, ok := extraArgs["tls"]
@@ -50,23 +130,22 @@ func vmServiceScrapeForServiceWithSpec(service *v1.Service, serviceScrapeSpec *v
 		isTLS = strings.ToLower(v) == "true"
 	}
 	authKey := extraArgs["metricsAuthKey"]
+	portFilter := defaults.Ports
+	portFilter.Names = append(slices.Clone(portFilter.Names), filterPortNames...)
 
 	for _, servicePort := range service.Spec.Ports {
-		var nameMatched bool
-		for _, filter := range filterPortNames {
-			if servicePort.Name == filter {
-				nameMatched = true
-				break
-			}
-		}
-		if len(filterPortNames) > 0 && !nameMatched {
+		if !portFilter.allows(servicePort) {
 			continue
 		}
 
 		ep := vmv1beta1.Endpoint{
 			Port: servicePort.Name,
 			EndpointScrapeParams: vmv1beta1.EndpointScrapeParams{
-				Path: metricPath,
+				Path:     metricPath,
+				Interval: defaults.Interval,
+			},
+			EndpointRelabelings: vmv1beta1.EndpointRelabelings{
+				MetricRelabelConfigs: defaults.MetricRelabelConfigs,
 			},
 		}
 		if isTLS {
@@ -77,6 +156,12 @@ func vmServiceScrapeForServiceWithSpec(service *v1.Service, serviceScrapeSpec *v
 				InsecureSkipVerify: true,
 			}
 		}
+		if defaults.Scheme != "" {
+			ep.Scheme = defaults.Scheme
+		}
+		if defaults.TLSConfig != nil {
+			ep.TLSConfig = defaults.TLSConfig.DeepCopy()
+		}
 		if len(authKey) > 0 {
 			ep.Params = map[string][]string{
 				"authKey": {authKey},
@@ -109,6 +194,18 @@ func vmServiceScrapeForServiceWithSpec(service *v1.Service, serviceScrapeSpec *v
 				if eps.Path == "" {
 					eps.Path = generatedEP.Path
 				}
+				if eps.Scheme == "" {
+					eps.Scheme = generatedEP.Scheme
+				}
+				if eps.TLSConfig == nil {
+					eps.TLSConfig = generatedEP.TLSConfig
+				}
+				if eps.Interval == "" {
+					eps.Interval = generatedEP.Interval
+				}
+				if len(eps.MetricRelabelConfigs) == 0 {
+					eps.MetricRelabelConfigs = generatedEP.MetricRelabelConfigs
+				}
 			}
 		}
 		if !found {
@@ -126,3 +223,28 @@ func vmServiceScrapeForServiceWithSpec(service *v1.Service, serviceScrapeSpec *v
 
 	return scrapeSvc
 }
+
+// VMServiceScrapeForOperator builds VMServiceScrape for operator metrics service
+// service is selected with given label selector, e.g. app.kubernetes.io/name=vm-operator
+func VMServiceScrapeForOperator(namespace, serviceSelector string) (*vmv1beta1.VMServiceScrape, error) {
+	selector, err := metav1.ParseToLabelSelector(serviceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse operator service selector=%q: %w", serviceSelector, err)
+	}
+	return &vmv1beta1.VMServiceScrape{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vm-operator",
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "vm-operator",
+				"app.kubernetes.io/managed-by": "vm-operator",
+			},
+		},
+		Spec: vmv1beta1.VMServiceScrapeSpec{
+			Selector: *selector,
+			Endpoints: []vmv1beta1.Endpoint{
+				{Port: "http"},
+			},
+		},
+	}, nil
+}
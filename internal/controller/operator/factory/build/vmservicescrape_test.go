@@ -1,12 +1,14 @@
 package build
 
 import (
+	"regexp"
 	"testing"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 type testVMServiceScrapeForServiceWithSpecArgs struct {
@@ -248,3 +250,156 @@ func TestVMServiceScrapeForServiceWithSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestVMServiceScrapeForOperator(t *testing.T) {
+	f := func(selector string, wantMatchLabels map[string]string, wantErr bool) {
+		t.Helper()
+		got, err := VMServiceScrapeForOperator("monitoring", selector)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if wantErr {
+			return
+		}
+		assert.Equal(t, "vm-operator", got.Name)
+		assert.Equal(t, "monitoring", got.Namespace)
+		assert.Equal(t, wantMatchLabels, got.Spec.Selector.MatchLabels)
+		assert.Equal(t, []vmv1beta1.Endpoint{{Port: "http"}}, got.Spec.Endpoints)
+	}
+	f("app.kubernetes.io/name=vm-operator", map[string]string{"app.kubernetes.io/name": "vm-operator"}, false)
+	f("control-plane=vm-operator,app.kubernetes.io/instance=vm", map[string]string{
+		"control-plane":              "vm-operator",
+		"app.kubernetes.io/instance": "vm",
+	}, false)
+	f("app.kubernetes.io/name in (vm-operator", nil, true)
+}
+
+func TestVMServiceScrapeForServiceWithDefaults(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "vmsingle-svc", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "vmsingle"},
+			Ports:    []corev1.ServicePort{{Name: "http"}},
+		},
+	}
+	dropGo := []*vmv1beta1.RelabelConfig{{Action: "drop", SourceLabels: []string{"__name__"}, Regex: vmv1beta1.StringOrArray{"go_.*"}}}
+	f := func(args testVMServiceScrapeForServiceWithSpecArgs, defaults ServiceScrapeDefaults, want []vmv1beta1.Endpoint) {
+		t.Helper()
+		got := VMServiceScrapeForServiceWithDefaults(svc, &args, defaults)
+		assert.Equal(t, want, got.Spec.Endpoints)
+	}
+
+	// defaults for generated endpoint
+	f(testVMServiceScrapeForServiceWithSpecArgs{metricPath: "/metrics"}, ServiceScrapeDefaults{
+		Scheme:               "https",
+		TLSConfig:            &vmv1beta1.TLSConfig{ServerName: "vmsingle.default.svc"},
+		Interval:             "10s",
+		MetricRelabelConfigs: dropGo,
+	}, []vmv1beta1.Endpoint{{
+		Port:                 "http",
+		EndpointScrapeParams: vmv1beta1.EndpointScrapeParams{Path: "/metrics", Interval: "10s", Scheme: "https"},
+		EndpointAuth:         vmv1beta1.EndpointAuth{TLSConfig: &vmv1beta1.TLSConfig{ServerName: "vmsingle.default.svc"}},
+		EndpointRelabelings:  vmv1beta1.EndpointRelabelings{MetricRelabelConfigs: dropGo},
+	}})
+
+	// tls flag params are applied to user defined endpoint
+	f(testVMServiceScrapeForServiceWithSpecArgs{
+		metricPath: "/metrics",
+		extraArgs:  map[string]string{"tls": "true"},
+		serviceScrapeSpecTemplate: &vmv1beta1.VMServiceScrapeSpec{
+			Endpoints: []vmv1beta1.Endpoint{{
+				Port:                "http",
+				EndpointRelabelings: vmv1beta1.EndpointRelabelings{MetricRelabelConfigs: dropGo},
+			}},
+		},
+	}, ServiceScrapeDefaults{Interval: "30s"}, []vmv1beta1.Endpoint{{
+		Port:                 "http",
+		EndpointScrapeParams: vmv1beta1.EndpointScrapeParams{Path: "/metrics", Interval: "30s", Scheme: "https"},
+		EndpointAuth:         vmv1beta1.EndpointAuth{TLSConfig: &vmv1beta1.TLSConfig{InsecureSkipVerify: true}},
+		EndpointRelabelings:  vmv1beta1.EndpointRelabelings{MetricRelabelConfigs: dropGo},
+	}})
+
+	// user defined params have priority over defaults
+	f(testVMServiceScrapeForServiceWithSpecArgs{
+		metricPath: "/metrics",
+		extraArgs:  map[string]string{"tls": "true"},
+		serviceScrapeSpecTemplate: &vmv1beta1.VMServiceScrapeSpec{
+			Endpoints: []vmv1beta1.Endpoint{{
+				Port:                 "http",
+				EndpointScrapeParams: vmv1beta1.EndpointScrapeParams{Interval: "1m", Scheme: "http"},
+				EndpointAuth:         vmv1beta1.EndpointAuth{TLSConfig: &vmv1beta1.TLSConfig{CAFile: "/etc/ca.crt"}},
+			}},
+		},
+	}, ServiceScrapeDefaults{Interval: "30s", MetricRelabelConfigs: dropGo}, []vmv1beta1.Endpoint{{
+		Port:                 "http",
+		EndpointScrapeParams: vmv1beta1.EndpointScrapeParams{Path: "/metrics", Interval: "1m", Scheme: "http"},
+		EndpointAuth:         vmv1beta1.EndpointAuth{TLSConfig: &vmv1beta1.TLSConfig{CAFile: "/etc/ca.crt"}},
+		EndpointRelabelings:  vmv1beta1.EndpointRelabelings{MetricRelabelConfigs: dropGo},
+	}})
+
+	// self-signed serverTLS certificate is verified
+	f(testVMServiceScrapeForServiceWithSpecArgs{
+		metricPath: "/metrics",
+		extraArgs:  map[string]string{"tls": "true"},
+	}, ServiceScrapeDefaults{}.WithServerTLS(&vmv1beta1.ServerTLS{SecretName: "vmsingle-tls", SelfSigned: true}, svc), []vmv1beta1.Endpoint{{
+		Port:                 "http",
+		EndpointScrapeParams: vmv1beta1.EndpointScrapeParams{Path: "/metrics", Scheme: "https"},
+		EndpointAuth: vmv1beta1.EndpointAuth{TLSConfig: &vmv1beta1.TLSConfig{
+			CA: vmv1beta1.SecretOrConfigMap{Secret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "vmsingle-tls"},
+				Key:                  "ca.crt",
+			}},
+			ServerName: "vmsingle-svc.default.svc",
+		}},
+	}})
+
+	// serverTLS certificate issued by external CA
+	f(testVMServiceScrapeForServiceWithSpecArgs{
+		metricPath: "/metrics",
+		extraArgs:  map[string]string{"tls": "true"},
+	}, ServiceScrapeDefaults{Interval: "30s"}.WithServerTLS(&vmv1beta1.ServerTLS{SecretName: "vmsingle-tls"}, svc), []vmv1beta1.Endpoint{{
+		Port:                 "http",
+		EndpointScrapeParams: vmv1beta1.EndpointScrapeParams{Path: "/metrics", Interval: "30s", Scheme: "https"},
+		EndpointAuth:         vmv1beta1.EndpointAuth{TLSConfig: &vmv1beta1.TLSConfig{InsecureSkipVerify: true}},
+	}})
+}
+
+func TestVMServiceScrapeForServiceWithPortFilter(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "vmalertmanager-svc", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http"},
+				{Name: "http-metrics", AppProtocol: ptr.To("http")},
+				{Name: "web", AppProtocol: ptr.To("http")},
+				{Name: "grpc", AppProtocol: ptr.To("grpc")},
+				{Name: "tcp-mesh"},
+				{Name: "udp-mesh"},
+			},
+		},
+	}
+	f := func(ports ServicePortFilter, filterPortNames []string, wantPorts []string) {
+		t.Helper()
+		got := VMServiceScrapeForServiceWithDefaults(svc, &testVMServiceScrapeForServiceWithSpecArgs{}, ServiceScrapeDefaults{Ports: ports}, filterPortNames...)
+		var gotPorts []string
+		for _, ep := range got.Spec.Endpoints {
+			gotPorts = append(gotPorts, ep.Port)
+		}
+		assert.Equal(t, wantPorts, gotPorts)
+	}
+
+	// no filter
+	f(ServicePortFilter{}, nil, []string{"http", "http-metrics", "web", "grpc", "tcp-mesh", "udp-mesh"})
+
+	// by name regex
+	f(ServicePortFilter{NameRegex: regexp.MustCompile("^http")}, nil, []string{"http", "http-metrics"})
+
+	// by appProtocol combined with names
+	f(ServicePortFilter{AppProtocols: []string{"http"}}, []string{"http"}, []string{"http", "http-metrics", "web"})
+
+	// exclude only
+	f(ServicePortFilter{Exclude: &ServicePortFilter{NameRegex: regexp.MustCompile("-mesh$"), AppProtocols: []string{"grpc"}}}, nil, []string{"http", "http-metrics", "web"})
+
+	// exclude has priority
+	f(ServicePortFilter{AppProtocols: []string{"http"}, Exclude: &ServicePortFilter{Names: []string{"web"}}}, nil, []string{"http-metrics"})
+}
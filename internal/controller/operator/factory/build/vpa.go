@@ -0,0 +1,79 @@
+package build
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// VPAGroupVersionKind defines VerticalPodAutoscaler kind
+// operator doesn't depend on VPA client and manages it as unstructured object
+var VPAGroupVersionKind = schema.GroupVersionKind{
+	Group:   "autoscaling.k8s.io",
+	Version: "v1",
+	Kind:    "VerticalPodAutoscaler",
+}
+
+// NewVPA returns empty VerticalPodAutoscaler object with the given name
+func NewVPA(name, namespace string) *unstructured.Unstructured {
+	vpa := &unstructured.Unstructured{}
+	vpa.SetGroupVersionKind(VPAGroupVersionKind)
+	vpa.SetName(name)
+	vpa.SetNamespace(namespace)
+	return vpa
+}
+
+// VPA creates VerticalPodAutoscaler object for the given workload
+// recommendations are applied to the given container only,
+// other containers, like config-reloader or sidecars, are not managed by VPA
+func VPA(opts builderOpts, targetRef autoscalingv2.CrossVersionObjectReference, containerName string, spec *vmv1beta1.EmbeddedVPA) *unstructured.Unstructured {
+	vpa := NewVPA(targetRef.Name, opts.GetNamespace())
+	vpa.SetAnnotations(opts.AnnotationsFiltered())
+	vpa.SetLabels(opts.AllLabels())
+	vpa.SetOwnerReferences(opts.AsOwner())
+
+	containerPolicy := map[string]any{
+		"containerName":       containerName,
+		"controlledResources": []any{string(corev1.ResourceCPU), string(corev1.ResourceMemory)},
+	}
+	if len(spec.MinAllowed) > 0 {
+		containerPolicy["minAllowed"] = resourceListToUnstructured(spec.MinAllowed)
+	}
+	if len(spec.MaxAllowed) > 0 {
+		containerPolicy["maxAllowed"] = resourceListToUnstructured(spec.MaxAllowed)
+	}
+	vpaSpec := map[string]any{
+		"targetRef": map[string]any{
+			"apiVersion": targetRef.APIVersion,
+			"kind":       targetRef.Kind,
+			"name":       targetRef.Name,
+		},
+		"resourcePolicy": map[string]any{
+			"containerPolicies": []any{
+				containerPolicy,
+				map[string]any{
+					"containerName": "*",
+					"mode":          "Off",
+				},
+			},
+		},
+	}
+	if spec.UpdateMode != "" {
+		vpaSpec["updatePolicy"] = map[string]any{
+			"updateMode": spec.UpdateMode,
+		}
+	}
+	vpa.Object["spec"] = vpaSpec
+	return vpa
+}
+
+func resourceListToUnstructured(rl corev1.ResourceList) map[string]any {
+	dst := make(map[string]any, len(rl))
+	for name, q := range rl {
+		dst[string(name)] = q.String()
+	}
+	return dst
+}
@@ -0,0 +1,82 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestVPA(t *testing.T) {
+	cr := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "agent",
+			Namespace: "default",
+		},
+	}
+	targetRef := autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       cr.PrefixedName(),
+	}
+	f := func(spec *vmv1beta1.EmbeddedVPA, want map[string]any) {
+		t.Helper()
+		got := VPA(cr, targetRef, "vmagent", spec)
+		assert.Equal(t, VPAGroupVersionKind, got.GroupVersionKind())
+		assert.Equal(t, cr.PrefixedName(), got.GetName())
+		assert.Equal(t, cr.Namespace, got.GetNamespace())
+		assert.Equal(t, want, got.Object["spec"])
+	}
+	wantTargetRef := map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"name":       "vmagent-agent",
+	}
+	offPolicy := map[string]any{
+		"containerName": "*",
+		"mode":          "Off",
+	}
+
+	// recommendations only with default update mode
+	f(&vmv1beta1.EmbeddedVPA{}, map[string]any{
+		"targetRef": wantTargetRef,
+		"resourcePolicy": map[string]any{
+			"containerPolicies": []any{
+				map[string]any{
+					"containerName":       "vmagent",
+					"controlledResources": []any{"cpu", "memory"},
+				},
+				offPolicy,
+			},
+		},
+	})
+
+	// update mode with resource bounds
+	f(&vmv1beta1.EmbeddedVPA{
+		UpdateMode: "Auto",
+		MinAllowed: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		MaxAllowed: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		},
+	}, map[string]any{
+		"targetRef": wantTargetRef,
+		"resourcePolicy": map[string]any{
+			"containerPolicies": []any{
+				map[string]any{
+					"containerName":       "vmagent",
+					"controlledResources": []any{"cpu", "memory"},
+					"minAllowed":          map[string]any{"cpu": "100m"},
+					"maxAllowed":          map[string]any{"cpu": "2", "memory": "4Gi"},
+				},
+				offPolicy,
+			},
+		},
+		"updatePolicy": map[string]any{"updateMode": "Auto"},
+	})
+}
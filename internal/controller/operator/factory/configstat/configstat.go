@@ -0,0 +1,91 @@
+package configstat
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	generationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "operator_config_generation_duration_seconds",
+		Help:    "Duration of configuration generation for the application",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 2, 5, 10, 30},
+	}, []string{"controller"})
+	generatedSizeBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "operator_generated_config_size_bytes",
+		Help: "Size of the last generated configuration for the application",
+	}, []string{"controller", "namespaced_name"})
+	compressedSizeBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "operator_generated_config_compressed_size_bytes",
+		Help: "Size of the last generated configuration for the application after compression",
+	}, []string{"controller", "namespaced_name"})
+	selectedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "operator_config_selected_objects",
+		Help: "Number of objects selected for the last generated configuration by kind",
+	}, []string{"controller", "namespaced_name", "kind"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(generationDuration, generatedSizeBytes, compressedSizeBytes, selectedObjects)
+}
+
+// ObserveGeneration records duration of configuration generation started at startTime
+// and size of the generated configuration
+func ObserveGeneration(ctx context.Context, controller string, cr client.Object, startTime time.Time, size int) {
+	nsn := cr.GetNamespace() + "/" + cr.GetName()
+	ObserveWithExemplar(ctx, generationDuration.WithLabelValues(controller), time.Since(startTime).Seconds(), nsn)
+	generatedSizeBytes.WithLabelValues(controller, nsn).Set(float64(size))
+}
+
+// ObserveWithExemplar observes value with exemplar, which refers to the object and trace of the given context.
+// Histograms are labeled only by controller in order to limit cardinality,
+// exemplars allow to find the object of slow observation.
+func ObserveWithExemplar(ctx context.Context, o prometheus.Observer, value float64, namespacedName string) {
+	eo, ok := o.(prometheus.ExemplarObserver)
+	if !ok {
+		o.Observe(value)
+		return
+	}
+	exemplar := prometheus.Labels{}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		exemplar["trace_id"] = sc.TraceID().String()
+	}
+	exemplar["namespaced_name"] = namespacedName
+	if exemplarRunes(exemplar) > prometheus.ExemplarMaxRunes {
+		// exemplar with too long labels is rejected, object could be found by trace
+		delete(exemplar, "namespaced_name")
+	}
+	eo.ObserveWithExemplar(value, exemplar)
+}
+
+func exemplarRunes(labels prometheus.Labels) int {
+	var n int
+	for k, v := range labels {
+		n += utf8.RuneCountInString(k) + utf8.RuneCountInString(v)
+	}
+	return n
+}
+
+// SetCompressedSize records size of the generated configuration after compression
+func SetCompressedSize(controller string, cr client.Object, size int) {
+	compressedSizeBytes.WithLabelValues(controller, cr.GetNamespace()+"/"+cr.GetName()).Set(float64(size))
+}
+
+// SetSelectedObjects records number of objects of the given kind selected for configuration
+func SetSelectedObjects(controller string, cr client.Object, kind string, count int) {
+	selectedObjects.WithLabelValues(controller, cr.GetNamespace()+"/"+cr.GetName(), kind).Set(float64(count))
+}
+
+// Deregister removes metrics of the deleted object
+func Deregister(controller, namespace, name string) {
+	labels := prometheus.Labels{"controller": controller, "namespaced_name": namespace + "/" + name}
+	generatedSizeBytes.DeletePartialMatch(labels)
+	compressedSizeBytes.DeletePartialMatch(labels)
+	selectedObjects.DeletePartialMatch(labels)
+}
@@ -0,0 +1,47 @@
+package configstat
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestObserveWithExemplar(t *testing.T) {
+	f := func(ctx context.Context, namespacedName string, want map[string]string) {
+		t.Helper()
+		h := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_duration_seconds", Buckets: []float64{1}})
+		ObserveWithExemplar(ctx, h, 0.5, namespacedName)
+		var pm dto.Metric
+		if err := h.Write(&pm); err != nil {
+			t.Fatalf("cannot write metric: %s", err)
+		}
+		got := map[string]string{}
+		for _, l := range pm.GetHistogram().GetBucket()[0].GetExemplar().GetLabel() {
+			got[l.GetName()] = l.GetValue()
+		}
+		if len(got) != len(want) {
+			t.Fatalf("unexpected exemplar labels, got: %v, want: %v", got, want)
+		}
+		for k, v := range want {
+			if got[k] != v {
+				t.Fatalf("unexpected exemplar label=%q, got: %q, want: %q", k, got[k], v)
+			}
+		}
+	}
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	tracedCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	// without trace
+	f(context.Background(), "default/example", map[string]string{"namespaced_name": "default/example"})
+
+	// with trace
+	f(tracedCtx, "default/example", map[string]string{"namespaced_name": "default/example", "trace_id": traceID.String()})
+
+	// too long object name is omitted
+	f(tracedCtx, "default/"+strings.Repeat("a", 100), map[string]string{"trace_id": traceID.String()})
+}
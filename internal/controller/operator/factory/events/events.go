@@ -0,0 +1,79 @@
+package events
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ReasonReconcile is used for reconcile progress events
+	ReasonReconcile = "ReconcileEvent"
+	// ReasonReconcileError is used for failed reconcile events
+	ReasonReconcileError = "ReconcilationError"
+	// ReasonCreated is used for created child objects
+	ReasonCreated = "Created"
+	// ReasonConfigUpdated is used for updated configuration secrets
+	ReasonConfigUpdated = "ConfigUpdated"
+	// ReasonConfigSkipped is used for objects, which were skipped during configuration generation
+	ReasonConfigSkipped = "ConfigSkipped"
+	// ReasonRemoteWriteUnhealthy is used for VMAgent remote write targets with failed delivery
+	ReasonRemoteWriteUnhealthy = "RemoteWriteUnhealthy"
+	// ReasonRemoteWriteRecovered is used for VMAgent remote write targets with restored delivery
+	ReasonRemoteWriteRecovered = "RemoteWriteRecovered"
+	// ReasonShardHot is used for VMAgent shards with significantly more scrape targets than the average
+	ReasonShardHot = "ShardHot"
+)
+
+// globalRecorder is nil until Init is called
+// it disables events for unit tests
+var globalRecorder record.EventRecorder
+
+// Init sets event recorder used by operator
+func Init(r record.EventRecorder) {
+	globalRecorder = r
+}
+
+// Normal emits event with normal type for the given object
+// nil object is ignored
+func Normal(obj client.Object, reason, message string) {
+	emit(obj, corev1.EventTypeNormal, reason, message)
+}
+
+// Warning emits event with warning type for the given object
+// nil object is ignored
+func Warning(obj client.Object, reason, message string) {
+	emit(obj, corev1.EventTypeWarning, reason, message)
+}
+
+func emit(obj client.Object, eventType, reason, message string) {
+	if globalRecorder == nil || obj == nil {
+		return
+	}
+	globalRecorder.Event(obj, eventType, reason, message)
+}
+
+// ControllerOf returns controller owner of the given object
+// it allows to emit events for the custom resource instead of its child objects
+// returns nil if object doesn't have controller owner
+func ControllerOf(obj client.Object) client.Object {
+	ref := metav1.GetControllerOf(obj)
+	if ref == nil {
+		return nil
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil
+	}
+	owner := &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ref.Name,
+			Namespace: obj.GetNamespace(),
+			UID:       ref.UID,
+		},
+	}
+	owner.SetGroupVersionKind(gv.WithKind(ref.Kind))
+	return owner
+}
@@ -0,0 +1,76 @@
+package events
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestControllerOf(t *testing.T) {
+	f := func(owners []metav1.OwnerReference, wantKind, wantName string) {
+		t.Helper()
+		obj := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "vmagent-main", Namespace: "default", OwnerReferences: owners},
+		}
+		got := ControllerOf(obj)
+		if wantName == "" {
+			if got != nil {
+				t.Fatalf("expected nil owner, got: %v", got)
+			}
+			return
+		}
+		if got == nil {
+			t.Fatalf("expected owner %s/%s, got nil", wantKind, wantName)
+		}
+		if kind := got.GetObjectKind().GroupVersionKind().Kind; kind != wantKind || got.GetName() != wantName || got.GetNamespace() != "default" {
+			t.Fatalf("unexpected owner, got: %s %s/%s, want: %s default/%s", kind, got.GetNamespace(), got.GetName(), wantKind, wantName)
+		}
+	}
+
+	// no owners
+	f(nil, "", "")
+
+	// owner without controller flag
+	f([]metav1.OwnerReference{{APIVersion: "operator.victoriametrics.com/v1beta1", Kind: "VMAgent", Name: "main"}}, "", "")
+
+	// controller owner
+	f([]metav1.OwnerReference{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "other"},
+		{APIVersion: "operator.victoriametrics.com/v1beta1", Kind: "VMAgent", Name: "main", Controller: ptr.To(true)},
+	}, "VMAgent", "main")
+}
+
+func TestEmit(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	Init(recorder)
+	defer Init(nil)
+
+	cr := &vmv1beta1.VMAgent{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"}}
+	Normal(cr, ReasonCreated, "created Deployment vmagent-main")
+	Warning(cr, ReasonReconcileError, "cannot create deployment")
+	// nil object must be ignored
+	Normal(nil, ReasonCreated, "created ClusterRole")
+	close(recorder.Events)
+
+	var got []string
+	for e := range recorder.Events {
+		got = append(got, e)
+	}
+	want := []string{
+		"Normal Created created Deployment vmagent-main",
+		"Warning ReconcilationError cannot create deployment",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected events, got: %v, want: %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected event at idx=%d, got: %q, want: %q", i, got[i], want[i])
+		}
+	}
+}
@@ -80,6 +80,22 @@ func removeFinalizeObjByNameWithOwnerReference(ctx context.Context, rclient clie
 	})
 }
 
+// removePVCsBySelector deletes PersistentVolumeClaims created by statefulset with given selector
+// statefulset controller deletes it only if StatefulSetAutoDeletePVC feature is enabled at kubernetes cluster
+func removePVCsBySelector(ctx context.Context, rclient client.Client, ns string, selector map[string]string) error {
+	var pvcs corev1.PersistentVolumeClaimList
+	if err := rclient.List(ctx, &pvcs, client.InNamespace(ns), client.MatchingLabels(selector)); err != nil {
+		return fmt.Errorf("cannot list pvc: %w", err)
+	}
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		if err := SafeDeleteWithFinalizer(ctx, rclient, pvc); err != nil {
+			return fmt.Errorf("cannot remove pvc=%s: %w", pvc.Name, err)
+		}
+	}
+	return nil
+}
+
 // SafeDelete removes object, ignores notfound error.
 func SafeDelete(ctx context.Context, rclient client.Client, r client.Object) error {
 	if err := rclient.Delete(ctx, r); err != nil {
@@ -29,6 +29,11 @@ func OnVLogsDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.VL
 			return err
 		}
 	}
+	for _, svc := range crd.Spec.AdditionalServices {
+		if err := removeFinalizeObjByName(ctx, rclient, &v1.Service{}, svc.Name, crd.Namespace); err != nil {
+			return err
+		}
+	}
 	if err := deleteSA(ctx, rclient, crd); err != nil {
 		return err
 	}
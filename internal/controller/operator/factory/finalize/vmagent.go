@@ -2,6 +2,7 @@ package finalize
 
 import (
 	"context"
+	"fmt"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
@@ -28,6 +29,11 @@ func OnVMAgentDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.
 	if err := RemoveOrphanedSTSs(ctx, rclient, crd, nil); err != nil {
 		return err
 	}
+	if vmv1beta1.IsPVCDeletedWithOwner(crd.PVCRetentionPolicy()) {
+		if err := removePVCsBySelector(ctx, rclient, crd.Namespace, crd.SelectorLabels()); err != nil {
+			return fmt.Errorf("cannot remove vmagent pvc: %w", err)
+		}
+	}
 	// check service
 	if err := removeFinalizeObjByName(ctx, rclient, &corev1.Service{}, crd.PrefixedName(), crd.Namespace); err != nil {
 		return err
@@ -37,6 +43,16 @@ func OnVMAgentDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.
 			return err
 		}
 	}
+	for _, svc := range crd.Spec.AdditionalServices {
+		if err := removeFinalizeObjByName(ctx, rclient, &corev1.Service{}, svc.Name, crd.Namespace); err != nil {
+			return err
+		}
+	}
+	if crd.Spec.OTLP != nil {
+		if err := removeFinalizeObjByName(ctx, rclient, &corev1.Service{}, crd.OTLPServiceName(), crd.Namespace); err != nil {
+			return err
+		}
+	}
 	// config secret
 	if err := removeFinalizeObjByName(ctx, rclient, &corev1.Secret{}, crd.PrefixedName(), crd.Namespace); err != nil {
 		return err
@@ -54,6 +70,11 @@ func OnVMAgentDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.
 	if err := removeFinalizeObjByName(ctx, rclient, &corev1.ConfigMap{}, crd.StreamAggrConfigName(), crd.Namespace); err != nil {
 		return err
 	}
+	if crd.Spec.GrafanaDashboard.UseConfigMap() {
+		if err := removeFinalizeObjByName(ctx, rclient, &corev1.ConfigMap{}, crd.GrafanaDashboardName(), crd.Namespace); err != nil {
+			return err
+		}
+	}
 
 	// check PDB
 	if crd.Spec.PodDisruptionBudget != nil {
@@ -62,34 +83,9 @@ func OnVMAgentDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.
 		}
 	}
 	// remove vmagents service discovery rbac.
-	if config.IsClusterWideAccessAllowed() {
-		if err := removeFinalizeObjByName(ctx, rclient, &rbacv1.ClusterRoleBinding{}, crd.GetClusterRoleName(), crd.GetNSName()); err != nil {
-			return err
-		}
-		if err := removeFinalizeObjByName(ctx, rclient, &rbacv1.ClusterRole{}, crd.GetClusterRoleName(), crd.GetNSName()); err != nil {
-			return err
-		}
-		if err := SafeDelete(ctx, rclient, &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: crd.GetClusterRoleName(), Namespace: crd.GetNSName()}}); err != nil {
-			return err
-		}
-
-		if err := SafeDelete(ctx, rclient, &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: crd.GetClusterRoleName(), Namespace: crd.GetNSName()}}); err != nil {
-			return err
-		}
-	} else {
-		if err := removeFinalizeObjByName(ctx, rclient, &rbacv1.RoleBinding{}, crd.GetClusterRoleName(), crd.GetNSName()); err != nil {
-			return err
-		}
-		if err := removeFinalizeObjByName(ctx, rclient, &rbacv1.Role{}, crd.GetClusterRoleName(), crd.GetNSName()); err != nil {
-			return err
-		}
-		if err := SafeDelete(ctx, rclient, &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: crd.GetClusterRoleName(), Namespace: crd.GetNSName()}}); err != nil {
-			return err
-		}
-
-		if err := SafeDelete(ctx, rclient, &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: crd.GetClusterRoleName(), Namespace: crd.GetNSName()}}); err != nil {
-			return err
-		}
+	// objects of both access modes are checked, since operator mode could be changed after objects creation
+	if err := removeVMAgentRBAC(ctx, rclient, crd); err != nil {
+		return err
 	}
 
 	if crd.Spec.AdditionalScrapeConfigs != nil {
@@ -113,3 +109,22 @@ func OnVMAgentDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.
 	}
 	return nil
 }
+
+// removeVMAgentRBAC removes Role and RoleBinding or ClusterRole and ClusterRoleBinding created for vmagent
+// cluster scoped objects cannot be garbage collected by owner reference and must be removed explicitly
+func removeVMAgentRBAC(ctx context.Context, rclient client.Client, crd *vmv1beta1.VMAgent) error {
+	meta := metav1.ObjectMeta{Name: crd.GetClusterRoleName(), Namespace: crd.GetNSName()}
+	toRemove := []client.Object{
+		&rbacv1.RoleBinding{ObjectMeta: meta},
+		&rbacv1.Role{ObjectMeta: meta},
+	}
+	if config.IsClusterWideAccessAllowed() {
+		toRemove = append(toRemove, &rbacv1.ClusterRoleBinding{ObjectMeta: meta}, &rbacv1.ClusterRole{ObjectMeta: meta})
+	}
+	for _, obj := range toRemove {
+		if err := SafeDeleteWithFinalizer(ctx, rclient, obj); err != nil {
+			return fmt.Errorf("cannot remove vmagent rbac object=%q: %w", obj.GetName(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package finalize
+
+import (
+	"context"
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestOnVMAgentDelete(t *testing.T) {
+	f := func(cr *vmv1beta1.VMAgent, wantPVCRemoved bool) {
+		t.Helper()
+		rbacMeta := metav1.ObjectMeta{Name: cr.GetClusterRoleName(), Namespace: cr.Namespace, Finalizers: []string{vmv1beta1.FinalizerName}}
+		fclient := testutil.GetTestClientWithObjects([]runtime.Object{
+			cr,
+			&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+				Name:       "persistent-queue-data-vmagent-base-0",
+				Namespace:  cr.Namespace,
+				Labels:     cr.SelectorLabels(),
+				Finalizers: []string{vmv1beta1.FinalizerName},
+			}},
+			&rbacv1.ClusterRole{ObjectMeta: rbacMeta},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: rbacMeta},
+			&rbacv1.Role{ObjectMeta: rbacMeta},
+			&rbacv1.RoleBinding{ObjectMeta: rbacMeta},
+		})
+		ctx := context.Background()
+		if err := OnVMAgentDelete(ctx, fclient, cr); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		// rbac objects of both access modes must be removed
+		for _, obj := range []client.Object{&rbacv1.ClusterRole{}, &rbacv1.ClusterRoleBinding{}, &rbacv1.Role{}, &rbacv1.RoleBinding{}} {
+			if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.GetClusterRoleName()}, obj); !errors.IsNotFound(err) {
+				t.Fatalf("expected %T to be removed, got err: %v", obj, err)
+			}
+		}
+		var pvc corev1.PersistentVolumeClaim
+		err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: "persistent-queue-data-vmagent-base-0"}, &pvc)
+		if gotRemoved := errors.IsNotFound(err); gotRemoved != wantPVCRemoved {
+			t.Fatalf("unexpected pvc removal, got: %v, want: %v, err: %v", gotRemoved, wantPVCRemoved, err)
+		}
+	}
+	agent := func(spec vmv1beta1.VMAgentSpec) *vmv1beta1.VMAgent {
+		return &vmv1beta1.VMAgent{
+			ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default", Finalizers: []string{vmv1beta1.FinalizerName}},
+			Spec:       spec,
+		}
+	}
+
+	// pvcs are retained by default
+	f(agent(vmv1beta1.VMAgentSpec{}), false)
+
+	// remove pvcs
+	f(agent(vmv1beta1.VMAgentSpec{RetainPVCs: ptr.To(false)}), true)
+}
@@ -36,6 +36,11 @@ func OnVMAlertDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.
 	if err := removeFinalizeObjByName(ctx, rclient, &corev1.Secret{}, crd.TLSAssetName(), crd.Namespace); err != nil {
 		return err
 	}
+	if crd.Spec.GrafanaDashboard.UseConfigMap() {
+		if err := removeFinalizeObjByName(ctx, rclient, &corev1.ConfigMap{}, crd.GrafanaDashboardName(), crd.Namespace); err != nil {
+			return err
+		}
+	}
 
 	// check PDB
 	if crd.Spec.PodDisruptionBudget != nil {
@@ -52,6 +57,11 @@ func OnVMAlertDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.
 			return err
 		}
 	}
+	for _, svc := range crd.Spec.AdditionalServices {
+		if err := removeFinalizeObjByName(ctx, rclient, &corev1.Service{}, svc.Name, crd.Namespace); err != nil {
+			return err
+		}
+	}
 	if err := removeFinalizeObjByName(ctx, rclient, crd, crd.Name, crd.Namespace); err != nil {
 		return err
 	}
@@ -2,6 +2,7 @@ package finalize
 
 import (
 	"context"
+	"fmt"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -15,6 +16,11 @@ func OnVMAlertManagerDelete(ctx context.Context, rclient client.Client, crd *vmv
 	if err := removeFinalizeObjByName(ctx, rclient, &appsv1.StatefulSet{}, crd.PrefixedName(), crd.Namespace); err != nil {
 		return err
 	}
+	if vmv1beta1.IsPVCDeletedWithOwner(crd.PVCRetentionPolicy()) {
+		if err := removePVCsBySelector(ctx, rclient, crd.Namespace, crd.SelectorLabels()); err != nil {
+			return fmt.Errorf("cannot remove vmalertmanager pvc: %w", err)
+		}
+	}
 	// check service
 	if err := removeFinalizeObjByName(ctx, rclient, &v1.Service{}, crd.PrefixedName(), crd.Namespace); err != nil {
 		return err
@@ -24,6 +30,11 @@ func OnVMAlertManagerDelete(ctx context.Context, rclient client.Client, crd *vmv
 			return err
 		}
 	}
+	for _, svc := range crd.Spec.AdditionalServices {
+		if err := removeFinalizeObjByName(ctx, rclient, &v1.Service{}, svc.Name, crd.Namespace); err != nil {
+			return err
+		}
+	}
 
 	// check config secret finalizer.
 	if err := removeFinalizeObjByName(ctx, rclient, &v1.Secret{}, crd.ConfigSecretName(), crd.Namespace); err != nil {
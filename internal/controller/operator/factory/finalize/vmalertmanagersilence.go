@@ -0,0 +1,14 @@
+package finalize
+
+import (
+	"context"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OnVMAlertmanagerSilenceDelete removes finalizer from vmalertmanagersilence
+// silence must be expired at alertmanager before this call
+func OnVMAlertmanagerSilenceDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.VMAlertmanagerSilence) error {
+	return removeFinalizeObjByName(ctx, rclient, crd, crd.Name, crd.Namespace)
+}
@@ -27,6 +27,11 @@ func OnVMAuthDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.V
 			return err
 		}
 	}
+	for _, svc := range crd.Spec.AdditionalServices {
+		if err := removeFinalizeObjByName(ctx, rclient, &v1.Service{}, svc.Name, crd.Namespace); err != nil {
+			return err
+		}
+	}
 
 	// check secret
 	if err := removeFinalizeObjByName(ctx, rclient, &v1.Secret{}, crd.ConfigSecretName(), crd.Namespace); err != nil {
@@ -8,6 +8,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	v2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,6 +34,12 @@ func OnVMInsertDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1
 			},
 		})
 	}
+	for _, svc := range obj.AdditionalServices {
+		objsToRemove = append(objsToRemove, &v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: crd.Namespace, Name: svc.Name}})
+	}
+	if obj.OTLP != nil {
+		objsToRemove = append(objsToRemove, &v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: crd.Namespace, Name: crd.GetVMInsertOTLPName()}})
+	}
 	if obj.PodDisruptionBudget != nil {
 		objsToRemove = append(objsToRemove, &policyv1.PodDisruptionBudget{ObjectMeta: objMeta})
 	}
@@ -43,6 +50,9 @@ func OnVMInsertDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1
 		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta})
 		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: metav1.ObjectMeta{Name: crd.GetVMInsertLBName(), Namespace: crd.Namespace}})
 	}
+	if ptr.Deref(obj.SelfMonitoringRules, false) {
+		objsToRemove = append(objsToRemove, &vmv1beta1.VMRule{ObjectMeta: objMeta})
+	}
 
 	if crd.Spec.RequestsLoadBalancer.Enabled && !crd.Spec.RequestsLoadBalancer.DisableInsertBalancing {
 		objsToRemove = append(objsToRemove, &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: crd.GetVMInsertLBName(), Namespace: crd.Namespace}})
@@ -73,6 +83,9 @@ func OnVMSelectDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1
 			},
 		})
 	}
+	for _, svc := range obj.AdditionalServices {
+		objsToRemove = append(objsToRemove, &v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: crd.Namespace, Name: svc.Name}})
+	}
 	if obj.PodDisruptionBudget != nil {
 		objsToRemove = append(objsToRemove, &policyv1.PodDisruptionBudget{ObjectMeta: objMeta})
 	}
@@ -83,6 +96,9 @@ func OnVMSelectDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1
 		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta})
 		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: metav1.ObjectMeta{Name: crd.GetVMSelectLBName(), Namespace: crd.Namespace}})
 	}
+	if ptr.Deref(obj.SelfMonitoringRules, false) {
+		objsToRemove = append(objsToRemove, &vmv1beta1.VMRule{ObjectMeta: objMeta})
+	}
 	if crd.Spec.RequestsLoadBalancer.Enabled && !crd.Spec.RequestsLoadBalancer.DisableSelectBalancing {
 		objsToRemove = append(objsToRemove, &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: crd.GetVMSelectLBName(), Namespace: crd.Namespace}})
 	}
@@ -112,12 +128,25 @@ func OnVMStorageDelete(ctx context.Context, rclient client.Client, crd *vmv1beta
 			},
 		})
 	}
+	for _, svc := range obj.AdditionalServices {
+		objsToRemove = append(objsToRemove, &v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: crd.Namespace, Name: svc.Name}})
+	}
 	if obj.PodDisruptionBudget != nil {
 		objsToRemove = append(objsToRemove, &policyv1.PodDisruptionBudget{ObjectMeta: objMeta})
 	}
 	if !ptr.Deref(obj.DisableSelfServiceScrape, false) {
 		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta})
 	}
+	if ptr.Deref(obj.SelfMonitoringRules, false) {
+		objsToRemove = append(objsToRemove, &vmv1beta1.VMRule{ObjectMeta: objMeta})
+	}
+
+	if obj.VMBackup != nil && obj.VMBackup.Verification != nil {
+		objsToRemove = append(objsToRemove, &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{
+			Namespace: crd.Namespace,
+			Name:      crd.GetVMStorageBackupVerificationName(),
+		}})
+	}
 
 	for _, objToRemove := range objsToRemove {
 		if err := SafeDeleteWithFinalizer(ctx, rclient, objToRemove); err != nil {
@@ -141,16 +170,31 @@ func OnVMClusterDelete(ctx context.Context, rclient client.Client, crd *vmv1beta
 		if err := OnVMSelectDelete(ctx, rclient, crd, crd.Spec.VMSelect); err != nil {
 			return fmt.Errorf("cannot remove vmselect component objects: %w", err)
 		}
+		if vmv1beta1.IsPVCDeletedWithOwner(crd.Spec.VMSelect.PVCRetentionPolicy()) {
+			if err := removePVCsBySelector(ctx, rclient, crd.Namespace, crd.VMSelectSelectorLabels()); err != nil {
+				return fmt.Errorf("cannot remove vmselect pvc: %w", err)
+			}
+		}
 	}
 	if crd.Spec.VMStorage != nil {
 		if err := OnVMStorageDelete(ctx, rclient, crd, crd.Spec.VMStorage); err != nil {
 			return fmt.Errorf("cannot remove vmstorage component objects: %w", err)
 		}
+		if vmv1beta1.IsPVCDeletedWithOwner(crd.Spec.VMStorage.PVCRetentionPolicy()) {
+			if err := removePVCsBySelector(ctx, rclient, crd.Namespace, crd.VMStorageSelectorLabels()); err != nil {
+				return fmt.Errorf("cannot remove vmstorage pvc: %w", err)
+			}
+		}
 	}
 
 	if err := deleteSA(ctx, rclient, crd); err != nil {
 		return err
 	}
+	if crd.Spec.GrafanaDashboard.UseConfigMap() {
+		if err := removeFinalizeObjByName(ctx, rclient, &v1.ConfigMap{}, crd.GrafanaDashboardName(), crd.Namespace); err != nil {
+			return err
+		}
+	}
 	if crd.Spec.RequestsLoadBalancer.Enabled {
 		if err := OnVMClusterLoadBalancerDelete(ctx, rclient, crd); err != nil {
 			return fmt.Errorf("cannot delete vmcluster loadbalancer components: %w", err)
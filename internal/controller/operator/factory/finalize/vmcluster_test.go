@@ -0,0 +1,97 @@
+package finalize
+
+import (
+	"context"
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestOnVMClusterDeleteRemovesPVC(t *testing.T) {
+	f := func(cr *vmv1beta1.VMCluster, wantPVCs []string) {
+		t.Helper()
+		pvc := func(name string, lbls map[string]string) *corev1.PersistentVolumeClaim {
+			return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Namespace:  cr.Namespace,
+				Labels:     lbls,
+				Finalizers: []string{vmv1beta1.FinalizerName},
+			}}
+		}
+		fclient := testutil.GetTestClientWithObjects([]runtime.Object{
+			cr,
+			pvc("vmstorage-db-vmstorage-base-0", cr.VMStorageSelectorLabels()),
+			pvc("vmselect-cachedir-vmselect-base-0", cr.VMSelectSelectorLabels()),
+			pvc("unrelated", map[string]string{"app": "unrelated"}),
+		})
+		ctx := context.Background()
+		if err := OnVMClusterDelete(ctx, fclient, cr); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var pvcs corev1.PersistentVolumeClaimList
+		if err := fclient.List(ctx, &pvcs, client.InNamespace(cr.Namespace)); err != nil {
+			t.Fatalf("cannot list pvcs: %s", err)
+		}
+		var gotPVCs []string
+		for _, p := range pvcs.Items {
+			if p.DeletionTimestamp.IsZero() {
+				gotPVCs = append(gotPVCs, p.Name)
+			}
+		}
+		if len(gotPVCs) != len(wantPVCs) {
+			t.Fatalf("unexpected pvcs, got: %v, want: %v", gotPVCs, wantPVCs)
+		}
+		for i := range wantPVCs {
+			if gotPVCs[i] != wantPVCs[i] {
+				t.Fatalf("unexpected pvcs, got: %v, want: %v", gotPVCs, wantPVCs)
+			}
+		}
+	}
+	cluster := func(spec vmv1beta1.VMClusterSpec) *vmv1beta1.VMCluster {
+		return &vmv1beta1.VMCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default", Finalizers: []string{vmv1beta1.FinalizerName}},
+			Spec:       spec,
+		}
+	}
+
+	// pvcs are retained by default
+	f(cluster(vmv1beta1.VMClusterSpec{
+		VMSelect:  &vmv1beta1.VMSelect{},
+		VMStorage: &vmv1beta1.VMStorage{},
+	}), []string{"unrelated", "vmselect-cachedir-vmselect-base-0", "vmstorage-db-vmstorage-base-0"})
+
+	// remove vmstorage pvcs only
+	f(cluster(vmv1beta1.VMClusterSpec{
+		VMSelect:  &vmv1beta1.VMSelect{},
+		VMStorage: &vmv1beta1.VMStorage{RetainPVCs: ptr.To(false)},
+	}), []string{"unrelated", "vmselect-cachedir-vmselect-base-0"})
+
+	// remove pvcs with statefulset retention policy
+	f(cluster(vmv1beta1.VMClusterSpec{
+		VMSelect: &vmv1beta1.VMSelect{PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+			WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+		}},
+		VMStorage: &vmv1beta1.VMStorage{},
+	}), []string{"unrelated", "vmstorage-db-vmstorage-base-0"})
+
+	// retainPVCs takes precedence over retention policy
+	f(cluster(vmv1beta1.VMClusterSpec{
+		VMSelect: &vmv1beta1.VMSelect{},
+		VMStorage: &vmv1beta1.VMStorage{RetainPVCs: ptr.To(true), PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+			WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+		}},
+	}), []string{"unrelated", "vmselect-cachedir-vmselect-base-0", "vmstorage-db-vmstorage-base-0"})
+
+	// remove all component pvcs
+	f(cluster(vmv1beta1.VMClusterSpec{
+		VMSelect:  &vmv1beta1.VMSelect{RetainPVCs: ptr.To(false)},
+		VMStorage: &vmv1beta1.VMStorage{RetainPVCs: ptr.To(false)},
+	}), []string{"unrelated"})
+}
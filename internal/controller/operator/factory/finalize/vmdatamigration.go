@@ -0,0 +1,14 @@
+package finalize
+
+import (
+	"context"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OnVMDataMigrationDelete removes finalizer from vmdatamigration
+// migration job is removed by garbage collector with owner reference
+func OnVMDataMigrationDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.VMDataMigration) error {
+	return removeFinalizeObjByName(ctx, rclient, crd, crd.Name, crd.Namespace)
+}
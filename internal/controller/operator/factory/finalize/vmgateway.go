@@ -0,0 +1,47 @@
+package finalize
+
+import (
+	"context"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OnVMGatewayDelete deletes all vmgateway related resources
+func OnVMGatewayDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.VMGateway) error {
+	// check deployment
+	if err := removeFinalizeObjByName(ctx, rclient, &appsv1.Deployment{}, crd.PrefixedName(), crd.Namespace); err != nil {
+		return err
+	}
+	// check service
+	if err := removeFinalizeObjByName(ctx, rclient, &v1.Service{}, crd.PrefixedName(), crd.Namespace); err != nil {
+		return err
+	}
+	// check config secret
+	if err := removeFinalizeObjByName(ctx, rclient, &v1.Secret{}, crd.ConfigSecretName(), crd.Namespace); err != nil {
+		return err
+	}
+	// check PDB
+	if crd.Spec.PodDisruptionBudget != nil {
+		if err := finalizePBD(ctx, rclient, crd); err != nil {
+			return err
+		}
+	}
+	if crd.Spec.ServiceSpec != nil {
+		if err := removeFinalizeObjByName(ctx, rclient, &v1.Service{}, crd.Spec.ServiceSpec.NameOrDefault(crd.PrefixedName()), crd.Namespace); err != nil {
+			return err
+		}
+	}
+	for _, svc := range crd.Spec.AdditionalServices {
+		if err := removeFinalizeObjByName(ctx, rclient, &v1.Service{}, svc.Name, crd.Namespace); err != nil {
+			return err
+		}
+	}
+	if err := deleteSA(ctx, rclient, crd); err != nil {
+		return err
+	}
+
+	return removeFinalizeObjByName(ctx, rclient, crd, crd.Name, crd.Namespace)
+}
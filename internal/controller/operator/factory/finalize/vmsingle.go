@@ -5,7 +5,9 @@ import (
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -29,9 +31,24 @@ func OnVMSingleDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1
 			return err
 		}
 	}
+	for _, svc := range crd.Spec.AdditionalServices {
+		if err := removeFinalizeObjByName(ctx, rclient, &v1.Service{}, svc.Name, crd.Namespace); err != nil {
+			return err
+		}
+	}
+	if crd.Spec.OTLP != nil {
+		if err := removeFinalizeObjByName(ctx, rclient, &v1.Service{}, crd.OTLPServiceName(), crd.Namespace); err != nil {
+			return err
+		}
+	}
 	if err := removeFinalizeObjByName(ctx, rclient, &v1.ConfigMap{}, crd.StreamAggrConfigName(), crd.Namespace); err != nil {
 		return err
 	}
+	if crd.Spec.VMBackup != nil && crd.Spec.VMBackup.Verification != nil {
+		if err := SafeDelete(ctx, rclient, &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: crd.BackupVerificationName(), Namespace: crd.Namespace}}); err != nil {
+			return err
+		}
+	}
 	if err := deleteSA(ctx, rclient, crd); err != nil {
 		return err
 	}
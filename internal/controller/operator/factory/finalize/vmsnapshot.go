@@ -0,0 +1,14 @@
+package finalize
+
+import (
+	"context"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OnVMSnapshotDelete removes finalizer from vmsnapshot
+// snapshots deletion at storage must be performed before this call
+func OnVMSnapshotDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.VMSnapshot) error {
+	return removeFinalizeObjByName(ctx, rclient, crd, crd.Name, crd.Namespace)
+}
@@ -0,0 +1,14 @@
+package finalize
+
+import (
+	"context"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OnVMStackDelete removes finalizer from vmstack
+// stack components are removed by garbage collector with owner reference
+func OnVMStackDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.VMStack) error {
+	return removeFinalizeObjByName(ctx, rclient, crd, crd.Name, crd.Namespace)
+}
@@ -0,0 +1,42 @@
+package k8stools
+
+import (
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/discovery"
+)
+
+const openShiftRouteGroupVersion = "route.openshift.io/v1"
+
+var isOpenShift bool
+
+// SetOpenShift configures OpenShift support with the given mode
+// auto mode detects OpenShift by route.openshift.io API served by kubernetes API server
+func SetOpenShift(dc discovery.ServerResourcesInterface, mode string) error {
+	switch mode {
+	case "true":
+		isOpenShift = true
+	case "false":
+		isOpenShift = false
+	case "auto":
+		_, err := dc.ServerResourcesForGroupVersion(openShiftRouteGroupVersion)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				isOpenShift = false
+				return nil
+			}
+			return fmt.Errorf("cannot discover %s API: %w", openShiftRouteGroupVersion, err)
+		}
+		isOpenShift = true
+	default:
+		return fmt.Errorf("unsupported openshift mode=%q", mode)
+	}
+	return nil
+}
+
+// IsOpenShift checks if operator runs at OpenShift cluster
+// in this case security context defaults must be compatible with restricted SecurityContextConstraints
+func IsOpenShift() bool {
+	return isOpenShift
+}
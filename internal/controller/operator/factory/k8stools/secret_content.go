@@ -8,6 +8,7 @@ import (
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -56,7 +57,7 @@ type OAuthCreds struct {
 }
 
 // LoadOAuthSecrets fetches content of OAuth secret and retruns it plain text value
-func LoadOAuthSecrets(ctx context.Context, rclient client.Client, oauth2 *vmv1beta1.OAuth2, ns string, cache map[string]*corev1.Secret, cmCache map[string]*corev1.ConfigMap) (*OAuthCreds, error) {
+func LoadOAuthSecrets(ctx context.Context, rclient client.Reader, oauth2 *vmv1beta1.OAuth2, ns string, cache map[string]*corev1.Secret, cmCache map[string]*corev1.ConfigMap) (*OAuthCreds, error) {
 	var r OAuthCreds
 	if oauth2.ClientSecret != nil {
 		s, err := GetCredFromSecret(ctx, rclient, ns, oauth2.ClientSecret, buildCacheKey(ns, oauth2.ClientSecret.Name), cache)
@@ -66,7 +67,7 @@ func LoadOAuthSecrets(ctx context.Context, rclient client.Client, oauth2 *vmv1be
 		r.ClientSecret = s
 	}
 	if oauth2.ClientID.Secret != nil {
-		s, err := GetCredFromSecret(ctx, rclient, ns, oauth2.ClientID.Secret, ns+"/"+oauth2.ClientID.Secret.Name, cache)
+		s, err := GetCredFromSecret(ctx, rclient, ns, oauth2.ClientID.Secret, buildCacheKey(ns, oauth2.ClientID.Secret.Name), cache)
 		if err != nil {
 			return nil, fmt.Errorf("cannot load oauth2 secret for: %s, err: %w", oauth2.ClientID.Secret, err)
 		}
@@ -90,7 +91,7 @@ type BasicAuthCredentials struct {
 }
 
 // LoadBasicAuthSecret fetch content of kubernetes secrets and returns it within plain text
-func LoadBasicAuthSecret(ctx context.Context, rclient client.Client, ns string, basicAuth *vmv1beta1.BasicAuth, secretCache map[string]*corev1.Secret) (BasicAuthCredentials, error) {
+func LoadBasicAuthSecret(ctx context.Context, rclient client.Reader, ns string, basicAuth *vmv1beta1.BasicAuth, secretCache map[string]*corev1.Secret) (BasicAuthCredentials, error) {
 	var err error
 	var bac BasicAuthCredentials
 	userNameContent, err := GetCredFromSecret(ctx, rclient, ns, &basicAuth.Username, fmt.Sprintf("%s/%s", ns, basicAuth.Username.Name), secretCache)
@@ -115,7 +116,7 @@ func LoadBasicAuthSecret(ctx context.Context, rclient client.Client, ns string,
 // GetCredFromSecret fetch content of secret by given key
 func GetCredFromSecret(
 	ctx context.Context,
-	rclient client.Client,
+	rclient client.Reader,
 	ns string,
 	sel *corev1.SecretKeySelector,
 	cacheKey string,
@@ -129,10 +130,17 @@ func GetCredFromSecret(
 	if s, ok = cache[cacheKey]; !ok {
 		s = &corev1.Secret{}
 		if err := rclient.Get(ctx, types.NamespacedName{Namespace: ns, Name: sel.Name}, s); err != nil {
+			if k8serrors.IsNotFound(err) {
+				// cache missing secret, it could be referenced by many objects
+				cache[cacheKey] = nil
+			}
 			return "", fmt.Errorf("unable to fetch key from secret: %q for object: %q : %w", sel.Name, cacheKey, err)
 		}
 		cache[cacheKey] = s
 	}
+	if s == nil {
+		return "", fmt.Errorf("unable to fetch key from secret: %q for object: %q : %w", sel.Name, cacheKey, k8serrors.NewNotFound(corev1.Resource("secrets"), sel.Name))
+	}
 	if s, ok := s.Data[sel.Key]; ok {
 		return maybeTrimSpace(string(s)), nil
 	}
@@ -142,7 +150,7 @@ func GetCredFromSecret(
 // GetCredFromConfigMap fetches content of configmap by given key
 func GetCredFromConfigMap(
 	ctx context.Context,
-	rclient client.Client,
+	rclient client.Reader,
 	ns string,
 	sel corev1.ConfigMapKeySelector,
 	cacheKey string,
@@ -155,10 +163,17 @@ func GetCredFromConfigMap(
 		s = &corev1.ConfigMap{}
 		err := rclient.Get(ctx, types.NamespacedName{Namespace: ns, Name: sel.Name}, s)
 		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				// cache missing configmap, it could be referenced by many objects
+				cache[cacheKey] = nil
+			}
 			return "", fmt.Errorf("cannot get configmap: %s at namespace %s, err: %s", sel.Name, ns, err)
 		}
 		cache[cacheKey] = s
 	}
+	if s == nil {
+		return "", fmt.Errorf("cannot get configmap: %s at namespace %s, err: %s", sel.Name, ns, k8serrors.NewNotFound(corev1.Resource("configmaps"), sel.Name))
+	}
 
 	if a, ok := s.Data[sel.Key]; ok {
 		return maybeTrimSpace(a), nil
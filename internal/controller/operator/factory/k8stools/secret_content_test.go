@@ -7,6 +7,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 )
 
 func Test_getCredFromSecret(t *testing.T) {
@@ -97,10 +99,51 @@ func Test_getCredFromSecret(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "extract key from cached secret",
+			args: args{
+				ns: "default",
+				sel: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "tls-secret",
+					},
+					Key: "key.pem",
+				},
+				cacheKey: "default/tls-secret",
+				cache: map[string]*corev1.Secret{
+					"default/tls-secret": {Data: map[string][]byte{"key.pem": []byte(`cached-key-data`)}},
+				},
+			},
+			want: "cached-key-data",
+		},
+		{
+			name: "fail on cached missing secret",
+			args: args{
+				ns: "default",
+				sel: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "tls-secret",
+					},
+					Key: "key.pem",
+				},
+				cacheKey: "default/tls-secret",
+				cache:    map[string]*corev1.Secret{"default/tls-secret": nil},
+			},
+			wantErr: true,
+			predefinedObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "tls-secret",
+						Namespace: "default",
+					},
+					Data: map[string][]byte{"key.pem": []byte(`tls-key-data`)},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 
 			got, err := GetCredFromSecret(context.TODO(), fclient, tt.args.ns, &tt.args.sel, tt.args.cacheKey, tt.args.cache)
 			if (err != nil) != tt.wantErr {
@@ -144,16 +187,29 @@ func Test_getCredFromConfigMap(t *testing.T) {
 			want:    "secret-data",
 			wantErr: false,
 		},
+		{
+			name: "fail on missing cm and cache it",
+			args: args{
+				ns:       "default",
+				sel:      corev1.ConfigMapKeySelector{Key: "tls-conf", LocalObjectReference: corev1.LocalObjectReference{Name: "tls-cm"}},
+				cacheKey: "default/tls-cm",
+				cache:    map[string]*corev1.ConfigMap{},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 
 			got, err := GetCredFromConfigMap(context.TODO(), fclient, tt.args.ns, tt.args.sel, tt.args.cacheKey, tt.args.cache)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getCredFromConfigMap() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if _, ok := tt.args.cache[tt.args.cacheKey]; !ok {
+				t.Errorf("getCredFromConfigMap() expected cache entry for key %q", tt.args.cacheKey)
+			}
 			if got != tt.want {
 				t.Errorf("getCredFromConfigMap() got = %v, want %v", got, tt.want)
 			}
@@ -3,11 +3,13 @@ package k8stools
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/VictoriaMetrics/operator/internal/config"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -60,7 +62,7 @@ func VisitObjectsForSelectorsAtNs[T any, PT interface {
 	}
 	objLabelSelector, err := metav1.LabelSelectorAsSelector(objectSelector)
 	if err != nil {
-		return fmt.Errorf("cannot convert  to Selector: %w", err)
+		return fmt.Errorf("cannot convert objectSelector=%s to Selector: %w", objectSelector.String(), err)
 	}
 	// namespaces could still be empty if nsSelector&objectSelector are nil and selectAllByDefault=true, and it's ok
 	return ListObjectsByNamespace(ctx, rclient, namespaces, cb, &client.ListOptions{LabelSelector: objLabelSelector})
@@ -81,3 +83,45 @@ func SelectNamespaces(ctx context.Context, rclient client.Client, selector label
 
 	return matchedNs, nil
 }
+
+// IsObjectSelected checks if the given object is matched by selectors
+// it follows VisitObjectsForSelectorsAtNs rules for selectors namespace and selectAllByDefault
+func IsObjectSelected(ctx context.Context, rclient client.Client,
+	nsSelector, objectSelector *metav1.LabelSelector,
+	selectorNamespace string, selectAllByDefault bool, obj client.Object,
+) (bool, error) {
+	if nsSelector == nil && objectSelector == nil && !selectAllByDefault {
+		return false, nil
+	}
+	watchNS := config.MustGetWatchNamespaces()
+	switch {
+	case len(watchNS) > 0:
+		if !slices.Contains(watchNS, obj.GetNamespace()) {
+			return false, nil
+		}
+	case objectSelector != nil && nsSelector == nil:
+		if obj.GetNamespace() != selectorNamespace {
+			return false, nil
+		}
+	case nsSelector != nil:
+		nsLabelSelector, err := metav1.LabelSelectorAsSelector(nsSelector)
+		if err != nil {
+			return false, fmt.Errorf("cannot convert selector: %w", err)
+		}
+		var ns v1.Namespace
+		if err := rclient.Get(ctx, types.NamespacedName{Name: obj.GetNamespace()}, &ns); err != nil {
+			return false, fmt.Errorf("cannot get namespace=%q: %w", obj.GetNamespace(), err)
+		}
+		if !nsLabelSelector.Matches(labels.Set(ns.Labels)) {
+			return false, nil
+		}
+	}
+	if objectSelector == nil {
+		return true, nil
+	}
+	objLabelSelector, err := metav1.LabelSelectorAsSelector(objectSelector)
+	if err != nil {
+		return false, fmt.Errorf("cannot convert objectSelector=%s to Selector: %w", objectSelector.String(), err)
+	}
+	return objLabelSelector.Matches(labels.Set(obj.GetLabels())), nil
+}
@@ -47,3 +47,13 @@ func IsFSGroupChangePolicySupported() bool {
 	}
 	return false
 }
+
+// IsNativeSidecarSupported checks if init containers with restartPolicy: Always are supported,
+// Enabled by default since 1.29
+// https://kubernetes.io/docs/concepts/workloads/pods/sidecar-containers/
+func IsNativeSidecarSupported() bool {
+	if ServerMajorVersion == 1 && ServerMinorVersion >= 29 {
+		return true
+	}
+	return ServerMajorVersion > 1
+}
@@ -0,0 +1,151 @@
+// Package podmetrics reads /metrics of application pods managed by operator
+package podmetrics
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+const requestTimeout = 10 * time.Second
+
+var httpClient = &http.Client{Timeout: requestTimeout}
+
+// Endpoint defines metrics endpoint of application pods
+type Endpoint struct {
+	// Namespace of application
+	Namespace string
+	// Scheme is http or https
+	Scheme string
+	// Port of http server
+	Port string
+	// Path of metrics handler
+	Path string
+	// AuthKey is the value of -metricsAuthKey flag
+	AuthKey string
+	// ServerName is used for server certificate verification
+	// pods are requested by IP address, so certificate must include application service DNS name
+	ServerName string
+	// ServerTLS holds secret with application certificate,
+	// ca.crt of the secret is used for server certificate verification
+	ServerTLS *vmv1beta1.ServerTLS
+}
+
+// EndpointFor returns metrics endpoint of the given application
+func EndpointFor(namespace, serviceName, port, metricsPath string, common *vmv1beta1.CommonApplicationDeploymentParams) Endpoint {
+	return Endpoint{
+		Namespace:  namespace,
+		Scheme:     strings.ToLower(protoFromArgs(common.ExtraArgsWithServerTLS())),
+		Port:       port,
+		Path:       metricsPath,
+		AuthKey:    common.ExtraArgs["metricsAuthKey"],
+		ServerName: fmt.Sprintf("%s.%s.svc", serviceName, namespace),
+		ServerTLS:  common.ServerTLS,
+	}
+}
+
+func protoFromArgs(args map[string]string) string {
+	if args["tls"] == "true" {
+		return "https"
+	}
+	return "http"
+}
+
+// Read requests metrics of the pod with the given IP address and passes response body to the parse func
+func Read(ctx context.Context, rclient client.Client, ep Endpoint, podIP string, parse func(r io.Reader) error) error {
+	c := httpClient
+	if ep.Scheme == "https" {
+		tc, err := buildTLSConfig(ctx, rclient, ep)
+		if err != nil {
+			return err
+		}
+		// transport isn't shared between requests, since certificates could be rotated
+		c = &http.Client{
+			Timeout:   requestTimeout,
+			Transport: &http.Transport{TLSClientConfig: tc, DisableKeepAlives: true},
+		}
+	}
+	u := fmt.Sprintf("%s://%s%s", ep.Scheme, net.JoinHostPort(podIP, ep.Port), ep.Path)
+	var req *http.Request
+	var err error
+	if ep.AuthKey != "" {
+		// authKey is passed at request body in order to not expose it at logs of proxies and access logs
+		body := url.Values{"authKey": {ep.AuthKey}}.Encode()
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(body))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot build request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot execute request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response code=%d", resp.StatusCode)
+	}
+	return parse(resp.Body)
+}
+
+func buildTLSConfig(ctx context.Context, rclient client.Client, ep Endpoint) (*tls.Config, error) {
+	tc := &tls.Config{ServerName: ep.ServerName}
+	if ep.ServerTLS == nil {
+		// tls is enabled with extraArgs, certificate must be issued by system trusted CA
+		return tc, nil
+	}
+	minVersion, err := vmv1beta1.ParseTLSVersion(ep.ServerTLS.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+	tc.MinVersion = minVersion
+	var s corev1.Secret
+	if err := rclient.Get(ctx, types.NamespacedName{Namespace: ep.Namespace, Name: ep.ServerTLS.SecretName}, &s); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("serverTLS secret=%q doesn't exist", ep.ServerTLS.SecretName)
+		}
+		return nil, fmt.Errorf("cannot get serverTLS secret=%q: %w", ep.ServerTLS.SecretName, err)
+	}
+	ca := s.Data[vmv1beta1.ServerTLSCAKey]
+	if len(ca) == 0 {
+		return tc, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("cannot parse ca.crt of serverTLS secret=%q", ep.ServerTLS.SecretName)
+	}
+	tc.RootCAs = pool
+	return tc, nil
+}
+
+// RoundSignificant rounds value to 2 significant digits
+// it prevents status updates on insignificant changes of metrics
+func RoundSignificant(v float64) int64 {
+	if v <= 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	scale := math.Pow(10, math.Floor(math.Log10(v))-1)
+	return int64(math.Round(v/scale) * scale)
+}
@@ -0,0 +1,73 @@
+package podmetrics
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+)
+
+func TestRead(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" || r.URL.Query().Get("authKey") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.FormValue("authKey") != "" && r.FormValue("authKey") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = io.WriteString(w, "vm_app_version 1\n")
+	}))
+	defer srv.Close()
+	host, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	assert.NoError(t, err)
+
+	f := func(ep Endpoint, wantErr bool) {
+		t.Helper()
+		var body string
+		err := Read(context.Background(), testutil.GetTestClientWithObjects(nil), ep, host, func(r io.Reader) error {
+			data, err := io.ReadAll(r)
+			body = string(data)
+			return err
+		})
+		if wantErr {
+			assert.Error(t, err)
+			return
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, "vm_app_version 1\n", body)
+	}
+
+	// plain request
+	f(Endpoint{Scheme: "http", Port: port, Path: "/metrics"}, false)
+
+	// authKey is sent at request body
+	f(Endpoint{Scheme: "http", Port: port, Path: "/metrics", AuthKey: "secret"}, false)
+	f(Endpoint{Scheme: "http", Port: port, Path: "/metrics", AuthKey: "bad"}, true)
+
+	// server certificate isn't trusted
+	tlsSrv := httptest.NewTLSServer(srv.Config.Handler)
+	defer tlsSrv.Close()
+	_, tlsPort, err := net.SplitHostPort(tlsSrv.Listener.Addr().String())
+	assert.NoError(t, err)
+	f(Endpoint{Scheme: "https", Port: tlsPort, Path: "/metrics", ServerName: "vmagent-main.default.svc"}, true)
+}
+
+func TestRoundSignificant(t *testing.T) {
+	f := func(v float64, want int64) {
+		t.Helper()
+		assert.Equal(t, want, RoundSignificant(v))
+	}
+	f(0, 0)
+	f(-5, 0)
+	f(7, 7)
+	f(1234, 1200)
+	f(1_256_000, 1_300_000)
+}
@@ -0,0 +1,51 @@
+// Package promtext parses metrics exposed by VictoriaMetrics components in Prometheus text exposition format
+package promtext
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseLine parses line of Prometheus text exposition format
+// it returns false for comments, empty and malformed lines
+func ParseLine(line string) (string, map[string]string, float64, bool) {
+	n := strings.IndexAny(line, "{ ")
+	if n < 0 {
+		return "", nil, 0, false
+	}
+	name, tail := line[:n], line[n:]
+	labels := make(map[string]string)
+	if tail[0] == '{' {
+		tail = tail[1:]
+		for {
+			tail = strings.TrimLeft(tail, " ,")
+			if strings.HasPrefix(tail, "}") {
+				tail = tail[1:]
+				break
+			}
+			key, rest, ok := strings.Cut(tail, "=")
+			if !ok {
+				return "", nil, 0, false
+			}
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return "", nil, 0, false
+			}
+			value, err := strconv.Unquote(quoted)
+			if err != nil {
+				return "", nil, 0, false
+			}
+			labels[strings.TrimSpace(key)] = value
+			tail = rest[len(quoted):]
+		}
+	}
+	fields := strings.Fields(tail)
+	if len(fields) == 0 {
+		return "", nil, 0, false
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return "", nil, 0, false
+	}
+	return name, labels, value, true
+}
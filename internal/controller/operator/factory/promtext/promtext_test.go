@@ -0,0 +1,34 @@
+package promtext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLine(t *testing.T) {
+	f := func(line, wantName string, wantLabels map[string]string, wantValue float64, wantOk bool) {
+		t.Helper()
+		name, labels, value, ok := ParseLine(line)
+		assert.Equal(t, wantOk, ok)
+		if !ok {
+			return
+		}
+		assert.Equal(t, wantName, name)
+		assert.Equal(t, wantLabels, labels)
+		assert.Equal(t, wantValue, value)
+	}
+
+	f(`vm_rows_added_to_storage_total 123`, "vm_rows_added_to_storage_total", map[string]string{}, 123, true)
+	f(`vm_cache_entries{type="storage/hour_metric_ids"} 1.5e+06`, "vm_cache_entries", map[string]string{"type": "storage/hour_metric_ids"}, 1.5e6, true)
+	f(`vmagent_remotewrite_requests_total{url="1:http://vm:8428/api/v1/write", status_code="200"} 5 1700000000`,
+		"vmagent_remotewrite_requests_total", map[string]string{"url": "1:http://vm:8428/api/v1/write", "status_code": "200"}, 5, true)
+	f(`label_with_escaped_quote{path="/a\"b"} 1`, "label_with_escaped_quote", map[string]string{"path": `/a"b`}, 1, true)
+
+	// comments and malformed lines
+	f(`# HELP vm_rows_added_to_storage_total`, "", nil, 0, false)
+	f(``, "", nil, 0, false)
+	f(`vm_cache_entries{type=storage} 1`, "", nil, 0, false)
+	f(`vm_cache_entries{type="storage"}`, "", nil, 0, false)
+	f(`vm_cache_entries NaN_value`, "", nil, 0, false)
+}
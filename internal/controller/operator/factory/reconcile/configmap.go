@@ -12,18 +12,23 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/tracing"
 )
 
 // ConfigMap reconciles configmap object
-func ConfigMap(ctx context.Context, rclient client.Client, newCM *corev1.ConfigMap, prevCMMEta *metav1.ObjectMeta) error {
+func ConfigMap(ctx context.Context, rclient client.Client, newCM *corev1.ConfigMap, prevCMMEta *metav1.ObjectMeta) (resultErr error) {
+	ctx, span := tracing.StartForObject(ctx, "reconcile.ConfigMap", newCM)
+	defer func() { tracing.End(span, resultErr) }()
 	var currentCM corev1.ConfigMap
 	if err := rclient.Get(ctx, types.NamespacedName{Namespace: newCM.Namespace, Name: newCM.Name}, &currentCM); err != nil {
 		if errors.IsNotFound(err) {
 			logger.WithContext(ctx).Info(fmt.Sprintf("creating new ConfigMap %s", newCM.Name))
-			return rclient.Create(ctx, newCM)
+			return createObject(ctx, rclient, newCM, "ConfigMap")
 		}
 	}
+	preserveChildLabels(newCM, &currentCM)
 	var prevAnnotations map[string]string
 	if prevCMMEta != nil {
 		prevAnnotations = prevCMMEta.Annotations
@@ -40,5 +45,9 @@ func ConfigMap(ctx context.Context, rclient client.Client, newCM *corev1.ConfigM
 
 	logger.WithContext(ctx).Info(fmt.Sprintf("updating ConfigMap %s configuration", newCM.Name))
 
-	return rclient.Update(ctx, newCM)
+	if err := rclient.Update(ctx, newCM); err != nil {
+		return err
+	}
+	events.Normal(events.ControllerOf(newCM), events.ReasonConfigUpdated, fmt.Sprintf("updated configuration ConfigMap %s", newCM.Name))
+	return nil
 }
@@ -0,0 +1,58 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+// CronJob creates or updates CronJob
+func CronJob(ctx context.Context, rclient client.Client, newCJ, prevCJ *batchv1.CronJob) error {
+	var isPrevEqual bool
+	if prevCJ != nil {
+		isPrevEqual = equality.Semantic.DeepDerivative(prevCJ.Spec, newCJ.Spec)
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		currentCJ := &batchv1.CronJob{}
+		err := rclient.Get(ctx, types.NamespacedName{Namespace: newCJ.Namespace, Name: newCJ.Name}, currentCJ)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				logger.WithContext(ctx).Info(fmt.Sprintf("creating new CronJob %s", newCJ.Name))
+				return createObject(ctx, rclient, newCJ, "CronJob")
+			}
+			return fmt.Errorf("cannot get existing CronJob: %s, err: %w", newCJ.Name, err)
+		}
+		if err := finalize.FreeIfNeeded(ctx, rclient, currentCJ); err != nil {
+			return err
+		}
+
+		preserveChildLabels(newCJ, currentCJ)
+		var prevAnnotations map[string]string
+		if prevCJ != nil {
+			prevAnnotations = prevCJ.Annotations
+		}
+
+		if equality.Semantic.DeepDerivative(newCJ.Spec, currentCJ.Spec) &&
+			isPrevEqual &&
+			equality.Semantic.DeepEqual(newCJ.Labels, currentCJ.Labels) &&
+			isAnnotationsEqual(currentCJ.Annotations, newCJ.Annotations, prevAnnotations) {
+			return nil
+		}
+		logger.WithContext(ctx).Info(fmt.Sprintf("updating CronJob %s configuration", newCJ.Name))
+
+		cloneSignificantMetadata(newCJ, currentCJ)
+		newCJ.Status = currentCJ.Status
+		newCJ.Annotations = mergeAnnotations(currentCJ.Annotations, newCJ.Annotations, prevAnnotations)
+
+		return rclient.Update(ctx, newCJ)
+	})
+}
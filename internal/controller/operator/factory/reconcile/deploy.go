@@ -18,10 +18,13 @@ import (
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/tracing"
 )
 
 // Deployment performs an update or create operator for deployment and waits until it's replicas is ready
-func Deployment(ctx context.Context, rclient client.Client, newDeploy, prevDeploy *appsv1.Deployment, hasHPA bool) error {
+func Deployment(ctx context.Context, rclient client.Client, newDeploy, prevDeploy *appsv1.Deployment, hasHPA bool) (resultErr error) {
+	ctx, span := tracing.StartForObject(ctx, "reconcile.Deployment", newDeploy)
+	defer func() { tracing.End(span, resultErr) }()
 
 	var isPrevEqual bool
 	if prevDeploy != nil {
@@ -35,7 +38,7 @@ func Deployment(ctx context.Context, rclient client.Client, newDeploy, prevDeplo
 		if err != nil {
 			if errors.IsNotFound(err) {
 				logger.WithContext(ctx).Info(fmt.Sprintf("creating new Deployment %s", newDeploy.Name))
-				if err := rclient.Create(ctx, newDeploy); err != nil {
+				if err := createObject(ctx, rclient, newDeploy, "Deployment"); err != nil {
 					return fmt.Errorf("cannot create new deployment for app: %s, err: %w", newDeploy.Name, err)
 				}
 				return waitDeploymentReady(ctx, rclient, newDeploy, appWaitReadyDeadline)
@@ -48,7 +51,11 @@ func Deployment(ctx context.Context, rclient client.Client, newDeploy, prevDeplo
 		if hasHPA {
 			newDeploy.Spec.Replicas = currentDeploy.Spec.Replicas
 		}
+		if err := preserveFields(newDeploy, &currentDeploy, "Deployment"); err != nil {
+			return fmt.Errorf("cannot preserve fields of deployment %s: %w", newDeploy.Name, err)
+		}
 		newDeploy.Status = currentDeploy.Status
+		preserveChildLabels(newDeploy, &currentDeploy)
 		var prevAnnotations map[string]string
 		if prevDeploy != nil {
 			prevAnnotations = prevDeploy.Annotations
@@ -14,15 +14,15 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
 
-	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 )
 
 func TestDeployOk(t *testing.T) {
 	f := func(dep *appsv1.Deployment) {
 		t.Helper()
 		ctx := context.Background()
-		rclient := k8stools.GetTestClientWithObjects(nil)
-		clientStats := rclient.(*k8stools.TestClientWithStatsTrack)
+		rclient := testutil.GetTestClientWithObjects(nil)
+		clientStats := rclient.(*testutil.TestClientWithStatsTrack)
 
 		waitTimeout := 5 * time.Second
 		prevDeploy := dep.DeepCopy()
@@ -0,0 +1,29 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GrafanaDashboard creates or updates grafana-operator GrafanaDashboard or ConfigMap for grafana sidecar
+// prevObj is ignored if it has different kind
+func GrafanaDashboard(ctx context.Context, rclient client.Client, newObj, prevObj client.Object) error {
+	switch obj := newObj.(type) {
+	case *corev1.ConfigMap:
+		var prevMeta *metav1.ObjectMeta
+		if prevCM, ok := prevObj.(*corev1.ConfigMap); ok {
+			prevMeta = &prevCM.ObjectMeta
+		}
+		return ConfigMap(ctx, rclient, obj, prevMeta)
+	case *unstructured.Unstructured:
+		prevGD, _ := prevObj.(*unstructured.Unstructured)
+		return unstructuredObject(ctx, rclient, obj, prevGD, "GrafanaDashboard", "grafana-operator CRDs must be installed at kubernetes cluster to use grafanaDashboard")
+	default:
+		return fmt.Errorf("BUG: unexpected grafana dashboard object type: %T", newObj)
+	}
+}
@@ -22,13 +22,14 @@ func HPA(ctx context.Context, rclient client.Client, newHPA, prevHPA *v2.Horizon
 		if err := rclient.Get(ctx, types.NamespacedName{Name: newHPA.GetName(), Namespace: newHPA.GetNamespace()}, &currentHPA); err != nil {
 			if errors.IsNotFound(err) {
 				logger.WithContext(ctx).Info(fmt.Sprintf("creating HPA %s configuration", newHPA.Name))
-				return rclient.Create(ctx, newHPA)
+				return createObject(ctx, rclient, newHPA, "HPA")
 			}
 			return fmt.Errorf("cannot get exist hpa object: %w", err)
 		}
 		if err := finalize.FreeIfNeeded(ctx, rclient, &currentHPA); err != nil {
 			return err
 		}
+		preserveChildLabels(newHPA, &currentHPA)
 		var prevAnnotations map[string]string
 		if prevHPA != nil {
 			prevAnnotations = prevHPA.Annotations
@@ -0,0 +1,53 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+// Ingress creates or updates Ingress
+// annotations added by 3rd party controllers, e.g. cert-manager, are kept
+func Ingress(ctx context.Context, rclient client.Client, newIng, prevIng *networkingv1.Ingress) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var currentIng networkingv1.Ingress
+		err := rclient.Get(ctx, types.NamespacedName{Namespace: newIng.Namespace, Name: newIng.Name}, &currentIng)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				logger.WithContext(ctx).Info(fmt.Sprintf("creating new Ingress %s", newIng.Name))
+				return createObject(ctx, rclient, newIng, "Ingress")
+			}
+			return fmt.Errorf("cannot get existing Ingress: %s, err: %w", newIng.Name, err)
+		}
+		if err := finalize.FreeIfNeeded(ctx, rclient, &currentIng); err != nil {
+			return err
+		}
+
+		preserveChildLabels(newIng, &currentIng)
+		var prevAnnotations map[string]string
+		if prevIng != nil {
+			prevAnnotations = prevIng.Annotations
+		}
+
+		if equality.Semantic.DeepEqual(newIng.Spec, currentIng.Spec) &&
+			equality.Semantic.DeepEqual(newIng.Labels, currentIng.Labels) &&
+			isAnnotationsEqual(currentIng.Annotations, newIng.Annotations, prevAnnotations) {
+			return nil
+		}
+		logger.WithContext(ctx).Info(fmt.Sprintf("updating Ingress %s configuration", newIng.Name))
+
+		cloneSignificantMetadata(newIng, &currentIng)
+		newIng.Annotations = mergeAnnotations(currentIng.Annotations, newIng.Annotations, prevAnnotations)
+
+		return rclient.Update(ctx, newIng)
+	})
+}
@@ -0,0 +1,15 @@
+package reconcile
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ScaledObject creates or updates KEDA ScaledObject
+// KEDA admission webhook may set default values for the spec,
+// so only fields managed by operator are compared with current object
+func ScaledObject(ctx context.Context, rclient client.Client, newSO, prevSO *unstructured.Unstructured) error {
+	return unstructuredObject(ctx, rclient, newSO, prevSO, "ScaledObject", "KEDA CRDs must be installed at kubernetes cluster to use keda")
+}
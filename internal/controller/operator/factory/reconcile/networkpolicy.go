@@ -0,0 +1,52 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+// NetworkPolicy creates or updates NetworkPolicy
+func NetworkPolicy(ctx context.Context, rclient client.Client, newNP, prevNP *networkingv1.NetworkPolicy) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var currentNP networkingv1.NetworkPolicy
+		err := rclient.Get(ctx, types.NamespacedName{Namespace: newNP.Namespace, Name: newNP.Name}, &currentNP)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				logger.WithContext(ctx).Info(fmt.Sprintf("creating new NetworkPolicy %s", newNP.Name))
+				return createObject(ctx, rclient, newNP, "NetworkPolicy")
+			}
+			return fmt.Errorf("cannot get existing NetworkPolicy: %s, err: %w", newNP.Name, err)
+		}
+		if err := finalize.FreeIfNeeded(ctx, rclient, &currentNP); err != nil {
+			return err
+		}
+
+		preserveChildLabels(newNP, &currentNP)
+		var prevAnnotations map[string]string
+		if prevNP != nil {
+			prevAnnotations = prevNP.Annotations
+		}
+
+		if equality.Semantic.DeepEqual(newNP.Spec, currentNP.Spec) &&
+			equality.Semantic.DeepEqual(newNP.Labels, currentNP.Labels) &&
+			isAnnotationsEqual(currentNP.Annotations, newNP.Annotations, prevAnnotations) {
+			return nil
+		}
+		logger.WithContext(ctx).Info(fmt.Sprintf("updating NetworkPolicy %s configuration", newNP.Name))
+
+		cloneSignificantMetadata(newNP, &currentNP)
+		newNP.Annotations = mergeAnnotations(currentNP.Annotations, newNP.Annotations, prevAnnotations)
+
+		return rclient.Update(ctx, newNP)
+	})
+}
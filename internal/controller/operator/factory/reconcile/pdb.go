@@ -23,7 +23,7 @@ func PDB(ctx context.Context, rclient client.Client, newPDB, prevPDB *policyv1.P
 		if err != nil {
 			if errors.IsNotFound(err) {
 				logger.WithContext(ctx).Info(fmt.Sprintf("creating new PDB %s", newPDB.Name))
-				return rclient.Create(ctx, newPDB)
+				return createObject(ctx, rclient, newPDB, "PDB")
 			}
 			return fmt.Errorf("cannot get existing pdb: %s, err: %w", newPDB.Name, err)
 		}
@@ -31,6 +31,7 @@ func PDB(ctx context.Context, rclient client.Client, newPDB, prevPDB *policyv1.P
 			return err
 		}
 
+		preserveChildLabels(newPDB, currentPdb)
 		var prevAnnotations map[string]string
 		if prevPDB != nil {
 			prevAnnotations = prevPDB.Annotations
@@ -28,7 +28,7 @@ func PersistentVolumeClaim(ctx context.Context, rclient client.Client, newPVC, p
 	if err != nil {
 		if errors.IsNotFound(err) {
 			l.Info(fmt.Sprintf("creating new PVC %s", newPVC.Name))
-			if err := rclient.Create(ctx, newPVC); err != nil {
+			if err := createObject(ctx, rclient, newPVC, "PVC"); err != nil {
 				return fmt.Errorf("cannot create new PVC: %w", err)
 			}
 			return nil
@@ -42,6 +42,7 @@ func PersistentVolumeClaim(ctx context.Context, rclient client.Client, newPVC, p
 	}
 	newSize := newPVC.Spec.Resources.Requests.Storage()
 	oldSize := currentPVC.Spec.Resources.Requests.Storage()
+	preserveChildLabels(newPVC, currentPVC)
 	var prevAnnotations map[string]string
 	if prevPVC != nil {
 		prevAnnotations = prevPVC.Annotations
@@ -21,7 +21,7 @@ func RoleBinding(ctx context.Context, rclient client.Client, newRB, prevRB *rbac
 	if err := rclient.Get(ctx, types.NamespacedName{Namespace: newRB.Namespace, Name: newRB.Name}, &currentRB); err != nil {
 		if errors.IsNotFound(err) {
 			logger.WithContext(ctx).Info(fmt.Sprintf("creating new RoleBinding %s", newRB.Name))
-			return rclient.Create(ctx, newRB)
+			return createObject(ctx, rclient, newRB, "RoleBinding")
 		}
 		return fmt.Errorf("cannot get exist rolebinding: %w", err)
 	}
@@ -29,6 +29,7 @@ func RoleBinding(ctx context.Context, rclient client.Client, newRB, prevRB *rbac
 		return err
 	}
 
+	preserveChildLabels(newRB, &currentRB)
 	var prevAnnotations map[string]string
 	if prevRB != nil {
 		prevAnnotations = prevRB.Annotations
@@ -58,13 +59,14 @@ func Role(ctx context.Context, rclient client.Client, newRL, prevRL *rbacv1.Role
 	if err := rclient.Get(ctx, types.NamespacedName{Namespace: newRL.Namespace, Name: newRL.Name}, &currentRL); err != nil {
 		if errors.IsNotFound(err) {
 			logger.WithContext(ctx).Info(fmt.Sprintf("creating new Role %s", newRL.Name))
-			return rclient.Create(ctx, newRL)
+			return createObject(ctx, rclient, newRL, "Role")
 		}
 		return fmt.Errorf("cannot get exist role: %w", err)
 	}
 	if err := finalize.FreeIfNeeded(ctx, rclient, &currentRL); err != nil {
 		return err
 	}
+	preserveChildLabels(newRL, &currentRL)
 	var prevAnnotations map[string]string
 	if prevRL != nil {
 		prevAnnotations = prevRL.Annotations
@@ -101,6 +103,7 @@ func ClusterRoleBinding(ctx context.Context, rclient client.Client, newCRB, prev
 		return err
 	}
 
+	preserveChildLabels(newCRB, &currentCRB)
 	var prevAnnotations map[string]string
 	if prevCRB != nil {
 		prevAnnotations = prevCRB.Annotations
@@ -138,6 +141,7 @@ func ClusterRole(ctx context.Context, rclient client.Client, newClusterRole, pre
 	if err := finalize.FreeIfNeeded(ctx, rclient, &currentClusterRole); err != nil {
 		return err
 	}
+	preserveChildLabels(newClusterRole, &currentClusterRole)
 	var prevAnnotations map[string]string
 	if prevClusterRole != nil {
 		prevAnnotations = prevClusterRole.Annotations
@@ -1,17 +1,27 @@
 package reconcile
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 )
 
 var (
 	podWaitReadyIntervalCheck = 50 * time.Millisecond
 	appWaitReadyDeadline      = 5 * time.Second
 	podWaitReadyTimeout       = 5 * time.Second
+
+	preservedChildLabelPrefixes []string
+	// parsed json pointers of preserved fields by object kind
+	preservedChildFields map[string][][]string
 )
 
 // InitFromConfig sets package configuration from config
@@ -72,6 +82,36 @@ func isAnnotationsEqual(currentA, newA, prevA map[string]string) bool {
 	return true
 }
 
+// InitPreservedChildLabelPrefixes sets prefixes of labels, which must be kept at generated objects
+// if they were added by 3rd party tools
+func InitPreservedChildLabelPrefixes(prefixes []string) {
+	preservedChildLabelPrefixes = prefixes
+}
+
+// preserveChildLabels copies labels of current object matching preservedChildLabelPrefixes into new object
+// labels defined by operator take precedence
+func preserveChildLabels(newObj, currObj client.Object) {
+	if len(preservedChildLabelPrefixes) == 0 {
+		return
+	}
+	newLabels := newObj.GetLabels()
+	for k, v := range currObj.GetLabels() {
+		if _, ok := newLabels[k]; ok {
+			continue
+		}
+		for _, prefix := range preservedChildLabelPrefixes {
+			if strings.HasPrefix(k, prefix) {
+				if newLabels == nil {
+					newLabels = make(map[string]string)
+				}
+				newLabels[k] = v
+				break
+			}
+		}
+	}
+	newObj.SetLabels(newLabels)
+}
+
 func cloneSignificantMetadata(newObj, currObj client.Object) {
 	// empty ResourceVersion for some resources produces the following error
 	// is invalid: metadata.resourceVersion: Invalid value: 0x0: must be specified for an update
@@ -85,6 +125,118 @@ func cloneSignificantMetadata(newObj, currObj client.Object) {
 	newObj.SetSelfLink(currObj.GetSelfLink())
 }
 
+// InitPreservedChildFields sets fields of generated objects, which must not be overwritten by operator
+// each field is defined as Kind:/json/pointer, e.g. Deployment:/spec/replicas
+func InitPreservedChildFields(fields []string) error {
+	parsed := make(map[string][][]string)
+	for _, field := range fields {
+		kind, pointer, ok := strings.Cut(strings.TrimSpace(field), ":")
+		if !ok {
+			return fmt.Errorf("field=%q must be defined as Kind:/json/pointer", field)
+		}
+		switch kind {
+		case "Deployment", "StatefulSet", "Service":
+		default:
+			return fmt.Errorf("field=%q has unsupported kind=%q, only Deployment, StatefulSet and Service are supported", field, kind)
+		}
+		path, err := parseJSONPointer(pointer)
+		if err != nil {
+			return fmt.Errorf("cannot parse field=%q: %w", field, err)
+		}
+		parsed[kind] = append(parsed[kind], path)
+	}
+	preservedChildFields = parsed
+	return nil
+}
+
+// preserveFields copies preserved fields of the given kind from current object into new object
+// it allows to keep changes made by 3rd party controllers, e.g. replicas managed by custom autoscaler
+func preserveFields(newObj, currObj client.Object, kind string) error {
+	paths := preservedChildFields[kind]
+	if len(paths) == 0 {
+		return nil
+	}
+	currContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(currObj)
+	if err != nil {
+		return fmt.Errorf("cannot convert current object to unstructured: %w", err)
+	}
+	newContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newObj)
+	if err != nil {
+		return fmt.Errorf("cannot convert new object to unstructured: %w", err)
+	}
+	for _, path := range paths {
+		if v, ok := getNestedValue(currContent, path); ok {
+			setNestedValue(newContent, path, v)
+		} else {
+			deleteNestedValue(newContent, path)
+		}
+	}
+	// reset object before conversion, otherwise maps and slices could be merged
+	reflect.ValueOf(newObj).Elem().Set(reflect.Zero(reflect.TypeOf(newObj).Elem()))
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(newContent, newObj); err != nil {
+		return fmt.Errorf("cannot convert unstructured into new object: %w", err)
+	}
+	return nil
+}
+
+// parseJSONPointer parses RFC 6901 JSON pointer into path segments
+func parseJSONPointer(pointer string) ([]string, error) {
+	if !strings.HasPrefix(pointer, "/") || len(pointer) == 1 {
+		return nil, fmt.Errorf("json pointer=%q must start with / and point to the object field", pointer)
+	}
+	path := strings.Split(pointer[1:], "/")
+	for i, p := range path {
+		path[i] = strings.ReplaceAll(strings.ReplaceAll(p, "~1", "/"), "~0", "~")
+	}
+	return path, nil
+}
+
+func getNestedValue(content map[string]any, path []string) (any, bool) {
+	var v any = content
+	for _, p := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[p]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func setNestedValue(content map[string]any, path []string, value any) {
+	m := content
+	for _, p := range path[:len(path)-1] {
+		next, ok := m[p].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			m[p] = next
+		}
+		m = next
+	}
+	m[path[len(path)-1]] = value
+}
+
+func deleteNestedValue(content map[string]any, path []string) {
+	parent, ok := getNestedValue(content, path[:len(path)-1])
+	if !ok {
+		return
+	}
+	if m, ok := parent.(map[string]any); ok {
+		delete(m, path[len(path)-1])
+	}
+}
+
+// createObject creates given object and emits event for its controller owner
+func createObject(ctx context.Context, rclient client.Client, obj client.Object, kind string) error {
+	if err := rclient.Create(ctx, obj); err != nil {
+		return err
+	}
+	events.Normal(events.ControllerOf(obj), events.ReasonCreated, fmt.Sprintf("created %s %s", kind, obj.GetName()))
+	return nil
+}
+
 // IsErrorWaitTimeout determines if the err is an error which indicates that timeout for app
 // transition into Ready state reached and should be continued at the next reconcile loop
 func IsErrorWaitTimeout(err error) bool {
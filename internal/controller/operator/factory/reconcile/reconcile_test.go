@@ -0,0 +1,93 @@
+package reconcile
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestPreserveFields(t *testing.T) {
+	f := func(fields []string, newDep, currDep, wantDep *appsv1.Deployment, wantErr bool) {
+		t.Helper()
+		err := InitPreservedChildFields(fields)
+		defer func() { preservedChildFields = nil }()
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error, got: %v, wantErr: %v", err, wantErr)
+		}
+		if wantErr {
+			return
+		}
+		if err := preserveFields(newDep, currDep, "Deployment"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !equality.Semantic.DeepEqual(newDep, wantDep) {
+			t.Fatalf("unexpected deployment\ngot:  %v\nwant: %v", newDep, wantDep)
+		}
+	}
+	newDep := func(replicas int32, labels map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "vmagent-main", Namespace: "default", Labels: labels},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(replicas)},
+		}
+	}
+
+	// no preserved fields
+	f(nil, newDep(1, nil), newDep(3, nil), newDep(1, nil), false)
+
+	// preserve replicas and label
+	f([]string{"Deployment:/spec/replicas", "Deployment:/metadata/labels/team.io~1owner"},
+		newDep(1, map[string]string{"app": "vmagent"}),
+		newDep(3, map[string]string{"app": "vmagent", "team.io/owner": "infra"}),
+		newDep(3, map[string]string{"app": "vmagent", "team.io/owner": "infra"}), false)
+
+	// fields of another kind
+	f([]string{"StatefulSet:/spec/replicas"}, newDep(1, nil), newDep(3, nil), newDep(1, nil), false)
+
+	// field removed from current object
+	f([]string{"Deployment:/metadata/labels/app"},
+		newDep(1, map[string]string{"app": "vmagent", "managed-by": "vm-operator"}),
+		newDep(1, map[string]string{"managed-by": "vm-operator"}),
+		newDep(1, map[string]string{"managed-by": "vm-operator"}), false)
+
+	// incorrect pointer
+	f([]string{"Deployment:spec.replicas"}, nil, nil, nil, true)
+
+	// missing kind
+	f([]string{"/spec/replicas"}, nil, nil, nil, true)
+
+	// unsupported kind
+	f([]string{"ConfigMap:/data"}, nil, nil, nil, true)
+}
+
+func TestPreserveChildLabels(t *testing.T) {
+	f := func(prefixes []string, newLabels, currLabels, wantLabels map[string]string) {
+		t.Helper()
+		InitPreservedChildLabelPrefixes(prefixes)
+		defer InitPreservedChildLabelPrefixes(nil)
+		newCM := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Labels: newLabels}}
+		currCM := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Labels: currLabels}}
+		preserveChildLabels(newCM, currCM)
+		if !equality.Semantic.DeepEqual(newCM.Labels, wantLabels) {
+			t.Fatalf("unexpected labels\ngot:  %v\nwant: %v", newCM.Labels, wantLabels)
+		}
+	}
+
+	// preservation disabled
+	f(nil, map[string]string{"app": "vmagent"}, map[string]string{"app": "vmagent", "policy.example.com/checked": "true"}, map[string]string{"app": "vmagent"})
+
+	// keep labels with matched prefix
+	f([]string{"policy.example.com/", "cost"},
+		map[string]string{"app": "vmagent"},
+		map[string]string{"app": "vmagent", "policy.example.com/checked": "true", "cost-center": "infra", "team": "infra"},
+		map[string]string{"app": "vmagent", "policy.example.com/checked": "true", "cost-center": "infra"})
+
+	// operator defined labels take precedence
+	f([]string{"cost"}, map[string]string{"cost-center": "monitoring"}, map[string]string{"cost-center": "infra"}, map[string]string{"cost-center": "monitoring"})
+
+	// new object without labels
+	f([]string{"cost"}, nil, map[string]string{"cost-center": "infra"}, map[string]string{"cost-center": "infra"})
+}
@@ -0,0 +1,21 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+// Route creates or updates OpenShift Route
+// OpenShift generates host for the route if it's not defined,
+// so only fields managed by operator are compared with current object
+func Route(ctx context.Context, rclient client.Client, newRoute, prevRoute *unstructured.Unstructured) error {
+	if !k8stools.IsOpenShift() {
+		return fmt.Errorf("cannot create route %s: OpenShift support is disabled, check VM_OPENSHIFT operator configuration", newRoute.GetName())
+	}
+	return unstructuredObject(ctx, rclient, newRoute, prevRoute, "Route", "route.openshift.io API is served only by OpenShift, route cannot be used at this cluster")
+}
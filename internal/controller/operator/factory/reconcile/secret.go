@@ -11,24 +11,29 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/tracing"
 )
 
 // Secret reconciles secret object
-func Secret(ctx context.Context, rclient client.Client, newS *corev1.Secret, prevMeta *metav1.ObjectMeta) error {
+func Secret(ctx context.Context, rclient client.Client, newS *corev1.Secret, prevMeta *metav1.ObjectMeta) (resultErr error) {
+	ctx, span := tracing.StartForObject(ctx, "reconcile.Secret", newS)
+	defer func() { tracing.End(span, resultErr) }()
 	var currentS corev1.Secret
 
 	if err := rclient.Get(ctx, types.NamespacedName{Namespace: newS.Namespace, Name: newS.Name}, &currentS); err != nil {
 		if errors.IsNotFound(err) {
 			logger.WithContext(ctx).Info(fmt.Sprintf("creating new Secret %s", newS.Name))
-			return rclient.Create(ctx, newS)
+			return createObject(ctx, rclient, newS, "Secret")
 		}
 		return err
 	}
 	if err := finalize.FreeIfNeeded(ctx, rclient, &currentS); err != nil {
 		return err
 	}
+	preserveChildLabels(newS, &currentS)
 	var prevAnnotations map[string]string
 	if prevMeta != nil {
 		prevAnnotations = prevMeta.Annotations
@@ -45,5 +50,9 @@ func Secret(ctx context.Context, rclient client.Client, newS *corev1.Secret, pre
 
 	logger.WithContext(ctx).Info(fmt.Sprintf("updating configuration Secret %s", newS.Name))
 
-	return rclient.Update(ctx, newS)
+	if err := rclient.Update(ctx, newS); err != nil {
+		return err
+	}
+	events.Normal(events.ControllerOf(newS), events.ReasonConfigUpdated, fmt.Sprintf("updated configuration Secret %s", newS.Name))
+	return nil
 }
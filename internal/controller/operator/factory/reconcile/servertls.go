@@ -0,0 +1,164 @@
+package reconcile
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/tracing"
+)
+
+const (
+	serverTLSCertValidity = 365 * 24 * time.Hour
+	// certificate is re-issued, if it expires earlier
+	serverTLSCertRenewBefore = 30 * 24 * time.Hour
+)
+
+// ServerTLSSecret reconciles secret with self-signed certificate for serverTLS
+// it's no-op if certificate generation isn't requested with serverTLS.selfSigned
+//
+// certificate is valid for localhost, service and service pods DNS names
+// and it's re-issued before expiration or on DNS names change
+func ServerTLSSecret(ctx context.Context, rclient client.Client, st *vmv1beta1.ServerTLS, meta metav1.ObjectMeta, serviceName string) (resultErr error) {
+	if st == nil || !st.SelfSigned {
+		return nil
+	}
+	meta.Name = st.SecretName
+	newS := &corev1.Secret{
+		ObjectMeta: meta,
+		Type:       corev1.SecretTypeTLS,
+	}
+	ctx, span := tracing.StartForObject(ctx, "reconcile.ServerTLSSecret", newS)
+	defer func() { tracing.End(span, resultErr) }()
+
+	dnsNames := serverTLSDNSNames(serviceName, meta.Namespace)
+	var currentS corev1.Secret
+	if err := rclient.Get(ctx, types.NamespacedName{Namespace: newS.Namespace, Name: newS.Name}, &currentS); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("cannot get serverTLS secret=%q: %w", newS.Name, err)
+		}
+		data, err := issueServerTLSCert(dnsNames, time.Now())
+		if err != nil {
+			return err
+		}
+		newS.Data = data
+		logger.WithContext(ctx).Info(fmt.Sprintf("creating new serverTLS Secret %s", newS.Name))
+		return createObject(ctx, rclient, newS, "Secret")
+	}
+	if !isOwnedBy(&currentS, meta.OwnerReferences) {
+		return fmt.Errorf("serverTLS secret=%q already exists and isn't managed by operator, remove it or disable serverTLS.selfSigned", newS.Name)
+	}
+	if !needServerTLSCertRenew(currentS.Data[corev1.TLSCertKey], dnsNames, time.Now()) {
+		return nil
+	}
+	data, err := issueServerTLSCert(dnsNames, time.Now())
+	if err != nil {
+		return err
+	}
+	newS.Data = data
+	newS.Annotations = currentS.Annotations
+	newS.ResourceVersion = currentS.ResourceVersion
+	logger.WithContext(ctx).Info(fmt.Sprintf("renewing certificate at serverTLS Secret %s", newS.Name))
+	return rclient.Update(ctx, newS)
+}
+
+func isOwnedBy(obj metav1.Object, owners []metav1.OwnerReference) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		for _, owner := range owners {
+			if ref.UID == owner.UID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func serverTLSDNSNames(serviceName, namespace string) []string {
+	dnsNames := []string{
+		"localhost",
+		serviceName,
+		fmt.Sprintf("%s.%s", serviceName, namespace),
+		fmt.Sprintf("%s.%s.svc", serviceName, namespace),
+		// pods of headless service
+		fmt.Sprintf("*.%s.%s.svc", serviceName, namespace),
+	}
+	if domain := config.MustGetBaseConfigForNamespace(namespace).ClusterDomainName; domain != "" {
+		dnsNames = append(dnsNames,
+			fmt.Sprintf("%s.%s.svc.%s", serviceName, namespace, domain),
+			fmt.Sprintf("*.%s.%s.svc.%s", serviceName, namespace, domain),
+		)
+	}
+	return dnsNames
+}
+
+func needServerTLSCertRenew(certPEM []byte, dnsNames []string, now time.Time) bool {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return true
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+	if cert.NotAfter.Sub(now) < serverTLSCertRenewBefore {
+		return true
+	}
+	return !slices.Equal(cert.DNSNames, dnsNames)
+}
+
+// issueServerTLSCert returns self-signed certificate with tls.crt, tls.key and ca.crt secret keys
+// ca.crt contains the certificate itself and allows clients to verify it
+func issueServerTLSCert(dnsNames []string, now time.Time) (map[string][]byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate serverTLS private key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate serverTLS certificate serial number: %w", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: dnsNames[1], Organization: []string{"VictoriaMetrics operator"}},
+		DNSNames:              dnsNames,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(serverTLSCertValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create serverTLS certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal serverTLS private key: %w", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return map[string][]byte{
+		corev1.TLSCertKey:        certPEM,
+		corev1.TLSPrivateKeyKey:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		vmv1beta1.ServerTLSCAKey: certPEM,
+	}, nil
+}
@@ -0,0 +1,116 @@
+package reconcile
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+)
+
+func TestServerTLSSecret(t *testing.T) {
+	owner := []metav1.OwnerReference{{APIVersion: "operator.victoriametrics.com/v1beta1", Kind: "VMSingle", Name: "example", UID: "vmsingle-uid"}}
+	meta := metav1.ObjectMeta{Namespace: "default", OwnerReferences: owner}
+	nsn := types.NamespacedName{Namespace: "default", Name: "vmsingle-tls"}
+	ctx := context.Background()
+
+	f := func(st *vmv1beta1.ServerTLS, predefinedObjects []runtime.Object, wantErr bool, validate func(s *corev1.Secret)) {
+		t.Helper()
+		rclient := testutil.GetTestClientWithObjects(predefinedObjects)
+		err := ServerTLSSecret(ctx, rclient, st, meta, "vmsingle-example")
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if validate == nil {
+			return
+		}
+		var s corev1.Secret
+		if err := rclient.Get(ctx, nsn, &s); err != nil {
+			t.Fatalf("cannot get secret: %s", err)
+		}
+		validate(&s)
+	}
+
+	verify := func(s *corev1.Secret, serverName string) {
+		t.Helper()
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(s.Data[vmv1beta1.ServerTLSCAKey]) {
+			t.Fatalf("cannot parse ca.crt")
+		}
+		cert, err := tls.X509KeyPair(s.Data[corev1.TLSCertKey], s.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			t.Fatalf("cannot load key pair: %s", err)
+		}
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			t.Fatalf("cannot parse certificate: %s", err)
+		}
+		if _, err := leaf.Verify(x509.VerifyOptions{Roots: pool, DNSName: serverName}); err != nil {
+			t.Fatalf("cannot verify certificate for %q: %s", serverName, err)
+		}
+	}
+
+	// certificate generation is disabled
+	f(&vmv1beta1.ServerTLS{SecretName: "vmsingle-tls"}, nil, false, nil)
+	f(nil, nil, false, nil)
+
+	// create new secret
+	f(&vmv1beta1.ServerTLS{SecretName: "vmsingle-tls", SelfSigned: true}, nil, false, func(s *corev1.Secret) {
+		verify(s, "localhost")
+		verify(s, "vmsingle-example.default.svc")
+		verify(s, "vmsingle-example-0.vmsingle-example.default.svc")
+	})
+
+	// keep valid certificate
+	data, err := issueServerTLSCert(serverTLSDNSNames("vmsingle-example", "default"), time.Now())
+	if err != nil {
+		t.Fatalf("cannot issue certificate: %s", err)
+	}
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vmsingle-tls", Namespace: "default", OwnerReferences: owner},
+		Data:       data,
+	}
+	f(&vmv1beta1.ServerTLS{SecretName: "vmsingle-tls", SelfSigned: true}, []runtime.Object{existing}, false, func(s *corev1.Secret) {
+		if string(s.Data[corev1.TLSCertKey]) != string(data[corev1.TLSCertKey]) {
+			t.Fatalf("unexpected certificate renew")
+		}
+	})
+
+	// renew expiring certificate
+	expiring, err := issueServerTLSCert(serverTLSDNSNames("vmsingle-example", "default"), time.Now().Add(-serverTLSCertValidity+time.Hour))
+	if err != nil {
+		t.Fatalf("cannot issue certificate: %s", err)
+	}
+	existing = existing.DeepCopy()
+	existing.Data = expiring
+	f(&vmv1beta1.ServerTLS{SecretName: "vmsingle-tls", SelfSigned: true}, []runtime.Object{existing}, false, func(s *corev1.Secret) {
+		if string(s.Data[corev1.TLSCertKey]) == string(expiring[corev1.TLSCertKey]) {
+			t.Fatalf("expected certificate renew")
+		}
+		verify(s, "vmsingle-example.default.svc")
+	})
+
+	// renew certificate issued for another service
+	other, err := issueServerTLSCert(serverTLSDNSNames("vmsingle-other", "default"), time.Now())
+	if err != nil {
+		t.Fatalf("cannot issue certificate: %s", err)
+	}
+	existing = existing.DeepCopy()
+	existing.Data = other
+	f(&vmv1beta1.ServerTLS{SecretName: "vmsingle-tls", SelfSigned: true}, []runtime.Object{existing}, false, func(s *corev1.Secret) {
+		verify(s, "vmsingle-example.default.svc")
+	})
+
+	// secret isn't managed by operator
+	f(&vmv1beta1.ServerTLS{SecretName: "vmsingle-tls", SelfSigned: true}, []runtime.Object{
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "vmsingle-tls", Namespace: "default"}, Data: data},
+	}, true, nil)
+}
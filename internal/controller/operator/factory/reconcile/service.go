@@ -10,11 +10,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/tracing"
 )
 
 // Service - reconcile needed and actual state of service for given crd,
@@ -22,7 +24,9 @@ import (
 // NOTE it doesn't perform validation:
 // in case of spec.type= LoadBalancer or NodePort, clusterIP: None is not allowed,
 // its users responsibility to define it correctly.
-func Service(ctx context.Context, rclient client.Client, newService, prevService *corev1.Service) error {
+func Service(ctx context.Context, rclient client.Client, newService, prevService *corev1.Service) (resultErr error) {
+	ctx, span := tracing.StartForObject(ctx, "reconcile.Service", newService)
+	defer func() { tracing.End(span, resultErr) }()
 	svcForReconcile := newService.DeepCopy()
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		return reconcileService(ctx, rclient, svcForReconcile, prevService)
@@ -53,7 +57,7 @@ func reconcileService(ctx context.Context, rclient client.Client, newService, pr
 	if err != nil {
 		if errors.IsNotFound(err) {
 			logger.WithContext(ctx).Info(fmt.Sprintf("creating new Service %s", newService.Name))
-			err := rclient.Create(ctx, newService)
+			err := createObject(ctx, rclient, newService, "Service")
 			if err != nil {
 				return fmt.Errorf("cannot create new service: %w", err)
 			}
@@ -81,12 +85,25 @@ func reconcileService(ctx context.Context, rclient client.Client, newService, pr
 	case newService.Spec.ClusterIP == "" && currentService.Spec.ClusterIP == "None":
 		// serviceType changes from headless to clusterIP
 		return recreateService(currentService)
+	case len(newService.Spec.IPFamilies) > 0 && len(currentService.Spec.IPFamilies) > 0 &&
+		newService.Spec.IPFamilies[0] != currentService.Spec.IPFamilies[0]:
+		// primary ip family is immutable
+		return recreateService(currentService)
 	}
 
 	// keep given clusterIP for service.
 	if newService.Spec.ClusterIP != "None" {
 		newService.Spec.ClusterIP = currentService.Spec.ClusterIP
 	}
+	// downgrade from dual-stack requires removal of secondary ip family and clusterIP
+	if ptr.Deref(newService.Spec.IPFamilyPolicy, "") == corev1.IPFamilyPolicySingleStack && len(currentService.Spec.IPFamilies) > 1 {
+		if len(newService.Spec.IPFamilies) == 0 {
+			newService.Spec.IPFamilies = currentService.Spec.IPFamilies[:1]
+		}
+		if newService.Spec.ClusterIP != "None" && len(currentService.Spec.ClusterIPs) > 1 {
+			newService.Spec.ClusterIPs = currentService.Spec.ClusterIPs[:1]
+		}
+	}
 	// keep allocated node ports.
 	if newService.Spec.Type == currentService.Spec.Type {
 		for i := range currentService.Spec.Ports {
@@ -102,6 +119,10 @@ func reconcileService(ctx context.Context, rclient client.Client, newService, pr
 		}
 	}
 
+	if err := preserveFields(newService, currentService, "Service"); err != nil {
+		return fmt.Errorf("cannot preserve fields of service %s: %w", newService.Name, err)
+	}
+	preserveChildLabels(newService, currentService)
 	var prevAnnotations map[string]string
 	if prevService != nil {
 		prevAnnotations = prevService.Annotations
@@ -131,6 +152,20 @@ func reconcileService(ctx context.Context, rclient client.Client, newService, pr
 	return nil
 }
 
+// Services reconciles each given service with the matching by name service from previous state
+func Services(ctx context.Context, rclient client.Client, newServices, prevServices []*corev1.Service) error {
+	prevByName := make(map[string]*corev1.Service, len(prevServices))
+	for _, prevSvc := range prevServices {
+		prevByName[prevSvc.Name] = prevSvc
+	}
+	for _, newSvc := range newServices {
+		if err := Service(ctx, rclient, newSvc, prevByName[newSvc.Name]); err != nil {
+			return fmt.Errorf("cannot reconcile service=%q: %w", newSvc.Name, err)
+		}
+	}
+	return nil
+}
+
 // AdditionalServices reconcile AdditionalServices
 // by conditionally removing service from previous state
 func AdditionalServices(ctx context.Context, rclient client.Client,
@@ -165,3 +200,26 @@ func AdditionalServices(ctx context.Context, rclient client.Client,
 	}
 	return nil
 }
+
+// AdditionalServiceList removes services from previous state
+// which are missing at current list of additional services
+func AdditionalServiceList(ctx context.Context, rclient client.Client,
+	namespace string,
+	prevSvcs, currSvcs []vmv1beta1.AdditionalServiceSpec) error {
+	currNames := make(map[string]struct{}, len(currSvcs))
+	for _, svc := range currSvcs {
+		currNames[svc.Name] = struct{}{}
+	}
+	for _, svc := range prevSvcs {
+		if _, ok := currNames[svc.Name]; ok {
+			continue
+		}
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient,
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      svc.Name}}); err != nil {
+			return fmt.Errorf("cannot remove additional service=%q: %w", svc.Name, err)
+		}
+	}
+	return nil
+}
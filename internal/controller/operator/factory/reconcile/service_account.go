@@ -23,13 +23,14 @@ func ServiceAccount(ctx context.Context, rclient client.Client, newSA, prevSA *c
 		if err := rclient.Get(ctx, types.NamespacedName{Name: newSA.Name, Namespace: newSA.Namespace}, &currentSA); err != nil {
 			if errors.IsNotFound(err) {
 				logger.WithContext(ctx).Info(fmt.Sprintf("creating new ServiceAccount %s", newSA.Name))
-				return rclient.Create(ctx, newSA)
+				return createObject(ctx, rclient, newSA, "ServiceAccount")
 			}
 			return fmt.Errorf("cannot get ServiceAccount: %w", err)
 		}
 		if err := finalize.FreeIfNeeded(ctx, rclient, &currentSA); err != nil {
 			return err
 		}
+		preserveChildLabels(newSA, &currentSA)
 		var prevAnnotations map[string]string
 		if prevSA != nil {
 			prevAnnotations = prevSA.Annotations
@@ -6,11 +6,14 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
-	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 )
 
 func Test_reconcileServiceForCRD(t *testing.T) {
@@ -252,10 +255,88 @@ func Test_reconcileServiceForCRD(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name: "downgrade svc from dual-stack to single-stack",
+			args: args{
+				newService: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "prefixed-1",
+						Namespace: "default",
+					},
+					Spec: corev1.ServiceSpec{
+						Type:           corev1.ServiceTypeClusterIP,
+						IPFamilyPolicy: ptr.To(corev1.IPFamilyPolicySingleStack),
+					},
+				},
+				ctx: context.TODO(),
+			},
+			predefinedObjects: []runtime.Object{
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "prefixed-1",
+						Namespace: "default",
+					},
+					Spec: corev1.ServiceSpec{
+						Type:           corev1.ServiceTypeClusterIP,
+						ClusterIP:      "10.0.0.5",
+						ClusterIPs:     []string{"10.0.0.5", "fd00::5"},
+						IPFamilies:     []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+						IPFamilyPolicy: ptr.To(corev1.IPFamilyPolicyPreferDualStack),
+					},
+				},
+			},
+			validate: func(svc *corev1.Service) error {
+				if len(svc.Spec.ClusterIPs) != 1 || svc.Spec.ClusterIPs[0] != "10.0.0.5" {
+					return fmt.Errorf("unexpected clusterIPs, want: [10.0.0.5], got: %v", svc.Spec.ClusterIPs)
+				}
+				if len(svc.Spec.IPFamilies) != 1 || svc.Spec.IPFamilies[0] != corev1.IPv4Protocol {
+					return fmt.Errorf("unexpected ipFamilies, want: [IPv4], got: %v", svc.Spec.IPFamilies)
+				}
+				return nil
+			},
+		},
+		{
+			name: "change primary ip family",
+			args: args{
+				newService: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "prefixed-1",
+						Namespace: "default",
+					},
+					Spec: corev1.ServiceSpec{
+						Type:       corev1.ServiceTypeClusterIP,
+						IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol},
+					},
+				},
+				ctx: context.TODO(),
+			},
+			predefinedObjects: []runtime.Object{
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "prefixed-1",
+						Namespace: "default",
+					},
+					Spec: corev1.ServiceSpec{
+						Type:       corev1.ServiceTypeClusterIP,
+						ClusterIP:  "10.0.0.5",
+						IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+					},
+				},
+			},
+			validate: func(svc *corev1.Service) error {
+				if svc.Spec.ClusterIP == "10.0.0.5" {
+					return fmt.Errorf("service must be recreated with new clusterIP")
+				}
+				if len(svc.Spec.IPFamilies) != 1 || svc.Spec.IPFamilies[0] != corev1.IPv6Protocol {
+					return fmt.Errorf("unexpected ipFamilies, want: [IPv6], got: %v", svc.Spec.IPFamilies)
+				}
+				return nil
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cl := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			cl := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			err := Service(tt.args.ctx, cl, tt.args.newService, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("reconcileServiceForCRD() error = %v, wantErr %v", err, tt.wantErr)
@@ -271,3 +352,29 @@ func Test_reconcileServiceForCRD(t *testing.T) {
 		})
 	}
 }
+
+func TestAdditionalServiceList(t *testing.T) {
+	ctx := context.TODO()
+	svcMeta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "default"}
+	}
+	cl := testutil.GetTestClientWithObjects([]runtime.Object{
+		&corev1.Service{ObjectMeta: svcMeta("internal")},
+		&corev1.Service{ObjectMeta: svcMeta("external")},
+	})
+	spec := func(name string) vmv1beta1.AdditionalServiceSpec {
+		return vmv1beta1.AdditionalServiceSpec{EmbeddedObjectMetadata: vmv1beta1.EmbeddedObjectMetadata{Name: name}}
+	}
+	prev := []vmv1beta1.AdditionalServiceSpec{spec("internal"), spec("external")}
+	curr := []vmv1beta1.AdditionalServiceSpec{spec("internal")}
+	if err := AdditionalServiceList(ctx, cl, "default", prev, curr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var svc corev1.Service
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: "default", Name: "internal"}, &svc); err != nil {
+		t.Fatalf("service must be kept: %v", err)
+	}
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: "default", Name: "external"}, &svc); !errors.IsNotFound(err) {
+		t.Fatalf("service must be removed, got err: %v", err)
+	}
+}
@@ -21,16 +21,20 @@ import (
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/tracing"
 )
 
 const podRevisionLabel = "controller-revision-hash"
 
 // STSOptions options for StatefulSet update
-// HPA and UpdateReplicaCount optional
+// HPA, PreserveReplicas and UpdateReplicaCount optional
 type STSOptions struct {
-	HasClaim           bool
-	SelectorLabels     func() map[string]string
-	HPA                *vmv1beta1.EmbeddedHPA
+	HasClaim       bool
+	SelectorLabels func() map[string]string
+	HPA            *vmv1beta1.EmbeddedHPA
+	// PreserveReplicas keeps replicas of existing StatefulSet,
+	// it must be set if replicas are managed by external autoscaler
+	PreserveReplicas   bool
 	UpdateReplicaCount func(count *int32)
 }
 
@@ -59,7 +63,9 @@ func waitForStatefulSetReady(ctx context.Context, rclient client.Client, newSts
 }
 
 // HandleSTSUpdate performs create and update operations for given statefulSet with STSOptions
-func HandleSTSUpdate(ctx context.Context, rclient client.Client, cr STSOptions, newSts, prevSts *appsv1.StatefulSet) error {
+func HandleSTSUpdate(ctx context.Context, rclient client.Client, cr STSOptions, newSts, prevSts *appsv1.StatefulSet) (resultErr error) {
+	ctx, span := tracing.StartForObject(ctx, "reconcile.StatefulSet", newSts)
+	defer func() { tracing.End(span, resultErr) }()
 	var isPrevEqual bool
 	if prevSts != nil {
 		isPrevEqual = equality.Semantic.DeepDerivative(prevSts.Spec, newSts.Spec)
@@ -71,7 +77,7 @@ func HandleSTSUpdate(ctx context.Context, rclient client.Client, cr STSOptions,
 		if err := rclient.Get(ctx, types.NamespacedName{Name: newSts.Name, Namespace: newSts.Namespace}, &currentSts); err != nil {
 			if errors.IsNotFound(err) {
 				logger.WithContext(ctx).Info(fmt.Sprintf("creating new StatefulSet %s", newSts.Name))
-				if err = rclient.Create(ctx, newSts); err != nil {
+				if err = createObject(ctx, rclient, newSts, "StatefulSet"); err != nil {
 					return fmt.Errorf("cannot create new sts %s under namespace %s: %w", newSts.Name, newSts.Namespace, err)
 				}
 				return waitForStatefulSetReady(ctx, rclient, newSts)
@@ -89,9 +95,12 @@ func HandleSTSUpdate(ctx context.Context, rclient client.Client, cr STSOptions,
 		}
 
 		// do not change replicas count.
-		if cr.HPA != nil {
+		if cr.HPA != nil || cr.PreserveReplicas {
 			newSts.Spec.Replicas = currentSts.Spec.Replicas
 		}
+		if err := preserveFields(newSts, &currentSts, "StatefulSet"); err != nil {
+			return fmt.Errorf("cannot preserve fields of sts %s: %w", newSts.Name, err)
+		}
 		// hack for kubernetes 1.18
 		newSts.Status.Replicas = currentSts.Status.Replicas
 
@@ -103,6 +112,7 @@ func HandleSTSUpdate(ctx context.Context, rclient client.Client, cr STSOptions,
 		// if sts wasn't recreated, update it first
 		// before making call for performRollingUpdateOnSts
 		if !stsRecreated {
+			preserveChildLabels(newSts, &currentSts)
 			var prevAnnotations map[string]string
 			if prevSts != nil {
 				prevAnnotations = prevSts.Annotations
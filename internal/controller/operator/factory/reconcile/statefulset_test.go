@@ -15,7 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
 
-	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 )
 
 func Test_waitForPodReady(t *testing.T) {
@@ -94,7 +94,7 @@ func Test_waitForPodReady(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 
 			if err := waitForPodReady(context.Background(), fclient, tt.args.ns, tt.args.podName, 0); (err != nil) != tt.wantErr {
 				t.Errorf("waitForPodReady() error = %v, wantErr %v", err, tt.wantErr)
@@ -307,7 +307,7 @@ func Test_performRollingUpdateOnSts(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 
 			if err := performRollingUpdateOnSts(context.Background(), false, fclient, tt.args.stsName, tt.args.ns, tt.args.podLabels); (err != nil) != tt.wantErr {
 				t.Errorf("performRollingUpdateOnSts() error = %v, wantErr %v", err, tt.wantErr)
@@ -347,8 +347,8 @@ func TestStatefulsetReconcileOk(t *testing.T) {
 	f := func(sts *appsv1.StatefulSet) {
 		//	t.Helper()
 		ctx := context.Background()
-		rclient := k8stools.GetTestClientWithObjects(nil)
-		clientStats := rclient.(*k8stools.TestClientWithStatsTrack)
+		rclient := testutil.GetTestClientWithObjects(nil)
+		clientStats := rclient.(*testutil.TestClientWithStatsTrack)
 
 		waitTimeout := 5 * time.Second
 		prevSts := sts.DeepCopy()
@@ -2,6 +2,7 @@ package reconcile
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand/v2"
 	"reflect"
@@ -9,12 +10,16 @@ import (
 	"strings"
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 )
 
@@ -62,6 +67,7 @@ func StatusForChildObjects[T any, PT interface {
 			currCound.Status = "False"
 			currCound.Message = st.CurrentSyncError
 			errors = append(errors, fmt.Sprintf("parent=%s config=namespace/name=%s/%s error text: %s", parentObjectName, childObject.GetNamespace(), childObject.GetName(), st.CurrentSyncError))
+			events.Warning(childObject, events.ReasonConfigSkipped, fmt.Sprintf("object was skipped during configuration generation for %s: %s", parentObjectName, st.CurrentSyncError))
 		}
 		if err := updateChildStatusConditions[T](ctx, rclient, childObject, currCound); err != nil {
 			return err
@@ -169,3 +175,64 @@ func jitterForDuration(d time.Duration) time.Duration {
 	p := float64(rand.Uint32()) / (1 << 32)
 	return time.Duration(p * float64(dv))
 }
+
+// RestoreStatus sets restore progress for the given status field of the object
+// it uses merge patch in order to keep rest of status fields untouched
+func RestoreStatus(ctx context.Context, rclient client.Client, obj client.Object, fieldName string, source string, status vmv1beta1.RestoreStatus) error {
+	patch := map[string]any{
+		"status": map[string]any{
+			fieldName: vmv1beta1.VMRestoreFromStatus{
+				Source:             source,
+				Status:             status,
+				LastTransitionTime: metav1.Now(),
+			},
+		},
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("BUG: cannot serialize restore status patch: %w", err)
+	}
+	// make a copy in order to keep defaults applied to the origin object
+	objToPatch := obj.DeepCopyObject().(client.Object)
+	if err := rclient.Status().Patch(ctx, objToPatch, client.RawPatch(types.MergePatchType, data)); err != nil {
+		return fmt.Errorf("cannot update restore status to %q: %w", status, err)
+	}
+	logger.WithContext(ctx).Info(fmt.Sprintf("restore from source=%q status changed to %q", source, status))
+	return nil
+}
+
+// BackupVerificationStatus reflects state of backup verification CronJob at the given status field of the object
+// empty cronJobName means that verification is disabled and status field must be removed
+// it uses merge patch in order to keep rest of status fields untouched
+func BackupVerificationStatus(ctx context.Context, rclient client.Client, obj client.Object, fieldName, cronJobName string, prevStatus *vmv1beta1.VMBackupVerificationStatus) (*vmv1beta1.VMBackupVerificationStatus, error) {
+	var newStatus *vmv1beta1.VMBackupVerificationStatus
+	if cronJobName != "" {
+		var cj batchv1.CronJob
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: cronJobName}, &cj); err != nil {
+			if !errors.IsNotFound(err) {
+				return prevStatus, fmt.Errorf("cannot get backup verification CronJob=%q: %w", cronJobName, err)
+			}
+		}
+		newStatus = &vmv1beta1.VMBackupVerificationStatus{
+			LastScheduleTime:   cj.Status.LastScheduleTime,
+			LastSuccessfulTime: cj.Status.LastSuccessfulTime,
+		}
+	}
+	if equality.Semantic.DeepEqual(newStatus, prevStatus) {
+		return prevStatus, nil
+	}
+	patch := map[string]any{
+		"status": map[string]any{
+			fieldName: newStatus,
+		},
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return prevStatus, fmt.Errorf("BUG: cannot serialize backup verification status patch: %w", err)
+	}
+	objToPatch := obj.DeepCopyObject().(client.Object)
+	if err := rclient.Status().Patch(ctx, objToPatch, client.RawPatch(types.MergePatchType, data)); err != nil {
+		return prevStatus, fmt.Errorf("cannot update backup verification status: %w", err)
+	}
+	return newStatus, nil
+}
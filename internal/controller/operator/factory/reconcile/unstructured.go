@@ -0,0 +1,66 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+// unstructuredObject creates or updates object of 3rd party API, which is managed without typed client
+// 3rd party admission controllers may set default values for the spec,
+// so only fields managed by operator are compared with current object
+// apiHint is added to the error if API isn't served by kubernetes API server
+func unstructuredObject(ctx context.Context, rclient client.Client, newObj, prevObj *unstructured.Unstructured, kind, apiHint string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		currentObj := &unstructured.Unstructured{}
+		currentObj.SetGroupVersionKind(newObj.GroupVersionKind())
+		if err := rclient.Get(ctx, types.NamespacedName{Name: newObj.GetName(), Namespace: newObj.GetNamespace()}, currentObj); err != nil {
+			if errors.IsNotFound(err) {
+				logger.WithContext(ctx).Info(fmt.Sprintf("creating %s %s configuration", kind, newObj.GetName()))
+				return createObject(ctx, rclient, newObj, kind)
+			}
+			if meta.IsNoMatchError(err) {
+				return fmt.Errorf("%s: %w", apiHint, err)
+			}
+			return fmt.Errorf("cannot get existing %s object: %w", kind, err)
+		}
+		if err := finalize.FreeIfNeeded(ctx, rclient, currentObj); err != nil {
+			return err
+		}
+		preserveChildLabels(newObj, currentObj)
+		var prevAnnotations map[string]string
+		// fields removed from the spec since previous state must be removed from current object
+		isSpecChanged := false
+		if prevObj != nil {
+			prevAnnotations = prevObj.GetAnnotations()
+			isSpecChanged = !equality.Semantic.DeepEqual(newObj.Object["spec"], prevObj.Object["spec"])
+		}
+
+		if !isSpecChanged &&
+			equality.Semantic.DeepDerivative(newObj.Object["spec"], currentObj.Object["spec"]) &&
+			equality.Semantic.DeepEqual(newObj.GetLabels(), currentObj.GetLabels()) &&
+			isAnnotationsEqual(currentObj.GetAnnotations(), newObj.GetAnnotations(), prevAnnotations) {
+			return nil
+		}
+
+		newObj.SetAnnotations(mergeAnnotations(currentObj.GetAnnotations(), newObj.GetAnnotations(), prevAnnotations))
+		cloneSignificantMetadata(newObj, currentObj)
+		if status, ok := currentObj.Object["status"]; ok {
+			newObj.Object["status"] = status
+		}
+
+		logger.WithContext(ctx).Info(fmt.Sprintf("updating %s %s configuration", kind, newObj.GetName()))
+
+		return rclient.Update(ctx, newObj)
+	})
+}
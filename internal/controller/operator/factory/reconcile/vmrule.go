@@ -0,0 +1,45 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+// VMRuleForCRD creates or updates given object
+func VMRuleForCRD(ctx context.Context, rclient client.Client, vmr *vmv1beta1.VMRule) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var existVMR vmv1beta1.VMRule
+		err := rclient.Get(ctx, types.NamespacedName{Namespace: vmr.Namespace, Name: vmr.Name}, &existVMR)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				logger.WithContext(ctx).Info(fmt.Sprintf("creating VMRule %s", vmr.Name))
+				return createObject(ctx, rclient, vmr, "VMRule")
+			}
+			return err
+		}
+		if err := finalize.FreeIfNeeded(ctx, rclient, &existVMR); err != nil {
+			return err
+		}
+
+		preserveChildLabels(vmr, &existVMR)
+		if equality.Semantic.DeepEqual(vmr.Spec, existVMR.Spec) &&
+			equality.Semantic.DeepEqual(vmr.Labels, existVMR.Labels) {
+			return nil
+		}
+		existVMR.Spec = vmr.Spec
+		existVMR.Labels = vmr.Labels
+		logger.WithContext(ctx).Info(fmt.Sprintf("updating VMRule %s for CRD object", vmr.Name))
+
+		return rclient.Update(ctx, &existVMR)
+	})
+}
@@ -23,7 +23,7 @@ func VMServiceScrapeForCRD(ctx context.Context, rclient client.Client, vss *vmv1
 		if err != nil {
 			if errors.IsNotFound(err) {
 				logger.WithContext(ctx).Info(fmt.Sprintf("creating VMServiceScrape %s", vss.Name))
-				return rclient.Create(ctx, vss)
+				return createObject(ctx, rclient, vss, "VMServiceScrape")
 			}
 			return err
 		}
@@ -31,6 +31,7 @@ func VMServiceScrapeForCRD(ctx context.Context, rclient client.Client, vss *vmv1
 			return err
 		}
 
+		preserveChildLabels(vss, &existVSS)
 		if equality.Semantic.DeepEqual(vss.Spec, existVSS.Spec) &&
 			equality.Semantic.DeepEqual(vss.Labels, existVSS.Labels) &&
 			equality.Semantic.DeepEqual(vss.Annotations, existVSS.Annotations) {
@@ -0,0 +1,15 @@
+package reconcile
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VPA creates or updates VerticalPodAutoscaler object
+// VPA admission controller may set default values for the spec,
+// so only fields managed by operator are compared with current object
+func VPA(ctx context.Context, rclient client.Client, newVPA, prevVPA *unstructured.Unstructured) error {
+	return unstructuredObject(ctx, rclient, newVPA, prevVPA, "VPA", "VerticalPodAutoscaler CRD must be installed at kubernetes cluster to use vpa")
+}
@@ -0,0 +1,68 @@
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+)
+
+func TestVPAReconcile(t *testing.T) {
+	ctx := context.Background()
+	rclient := testutil.GetTestClientWithObjects(nil)
+	clientStats := rclient.(*testutil.TestClientWithStatsTrack)
+
+	newVPA := func(updateMode string) *unstructured.Unstructured {
+		vpa := build.NewVPA("vmagent-example", "default")
+		vpa.Object["spec"] = map[string]any{
+			"targetRef": map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"name":       "vmagent-example",
+			},
+		}
+		if updateMode != "" {
+			vpa.Object["spec"].(map[string]any)["updatePolicy"] = map[string]any{"updateMode": updateMode}
+		}
+		return vpa
+	}
+	getVPA := func() *unstructured.Unstructured {
+		t.Helper()
+		vpa := build.NewVPA("", "")
+		if err := rclient.Get(ctx, types.NamespacedName{Name: "vmagent-example", Namespace: "default"}, vpa); err != nil {
+			t.Fatalf("cannot get vpa: %s", err)
+		}
+		return vpa
+	}
+
+	// create
+	if err := VPA(ctx, rclient, newVPA("Auto"), nil); err != nil {
+		t.Fatalf("cannot create vpa: %s", err)
+	}
+	assert.Equal(t, int64(1), clientStats.CreateCalls.Load())
+
+	// fields defaulted by VPA admission must not trigger update
+	current := getVPA()
+	current.Object["spec"].(map[string]any)["updatePolicy"].(map[string]any)["minReplicas"] = int64(2)
+	if err := rclient.Update(ctx, current); err != nil {
+		t.Fatalf("cannot update vpa: %s", err)
+	}
+	updateCalls := clientStats.UpdateCalls.Load()
+	if err := VPA(ctx, rclient, newVPA("Auto"), newVPA("Auto")); err != nil {
+		t.Fatalf("cannot reconcile vpa: %s", err)
+	}
+	assert.Equal(t, updateCalls, clientStats.UpdateCalls.Load())
+
+	// field removed from spec
+	if err := VPA(ctx, rclient, newVPA(""), newVPA("Auto")); err != nil {
+		t.Fatalf("cannot reconcile vpa: %s", err)
+	}
+	assert.Equal(t, updateCalls+1, clientStats.UpdateCalls.Load())
+	_, ok := getVPA().Object["spec"].(map[string]any)["updatePolicy"]
+	assert.False(t, ok)
+}
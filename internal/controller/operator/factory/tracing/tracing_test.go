@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestStartForObject(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prevProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(prevProvider)
+
+	cr := &vmv1beta1.VMAgent{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "monitoring"}}
+	f := func(err error, wantStatus codes.Code) {
+		t.Helper()
+		ctx, parent := Start(context.Background(), "Reconcile vmagent")
+		_, span := StartForObject(ctx, "vmagent.generateConfig", cr)
+		End(span, err)
+		End(parent, nil)
+
+		spans := recorder.Ended()
+		if len(spans) < 2 {
+			t.Fatalf("expected at least 2 ended spans, got: %d", len(spans))
+		}
+		child, root := spans[len(spans)-2], spans[len(spans)-1]
+		if child.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Fatalf("span must be child of reconcile span")
+		}
+		if child.Status().Code != wantStatus {
+			t.Fatalf("unexpected span status, got: %v, want: %v", child.Status().Code, wantStatus)
+		}
+		attrs := map[string]string{}
+		for _, attr := range child.Attributes() {
+			attrs[string(attr.Key)] = attr.Value.AsString()
+		}
+		if attrs["k8s.namespace.name"] != "monitoring" || attrs["k8s.object.name"] != "main" {
+			t.Fatalf("unexpected span attributes: %v", attrs)
+		}
+	}
+
+	// successful call
+	f(nil, codes.Unset)
+
+	// failed call
+	f(fmt.Errorf("cannot generate config"), codes.Error)
+}
@@ -89,12 +89,20 @@ func CreateOrUpdateVLogs(ctx context.Context, rclient client.Client, cr *vmv1bet
 	}
 
 	if !ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
-		err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(svc, cr))
+		err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithDefaults(svc, cr, build.ServiceScrapeDefaults{}.WithServerTLS(cr.Spec.ServerTLS, svc)))
 		if err != nil {
 			return fmt.Errorf("cannot create serviceScrape for vlogs: %w", err)
 		}
 	}
+	if ptr.Deref(cr.Spec.SelfMonitoringRules, false) {
+		if err := reconcile.VMRuleForCRD(ctx, rclient, build.SelfMonitoringVMRule(svc, svc.Name, "vlogs")); err != nil {
+			return fmt.Errorf("cannot create self-monitoring VMRule for vlogs: %w", err)
+		}
+	}
 
+	if err := reconcile.ServerTLSSecret(ctx, rclient, cr.Spec.ServerTLS, metav1.ObjectMeta{Namespace: cr.Namespace, Labels: cr.AllLabels(), OwnerReferences: cr.AsOwner()}, cr.PrefixedName()); err != nil {
+		return fmt.Errorf("cannot reconcile serverTLS secret for vlogs: %w", err)
+	}
 	var prevDeploy *appsv1.Deployment
 	if prevCR != nil {
 		prevDeploy, err = newDeployForVLogs(prevCR)
@@ -139,6 +147,9 @@ func newDeployForVLogs(r *vmv1beta1.VLogs) (*appsv1.Deployment, error) {
 		},
 	}
 	build.DeploymentAddCommonParams(depSpec, ptr.Deref(r.Spec.UseStrictSecurity, false), &r.Spec.CommonApplicationDeploymentParams)
+	if err := build.PodTemplateAddPatches(&depSpec.Spec.Template, r.Spec.PodTemplatePatches); err != nil {
+		return nil, err
+	}
 	return depSpec, nil
 }
 
@@ -245,6 +256,9 @@ func makeSpecForVLogs(r *vmv1beta1.VLogs) (*corev1.PodTemplateSpec, error) {
 		})
 	}
 
+	volumes, vmMounts = r.Spec.ServerTLS.MaybeAddToVolumes(volumes, vmMounts, vmv1beta1.ServerTLSDir)
+	args = r.Spec.ServerTLS.MaybeAddToArgs(args, vmv1beta1.ServerTLSDir)
+
 	args = build.AddExtraArgsOverrideDefaults(args, r.Spec.ExtraArgs, "-")
 	sort.Strings(args)
 	vlogsContainer := corev1.Container{
@@ -255,6 +269,7 @@ func makeSpecForVLogs(r *vmv1beta1.VLogs) (*corev1.PodTemplateSpec, error) {
 		VolumeMounts:             vmMounts,
 		Resources:                r.Spec.Resources,
 		Env:                      envs,
+		Lifecycle:                r.Spec.Lifecycle,
 		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		ImagePullPolicy:          r.Spec.Image.PullPolicy,
 	}
@@ -263,12 +278,13 @@ func makeSpecForVLogs(r *vmv1beta1.VLogs) (*corev1.PodTemplateSpec, error) {
 
 	operatorContainers := []corev1.Container{vlogsContainer}
 
-	build.AddStrictSecuritySettingsToContainers(r.Spec.SecurityContext, operatorContainers, ptr.Deref(r.Spec.UseStrictSecurity, false))
+	build.AddStrictSecuritySettingsToContainers(r.Namespace, r.Spec.SecurityContext, operatorContainers, ptr.Deref(r.Spec.UseStrictSecurity, false))
 
 	containers, err := k8stools.MergePatchContainers(operatorContainers, r.Spec.Containers)
 	if err != nil {
 		return nil, err
 	}
+	ic, containers := build.AddSidecars(r.Spec.InitContainers, containers, r.Spec.Sidecars)
 
 	vlogsSpec := &corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
@@ -277,7 +293,7 @@ func makeSpecForVLogs(r *vmv1beta1.VLogs) (*corev1.PodTemplateSpec, error) {
 		},
 		Spec: corev1.PodSpec{
 			Volumes:            volumes,
-			InitContainers:     r.Spec.InitContainers,
+			InitContainers:     ic,
 			Containers:         containers,
 			ServiceAccountName: r.GetServiceAccountName(),
 		},
@@ -289,9 +305,11 @@ func makeSpecForVLogs(r *vmv1beta1.VLogs) (*corev1.PodTemplateSpec, error) {
 // createOrUpdateVLogsService creates service for vlogs
 func createOrUpdateVLogsService(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VLogs) (*corev1.Service, error) {
 	var prevService, prevAdditionalService *corev1.Service
+	var prevAdditionalServices []*corev1.Service
 	if prevCR != nil {
 		prevService = build.Service(prevCR, prevCR.Spec.Port, nil)
 		prevAdditionalService = build.AdditionalServiceFromDefault(prevService, prevCR.Spec.ServiceSpec)
+		prevAdditionalServices = build.AdditionalServicesFromDefault(prevService, prevCR.Spec.AdditionalServices)
 	}
 
 	newService := build.Service(cr, cr.Spec.Port, nil)
@@ -308,6 +326,10 @@ func createOrUpdateVLogsService(ctx context.Context, rclient client.Client, cr,
 		return nil, err
 	}
 
+	if err := reconcile.Services(ctx, rclient, build.AdditionalServicesFromDefault(newService, cr.Spec.AdditionalServices), prevAdditionalServices); err != nil {
+		return nil, fmt.Errorf("cannot reconcile additional services for vlogs: %w", err)
+	}
+
 	if err := reconcile.Service(ctx, rclient, newService, prevService); err != nil {
 		return nil, fmt.Errorf("cannot reconcile service for vlogs: %w", err)
 	}
@@ -322,6 +344,9 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VLogs, rclient
 	if err := reconcile.AdditionalServices(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevSvc, currSvc); err != nil {
 		return fmt.Errorf("cannot remove additional service: %w", err)
 	}
+	if err := reconcile.AdditionalServiceList(ctx, rclient, cr.Namespace, cr.ParsedLastAppliedSpec.AdditionalServices, cr.Spec.AdditionalServices); err != nil {
+		return fmt.Errorf("cannot remove additional services: %w", err)
+	}
 
 	objMeta := metav1.ObjectMeta{Name: cr.PrefixedName(), Namespace: cr.Namespace}
 	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(cr.ParsedLastAppliedSpec.DisableSelfServiceScrape, false) {
@@ -329,6 +354,11 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VLogs, rclient
 			return fmt.Errorf("cannot remove serviceScrape: %w", err)
 		}
 	}
+	if !ptr.Deref(cr.Spec.SelfMonitoringRules, false) && ptr.Deref(cr.ParsedLastAppliedSpec.SelfMonitoringRules, false) {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMRule{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot remove self-monitoring VMRule: %w", err)
+		}
+	}
 
 	return nil
 }
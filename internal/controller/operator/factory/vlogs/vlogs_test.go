@@ -7,7 +7,7 @@ import (
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
-	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -48,7 +48,7 @@ func TestCreateOrUpdateVLogs(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "vlogs-0", Labels: map[string]string{"app.kubernetes.io/component": "monitoring", "app.kubernetes.io/name": "vlogs", "app.kubernetes.io/instance": "vlogs-base", "managed-by": "vm-operator"}},
 					Status:     corev1.PodStatus{Phase: corev1.PodRunning, Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: "True"}}},
 				},
-				k8stools.NewReadyDeployment("vlogs-vlogs-base", "default"),
+				testutil.NewReadyDeployment("vlogs-vlogs-base", "default"),
 			},
 			want: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "vlogs-vlogs-base", Namespace: "default"}},
 		},
@@ -73,14 +73,14 @@ func TestCreateOrUpdateVLogs(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "vlogs-0", Labels: map[string]string{"app.kubernetes.io/component": "monitoring", "app.kubernetes.io/name": "vlogs", "app.kubernetes.io/instance": "vlogs-base", "managed-by": "vm-operator"}},
 					Status:     corev1.PodStatus{Phase: corev1.PodRunning, Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: "True"}}},
 				},
-				k8stools.NewReadyDeployment("vlogs-vlogs-base", "default"),
+				testutil.NewReadyDeployment("vlogs-vlogs-base", "default"),
 			},
 			want: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "vlogs-vlogs-base", Namespace: "default"}},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			err := CreateOrUpdateVLogs(context.TODO(), fclient, tt.args.cr)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateOrUpdateVLogs() error = %v, wantErr %v", err, tt.wantErr)
@@ -168,7 +168,7 @@ func TestCreateOrUpdateVLogsService(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			got, err := createOrUpdateVLogsService(context.TODO(), fclient, tt.args.cr, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateOrUpdateVLogsService() error = %v, wantErr %v", err, tt.wantErr)
@@ -8,7 +8,7 @@ import (
 	"testing"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
-	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -275,7 +275,7 @@ func TestSelectServiceMonitors(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			got, err := selectServiceScrapes(context.TODO(), tt.args.p, fclient)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SelectServiceScrapes() error = %v, wantErr %v", err, tt.wantErr)
@@ -371,7 +371,7 @@ func TestSelectPodMonitors(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			got, err := selectPodScrapes(context.TODO(), tt.args.p, fclient)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SelectPodScrapes() error = %v, wantErr %v", err, tt.wantErr)
@@ -428,7 +428,7 @@ func TestSelectVMProbes(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			got, err := selectVMProbes(context.TODO(), tt.args.cr, fclient)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SelectVMProbes() error = %v, wantErr %v", err, tt.wantErr)
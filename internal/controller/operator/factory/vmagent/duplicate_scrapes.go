@@ -0,0 +1,133 @@
+package vmagent
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+// FindDuplicateScrapes returns VMServiceScrape or VMPodScrape objects selected by the same VMAgent as the given object,
+// which scrape the same targets: the same namespaces, selector, port and path of endpoint
+// relabeling rules are not taken into account
+func FindDuplicateScrapes(ctx context.Context, rclient client.Client, obj client.Object) ([]string, error) {
+	keys := scrapeTargetKeys(obj)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	var agents []*vmv1beta1.VMAgent
+	if err := k8stools.ListObjectsByNamespace(ctx, rclient, config.MustGetWatchNamespaces(), func(list *vmv1beta1.VMAgentList) {
+		for i := range list.Items {
+			item := &list.Items[i]
+			if !item.DeletionTimestamp.IsZero() || item.IsUnmanaged() {
+				continue
+			}
+			agents = append(agents, item.DeepCopy())
+		}
+	}); err != nil {
+		return nil, fmt.Errorf("cannot list VMAgents: %w", err)
+	}
+
+	var duplicates []string
+	seen := make(map[string]struct{})
+	for _, cr := range agents {
+		var candidates []client.Object
+		switch obj.(type) {
+		case *vmv1beta1.VMServiceScrape:
+			ok, err := k8stools.IsObjectSelected(ctx, rclient, cr.Spec.ServiceScrapeNamespaceSelector, cr.Spec.ServiceScrapeSelector, cr.Namespace, cr.Spec.SelectAllByDefault, obj)
+			if err != nil {
+				return nil, fmt.Errorf("cannot check VMAgent=%s/%s selectors: %w", cr.Namespace, cr.Name, err)
+			}
+			if !ok {
+				continue
+			}
+			sss, err := selectServiceScrapes(ctx, cr, rclient)
+			if err != nil {
+				return nil, fmt.Errorf("cannot select VMServiceScrapes for VMAgent=%s/%s: %w", cr.Namespace, cr.Name, err)
+			}
+			for _, ss := range sss {
+				candidates = append(candidates, ss)
+			}
+		case *vmv1beta1.VMPodScrape:
+			ok, err := k8stools.IsObjectSelected(ctx, rclient, cr.Spec.PodScrapeNamespaceSelector, cr.Spec.PodScrapeSelector, cr.Namespace, cr.Spec.SelectAllByDefault, obj)
+			if err != nil {
+				return nil, fmt.Errorf("cannot check VMAgent=%s/%s selectors: %w", cr.Namespace, cr.Name, err)
+			}
+			if !ok {
+				continue
+			}
+			pss, err := selectPodScrapes(ctx, cr, rclient)
+			if err != nil {
+				return nil, fmt.Errorf("cannot select VMPodScrapes for VMAgent=%s/%s: %w", cr.Namespace, cr.Name, err)
+			}
+			for _, ps := range pss {
+				candidates = append(candidates, ps)
+			}
+		}
+		for _, candidate := range candidates {
+			if candidate.GetNamespace() == obj.GetNamespace() && candidate.GetName() == obj.GetName() {
+				continue
+			}
+			nsn := fmt.Sprintf("%s/%s", candidate.GetNamespace(), candidate.GetName())
+			if _, ok := seen[nsn]; ok {
+				continue
+			}
+			if !slices.ContainsFunc(scrapeTargetKeys(candidate), func(key string) bool { return slices.Contains(keys, key) }) {
+				continue
+			}
+			seen[nsn] = struct{}{}
+			duplicates = append(duplicates, fmt.Sprintf("%s selected by VMAgent=%s/%s", nsn, cr.Namespace, cr.Name))
+		}
+	}
+	return duplicates, nil
+}
+
+// scrapeTargetKeys returns keys of targets for each endpoint of the given scrape object
+func scrapeTargetKeys(obj client.Object) []string {
+	var keys []string
+	switch so := obj.(type) {
+	case *vmv1beta1.VMServiceScrape:
+		prefix := scrapeTargetPrefix(so.Namespace, so.Spec.NamespaceSelector, so.Spec.Selector)
+		for _, ep := range so.Spec.Endpoints {
+			keys = append(keys, scrapeTargetKey(prefix, ep.Port, ep.TargetPort, ep.Path))
+		}
+	case *vmv1beta1.VMPodScrape:
+		prefix := scrapeTargetPrefix(so.Namespace, so.Spec.NamespaceSelector, so.Spec.Selector)
+		for _, ep := range so.Spec.PodMetricsEndpoints {
+			keys = append(keys, scrapeTargetKey(prefix, ep.Port, ep.TargetPort, ep.Path))
+		}
+	}
+	return keys
+}
+
+func scrapeTargetPrefix(namespace string, nsSelector vmv1beta1.NamespaceSelector, selector metav1.LabelSelector) string {
+	namespaces := namespace
+	switch {
+	case nsSelector.Any:
+		namespaces = "*"
+	case len(nsSelector.MatchNames) > 0:
+		names := slices.Clone(nsSelector.MatchNames)
+		slices.Sort(names)
+		namespaces = strings.Join(names, ",")
+	}
+	return fmt.Sprintf("namespaces=%s selector=%s", namespaces, metav1.FormatLabelSelector(&selector))
+}
+
+func scrapeTargetKey(prefix, port string, targetPort *intstr.IntOrString, path string) string {
+	if path == "" {
+		path = "/metrics"
+	}
+	var tp string
+	if targetPort != nil {
+		tp = targetPort.String()
+	}
+	return fmt.Sprintf("%s port=%s targetPort=%s path=%s", prefix, port, tp, path)
+}
@@ -0,0 +1,104 @@
+package vmagent
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+)
+
+func TestFindDuplicateScrapes(t *testing.T) {
+	f := func(obj client.Object, predefinedObjects []runtime.Object, want []string) {
+		t.Helper()
+		fclient := testutil.GetTestClientWithObjects(predefinedObjects)
+		got, err := FindDuplicateScrapes(context.TODO(), fclient, obj)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected duplicates, got: %v, want: %v", got, want)
+		}
+	}
+	agent := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		Spec: vmv1beta1.VMAgentSpec{
+			SelectAllByDefault: true,
+		},
+	}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	newServiceScrape := func(name, port, path string) *vmv1beta1.VMServiceScrape {
+		return &vmv1beta1.VMServiceScrape{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: vmv1beta1.VMServiceScrapeSpec{
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+				Endpoints: []vmv1beta1.Endpoint{
+					{Port: port, EndpointScrapeParams: vmv1beta1.EndpointScrapeParams{Path: path}},
+				},
+			},
+		}
+	}
+
+	// no agents
+	f(newServiceScrape("ss1", "http", ""), []runtime.Object{ns, newServiceScrape("ss2", "http", "")}, nil)
+
+	// same port and default path
+	f(newServiceScrape("ss1", "http", ""), []runtime.Object{
+		ns,
+		agent,
+		newServiceScrape("ss1", "http", ""),
+		newServiceScrape("ss2", "http", "/metrics"),
+	}, []string{"default/ss2 selected by VMAgent=default/agent"})
+
+	// different ports
+	f(newServiceScrape("ss1", "http", ""), []runtime.Object{
+		ns,
+		agent,
+		newServiceScrape("ss2", "metrics", ""),
+	}, nil)
+
+	// different paths
+	f(newServiceScrape("ss1", "http", "/federate"), []runtime.Object{
+		ns,
+		agent,
+		newServiceScrape("ss2", "http", ""),
+	}, nil)
+
+	// object is not selected by agent
+	f(newServiceScrape("ss1", "http", ""), []runtime.Object{
+		ns,
+		&vmv1beta1.VMAgent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec: vmv1beta1.VMAgentSpec{
+				ServiceScrapeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "db"}},
+			},
+		},
+		newServiceScrape("ss2", "http", ""),
+	}, nil)
+
+	// pod scrape with the same target port
+	newPodScrape := func(name string) *vmv1beta1.VMPodScrape {
+		return &vmv1beta1.VMPodScrape{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: vmv1beta1.VMPodScrapeSpec{
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+				PodMetricsEndpoints: []vmv1beta1.PodMetricsEndpoint{
+					{TargetPort: &intstr.IntOrString{Type: intstr.Int, IntVal: 8080}},
+				},
+			},
+		}
+	}
+	f(newPodScrape("ps1"), []runtime.Object{
+		ns,
+		agent,
+		newPodScrape("ps2"),
+		newServiceScrape("ss1", "", ""),
+	}, []string{"default/ps2 selected by VMAgent=default/agent"})
+}
@@ -0,0 +1,63 @@
+package vmagent
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"gopkg.in/yaml.v2"
+)
+
+const defaultFederationPath = "/federate"
+
+// federationJobName returns job_name for federation target, it's also used as secrets cache key
+func federationJobName(cr *vmv1beta1.VMAgent, ft *vmv1beta1.VMAgentFederationTarget) string {
+	return fmt.Sprintf("federate/%s/%s/%s", cr.Namespace, cr.Name, ft.Name)
+}
+
+func generateFederationScrapeConfig(
+	ctx context.Context,
+	vmagentCR *vmv1beta1.VMAgent,
+	ft *vmv1beta1.VMAgentFederationTarget,
+	ssCache *scrapesSecretsCache,
+) yaml.MapSlice {
+	cfg := yaml.MapSlice{
+		{
+			Key:   "job_name",
+			Value: federationJobName(vmagentCR, ft),
+		},
+	}
+
+	tgs := yaml.MapSlice{{Key: "targets", Value: ft.Targets}}
+	if ft.Labels != nil {
+		tgs = append(tgs, yaml.MapItem{Key: "labels", Value: ft.Labels})
+	}
+	cfg = append(cfg, yaml.MapItem{Key: "static_configs", Value: []yaml.MapSlice{tgs}})
+
+	sp := vmv1beta1.EndpointScrapeParams{
+		Path:          ft.Path,
+		Scheme:        ft.Scheme,
+		Interval:      ft.Interval,
+		ScrapeTimeout: ft.ScrapeTimeout,
+		Params:        map[string][]string{"match[]": ft.Match},
+		// federated series already have job and instance labels of the origin targets
+		HonorLabels: true,
+	}
+	if sp.Path == "" {
+		sp.Path = defaultFederationPath
+	}
+	if sp.ScrapeTimeout == "" {
+		sp.ScrapeTimeout = vmagentCR.Spec.ScrapeTimeout
+	}
+	setScrapeIntervalToWithLimit(ctx, &sp, vmagentCR)
+
+	// federation targets are defined by VMAgent owner,
+	// so security enforcements for scrape objects are not applied
+	var se vmv1beta1.VMAgentSecurityEnforcements
+	cfg = addCommonScrapeParamsTo(cfg, sp, se)
+	cfg = addMetricRelabelingsTo(cfg, ft.MetricRelabelConfigs, se)
+	cfg = addTLStoYaml(cfg, vmagentCR.Namespace, ft.TLSConfig, false)
+	cfg = addEndpointAuthTo(cfg, ft.EndpointAuth, federationJobName(vmagentCR, ft), ssCache)
+
+	return cfg
+}
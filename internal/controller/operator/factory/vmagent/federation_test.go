@@ -0,0 +1,127 @@
+package vmagent
+
+import (
+	"context"
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_generateFederationScrapeConfig(t *testing.T) {
+	type args struct {
+		cr      vmv1beta1.VMAgent
+		ft      *vmv1beta1.VMAgentFederationTarget
+		ssCache *scrapesSecretsCache
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "basic cfg",
+			args: args{
+				cr: vmv1beta1.VMAgent{
+					ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+				},
+				ssCache: &scrapesSecretsCache{},
+				ft: &vmv1beta1.VMAgentFederationTarget{
+					Name:    "prom",
+					Targets: []string{"prometheus-0:9090", "prometheus-1:9090"},
+					Match:   []string{`{job="node-exporter"}`, `{__name__=~"job:.*"}`},
+				},
+			},
+			want: `job_name: federate/default/agent/prom
+static_configs:
+- targets:
+  - prometheus-0:9090
+  - prometheus-1:9090
+honor_labels: true
+metrics_path: /federate
+params:
+  match[]:
+  - '{job="node-exporter"}'
+  - '{__name__=~"job:.*"}'
+`,
+		},
+		{
+			name: "with auth and overrides",
+			args: args{
+				cr: vmv1beta1.VMAgent{
+					ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+					Spec: vmv1beta1.VMAgentSpec{
+						ScrapeTimeout: "20s",
+						VMAgentSecurityEnforcements: vmv1beta1.VMAgentSecurityEnforcements{
+							OverrideHonorLabels: true,
+						},
+					},
+				},
+				ssCache: &scrapesSecretsCache{
+					baSecrets: map[string]*k8stools.BasicAuthCredentials{
+						"federate/default/agent/prom": {Username: "admin", Password: "pass"},
+					},
+				},
+				ft: &vmv1beta1.VMAgentFederationTarget{
+					Name:     "prom",
+					Targets:  []string{"prometheus:9090"},
+					Match:    []string{`{job!=""}`},
+					Path:     "/prometheus/federate",
+					Scheme:   "HTTPS",
+					Interval: "1m",
+					Labels:   map[string]string{"origin": "prometheus"},
+					MetricRelabelConfigs: []*vmv1beta1.RelabelConfig{
+						{Action: "drop", SourceLabels: []string{"__name__"}, Regex: vmv1beta1.StringOrArray{"go_.*"}},
+					},
+					EndpointAuth: vmv1beta1.EndpointAuth{
+						BasicAuth: &vmv1beta1.BasicAuth{
+							Username: corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "prom-auth"},
+								Key:                  "user",
+							},
+						},
+					},
+				},
+			},
+			want: `job_name: federate/default/agent/prom
+static_configs:
+- targets:
+  - prometheus:9090
+  labels:
+    origin: prometheus
+honor_labels: true
+scrape_interval: 1m
+scrape_timeout: 20s
+metrics_path: /prometheus/federate
+params:
+  match[]:
+  - '{job!=""}'
+scheme: https
+metric_relabel_configs:
+- source_labels:
+  - __name__
+  regex: go_.*
+  action: drop
+basic_auth:
+  username: admin
+  password_file: /etc/vmagent-tls/certs/password_d74ff0ee8da3b980
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := generateFederationScrapeConfig(context.Background(), &tt.args.cr, tt.args.ft, tt.args.ssCache)
+			gotBytes, err := yaml.Marshal(got)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !assert.Equal(t, tt.want, string(gotBytes)) {
+				t.Errorf("generateFederationScrapeConfig() = \n%v, want \n%v", string(gotBytes), tt.want)
+			}
+		})
+	}
+}
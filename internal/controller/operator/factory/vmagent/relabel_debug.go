@@ -0,0 +1,132 @@
+package vmagent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/VictoriaMetrics/VictoriaMetrics/lib/prompbmarshal"
+	"github.com/VictoriaMetrics/VictoriaMetrics/lib/promrelabel"
+	"gopkg.in/yaml.v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// RelabelDebugStep contains result of a single relabeling rule
+type RelabelDebugStep struct {
+	Rule string `json:"rule"`
+	In   string `json:"in"`
+	Out  string `json:"out"`
+}
+
+// RelabelDebugResult contains result of target relabeling
+type RelabelDebugResult struct {
+	// JobName is a name of generated scrape job
+	JobName string `json:"jobName"`
+	// Dropped is set if target was dropped by relabeling rules
+	Dropped bool `json:"dropped"`
+	// Labels contains target labels after relabeling without labels with __ prefix
+	Labels map[string]string `json:"labels,omitempty"`
+	// Steps contains results of each relabeling rule
+	Steps []RelabelDebugStep `json:"steps"`
+}
+
+// DebugTargetRelabeling generates scrape job for the given scrape object with the same code as vmagent configuration
+// and applies its relabel_configs to the given target labels.
+// endpointIdx selects endpoint for VMServiceScrape, VMPodScrape and VMStaticScrape objects.
+// VMAgent is optional and defines security enforcements and scrape interval limits
+// secrets referenced by scrape object are not loaded, since they don't affect relabeling
+func DebugTargetRelabeling(ctx context.Context, cr *vmv1beta1.VMAgent, obj client.Object, endpointIdx int, targetLabels map[string]string) (*RelabelDebugResult, error) {
+	if cr == nil {
+		cr = &vmv1beta1.VMAgent{}
+	}
+	cr = cr.DeepCopy()
+	apiserverConfig := cr.Spec.APIServerConfig
+	se := cr.Spec.VMAgentSecurityEnforcements
+	ssCache := &scrapesSecretsCache{}
+	checkEndpoint := func(cnt int) error {
+		if endpointIdx < 0 || endpointIdx >= cnt {
+			return fmt.Errorf("endpoint index=%d is out of range, object has %d endpoints", endpointIdx, cnt)
+		}
+		return nil
+	}
+
+	var cfg yaml.MapSlice
+	switch so := obj.(type) {
+	case *vmv1beta1.VMServiceScrape:
+		if err := checkEndpoint(len(so.Spec.Endpoints)); err != nil {
+			return nil, err
+		}
+		so = so.DeepCopy()
+		cfg = generateServiceScrapeConfig(ctx, cr, so, so.Spec.Endpoints[endpointIdx], endpointIdx, apiserverConfig, ssCache, se)
+	case *vmv1beta1.VMPodScrape:
+		if err := checkEndpoint(len(so.Spec.PodMetricsEndpoints)); err != nil {
+			return nil, err
+		}
+		so = so.DeepCopy()
+		cfg = generatePodScrapeConfig(ctx, cr, so, so.Spec.PodMetricsEndpoints[endpointIdx], endpointIdx, apiserverConfig, ssCache, se)
+	case *vmv1beta1.VMStaticScrape:
+		if err := checkEndpoint(len(so.Spec.TargetEndpoints)); err != nil {
+			return nil, err
+		}
+		so = so.DeepCopy()
+		cfg = generateStaticScrapeConfig(ctx, cr, so, so.Spec.TargetEndpoints[endpointIdx], endpointIdx, ssCache, se)
+	case *vmv1beta1.VMProbe:
+		cfg = generateProbeConfig(ctx, cr, so.DeepCopy(), 0, apiserverConfig, ssCache, se)
+	case *vmv1beta1.VMNodeScrape:
+		cfg = generateNodeScrapeConfig(ctx, cr, so.DeepCopy(), 0, apiserverConfig, ssCache, se)
+	case *vmv1beta1.VMScrapeConfig:
+		cfg = generateScrapeConfig(ctx, cr, so.DeepCopy(), ssCache, se)
+	default:
+		return nil, fmt.Errorf("unsupported scrape object type=%T", obj)
+	}
+
+	var res RelabelDebugResult
+	var relabelings any
+	for _, item := range cfg {
+		switch item.Key {
+		case "job_name":
+			res.JobName, _ = item.Value.(string)
+		case "relabel_configs":
+			relabelings = item.Value
+		}
+	}
+	data, err := yaml.Marshal(relabelings)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal relabel_configs: %w", err)
+	}
+	pcs, err := promrelabel.ParseRelabelConfigsData(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse generated relabel_configs: %w", err)
+	}
+
+	labels := make([]prompbmarshal.Label, 0, len(targetLabels))
+	for k, v := range targetLabels {
+		labels = append(labels, prompbmarshal.Label{Name: k, Value: v})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	labels, dss := pcs.ApplyDebug(labels)
+	for _, ds := range dss {
+		res.Steps = append(res.Steps, RelabelDebugStep{Rule: ds.Rule, In: ds.In, Out: ds.Out})
+	}
+	if len(labels) == 0 {
+		res.Dropped = true
+		return &res, nil
+	}
+	// the same as vmagent does for discovered targets
+	if promrelabel.GetLabelByName(labels, "instance") == nil {
+		if address := promrelabel.GetLabelByName(labels, "__address__"); address != nil {
+			labels = append(labels, prompbmarshal.Label{Name: "instance", Value: address.Value})
+		}
+	}
+	res.Labels = make(map[string]string, len(labels))
+	for _, l := range labels {
+		if strings.HasPrefix(l.Name, "__") {
+			continue
+		}
+		res.Labels[l.Name] = l.Value
+	}
+	return &res, nil
+}
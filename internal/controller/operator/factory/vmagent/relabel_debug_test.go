@@ -0,0 +1,84 @@
+package vmagent
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestDebugTargetRelabeling(t *testing.T) {
+	f := func(obj client.Object, endpointIdx int, targetLabels map[string]string, wantJob string, wantDropped bool, wantLabels map[string]string) {
+		t.Helper()
+		got, err := DebugTargetRelabeling(context.TODO(), nil, obj, endpointIdx, targetLabels)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got.JobName != wantJob {
+			t.Fatalf("unexpected job name, got: %q, want: %q", got.JobName, wantJob)
+		}
+		if got.Dropped != wantDropped {
+			t.Fatalf("unexpected dropped state, got: %v, want: %v, steps: %v", got.Dropped, wantDropped, got.Steps)
+		}
+		if !reflect.DeepEqual(got.Labels, wantLabels) {
+			t.Fatalf("unexpected labels, got: %v, want: %v", got.Labels, wantLabels)
+		}
+		if len(got.Steps) == 0 {
+			t.Fatalf("expected non-empty relabeling steps")
+		}
+	}
+	ss := &vmv1beta1.VMServiceScrape{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec: vmv1beta1.VMServiceScrapeSpec{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			Endpoints: []vmv1beta1.Endpoint{
+				{Port: "http"},
+			},
+		},
+	}
+
+	// target is kept
+	f(ss, 0, map[string]string{
+		"__address__":                                "10.0.0.1:8080",
+		"__meta_kubernetes_service_label_app":        "db",
+		"__meta_kubernetes_service_labelpresent_app": "true",
+		"__meta_kubernetes_endpoint_port_name":       "http",
+		"__meta_kubernetes_namespace":                "default",
+		"__meta_kubernetes_service_name":             "db",
+		"__meta_kubernetes_pod_name":                 "db-0",
+		"__meta_kubernetes_pod_container_name":       "db",
+	}, "serviceScrape/default/db/0", false, map[string]string{
+		"instance":  "10.0.0.1:8080",
+		"job":       "db",
+		"namespace": "default",
+		"service":   "db",
+		"pod":       "db-0",
+		"container": "db",
+		"endpoint":  "http",
+	})
+
+	// target is dropped by port name
+	f(ss, 0, map[string]string{
+		"__address__":                                "10.0.0.1:9090",
+		"__meta_kubernetes_service_label_app":        "db",
+		"__meta_kubernetes_service_labelpresent_app": "true",
+		"__meta_kubernetes_endpoint_port_name":       "grpc",
+	}, "serviceScrape/default/db/0", true, nil)
+
+	// target is dropped by service selector
+	f(ss, 0, map[string]string{
+		"__address__":                                "10.0.0.1:8080",
+		"__meta_kubernetes_service_label_app":        "cache",
+		"__meta_kubernetes_service_labelpresent_app": "true",
+		"__meta_kubernetes_endpoint_port_name":       "http",
+	}, "serviceScrape/default/db/0", true, nil)
+
+	// invalid endpoint index
+	if _, err := DebugTargetRelabeling(context.TODO(), nil, ss, 1, nil); err == nil {
+		t.Fatalf("expected error for out of range endpoint index")
+	}
+}
@@ -0,0 +1,236 @@
+package vmagent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/podmetrics"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/promtext"
+)
+
+// remoteWriteMetrics holds remote write metrics of all vmagent pods for the single remote write target
+type remoteWriteMetrics struct {
+	pendingBytes       float64
+	successfulRequests float64
+	failedRequests     float64
+	// failed requests by response status code
+	statusCodes map[string]float64
+}
+
+var (
+	remoteWriteMetricsMu sync.Mutex
+	// remoteWriteMetricsCache holds metrics observed at the previous check by VMAgent namespaced name
+	// counters cannot be stored at status, since its changes trigger VMAgent reconcile
+	remoteWriteMetricsCache = map[types.NamespacedName][]remoteWriteMetrics{}
+)
+
+// PruneRemoteWriteMetrics removes metrics observed at the previous check for VMAgents missing at the given set
+func PruneRemoteWriteMetrics(existing map[types.NamespacedName]struct{}) {
+	remoteWriteMetricsMu.Lock()
+	defer remoteWriteMetricsMu.Unlock()
+	for nsn := range remoteWriteMetricsCache {
+		if _, ok := existing[nsn]; !ok {
+			delete(remoteWriteMetricsCache, nsn)
+		}
+	}
+}
+
+// UpdateRemoteWriteStatus reads remote write metrics of vmagent pods
+// and reflects health of each remote write target at status.remoteWrite
+func UpdateRemoteWriteStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAgent) error {
+	var pods corev1.PodList
+	if err := rclient.List(ctx, &pods, client.InNamespace(cr.Namespace), client.MatchingLabels(cr.SelectorLabels())); err != nil {
+		return fmt.Errorf("cannot list vmagent pods: %w", err)
+	}
+	metrics := make([]remoteWriteMetrics, len(cr.Spec.RemoteWrite))
+	ep := metricsEndpoint(cr)
+	var scrapedPods int
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		err := podmetrics.Read(ctx, rclient, ep, pod.Status.PodIP, func(r io.Reader) error {
+			return parseRemoteWriteMetrics(r, metrics)
+		})
+		if err != nil {
+			logger.WithContext(ctx).Error(err, "cannot read remote write metrics", "pod", pod.Name)
+			continue
+		}
+		scrapedPods++
+	}
+	if scrapedPods == 0 {
+		// keep the previous status, health of remote write targets is unknown
+		return nil
+	}
+	nsn := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}
+	remoteWriteMetricsMu.Lock()
+	prevMetrics := remoteWriteMetricsCache[nsn]
+	remoteWriteMetricsCache[nsn] = metrics
+	remoteWriteMetricsMu.Unlock()
+
+	statuses := buildRemoteWriteStatuses(cr, metrics, prevMetrics)
+	if equality.Semantic.DeepEqual(statuses, cr.Status.RemoteWrite) {
+		return nil
+	}
+	patch := map[string]any{
+		"status": map[string]any{
+			"remoteWrite": statuses,
+		},
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("BUG: cannot serialize remote write status patch: %w", err)
+	}
+	objToPatch := cr.DeepCopy()
+	if err := rclient.Status().Patch(ctx, objToPatch, client.RawPatch(types.MergePatchType, data)); err != nil {
+		return fmt.Errorf("cannot update remote write status: %w", err)
+	}
+	cr.Status.RemoteWrite = statuses
+	return nil
+}
+
+// buildRemoteWriteStatuses compares current metrics with metrics observed at the previous check
+// and emits events on remote write target health changes
+func buildRemoteWriteStatuses(cr *vmv1beta1.VMAgent, metrics, prevMetrics []remoteWriteMetrics) []vmv1beta1.VMAgentRemoteWriteStatus {
+	statuses := make([]vmv1beta1.VMAgentRemoteWriteStatus, 0, len(cr.Spec.RemoteWrite))
+	for i, rw := range cr.Spec.RemoteWrite {
+		m := metrics[i]
+		st := vmv1beta1.VMAgentRemoteWriteStatus{
+			URL:     sanitizeRemoteWriteURL(rw.URL),
+			Healthy: true,
+		}
+		var prevSt *vmv1beta1.VMAgentRemoteWriteStatus
+		if i < len(cr.Status.RemoteWrite) && cr.Status.RemoteWrite[i].URL == st.URL {
+			prevSt = &cr.Status.RemoteWrite[i]
+			st.Healthy = prevSt.Healthy
+			st.Blocked = prevSt.Blocked
+			st.LastError = prevSt.LastError
+			st.LastTransitionTime = prevSt.LastTransitionTime
+		}
+		var failed float64
+		// metrics of the previous check are missing after operator restart or remote write targets change
+		// in this case health of remote write target cannot be changed
+		if len(prevMetrics) == len(metrics) {
+			prev := prevMetrics[i]
+			failed = counterIncrease(prev.failedRequests, m.failedRequests)
+			delivered := counterIncrease(prev.successfulRequests, m.successfulRequests)
+			st.Healthy = failed == 0
+			if !st.Healthy {
+				// message must not contain counters, since status changes trigger VMAgent reconcile
+				st.LastError = "remote write requests failing"
+				var codes []string
+				for code, v := range m.statusCodes {
+					if counterIncrease(prev.statusCodes[code], v) > 0 {
+						codes = append(codes, code)
+					}
+				}
+				if len(codes) > 0 {
+					sort.Strings(codes)
+					st.LastError += fmt.Sprintf(", response status codes: %s", strings.Join(codes, ","))
+				}
+			}
+			st.Blocked = delivered == 0 && m.pendingBytes > 0 && m.pendingBytes >= prev.pendingBytes
+		}
+		if prevSt == nil || prevSt.Healthy != st.Healthy || prevSt.Blocked != st.Blocked {
+			st.LastTransitionTime = metav1.Now()
+			wasHealthy := prevSt == nil || (prevSt.Healthy && !prevSt.Blocked)
+			switch {
+			case !st.Healthy && (prevSt == nil || prevSt.Healthy):
+				events.Warning(cr, events.ReasonRemoteWriteUnhealthy, fmt.Sprintf("remote write url=%s: %s, %d failed requests since the previous check", st.URL, st.LastError, int64(failed)))
+			case st.Blocked && (prevSt == nil || !prevSt.Blocked):
+				events.Warning(cr, events.ReasonRemoteWriteUnhealthy, fmt.Sprintf("remote write url=%s is blocked, pending data size=%d bytes", st.URL, int64(m.pendingBytes)))
+			case st.Healthy && !st.Blocked && !wasHealthy:
+				events.Normal(cr, events.ReasonRemoteWriteRecovered, fmt.Sprintf("remote write url=%s recovered", st.URL))
+			}
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses
+}
+
+// counterIncrease returns increase of the counter, which could be reset on pod restart
+func counterIncrease(prev, curr float64) float64 {
+	if curr < prev {
+		return curr
+	}
+	return curr - prev
+}
+
+// sanitizeRemoteWriteURL removes credentials and query params from remote write url
+func sanitizeRemoteWriteURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		s, _, _ = strings.Cut(s, "?")
+		return s
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
+// metricsEndpoint returns metrics endpoint of vmagent pods
+func metricsEndpoint(cr *vmv1beta1.VMAgent) podmetrics.Endpoint {
+	port := cr.Spec.Port
+	if port == "" {
+		port = "8429"
+	}
+	return podmetrics.EndpointFor(cr.Namespace, cr.PrefixedName(), port, cr.GetMetricPath(), &cr.Spec.CommonApplicationDeploymentParams)
+}
+
+// parseRemoteWriteMetrics adds vmagent_remotewrite_* metrics of the single vmagent pod to the given metrics
+// remote write target is identified by url label, which has "<index>:<url>" format, where index starts from 1
+func parseRemoteWriteMetrics(r io.Reader, metrics []remoteWriteMetrics) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if !strings.HasPrefix(line, "vmagent_remotewrite_") {
+			continue
+		}
+		name, labels, value, ok := promtext.ParseLine(line)
+		if !ok {
+			continue
+		}
+		idxStr, _, _ := strings.Cut(labels["url"], ":")
+		idx, err := strconv.Atoi(idxStr)
+		if err != nil || idx < 1 || idx > len(metrics) {
+			continue
+		}
+		m := &metrics[idx-1]
+		switch name {
+		case "vmagent_remotewrite_pending_data_bytes":
+			m.pendingBytes += value
+		case "vmagent_remotewrite_retries_count_total":
+			m.failedRequests += value
+		case "vmagent_remotewrite_requests_total":
+			code := labels["status_code"]
+			if strings.HasPrefix(code, "2") {
+				m.successfulRequests += value
+				continue
+			}
+			if m.statusCodes == nil {
+				m.statusCodes = make(map[string]float64)
+			}
+			m.statusCodes[code] += value
+		}
+	}
+	return sc.Err()
+}
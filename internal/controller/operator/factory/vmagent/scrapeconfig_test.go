@@ -74,7 +74,7 @@ max_scrape_size: 60KB
 relabel_configs: []
 basic_auth:
   username: admin
-  password: dangerous
+  password_file: /etc/vmagent-tls/certs/password_7eaa968417c5dbde
 static_configs:
 - targets:
   - http://test1.com
@@ -800,7 +800,7 @@ tls_config:
 bearer_token_file: /var/run/tolen
 basic_auth:
   username: user
-  password: pass
+  password_file: /etc/vmagent-tls/certs/password_d74ff0ee8da3b980
 oauth2:
   client_id: some-id
   client_secret: some-secret
@@ -0,0 +1,139 @@
+package vmagent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/podmetrics"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/promtext"
+)
+
+// hotShardRatio defines how many times shard targets must exceed the average to consider shard as hot
+const hotShardRatio = 2
+
+// UpdateShardStatus reads the number of scrape targets of vmagent pods
+// and reports it per shard at status.shardTargets
+func UpdateShardStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAgent) error {
+	if cr.Spec.ShardCount == nil || *cr.Spec.ShardCount <= 1 {
+		return nil
+	}
+	var pods corev1.PodList
+	if err := rclient.List(ctx, &pods, client.InNamespace(cr.Namespace), client.MatchingLabels(cr.SelectorLabels())); err != nil {
+		return fmt.Errorf("cannot list vmagent pods: %w", err)
+	}
+	// all replicas of the shard scrape the same targets, so the maximum value is used
+	targets := make(map[int32]int64)
+	ep := metricsEndpoint(cr)
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		shardNum, err := strconv.ParseInt(pod.Labels["shard-num"], 10, 32)
+		if err != nil || shardNum >= int64(*cr.Spec.ShardCount) {
+			continue
+		}
+		var cnt int64
+		err = podmetrics.Read(ctx, rclient, ep, pod.Status.PodIP, func(r io.Reader) error {
+			var err error
+			cnt, err = parseTargetsCount(r)
+			return err
+		})
+		if err != nil {
+			logger.WithContext(ctx).Error(err, "cannot read scrape targets metrics", "pod", pod.Name)
+			continue
+		}
+		targets[int32(shardNum)] = max(targets[int32(shardNum)], cnt)
+	}
+	if len(targets) == 0 {
+		// keep the previous status, shards state is unknown
+		return nil
+	}
+	statuses := buildShardStatuses(targets)
+	if equality.Semantic.DeepEqual(statuses, cr.Status.ShardTargets) {
+		return nil
+	}
+	patch := map[string]any{
+		"status": map[string]any{
+			"shardTargets": statuses,
+		},
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("BUG: cannot serialize shard status patch: %w", err)
+	}
+	objToPatch := cr.DeepCopy()
+	if err := rclient.Status().Patch(ctx, objToPatch, client.RawPatch(types.MergePatchType, data)); err != nil {
+		return fmt.Errorf("cannot update shard status: %w", err)
+	}
+	emitHotShardEvents(cr, cr.Status.ShardTargets, statuses)
+	cr.Status.ShardTargets = statuses
+	return nil
+}
+
+// emitHotShardEvents emits events for shards, which became hot since the previous check
+func emitHotShardEvents(cr *vmv1beta1.VMAgent, prev, curr []vmv1beta1.VMAgentShardTargetsStatus) {
+	prevHot := make(map[int32]bool, len(prev))
+	for _, st := range prev {
+		prevHot[st.Shard] = st.Hot
+	}
+	for _, st := range curr {
+		if st.Hot && !prevHot[st.Shard] {
+			events.Warning(cr, events.ReasonShardHot, fmt.Sprintf("shard=%d has ~%d scrape targets, which is at least %d times more than the average", st.Shard, st.Targets, hotShardRatio))
+		}
+	}
+}
+
+// buildShardStatuses marks shards with significantly more targets than the average as hot
+// targets count is rounded to 2 significant digits, since it changes frequently
+func buildShardStatuses(targets map[int32]int64) []vmv1beta1.VMAgentShardTargetsStatus {
+	var total int64
+	for _, cnt := range targets {
+		total += cnt
+	}
+	statuses := make([]vmv1beta1.VMAgentShardTargetsStatus, 0, len(targets))
+	for shard, cnt := range targets {
+		statuses = append(statuses, vmv1beta1.VMAgentShardTargetsStatus{
+			Shard:   shard,
+			Targets: podmetrics.RoundSignificant(float64(cnt)),
+			Hot:     len(targets) > 1 && cnt*int64(len(targets)) >= hotShardRatio*total && cnt > 0,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Shard < statuses[j].Shard
+	})
+	return statuses
+}
+
+// parseTargetsCount sums vm_promscrape_targets of the single vmagent pod
+// the metric is reported per scrape job type and target status
+func parseTargetsCount(r io.Reader) (int64, error) {
+	var total float64
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if !strings.HasPrefix(line, "vm_promscrape_targets{") {
+			continue
+		}
+		name, _, value, ok := promtext.ParseLine(line)
+		if !ok || name != "vm_promscrape_targets" {
+			continue
+		}
+		total += value
+	}
+	return int64(total), sc.Err()
+}
@@ -0,0 +1,63 @@
+package vmagent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestParseTargetsCount(t *testing.T) {
+	f := func(data string, want int64) {
+		t.Helper()
+		got, err := parseTargetsCount(strings.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	// empty response
+	f("", 0)
+
+	// targets of different types and statuses
+	f(`# HELP vm_promscrape_targets
+vm_promscrape_targets{type="kubernetes_sd_configs",status="up"} 10
+vm_promscrape_targets{type="kubernetes_sd_configs",status="down"} 2
+vm_promscrape_targets{type="static_configs",status="up"} 1
+vm_promscrape_targets_dropped_total 100
+`, 13)
+}
+
+func TestBuildShardStatuses(t *testing.T) {
+	f := func(targets map[int32]int64, want []vmv1beta1.VMAgentShardTargetsStatus) {
+		t.Helper()
+		assert.Equal(t, want, buildShardStatuses(targets))
+	}
+
+	// balanced shards
+	f(map[int32]int64{1: 100, 0: 110, 2: 90}, []vmv1beta1.VMAgentShardTargetsStatus{
+		{Shard: 0, Targets: 110},
+		{Shard: 1, Targets: 100},
+		{Shard: 2, Targets: 90},
+	})
+
+	// hot shard
+	f(map[int32]int64{0: 10, 1: 10, 2: 10, 3: 90}, []vmv1beta1.VMAgentShardTargetsStatus{
+		{Shard: 0, Targets: 10},
+		{Shard: 1, Targets: 10},
+		{Shard: 2, Targets: 10},
+		{Shard: 3, Targets: 90, Hot: true},
+	})
+
+	// targets count is rounded
+	f(map[int32]int64{0: 1234, 1: 1278}, []vmv1beta1.VMAgentShardTargetsStatus{
+		{Shard: 0, Targets: 1200},
+		{Shard: 1, Targets: 1300},
+	})
+
+	// single scraped shard is never hot
+	f(map[int32]int64{0: 50}, []vmv1beta1.VMAgentShardTargetsStatus{
+		{Shard: 0, Targets: 50},
+	})
+}
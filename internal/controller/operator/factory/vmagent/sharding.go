@@ -0,0 +1,100 @@
+package vmagent
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// shardSlotsCount defines the number of hashmod slots distributed among shards at ConsistentHashing mode
+	// it must not be changed, since it re-distributes all targets
+	shardSlotsCount = 256
+	// shardSlotsEnvName is the name of env var with slots of the shard
+	// it's referenced at scrape configuration with %{ENV} placeholder, which allows to share configuration among shards
+	shardSlotsEnvName = "VMAGENT_SHARD_SLOTS"
+	shardSlotLabel    = "__vmagent_shard_slot"
+)
+
+// shardingRelabelConfigs returns relabeling, which keeps only targets of the slots assigned to the shard
+func shardingRelabelConfigs() []yaml.MapSlice {
+	return []yaml.MapSlice{
+		{
+			{Key: "source_labels", Value: []string{"__address__", "__metrics_path__"}},
+			{Key: "target_label", Value: shardSlotLabel},
+			{Key: "modulus", Value: shardSlotsCount},
+			{Key: "action", Value: "hashmod"},
+		},
+		{
+			{Key: "source_labels", Value: []string{shardSlotLabel}},
+			{Key: "regex", Value: "%{" + shardSlotsEnvName + "}"},
+			{Key: "action", Value: "keep"},
+		},
+	}
+}
+
+// appendRelabelConfigsToJob adds relabelings to the end of relabel_configs of the given scrape job
+func appendRelabelConfigsToJob(job yaml.MapSlice, rcs []yaml.MapSlice) yaml.MapSlice {
+	for i := range job {
+		item := &job[i]
+		if item.Key != "relabel_configs" {
+			continue
+		}
+		switch v := item.Value.(type) {
+		case []yaml.MapSlice:
+			item.Value = append(v[:len(v):len(v)], rcs...)
+		case []any:
+			dst := append([]any{}, v...)
+			for _, rc := range rcs {
+				dst = append(dst, rc)
+			}
+			item.Value = dst
+		default:
+			item.Value = rcs
+		}
+		return job
+	}
+	return append(job, yaml.MapItem{Key: "relabel_configs", Value: rcs})
+}
+
+// shardSlotsRegex returns regex, which matches hashmod slots assigned to the given shard
+//
+// each slot is assigned to replicationFactor shards with the highest rendezvous hash of the slot and shard number.
+// It moves only slots of added or removed shard on shardsCount change.
+func shardSlotsRegex(shardNum, shardsCount, replicationFactor int) string {
+	replicationFactor = max(min(replicationFactor, shardsCount), 1)
+	type shardScore struct {
+		num   int
+		score uint64
+	}
+	scores := make([]shardScore, shardsCount)
+	var slots []string
+	for slot := 0; slot < shardSlotsCount; slot++ {
+		for num := range scores {
+			scores[num] = shardScore{num: num, score: rendezvousHash(slot, num)}
+		}
+		sort.Slice(scores, func(i, j int) bool {
+			return scores[i].score > scores[j].score
+		})
+		for _, s := range scores[:replicationFactor] {
+			if s.num == shardNum {
+				slots = append(slots, strconv.Itoa(slot))
+				break
+			}
+		}
+	}
+	return strings.Join(slots, "|")
+}
+
+func rendezvousHash(slot, shardNum int) uint64 {
+	var buf [16]byte
+	binary.LittleEndian.PutUint64(buf[:8], uint64(slot))
+	binary.LittleEndian.PutUint64(buf[8:], uint64(shardNum))
+	h := fnv.New64a()
+	h.Write(buf[:])
+	return h.Sum64()
+}
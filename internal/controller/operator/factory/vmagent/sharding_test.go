@@ -0,0 +1,148 @@
+package vmagent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestShardSlotsRegex(t *testing.T) {
+	slotsOf := func(shardsCount, replicationFactor int) []map[string]struct{} {
+		t.Helper()
+		result := make([]map[string]struct{}, shardsCount)
+		for num := range result {
+			result[num] = make(map[string]struct{})
+			for _, slot := range strings.Split(shardSlotsRegex(num, shardsCount, replicationFactor), "|") {
+				result[num][slot] = struct{}{}
+			}
+		}
+		return result
+	}
+	f := func(shardsCount, replicationFactor int) {
+		t.Helper()
+		assigned := make(map[string]int)
+		for num, slots := range slotsOf(shardsCount, replicationFactor) {
+			// slots must be distributed evenly enough
+			avg := shardSlotsCount * min(replicationFactor, shardsCount) / shardsCount
+			assert.Greater(t, len(slots), avg/2, "shard %d", num)
+			assert.Less(t, len(slots), avg*2, "shard %d", num)
+			for slot := range slots {
+				assigned[slot]++
+			}
+		}
+		assert.Len(t, assigned, shardSlotsCount)
+		for slot, cnt := range assigned {
+			assert.Equal(t, min(replicationFactor, shardsCount), cnt, "slot %s", slot)
+		}
+	}
+	f(1, 1)
+	f(2, 1)
+	f(4, 1)
+	f(4, 2)
+	f(3, 5)
+
+	// only slots of added shard are moved
+	prev := slotsOf(4, 1)
+	curr := slotsOf(5, 1)
+	for num := range prev {
+		for slot := range curr[num] {
+			assert.Contains(t, prev[num], slot, "shard %d", num)
+		}
+	}
+}
+
+func TestAppendRelabelConfigsToJob(t *testing.T) {
+	f := func(job, want string) {
+		t.Helper()
+		var src yaml.MapSlice
+		assert.NoError(t, yaml.Unmarshal([]byte(job), &src))
+		got, err := yaml.Marshal(appendRelabelConfigsToJob(src, shardingRelabelConfigs()))
+		assert.NoError(t, err)
+		assert.Equal(t, want, string(got))
+	}
+
+	// job without relabelings
+	f(`job_name: static
+static_configs:
+- targets: [host:80]
+`, `job_name: static
+static_configs:
+- targets:
+  - host:80
+relabel_configs:
+- source_labels:
+  - __address__
+  - __metrics_path__
+  target_label: __vmagent_shard_slot
+  modulus: 256
+  action: hashmod
+- source_labels:
+  - __vmagent_shard_slot
+  regex: '%{VMAGENT_SHARD_SLOTS}'
+  action: keep
+`)
+
+	// job with relabelings
+	f(`job_name: static
+relabel_configs:
+- action: drop
+  source_labels: [job]
+`, `job_name: static
+relabel_configs:
+- action: drop
+  source_labels:
+  - job
+- source_labels:
+  - __address__
+  - __metrics_path__
+  target_label: __vmagent_shard_slot
+  modulus: 256
+  action: hashmod
+- source_labels:
+  - __vmagent_shard_slot
+  regex: '%{VMAGENT_SHARD_SLOTS}'
+  action: keep
+`)
+}
+
+func TestAddShardSettingsToVMAgent(t *testing.T) {
+	f := func(sharding *vmv1beta1.VMAgentShardingSpec, extraArgs []string, wantArgs []string, wantEnv []corev1.EnvVar) {
+		t.Helper()
+		dep := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "vmagent-example"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "vmagent", Args: extraArgs}},
+					},
+				},
+			},
+		}
+		addShardSettingsToVMAgent(1, 3, sharding, dep)
+		assert.Equal(t, "vmagent-example-1", dep.Name)
+		assert.Equal(t, wantArgs, dep.Spec.Template.Spec.Containers[0].Args)
+		assert.Equal(t, wantEnv, dep.Spec.Template.Spec.Containers[0].Env)
+	}
+
+	// default sharding
+	f(nil, []string{"-promscrape.cluster.replicationFactor=2"},
+		[]string{"-promscrape.cluster.replicationFactor=2", "-promscrape.cluster.membersCount=3", "-promscrape.cluster.memberNum=1"}, nil)
+
+	// default sharding with replication
+	f(&vmv1beta1.VMAgentShardingSpec{ReplicationFactor: ptr.To[int32](2)}, []string{"-promscrape.cluster.replicationFactor=3", "-promscrape.cluster.memberNum=5"},
+		[]string{"-promscrape.cluster.membersCount=3", "-promscrape.cluster.memberNum=1", "-promscrape.cluster.replicationFactor=2"}, nil)
+
+	// consistent hashing
+	f(&vmv1beta1.VMAgentShardingSpec{Mode: vmv1beta1.VMAgentShardingModeConsistentHashing}, []string{"-promscrape.config=/etc/vmagent/config.yaml"},
+		[]string{"-promscrape.config=/etc/vmagent/config.yaml"}, []corev1.EnvVar{{Name: shardSlotsEnvName, Value: shardSlotsRegex(1, 3, 1)}})
+}
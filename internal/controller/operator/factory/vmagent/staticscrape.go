@@ -41,6 +41,10 @@ func generateStaticScrapeConfig(
 	if ep.ScrapeTimeout == "" {
 		ep.ScrapeTimeout = vmagentCR.Spec.ScrapeTimeout
 	}
+	if m.Spec.Preset == vmv1beta1.StaticScrapePresetPushgateway {
+		// pushed series must keep their own job and instance labels
+		ep.HonorLabels = true
+	}
 	setScrapeIntervalToWithLimit(ctx, &ep.EndpointScrapeParams, vmagentCR)
 
 	cfg = addCommonScrapeParamsTo(cfg, ep.EndpointScrapeParams, se)
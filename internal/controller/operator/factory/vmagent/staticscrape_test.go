@@ -57,6 +57,62 @@ honor_labels: false
 relabel_configs:
 - target_label: job
   replacement: static-job
+`,
+		},
+		{
+			name: "pushgateway preset",
+			args: args{
+				ssCache: &scrapesSecretsCache{},
+				m: &vmv1beta1.VMStaticScrape{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pushgateway",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMStaticScrapeSpec{
+						JobName: "pushgateway",
+						Preset:  vmv1beta1.StaticScrapePresetPushgateway,
+					},
+				},
+				ep: &vmv1beta1.TargetEndpoint{
+					Targets: []string{"pushgateway:9091"},
+				},
+			},
+			want: `job_name: staticScrape/default/pushgateway/0
+static_configs:
+- targets:
+  - pushgateway:9091
+honor_labels: true
+relabel_configs:
+- target_label: job
+  replacement: pushgateway
+`,
+		},
+		{
+			name: "pushgateway preset with overridden honor labels",
+			args: args{
+				ssCache: &scrapesSecretsCache{},
+				m: &vmv1beta1.VMStaticScrape{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pushgateway",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMStaticScrapeSpec{
+						Preset: vmv1beta1.StaticScrapePresetPushgateway,
+					},
+				},
+				ep: &vmv1beta1.TargetEndpoint{
+					Targets: []string{"pushgateway:9091"},
+				},
+				se: vmv1beta1.VMAgentSecurityEnforcements{
+					OverrideHonorLabels: true,
+				},
+			},
+			want: `job_name: staticScrape/default/pushgateway/0
+static_configs:
+- targets:
+  - pushgateway:9091
+honor_labels: false
+relabel_configs: []
 `,
 		},
 		{
@@ -291,7 +347,7 @@ headers:
 - 'customer-header: with-value'
 proxy_basic_auth:
   username: proxy-user
-  password: proxy-password
+  password_file: /etc/vmagent-tls/certs/password_1132fad75100db91
 tls_config:
   insecure_skip_verify: true
   ca_file: /etc/vmagent-tls/certs/default_tls-cfg_ca
@@ -299,7 +355,7 @@ tls_config:
   key_file: /etc/vmagent-tls/certs/default_tls-cfg_key
 basic_auth:
   username: admin
-  password: pass
+  password_file: /etc/vmagent-tls/certs/password_d74ff0ee8da3b980
 oauth2:
   client_id: some-id
   client_secret: some-secret
@@ -18,9 +18,12 @@ import (
 
 	"gopkg.in/yaml.v2"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
@@ -40,8 +43,14 @@ const (
 	shardNumPlaceholder    = "%SHARD_NUM%"
 	tlsAssetsDir           = "/etc/vmagent-tls/certs"
 	vmagentGzippedFilename = "vmagent.yaml.gz"
+	vmagentZstdFilename    = "vmagent.yaml.zst"
+	vmagentConfigFilename  = "vmagent.yaml"
 	configEnvsubstFilename = "vmagent.env.yaml"
 	defaultMaxDiskUsage    = "1073741824"
+
+	// total number of remote write queues of all shards to each remote storage
+	shardedRemoteWriteQueues  = 16
+	minShardRemoteWriteQueues = 2
 )
 
 // To save compatibility in the single-shard version still need to fill in %SHARD_NUM% placeholder
@@ -50,6 +59,7 @@ var defaultPlaceholders = map[string]string{shardNumPlaceholder: "0"}
 func createOrUpdateVMAgentService(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMAgent) (*corev1.Service, error) {
 
 	var prevService, prevAdditionalService *corev1.Service
+	var prevAdditionalServices []*corev1.Service
 	if prevCR != nil {
 		prevService = build.Service(prevCR, prevCR.Spec.Port, func(svc *corev1.Service) {
 			if prevCR.Spec.StatefulMode {
@@ -58,6 +68,7 @@ func createOrUpdateVMAgentService(ctx context.Context, rclient client.Client, cr
 			build.AppendInsertPortsToService(prevCR.Spec.InsertPorts, svc)
 		})
 		prevAdditionalService = build.AdditionalServiceFromDefault(prevService, cr.Spec.ServiceSpec)
+		prevAdditionalServices = build.AdditionalServicesFromDefault(prevService, prevCR.Spec.AdditionalServices)
 	}
 	newService := build.Service(cr, cr.Spec.Port, func(svc *corev1.Service) {
 		if cr.Spec.StatefulMode {
@@ -79,6 +90,19 @@ func createOrUpdateVMAgentService(ctx context.Context, rclient client.Client, cr
 		return nil, err
 	}
 
+	if err := reconcile.Services(ctx, rclient, build.AdditionalServicesFromDefault(newService, cr.Spec.AdditionalServices), prevAdditionalServices); err != nil {
+		return nil, fmt.Errorf("cannot reconcile additional services for vmagent: %w", err)
+	}
+	if cr.Spec.OTLP != nil {
+		var prevOTLPService *corev1.Service
+		if prevCR != nil {
+			prevOTLPService = build.OTLPServiceFromDefault(prevService, prevCR.OTLPServiceName(), prevCR.Spec.OTLP)
+		}
+		if err := reconcile.Service(ctx, rclient, build.OTLPServiceFromDefault(newService, cr.OTLPServiceName(), cr.Spec.OTLP), prevOTLPService); err != nil {
+			return nil, fmt.Errorf("cannot reconcile otlp service for vmagent: %w", err)
+		}
+	}
+
 	if err := reconcile.Service(ctx, rclient, newService, prevService); err != nil {
 		return nil, fmt.Errorf("cannot reconcile service for vmagent: %w", err)
 	}
@@ -118,11 +142,16 @@ func CreateOrUpdateVMAgent(ctx context.Context, cr *vmv1beta1.VMAgent, rclient c
 	}
 
 	if !ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
-		err = reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(svc, cr, "http"))
+		err = reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithDefaults(svc, cr, build.ServiceScrapeDefaults{}.WithServerTLS(cr.Spec.ServerTLS, svc), "http"))
 		if err != nil {
 			return fmt.Errorf("cannot create serviceScrape: %w", err)
 		}
 	}
+	if ptr.Deref(cr.Spec.SelfMonitoringRules, false) {
+		if err := reconcile.VMRuleForCRD(ctx, rclient, build.SelfMonitoringVMRule(svc, svc.Name, "vmagent")); err != nil {
+			return fmt.Errorf("cannot create self-monitoring VMRule for vmagent: %w", err)
+		}
+	}
 
 	ssCache, err := createOrUpdateConfigurationSecret(ctx, rclient, cr, prevCR)
 	if err != nil {
@@ -147,6 +176,46 @@ func CreateOrUpdateVMAgent(ctx context.Context, cr *vmv1beta1.VMAgent, rclient c
 			return fmt.Errorf("cannot update pod disruption budget for vmagent: %w", err)
 		}
 	}
+	if cr.Spec.NetworkPolicy.IsEnabled() {
+		var prevNP *networkingv1.NetworkPolicy
+		if prevCR != nil && prevCR.Spec.NetworkPolicy.IsEnabled() {
+			prevNP = build.NetworkPolicy(prevCR, prevCR.Spec.NetworkPolicy, nil)
+		}
+		if err := reconcile.NetworkPolicy(ctx, rclient, build.NetworkPolicy(cr, cr.Spec.NetworkPolicy, nil), prevNP); err != nil {
+			return fmt.Errorf("cannot update network policy for vmagent: %w", err)
+		}
+	}
+	if cr.Spec.VPA != nil {
+		var prevVPA *unstructured.Unstructured
+		if prevCR != nil && prevCR.Spec.VPA != nil {
+			prevVPA = buildVPA(prevCR)
+		}
+		if err := reconcile.VPA(ctx, rclient, buildVPA(cr), prevVPA); err != nil {
+			return fmt.Errorf("cannot update vertical pod autoscaler for vmagent: %w", err)
+		}
+	}
+	if cr.Spec.KEDA != nil {
+		var prevSO *unstructured.Unstructured
+		if prevCR != nil && prevCR.Spec.KEDA != nil {
+			prevSO = buildScaledObject(prevCR)
+		}
+		if err := reconcile.ScaledObject(ctx, rclient, buildScaledObject(cr), prevSO); err != nil {
+			return fmt.Errorf("cannot update keda scaled object for vmagent: %w", err)
+		}
+	}
+	if cr.Spec.GrafanaDashboard != nil {
+		var prevGD client.Object
+		if prevCR != nil && prevCR.Spec.GrafanaDashboard != nil {
+			prevGD = build.GrafanaDashboard(prevCR, prevCR.GrafanaDashboardName(), prevCR.Spec.GrafanaDashboard, "vmagent", prevCR.Spec.Image.Tag)
+		}
+		if err := reconcile.GrafanaDashboard(ctx, rclient, build.GrafanaDashboard(cr, cr.GrafanaDashboardName(), cr.Spec.GrafanaDashboard, "vmagent", cr.Spec.Image.Tag), prevGD); err != nil {
+			return fmt.Errorf("cannot update grafana dashboard for vmagent: %w", err)
+		}
+	}
+
+	if err := reconcile.ServerTLSSecret(ctx, rclient, cr.Spec.ServerTLS, metav1.ObjectMeta{Namespace: cr.Namespace, Labels: cr.AllLabels(), OwnerReferences: cr.AsOwner()}, cr.PrefixedName()); err != nil {
+		return fmt.Errorf("cannot reconcile serverTLS secret for vmagent: %w", err)
+	}
 
 	var prevObjectSpec runtime.Object
 
@@ -169,10 +238,10 @@ func CreateOrUpdateVMAgent(ctx context.Context, cr *vmv1beta1.VMAgent, rclient c
 		for shardNum := 0; shardNum < shardsCount; shardNum++ {
 			shardedDeploy := newDeploy.DeepCopyObject()
 			var prevShardedObject runtime.Object
-			addShardSettingsToVMAgent(shardNum, shardsCount, shardedDeploy)
+			addShardSettingsToVMAgent(shardNum, shardsCount, cr.Spec.Sharding, shardedDeploy)
 			if prevObjectSpec != nil {
 				prevShardedObject = prevObjectSpec.DeepCopyObject()
-				addShardSettingsToVMAgent(shardNum, shardsCount, prevShardedObject)
+				addShardSettingsToVMAgent(shardNum, shardsCount, prevCR.Spec.Sharding, prevShardedObject)
 			}
 			placeholders := map[string]string{shardNumPlaceholder: strconv.Itoa(shardNum)}
 			switch shardedDeploy := shardedDeploy.(type) {
@@ -249,7 +318,7 @@ func CreateOrUpdateVMAgent(ctx context.Context, cr *vmv1beta1.VMAgent, rclient c
 			if err != nil {
 				return fmt.Errorf("cannot fill placeholders for deployment in vmagent: %w", err)
 			}
-			if err := reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, false); err != nil {
+			if err := reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, cr.Spec.KEDA.ScalesReplicas()); err != nil {
 				return err
 			}
 			deploymentNames[newDeploy.Name] = struct{}{}
@@ -270,8 +339,9 @@ func CreateOrUpdateVMAgent(ctx context.Context, cr *vmv1beta1.VMAgent, rclient c
 				return fmt.Errorf("cannot fill placeholders for sts in vmagent: %w", err)
 			}
 			stsOpts := reconcile.STSOptions{
-				HasClaim:       len(newDeploy.Spec.VolumeClaimTemplates) > 0,
-				SelectorLabels: cr.SelectorLabels,
+				HasClaim:         len(newDeploy.Spec.VolumeClaimTemplates) > 0,
+				SelectorLabels:   cr.SelectorLabels,
+				PreserveReplicas: cr.Spec.KEDA.ScalesReplicas(),
 			}
 			if err := reconcile.HandleSTSUpdate(ctx, rclient, stsOpts, newDeploy, prevSTS); err != nil {
 				return err
@@ -316,8 +386,9 @@ func newDeployForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (r
 				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
 					Type: cr.Spec.StatefulRollingUpdateStrategy,
 				},
-				PodManagementPolicy: appsv1.ParallelPodManagement,
-				ServiceName:         buildSTSServiceName(cr),
+				PodManagementPolicy:                  appsv1.ParallelPodManagement,
+				ServiceName:                          buildSTSServiceName(cr),
+				PersistentVolumeClaimRetentionPolicy: cr.PVCRetentionPolicy(),
 				Template: corev1.PodTemplateSpec{
 					ObjectMeta: metav1.ObjectMeta{
 						Labels:      cr.PodLabels(),
@@ -330,6 +401,9 @@ func newDeployForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (r
 		build.StatefulSetAddCommonParams(stsSpec, useStrictSecurity, &cr.Spec.CommonApplicationDeploymentParams)
 		cr.Spec.StatefulStorage.IntoSTSVolume(vmAgentPersistentQueueMountName, &stsSpec.Spec)
 		stsSpec.Spec.VolumeClaimTemplates = append(stsSpec.Spec.VolumeClaimTemplates, cr.Spec.ClaimTemplates...)
+		if err := build.PodTemplateAddPatches(&stsSpec.Spec.Template, cr.Spec.PodTemplatePatches); err != nil {
+			return nil, err
+		}
 		return stsSpec, nil
 	}
 
@@ -364,6 +438,9 @@ func newDeployForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (r
 		},
 	}
 	build.DeploymentAddCommonParams(depSpec, useStrictSecurity, &cr.Spec.CommonApplicationDeploymentParams)
+	if err := build.PodTemplateAddPatches(&depSpec.Spec.Template, cr.Spec.PodTemplatePatches); err != nil {
+		return nil, err
+	}
 	return depSpec, nil
 }
 
@@ -440,6 +517,9 @@ func makeSpecForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (*c
 	if !cr.Spec.IngestOnlyMode {
 		args = append(args,
 			fmt.Sprintf("-promscrape.config=%s", path.Join(vmAgentConOfOutDir, configEnvsubstFilename)))
+		if cr.Spec.MaxScrapeSize != nil {
+			args = append(args, fmt.Sprintf("-promscrape.maxScrapeSize=%s", *cr.Spec.MaxScrapeSize))
+		}
 
 		volumes = append(volumes,
 			corev1.Volume{
@@ -563,6 +643,31 @@ func makeSpecForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (*c
 		})
 	}
 
+	for _, t := range cr.Spec.ServiceAccountTokens {
+		volumeName := k8stools.SanitizeVolumeName("sa-token-" + t.Name)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{
+							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+								Audience:          t.Audience,
+								ExpirationSeconds: t.ExpirationSeconds,
+								Path:              path.Base(t.TokenPath()),
+							},
+						},
+					},
+				},
+			},
+		})
+		agentVolumeMounts = append(agentVolumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			ReadOnly:  true,
+			MountPath: path.Dir(t.TokenPath()),
+		})
+	}
+
 	volumes, agentVolumeMounts = cr.Spec.License.MaybeAddToVolumes(volumes, agentVolumeMounts, vmv1beta1.SecretsDir)
 	args = cr.Spec.License.MaybeAddToArgs(args, vmv1beta1.SecretsDir)
 
@@ -583,7 +688,7 @@ func makeSpecForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (*c
 		if cr.Spec.StreamAggrConfig.DedupInterval != "" {
 			args = append(args, fmt.Sprintf("-streamAggr.dedupInterval=%s", cr.Spec.StreamAggrConfig.DedupInterval))
 		}
-		if len(cr.Spec.StreamAggrConfig.DropInputLabels) > 0 {
+		if len(cr.Spec.StreamAggrConfig.DropInputLabels) > 0 && cr.SupportsStreamAggrDropInputLabels() {
 			args = append(args, fmt.Sprintf("-streamAggr.dropInputLabels=%s", strings.Join(cr.Spec.StreamAggrConfig.DropInputLabels, ",")))
 		}
 		if cr.Spec.StreamAggrConfig.IgnoreOldSamples {
@@ -592,6 +697,10 @@ func makeSpecForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (*c
 	}
 
 	args = build.AppendArgsForInsertPorts(args, cr.Spec.InsertPorts)
+	args = build.AppendArgsForOTLP(args, cr.Spec.OTLP)
+
+	volumes, agentVolumeMounts = cr.Spec.ServerTLS.MaybeAddToVolumes(volumes, agentVolumeMounts, vmv1beta1.ServerTLSDir)
+	args = cr.Spec.ServerTLS.MaybeAddToArgs(args, vmv1beta1.ServerTLSDir)
 
 	args = build.AddExtraArgsOverrideDefaults(args, cr.Spec.ExtraArgs, "-")
 	sort.Strings(args)
@@ -605,6 +714,7 @@ func makeSpecForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (*c
 		Env:                      envs,
 		VolumeMounts:             agentVolumeMounts,
 		Resources:                cr.Spec.Resources,
+		Lifecycle:                cr.Spec.Lifecycle,
 		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 	}
 
@@ -620,8 +730,8 @@ func makeSpecForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (*c
 		operatorContainers = append(operatorContainers, configReloader)
 		if !cr.Spec.IngestOnlyMode {
 			ic = append(ic,
-				buildInitConfigContainer(ptr.Deref(cr.Spec.UseVMConfigReloader, false), cr.Spec.ConfigReloaderImageTag, cr.Spec.ConfigReloaderResources, configReloader.Args)...)
-			build.AddStrictSecuritySettingsToContainers(cr.Spec.SecurityContext, ic, useStrictSecurity)
+				buildInitConfigContainer(ptr.Deref(cr.Spec.UseVMConfigReloader, false), cr.Spec.ConfigReloaderImageTag, cr.Spec.ConfigReloaderResources, configReloader.Args, configSecretKey(cr))...)
+			build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.SecurityContext, ic, useStrictSecurity)
 		}
 	}
 	var err error
@@ -632,12 +742,13 @@ func makeSpecForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (*c
 
 	operatorContainers = append(operatorContainers, vmagentContainer)
 
-	build.AddStrictSecuritySettingsToContainers(cr.Spec.SecurityContext, operatorContainers, useStrictSecurity)
+	build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.SecurityContext, operatorContainers, useStrictSecurity)
 
 	containers, err := k8stools.MergePatchContainers(operatorContainers, cr.Spec.Containers)
 	if err != nil {
 		return nil, err
 	}
+	ic, containers = build.AddSidecars(ic, containers, cr.Spec.Sidecars)
 
 	for i := range cr.Spec.TopologySpreadConstraints {
 		if cr.Spec.TopologySpreadConstraints[i].LabelSelector == nil {
@@ -655,7 +766,7 @@ func makeSpecForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (*c
 	}, nil
 }
 
-func addShardSettingsToVMAgent(shardNum, shardsCount int, dep runtime.Object) {
+func addShardSettingsToVMAgent(shardNum, shardsCount int, sharding *vmv1beta1.VMAgentShardingSpec, dep runtime.Object) {
 	var containers []corev1.Container
 	switch dep := dep.(type) {
 	case *appsv1.StatefulSet:
@@ -679,14 +790,26 @@ func addShardSettingsToVMAgent(shardNum, shardsCount int, dep runtime.Object) {
 			cnt := 0
 			for i := range args {
 				arg := args[i]
-				if !strings.Contains(arg, "promscrape.cluster.membersCount") && !strings.Contains(arg, "promscrape.cluster.memberNum") {
+				if !strings.Contains(arg, "promscrape.cluster.membersCount") && !strings.Contains(arg, "promscrape.cluster.memberNum") &&
+					(sharding == nil || !strings.Contains(arg, "promscrape.cluster.replicationFactor")) {
 					args[cnt] = arg
 					cnt++
 				}
 			}
 			args = args[:cnt]
-			args = append(args, fmt.Sprintf("-promscrape.cluster.membersCount=%d", shardsCount))
-			args = append(args, fmt.Sprintf("-promscrape.cluster.memberNum=%d", shardNum))
+			if sharding.IsConsistentHashing() {
+				// targets are filtered by relabeling at scrape configuration
+				container.Env = append(container.Env, corev1.EnvVar{
+					Name:  shardSlotsEnvName,
+					Value: shardSlotsRegex(shardNum, shardsCount, sharding.GetReplicationFactor()),
+				})
+			} else {
+				args = append(args, fmt.Sprintf("-promscrape.cluster.membersCount=%d", shardsCount))
+				args = append(args, fmt.Sprintf("-promscrape.cluster.memberNum=%d", shardNum))
+				if rf := sharding.GetReplicationFactor(); rf > 1 {
+					args = append(args, fmt.Sprintf("-promscrape.cluster.replicationFactor=%d", rf))
+				}
+			}
 			container.Args = args
 		}
 	}
@@ -892,7 +1015,7 @@ func createOrUpdateTLSAssets(ctx context.Context, rclient client.Client, cr, pre
 
 func addAssetsToCache(
 	ctx context.Context,
-	rclient client.Client,
+	rclient client.Reader,
 	objectNS string,
 	tlsConfig *vmv1beta1.TLSConfig,
 	ssCache *scrapesSecretsCache,
@@ -905,7 +1028,6 @@ func addAssetsToCache(
 	fetchAssetFor := func(assetPath string, src vmv1beta1.SecretOrConfigMap) error {
 		var asset string
 		var err error
-		cacheKey := objectNS + "/" + src.PrefixedName()
 		switch {
 		case src.Secret != nil:
 			asset, err = k8stools.GetCredFromSecret(
@@ -913,7 +1035,7 @@ func addAssetsToCache(
 				rclient,
 				objectNS,
 				src.Secret,
-				cacheKey,
+				buildCacheKey(objectNS, src.Secret.Name),
 				nsSecretCache,
 			)
 			if err != nil {
@@ -929,7 +1051,7 @@ func addAssetsToCache(
 				rclient,
 				objectNS,
 				*src.ConfigMap,
-				cacheKey,
+				buildCacheKey(objectNS, src.ConfigMap.Name),
 				nsConfigMapCache,
 			)
 			if err != nil {
@@ -959,7 +1081,7 @@ func addAssetsToCache(
 			rclient,
 			objectNS,
 			tlsConfig.KeySecret,
-			objectNS+"/"+tlsConfig.KeySecret.Name,
+			buildCacheKey(objectNS, tlsConfig.KeySecret.Name),
 			nsSecretCache,
 		)
 		if err != nil {
@@ -990,6 +1112,9 @@ func buildRemoteWriteSettings(cr *vmv1beta1.VMAgent) []string {
 		args = append(args,
 			"-remoteWrite.maxDiskUsagePerURL=1073741824",
 			fmt.Sprintf("-remoteWrite.tmpDataPath=%s", pqMountPath))
+		if queues, ok := shardedQueues(cr); ok {
+			args = append(args, fmt.Sprintf("-remoteWrite.queues=%d", queues))
+		}
 		return args
 	}
 
@@ -1009,6 +1134,8 @@ func buildRemoteWriteSettings(cr *vmv1beta1.VMAgent) []string {
 	}
 	if rws.Queues != nil {
 		args = append(args, fmt.Sprintf("-remoteWrite.queues=%d", *rws.Queues))
+	} else if queues, ok := shardedQueues(cr); ok {
+		args = append(args, fmt.Sprintf("-remoteWrite.queues=%d", queues))
 	}
 	if rws.ShowURL != nil {
 		args = append(args, fmt.Sprintf("-remoteWrite.showURL=%t", *rws.ShowURL))
@@ -1059,6 +1186,18 @@ func buildRemoteWriteSettings(cr *vmv1beta1.VMAgent) []string {
 	return args
 }
 
+// shardedQueues returns default number of remote write queues for sharded vmagent
+// each shard sends only part of data, so queues are scaled down with shards count
+// in order to keep number of concurrent connections to remote storage bounded
+func shardedQueues(cr *vmv1beta1.VMAgent) (int, bool) {
+	if cr.Spec.ShardCount == nil || *cr.Spec.ShardCount <= 1 {
+		return 0, false
+	}
+	shards := *cr.Spec.ShardCount
+	queues := (shardedRemoteWriteQueues + shards - 1) / shards
+	return max(queues, minShardRemoteWriteQueues), true
+}
+
 type item struct {
 	key, value string
 }
@@ -1086,6 +1225,7 @@ func buildRemoteWrites(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) []st
 	bearerTokenFile := remoteFlag{flagSetting: "-remoteWrite.bearerTokenFile="}
 	urlRelabelConfig := remoteFlag{flagSetting: "-remoteWrite.urlRelabelConfig="}
 	sendTimeout := remoteFlag{flagSetting: "-remoteWrite.sendTimeout="}
+	proxyURL := remoteFlag{flagSetting: "-remoteWrite.proxyURL="}
 	tlsCAs := remoteFlag{flagSetting: "-remoteWrite.tlsCAFile="}
 	tlsCerts := remoteFlag{flagSetting: "-remoteWrite.tlsCertFile="}
 	tlsKeys := remoteFlag{flagSetting: "-remoteWrite.tlsKeyFile="}
@@ -1227,6 +1367,13 @@ func buildRemoteWrites(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) []st
 		}
 		sendTimeout.flagSetting += fmt.Sprintf("%s,", value)
 
+		value = ""
+		if rws.ProxyURL != nil {
+			proxyURL.isNotNull = true
+			value = *rws.ProxyURL
+		}
+		proxyURL.flagSetting += fmt.Sprintf("%s,", value)
+
 		value = ""
 		if len(rws.Headers) > 0 {
 			headers.isNotNull = true
@@ -1293,11 +1440,13 @@ func buildRemoteWrites(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) []st
 			if dropInputVal {
 				streamAggrDropInput.isNotNull = true
 			}
-			if len(rws.StreamAggrConfig.DropInputLabels) > 0 {
+			if len(rws.StreamAggrConfig.DropInputLabels) > 0 && cr.SupportsStreamAggrDropInputLabels() {
 				streamAggrDropInputLabels.isNotNull = true
 				streamAggrDropInputLabels.flagSetting += fmt.Sprintf("%s,", strings.Join(rws.StreamAggrConfig.DropInputLabels, ","))
 			}
-			ignoreFirstIntervalsVal = rws.StreamAggrConfig.IgnoreFirstIntervals
+			if cr.SupportsStreamAggrIgnoreFirstIntervals() {
+				ignoreFirstIntervalsVal = rws.StreamAggrConfig.IgnoreFirstIntervals
+			}
 			if ignoreFirstIntervalsVal > 0 {
 				streamAggrIgnoreFirstIntervals.isNotNull = true
 			}
@@ -1326,7 +1475,7 @@ func buildRemoteWrites(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) []st
 		}
 	}
 
-	remoteArgs = append(remoteArgs, url, authUser, bearerTokenFile, urlRelabelConfig, tlsInsecure, sendTimeout)
+	remoteArgs = append(remoteArgs, url, authUser, bearerTokenFile, urlRelabelConfig, tlsInsecure, sendTimeout, proxyURL)
 	remoteArgs = append(remoteArgs, tlsServerName, tlsKeys, tlsCerts, tlsCAs)
 	remoteArgs = append(remoteArgs, oauth2ClientID, oauth2ClientSecretFile, oauth2Scopes, oauth2TokenURL)
 	remoteArgs = append(remoteArgs, headers, authPasswordFile)
@@ -1408,7 +1557,7 @@ func buildConfigReloaderArgs(cr *vmv1beta1.VMAgent) []string {
 	dirsArg := "watched-dir"
 
 	args := []string{
-		fmt.Sprintf("--reload-url=%s", vmv1beta1.BuildReloadPathWithPort(cr.Spec.ExtraArgs, cr.Spec.Port)),
+		fmt.Sprintf("--reload-url=%s", vmv1beta1.BuildReloadPathWithPort(cr.Spec.ExtraArgsWithServerTLS(), cr.Spec.Port)),
 	}
 	useCustomConfigReloader := ptr.Deref(cr.Spec.UseVMConfigReloader, false)
 
@@ -1416,9 +1565,9 @@ func buildConfigReloaderArgs(cr *vmv1beta1.VMAgent) []string {
 		args = append(args, fmt.Sprintf("--config-envsubst-file=%s", path.Join(vmAgentConOfOutDir, configEnvsubstFilename)))
 		if useCustomConfigReloader {
 			args = append(args, fmt.Sprintf("--config-secret-name=%s/%s", cr.Namespace, cr.PrefixedName()))
-			args = append(args, "--config-secret-key=vmagent.yaml.gz")
+			args = append(args, fmt.Sprintf("--config-secret-key=%s", configSecretKey(cr)))
 		} else {
-			args = append(args, fmt.Sprintf("--config-file=%s", path.Join(vmAgentConfDir, vmagentGzippedFilename)))
+			args = append(args, fmt.Sprintf("--config-file=%s", path.Join(vmAgentConfDir, configSecretKey(cr))))
 		}
 	}
 	if cr.HasAnyStreamAggrRule() {
@@ -1447,7 +1596,7 @@ func buildConfigReloaderArgs(cr *vmv1beta1.VMAgent) []string {
 	return args
 }
 
-func buildInitConfigContainer(useCustomConfigReloader bool, baseImage string, resources corev1.ResourceRequirements, configReloaderArgs []string) []corev1.Container {
+func buildInitConfigContainer(useCustomConfigReloader bool, baseImage string, resources corev1.ResourceRequirements, configReloaderArgs []string, configKey string) []corev1.Container {
 	var initReloader corev1.Container
 	if useCustomConfigReloader {
 		initReloader = corev1.Container{
@@ -1464,6 +1613,10 @@ func buildInitConfigContainer(useCustomConfigReloader bool, baseImage string, re
 		}
 		return []corev1.Container{initReloader}
 	}
+	initCmd := "gunzip -c %s > %s"
+	if configKey == vmagentConfigFilename {
+		initCmd = "cp %s %s"
+	}
 	initReloader = corev1.Container{
 		Image: baseImage,
 		Name:  "config-init",
@@ -1472,7 +1625,7 @@ func buildInitConfigContainer(useCustomConfigReloader bool, baseImage string, re
 		},
 		Args: []string{
 			"-c",
-			fmt.Sprintf("gunzip -c %s > %s", path.Join(vmAgentConfDir, vmagentGzippedFilename), path.Join(vmAgentConOfOutDir, configEnvsubstFilename)),
+			fmt.Sprintf(initCmd, path.Join(vmAgentConfDir, configKey), path.Join(vmAgentConOfOutDir, configEnvsubstFilename)),
 		},
 		VolumeMounts: []corev1.VolumeMount{
 			{
@@ -1499,18 +1652,87 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAgent, rclien
 	if err := reconcile.AdditionalServices(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevSvc, currSvc); err != nil {
 		return fmt.Errorf("cannot remove additional service: %w", err)
 	}
+	if err := reconcile.AdditionalServiceList(ctx, rclient, cr.Namespace, cr.ParsedLastAppliedSpec.AdditionalServices, cr.Spec.AdditionalServices); err != nil {
+		return fmt.Errorf("cannot remove additional services: %w", err)
+	}
 	objMeta := metav1.ObjectMeta{Name: cr.PrefixedName(), Namespace: cr.Namespace}
 	if cr.Spec.PodDisruptionBudget == nil && cr.ParsedLastAppliedSpec.PodDisruptionBudget != nil {
 		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &policyv1.PodDisruptionBudget{ObjectMeta: objMeta}); err != nil {
 			return fmt.Errorf("cannot delete PDB from prev state: %w", err)
 		}
 	}
+	if !cr.Spec.NetworkPolicy.IsEnabled() && cr.ParsedLastAppliedSpec.NetworkPolicy.IsEnabled() {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &networkingv1.NetworkPolicy{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot delete NetworkPolicy from prev state: %w", err)
+		}
+	}
+	if cr.Spec.VPA == nil && cr.ParsedLastAppliedSpec.VPA != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, build.NewVPA(cr.PrefixedName(), cr.Namespace)); err != nil {
+			return fmt.Errorf("cannot delete VPA from prev state: %w", err)
+		}
+	}
+	if cr.Spec.KEDA == nil && cr.ParsedLastAppliedSpec.KEDA != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, build.NewScaledObject(cr.PrefixedName(), cr.Namespace)); err != nil {
+			return fmt.Errorf("cannot delete ScaledObject from prev state: %w", err)
+		}
+	}
+	if cr.Spec.OTLP == nil && cr.ParsedLastAppliedSpec.OTLP != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: cr.OTLPServiceName(), Namespace: cr.Namespace}}); err != nil {
+			return fmt.Errorf("cannot delete otlp service from prev state: %w", err)
+		}
+	}
+	if prevGD := cr.ParsedLastAppliedSpec.GrafanaDashboard; prevGD != nil && (cr.Spec.GrafanaDashboard == nil || cr.Spec.GrafanaDashboard.UseConfigMap() != prevGD.UseConfigMap()) {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, build.NewGrafanaDashboardObject(prevGD, cr.GrafanaDashboardName(), cr.Namespace)); err != nil {
+			return fmt.Errorf("cannot delete grafana dashboard from prev state: %w", err)
+		}
+	}
 
 	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(cr.ParsedLastAppliedSpec.DisableSelfServiceScrape, false) {
 		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta}); err != nil {
 			return fmt.Errorf("cannot remove serviceScrape: %w", err)
 		}
 	}
+	if !ptr.Deref(cr.Spec.SelfMonitoringRules, false) && ptr.Deref(cr.ParsedLastAppliedSpec.SelfMonitoringRules, false) {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMRule{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot remove self-monitoring VMRule: %w", err)
+		}
+	}
 
 	return nil
 }
+
+// buildVPA creates VerticalPodAutoscaler for vmagent Deployment or StatefulSet
+func buildVPA(cr *vmv1beta1.VMAgent) *unstructured.Unstructured {
+	targetRef := autoscalingv2.CrossVersionObjectReference{
+		Name:       cr.PrefixedName(),
+		Kind:       "Deployment",
+		APIVersion: "apps/v1",
+	}
+	if cr.Spec.StatefulMode {
+		targetRef.Kind = "StatefulSet"
+	}
+	return build.VPA(cr, targetRef, "vmagent", cr.Spec.VPA)
+}
+
+// buildScaledObject creates KEDA ScaledObject for vmagent
+// it targets either VMAgent scale subresource for shards scaling
+// or vmagent Deployment or StatefulSet for replicas scaling
+func buildScaledObject(cr *vmv1beta1.VMAgent) *unstructured.Unstructured {
+	targetRef := autoscalingv2.CrossVersionObjectReference{
+		Name:       cr.PrefixedName(),
+		Kind:       "Deployment",
+		APIVersion: "apps/v1",
+	}
+	switch {
+	case cr.Spec.KEDA.ScalesShards():
+		targetRef = autoscalingv2.CrossVersionObjectReference{
+			Name:       cr.Name,
+			Kind:       "VMAgent",
+			APIVersion: vmv1beta1.GroupVersion.String(),
+		}
+	case cr.Spec.StatefulMode:
+		targetRef.Kind = "StatefulSet"
+	}
+	defaultQuery := fmt.Sprintf(`sum(vm_persistentqueue_bytes_pending{job=%q,namespace=%q})`, cr.PrefixedName(), cr.Namespace)
+	return build.ScaledObject(cr, targetRef, cr.Spec.KEDA, defaultQuery)
+}
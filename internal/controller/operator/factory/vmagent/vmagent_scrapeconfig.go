@@ -4,16 +4,21 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	stderrors "errors"
 	"fmt"
+	"io"
 	"path"
 	"reflect"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/VictoriaMetrics/metricsql"
+	"github.com/klauspost/compress/zstd"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -25,9 +30,11 @@ import (
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/configstat"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/tracing"
 )
 
 var vmagentSecretFetchErrsTotal prometheus.Counter
@@ -66,6 +73,16 @@ type scrapeObjects struct {
 	totalBrokenCount int
 }
 
+// setSelectedStat records number of scrape objects used for configuration generation
+func (sos *scrapeObjects) setSelectedStat(cr *vmv1beta1.VMAgent) {
+	configstat.SetSelectedObjects("vmagent", cr, "VMServiceScrape", len(sos.sss))
+	configstat.SetSelectedObjects("vmagent", cr, "VMPodScrape", len(sos.pss))
+	configstat.SetSelectedObjects("vmagent", cr, "VMStaticScrape", len(sos.stss))
+	configstat.SetSelectedObjects("vmagent", cr, "VMNodeScrape", len(sos.nss))
+	configstat.SetSelectedObjects("vmagent", cr, "VMProbe", len(sos.prss))
+	configstat.SetSelectedObjects("vmagent", cr, "VMScrapeConfig", len(sos.scss))
+}
+
 // CreateOrUpdateConfigurationSecret builds scrape configuration for VMAgent
 func CreateOrUpdateConfigurationSecret(ctx context.Context, cr *vmv1beta1.VMAgent, rclient client.Client) error {
 	var prevCR *vmv1beta1.VMAgent
@@ -79,10 +96,13 @@ func CreateOrUpdateConfigurationSecret(ctx context.Context, cr *vmv1beta1.VMAgen
 	return nil
 }
 
-func createOrUpdateConfigurationSecret(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMAgent) (*scrapesSecretsCache, error) {
+func createOrUpdateConfigurationSecret(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMAgent) (_ *scrapesSecretsCache, resultErr error) {
+	ctx, span := tracing.StartForObject(ctx, "vmagent.generateConfig", cr)
+	defer func() { tracing.End(span, resultErr) }()
 	if cr.Spec.IngestOnlyMode {
 		return nil, nil
 	}
+	startTime := time.Now()
 	sss, err := selectServiceScrapes(ctx, cr, rclient)
 	if err != nil {
 		return nil, fmt.Errorf("selecting ServiceScrapes failed: %w", err)
@@ -120,6 +140,53 @@ func createOrUpdateConfigurationSecret(ctx context.Context, rclient client.Clien
 		stss: statics,
 		scss: scrapeConfigs,
 	}
+	// Compress config to avoid 1mb secret limit for a while
+	// configuration is streamed into compressor in order to not keep the whole uncompressed configuration in memory
+	var buf bytes.Buffer
+	compression, level := configCompression(cr)
+	cw, err := newConfigCompressor(&buf, compression, level)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create %s compressor for vmagent config: %w", compression, err)
+	}
+	sw := &sizeWriter{w: cw}
+	ssCache, err := buildScrapeConfig(ctx, rclient, cr, sos, sw)
+	if err != nil {
+		_ = cw.Close()
+		return nil, err
+	}
+	if err := cw.Close(); err != nil {
+		return nil, fmt.Errorf("cannot compress config for vmagent with %s: %w", compression, err)
+	}
+	if err := createOrUpdateTLSAssets(ctx, rclient, cr, prevCR, ssCache.tlsAssets); err != nil {
+		return nil, fmt.Errorf("cannot create tls assets secret for vmagent: %w", err)
+	}
+	configstat.ObserveGeneration(ctx, "vmagent", cr, startTime, sw.size)
+	configstat.SetCompressedSize("vmagent", cr, buf.Len())
+	sos.setSelectedStat(cr)
+
+	s := makeConfigSecret(cr, ssCache)
+	s.Annotations = map[string]string{
+		"generated": "true",
+	}
+	s.Data[configSecretKey(cr)] = buf.Bytes()
+
+	var prevSecretMeta *metav1.ObjectMeta
+	if prevCR != nil {
+		prevSecretMeta = ptr.To(buildConfigMeta(prevCR))
+	}
+	if err := reconcile.Secret(ctx, rclient, s, prevSecretMeta); err != nil {
+		return nil, fmt.Errorf("cannot reconcile vmagent config secret: %w", err)
+	}
+	if err := updateStatusesForScrapeObjects(ctx, rclient, cr, sos); err != nil {
+		return nil, err
+	}
+
+	return ssCache, nil
+}
+
+// buildScrapeConfig filters scrape objects, loads secrets referenced by them and writes generated scrape configuration into w
+// objects with missing references are moved into broken lists of sos
+func buildScrapeConfig(ctx context.Context, rclient client.Reader, cr *vmv1beta1.VMAgent, sos *scrapeObjects, w io.Writer) (*scrapesSecretsCache, error) {
 	// filter out all service scrapes that access
 	// the file system.
 	// TODO: @f41gh7 properly check file system for other components
@@ -148,8 +215,11 @@ func createOrUpdateConfigurationSecret(ctx context.Context, rclient client.Clien
 		return nil, fmt.Errorf("cannot load scrape target secrets: %w", err)
 	}
 
-	if err := createOrUpdateTLSAssets(ctx, rclient, cr, prevCR, ssCache.tlsAssets); err != nil {
-		return nil, fmt.Errorf("cannot create tls assets secret for vmagent: %w", err)
+	for i := range cr.Spec.FederationTargets {
+		ft := &cr.Spec.FederationTargets[i]
+		if err := loadSecretsToCacheFrom(ctx, rclient, &ft.EndpointAuth, federationJobName(cr, ft), cr.Namespace, ssCache); err != nil {
+			return nil, fmt.Errorf("cannot load federation target secrets: %w", err)
+		}
 	}
 
 	additionalScrapeConfigs, err := loadAdditionalScrapeConfigsSecret(ctx, rclient, cr.Spec.AdditionalScrapeConfigs, cr.Namespace)
@@ -159,42 +229,69 @@ func createOrUpdateConfigurationSecret(ctx context.Context, rclient client.Clien
 	// TODO: @f41gh7  move it to the separate function
 	sos.sssBroken = append(sos.sssBroken, brokenServiceScrapes...)
 
-	// Update secret based on the most recent configuration.
-	generatedConfig, err := generateConfig(
+	if err := generateConfig(
 		ctx,
 		cr,
 		sos,
 		ssCache,
 		additionalScrapeConfigs,
-	)
-	if err != nil {
+		w,
+	); err != nil {
 		return nil, fmt.Errorf("generating config for vmagent failed: %w", err)
 	}
+	return ssCache, nil
+}
 
-	s := makeConfigSecret(cr, ssCache)
-	s.Annotations = map[string]string{
-		"generated": "true",
+// RenderScrapeConfig generates scrape configuration for the given VMAgent and scrape objects
+// with the same code as operator, but without objects selection and configuration secret reconcile.
+// Secrets and configmaps referenced by objects are loaded with the given client.
+// It returns generated configuration, assets referenced by it and objects skipped due to missing references
+// Given objects are modified, the reason of skip is stored at status.currentSyncError
+func RenderScrapeConfig(ctx context.Context, rclient client.Reader, cr *vmv1beta1.VMAgent, objects []client.Object) ([]byte, map[string]string, []client.Object, error) {
+	sos := &scrapeObjects{}
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *vmv1beta1.VMServiceScrape:
+			sos.sss = append(sos.sss, o)
+		case *vmv1beta1.VMPodScrape:
+			sos.pss = append(sos.pss, o)
+		case *vmv1beta1.VMStaticScrape:
+			sos.stss = append(sos.stss, o)
+		case *vmv1beta1.VMNodeScrape:
+			sos.nss = append(sos.nss, o)
+		case *vmv1beta1.VMProbe:
+			sos.prss = append(sos.prss, o)
+		case *vmv1beta1.VMScrapeConfig:
+			sos.scss = append(sos.scss, o)
+		default:
+			return nil, nil, nil, fmt.Errorf("unsupported scrape object type=%T", obj)
+		}
 	}
-
-	// Compress config to avoid 1mb secret limit for a while
 	var buf bytes.Buffer
-	if err = gzipConfig(&buf, generatedConfig); err != nil {
-		return nil, fmt.Errorf("cannot gzip config for vmagent: %w", err)
+	ssCache, err := buildScrapeConfig(ctx, rclient, cr, sos, &buf)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	s.Data[vmagentGzippedFilename] = buf.Bytes()
-
-	var prevSecretMeta *metav1.ObjectMeta
-	if prevCR != nil {
-		prevSecretMeta = ptr.To(buildConfigMeta(prevCR))
+	var skipped []client.Object
+	for _, o := range sos.sssBroken {
+		skipped = append(skipped, o)
 	}
-	if err := reconcile.Secret(ctx, rclient, s, prevSecretMeta); err != nil {
-		return nil, fmt.Errorf("cannot reconcile vmagent config secret: %w", err)
+	for _, o := range sos.pssBroken {
+		skipped = append(skipped, o)
 	}
-	if err := updateStatusesForScrapeObjects(ctx, rclient, cr, sos); err != nil {
-		return nil, err
+	for _, o := range sos.stssBroken {
+		skipped = append(skipped, o)
 	}
-
-	return ssCache, nil
+	for _, o := range sos.nssBroken {
+		skipped = append(skipped, o)
+	}
+	for _, o := range sos.prssBroken {
+		skipped = append(skipped, o)
+	}
+	for _, o := range sos.scssBroken {
+		skipped = append(skipped, o)
+	}
+	return buf.Bytes(), ssCache.tlsAssets, skipped, nil
 }
 
 func updateStatusesForScrapeObjects(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAgent, sos *scrapeObjects) error {
@@ -271,7 +368,7 @@ func forEachCollectSkipNotFound[T scrapeObjectWithStatus](src []T, apply func(s
 	src = src[:cnt]
 	return src, notNotFoundLinks, nil
 }
-func loadSecretsToCacheFrom(ctx context.Context, rclient client.Client, ep *vmv1beta1.EndpointAuth, cacheKey, namespace string, ss *scrapesSecretsCache) error {
+func loadSecretsToCacheFrom(ctx context.Context, rclient client.Reader, ep *vmv1beta1.EndpointAuth, cacheKey, namespace string, ss *scrapesSecretsCache) error {
 	if ep.BasicAuth != nil {
 		credentials, err := loadBasicAuthSecretFromAPI(ctx, rclient, ep.BasicAuth, namespace, ss.nsSecretCache)
 		if err != nil {
@@ -311,12 +408,14 @@ func loadSecretsToCacheFrom(ctx context.Context, rclient client.Client, ep *vmv1
 
 func loadScrapeSecrets(
 	ctx context.Context,
-	rclient client.Client,
+	rclient client.Reader,
 	sos *scrapeObjects,
 	vmagentCRNamespace string,
 	apiserverConfig *vmv1beta1.APIServerConfig,
 	remoteWriteSpecs []vmv1beta1.VMAgentRemoteWriteSpec,
-) (*scrapesSecretsCache, error) {
+) (_ *scrapesSecretsCache, resultErr error) {
+	ctx, span := tracing.Start(ctx, "vmagent.loadScrapeSecrets", attribute.String("k8s.namespace.name", vmagentCRNamespace))
+	defer func() { tracing.End(span, resultErr) }()
 	ssCache := &scrapesSecretsCache{
 		baSecrets:            map[string]*k8stools.BasicAuthCredentials{},
 		oauth2Secrets:        map[string]*k8stools.OAuthCreds{},
@@ -654,7 +753,7 @@ func loadScrapeSecrets(
 			ssCache.baSecrets["apiserver"] = &credentials
 		}
 		if apiserverConfig.Authorization != nil {
-			secretValue, err := k8stools.GetCredFromSecret(ctx, rclient, vmagentCRNamespace, apiserverConfig.Authorization.Credentials, buildCacheKey(vmagentCRNamespace, "apiserver"), ssCache.nsSecretCache)
+			secretValue, err := k8stools.GetCredFromSecret(ctx, rclient, vmagentCRNamespace, apiserverConfig.Authorization.Credentials, buildCacheKey(vmagentCRNamespace, apiserverConfig.Authorization.Credentials.Name), ssCache.nsSecretCache)
 			if err != nil {
 				return nil, fmt.Errorf("cannot fetch authorization secret for apiserver config: %w", err)
 			}
@@ -699,7 +798,7 @@ func loadScrapeSecrets(
 	return ssCache, nil
 }
 
-func loadBasicAuthSecretFromAPI(ctx context.Context, rclient client.Client, basicAuth *vmv1beta1.BasicAuth, ns string, cache map[string]*corev1.Secret) (*k8stools.BasicAuthCredentials, error) {
+func loadBasicAuthSecretFromAPI(ctx context.Context, rclient client.Reader, basicAuth *vmv1beta1.BasicAuth, ns string, cache map[string]*corev1.Secret) (*k8stools.BasicAuthCredentials, error) {
 	var username string
 	var password string
 	var err error
@@ -719,7 +818,20 @@ func buildCacheKey(ns, keyName string) string {
 	return fmt.Sprintf("%s/%s", ns, keyName)
 }
 
-func loadProxySecrets(ctx context.Context, rclient client.Client, proxyCfg *vmv1beta1.ProxyAuth, ns string, cache map[string]*corev1.Secret) (ba *k8stools.BasicAuthCredentials, token string, err error) {
+// addCredentialAsset stores credential at tls assets secret and returns path to the mounted file with it.
+// Asset key is built from content hash, so it doesn't change if secret is updated with the same content
+// and credential referenced by multiple scrape objects is stored only once.
+func (ssc *scrapesSecretsCache) addCredentialAsset(kind, value string) string {
+	if ssc.tlsAssets == nil {
+		ssc.tlsAssets = make(map[string]string)
+	}
+	h := sha256.Sum256([]byte(value))
+	key := fmt.Sprintf("%s_%x", kind, h[:8])
+	ssc.tlsAssets[key] = value
+	return path.Join(tlsAssetsDir, key)
+}
+
+func loadProxySecrets(ctx context.Context, rclient client.Reader, proxyCfg *vmv1beta1.ProxyAuth, ns string, cache map[string]*corev1.Secret) (ba *k8stools.BasicAuthCredentials, token string, err error) {
 	if proxyCfg.BasicAuth != nil {
 		ba, err = loadBasicAuthSecretFromAPI(ctx, rclient, proxyCfg.BasicAuth, ns, cache)
 		if err != nil {
@@ -745,7 +857,7 @@ func loadProxySecrets(ctx context.Context, rclient client.Client, proxyCfg *vmv1
 	return
 }
 
-func loadAdditionalScrapeConfigsSecret(ctx context.Context, rclient client.Client, additionalScrapeConfigs *corev1.SecretKeySelector, namespace string) ([]byte, error) {
+func loadAdditionalScrapeConfigsSecret(ctx context.Context, rclient client.Reader, additionalScrapeConfigs *corev1.SecretKeySelector, namespace string) ([]byte, error) {
 	if additionalScrapeConfigs != nil {
 		var s corev1.Secret
 		if err := rclient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: additionalScrapeConfigs.Name}, &s); err != nil {
@@ -792,13 +904,109 @@ func testForArbitraryFSAccess(e vmv1beta1.EndpointAuth) error {
 	return nil
 }
 
-func gzipConfig(buf *bytes.Buffer, conf []byte) error {
-	w := gzip.NewWriter(buf)
-	defer w.Close()
-	if _, err := w.Write(conf); err != nil {
-		return err
+// configCompression returns compression and its level for scrape configuration of the given VMAgent
+// prometheus-config-reloader doesn't support zstd and gzip is used for it instead
+func configCompression(cr *vmv1beta1.VMAgent) (string, int) {
+	cfg := config.MustGetBaseConfigForNamespace(cr.Namespace).VMAgentScrapeDefault
+	switch cfg.ConfigCompression {
+	case config.ConfigCompressionNone:
+		return config.ConfigCompressionNone, 0
+	case config.ConfigCompressionZstd:
+		if ptr.Deref(cr.Spec.UseVMConfigReloader, false) {
+			return config.ConfigCompressionZstd, cfg.ConfigCompressionLevel
+		}
+		return config.ConfigCompressionGzip, 0
+	default:
+		return config.ConfigCompressionGzip, cfg.ConfigCompressionLevel
 	}
-	return nil
+}
+
+// configSecretKey returns key of secret with scrape configuration for the given VMAgent
+func configSecretKey(cr *vmv1beta1.VMAgent) string {
+	compression, _ := configCompression(cr)
+	switch compression {
+	case config.ConfigCompressionNone:
+		return vmagentConfigFilename
+	case config.ConfigCompressionZstd:
+		return vmagentZstdFilename
+	default:
+		return vmagentGzippedFilename
+	}
+}
+
+// newConfigCompressor returns writer, which compresses configuration with the given compression and level into dst
+// level 0 means default level of compression algorithm
+func newConfigCompressor(dst io.Writer, compression string, level int) (io.WriteCloser, error) {
+	switch compression {
+	case config.ConfigCompressionNone:
+		return nopWriteCloser{dst}, nil
+	case config.ConfigCompressionZstd:
+		var opts []zstd.EOption
+		if level > 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}
+		return zstd.NewWriter(dst, opts...)
+	default:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		return gzip.NewWriterLevel(dst, level)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// sizeWriter counts bytes written to the underlying writer
+type sizeWriter struct {
+	w    io.Writer
+	size int
+}
+
+func (sw *sizeWriter) Write(p []byte) (int, error) {
+	n, err := sw.w.Write(p)
+	sw.size += n
+	return n, err
+}
+
+// scrapeJobsWriter writes scrape jobs into scrape_configs list one by one
+// it allows to not keep the whole marshaled configuration in memory
+type scrapeJobsWriter struct {
+	w     io.Writer
+	count int
+	// relabelConfigs are added to the end of relabel_configs of each job
+	relabelConfigs []yaml.MapSlice
+}
+
+func (jw *scrapeJobsWriter) write(job yaml.MapSlice) error {
+	if len(jw.relabelConfigs) > 0 {
+		job = appendRelabelConfigsToJob(job, jw.relabelConfigs)
+	}
+	if jw.count == 0 {
+		if _, err := io.WriteString(jw.w, "scrape_configs:\n"); err != nil {
+			return err
+		}
+	}
+	jw.count++
+	// yaml list at the top level has the same indentation as list under the key of top level mapping
+	data, err := yaml.Marshal([]yaml.MapSlice{job})
+	if err != nil {
+		return fmt.Errorf("cannot marshal scrape job: %w", err)
+	}
+	_, err = jw.w.Write(data)
+	return err
+}
+
+// close writes empty scrape_configs list if there were no jobs
+func (jw *scrapeJobsWriter) close() error {
+	if jw.count > 0 {
+		return nil
+	}
+	_, err := io.WriteString(jw.w, "scrape_configs: []\n")
+	return err
 }
 
 func setScrapeIntervalToWithLimit(ctx context.Context, dst *vmv1beta1.EndpointScrapeParams, vmagentCR *vmv1beta1.VMAgent) {
@@ -842,7 +1050,6 @@ func setScrapeIntervalToWithLimit(ctx context.Context, dst *vmv1beta1.EndpointSc
 }
 
 const (
-	defaultScrapeInterval          = "30s"
 	kubernetesSDRoleEndpoint       = "endpoints"
 	kubernetesSDRoleService        = "service"
 	kubernetesSDRoleEndpointSlices = "endpointslices"
@@ -859,8 +1066,8 @@ func generateConfig(
 	sos *scrapeObjects,
 	secretsCache *scrapesSecretsCache,
 	additionalScrapeConfigs []byte,
-) ([]byte, error) {
-	cfg := yaml.MapSlice{}
+	w io.Writer,
+) error {
 	if !config.IsClusterWideAccessAllowed() && cr.IsOwnsServiceAccount() {
 		logger.WithContext(ctx).Info("Setting discovery for the single namespace only." +
 			"Since operator launched with set WATCH_NAMESPACE param. " +
@@ -869,7 +1076,7 @@ func generateConfig(
 	}
 
 	if cr.Spec.ScrapeInterval == "" {
-		cr.Spec.ScrapeInterval = defaultScrapeInterval
+		cr.Spec.ScrapeInterval = config.MustGetBaseConfigForNamespace(cr.Namespace).VMAgentScrapeDefault.ScrapeInterval
 	}
 
 	globalItems := yaml.MapSlice{
@@ -883,107 +1090,140 @@ func generateConfig(
 		})
 	}
 
-	cfg = append(cfg, yaml.MapItem{Key: "global", Value: globalItems})
-
-	apiserverConfig := cr.Spec.APIServerConfig
+	var additionalScrapeConfigsYaml []yaml.MapSlice
+	if err := yaml.Unmarshal(additionalScrapeConfigs, &additionalScrapeConfigsYaml); err != nil {
+		return fmt.Errorf("unmarshalling additional scrape configs failed: %w", err)
+	}
 
-	var scrapeConfigs []yaml.MapSlice
-	for _, ss := range sos.sss {
-		for i, ep := range ss.Spec.Endpoints {
-			scrapeConfigs = append(scrapeConfigs,
-				generateServiceScrapeConfig(
-					ctx,
-					cr,
-					ss,
-					ep, i,
-					apiserverConfig,
-					secretsCache,
-					cr.Spec.VMAgentSecurityEnforcements,
-				))
+	var inlineScrapeConfigsYaml []yaml.MapSlice
+	if len(cr.Spec.InlineScrapeConfig) > 0 {
+		if err := yaml.Unmarshal([]byte(cr.Spec.InlineScrapeConfig), &inlineScrapeConfigsYaml); err != nil {
+			return fmt.Errorf("unmarshalling  inline additional scrape configs failed: %w", err)
 		}
 	}
-	for _, identifier := range sos.pss {
-		for i, ep := range identifier.Spec.PodMetricsEndpoints {
-			scrapeConfigs = append(scrapeConfigs,
-				generatePodScrapeConfig(
-					ctx,
-					cr,
-					identifier, ep, i,
-					apiserverConfig,
-					secretsCache,
-					cr.Spec.VMAgentSecurityEnforcements,
-				))
-		}
+	additionalScrapeConfigsYaml = append(additionalScrapeConfigsYaml, inlineScrapeConfigsYaml...)
+
+	// configuration is written by parts instead of marshaling of the whole configuration at once
+	// it reduces memory usage for large number of scrape jobs
+	header := yaml.MapSlice{{Key: "global", Value: globalItems}}
+	if len(cr.Spec.ScrapeConfigFiles) > 0 {
+		header = append(header, yaml.MapItem{Key: "scrape_config_files", Value: cr.Spec.ScrapeConfigFiles})
+	}
+	data, err := yaml.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("cannot marshal global config: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
 	}
 
-	for i, identifier := range sos.prss {
-		scrapeConfigs = append(scrapeConfigs,
-			generateProbeConfig(
+	apiserverConfig := cr.Spec.APIServerConfig
+
+	jw := &scrapeJobsWriter{w: w}
+	if cr.Spec.Sharding.IsConsistentHashing() && cr.Spec.ShardCount != nil && *cr.Spec.ShardCount > 1 {
+		jw.relabelConfigs = shardingRelabelConfigs()
+	}
+	for _, ss := range sos.sss {
+		for i, ep := range ss.Spec.Endpoints {
+			if err := jw.write(generateServiceScrapeConfig(
 				ctx,
 				cr,
-				identifier,
-				i,
+				ss,
+				ep, i,
 				apiserverConfig,
 				secretsCache,
 				cr.Spec.VMAgentSecurityEnforcements,
-			))
+			)); err != nil {
+				return err
+			}
+		}
 	}
-	for i, identifier := range sos.nss {
-		scrapeConfigs = append(scrapeConfigs,
-			generateNodeScrapeConfig(
+	for _, identifier := range sos.pss {
+		for i, ep := range identifier.Spec.PodMetricsEndpoints {
+			if err := jw.write(generatePodScrapeConfig(
 				ctx,
 				cr,
-				identifier,
-				i,
+				identifier, ep, i,
 				apiserverConfig,
 				secretsCache,
 				cr.Spec.VMAgentSecurityEnforcements,
-			))
+			)); err != nil {
+				return err
+			}
+		}
 	}
 
-	for _, identifier := range sos.stss {
-		for i, ep := range identifier.Spec.TargetEndpoints {
-			scrapeConfigs = append(scrapeConfigs,
-				generateStaticScrapeConfig(
-					ctx,
-					cr,
-					identifier,
-					ep, i,
-					secretsCache,
-					cr.Spec.VMAgentSecurityEnforcements,
-				))
+	for i, identifier := range sos.prss {
+		if err := jw.write(generateProbeConfig(
+			ctx,
+			cr,
+			identifier,
+			i,
+			apiserverConfig,
+			secretsCache,
+			cr.Spec.VMAgentSecurityEnforcements,
+		)); err != nil {
+			return err
+		}
+	}
+	for i, identifier := range sos.nss {
+		if err := jw.write(generateNodeScrapeConfig(
+			ctx,
+			cr,
+			identifier,
+			i,
+			apiserverConfig,
+			secretsCache,
+			cr.Spec.VMAgentSecurityEnforcements,
+		)); err != nil {
+			return err
 		}
 	}
 
-	for _, identifier := range sos.scss {
-		scrapeConfigs = append(scrapeConfigs,
-			generateScrapeConfig(
+	for _, identifier := range sos.stss {
+		for i, ep := range identifier.Spec.TargetEndpoints {
+			if err := jw.write(generateStaticScrapeConfig(
 				ctx,
 				cr,
 				identifier,
+				ep, i,
 				secretsCache,
 				cr.Spec.VMAgentSecurityEnforcements,
-			))
+			)); err != nil {
+				return err
+			}
+		}
 	}
 
-	var additionalScrapeConfigsYaml []yaml.MapSlice
-	if err := yaml.Unmarshal(additionalScrapeConfigs, &additionalScrapeConfigsYaml); err != nil {
-		return nil, fmt.Errorf("unmarshalling additional scrape configs failed: %w", err)
+	for _, identifier := range sos.scss {
+		if err := jw.write(generateScrapeConfig(
+			ctx,
+			cr,
+			identifier,
+			secretsCache,
+			cr.Spec.VMAgentSecurityEnforcements,
+		)); err != nil {
+			return err
+		}
 	}
 
-	var inlineScrapeConfigsYaml []yaml.MapSlice
-	if len(cr.Spec.InlineScrapeConfig) > 0 {
-		if err := yaml.Unmarshal([]byte(cr.Spec.InlineScrapeConfig), &inlineScrapeConfigsYaml); err != nil {
-			return nil, fmt.Errorf("unmarshalling  inline additional scrape configs failed: %w", err)
+	for i := range cr.Spec.FederationTargets {
+		if err := jw.write(generateFederationScrapeConfig(
+			ctx,
+			cr,
+			&cr.Spec.FederationTargets[i],
+			secretsCache,
+		)); err != nil {
+			return err
 		}
 	}
-	additionalScrapeConfigsYaml = append(additionalScrapeConfigsYaml, inlineScrapeConfigsYaml...)
-	cfg = append(cfg, yaml.MapItem{
-		Key:   "scrape_configs",
-		Value: append(scrapeConfigs, additionalScrapeConfigsYaml...),
-	})
 
-	return yaml.Marshal(cfg)
+	for _, job := range additionalScrapeConfigsYaml {
+		if err := jw.write(job); err != nil {
+			return err
+		}
+	}
+	return jw.close()
 }
 
 func buildConfigMeta(cr *vmv1beta1.VMAgent) metav1.ObjectMeta {
@@ -1001,7 +1241,7 @@ func makeConfigSecret(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) *core
 	s := &corev1.Secret{
 		ObjectMeta: buildConfigMeta(cr),
 		Data: map[string][]byte{
-			vmagentGzippedFilename: {},
+			configSecretKey(cr): {},
 		},
 	}
 	for idx, rw := range cr.Spec.RemoteWrite {
@@ -1120,6 +1360,9 @@ func addTLStoYaml(cfg yaml.MapSlice, namespace string, tls *vmv1beta1.TLSConfig,
 		if tls.ServerName != "" {
 			tlsConfig = append(tlsConfig, yaml.MapItem{Key: "server_name", Value: tls.ServerName})
 		}
+		if tls.MinVersion != "" {
+			tlsConfig = append(tlsConfig, yaml.MapItem{Key: "min_version", Value: tls.MinVersion})
+		}
 		if addDirect {
 			cfg = append(cfg, tlsConfig...)
 			return cfg
@@ -1300,6 +1543,12 @@ func enforceNamespaceLabel(relabelings []yaml.MapSlice, namespace, enforcedNames
 
 func buildExternalLabels(p *vmv1beta1.VMAgent) yaml.MapSlice {
 	m := map[string]string{}
+	if p.Spec.ExternalLabelsPolicy == vmv1beta1.ExternalLabelsPolicyReplace {
+		for n, v := range p.Spec.ExternalLabels {
+			m[n] = v
+		}
+		return stringMapToMapSlice(m)
+	}
 
 	// Use "prometheus" external label name by default if field is missing.
 	// in case of migration from prometheus to vmagent, it helps to have same labels
@@ -1316,6 +1565,9 @@ func buildExternalLabels(p *vmv1beta1.VMAgent) yaml.MapSlice {
 	if prometheusExternalLabelName != "" {
 		m[prometheusExternalLabelName] = fmt.Sprintf("%s/%s", p.Namespace, p.Name)
 	}
+	if cfg := config.MustGetBaseConfigForNamespace(p.Namespace); cfg.ClusterName != "" {
+		m[cfg.ClusterLabelName] = cfg.ClusterName
+	}
 
 	for n, v := range p.Spec.ExternalLabels {
 		m[n] = v
@@ -1412,10 +1664,10 @@ func buildProxyAuthConfig(namespace, cacheKey string, proxyAuth *vmv1beta1.Proxy
 	if proxyAuth.BasicAuth != nil {
 		var pa yaml.MapSlice
 		if ba, ok := ssCache.baSecrets[cacheKey]; ok {
-			pa = append(pa,
-				yaml.MapItem{Key: "username", Value: ba.Username},
-				yaml.MapItem{Key: "password", Value: ba.Password},
-			)
+			pa = append(pa, yaml.MapItem{Key: "username", Value: ba.Username})
+			if len(ba.Password) > 0 {
+				pa = append(pa, yaml.MapItem{Key: "password_file", Value: ssCache.addCredentialAsset("password", ba.Password)})
+			}
 		}
 		if len(proxyAuth.BasicAuth.PasswordFile) > 0 {
 			pa = append(pa, yaml.MapItem{Key: "password_file", Value: proxyAuth.BasicAuth.PasswordFile})
@@ -1433,7 +1685,7 @@ func buildProxyAuthConfig(namespace, cacheKey string, proxyAuth *vmv1beta1.Proxy
 
 	if proxyAuth.BearerToken != nil {
 		if bt, ok := ssCache.bearerTokens[cacheKey]; ok {
-			r = append(r, yaml.MapItem{Key: "proxy_bearer_token", Value: bt})
+			r = append(r, yaml.MapItem{Key: "proxy_bearer_token_file", Value: ssCache.addCredentialAsset("bearer", bt)})
 		}
 	} else if len(proxyAuth.BearerTokenFile) > 0 {
 		r = append(r, yaml.MapItem{Key: "proxy_bearer_token_file", Value: proxyAuth.BearerTokenFile})
@@ -1571,7 +1823,7 @@ func addEndpointAuthTo(cfg yaml.MapSlice, ac vmv1beta1.EndpointAuth, key string,
 
 	if ac.BearerTokenSecret != nil && ac.BearerTokenSecret.Name != "" {
 		if s, ok := ssCache.bearerTokens[key]; ok {
-			cfg = append(cfg, yaml.MapItem{Key: "bearer_token", Value: s})
+			cfg = append(cfg, yaml.MapItem{Key: "bearer_token_file", Value: ssCache.addCredentialAsset("bearer", s)})
 		}
 	}
 	if ac.BasicAuth != nil {
@@ -1581,7 +1833,7 @@ func addEndpointAuthTo(cfg yaml.MapSlice, ac vmv1beta1.EndpointAuth, key string,
 				yaml.MapItem{Key: "username", Value: s.Username},
 			)
 			if len(s.Password) > 0 {
-				bac = append(bac, yaml.MapItem{Key: "password", Value: s.Password})
+				bac = append(bac, yaml.MapItem{Key: "password_file", Value: ssCache.addCredentialAsset("password", s.Password)})
 			}
 		}
 		if len(ac.BasicAuth.PasswordFile) > 0 {
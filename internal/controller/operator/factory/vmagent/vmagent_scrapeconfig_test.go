@@ -12,7 +12,8 @@ import (
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
-	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
@@ -90,6 +91,22 @@ func Test_addTLStoYaml(t *testing.T) {
   ca_file: /etc/vmagent-tls/certs/default_tls-secret_ca
   cert_file: /etc/vmagent-tls/certs/default_tls-secret_cert
   key_file: /etc/vmagent-tls/certs/default_tls-secret_key
+`,
+		},
+		{
+			name: "check min version added to config",
+			args: args{
+				namespace: "default",
+				cfg:       yaml.MapSlice{},
+				tls: &vmv1beta1.TLSConfig{
+					ServerName: "vmselect.example.com",
+					MinVersion: "TLS12",
+				},
+			},
+			want: `tls_config:
+  insecure_skip_verify: false
+  server_name: vmselect.example.com
+  min_version: TLS12
 `,
 		},
 	}
@@ -220,6 +237,32 @@ func TestCreateOrUpdateConfigurationSecret(t *testing.T) {
 		wantConfig        string
 		wantErr           bool
 	}{
+		{
+			name: "global knobs with replaced external labels",
+			args: args{
+				cr: &vmv1beta1.VMAgent{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMAgentSpec{
+						ScrapeTimeout:        "10s",
+						ExternalLabels:       map[string]string{"cluster": "main"},
+						ExternalLabelsPolicy: vmv1beta1.ExternalLabelsPolicyReplace,
+						ScrapeConfigFiles:    []string{"/etc/vmagent/extra/*.yaml"},
+					},
+				},
+			},
+			wantConfig: `global:
+  scrape_interval: 30s
+  external_labels:
+    cluster: main
+  scrape_timeout: 10s
+scrape_config_files:
+- /etc/vmagent/extra/*.yaml
+scrape_configs: []
+`,
+		},
 		{
 			name: "complete test",
 			args: args{
@@ -521,7 +564,7 @@ scrape_configs:
     replacement: ${1}
   - target_label: endpoint
     replacement: "8085"
-  bearer_token: some-bearer
+  bearer_token_file: /etc/vmagent-tls/certs/bearer_72f58341f7077db6
 - job_name: serviceScrape/default/test-vms/1
   kubernetes_sd_configs:
   - role: endpoints
@@ -690,7 +733,7 @@ scrape_configs:
     replacement: default/test-vms
   basic_auth:
     username: some-username
-    password: some-password
+    password_file: /etc/vmagent-tls/certs/password_f0ce9d6e7315534d
 - job_name: staticScrape/default/test-vmstatic/0
   static_configs:
   - targets: []
@@ -1139,7 +1182,7 @@ scrape_configs:
     replacement: ${1}
   - target_label: endpoint
     replacement: "8085"
-  bearer_token: some-bearer
+  bearer_token_file: /etc/vmagent-tls/certs/bearer_72f58341f7077db6
 - job_name: serviceScrape/default/test-vms/1
   kubernetes_sd_configs:
   - role: endpointslices
@@ -1195,7 +1238,7 @@ scrape_configs:
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			testClient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			testClient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			cfgO := *config.MustGetBaseConfig()
 			if tt.args.c != nil {
 				*config.MustGetBaseConfig() = *tt.args.c
@@ -1227,3 +1270,101 @@ scrape_configs:
 		})
 	}
 }
+
+func TestCompressConfig(t *testing.T) {
+	f := func(compression string, level int) {
+		t.Helper()
+		conf := bytes.Repeat([]byte("scrape_configs:\n- job_name: test\n"), 100)
+		var buf bytes.Buffer
+		cw, err := newConfigCompressor(&buf, compression, level)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if _, err := cw.Write(conf); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := cw.Close(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var got []byte
+		switch compression {
+		case config.ConfigCompressionNone:
+			got = buf.Bytes()
+		case config.ConfigCompressionZstd:
+			zr, err := zstd.NewReader(nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			defer zr.Close()
+			got, err = zr.DecodeAll(buf.Bytes(), nil)
+			if err != nil {
+				t.Fatalf("cannot decompress zstd: %s", err)
+			}
+			if buf.Len() >= len(conf) {
+				t.Fatalf("expected compressed config")
+			}
+		default:
+			gr, err := gzip.NewReader(&buf)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got, err = io.ReadAll(gr)
+			if err != nil {
+				t.Fatalf("cannot decompress gzip: %s", err)
+			}
+		}
+		if !bytes.Equal(got, conf) {
+			t.Fatalf("unexpected config after decompression")
+		}
+	}
+	f(config.ConfigCompressionGzip, 0)
+	f(config.ConfigCompressionGzip, 1)
+	f(config.ConfigCompressionGzip, 9)
+	f(config.ConfigCompressionZstd, 0)
+	f(config.ConfigCompressionZstd, 19)
+	f(config.ConfigCompressionNone, 0)
+}
+
+func TestAddCredentialAsset(t *testing.T) {
+	ssCache := &scrapesSecretsCache{}
+	first := ssCache.addCredentialAsset("bearer", "some-token")
+	second := ssCache.addCredentialAsset("bearer", "some-token")
+	if first != second {
+		t.Fatalf("expected the same path for the same content, got: %q and %q", first, second)
+	}
+	other := ssCache.addCredentialAsset("bearer", "other-token")
+	if first == other {
+		t.Fatalf("expected different paths for different content, got: %q", first)
+	}
+	assert.Equal(t, map[string]string{
+		"bearer_308eda9daf26b744": "some-token",
+		"bearer_6c67163bbed989f2": "other-token",
+	}, ssCache.tlsAssets)
+	assert.Equal(t, "/etc/vmagent-tls/certs/bearer_308eda9daf26b744", first)
+}
+
+func TestBuildExternalLabels(t *testing.T) {
+	f := func(clusterName string, spec vmv1beta1.VMAgentSpec, want yaml.MapSlice) {
+		t.Helper()
+		cfg := config.MustGetBaseConfig()
+		defaultCfg := *cfg
+		t.Cleanup(func() { *cfg = defaultCfg })
+		cfg.ClusterName = clusterName
+		cr := &vmv1beta1.VMAgent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       spec,
+		}
+		assert.Equal(t, want, buildExternalLabels(cr))
+	}
+	// default labels
+	f("", vmv1beta1.VMAgentSpec{}, yaml.MapSlice{{Key: "prometheus", Value: "default/agent"}})
+	// cluster name from operator config
+	f("main", vmv1beta1.VMAgentSpec{}, yaml.MapSlice{{Key: "cluster", Value: "main"}, {Key: "prometheus", Value: "default/agent"}})
+	// spec labels take precedence
+	f("main", vmv1beta1.VMAgentSpec{ExternalLabels: map[string]string{"cluster": "edge"}}, yaml.MapSlice{{Key: "cluster", Value: "edge"}, {Key: "prometheus", Value: "default/agent"}})
+	// replace policy ignores operator labels
+	f("main", vmv1beta1.VMAgentSpec{
+		ExternalLabels:       map[string]string{"region": "eu"},
+		ExternalLabelsPolicy: vmv1beta1.ExternalLabelsPolicyReplace,
+	}, yaml.MapSlice{{Key: "region", Value: "eu"}})
+}
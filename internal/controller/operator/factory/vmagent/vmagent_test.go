@@ -13,6 +13,7 @@ import (
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 	"github.com/go-test/deep"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
@@ -20,6 +21,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -118,7 +120,7 @@ func TestCreateOrUpdateVMAgent(t *testing.T) {
 			},
 			statefulsetMode: true,
 			predefinedObjects: []runtime.Object{
-				k8stools.NewReadyDeployment("vmagent-example-agent", "default"),
+				testutil.NewReadyDeployment("vmagent-example-agent", "default"),
 			},
 		},
 		{
@@ -141,8 +143,8 @@ func TestCreateOrUpdateVMAgent(t *testing.T) {
 				},
 			},
 			predefinedObjects: []runtime.Object{
-				k8stools.NewReadyDeployment("vmagent-example-agent-0", "default"),
-				k8stools.NewReadyDeployment("vmagent-example-agent-1", "default"),
+				testutil.NewReadyDeployment("vmagent-example-agent-0", "default"),
+				testutil.NewReadyDeployment("vmagent-example-agent-1", "default"),
 			},
 		},
 		{
@@ -162,7 +164,7 @@ func TestCreateOrUpdateVMAgent(t *testing.T) {
 				},
 			},
 			predefinedObjects: []runtime.Object{
-				k8stools.NewReadyDeployment("vmagent-example-agent-bauth", "default"),
+				testutil.NewReadyDeployment("vmagent-example-agent-bauth", "default"),
 				&corev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{Name: "bauth-secret", Namespace: "default"},
 					Data:       map[string][]byte{"user": []byte(`user-name`), "password": []byte(`user-password`)},
@@ -288,7 +290,7 @@ func TestCreateOrUpdateVMAgent(t *testing.T) {
 				},
 			},
 			predefinedObjects: []runtime.Object{
-				k8stools.NewReadyDeployment("vmagent-example-agent-tls", "default"),
+				testutil.NewReadyDeployment("vmagent-example-agent-tls", "default"),
 				&corev1.Namespace{
 					ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
 				},
@@ -360,7 +362,7 @@ func TestCreateOrUpdateVMAgent(t *testing.T) {
 				},
 			},
 			predefinedObjects: []runtime.Object{
-				k8stools.NewReadyDeployment("vmagent-example-agent", "default"),
+				testutil.NewReadyDeployment("vmagent-example-agent", "default"),
 			},
 		},
 		{
@@ -388,7 +390,7 @@ func TestCreateOrUpdateVMAgent(t *testing.T) {
 				},
 			},
 			predefinedObjects: []runtime.Object{
-				k8stools.NewReadyDeployment("vmagent-example-agent", "default"),
+				testutil.NewReadyDeployment("vmagent-example-agent", "default"),
 				&corev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{Name: "add-cfg", Namespace: "default"},
 					Data: map[string][]byte{"agent.yaml": []byte(strings.TrimSpace(`
@@ -434,7 +436,7 @@ func TestCreateOrUpdateVMAgent(t *testing.T) {
 			},
 			statefulsetMode: true,
 			predefinedObjects: []runtime.Object{
-				k8stools.NewReadyDeployment("vmagent-example-agent", "default"),
+				testutil.NewReadyDeployment("vmagent-example-agent", "default"),
 			},
 		},
 		{
@@ -603,7 +605,7 @@ func TestCreateOrUpdateVMAgent(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			if tt.args.mustAddPrevSpec {
 				jsonSpec, err := json.Marshal(tt.args.cr.Spec)
 				if err != nil {
@@ -962,7 +964,7 @@ func Test_loadTLSAssets(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 
 			sos := &scrapeObjects{
 				sss:  tt.args.servicescrapes,
@@ -1129,6 +1131,26 @@ func TestBuildRemoteWrites(t *testing.T) {
 			},
 			want: []string{"-remoteWrite.url=localhost:8429,localhost:8431", "-remoteWrite.sendTimeout=10s,15s"},
 		},
+		{
+			name: "test per target proxyURL, headers and forceVMProto",
+			args: args{
+				ssCache: &scrapesSecretsCache{},
+				cr: &vmv1beta1.VMAgent{
+					Spec: vmv1beta1.VMAgentSpec{RemoteWrite: []vmv1beta1.VMAgentRemoteWriteSpec{
+						{
+							URL:          "localhost:8429",
+							ProxyURL:     ptr.To("socks5://proxy:1234"),
+							ForceVMProto: true,
+						},
+						{
+							URL:     "localhost:8431",
+							Headers: []string{"X-Scope-OrgID: 1", "X-Custom: value"},
+						},
+					}},
+				},
+			},
+			want: []string{"-remoteWrite.url=localhost:8429,localhost:8431", "-remoteWrite.proxyURL=socks5://proxy:1234,", "-remoteWrite.headers=,X-Scope-OrgID: 1^^X-Custom: value", "-remoteWrite.forceVMProto=true,false"},
+		},
 		{
 			name: "test multi-tenant",
 			args: args{
@@ -1469,6 +1491,33 @@ func TestBuildRemoteWrites(t *testing.T) {
 				`-remoteWrite.url=localhost:8428,localhost:8429,localhost:8430,localhost:8431,localhost:8432`,
 			},
 		},
+		{
+			name: "test with stream aggr features unsupported by image version",
+			args: args{
+				ssCache: &scrapesSecretsCache{},
+				cr: &vmv1beta1.VMAgent{
+					Spec: vmv1beta1.VMAgentSpec{
+						CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{
+							Image: vmv1beta1.Image{Tag: "v1.99.0"},
+						},
+						RemoteWrite: []vmv1beta1.VMAgentRemoteWriteSpec{
+							{
+								URL: "localhost:8428",
+								StreamAggrConfig: &vmv1beta1.StreamAggrConfig{
+									KeepInput:            true,
+									DropInputLabels:      []string{"pod"},
+									IgnoreFirstIntervals: 2,
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []string{
+				`-remoteWrite.streamAggr.keepInput=true`,
+				`-remoteWrite.url=localhost:8428`,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1634,7 +1683,7 @@ func TestCreateOrUpdateVMAgentService(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cl := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			cl := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			got, err := createOrUpdateVMAgentService(tt.args.ctx, cl, tt.args.cr, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateOrUpdateVMAgentService() error = %v, wantErr %v", err, tt.wantErr)
@@ -1753,7 +1802,7 @@ func TestCreateOrUpdateRelabelConfigsAssets(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cl := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			cl := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			if err := createOrUpdateRelabelConfigsAssets(tt.args.ctx, cl, tt.args.cr, nil); (err != nil) != tt.wantErr {
 				t.Fatalf("CreateOrUpdateRelabelConfigsAssets() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -1955,7 +2004,7 @@ func TestCreateOrUpdateStreamAggrConfig(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cl := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			cl := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			if err := createOrUpdateStreamAggrConfig(tt.args.ctx, cl, tt.args.cr, nil); (err != nil) != tt.wantErr {
 				t.Fatalf("CreateOrUpdateVMAgentStreamAggrConfig() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -2140,6 +2189,33 @@ func TestBuildRemoteWriteSettings(t *testing.T) {
 			},
 			want: []string{"-remoteWrite.tmpDataPath=/tmp/vmagent-remotewrite-data"},
 		},
+		{
+			name: "queues scaled to shards count",
+			args: args{
+				cr: &vmv1beta1.VMAgent{Spec: vmv1beta1.VMAgentSpec{ShardCount: ptr.To(3)}},
+			},
+			want: []string{"-remoteWrite.maxDiskUsagePerURL=1073741824", "-remoteWrite.tmpDataPath=/tmp/vmagent-remotewrite-data", "-remoteWrite.queues=6"},
+		},
+		{
+			name: "minimal queues for many shards",
+			args: args{
+				cr: &vmv1beta1.VMAgent{Spec: vmv1beta1.VMAgentSpec{
+					ShardCount:          ptr.To(20),
+					RemoteWriteSettings: &vmv1beta1.VMAgentRemoteWriteSettings{FlushInterval: ptr.To("5s")},
+				}},
+			},
+			want: []string{"-remoteWrite.flushInterval=5s", "-remoteWrite.maxDiskUsagePerURL=1073741824", "-remoteWrite.tmpDataPath=/tmp/vmagent-remotewrite-data", "-remoteWrite.queues=2"},
+		},
+		{
+			name: "explicit queues with shards",
+			args: args{
+				cr: &vmv1beta1.VMAgent{Spec: vmv1beta1.VMAgentSpec{
+					ShardCount:          ptr.To(4),
+					RemoteWriteSettings: &vmv1beta1.VMAgentRemoteWriteSettings{Queues: ptr.To(int32(10))},
+				}},
+			},
+			want: []string{"-remoteWrite.maxDiskUsagePerURL=1073741824", "-remoteWrite.tmpDataPath=/tmp/vmagent-remotewrite-data", "-remoteWrite.queues=10"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2157,7 +2233,7 @@ func TestMakeSpecForAgentOk(t *testing.T) {
 	f := func(cr *vmv1beta1.VMAgent, sCache *scrapesSecretsCache, wantYaml string) {
 		t.Helper()
 
-		scheme := k8stools.GetTestClientWithObjects(nil).Scheme()
+		scheme := testutil.GetTestClientWithObjects(nil).Scheme()
 		build.AddDefaults(scheme)
 		scheme.Default(cr)
 		// this trick allows to omit empty fields for yaml
@@ -2441,3 +2517,162 @@ containers:
 serviceaccountname: vmagent-agent
 `)
 }
+
+func TestMakeSpecForAgentServiceAccountTokens(t *testing.T) {
+	cr := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		Spec: vmv1beta1.VMAgentSpec{
+			ServiceAccountTokens: []vmv1beta1.VMAgentServiceAccountToken{
+				{Name: "apiserver", Audience: "https://kubernetes.default.svc", ExpirationSeconds: ptr.To[int64](3600)},
+			},
+		},
+	}
+	scheme := testutil.GetTestClientWithObjects(nil).Scheme()
+	build.AddDefaults(scheme)
+	scheme.Default(cr)
+	got, err := makeSpecForVMAgent(cr, &scrapesSecretsCache{})
+	if err != nil {
+		t.Fatalf("not expected error=%q", err)
+	}
+	var tokenVolume *corev1.Volume
+	for i := range got.Volumes {
+		if got.Volumes[i].Name == "sa-token-apiserver" {
+			tokenVolume = &got.Volumes[i]
+		}
+	}
+	if tokenVolume == nil || tokenVolume.Projected == nil {
+		t.Fatalf("expected projected volume sa-token-apiserver, got: %v", got.Volumes)
+	}
+	assert.Equal(t, []corev1.VolumeProjection{{ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+		Audience:          "https://kubernetes.default.svc",
+		ExpirationSeconds: ptr.To[int64](3600),
+		Path:              "token",
+	}}}, tokenVolume.Projected.Sources)
+	var agentMounts []corev1.VolumeMount
+	for _, c := range got.Containers {
+		if c.Name == "vmagent" {
+			agentMounts = c.VolumeMounts
+		}
+	}
+	assert.Contains(t, agentMounts, corev1.VolumeMount{
+		Name:      "sa-token-apiserver",
+		ReadOnly:  true,
+		MountPath: "/var/run/secrets/vmagent/tokens/apiserver",
+	})
+}
+
+func TestMakeSpecForAgentMaxScrapeSize(t *testing.T) {
+	cr := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		Spec: vmv1beta1.VMAgentSpec{
+			MaxScrapeSize: ptr.To("64MiB"),
+		},
+	}
+	scheme := testutil.GetTestClientWithObjects(nil).Scheme()
+	build.AddDefaults(scheme)
+	scheme.Default(cr)
+	got, err := makeSpecForVMAgent(cr, &scrapesSecretsCache{})
+	if err != nil {
+		t.Fatalf("not expected error=%q", err)
+	}
+	var agentArgs []string
+	for _, c := range got.Containers {
+		if c.Name == "vmagent" {
+			agentArgs = c.Args
+		}
+	}
+	assert.Contains(t, agentArgs, "-promscrape.maxScrapeSize=64MiB")
+}
+
+func TestMakeSpecForAgentLifecycle(t *testing.T) {
+	lifecycle := &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Sleep: &corev1.SleepAction{Seconds: 15},
+		},
+	}
+	cr := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		Spec: vmv1beta1.VMAgentSpec{
+			CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+				Lifecycle: lifecycle,
+			},
+		},
+	}
+	scheme := testutil.GetTestClientWithObjects(nil).Scheme()
+	build.AddDefaults(scheme)
+	scheme.Default(cr)
+	got, err := makeSpecForVMAgent(cr, &scrapesSecretsCache{})
+	if err != nil {
+		t.Fatalf("not expected error=%q", err)
+	}
+	for _, c := range got.Containers {
+		if c.Name == "vmagent" {
+			assert.Equal(t, lifecycle, c.Lifecycle)
+		} else {
+			assert.Nil(t, c.Lifecycle, "unexpected lifecycle for container=%q", c.Name)
+		}
+	}
+}
+
+func TestBuildScaledObject(t *testing.T) {
+	f := func(cr *vmv1beta1.VMAgent, wantTargetRef map[string]any, wantQuery string) {
+		t.Helper()
+		so := buildScaledObject(cr)
+		assert.Equal(t, cr.PrefixedName(), so.GetName())
+		targetRef, _, _ := unstructured.NestedMap(so.Object, "spec", "scaleTargetRef")
+		assert.Equal(t, wantTargetRef, targetRef)
+		triggers, _, _ := unstructured.NestedSlice(so.Object, "spec", "triggers")
+		assert.Len(t, triggers, 1)
+		query, _, _ := unstructured.NestedString(triggers[0].(map[string]any), "metadata", "query")
+		assert.Equal(t, wantQuery, query)
+	}
+	defaultQuery := `sum(vm_persistentqueue_bytes_pending{job="vmagent-agent",namespace="default"})`
+
+	// deployment replicas
+	f(&vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		Spec: vmv1beta1.VMAgentSpec{
+			KEDA: &vmv1beta1.VMAgentKEDA{MaxReplicaCount: 3, ServerAddress: "http://vmsingle:8429"},
+		},
+	}, map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"name":       "vmagent-agent",
+	}, defaultQuery)
+
+	// statefulset replicas with custom query
+	f(&vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		Spec: vmv1beta1.VMAgentSpec{
+			StatefulMode: true,
+			KEDA: &vmv1beta1.VMAgentKEDA{
+				Target:          "replicas",
+				MaxReplicaCount: 3,
+				ServerAddress:   "http://vmsingle:8429",
+				Query:           "sum(custom)",
+			},
+		},
+	}, map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "StatefulSet",
+		"name":       "vmagent-agent",
+	}, "sum(custom)")
+
+	// shards
+	f(&vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		Spec: vmv1beta1.VMAgentSpec{
+			StatefulMode: true,
+			ShardCount:   ptr.To(2),
+			KEDA: &vmv1beta1.VMAgentKEDA{
+				Target:          "shards",
+				MaxReplicaCount: 4,
+				ServerAddress:   "http://vmsingle:8429",
+			},
+		},
+	}, map[string]any{
+		"apiVersion": "operator.victoriametrics.com/v1beta1",
+		"kind":       "VMAgent",
+		"name":       "agent",
+	}, defaultQuery)
+}
@@ -2,17 +2,23 @@ package vmalert
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/configstat"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/tracing"
 	"github.com/ghodss/yaml"
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
@@ -61,48 +67,36 @@ groups:
 `
 
 // CreateOrUpdateRuleConfigMaps conditionally selects vmrules and stores content at configmaps
-func CreateOrUpdateRuleConfigMaps(ctx context.Context, cr *vmv1beta1.VMAlert, rclient client.Client) ([]string, error) {
+func CreateOrUpdateRuleConfigMaps(ctx context.Context, cr *vmv1beta1.VMAlert, rclient client.Client) (_ []string, resultErr error) {
+	ctx, span := tracing.StartForObject(ctx, "vmalert.generateRules", cr)
+	defer func() { tracing.End(span, resultErr) }()
 	// fast path
 	if cr.IsUnmanaged() {
 		return nil, nil
 	}
+	startTime := time.Now()
 	newRules, err := selectRulesUpdateStatus(ctx, cr, rclient)
 	if err != nil {
 		return nil, err
 	}
+	var rulesSize int
+	for _, content := range newRules {
+		rulesSize += len(content)
+	}
+	configstat.ObserveGeneration(ctx, "vmalert", cr, startTime, rulesSize)
 
-	newConfigMaps := makeRulesConfigMaps(cr, newRules)
-	currentCMs := make([]corev1.ConfigMap, len(newConfigMaps))
-	for idx, cm := range newConfigMaps {
-		var existCM corev1.ConfigMap
-		if err := rclient.Get(ctx, types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name}, &existCM); err != nil {
-			if errors.IsNotFound(err) {
-				continue
-			}
-			return nil, err
-		}
-		currentCMs[idx] = existCM
+	var cmList corev1.ConfigMapList
+	if err := rclient.List(ctx, &cmList, cr.RulesConfigMapSelector()); err != nil {
+		return nil, fmt.Errorf("cannot list rules ConfigMaps: %w", err)
 	}
+	currentCMs := cmList.Items
+	newConfigMaps := makeRulesConfigMaps(cr, newRules, currentCMs)
 
 	newConfigMapNames := make([]string, 0, len(newConfigMaps))
 	for _, cm := range newConfigMaps {
 		newConfigMapNames = append(newConfigMapNames, cm.Name)
 	}
 
-	if len(currentCMs) == 0 {
-		for _, cm := range newConfigMaps {
-			logger.WithContext(ctx).Info(fmt.Sprintf("creating new ConfigMap %s for rules", cm.Name))
-			err := rclient.Create(ctx, &cm)
-			if err != nil {
-				if errors.IsAlreadyExists(err) {
-					continue
-				}
-				return nil, fmt.Errorf("failed to create Configmap: %s, err: %w", cm.Name, err)
-			}
-		}
-		return newConfigMapNames, nil
-	}
-
 	// sort
 	sort.Strings(newConfigMapNames)
 	sort.Slice(currentCMs, func(i, j int) bool {
@@ -114,6 +108,16 @@ func CreateOrUpdateRuleConfigMaps(ctx context.Context, cr *vmv1beta1.VMAlert, rc
 
 	// compute diff for current and needed rules configmaps.
 	toCreate, toUpdate := rulesCMDiff(currentCMs, newConfigMaps)
+	// ConfigMaps removed by compaction could be still mounted to vmalert pods,
+	// clean its content in order to prevent loading of moved rules twice.
+	// They're deleted by DeleteStaleRuleConfigMaps after vmalert deployment update
+	for _, cm := range staleRuleConfigMaps(cr, currentCMs, newConfigMapNames) {
+		if len(cm.Data) == 0 {
+			continue
+		}
+		cm.Data = nil
+		toUpdate = append(toUpdate, cm)
+	}
 	for _, cm := range toCreate {
 		logger.WithContext(ctx).Info(fmt.Sprintf("creating additional configmap=%s for rules", cm.Name))
 		err = rclient.Create(ctx, &cm)
@@ -123,6 +127,7 @@ func CreateOrUpdateRuleConfigMaps(ctx context.Context, cr *vmv1beta1.VMAlert, rc
 			}
 			return nil, fmt.Errorf("failed to create new rules Configmap: %s, err: %w", cm.Name, err)
 		}
+		events.Normal(cr, events.ReasonCreated, fmt.Sprintf("created ConfigMap %s for rules", cm.Name))
 	}
 	for _, cm := range toUpdate {
 		if err := finalize.FreeIfNeeded(ctx, rclient, &cm); err != nil {
@@ -133,6 +138,7 @@ func CreateOrUpdateRuleConfigMaps(ctx context.Context, cr *vmv1beta1.VMAlert, rc
 		if err != nil {
 			return nil, fmt.Errorf("failed to update rules Configmap: %s, err: %w", cm.Name, err)
 		}
+		events.Normal(cr, events.ReasonConfigUpdated, fmt.Sprintf("updated rules ConfigMap %s", cm.Name))
 	}
 
 	if len(toCreate) > 0 || len(toUpdate) > 0 {
@@ -143,10 +149,76 @@ func CreateOrUpdateRuleConfigMaps(ctx context.Context, cr *vmv1beta1.VMAlert, rc
 			logger.WithContext(ctx).Error(err, "failed to update vmalert pod cm-sync annotation")
 		}
 	}
+	if err := updateRuleConfigMapsStatus(ctx, rclient, cr, newConfigMaps); err != nil {
+		return nil, err
+	}
 
 	return newConfigMapNames, nil
 }
 
+// DeleteStaleRuleConfigMaps removes rules ConfigMaps, which are not used by vmalert anymore
+// it must be called after vmalert deployment update, since ConfigMaps are mounted to pods
+func DeleteStaleRuleConfigMaps(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert, configMapNames []string) error {
+	if cr.IsUnmanaged() {
+		return nil
+	}
+	var cmList corev1.ConfigMapList
+	if err := rclient.List(ctx, &cmList, cr.RulesConfigMapSelector()); err != nil {
+		return fmt.Errorf("cannot list rules ConfigMaps: %w", err)
+	}
+	for _, cm := range staleRuleConfigMaps(cr, cmList.Items, configMapNames) {
+		logger.WithContext(ctx).Info(fmt.Sprintf("removing unused rules ConfigMap %s", cm.Name))
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &cm); err != nil {
+			return fmt.Errorf("cannot remove unused rules ConfigMap=%s: %w", cm.Name, err)
+		}
+	}
+	return nil
+}
+
+// staleRuleConfigMaps returns rules ConfigMaps, which are missing at configMapNames
+func staleRuleConfigMaps(cr *vmv1beta1.VMAlert, currentCMs []corev1.ConfigMap, configMapNames []string) []corev1.ConfigMap {
+	var stale []corev1.ConfigMap
+	for _, cm := range currentCMs {
+		if _, ok := ruleConfigMapIdx(cr, cm.Name); !ok {
+			continue
+		}
+		if !slices.Contains(configMapNames, cm.Name) {
+			stale = append(stale, cm)
+		}
+	}
+	return stale
+}
+
+// updateRuleConfigMapsStatus reflects layout of rules ConfigMaps at status.ruleConfigMaps
+func updateRuleConfigMapsStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert, cms []corev1.ConfigMap) error {
+	statuses := make([]vmv1beta1.VMAlertRuleConfigMapStatus, 0, len(cms))
+	for _, cm := range cms {
+		statuses = append(statuses, vmv1beta1.VMAlertRuleConfigMapStatus{
+			Name:  cm.Name,
+			Files: int32(len(cm.Data)),
+			Size:  int32(bucketSize(cm.Data)),
+		})
+	}
+	if equality.Semantic.DeepEqual(statuses, cr.Status.RuleConfigMaps) {
+		return nil
+	}
+	patch := map[string]any{
+		"status": map[string]any{
+			"ruleConfigMaps": statuses,
+		},
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("BUG: cannot serialize rule ConfigMaps status patch: %w", err)
+	}
+	objToPatch := cr.DeepCopy()
+	if err := rclient.Status().Patch(ctx, objToPatch, client.RawPatch(types.MergePatchType, data)); err != nil {
+		return fmt.Errorf("cannot update rule ConfigMaps status: %w", err)
+	}
+	cr.Status.RuleConfigMaps = statuses
+	return nil
+}
+
 // rulesCMDiff - calculates diff between existing at k8s (current) configmaps with rules
 // and generated by operator (new) configmaps.
 // Configmaps are grouped by operations, that must be performed over them.
@@ -228,6 +300,7 @@ func selectRulesUpdateStatus(ctx context.Context, cr *vmv1beta1.VMAlert, rclient
 		rules["default-vmalert.yaml"] = defAlert
 	}
 	badConfigsTotal.Add(float64(len(badRules)))
+	configstat.SetSelectedObjects("vmalert", cr, "VMRule", len(vmRules))
 
 	parentObject := fmt.Sprintf("%s.%s.vmalert", cr.Name, cr.Namespace)
 	if err := reconcile.StatusForChildObjects(ctx, rclient, parentObject, vmRules); err != nil {
@@ -236,6 +309,9 @@ func selectRulesUpdateStatus(ctx context.Context, cr *vmv1beta1.VMAlert, rclient
 	if err := reconcile.StatusForChildObjects(ctx, rclient, parentObject, badRules); err != nil {
 		return nil, fmt.Errorf("cannot update bad rules statuses: %w", err)
 	}
+	if err := updateRuleSelectionStatus(ctx, rclient, cr, len(namespacedNames), vmRules, badRules); err != nil {
+		return nil, err
+	}
 
 	if len(namespacedNames) > 0 {
 		logger.WithContext(ctx).Info(fmt.Sprintf("selected Rules count=%d, invalid rules count=%d, namespaced names %s",
@@ -245,6 +321,58 @@ func selectRulesUpdateStatus(ctx context.Context, cr *vmv1beta1.VMAlert, rclient
 	return rules, nil
 }
 
+// maxRuleSelectionStatusItems limits number of rules listed at status
+// in order to keep object size below api-server limits
+const maxRuleSelectionStatusItems = 100
+
+// updateRuleSelectionStatus reflects selected and invalid VMRule objects at status.ruleSelection
+func updateRuleSelectionStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert, selected int, vmRules, badRules []*vmv1beta1.VMRule) error {
+	ruleNames := func(src []*vmv1beta1.VMRule) []string {
+		if len(src) == 0 {
+			return nil
+		}
+		names := make([]string, 0, len(src))
+		for _, r := range src {
+			names = append(names, fmt.Sprintf("%s/%s", r.Namespace, r.Name))
+		}
+		sort.Strings(names)
+		if len(names) > maxRuleSelectionStatusItems {
+			names = names[:maxRuleSelectionStatusItems]
+		}
+		return names
+	}
+	rs := &vmv1beta1.VMAlertRuleSelectionStatus{
+		Selected:     int32(selected),
+		Invalid:      int32(len(badRules)),
+		Rules:        ruleNames(vmRules),
+		InvalidRules: ruleNames(badRules),
+	}
+	if equality.Semantic.DeepEqual(rs, cr.Status.RuleSelection) {
+		return nil
+	}
+	// explicitly set empty lists to null in order to remove them with merge patch
+	patch := map[string]any{
+		"status": map[string]any{
+			"ruleSelection": map[string]any{
+				"selected":     rs.Selected,
+				"invalid":      rs.Invalid,
+				"rules":        rs.Rules,
+				"invalidRules": rs.InvalidRules,
+			},
+		},
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("BUG: cannot serialize rule selection status patch: %w", err)
+	}
+	objToPatch := cr.DeepCopy()
+	if err := rclient.Status().Patch(ctx, objToPatch, client.RawPatch(types.MergePatchType, data)); err != nil {
+		return fmt.Errorf("cannot update rule selection status: %w", err)
+	}
+	cr.Status.RuleSelection = rs
+	return nil
+}
+
 func generateContent(promRule vmv1beta1.VMRuleSpec, enforcedNsLabel, ns string) (string, error) {
 	if enforcedNsLabel != "" {
 		for gi, group := range promRule.Groups {
@@ -266,15 +394,56 @@ func generateContent(promRule vmv1beta1.VMRuleSpec, enforcedNsLabel, ns string)
 // makeRulesConfigMaps takes a VMAlert configuration and rule files and
 // returns a list of Kubernetes ConfigMaps to be later on mounted
 // If the total size of rule files exceeds the Kubernetes ConfigMap limit,
-// they are split up via the simple first-fit [1] bin packing algorithm. In the
-// future this can be replaced by a more sophisticated algorithm, but for now
-// simplicity should be sufficient.
+// they are split up via the simple first-fit [1] bin packing algorithm.
+// Rule files are kept at the ConfigMaps from currentCMs while they fit into it,
+// so change of a single rule file updates only a single ConfigMap.
+// If rule files could be packed into smaller number of ConfigMaps, for instance if some of ConfigMaps become empty,
+// they're packed from scratch in order to reduce number of ConfigMaps mounted to vmalert.
 // [1] https://en.wikipedia.org/wiki/Bin_packing_problem#First-fit_algorithm
-func makeRulesConfigMaps(cr *vmv1beta1.VMAlert, ruleFiles map[string]string) []corev1.ConfigMap {
-	buckets := []map[string]string{
-		{},
+func makeRulesConfigMaps(cr *vmv1beta1.VMAlert, ruleFiles map[string]string, currentCMs []corev1.ConfigMap) []corev1.ConfigMap {
+	prevBuckets := make(map[string]int)
+	prevContent := make(map[string]string)
+	bucketsCount := 1
+	for _, cm := range currentCMs {
+		idx, ok := ruleConfigMapIdx(cr, cm.Name)
+		if !ok {
+			continue
+		}
+		if idx >= bucketsCount {
+			bucketsCount = idx + 1
+		}
+		for filename, content := range cm.Data {
+			prevBuckets[filename] = idx
+			prevContent[filename] = content
+		}
+	}
+	buckets := packRuleFiles(ruleFiles, prevBuckets, prevContent, bucketsCount)
+	if compacted := packRuleFiles(ruleFiles, nil, nil, 1); len(compacted) < len(buckets) {
+		buckets = compacted
+	}
+
+	ruleFileConfigMaps := make([]corev1.ConfigMap, 0, len(buckets))
+	for i, bucket := range buckets {
+		cm := makeRulesConfigMap(cr, bucket)
+		cm.Name = cm.Name + "-" + strconv.Itoa(i)
+		ruleFileConfigMaps = append(ruleFileConfigMaps, cm)
+	}
+
+	return ruleFileConfigMaps
+}
+
+// packRuleFiles splits rule files into buckets with size limited by MaxConfigMapDataSize
+// files are kept at prevBuckets if content wasn't changed and it fits into the bucket
+func packRuleFiles(ruleFiles map[string]string, prevBuckets map[string]int, prevContent map[string]string, bucketsCount int) []map[string]string {
+	buckets := make([]map[string]string, bucketsCount)
+	sizes := make([]int, bucketsCount)
+	for i := range buckets {
+		buckets[i] = map[string]string{}
+	}
+	addToBucket := func(idx int, filename string) {
+		buckets[idx][filename] = ruleFiles[filename]
+		sizes[idx] += len(ruleFiles[filename])
 	}
-	currBucketIndex := 0
 
 	// To make bin packing algorithm deterministic, sort ruleFiles filenames and
 	// iterate over filenames instead of ruleFiles map (not deterministic).
@@ -283,24 +452,40 @@ func makeRulesConfigMaps(cr *vmv1beta1.VMAlert, ruleFiles map[string]string) []c
 		fileNames = append(fileNames, n)
 	}
 	sort.Strings(fileNames)
+	// place unchanged files first, so changed file is moved to another bucket
+	// if it doesn't fit into the previous one anymore
+	isUnchanged := func(filename string) bool {
+		content, ok := prevContent[filename]
+		return ok && content == ruleFiles[filename]
+	}
+	sort.SliceStable(fileNames, func(i, j int) bool {
+		return isUnchanged(fileNames[i]) && !isUnchanged(fileNames[j])
+	})
 
+	var unassigned []string
 	for _, filename := range fileNames {
-		// If rule file doesn't fit into current bucket, create new bucket.
-		if bucketSize(buckets[currBucketIndex])+len(ruleFiles[filename]) > vmv1beta1.MaxConfigMapDataSize {
+		if idx, ok := prevBuckets[filename]; ok && sizes[idx]+len(ruleFiles[filename]) <= vmv1beta1.MaxConfigMapDataSize {
+			addToBucket(idx, filename)
+			continue
+		}
+		unassigned = append(unassigned, filename)
+	}
+	sort.Strings(unassigned)
+	for _, filename := range unassigned {
+		idx := 0
+		for ; idx < len(buckets); idx++ {
+			// empty bucket accepts file of any size
+			if sizes[idx] == 0 || sizes[idx]+len(ruleFiles[filename]) <= vmv1beta1.MaxConfigMapDataSize {
+				break
+			}
+		}
+		if idx == len(buckets) {
 			buckets = append(buckets, map[string]string{})
-			currBucketIndex++
+			sizes = append(sizes, 0)
 		}
-		buckets[currBucketIndex][filename] = ruleFiles[filename]
+		addToBucket(idx, filename)
 	}
-
-	ruleFileConfigMaps := make([]corev1.ConfigMap, 0, len(buckets))
-	for i, bucket := range buckets {
-		cm := makeRulesConfigMap(cr, bucket)
-		cm.Name = cm.Name + "-" + strconv.Itoa(i)
-		ruleFileConfigMaps = append(ruleFileConfigMaps, cm)
-	}
-
-	return ruleFileConfigMaps
+	return buckets
 }
 
 func bucketSize(bucket map[string]string) int {
@@ -334,6 +519,19 @@ func ruleConfigMapName(vmName string) string {
 	return "vm-" + vmName + "-rulefiles"
 }
 
+// ruleConfigMapIdx returns index of rules ConfigMap with given name
+func ruleConfigMapIdx(cr *vmv1beta1.VMAlert, name string) (int, bool) {
+	suffix, ok := strings.CutPrefix(name, ruleConfigMapName(cr.Name)+"-")
+	if !ok {
+		return 0, false
+	}
+	idx, err := strconv.Atoi(suffix)
+	if err != nil || idx < 0 || strconv.Itoa(idx) != suffix {
+		return 0, false
+	}
+	return idx, true
+}
+
 // deduplicateRules - takes list of vmRules and modifies it
 // by removing duplicates.
 // possible duplicates:
@@ -2,6 +2,7 @@ package vmalert
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -12,11 +13,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 )
 
 func Test_selectNamespaces(t *testing.T) {
@@ -50,7 +53,7 @@ func Test_selectNamespaces(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedNs)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedNs)
 			got, err := k8stools.SelectNamespaces(context.TODO(), fclient, tt.args.selector)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("selectNamespaces() error = %v, wantErr %v", err, tt.wantErr)
@@ -78,7 +81,7 @@ func TestSelectRules(t *testing.T) {
 		{
 			name: "select default rule",
 			args: args{
-				p: &vmv1beta1.VMAlert{},
+				p: &vmv1beta1.VMAlert{ObjectMeta: metav1.ObjectMeta{Name: "test-vm-alert", Namespace: "monitor"}},
 				l: logf.Log.WithName("unit-test"),
 			},
 			want: map[string]string{
@@ -273,7 +276,7 @@ groups:
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(append(tt.predefinedObjects, tt.args.p))
 			got, err := selectRulesUpdateStatus(ctx, tt.args.p, fclient)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SelectRules() error = %v, wantErr %v", err, tt.wantErr)
@@ -309,6 +312,58 @@ groups:
 	}
 }
 
+func TestRuleSelectionStatus(t *testing.T) {
+	f := func(predefinedObjects []runtime.Object, want *vmv1beta1.VMAlertRuleSelectionStatus) {
+		t.Helper()
+		ctx := context.Background()
+		cr := &vmv1beta1.VMAlert{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-vm-alert", Namespace: "default"},
+			Spec: vmv1beta1.VMAlertSpec{
+				RuleSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			},
+		}
+		fclient := testutil.GetTestClientWithObjects(append(predefinedObjects, cr))
+		if _, err := selectRulesUpdateStatus(ctx, cr, fclient); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		assert.Equal(t, want, cr.Status.RuleSelection)
+		var got vmv1beta1.VMAlert
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, &got); err != nil {
+			t.Fatalf("cannot get vmalert: %s", err)
+		}
+		assert.Equal(t, want, got.Status.RuleSelection)
+	}
+	validRule := func(name string, labels map[string]string) *vmv1beta1.VMRule {
+		return &vmv1beta1.VMRule{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Spec: vmv1beta1.VMRuleSpec{Groups: []vmv1beta1.RuleGroup{{Name: name, Rules: []vmv1beta1.Rule{
+				{Alert: "alerting", Expr: "10"},
+			}}}},
+		}
+	}
+
+	// no rules matched selector
+	f([]runtime.Object{validRule("other-team", map[string]string{"team": "b"})}, &vmv1beta1.VMAlertRuleSelectionStatus{})
+
+	// valid and invalid rules
+	f([]runtime.Object{
+		validRule("rule-2", map[string]string{"team": "a"}),
+		validRule("rule-1", map[string]string{"team": "a"}),
+		validRule("other-team", map[string]string{"team": "b"}),
+		&vmv1beta1.VMRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "bad-rule", Namespace: "default", Labels: map[string]string{"team": "a"}},
+			Spec: vmv1beta1.VMRuleSpec{Groups: []vmv1beta1.RuleGroup{{Name: "bad", Rules: []vmv1beta1.Rule{
+				{Alert: "alerting", Expr: "sum(("},
+			}}}},
+		},
+	}, &vmv1beta1.VMAlertRuleSelectionStatus{
+		Selected:     3,
+		Invalid:      1,
+		Rules:        []string{"default/rule-1", "default/rule-2"},
+		InvalidRules: []string{"default/bad-rule"},
+	})
+}
+
 func TestCreateOrUpdateRuleConfigMaps(t *testing.T) {
 	type args struct {
 		cr *vmv1beta1.VMAlert
@@ -344,7 +399,7 @@ func TestCreateOrUpdateRuleConfigMaps(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(append(tt.predefinedObjects, tt.args.cr))
 			got, err := CreateOrUpdateRuleConfigMaps(context.TODO(), tt.args.cr, fclient)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateOrUpdateRuleConfigMaps() error = %v, wantErr %v", err, tt.wantErr)
@@ -353,6 +408,9 @@ func TestCreateOrUpdateRuleConfigMaps(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("CreateOrUpdateRuleConfigMaps() got = %v, want %v", got, tt.want)
 			}
+			if len(tt.args.cr.Status.RuleConfigMaps) != len(tt.want) {
+				t.Errorf("unexpected status.ruleConfigMaps: %v", tt.args.cr.Status.RuleConfigMaps)
+			}
 		})
 	}
 }
@@ -484,6 +542,111 @@ func Test_deduplicateRules(t *testing.T) {
 	}
 }
 
+func TestMakeRulesConfigMaps(t *testing.T) {
+	defer func(size int) { vmv1beta1.MaxConfigMapDataSize = size }(vmv1beta1.MaxConfigMapDataSize)
+	vmv1beta1.MaxConfigMapDataSize = 10
+	cr := &vmv1beta1.VMAlert{ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default"}}
+	f := func(ruleFiles map[string]string, current, want []map[string]string) {
+		t.Helper()
+		var currentCMs []v1.ConfigMap
+		for i, data := range current {
+			currentCMs = append(currentCMs, v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("vm-base-rulefiles-%d", i)},
+				Data:       data,
+			})
+		}
+		got := makeRulesConfigMaps(cr, ruleFiles, currentCMs)
+		gotData := make([]map[string]string, 0, len(got))
+		for i, cm := range got {
+			if cm.Name != fmt.Sprintf("vm-base-rulefiles-%d", i) {
+				t.Fatalf("unexpected ConfigMap name at idx=%d: %q", i, cm.Name)
+			}
+			gotData = append(gotData, cm.Data)
+		}
+		assert.Equal(t, want, gotData)
+	}
+
+	// first-fit without current ConfigMaps
+	f(map[string]string{"a": "aaaa", "b": "bbbb", "c": "cccc"}, nil,
+		[]map[string]string{{"a": "aaaa", "b": "bbbb"}, {"c": "cccc"}})
+
+	// new file is added to the first ConfigMap with enough space
+	f(map[string]string{"a": "aaaa", "b": "bbbb", "c": "cccc", "d": "dd"},
+		[]map[string]string{{"a": "aaaa", "b": "bbbb"}, {"c": "cccc"}},
+		[]map[string]string{{"a": "aaaa", "b": "bbbb", "d": "dd"}, {"c": "cccc"}})
+
+	// changed file is moved, unchanged files keep ConfigMaps
+	f(map[string]string{"a": "aaaaaaa", "b": "bbbb", "c": "ccc"},
+		[]map[string]string{{"a": "aaaa", "b": "bbbb"}, {"c": "ccc"}},
+		[]map[string]string{{"b": "bbbb"}, {"c": "ccc", "a": "aaaaaaa"}})
+
+	// files are packed from scratch if it reduces number of ConfigMaps
+	f(map[string]string{"a": "aaaaaaa", "b": "bbbb", "c": "cccc"},
+		[]map[string]string{{"a": "aaaa", "b": "bbbb"}, {"c": "cccc"}},
+		[]map[string]string{{"a": "aaaaaaa"}, {"b": "bbbb", "c": "cccc"}})
+
+	// empty ConfigMaps are removed
+	f(map[string]string{"c": "cccc"},
+		[]map[string]string{{"a": "aaaa", "b": "bbbb"}, {"c": "cccc"}},
+		[]map[string]string{{"c": "cccc"}})
+	f(map[string]string{"a": "aaaa", "c": "cccc", "d": "dddd"},
+		[]map[string]string{{"a": "aaaa", "b": "bbbb"}, {"c": "cccc"}, {"d": "dddd"}},
+		[]map[string]string{{"a": "aaaa", "c": "cccc"}, {"d": "dddd"}})
+
+	// single empty ConfigMap is kept
+	f(map[string]string{}, []map[string]string{{"a": "aaaa"}}, []map[string]string{{}})
+
+	// file larger than limit gets its own ConfigMap
+	f(map[string]string{"a": "aaaa", "big": "bbbbbbbbbbbb"}, nil,
+		[]map[string]string{{"a": "aaaa"}, {"big": "bbbbbbbbbbbb"}})
+}
+
+func TestStaleRuleConfigMaps(t *testing.T) {
+	cr := &vmv1beta1.VMAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default"},
+		Spec:       vmv1beta1.VMAlertSpec{SelectAllByDefault: true},
+	}
+	cm := func(name string, data map[string]string) *v1.ConfigMap {
+		c := makeRulesConfigMap(cr, data)
+		c.Name = name
+		return &c
+	}
+	fclient := testutil.GetTestClientWithObjects([]runtime.Object{
+		cr,
+		cm("vm-base-rulefiles-0", map[string]string{"a.yaml": "groups: []"}),
+		cm("vm-base-rulefiles-1", map[string]string{"b.yaml": "groups: []"}),
+		cm("vm-base-rulefiles-2", map[string]string{"c.yaml": "groups: []"}),
+	})
+	ctx := context.Background()
+	names, err := CreateOrUpdateRuleConfigMaps(ctx, cr, fclient)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, []string{"vm-base-rulefiles-0"}, names)
+
+	// stale ConfigMaps are cleaned, but kept until vmalert deployment update
+	var stale v1.ConfigMap
+	for _, name := range []string{"vm-base-rulefiles-1", "vm-base-rulefiles-2"} {
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, &stale); err != nil {
+			t.Fatalf("cannot get ConfigMap=%s: %s", name, err)
+		}
+		assert.Empty(t, stale.Data)
+	}
+
+	if err := DeleteStaleRuleConfigMaps(ctx, fclient, cr, names); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var cmList v1.ConfigMapList
+	if err := fclient.List(ctx, &cmList, cr.RulesConfigMapSelector()); err != nil {
+		t.Fatalf("cannot list ConfigMaps: %s", err)
+	}
+	var got []string
+	for _, c := range cmList.Items {
+		got = append(got, c.Name)
+	}
+	assert.Equal(t, []string{"vm-base-rulefiles-0"}, got)
+}
+
 func Test_rulesCMDiff(t *testing.T) {
 	type args struct {
 		currentCMs []v1.ConfigMap
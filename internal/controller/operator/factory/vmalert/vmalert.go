@@ -2,6 +2,7 @@ package vmalert
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path"
 	"sort"
@@ -15,9 +16,12 @@ import (
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -48,9 +52,11 @@ func buildRemoteSecretKey(source, suffix string) string {
 func createOrUpdateVMAlertService(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMAlert) (*corev1.Service, error) {
 
 	var prevService, prevAdditionalService *corev1.Service
+	var prevAdditionalServices []*corev1.Service
 	if prevCR != nil {
 		prevService = build.Service(prevCR, prevCR.Spec.Port, nil)
 		prevAdditionalService = build.AdditionalServiceFromDefault(prevService, prevCR.Spec.ServiceSpec)
+		prevAdditionalServices = build.AdditionalServicesFromDefault(prevService, prevCR.Spec.AdditionalServices)
 	}
 
 	newService := build.Service(cr, cr.Spec.Port, nil)
@@ -68,6 +74,10 @@ func createOrUpdateVMAlertService(ctx context.Context, rclient client.Client, cr
 		return nil, err
 	}
 
+	if err := reconcile.Services(ctx, rclient, build.AdditionalServicesFromDefault(newService, cr.Spec.AdditionalServices), prevAdditionalServices); err != nil {
+		return nil, fmt.Errorf("cannot reconcile additional services for vmalert: %w", err)
+	}
+
 	if err := reconcile.Service(ctx, rclient, newService, prevService); err != nil {
 		return nil, fmt.Errorf("cannot reconcile service for vmalert: %w", err)
 	}
@@ -165,11 +175,16 @@ func CreateOrUpdateVMAlert(ctx context.Context, cr *vmv1beta1.VMAlert, rclient c
 	}
 
 	if !ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
-		err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(svc, cr))
+		err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithDefaults(svc, cr, build.ServiceScrapeDefaults{}.WithServerTLS(cr.Spec.ServerTLS, svc)))
 		if err != nil {
 			return fmt.Errorf("cannot create vmservicescrape: %w", err)
 		}
 	}
+	if ptr.Deref(cr.Spec.SelfMonitoringRules, false) {
+		if err := reconcile.VMRuleForCRD(ctx, rclient, build.SelfMonitoringVMRule(svc, svc.Name, "vmalert")); err != nil {
+			return fmt.Errorf("cannot create self-monitoring VMRule for vmalert: %w", err)
+		}
+	}
 
 	if cr.Spec.PodDisruptionBudget != nil {
 		var prevPDB *policyv1.PodDisruptionBudget
@@ -180,11 +195,41 @@ func CreateOrUpdateVMAlert(ctx context.Context, cr *vmv1beta1.VMAlert, rclient c
 			return fmt.Errorf("cannot update pod disruption budget for vmalert: %w", err)
 		}
 	}
+	if cr.Spec.NetworkPolicy.IsEnabled() {
+		var prevNP *networkingv1.NetworkPolicy
+		if prevCR != nil && prevCR.Spec.NetworkPolicy.IsEnabled() {
+			prevNP = build.NetworkPolicy(prevCR, prevCR.Spec.NetworkPolicy, nil)
+		}
+		if err := reconcile.NetworkPolicy(ctx, rclient, build.NetworkPolicy(cr, cr.Spec.NetworkPolicy, nil), prevNP); err != nil {
+			return fmt.Errorf("cannot update network policy for vmalert: %w", err)
+		}
+	}
+	if cr.Spec.VPA != nil {
+		var prevVPA *unstructured.Unstructured
+		if prevCR != nil && prevCR.Spec.VPA != nil {
+			prevVPA = buildVPA(prevCR)
+		}
+		if err := reconcile.VPA(ctx, rclient, buildVPA(cr), prevVPA); err != nil {
+			return fmt.Errorf("cannot update vertical pod autoscaler for vmalert: %w", err)
+		}
+	}
+	if cr.Spec.GrafanaDashboard != nil {
+		var prevGD client.Object
+		if prevCR != nil && prevCR.Spec.GrafanaDashboard != nil {
+			prevGD = build.GrafanaDashboard(prevCR, prevCR.GrafanaDashboardName(), prevCR.Spec.GrafanaDashboard, "vmalert", prevCR.Spec.Image.Tag)
+		}
+		if err := reconcile.GrafanaDashboard(ctx, rclient, build.GrafanaDashboard(cr, cr.GrafanaDashboardName(), cr.Spec.GrafanaDashboard, "vmalert", cr.Spec.Image.Tag), prevGD); err != nil {
+			return fmt.Errorf("cannot update grafana dashboard for vmalert: %w", err)
+		}
+	}
 
 	err = createOrUpdateTLSAssetsForVMAlert(ctx, rclient, cr, prevCR)
 	if err != nil {
 		return err
 	}
+	if err := reconcile.ServerTLSSecret(ctx, rclient, cr.Spec.ServerTLS, metav1.ObjectMeta{Namespace: cr.Namespace, Labels: cr.AllLabels(), OwnerReferences: cr.AsOwner()}, cr.PrefixedName()); err != nil {
+		return fmt.Errorf("cannot reconcile serverTLS secret for vmalert: %w", err)
+	}
 	var prevDeploy *appsv1.Deployment
 	if prevCR != nil {
 		prevDeploy, err = newDeployForVMAlert(prevCR, cmNames, remoteSecrets)
@@ -221,6 +266,9 @@ func newDeployForVMAlert(cr *vmv1beta1.VMAlert, ruleConfigMapNames []string, rem
 		Spec: *generatedSpec,
 	}
 	build.DeploymentAddCommonParams(deploy, ptr.Deref(cr.Spec.UseStrictSecurity, false), &cr.Spec.CommonApplicationDeploymentParams)
+	if err := build.PodTemplateAddPatches(&deploy.Spec.Template, cr.Spec.PodTemplatePatches); err != nil {
+		return nil, err
+	}
 	return deploy, nil
 }
 
@@ -281,6 +329,7 @@ func vmAlertSpecGen(cr *vmv1beta1.VMAlert, ruleConfigMapNames []string, remoteSe
 	)
 
 	volumes, volumeMounts = cr.Spec.License.MaybeAddToVolumes(volumes, volumeMounts, vmv1beta1.SecretsDir)
+	volumes, volumeMounts = cr.Spec.ServerTLS.MaybeAddToVolumes(volumes, volumeMounts, vmv1beta1.ServerTLSDir)
 
 	if cr.Spec.NotifierConfigRef != nil {
 		volumes = append(volumes, corev1.Volume{
@@ -360,6 +409,7 @@ func vmAlertSpecGen(cr *vmv1beta1.VMAlert, ruleConfigMapNames []string, remoteSe
 		VolumeMounts:             volumeMounts,
 		Resources:                cr.Spec.Resources,
 		Env:                      envs,
+		Lifecycle:                cr.Spec.Lifecycle,
 		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 	}
 	vmalertContainer = build.Probe(vmalertContainer, cr)
@@ -369,11 +419,12 @@ func vmAlertSpecGen(cr *vmv1beta1.VMAlert, ruleConfigMapNames []string, remoteSe
 
 	useStrictSecurity := ptr.Deref(cr.Spec.UseStrictSecurity, false)
 
-	build.AddStrictSecuritySettingsToContainers(cr.Spec.SecurityContext, vmalertContainers, useStrictSecurity)
+	build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.SecurityContext, vmalertContainers, useStrictSecurity)
 	containers, err := k8stools.MergePatchContainers(vmalertContainers, cr.Spec.Containers)
 	if err != nil {
 		return nil, err
 	}
+	ic, containers := build.AddSidecars(cr.Spec.InitContainers, containers, cr.Spec.Sidecars)
 
 	strategyType := appsv1.RollingUpdateDeploymentStrategyType
 	if cr.Spec.UpdateStrategy != nil {
@@ -405,7 +456,7 @@ func vmAlertSpecGen(cr *vmv1beta1.VMAlert, ruleConfigMapNames []string, remoteSe
 			},
 			Spec: corev1.PodSpec{
 				ServiceAccountName: cr.GetServiceAccountName(),
-				InitContainers:     cr.Spec.InitContainers,
+				InitContainers:     ic,
 				Containers:         containers,
 				Volumes:            volumes,
 			},
@@ -454,7 +505,13 @@ func buildVMAlertAuthArgs(args []string, flagPrefix string, ha vmv1beta1.HTTPAut
 			}
 			args = append(args, fmt.Sprintf("-%s.oauth2.clientID=%s", flagPrefix, s.OAuthCreds.ClientID))
 			args = append(args, fmt.Sprintf("-%s.oauth2.tokenUrl=%s", flagPrefix, ha.OAuth2.TokenURL))
-			args = append(args, fmt.Sprintf("-%s.oauth2.scopes=%s", flagPrefix, strings.Join(ha.OAuth2.Scopes, ",")))
+			if len(ha.OAuth2.Scopes) > 0 {
+				// vmalert expects scopes delimited by ';'
+				args = append(args, fmt.Sprintf("-%s.oauth2.scopes=%s", flagPrefix, strings.Join(ha.OAuth2.Scopes, ";")))
+			}
+			if len(ha.OAuth2.EndpointParams) > 0 {
+				args = append(args, fmt.Sprintf("-%s.oauth2.endpointParams=%s", flagPrefix, buildEndpointParams(ha.OAuth2.EndpointParams)))
+			}
 		}
 	}
 
@@ -500,6 +557,11 @@ func buildVMAlertArgs(cr *vmv1beta1.VMAlert, ruleConfigMapNames []string, remote
 	for k, v := range cr.Spec.ExternalLabels {
 		args = append(args, fmt.Sprintf("-external.label=%s=%s", k, v))
 	}
+	if cfg := config.MustGetBaseConfigForNamespace(cr.Namespace); cfg.ClusterName != "" {
+		if _, ok := cr.Spec.ExternalLabels[cfg.ClusterLabelName]; !ok {
+			args = append(args, fmt.Sprintf("-external.label=%s=%s", cfg.ClusterLabelName, cfg.ClusterName))
+		}
+	}
 
 	if cr.Spec.RemoteRead != nil {
 		args = append(args, fmt.Sprintf("-remoteRead.url=%s", cr.Spec.RemoteRead.URL))
@@ -538,12 +600,20 @@ func buildVMAlertArgs(cr *vmv1beta1.VMAlert, ruleConfigMapNames []string, remote
 	}
 
 	args = cr.Spec.License.MaybeAddToArgs(args, vmv1beta1.SecretsDir)
+	args = cr.Spec.ServerTLS.MaybeAddToArgs(args, vmv1beta1.ServerTLSDir)
 
 	args = build.AddExtraArgsOverrideDefaults(args, cr.Spec.ExtraArgs, "-")
 	sort.Strings(args)
 	return args
 }
 
+// buildEndpointParams returns oauth2 endpoint params in json format expected by vmalert
+func buildEndpointParams(params map[string]string) string {
+	// json.Marshal sorts map keys and cannot fail for map[string]string
+	data, _ := json.Marshal(params)
+	return string(data)
+}
+
 type authSecret struct {
 	bearerValue string
 	*k8stools.BasicAuthCredentials
@@ -786,6 +856,7 @@ func buildNotifiersArgs(cr *vmv1beta1.VMAlert, ntBasicAuth map[string]*authSecre
 	oauth2ClientID := remoteFlag{flagSetting: "-notifier.oauth2.clientID="}
 	oauth2Scopes := remoteFlag{flagSetting: "-notifier.oauth2.scopes="}
 	oauth2TokenURL := remoteFlag{flagSetting: "-notifier.oauth2.tokenUrl="}
+	oauth2EndpointParams := remoteFlag{flagSetting: "-notifier.oauth2.endpointParams="}
 
 	pathPrefix := path.Join(tlsAssetsDir, cr.Namespace)
 
@@ -874,14 +945,19 @@ func buildNotifiersArgs(cr *vmv1beta1.VMAlert, ntBasicAuth map[string]*authSecre
 			}
 		}
 		bearerTokenPath.flagSetting += fmt.Sprintf("%s,", tokenPath)
-		var scopes, tokenURL, secretFile, clientID string
+		var scopes, tokenURL, secretFile, clientID, endpointParams string
 		if nt.OAuth2 != nil {
 			if s == nil {
 				panic("secret for oauth2 notifier cannot be nil")
 			}
 			if len(nt.OAuth2.Scopes) > 0 {
 				oauth2Scopes.isNotNull = true
-				scopes = strings.Join(nt.OAuth2.Scopes, ",")
+				// vmalert expects scopes delimited by ';'
+				scopes = strings.Join(nt.OAuth2.Scopes, ";")
+			}
+			if len(nt.OAuth2.EndpointParams) > 0 {
+				oauth2EndpointParams.isNotNull = true
+				endpointParams = buildEndpointParams(nt.OAuth2.EndpointParams)
 			}
 			if len(nt.OAuth2.TokenURL) > 0 {
 				oauth2TokenURL.isNotNull = true
@@ -901,10 +977,11 @@ func buildNotifiersArgs(cr *vmv1beta1.VMAlert, ntBasicAuth map[string]*authSecre
 		oauth2TokenURL.flagSetting += fmt.Sprintf("%s,", tokenURL)
 		oauth2ClientID.flagSetting += fmt.Sprintf("%s,", clientID)
 		oauth2SecretFile.flagSetting += fmt.Sprintf("%s,", secretFile)
+		oauth2EndpointParams.flagSetting += fmt.Sprintf("\"%s\",", strings.ReplaceAll(endpointParams, `"`, `\"`))
 	}
 	notifierArgs = append(notifierArgs, url, authUser, authPasswordFile)
 	notifierArgs = append(notifierArgs, tlsServerName, tlsKeys, tlsCerts, tlsCAs, tlsInSecure, headers, bearerTokenPath)
-	notifierArgs = append(notifierArgs, oauth2SecretFile, oauth2ClientID, oauth2Scopes, oauth2TokenURL)
+	notifierArgs = append(notifierArgs, oauth2SecretFile, oauth2ClientID, oauth2Scopes, oauth2TokenURL, oauth2EndpointParams)
 
 	for _, remoteArgType := range notifierArgs {
 		if remoteArgType.isNotNull {
@@ -931,7 +1008,7 @@ func buildConfigReloaderContainer(dst []corev1.Container, cr *vmv1beta1.VMAlert,
 		reloadURLArg = "--reload-url"
 	}
 	confReloadArgs := []string{
-		fmt.Sprintf("%s=%s", reloadURLArg, vmv1beta1.BuildReloadPathWithPort(cr.Spec.ExtraArgs, cr.Spec.Port)),
+		fmt.Sprintf("%s=%s", reloadURLArg, vmv1beta1.BuildReloadPathWithPort(cr.Spec.ExtraArgsWithServerTLS(), cr.Spec.Port)),
 	}
 	for _, cm := range ruleConfigMapNames {
 		confReloadArgs = append(confReloadArgs, fmt.Sprintf("%s=%s", volumeWatchArg, path.Join(vmAlertConfigDir, cm)))
@@ -1032,6 +1109,9 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAlert, rclien
 	if err := reconcile.AdditionalServices(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevSvc, currSvc); err != nil {
 		return fmt.Errorf("cannot remove additional service: %w", err)
 	}
+	if err := reconcile.AdditionalServiceList(ctx, rclient, cr.Namespace, cr.ParsedLastAppliedSpec.AdditionalServices, cr.Spec.AdditionalServices); err != nil {
+		return fmt.Errorf("cannot remove additional services: %w", err)
+	}
 
 	objMeta := metav1.ObjectMeta{Name: cr.PrefixedName(), Namespace: cr.Namespace}
 	if cr.Spec.PodDisruptionBudget == nil && cr.ParsedLastAppliedSpec.PodDisruptionBudget != nil {
@@ -1039,12 +1119,42 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAlert, rclien
 			return fmt.Errorf("cannot delete PDB from prev state: %w", err)
 		}
 	}
+	if !cr.Spec.NetworkPolicy.IsEnabled() && cr.ParsedLastAppliedSpec.NetworkPolicy.IsEnabled() {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &networkingv1.NetworkPolicy{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot delete NetworkPolicy from prev state: %w", err)
+		}
+	}
+	if cr.Spec.VPA == nil && cr.ParsedLastAppliedSpec.VPA != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, build.NewVPA(cr.PrefixedName(), cr.Namespace)); err != nil {
+			return fmt.Errorf("cannot delete VPA from prev state: %w", err)
+		}
+	}
+	if prevGD := cr.ParsedLastAppliedSpec.GrafanaDashboard; prevGD != nil && (cr.Spec.GrafanaDashboard == nil || cr.Spec.GrafanaDashboard.UseConfigMap() != prevGD.UseConfigMap()) {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, build.NewGrafanaDashboardObject(prevGD, cr.GrafanaDashboardName(), cr.Namespace)); err != nil {
+			return fmt.Errorf("cannot delete grafana dashboard from prev state: %w", err)
+		}
+	}
 
 	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(cr.ParsedLastAppliedSpec.DisableSelfServiceScrape, false) {
 		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta}); err != nil {
 			return fmt.Errorf("cannot remove serviceScrape: %w", err)
 		}
 	}
+	if !ptr.Deref(cr.Spec.SelfMonitoringRules, false) && ptr.Deref(cr.ParsedLastAppliedSpec.SelfMonitoringRules, false) {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMRule{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot remove self-monitoring VMRule: %w", err)
+		}
+	}
 
 	return nil
 }
+
+// buildVPA creates VerticalPodAutoscaler for vmalert Deployment
+func buildVPA(cr *vmv1beta1.VMAlert) *unstructured.Unstructured {
+	targetRef := autoscalingv2.CrossVersionObjectReference{
+		Name:       cr.PrefixedName(),
+		Kind:       "Deployment",
+		APIVersion: "apps/v1",
+	}
+	return build.VPA(cr, targetRef, "vmalert", cr.Spec.VPA)
+}
@@ -10,6 +10,7 @@ import (
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -74,7 +75,7 @@ func Test_loadVMAlertRemoteSecrets(t *testing.T) {
 			for i := range tt.args.SecretsInNS.Items {
 				predefinedObjets = append(predefinedObjets, &tt.args.SecretsInNS.Items[i])
 			}
-			testClient := k8stools.GetTestClientWithObjects(predefinedObjets)
+			testClient := testutil.GetTestClientWithObjects(predefinedObjets)
 			got, err := loadVMAlertRemoteSecrets(context.TODO(), testClient, tt.args.cr)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("loadVMAlertRemoteSecrets() error = %v, wantErr %v", err, tt.wantErr)
@@ -121,7 +122,7 @@ func Test_loadTLSAssetsForVMAlert(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			got, err := loadTLSAssetsForVMAlert(context.TODO(), fclient, tt.args.cr)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("loadTLSAssetsForVMAlert() error = %v, wantErr %v", err, tt.wantErr)
@@ -560,7 +561,7 @@ func TestCreateOrUpdateVMAlert(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			err := CreateOrUpdateVMAlert(context.TODO(), tt.args.cr, fclient, tt.args.cmNames)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateOrUpdateVMAlert() error = %v, wantErr %v", err, tt.wantErr)
@@ -641,10 +642,11 @@ func TestBuildNotifiers(t *testing.T) {
 								HTTPAuth: vmv1beta1.HTTPAuth{
 									Headers: []string{"key=value", "key2=value2"},
 									OAuth2: &vmv1beta1.OAuth2{
-										Scopes:       []string{"1", "2"},
-										TokenURL:     "http://some-url",
-										ClientSecret: &corev1.SecretKeySelector{},
-										ClientID:     vmv1beta1.SecretOrConfigMap{},
+										Scopes:         []string{"1", "2"},
+										TokenURL:       "http://some-url",
+										ClientSecret:   &corev1.SecretKeySelector{},
+										ClientID:       vmv1beta1.SecretOrConfigMap{},
+										EndpointParams: map[string]string{"audience": "vmalert"},
 									},
 								},
 							},
@@ -660,7 +662,7 @@ func TestBuildNotifiers(t *testing.T) {
 				},
 				ntBasicAuth: map[string]*authSecret{"notifier-0": {OAuthCreds: &k8stools.OAuthCreds{ClientSecret: "some-secret", ClientID: "some-id"}}, "notifier-1": {bearerValue: "some-v"}},
 			},
-			want: []string{"-notifier.url=http://1,http://2", "-notifier.headers=key=value^^key2=value2,key3=value3^^key4=value4", "-notifier.bearerTokenFile=,/etc/vmalert/remote_secrets/NOTIFIER-1_BEARERTOKEN", "-notifier.oauth2.clientSecretFile=/etc/vmalert/remote_secrets/NOTIFIER-0_OAUTH2SECRETKEY,", "-notifier.oauth2.clientID=some-id,", "-notifier.oauth2.scopes=1,2,", "-notifier.oauth2.tokenUrl=http://some-url,"},
+			want: []string{"-notifier.url=http://1,http://2", "-notifier.headers=key=value^^key2=value2,key3=value3^^key4=value4", "-notifier.bearerTokenFile=,/etc/vmalert/remote_secrets/NOTIFIER-1_BEARERTOKEN", "-notifier.oauth2.clientSecretFile=/etc/vmalert/remote_secrets/NOTIFIER-0_OAUTH2SECRETKEY,", "-notifier.oauth2.clientID=some-id,", "-notifier.oauth2.scopes=1;2,", "-notifier.oauth2.tokenUrl=http://some-url,", `-notifier.oauth2.endpointParams="{\"audience\":\"vmalert\"}",""`},
 		},
 	}
 	for _, tt := range tests {
@@ -707,7 +709,7 @@ func TestCreateOrUpdateVMAlertService(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cl := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			cl := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			got, err := createOrUpdateVMAlertService(tt.args.ctx, cl, tt.args.cr, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateOrUpdateVMAlertService() error = %v, wantErr %v", err, tt.wantErr)
@@ -769,6 +771,59 @@ func Test_buildVMAlertArgs(t *testing.T) {
 			},
 			want: []string{"--datasource.headers=x-org-id:one^^x-org-tenant:5", "-datasource.tlsCAFile=/path/to/sa", "-datasource.tlsInsecureSkipVerify=true", "-datasource.tlsKeyFile=/path/to/key", "-datasource.url=http://vmsingle-url", "-httpListenAddr=:", "-notifier.url=", "-rule=\"/etc/vmalert/config/first-rule-cm.yaml/*.yaml\""},
 		},
+		{
+			name: "with per url auth",
+			args: args{
+				cr: &vmv1beta1.VMAlert{
+					Spec: vmv1beta1.VMAlertSpec{
+						Datasource: vmv1beta1.VMAlertDatasourceSpec{
+							URL: "http://vmselect-url",
+							HTTPAuth: vmv1beta1.HTTPAuth{
+								OAuth2: &vmv1beta1.OAuth2{
+									Scopes:         []string{"read", "write"},
+									TokenURL:       "http://oauth2-url",
+									EndpointParams: map[string]string{"audience": "vmselect"},
+								},
+							},
+						},
+						RemoteRead: &vmv1beta1.VMAlertRemoteReadSpec{
+							URL: "http://vmselect-url",
+							HTTPAuth: vmv1beta1.HTTPAuth{
+								BearerAuth: &vmv1beta1.BearerAuth{TokenFilePath: "/path/to/token"},
+								TLSConfig:  &vmv1beta1.TLSConfig{CAFile: "/path/to/ca"},
+							},
+						},
+						RemoteWrite: &vmv1beta1.VMAlertRemoteWriteSpec{
+							URL: "http://vminsert-url",
+							HTTPAuth: vmv1beta1.HTTPAuth{
+								BasicAuth: &vmv1beta1.BasicAuth{},
+							},
+						},
+					},
+				},
+				remoteSecrets: map[string]*authSecret{
+					"datasource":  {OAuthCreds: &k8stools.OAuthCreds{ClientSecret: "some-secret", ClientID: "some-id"}},
+					"remoteRead":  {},
+					"remoteWrite": {BasicAuthCredentials: &k8stools.BasicAuthCredentials{Username: "user", Password: "pass"}},
+				},
+			},
+			want: []string{
+				`-datasource.oauth2.clientID=some-id`,
+				`-datasource.oauth2.clientSecretFile=/etc/vmalert/remote_secrets/DATASOURCE_OAUTH2SECRETKEY`,
+				`-datasource.oauth2.endpointParams={"audience":"vmselect"}`,
+				`-datasource.oauth2.scopes=read;write`,
+				`-datasource.oauth2.tokenUrl=http://oauth2-url`,
+				`-datasource.url=http://vmselect-url`,
+				`-httpListenAddr=:`,
+				`-notifier.url=`,
+				`-remoteRead.bearerTokenFile=/path/to/token`,
+				`-remoteRead.tlsCAFile=/path/to/ca`,
+				`-remoteRead.url=http://vmselect-url`,
+				`-remoteWrite.basicAuth.passwordFile=/etc/vmalert/remote_secrets/REMOTEWRITE_BASICAUTHPASSWORD`,
+				`-remoteWrite.basicAuth.username=user`,
+				`-remoteWrite.url=http://vminsert-url`,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -779,3 +834,34 @@ func Test_buildVMAlertArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildVMAlertArgsClusterName(t *testing.T) {
+	f := func(externalLabels map[string]string, want string) {
+		t.Helper()
+		cfg := config.MustGetBaseConfig()
+		defaultCfg := *cfg
+		t.Cleanup(func() { *cfg = defaultCfg })
+		cfg.ClusterName = "main"
+		cr := &vmv1beta1.VMAlert{
+			Spec: vmv1beta1.VMAlertSpec{
+				Datasource:     vmv1beta1.VMAlertDatasourceSpec{URL: "http://vmsingle-url"},
+				ExternalLabels: externalLabels,
+			},
+		}
+		got := buildVMAlertArgs(cr, nil, map[string]*authSecret{})
+		assert.Contains(t, got, want)
+		var cnt int
+		for _, arg := range got {
+			if strings.HasPrefix(arg, "-external.label=cluster=") {
+				cnt++
+			}
+		}
+		if cnt != 1 {
+			t.Fatalf("expected single cluster external label, got: %v", got)
+		}
+	}
+	// cluster label from operator config
+	f(nil, "-external.label=cluster=main")
+	// cluster label defined at spec takes precedence
+	f(map[string]string{"cluster": "edge"}, "-external.label=cluster=edge")
+}
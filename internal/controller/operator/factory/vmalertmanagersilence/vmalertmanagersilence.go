@@ -0,0 +1,377 @@
+package vmalertmanagersilence
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+var httpClient = &http.Client{Timeout: time.Minute}
+
+// expireTimeout defines how long silence expiration is retried on object deletion
+// after it, finalizer is removed and silence is kept at alertmanager until its end time
+const expireTimeout = 10 * time.Minute
+
+var errNotFound = errors.New("silence not found")
+
+// silence states reported by alertmanager
+const (
+	statePending = "pending"
+	stateActive  = "active"
+	stateExpired = "expired"
+)
+
+// silence defines silence object of alertmanager api v2
+type silence struct {
+	ID        string           `json:"id,omitempty"`
+	Matchers  []silenceMatcher `json:"matchers"`
+	StartsAt  time.Time        `json:"startsAt"`
+	EndsAt    time.Time        `json:"endsAt"`
+	CreatedBy string           `json:"createdBy"`
+	Comment   string           `json:"comment"`
+	Status    *silenceStatus   `json:"status,omitempty"`
+}
+
+type silenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+type silenceStatus struct {
+	State string `json:"state"`
+}
+
+// CreateOrUpdate synchronizes silence with the referenced VMAlertmanager
+// returns duration until the next silence state change, zero if silence is expired
+func CreateOrUpdate(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlertmanagerSilence) (time.Duration, error) {
+	startsAt, endsAt, err := cr.TimeRange()
+	if err != nil {
+		return 0, err
+	}
+	am, err := getAlertmanager(ctx, rclient, cr)
+	if err != nil {
+		return 0, err
+	}
+	ac, err := newAPIClient(ctx, rclient, cr, am)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	desired := buildSilence(cr, startsAt, endsAt)
+	var current *silence
+	if cr.Status.SilenceID != "" {
+		current, err = ac.getSilence(ctx, cr.SilenceURL(am, cr.Status.SilenceID))
+		if err != nil {
+			return 0, err
+		}
+	}
+	id, state := cr.Status.SilenceID, statePending
+	switch {
+	case !endsAt.After(now):
+		// alertmanager doesn't accept silences ending in the past
+		// silence expired by alertmanager or never created must be kept as is
+		state = stateExpired
+		if current != nil && current.Status != nil && current.Status.State != stateExpired {
+			if err := ac.call(ctx, http.MethodDelete, cr.SilenceURL(am, current.ID), nil, nil); err != nil {
+				return 0, fmt.Errorf("cannot expire silence=%q: %w", current.ID, err)
+			}
+		}
+	case current != nil && current.Status != nil && current.Status.State != stateExpired && isSameSilence(current, desired, now):
+		state = current.Status.State
+	default:
+		if current != nil && current.Status != nil && current.Status.State != stateExpired {
+			// alertmanager updates silence in-place or expires it and returns id of the new silence
+			desired.ID = current.ID
+		}
+		id, err = ac.postSilence(ctx, cr.SilencesURL(am), desired)
+		if err != nil {
+			return 0, err
+		}
+		if !startsAt.After(now) {
+			state = stateActive
+		}
+		logger.WithContext(ctx).Info(fmt.Sprintf("synced silence=%q with alertmanager=%q", id, am.PrefixedName()))
+	}
+
+	if id != cr.Status.SilenceID || state != cr.Status.State || cr.Status.EndsAt == nil || !cr.Status.EndsAt.Time.Equal(endsAt) {
+		cr.Status.SilenceID = id
+		cr.Status.State = state
+		cr.Status.EndsAt = &metav1.Time{Time: endsAt}
+		if err := rclient.Status().Update(ctx, cr); err != nil {
+			return 0, fmt.Errorf("cannot update silence status: %w", err)
+		}
+	}
+	switch state {
+	case statePending:
+		return startsAt.Sub(now), nil
+	case stateActive:
+		return endsAt.Sub(now), nil
+	default:
+		return 0, nil
+	}
+}
+
+// Expire expires silence tracked at the status of given VMAlertmanagerSilence
+// if alertmanager is not reachable during expireTimeout since object deletion, error is logged and silence is kept at alertmanager
+func Expire(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlertmanagerSilence) error {
+	err := expire(ctx, rclient, cr)
+	if err != nil && cr.DeletionTimestamp != nil && time.Since(cr.DeletionTimestamp.Time) > expireTimeout {
+		logger.WithContext(ctx).Error(err, fmt.Sprintf("cannot expire silence=%q during %s, it remains active at alertmanager until its end time", cr.Status.SilenceID, expireTimeout))
+		return nil
+	}
+	return err
+}
+
+func expire(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlertmanagerSilence) error {
+	if cr.Status.SilenceID == "" {
+		return nil
+	}
+	am, err := getAlertmanager(ctx, rclient, cr)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// silence was removed with alertmanager
+			return nil
+		}
+		return err
+	}
+	ac, err := newAPIClient(ctx, rclient, cr, am)
+	if err != nil {
+		return err
+	}
+	current, err := ac.getSilence(ctx, cr.SilenceURL(am, cr.Status.SilenceID))
+	if err != nil {
+		return err
+	}
+	if current == nil || (current.Status != nil && current.Status.State == stateExpired) {
+		return nil
+	}
+	if err := ac.call(ctx, http.MethodDelete, cr.SilenceURL(am, current.ID), nil, nil); err != nil {
+		return fmt.Errorf("cannot expire silence=%q: %w", current.ID, err)
+	}
+	return nil
+}
+
+func getAlertmanager(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlertmanagerSilence) (*vmv1beta1.VMAlertmanager, error) {
+	nsn := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Spec.AlertmanagerRef.Name}
+	var am vmv1beta1.VMAlertmanager
+	if err := rclient.Get(ctx, nsn, &am); err != nil {
+		return nil, fmt.Errorf("cannot get VMAlertmanager=%q: %w", nsn, err)
+	}
+	return &am, nil
+}
+
+func buildSilence(cr *vmv1beta1.VMAlertmanagerSilence, startsAt, endsAt time.Time) *silence {
+	s := &silence{
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		CreatedBy: cr.CreatedBy(),
+		Comment:   cr.Spec.Comment,
+	}
+	for _, m := range cr.Spec.Matchers {
+		isEqual := true
+		if m.IsEqual != nil {
+			isEqual = *m.IsEqual
+		}
+		s.Matchers = append(s.Matchers, silenceMatcher{Name: m.Name, Value: m.Value, IsRegex: m.IsRegex, IsEqual: isEqual})
+	}
+	return s
+}
+
+// isSameSilence checks if silence at alertmanager matches desired silence
+func isSameSilence(current, desired *silence, now time.Time) bool {
+	if current.Comment != desired.Comment || current.CreatedBy != desired.CreatedBy {
+		return false
+	}
+	if len(current.Matchers) != len(desired.Matchers) {
+		return false
+	}
+	for i := range current.Matchers {
+		if current.Matchers[i] != desired.Matchers[i] {
+			return false
+		}
+	}
+	// alertmanager returns timestamps with milliseconds precision
+	if !current.EndsAt.Truncate(time.Millisecond).Equal(desired.EndsAt.Truncate(time.Millisecond)) {
+		return false
+	}
+	// alertmanager replaces start time in the past with silence creation time
+	if !desired.StartsAt.After(now) {
+		return !current.StartsAt.After(now)
+	}
+	return current.StartsAt.Truncate(time.Millisecond).Equal(desired.StartsAt.Truncate(time.Millisecond))
+}
+
+// apiClient performs requests to alertmanager api
+// according to webConfig of VMAlertmanager
+type apiClient struct {
+	c         *http.Client
+	basicAuth *k8stools.BasicAuthCredentials
+}
+
+func newAPIClient(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlertmanagerSilence, am *vmv1beta1.VMAlertmanager) (*apiClient, error) {
+	ac := &apiClient{c: httpClient}
+	if cr.Spec.BasicAuth != nil {
+		creds, err := k8stools.LoadBasicAuthSecret(ctx, rclient, cr.Namespace, cr.Spec.BasicAuth, map[string]*corev1.Secret{})
+		if err != nil {
+			return nil, fmt.Errorf("cannot load basicAuth credentials: %w", err)
+		}
+		ac.basicAuth = &creds
+	}
+	if am.Spec.WebConfig == nil || am.Spec.WebConfig.TLSServerConfig == nil {
+		return ac, nil
+	}
+	tc, err := buildTLSConfig(ctx, rclient, cr.Namespace, cr.Spec.TLSConfig)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build tlsConfig: %w", err)
+	}
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if t, ok := httpClient.Transport.(*http.Transport); ok {
+		tr = t.Clone()
+	}
+	// transport isn't shared between requests, since certificates could be rotated
+	tr.TLSClientConfig = tc
+	tr.DisableKeepAlives = true
+	ac.c = &http.Client{Timeout: httpClient.Timeout, Transport: tr}
+	return ac, nil
+}
+
+func buildTLSConfig(ctx context.Context, rclient client.Client, ns string, cfg *vmv1beta1.TLSConfig) (*tls.Config, error) {
+	tc := &tls.Config{}
+	if cfg == nil {
+		// alertmanager certificate must be issued by system trusted CA
+		return tc, nil
+	}
+	tc.ServerName = cfg.ServerName
+	tc.InsecureSkipVerify = cfg.InsecureSkipVerify
+	minVersion, err := vmv1beta1.ParseTLSVersion(cfg.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+	tc.MinVersion = minVersion
+	secrets := map[string]*corev1.Secret{}
+	configMaps := map[string]*corev1.ConfigMap{}
+	ca, err := loadSecretOrConfigMap(ctx, rclient, ns, &cfg.CA, secrets, configMaps)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load CA: %w", err)
+	}
+	if len(ca) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return nil, fmt.Errorf("cannot parse CA certificate")
+		}
+		tc.RootCAs = pool
+	}
+	cert, err := loadSecretOrConfigMap(ctx, rclient, ns, &cfg.Cert, secrets, configMaps)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load client certificate: %w", err)
+	}
+	if len(cert) > 0 && cfg.KeySecret != nil {
+		key, err := k8stools.GetCredFromSecret(ctx, rclient, ns, cfg.KeySecret, fmt.Sprintf("%s/%s", ns, cfg.KeySecret.Name), secrets)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load client certificate key: %w", err)
+		}
+		pair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse client certificate: %w", err)
+		}
+		tc.Certificates = []tls.Certificate{pair}
+	}
+	return tc, nil
+}
+
+func loadSecretOrConfigMap(ctx context.Context, rclient client.Client, ns string, src *vmv1beta1.SecretOrConfigMap, secrets map[string]*corev1.Secret, configMaps map[string]*corev1.ConfigMap) (string, error) {
+	switch {
+	case src.Secret != nil:
+		return k8stools.GetCredFromSecret(ctx, rclient, ns, src.Secret, fmt.Sprintf("%s/%s", ns, src.Secret.Name), secrets)
+	case src.ConfigMap != nil:
+		return k8stools.GetCredFromConfigMap(ctx, rclient, ns, *src.ConfigMap, fmt.Sprintf("%s/%s", ns, src.ConfigMap.Name), configMaps)
+	default:
+		return "", nil
+	}
+}
+
+// getSilence returns silence by url, nil if silence doesn't exist
+func (ac *apiClient) getSilence(ctx context.Context, url string) (*silence, error) {
+	var s silence
+	if err := ac.call(ctx, http.MethodGet, url, nil, &s); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot get silence: %w", err)
+	}
+	return &s, nil
+}
+
+func (ac *apiClient) postSilence(ctx context.Context, url string, s *silence) (string, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("BUG: cannot serialize silence: %w", err)
+	}
+	var resp struct {
+		SilenceID string `json:"silenceID"`
+	}
+	if err := ac.call(ctx, http.MethodPost, url, data, &resp); err != nil {
+		return "", fmt.Errorf("cannot post silence: %w", err)
+	}
+	if resp.SilenceID == "" {
+		return "", fmt.Errorf("alertmanager returned empty silence id")
+	}
+	return resp.SilenceID, nil
+}
+
+func (ac *apiClient) call(ctx context.Context, method, url string, reqBody []byte, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("cannot build request: %w", err)
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if ac.basicAuth != nil {
+		req.SetBasicAuth(ac.basicAuth.Username, ac.basicAuth.Password)
+	}
+	resp, err := ac.c.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot execute request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("cannot read response body: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response code=%d, body=%q", resp.StatusCode, string(body))
+	}
+	if dst == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, dst); err != nil {
+		return fmt.Errorf("cannot parse response body=%q: %w", string(body), err)
+	}
+	return nil
+}
@@ -0,0 +1,365 @@
+package vmalertmanagersilence
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+)
+
+// fakeAlertmanager implements silences api of alertmanager
+type fakeAlertmanager struct {
+	mu       sync.Mutex
+	silences map[string]*silence
+	posts    int
+	nextID   int
+}
+
+func (fa *fakeAlertmanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+		var s silence
+		if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fa.posts++
+		if s.ID != "" {
+			if _, ok := fa.silences[s.ID]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+		} else {
+			fa.nextID++
+			s.ID = fmt.Sprintf("silence-%d", fa.nextID)
+		}
+		s.Status = &silenceStatus{State: stateActive}
+		fa.silences[s.ID] = &s
+		fmt.Fprintf(w, `{"silenceID":%q}`, s.ID)
+	case strings.HasPrefix(r.URL.Path, "/api/v2/silence/"):
+		s, ok := fa.silences[strings.TrimPrefix(r.URL.Path, "/api/v2/silence/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(s)
+		case http.MethodDelete:
+			s.Status.State = stateExpired
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// withFakeAlertmanager routes all api requests to the given handler
+func withFakeAlertmanager(t *testing.T, h http.Handler) {
+	t.Helper()
+	withFakeAlertmanagerServer(t, httptest.NewServer(h))
+}
+
+// withFakeAlertmanagerServer routes all api requests to the given server
+func withFakeAlertmanagerServer(t *testing.T, srv *httptest.Server) {
+	t.Helper()
+	origin := httpClient
+	httpClient = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, srv.Listener.Addr().String())
+			},
+		},
+	}
+	t.Cleanup(func() {
+		httpClient = origin
+		srv.Close()
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	fa := &fakeAlertmanager{silences: map[string]*silence{}}
+	withFakeAlertmanager(t, fa)
+
+	cr := &vmv1beta1.VMAlertmanagerSilence{
+		ObjectMeta: metav1.ObjectMeta{Name: "maintenance", Namespace: "default", CreationTimestamp: metav1.Now()},
+		Spec: vmv1beta1.VMAlertmanagerSilenceSpec{
+			AlertmanagerRef: vmv1beta1.VMAlertmanagerSilenceRef{Name: "main"},
+			Matchers: []vmv1beta1.VMAlertmanagerSilenceMatcher{
+				{Name: "alertname", Value: "Watchdog"},
+				{Name: "namespace", Value: "dev-.*", IsRegex: true, IsEqual: ptr.To(false)},
+			},
+			Duration: "1h",
+			Comment:  "maintenance",
+		},
+	}
+	fclient := testutil.GetTestClientWithObjects([]runtime.Object{
+		cr,
+		&vmv1beta1.VMAlertmanager{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"}},
+	})
+	ctx := context.Background()
+	f := func(wantState string, wantPosts int) {
+		t.Helper()
+		var got vmv1beta1.VMAlertmanagerSilence
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, &got); err != nil {
+			t.Fatalf("cannot get silence: %s", err)
+		}
+		got.Spec = cr.Spec
+		next, err := CreateOrUpdate(ctx, fclient, &got)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got.Status.State != wantState {
+			t.Fatalf("unexpected state, got: %q, want: %q", got.Status.State, wantState)
+		}
+		if wantState == stateActive && (next <= 0 || next > time.Hour) {
+			t.Fatalf("unexpected next sync: %s", next)
+		}
+		if fa.posts != wantPosts {
+			t.Fatalf("unexpected number of posted silences, got: %d, want: %d", fa.posts, wantPosts)
+		}
+		s := fa.silences[got.Status.SilenceID]
+		if s == nil {
+			t.Fatalf("silence=%q is missing at alertmanager", got.Status.SilenceID)
+		}
+		if s.Status.State != wantState {
+			t.Fatalf("unexpected silence state at alertmanager, got: %q, want: %q", s.Status.State, wantState)
+		}
+		if s.CreatedBy != "default/maintenance" || len(s.Matchers) != 2 || s.Matchers[1].IsEqual || !s.Matchers[1].IsRegex {
+			t.Fatalf("unexpected silence: %+v", s)
+		}
+	}
+
+	// create silence
+	f(stateActive, 1)
+
+	// silence is not changed
+	f(stateActive, 1)
+
+	// update comment
+	cr.Spec.Comment = "extended maintenance"
+	f(stateActive, 2)
+	if len(fa.silences) != 1 {
+		t.Fatalf("expected silence to be updated in-place, got: %d silences", len(fa.silences))
+	}
+
+	// silence removed at alertmanager is created again
+	delete(fa.silences, "silence-1")
+	f(stateActive, 3)
+
+	// silence end time is in the past
+	cr.Spec.EndsAt = &metav1.Time{Time: cr.CreationTimestamp.Add(-time.Minute)}
+	cr.Spec.StartsAt = &metav1.Time{Time: cr.CreationTimestamp.Add(-time.Hour)}
+	f(stateExpired, 3)
+}
+
+func TestExpire(t *testing.T) {
+	fa := &fakeAlertmanager{silences: map[string]*silence{
+		"active":  {ID: "active", Status: &silenceStatus{State: stateActive}},
+		"expired": {ID: "expired", Status: &silenceStatus{State: stateExpired}},
+	}}
+	withFakeAlertmanager(t, fa)
+	f := func(silenceID string, predefinedObjects []runtime.Object) {
+		t.Helper()
+		cr := &vmv1beta1.VMAlertmanagerSilence{
+			ObjectMeta: metav1.ObjectMeta{Name: "maintenance", Namespace: "default"},
+			Spec: vmv1beta1.VMAlertmanagerSilenceSpec{
+				AlertmanagerRef: vmv1beta1.VMAlertmanagerSilenceRef{Name: "main"},
+			},
+			Status: vmv1beta1.VMAlertmanagerSilenceStatus{SilenceID: silenceID},
+		}
+		fclient := testutil.GetTestClientWithObjects(predefinedObjects)
+		if err := Expire(context.Background(), fclient, cr); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if s := fa.silences[silenceID]; s != nil && s.Status.State != stateExpired {
+			t.Fatalf("expected silence=%q to be expired", silenceID)
+		}
+	}
+	am := &vmv1beta1.VMAlertmanager{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"}}
+
+	// active silence
+	f("active", []runtime.Object{am})
+
+	// already expired silence
+	f("expired", []runtime.Object{am})
+
+	// silence is missing at alertmanager
+	f("missing", []runtime.Object{am})
+
+	// alertmanager was removed
+	f("active", nil)
+}
+
+func TestTimeRange(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	f := func(spec vmv1beta1.VMAlertmanagerSilenceSpec, wantStart, wantEnd time.Time, wantErr bool) {
+		t.Helper()
+		cr := &vmv1beta1.VMAlertmanagerSilence{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: created}},
+			Spec:       spec,
+		}
+		start, end, err := cr.TimeRange()
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if wantErr {
+			return
+		}
+		if !start.Equal(wantStart) || !end.Equal(wantEnd) {
+			t.Fatalf("unexpected time range, got: %s-%s, want: %s-%s", start, end, wantStart, wantEnd)
+		}
+	}
+
+	// duration from creation time
+	f(vmv1beta1.VMAlertmanagerSilenceSpec{Duration: "2h"}, created, created.Add(2*time.Hour), false)
+
+	// endsAt has priority over duration
+	f(vmv1beta1.VMAlertmanagerSilenceSpec{
+		StartsAt: &metav1.Time{Time: created.Add(time.Hour)},
+		EndsAt:   &metav1.Time{Time: created.Add(3 * time.Hour)},
+		Duration: "10m",
+	}, created.Add(time.Hour), created.Add(3*time.Hour), false)
+
+	// missing end time
+	f(vmv1beta1.VMAlertmanagerSilenceSpec{}, time.Time{}, time.Time{}, true)
+
+	// endsAt before startsAt
+	f(vmv1beta1.VMAlertmanagerSilenceSpec{EndsAt: &metav1.Time{Time: created.Add(-time.Hour)}}, time.Time{}, time.Time{}, true)
+
+	// bad duration
+	f(vmv1beta1.VMAlertmanagerSilenceSpec{Duration: "-1h"}, time.Time{}, time.Time{}, true)
+}
+
+func TestExpireTimeout(t *testing.T) {
+	withFakeAlertmanager(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	f := func(deletedAgo time.Duration, wantErr bool) {
+		t.Helper()
+		cr := &vmv1beta1.VMAlertmanagerSilence{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "maintenance",
+				Namespace:         "default",
+				DeletionTimestamp: &metav1.Time{Time: time.Now().Add(-deletedAgo)},
+			},
+			Spec: vmv1beta1.VMAlertmanagerSilenceSpec{
+				AlertmanagerRef: vmv1beta1.VMAlertmanagerSilenceRef{Name: "main"},
+			},
+			Status: vmv1beta1.VMAlertmanagerSilenceStatus{SilenceID: "active"},
+		}
+		fclient := testutil.GetTestClientWithObjects([]runtime.Object{
+			&vmv1beta1.VMAlertmanager{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"}},
+		})
+		err := Expire(context.Background(), fclient, cr)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+
+	// expiration is retried
+	f(time.Minute, true)
+
+	// silence is kept at unavailable alertmanager after timeout
+	f(expireTimeout+time.Minute, false)
+}
+
+func TestCreateOrUpdateWithWebConfig(t *testing.T) {
+	fa := &fakeAlertmanager{silences: map[string]*silence{}}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fa.ServeHTTP(w, r)
+	}))
+	withFakeAlertmanagerServer(t, srv)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	cr := &vmv1beta1.VMAlertmanagerSilence{
+		ObjectMeta: metav1.ObjectMeta{Name: "maintenance", Namespace: "default", CreationTimestamp: metav1.Now()},
+		Spec: vmv1beta1.VMAlertmanagerSilenceSpec{
+			AlertmanagerRef: vmv1beta1.VMAlertmanagerSilenceRef{Name: "main"},
+			Matchers:        []vmv1beta1.VMAlertmanagerSilenceMatcher{{Name: "alertname", Value: "Watchdog"}},
+			Duration:        "1h",
+			Comment:         "maintenance",
+			BasicAuth: &vmv1beta1.BasicAuth{
+				Username: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "am-access"}, Key: "username"},
+				Password: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "am-access"}, Key: "password"},
+			},
+			TLSConfig: &vmv1beta1.TLSConfig{
+				CA: vmv1beta1.SecretOrConfigMap{
+					Secret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "am-access"}, Key: "ca.crt"},
+				},
+				// certificate of test server is issued for example.com
+				ServerName: "example.com",
+			},
+		},
+	}
+	fclient := testutil.GetTestClientWithObjects([]runtime.Object{
+		cr,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "am-access", Namespace: "default"},
+			Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("secret"), "ca.crt": caPEM},
+		},
+		&vmv1beta1.VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+			Spec: vmv1beta1.VMAlertmanagerSpec{
+				WebConfig: &vmv1beta1.AlertmanagerWebConfig{
+					TLSServerConfig: &vmv1beta1.TLSServerConfig{},
+					BasicAuthUsers:  map[string]string{"admin": "$2y$10$hashed"},
+				},
+			},
+		},
+	})
+	ctx := context.Background()
+	if _, err := CreateOrUpdate(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cr.Status.State != stateActive || fa.silences[cr.Status.SilenceID] == nil {
+		t.Fatalf("expected silence to be created, got status: %+v", cr.Status)
+	}
+
+	// certificate isn't trusted without CA
+	cr.Spec.TLSConfig = nil
+	if _, err := CreateOrUpdate(ctx, fclient, cr); err == nil {
+		t.Fatalf("expected certificate verification error")
+	}
+}
+
+func TestBuildTLSConfigMinVersion(t *testing.T) {
+	f := func(minVersion string, want uint16) {
+		t.Helper()
+		tc, err := buildTLSConfig(context.Background(), testutil.GetTestClientWithObjects(nil), "default", &vmv1beta1.TLSConfig{
+			ServerName: "alertmanager.example.com",
+			MinVersion: minVersion,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if tc.MinVersion != want {
+			t.Fatalf("unexpected min version, got: %d, want: %d", tc.MinVersion, want)
+		}
+	}
+	f("", 0)
+	f("TLS13", tls.VersionTLS13)
+}
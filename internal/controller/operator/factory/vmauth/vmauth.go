@@ -10,13 +10,12 @@ import (
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +34,8 @@ const (
 	vmAuthConfigName      = "config.yaml"
 	vmAuthConfigNameGz    = "config.yaml.gz"
 	vmAuthVolumeName      = "config"
+
+	defaultConfigCheckInterval = "1m"
 )
 
 // CreateOrUpdateVMAuth - handles VMAuth deployment reconciliation.
@@ -63,14 +64,34 @@ func CreateOrUpdateVMAuth(ctx context.Context, cr *vmv1beta1.VMAuth, rclient cli
 	if err != nil {
 		return fmt.Errorf("cannot create or update vmauth service :%w", err)
 	}
-	if err := createOrUpdateVMAuthIngress(ctx, rclient, cr); err != nil {
-		return fmt.Errorf("cannot create or update ingress for vmauth: %w", err)
+	if cr.Spec.Ingress != nil {
+		var prevIngress *networkingv1.Ingress
+		if prevCR != nil && prevCR.Spec.Ingress != nil {
+			prevIngress = build.Ingress(prevCR, prevCR.Spec.Ingress)
+		}
+		if err := reconcile.Ingress(ctx, rclient, build.Ingress(cr, cr.Spec.Ingress), prevIngress); err != nil {
+			return fmt.Errorf("cannot create or update ingress for vmauth: %w", err)
+		}
+	}
+	if cr.Spec.Route != nil {
+		var prevRoute *unstructured.Unstructured
+		if prevCR != nil && prevCR.Spec.Route != nil {
+			prevRoute = build.Route(prevCR, prevCR.Spec.Route)
+		}
+		if err := reconcile.Route(ctx, rclient, build.Route(cr, cr.Spec.Route), prevRoute); err != nil {
+			return fmt.Errorf("cannot update route for vmauth: %w", err)
+		}
 	}
 	if !ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
-		if err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(svc, cr)); err != nil {
+		if err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithDefaults(svc, cr, build.ServiceScrapeDefaults{}.WithServerTLS(cr.Spec.ServerTLS, svc))); err != nil {
 			return err
 		}
 	}
+	if ptr.Deref(cr.Spec.SelfMonitoringRules, false) {
+		if err := reconcile.VMRuleForCRD(ctx, rclient, build.SelfMonitoringVMRule(svc, svc.Name, "vmauth")); err != nil {
+			return fmt.Errorf("cannot create self-monitoring VMRule for vmauth: %w", err)
+		}
+	}
 
 	if err := CreateOrUpdateVMAuthConfig(ctx, rclient, cr); err != nil {
 		return err
@@ -85,6 +106,27 @@ func CreateOrUpdateVMAuth(ctx context.Context, cr *vmv1beta1.VMAuth, rclient cli
 			return fmt.Errorf("cannot update pod disruption budget for vmauth: %w", err)
 		}
 	}
+	if cr.Spec.NetworkPolicy.IsEnabled() {
+		var prevNP *networkingv1.NetworkPolicy
+		if prevCR != nil && prevCR.Spec.NetworkPolicy.IsEnabled() {
+			prevNP = build.NetworkPolicy(prevCR, prevCR.Spec.NetworkPolicy, nil)
+		}
+		if err := reconcile.NetworkPolicy(ctx, rclient, build.NetworkPolicy(cr, cr.Spec.NetworkPolicy, nil), prevNP); err != nil {
+			return fmt.Errorf("cannot update network policy for vmauth: %w", err)
+		}
+	}
+	if cr.Spec.VPA != nil {
+		var prevVPA *unstructured.Unstructured
+		if prevCR != nil && prevCR.Spec.VPA != nil {
+			prevVPA = buildVPA(prevCR)
+		}
+		if err := reconcile.VPA(ctx, rclient, buildVPA(cr), prevVPA); err != nil {
+			return fmt.Errorf("cannot update vertical pod autoscaler for vmauth: %w", err)
+		}
+	}
+	if err := reconcile.ServerTLSSecret(ctx, rclient, cr.Spec.ServerTLS, metav1.ObjectMeta{Namespace: cr.Namespace, Labels: cr.AllLabels(), OwnerReferences: cr.AsOwner()}, cr.PrefixedName()); err != nil {
+		return fmt.Errorf("cannot reconcile serverTLS secret for vmauth: %w", err)
+	}
 	var prevDeploy *appsv1.Deployment
 	if prevCR != nil {
 		prevDeploy, err = newDeployForVMAuth(prevCR)
@@ -113,6 +155,10 @@ func newDeployForVMAuth(cr *vmv1beta1.VMAuth) (*appsv1.Deployment, error) {
 		return nil, err
 	}
 
+	strategyType := appsv1.RollingUpdateDeploymentStrategyType
+	if cr.Spec.UpdateStrategy != nil {
+		strategyType = *cr.Spec.UpdateStrategy
+	}
 	depSpec := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            cr.PrefixedName(),
@@ -126,13 +172,17 @@ func newDeployForVMAuth(cr *vmv1beta1.VMAuth) (*appsv1.Deployment, error) {
 				MatchLabels: cr.SelectorLabels(),
 			},
 			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				Type:          strategyType,
+				RollingUpdate: cr.Spec.RollingUpdate,
 			},
 			Template: *podSpec,
 		},
 	}
 	build.DeploymentAddCommonParams(depSpec, ptr.Deref(cr.Spec.UseStrictSecurity, false), &cr.Spec.CommonApplicationDeploymentParams)
 
+	if err := build.PodTemplateAddPatches(&depSpec.Spec.Template, cr.Spec.PodTemplatePatches); err != nil {
+		return nil, err
+	}
 	return depSpec, nil
 }
 
@@ -236,8 +286,6 @@ func makeSpecForVMAuth(cr *vmv1beta1.VMAuth) (*corev1.PodTemplateSpec, error) {
 
 	case cr.Spec.ExternalConfig.LocalPath != "":
 		// no-op external managed configuration
-		// add check interval
-		args = append(args, "-configCheckInterval=1m")
 	default:
 		volumes = append(volumes, corev1.Volume{
 			Name: "config-out",
@@ -268,13 +316,25 @@ func makeSpecForVMAuth(cr *vmv1beta1.VMAuth) (*corev1.PodTemplateSpec, error) {
 		operatorContainers = append(operatorContainers, configReloader)
 		initContainers = append(initContainers,
 			buildInitConfigContainer(useCustomConfigReloader, cr.Spec.ConfigReloaderImageTag, cr.Spec.ConfigReloaderResources, configReloader.Args)...)
-		build.AddStrictSecuritySettingsToContainers(cr.Spec.SecurityContext, initContainers, useStrictSecurity)
+		build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.SecurityContext, initContainers, useStrictSecurity)
+	}
+	// externally managed configuration has no config-reloader
+	// vmauth must check file changes by itself in order to apply it without restart
+	configCheckInterval := cr.Spec.ConfigCheckInterval
+	if configCheckInterval == "" && (cr.Spec.ExternalConfig.SecretRef != nil || cr.Spec.ExternalConfig.LocalPath != "") {
+		configCheckInterval = defaultConfigCheckInterval
+	}
+	if configCheckInterval != "" {
+		args = append(args, fmt.Sprintf("-configCheckInterval=%s", configCheckInterval))
 	}
 	ic, err := k8stools.MergePatchContainers(initContainers, cr.Spec.InitContainers)
 	if err != nil {
 		return nil, fmt.Errorf("cannot apply patch for initContainers: %w", err)
 	}
 
+	volumes, volumeMounts = cr.Spec.ServerTLS.MaybeAddToVolumes(volumes, volumeMounts, vmv1beta1.ServerTLSDir)
+	args = cr.Spec.ServerTLS.MaybeAddToArgs(args, vmv1beta1.ServerTLSDir)
+
 	args = build.AddExtraArgsOverrideDefaults(args, cr.Spec.ExtraArgs, "-")
 	sort.Strings(args)
 
@@ -286,6 +346,7 @@ func makeSpecForVMAuth(cr *vmv1beta1.VMAuth) (*corev1.PodTemplateSpec, error) {
 		VolumeMounts:             volumeMounts,
 		Resources:                cr.Spec.Resources,
 		Env:                      envs,
+		Lifecycle:                cr.Spec.Lifecycle,
 		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		ImagePullPolicy:          cr.Spec.Image.PullPolicy,
 	}
@@ -294,11 +355,12 @@ func makeSpecForVMAuth(cr *vmv1beta1.VMAuth) (*corev1.PodTemplateSpec, error) {
 	// move vmauth container to the 0 index
 	operatorContainers = append([]corev1.Container{vmauthContainer}, operatorContainers...)
 
-	build.AddStrictSecuritySettingsToContainers(cr.Spec.SecurityContext, operatorContainers, useStrictSecurity)
+	build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.SecurityContext, operatorContainers, useStrictSecurity)
 	containers, err := k8stools.MergePatchContainers(operatorContainers, cr.Spec.Containers)
 	if err != nil {
 		return nil, err
 	}
+	ic, containers = build.AddSidecars(ic, containers, cr.Spec.Sidecars)
 
 	vmAuthSpec := &corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
@@ -371,89 +433,9 @@ func buildConfigSecretMeta(cr *vmv1beta1.VMAuth) metav1.ObjectMeta {
 	}
 }
 
-// createOrUpdateVMAuthIngress handles ingress for vmauth.
-func createOrUpdateVMAuthIngress(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAuth) error {
-	if cr.Spec.Ingress == nil {
-		return nil
-	}
-	newIngress := buildIngressConfig(cr)
-	var existIngress networkingv1.Ingress
-	if err := rclient.Get(ctx, types.NamespacedName{Namespace: newIngress.Namespace, Name: newIngress.Name}, &existIngress); err != nil {
-		if errors.IsNotFound(err) {
-			return rclient.Create(ctx, newIngress)
-		}
-		return err
-	}
-	if err := finalize.FreeIfNeeded(ctx, rclient, &existIngress); err != nil {
-		return err
-	}
-	// TODO compare
-	newIngress.Annotations = labels.Merge(existIngress.Annotations, newIngress.Annotations)
-	vmv1beta1.AddFinalizer(newIngress, &existIngress)
-	return rclient.Update(ctx, newIngress)
-}
-
-var defaultPt = networkingv1.PathTypePrefix
-
-func buildIngressConfig(cr *vmv1beta1.VMAuth) *networkingv1.Ingress {
-	defaultRule := networkingv1.IngressRule{
-		Host: cr.Spec.Ingress.Host,
-		IngressRuleValue: networkingv1.IngressRuleValue{
-			HTTP: &networkingv1.HTTPIngressRuleValue{
-				Paths: []networkingv1.HTTPIngressPath{
-					{
-						Path: "/",
-						Backend: networkingv1.IngressBackend{
-							Service: &networkingv1.IngressServiceBackend{
-								Name: cr.PrefixedName(),
-								Port: networkingv1.ServiceBackendPort{Name: "http"},
-							},
-						},
-						PathType: &defaultPt,
-					},
-				},
-			},
-		},
-	}
-	spec := networkingv1.IngressSpec{
-		Rules:            []networkingv1.IngressRule{},
-		IngressClassName: cr.Spec.Ingress.ClassName,
-	}
-	if cr.Spec.Ingress.TlsSecretName != "" {
-		spec.TLS = []networkingv1.IngressTLS{
-			{
-				SecretName: cr.Spec.Ingress.TlsSecretName,
-				Hosts:      cr.Spec.Ingress.TlsHosts,
-			},
-		}
-		for _, host := range cr.Spec.Ingress.TlsHosts {
-			hostRule := defaultRule.DeepCopy()
-			hostRule.Host = host
-			spec.Rules = append(spec.Rules, *hostRule)
-		}
-	} else {
-		spec.Rules = append(spec.Rules, defaultRule)
-	}
-	// add user defined routes.
-	spec.Rules = append(spec.Rules, cr.Spec.Ingress.ExtraRules...)
-	spec.TLS = append(spec.TLS, cr.Spec.Ingress.ExtraTLS...)
-	lbls := labels.Merge(cr.Spec.Ingress.Labels, cr.SelectorLabels())
-	return &networkingv1.Ingress{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:            cr.PrefixedName(),
-			Namespace:       cr.Namespace,
-			Labels:          lbls,
-			Annotations:     cr.Spec.Ingress.Annotations,
-			OwnerReferences: cr.AsOwner(),
-			Finalizers:      []string{vmv1beta1.FinalizerName},
-		},
-		Spec: spec,
-	}
-}
-
 func buildVMAuthConfigReloaderContainer(cr *vmv1beta1.VMAuth) corev1.Container {
 	configReloaderArgs := []string{
-		fmt.Sprintf("--reload-url=%s", vmv1beta1.BuildReloadPathWithPort(cr.Spec.ExtraArgs, cr.Spec.Port)),
+		fmt.Sprintf("--reload-url=%s", vmv1beta1.BuildReloadPathWithPort(cr.Spec.ExtraArgsWithServerTLS(), cr.Spec.Port)),
 		fmt.Sprintf("--config-envsubst-file=%s", path.Join(vmAuthConfigFolder, vmAuthConfigName)),
 	}
 	useCustomConfigReloader := ptr.Deref(cr.Spec.UseVMConfigReloader, false)
@@ -570,9 +552,11 @@ func gzipConfig(buf *bytes.Buffer, conf []byte) error {
 // createOrUpdateVMAuthService creates service for VMAuth
 func createOrUpdateVMAuthService(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMAuth) (*corev1.Service, error) {
 	var prevService, prevAdditionalService *corev1.Service
+	var prevAdditionalServices []*corev1.Service
 	if prevCR != nil {
 		prevService = build.Service(prevCR, prevCR.Spec.Port, nil)
 		prevAdditionalService = build.AdditionalServiceFromDefault(prevService, prevCR.Spec.ServiceSpec)
+		prevAdditionalServices = build.AdditionalServicesFromDefault(prevService, prevCR.Spec.AdditionalServices)
 	}
 	newService := build.Service(cr, cr.Spec.Port, nil)
 	if err := cr.Spec.ServiceSpec.IsSomeAndThen(func(s *vmv1beta1.AdditionalServiceSpec) error {
@@ -588,6 +572,10 @@ func createOrUpdateVMAuthService(ctx context.Context, rclient client.Client, cr,
 		return nil, err
 	}
 
+	if err := reconcile.Services(ctx, rclient, build.AdditionalServicesFromDefault(newService, cr.Spec.AdditionalServices), prevAdditionalServices); err != nil {
+		return nil, fmt.Errorf("cannot reconcile additional services for vmauth: %w", err)
+	}
+
 	if err := reconcile.Service(ctx, rclient, newService, prevService); err != nil {
 		return nil, fmt.Errorf("cannot reconcile service for vmauth: %w", err)
 	}
@@ -603,6 +591,9 @@ func deletePrevStateResources(ctx context.Context, rclient client.Client, cr, pr
 	if err := reconcile.AdditionalServices(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevSvc, currSvc); err != nil {
 		return fmt.Errorf("cannot remove additional service: %w", err)
 	}
+	if err := reconcile.AdditionalServiceList(ctx, rclient, cr.Namespace, prevCR.Spec.AdditionalServices, cr.Spec.AdditionalServices); err != nil {
+		return fmt.Errorf("cannot remove additional services: %w", err)
+	}
 
 	objMeta := metav1.ObjectMeta{Name: cr.PrefixedName(), Namespace: cr.Namespace}
 	if cr.Spec.PodDisruptionBudget == nil && prevCR.Spec.PodDisruptionBudget != nil {
@@ -610,17 +601,47 @@ func deletePrevStateResources(ctx context.Context, rclient client.Client, cr, pr
 			return fmt.Errorf("cannot delete PDB from prev state: %w", err)
 		}
 	}
+	if !cr.Spec.NetworkPolicy.IsEnabled() && prevCR.Spec.NetworkPolicy.IsEnabled() {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &networkingv1.NetworkPolicy{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot delete NetworkPolicy from prev state: %w", err)
+		}
+	}
+	if cr.Spec.VPA == nil && prevCR.Spec.VPA != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, build.NewVPA(cr.PrefixedName(), cr.Namespace)); err != nil {
+			return fmt.Errorf("cannot delete VPA from prev state: %w", err)
+		}
+	}
 
 	if cr.Spec.Ingress == nil && prevCR.Spec.Ingress != nil {
 		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &networkingv1.Ingress{ObjectMeta: objMeta}); err != nil {
 			return fmt.Errorf("cannot delete ingress from prev state: %w", err)
 		}
 	}
+	if cr.Spec.Route == nil && prevCR.Spec.Route != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, build.NewRoute(cr.PrefixedName(), cr.Namespace)); err != nil {
+			return fmt.Errorf("cannot delete route from prev state: %w", err)
+		}
+	}
 	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(prevCR.Spec.DisableSelfServiceScrape, false) {
 		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta}); err != nil {
 			return fmt.Errorf("cannot remove serviceScrape: %w", err)
 		}
 	}
+	if !ptr.Deref(cr.Spec.SelfMonitoringRules, false) && ptr.Deref(prevCR.Spec.SelfMonitoringRules, false) {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMRule{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot remove self-monitoring VMRule: %w", err)
+		}
+	}
 
 	return nil
 }
+
+// buildVPA creates VerticalPodAutoscaler for vmauth Deployment
+func buildVPA(cr *vmv1beta1.VMAuth) *unstructured.Unstructured {
+	targetRef := autoscalingv2.CrossVersionObjectReference{
+		Name:       cr.PrefixedName(),
+		Kind:       "Deployment",
+		APIVersion: "apps/v1",
+	}
+	return build.VPA(cr, targetRef, "vmauth", cr.Spec.VPA)
+}
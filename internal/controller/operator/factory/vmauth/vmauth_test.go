@@ -2,17 +2,20 @@ package vmauth
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
-	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 )
 
@@ -44,7 +47,7 @@ func TestCreateOrUpdateVMAuth(t *testing.T) {
 				c: config.MustGetBaseConfig(),
 			},
 			predefinedObjects: []runtime.Object{
-				k8stools.NewReadyDeployment("vmauth-test", "default"),
+				testutil.NewReadyDeployment("vmauth-test", "default"),
 			},
 		},
 		{
@@ -68,7 +71,7 @@ func TestCreateOrUpdateVMAuth(t *testing.T) {
 				c: config.MustGetBaseConfig(),
 			},
 			predefinedObjects: []runtime.Object{
-				k8stools.NewReadyDeployment("vmauth-test", "default"),
+				testutil.NewReadyDeployment("vmauth-test", "default"),
 				&corev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "external-cfg",
@@ -95,7 +98,7 @@ func TestCreateOrUpdateVMAuth(t *testing.T) {
 				c: config.MustGetBaseConfig(),
 			},
 			predefinedObjects: []runtime.Object{
-				k8stools.NewReadyDeployment("vmauth-test", "default"),
+				testutil.NewReadyDeployment("vmauth-test", "default"),
 				&vmv1beta1.VMUser{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "user-1",
@@ -132,7 +135,7 @@ func TestCreateOrUpdateVMAuth(t *testing.T) {
 				}),
 			},
 			predefinedObjects: []runtime.Object{
-				k8stools.NewReadyDeployment("vmauth-test", "default"),
+				testutil.NewReadyDeployment("vmauth-test", "default"),
 				&vmv1beta1.VMUser{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "user-1",
@@ -156,7 +159,7 @@ func TestCreateOrUpdateVMAuth(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			tc := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			tc := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			// TODO fix
 			if err := CreateOrUpdateVMAuth(ctx, tt.args.cr, tc); (err != nil) != tt.wantErr {
 				t.Errorf("CreateOrUpdateVMAuth() error = %v, wantErr %v", err, tt.wantErr)
@@ -169,7 +172,7 @@ func TestMakeSpecForAuthOk(t *testing.T) {
 	f := func(t *testing.T, cr *vmv1beta1.VMAuth, wantYaml string) {
 		t.Helper()
 
-		scheme := k8stools.GetTestClientWithObjects(nil).Scheme()
+		scheme := testutil.GetTestClientWithObjects(nil).Scheme()
 		build.AddDefaults(scheme)
 		scheme.Default(cr)
 		var wantSpec corev1.PodSpec
@@ -447,3 +450,62 @@ serviceaccountname: vmauth-auth
 `)
 	})
 }
+
+func TestMakeSpecForAuthConfigCheckInterval(t *testing.T) {
+	f := func(spec vmv1beta1.VMAuthSpec, wantArg string) {
+		t.Helper()
+		cr := &vmv1beta1.VMAuth{
+			ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: "default"},
+			Spec:       spec,
+		}
+		scheme := testutil.GetTestClientWithObjects(nil).Scheme()
+		build.AddDefaults(scheme)
+		scheme.Default(cr)
+		got, err := makeSpecForVMAuth(cr)
+		if err != nil {
+			t.Fatalf("not expected error=%q", err)
+		}
+		var gotArg string
+		for _, arg := range got.Spec.Containers[0].Args {
+			if strings.HasPrefix(arg, "-configCheckInterval=") {
+				gotArg = arg
+			}
+		}
+		assert.Equal(t, wantArg, gotArg)
+	}
+	secretRef := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "external-config"},
+		Key:                  "config.yaml",
+	}
+
+	// generated config is reloaded by config-reloader
+	f(vmv1beta1.VMAuthSpec{}, "")
+	f(vmv1beta1.VMAuthSpec{ConfigCheckInterval: "5m"}, "-configCheckInterval=5m")
+
+	// external config is checked by vmauth
+	f(vmv1beta1.VMAuthSpec{ExternalConfig: vmv1beta1.ExternalConfig{SecretRef: secretRef}}, "-configCheckInterval=1m")
+	f(vmv1beta1.VMAuthSpec{ExternalConfig: vmv1beta1.ExternalConfig{LocalPath: "/etc/vmauth/config.yaml"}}, "-configCheckInterval=1m")
+	f(vmv1beta1.VMAuthSpec{ExternalConfig: vmv1beta1.ExternalConfig{SecretRef: secretRef}, ConfigCheckInterval: "0"}, "-configCheckInterval=0")
+}
+
+func TestNewDeployForVMAuthStrategy(t *testing.T) {
+	f := func(spec vmv1beta1.VMAuthSpec, want appsv1.DeploymentStrategy) {
+		t.Helper()
+		cr := &vmv1beta1.VMAuth{
+			ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: "default"},
+			Spec:       spec,
+		}
+		got, err := newDeployForVMAuth(cr)
+		if err != nil {
+			t.Fatalf("not expected error=%q", err)
+		}
+		assert.Equal(t, want, got.Spec.Strategy)
+	}
+	f(vmv1beta1.VMAuthSpec{}, appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType})
+	f(vmv1beta1.VMAuthSpec{UpdateStrategy: ptr.To(appsv1.RecreateDeploymentStrategyType)}, appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType})
+	ru := &appsv1.RollingUpdateDeployment{
+		MaxSurge:       ptr.To(intstr.FromInt(0)),
+		MaxUnavailable: ptr.To(intstr.FromString("10%")),
+	}
+	f(vmv1beta1.VMAuthSpec{RollingUpdate: ru}, appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType, RollingUpdate: ru})
+}
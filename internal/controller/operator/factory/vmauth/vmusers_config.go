@@ -8,15 +8,18 @@ import (
 	"math/big"
 	"net/url"
 	"path"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/configstat"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/tracing"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -33,6 +36,8 @@ type skipableVMUsers struct {
 	stopIter      bool
 	users         []*vmv1beta1.VMUser
 	brokenVMUsers []*vmv1beta1.VMUser
+	// previous passwords of users, which are still valid after rotation
+	previousPasswords map[*vmv1beta1.VMUser]string
 }
 
 // visitAll visits all users objects
@@ -57,12 +62,13 @@ func (sus *skipableVMUsers) deduplicateBy(cb func(user *vmv1beta1.VMUser) (strin
 	// later map[key]index could be re-place with map[key]tuple(index,timestamp)
 	uniqByIndex := make(map[string]int, len(sus.users))
 	var cnt int
-	for idx, user := range sus.users {
+	for _, user := range sus.users {
 		key, createdAt := cb(user)
 		prevIdx, ok := uniqByIndex[key]
 		if !ok {
 			// fast path
-			uniqByIndex[key] = idx
+			// store index at filtered slice, since users are filtered in-place
+			uniqByIndex[key] = cnt
 			sus.users[cnt] = user
 			cnt++
 			continue
@@ -86,7 +92,10 @@ func (sus *skipableVMUsers) sort() {
 }
 
 // builds vmauth config.
-func buildVMAuthConfig(ctx context.Context, rclient client.Client, vmauth *vmv1beta1.VMAuth, tlsAssets map[string]string) ([]byte, error) {
+func buildVMAuthConfig(ctx context.Context, rclient client.Client, vmauth *vmv1beta1.VMAuth, tlsAssets map[string]string) (_ []byte, resultErr error) {
+	ctx, span := tracing.StartForObject(ctx, "vmauth.generateConfig", vmauth)
+	defer func() { tracing.End(span, resultErr) }()
+	startTime := time.Now()
 	// fetch exist users for vmauth.
 	users, err := selectVMUsers(ctx, vmauth, rclient)
 	if err != nil {
@@ -136,6 +145,8 @@ func buildVMAuthConfig(ctx context.Context, rclient client.Client, vmauth *vmv1b
 	if err := reconcile.StatusForChildObjects(ctx, rclient, parentObject, sus.brokenVMUsers); err != nil {
 		return nil, fmt.Errorf("cannot update statuses for broken vmusers: %w", err)
 	}
+	configstat.ObserveGeneration(ctx, "vmauth", vmauth, startTime, len(cfg))
+	configstat.SetSelectedObjects("vmauth", vmauth, "VMUser", len(sus.users))
 	return cfg, nil
 }
 
@@ -166,6 +177,10 @@ func filterNonUniqUsers(sus *skipableVMUsers) {
 		if user.Spec.BearerToken != nil {
 			at = "bearerToken:" + *user.Spec.BearerToken
 		}
+		// tokens are matched by claims, so jwt users cannot be deduplicated by credentials
+		if user.Spec.JWT != nil {
+			at = "jwt:" + user.Namespace + "/" + user.Name
+		}
 		return at, user.CreationTimestamp.Time
 	})
 }
@@ -194,6 +209,8 @@ func getAsURLObject(ctx context.Context, rclient client.Client, objT objectWithU
 }
 
 func addAuthCredentialsBuildSecrets(ctx context.Context, rclient client.Client, sus *skipableVMUsers) (needToCreateSecrets []*corev1.Secret, needToUpdateSecrets []*corev1.Secret, resultErr error) {
+	ctx, span := tracing.Start(ctx, "vmauth.loadUserSecrets")
+	defer func() { tracing.End(span, resultErr) }()
 	dst := make(map[string]*corev1.Secret)
 
 	sus.visitAll(func(user *vmv1beta1.VMUser) bool {
@@ -218,6 +235,17 @@ func addAuthCredentialsBuildSecrets(ctx context.Context, rclient client.Client,
 			}
 			user.Spec.BearerToken = ptr.To(v)
 		}
+		if user.Spec.JWT != nil {
+			for j := range user.Spec.JWT.PublicKeySecrets {
+				keyRef := &user.Spec.JWT.PublicKeySecrets[j]
+				v, err := k8stools.GetCredFromSecret(ctx, rclient, user.Namespace, keyRef, fmt.Sprintf("%s/%s", user.Namespace, keyRef.Name), dst)
+				if err != nil {
+					user.Status.CurrentSyncError = fmt.Sprintf("cannot get jwt public key from secret: %q", err)
+					return false
+				}
+				user.Spec.JWT.PublicKeys = append(user.Spec.JWT.PublicKeys, v)
+			}
+		}
 
 		if !user.Spec.DisableSecretCreation {
 			var vmus corev1.Secret
@@ -236,7 +264,18 @@ func addAuthCredentialsBuildSecrets(ctx context.Context, rclient client.Client,
 
 			} else {
 				// secret exists, check it's state
-				if injectAuthSettings(&vmus, user) {
+				prevPassword, rotated, err := rotateGeneratedPassword(&vmus, user, time.Now())
+				if err != nil {
+					user.Status.CurrentSyncError = fmt.Sprintf("cannot rotate user password: %q", err)
+					return false
+				}
+				if prevPassword != "" {
+					if sus.previousPasswords == nil {
+						sus.previousPasswords = make(map[*vmv1beta1.VMUser]string)
+					}
+					sus.previousPasswords[user] = prevPassword
+				}
+				if injectAuthSettings(&vmus, user) || rotated {
 					needToUpdateSecrets = append(needToUpdateSecrets, &vmus)
 				}
 			}
@@ -280,6 +319,85 @@ func injectBackendAuthHeader(ctx context.Context, rclient client.Client, user *v
 	return nil
 }
 
+const (
+	passwordRotatedAtAnnotation = "operator.victoriametrics.com/password-rotated-at"
+	previousPasswordKey         = "previousPassword"
+)
+
+// rotateGeneratedPassword regenerates password at the given secret if rotation period passed
+// and revokes previous password after grace period.
+// It returns previous password if it's still valid and flag if secret must be updated.
+func rotateGeneratedPassword(secret *corev1.Secret, vmuser *vmv1beta1.VMUser, now time.Time) (string, bool, error) {
+	pr := vmuser.Spec.PasswordRotation
+	if pr == nil || !vmuser.Spec.GeneratePassword || vmuser.Spec.Password != nil || vmuser.Spec.BearerToken != nil {
+		return "", false, nil
+	}
+	rotationPeriod, gracePeriod := pr.Periods()
+	if rotationPeriod <= 0 {
+		return "", false, fmt.Errorf("incorrect rotationPeriod=%q", pr.RotationPeriod)
+	}
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, secret.Annotations[passwordRotatedAtAnnotation])
+	if err != nil || len(secret.Data["password"]) == 0 {
+		// secret was created before rotation was enabled, start schedule from now
+		secret.Annotations[passwordRotatedAtAnnotation] = now.Format(time.RFC3339)
+		if len(secret.Data["password"]) > 0 {
+			return "", true, nil
+		}
+		rotatedAt = now.Add(-rotationPeriod)
+	}
+	var needUpdate bool
+	if !now.Before(rotatedAt.Add(rotationPeriod)) {
+		pwd, err := genPassword()
+		if err != nil {
+			return "", false, fmt.Errorf("cannot generate password for user=%q: %w", vmuser.Name, err)
+		}
+		if prev := secret.Data["password"]; len(prev) > 0 {
+			secret.Data[previousPasswordKey] = prev
+		}
+		secret.Data["password"] = []byte(pwd)
+		rotatedAt = now
+		secret.Annotations[passwordRotatedAtAnnotation] = rotatedAt.Format(time.RFC3339)
+		needUpdate = true
+	}
+	prevPassword := string(secret.Data[previousPasswordKey])
+	if prevPassword != "" && !now.Before(rotatedAt.Add(gracePeriod)) {
+		delete(secret.Data, previousPasswordKey)
+		prevPassword = ""
+		needUpdate = true
+	}
+	return prevPassword, needUpdate, nil
+}
+
+// PasswordRotationRequeueAfter returns duration until the next password rotation or previous password revocation for the given vmuser secret
+// zero value means that rotation is not configured
+func PasswordRotationRequeueAfter(secret *corev1.Secret, vmuser *vmv1beta1.VMUser, now time.Time) time.Duration {
+	pr := vmuser.Spec.PasswordRotation
+	if pr == nil || !vmuser.Spec.GeneratePassword {
+		return 0
+	}
+	rotationPeriod, gracePeriod := pr.Periods()
+	rotatedAt, err := time.Parse(time.RFC3339, secret.Annotations[passwordRotatedAtAnnotation])
+	if err != nil || rotationPeriod <= 0 {
+		return rotationPeriod
+	}
+	next := rotatedAt.Add(rotationPeriod)
+	if len(secret.Data[previousPasswordKey]) > 0 && rotatedAt.Add(gracePeriod).Before(next) {
+		next = rotatedAt.Add(gracePeriod)
+	}
+	// round up to seconds, since rotation time is stored with seconds precision
+	d := next.Sub(now) + time.Second
+	if d < time.Second {
+		d = time.Second
+	}
+	return d
+}
+
 func injectAuthSettings(secret *corev1.Secret, vmuser *vmv1beta1.VMUser) bool {
 	if secret.Data == nil {
 		secret.Data = make(map[string][]byte)
@@ -293,6 +411,17 @@ func injectAuthSettings(secret *corev1.Secret, vmuser *vmv1beta1.VMUser) bool {
 		}
 	}
 
+	if vmuser.Spec.JWT != nil {
+		// tokens are issued by external identity provider
+		for _, key := range []string{"username", "password", "bearerToken"} {
+			if len(secret.Data[key]) > 0 {
+				needUpdate = true
+				delete(secret.Data, key)
+			}
+		}
+		return needUpdate
+	}
+
 	if vmuser.Spec.BearerToken != nil {
 		if len(secret.Data["username"]) > 0 || len(secret.Data["password"]) > 0 {
 			needUpdate = true
@@ -375,8 +504,9 @@ func fetchCRDRefURLs(ctx context.Context, rclient client.Client, sus *skipableVM
 	crdCacheURLCache := make(map[string]string)
 	var resultErr error
 	sus.visitAll(func(user *vmv1beta1.VMUser) bool {
-		for j := range user.Spec.TargetRefs {
-			ref := user.Spec.TargetRefs[j]
+		refs := expandClusterTenantRefs(user.Spec.TargetRefs)
+		for j := range refs {
+			ref := refs[j]
 			if ref.CRD == nil {
 				continue
 			}
@@ -429,7 +559,21 @@ func generateVMAuthConfig(cr *vmv1beta1.VMAuth, sus *skipableVMUsers, crdCache m
 			user.Status.CurrentSyncError = err.Error()
 			return false
 		}
+		// broken user config must not break vmauth configuration for other users
+		if err := validateUserCfg(userCfg); err != nil {
+			user.Status.CurrentSyncError = fmt.Sprintf("generated vmauth config for user is invalid: %s", err)
+			return false
+		}
+		// global ip filters are applied to users without own filters
+		// deprecated unauthorizedAccessConfig uses spec level options only for unauthorized_user
+		if len(cr.Spec.UnauthorizedAccessConfig) == 0 && user.Spec.IPFilters.IsEmpty() {
+			userCfg = addIPFiltersToYaml(userCfg, cr.Spec.IPFilters)
+		}
 		cfgUsers = append(cfgUsers, userCfg)
+		// previous password remains valid during rotation grace period
+		if prevPassword := sus.previousPasswords[user]; prevPassword != "" {
+			cfgUsers = append(cfgUsers, withPassword(userCfg, prevPassword))
+		}
 		return true
 	})
 
@@ -457,6 +601,100 @@ func generateVMAuthConfig(cr *vmv1beta1.VMAuth, sus *skipableVMUsers, crdCache m
 	return ac, nil
 }
 
+// dryRunUserCfg mirrors part of vmauth user config, which must be validated before publishing
+type dryRunUserCfg struct {
+	URLPrefix dryRunURLPrefix `yaml:"url_prefix,omitempty"`
+	URLMaps   []dryRunURLMap  `yaml:"url_map,omitempty"`
+	JWT       *yaml.MapSlice  `yaml:"jwt,omitempty"`
+	Username  string          `yaml:"username,omitempty"`
+	Token     string          `yaml:"bearer_token,omitempty"`
+	Rest      map[string]any  `yaml:",inline"`
+}
+
+type dryRunURLMap struct {
+	URLPrefix    dryRunURLPrefix `yaml:"url_prefix,omitempty"`
+	SrcPaths     []string        `yaml:"src_paths,omitempty"`
+	SrcHosts     []string        `yaml:"src_hosts,omitempty"`
+	SrcQueryArgs []string        `yaml:"src_query_args,omitempty"`
+	SrcHeaders   []string        `yaml:"src_headers,omitempty"`
+	Rest         map[string]any  `yaml:",inline"`
+}
+
+// dryRunURLPrefix could be defined as string or list of strings
+type dryRunURLPrefix []string
+
+// UnmarshalYAML implements yaml.Unmarshaler interface
+func (up *dryRunURLPrefix) UnmarshalYAML(unmarshal func(any) error) error {
+	var single string
+	if err := unmarshal(&single); err == nil {
+		*up = []string{single}
+		return nil
+	}
+	var list []string
+	if err := unmarshal(&list); err != nil {
+		return fmt.Errorf("url_prefix must be a string or list of strings: %w", err)
+	}
+	*up = list
+	return nil
+}
+
+func (up dryRunURLPrefix) validate() error {
+	if len(up) == 0 {
+		return fmt.Errorf("url_prefix cannot be empty")
+	}
+	for _, u := range up {
+		pu, err := url.Parse(u)
+		if err != nil {
+			return fmt.Errorf("cannot parse url_prefix=%q: %w", u, err)
+		}
+		if pu.Scheme != "http" && pu.Scheme != "https" {
+			return fmt.Errorf("unsupported scheme for url_prefix=%q: %q; must be http or https", u, pu.Scheme)
+		}
+		if pu.Host == "" {
+			return fmt.Errorf("missing hostname in url_prefix=%q", u)
+		}
+	}
+	return nil
+}
+
+// validateUserCfg performs the same checks for generated user config as vmauth does on config load
+func validateUserCfg(userCfg yaml.MapSlice) error {
+	data, err := yaml.Marshal(userCfg)
+	if err != nil {
+		return fmt.Errorf("cannot serialize config: %w", err)
+	}
+	var uc dryRunUserCfg
+	if err := yaml.Unmarshal(data, &uc); err != nil {
+		return fmt.Errorf("cannot parse config: %w", err)
+	}
+	if uc.Username == "" && uc.Token == "" && uc.JWT == nil {
+		return fmt.Errorf("missing username, bearer_token or jwt")
+	}
+	if len(uc.URLPrefix) == 0 && len(uc.URLMaps) == 0 {
+		return fmt.Errorf("missing url_prefix or url_map")
+	}
+	if len(uc.URLPrefix) > 0 {
+		if err := uc.URLPrefix.validate(); err != nil {
+			return err
+		}
+	}
+	for idx, um := range uc.URLMaps {
+		if err := um.URLPrefix.validate(); err != nil {
+			return fmt.Errorf("incorrect url_map at idx=%d: %w", idx, err)
+		}
+		if len(um.SrcPaths) == 0 && len(um.SrcHosts) == 0 && len(um.SrcQueryArgs) == 0 && len(um.SrcHeaders) == 0 {
+			return fmt.Errorf("incorrect url_map at idx=%d: missing src_paths, src_hosts, src_query_args or src_headers", idx)
+		}
+		// vmauth matches paths and hosts with anchored regexps
+		for _, expr := range append(um.SrcPaths, um.SrcHosts...) {
+			if _, err := regexp.Compile("^(?:" + expr + ")$"); err != nil {
+				return fmt.Errorf("incorrect url_map at idx=%d: cannot compile regexp=%q: %w", idx, expr, err)
+			}
+		}
+	}
+	return nil
+}
+
 func appendIfNotEmpty(src []string, key string, origin yaml.MapSlice) yaml.MapSlice {
 	if len(src) > 0 {
 		return append(origin, yaml.MapItem{
@@ -496,8 +734,12 @@ func buildUnauthorizedConfig(cr *vmv1beta1.VMAuth, cb *build.TLSConfigBuilder) (
 				Value: uua.MetricLabels,
 			})
 		}
+		opts := uua.VMUserConfigOptions
+		if opts.IPFilters.IsEmpty() {
+			opts.IPFilters = cr.Spec.IPFilters
+		}
 		var err error
-		result, err = addUserConfigOptionToYaml(result, uua.VMUserConfigOptions, cb)
+		result, err = addUserConfigOptionToYaml(result, opts, cb)
 		if err != nil {
 			return nil, err
 		}
@@ -656,8 +898,46 @@ func addIPFiltersToYaml(dst yaml.MapSlice, ipf vmv1beta1.VMUserIPFilters) yaml.M
 	return dst
 }
 
+// expandClusterTenantRefs replaces VMCluster refs with vmselect and vminsert refs
+// routed to the tenant of the given ref
+func expandClusterTenantRefs(refs []vmv1beta1.TargetRef) []vmv1beta1.TargetRef {
+	var hasClusterRefs bool
+	for _, ref := range refs {
+		if ref.CRD != nil && ref.CRD.Kind == "VMCluster" {
+			hasClusterRefs = true
+			break
+		}
+	}
+	if !hasClusterRefs {
+		return refs
+	}
+	dst := make([]vmv1beta1.TargetRef, 0, len(refs)+1)
+	for _, ref := range refs {
+		if ref.CRD == nil || ref.CRD.Kind != "VMCluster" {
+			dst = append(dst, ref)
+			continue
+		}
+		tenant := ref.CRD.Tenant
+		if tenant == "" {
+			tenant = "0"
+		}
+		selectRef := ref
+		selectRef.CRD = &vmv1beta1.CRDRef{Kind: "VMCluster/vmselect", Name: ref.CRD.Name, Namespace: ref.CRD.Namespace}
+		selectRef.Paths = addVMSelectPaths(nil)
+		selectRef.TargetPathSuffix = "/select/" + tenant
+
+		insertRef := ref
+		insertRef.CRD = &vmv1beta1.CRDRef{Kind: "VMCluster/vminsert", Name: ref.CRD.Name, Namespace: ref.CRD.Namespace}
+		insertRef.Paths = addVMInsertPaths(nil)
+		insertRef.TargetPathSuffix = "/insert/" + tenant
+		dst = append(dst, selectRef, insertRef)
+	}
+	return dst
+}
+
 // generates routing config for given target refs
 func genURLMaps(userName string, refs []vmv1beta1.TargetRef, result yaml.MapSlice, crdURLCache map[string]string) (yaml.MapSlice, error) {
+	refs = expandClusterTenantRefs(refs)
 	var urlMaps []yaml.MapSlice
 	handleRef := func(ref vmv1beta1.TargetRef) ([]string, error) {
 		var urlPrefixes []string
@@ -702,7 +982,8 @@ func genURLMaps(userName string, refs []vmv1beta1.TargetRef, result yaml.MapSlic
 		return urlPrefixes, nil
 	}
 	// fast path for single or empty route
-	if len(refs) == 1 && len(refs[0].Paths) < 2 {
+	// route with hosts, headers or query args matchers must be defined as url_map
+	if len(refs) == 1 && len(refs[0].Paths) < 2 && !hasSrcMatchers(refs[0]) {
 		srcPaths := refs[0].Paths
 		var isDefaultRoute bool
 		switch len(srcPaths) {
@@ -813,6 +1094,10 @@ func genURLMaps(userName string, refs []vmv1beta1.TargetRef, result yaml.MapSlic
 	return result, nil
 }
 
+func hasSrcMatchers(ref vmv1beta1.TargetRef) bool {
+	return len(ref.Hosts) > 0 || len(ref.SrcHeaders) > 0 || len(ref.SrcQueryArgs) > 0
+}
+
 // this function mutates user and fills missing fields,
 // such password or username.
 func genUserCfg(user *vmv1beta1.VMUser, crdURLCache map[string]string, cb *build.TLSConfigBuilder) (yaml.MapSlice, error) {
@@ -856,6 +1141,14 @@ func genUserCfg(user *vmv1beta1.VMUser, crdURLCache map[string]string, cb *build
 		})
 	}
 
+	if user.Spec.JWT != nil {
+		r = append(r, yaml.MapItem{
+			Key:   "jwt",
+			Value: genJWTCfg(user.Spec.JWT),
+		})
+		return r, nil
+	}
+
 	// fast path.
 	if token != "" {
 		r = append(r, yaml.MapItem{
@@ -884,6 +1177,38 @@ func genUserCfg(user *vmv1beta1.VMUser, crdURLCache map[string]string, cb *build
 	return r, nil
 }
 
+func genJWTCfg(jwt *vmv1beta1.VMUserJWT) yaml.MapSlice {
+	var r yaml.MapSlice
+	if len(jwt.PublicKeys) > 0 {
+		r = append(r, yaml.MapItem{Key: "public_keys", Value: jwt.PublicKeys})
+	}
+	if jwt.JWKSURL != "" {
+		r = append(r, yaml.MapItem{Key: "jwks_url", Value: jwt.JWKSURL})
+	}
+	if jwt.Issuer != "" {
+		r = append(r, yaml.MapItem{Key: "oidc", Value: yaml.MapSlice{{Key: "issuer", Value: jwt.Issuer}}})
+	}
+	if len(jwt.Audience) > 0 {
+		r = append(r, yaml.MapItem{Key: "audience", Value: jwt.Audience})
+	}
+	if jwt.SkipVerify {
+		r = append(r, yaml.MapItem{Key: "skip_verify", Value: true})
+	}
+	return r
+}
+
+// withPassword returns copy of user config with the given password
+func withPassword(userCfg yaml.MapSlice, password string) yaml.MapSlice {
+	dst := make(yaml.MapSlice, 0, len(userCfg))
+	for _, item := range userCfg {
+		if item.Key == "password" {
+			item.Value = password
+		}
+		dst = append(dst, item)
+	}
+	return dst
+}
+
 // simple password generation.
 // its kubernetes, strong security does not work there.
 var (
@@ -952,6 +1277,9 @@ func buildVMUserSecret(src *vmv1beta1.VMUser) (*corev1.Secret, error) {
 			return nil, fmt.Errorf("cannot generate password for user=%q: %w", src.Name, err)
 		}
 		src.Spec.Password = ptr.To(pwd)
+		if src.Spec.PasswordRotation != nil {
+			s.Annotations[passwordRotatedAtAnnotation] = time.Now().Format(time.RFC3339)
+		}
 	}
 	if src.Spec.Name != nil {
 		s.Data["name"] = []byte(*src.Spec.Name)
@@ -8,7 +8,7 @@ import (
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
-	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
@@ -471,6 +471,99 @@ metric_labels:
   foo: bar
 username: basic
 password: pass
+`,
+		},
+		{
+			name: "vmcluster with tenant",
+			args: args{
+				user: &vmv1beta1.VMUser{
+					Spec: vmv1beta1.VMUserSpec{
+						Name:        ptr.To("user1"),
+						BearerToken: ptr.To("token"),
+						TargetRefs: []vmv1beta1.TargetRef{
+							{
+								CRD: &vmv1beta1.CRDRef{
+									Kind:      "VMCluster",
+									Name:      "main",
+									Namespace: "default",
+									Tenant:    "42",
+								},
+							},
+						},
+					},
+				},
+				crdURLCache: map[string]string{
+					"VMCluster/vmselect/default/main": "http://vmselect-main.default.svc:8481",
+					"VMCluster/vminsert/default/main": "http://vminsert-main.default.svc:8480",
+				},
+			},
+			want: `url_map:
+- url_prefix:
+  - http://vmselect-main.default.svc:8481/select/42
+  src_paths:
+  - /vmui.*
+  - /vmui/vmui
+  - /graph
+  - /prometheus/graph
+  - /prometheus/vmui.*
+  - /prometheus/api/v1/label.*
+  - /graphite.*
+  - /prometheus/api/v1/query.*
+  - /prometheus/api/v1/rules
+  - /prometheus/api/v1/alerts
+  - /prometheus/api/v1/metadata
+  - /prometheus/api/v1/rules
+  - /prometheus/api/v1/series.*
+  - /prometheus/api/v1/status.*
+  - /prometheus/api/v1/export.*
+  - /prometheus/federate
+  - /prometheus/api/v1/admin/tsdb/delete_series
+  - /admin/tenants
+  - /api/v1/status/.*
+  - /internal/resetRollupResultCache
+  - /prometheus/api/v1/admin/.*
+- url_prefix:
+  - http://vminsert-main.default.svc:8480/insert/42
+  src_paths:
+  - /newrelic/.*
+  - /opentelemetry/.*
+  - /prometheus/api/v1/write
+  - /prometheus/api/v1/import.*
+  - /influx/.*
+  - /datadog/.*
+name: user1
+bearer_token: token
+`,
+		},
+		{
+			name: "single route with src_headers",
+			args: args{
+				user: &vmv1beta1.VMUser{
+					Spec: vmv1beta1.VMUserSpec{
+						Name:     ptr.To("user1"),
+						UserName: ptr.To("basic"),
+						Password: ptr.To("pass"),
+						TargetRefs: []vmv1beta1.TargetRef{
+							{
+								Static: &vmv1beta1.StaticRef{URL: "http://vmselect"},
+								URLMapCommon: vmv1beta1.URLMapCommon{
+									SrcHeaders: []string{"TenantID: 1"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: `url_map:
+- url_prefix:
+  - http://vmselect
+  src_paths:
+  - /.*
+  src_headers:
+  - 'TenantID: 1'
+name: user1
+username: basic
+password: pass
 `,
 		},
 	}
@@ -518,6 +611,151 @@ func Test_genPassword(t *testing.T) {
 	}
 }
 
+func Test_rotateGeneratedPassword(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	user := &vmv1beta1.VMUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "user-1", Namespace: "default"},
+		Spec: vmv1beta1.VMUserSpec{
+			GeneratePassword: true,
+			PasswordRotation: &vmv1beta1.VMUserPasswordRotation{RotationPeriod: "24h", GracePeriod: "1h"},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{passwordRotatedAtAnnotation: now.Add(-time.Hour).Format(time.RFC3339)},
+		},
+		Data: map[string][]byte{"password": []byte("old-password")},
+	}
+
+	// rotation period is not passed yet
+	prev, needUpdate, err := rotateGeneratedPassword(secret, user, now)
+	assert.NoError(t, err)
+	assert.False(t, needUpdate)
+	assert.Empty(t, prev)
+	assert.Equal(t, 23*time.Hour+time.Second, PasswordRotationRequeueAfter(secret, user, now))
+
+	// password is rotated, previous password is kept
+	now = now.Add(23 * time.Hour)
+	prev, needUpdate, err = rotateGeneratedPassword(secret, user, now)
+	assert.NoError(t, err)
+	assert.True(t, needUpdate)
+	assert.Equal(t, "old-password", prev)
+	assert.NotEqual(t, "old-password", string(secret.Data["password"]))
+	assert.Equal(t, now.Format(time.RFC3339), secret.Annotations[passwordRotatedAtAnnotation])
+	assert.Equal(t, time.Hour+time.Second, PasswordRotationRequeueAfter(secret, user, now))
+
+	// previous password is still valid within grace period
+	prev, needUpdate, err = rotateGeneratedPassword(secret, user, now.Add(30*time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, needUpdate)
+	assert.Equal(t, "old-password", prev)
+
+	// previous password is revoked after grace period
+	prev, needUpdate, err = rotateGeneratedPassword(secret, user, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, needUpdate)
+	assert.Empty(t, prev)
+	assert.NotContains(t, secret.Data, previousPasswordKey)
+
+	// rotation is not configured
+	user.Spec.PasswordRotation = nil
+	prev, needUpdate, err = rotateGeneratedPassword(secret, user, now.Add(48*time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, needUpdate)
+	assert.Empty(t, prev)
+}
+
+func Test_deduplicateBy(t *testing.T) {
+	now := time.Now()
+	newUser := func(name, token string, createdAt time.Time) *vmv1beta1.VMUser {
+		return &vmv1beta1.VMUser{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(createdAt)},
+			Spec:       vmv1beta1.VMUserSpec{BearerToken: ptr.To(token)},
+		}
+	}
+	sus := &skipableVMUsers{users: []*vmv1beta1.VMUser{
+		newUser("user-1", "token-1", now),
+		newUser("user-2", "token-1", now.Add(time.Minute)),
+		newUser("user-3", "token-2", now.Add(time.Minute)),
+		newUser("user-4", "token-2", now),
+	}}
+	sus.deduplicateBy(func(user *vmv1beta1.VMUser) (string, time.Time) {
+		return *user.Spec.BearerToken, user.CreationTimestamp.Time
+	})
+	var got, gotBroken []string
+	for _, user := range sus.users {
+		got = append(got, user.Name)
+	}
+	for _, user := range sus.brokenVMUsers {
+		gotBroken = append(gotBroken, user.Name)
+	}
+	assert.Equal(t, []string{"user-1", "user-4"}, got)
+	assert.Equal(t, []string{"user-2", "user-3"}, gotBroken)
+}
+
+func Test_validateUserCfg(t *testing.T) {
+	f := func(userCfg yaml.MapSlice, wantErr bool) {
+		t.Helper()
+		err := validateUserCfg(userCfg)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+	}
+	// valid url_prefix
+	f(yaml.MapSlice{
+		{Key: "url_prefix", Value: []string{"http://vmselect:8481"}},
+		{Key: "username", Value: "user"},
+	}, false)
+
+	// valid url_map
+	f(yaml.MapSlice{
+		{Key: "url_map", Value: []yaml.MapSlice{
+			{
+				{Key: "url_prefix", Value: "http://vmselect:8481"},
+				{Key: "src_paths", Value: []string{"/select/.*"}},
+			},
+		}},
+		{Key: "bearer_token", Value: "token"},
+	}, false)
+
+	// missing url_prefix
+	f(yaml.MapSlice{
+		{Key: "username", Value: "user"},
+	}, true)
+
+	// empty url_prefix
+	f(yaml.MapSlice{
+		{Key: "url_prefix", Value: []string{""}},
+		{Key: "username", Value: "user"},
+	}, true)
+
+	// missing auth
+	f(yaml.MapSlice{
+		{Key: "url_prefix", Value: []string{"http://vmselect:8481"}},
+	}, true)
+
+	// url_map without matchers
+	f(yaml.MapSlice{
+		{Key: "url_map", Value: []yaml.MapSlice{
+			{
+				{Key: "url_prefix", Value: []string{"http://vmselect:8481"}},
+			},
+		}},
+		{Key: "username", Value: "user"},
+	}, true)
+
+	// invalid src_paths regexp
+	f(yaml.MapSlice{
+		{Key: "url_map", Value: []yaml.MapSlice{
+			{
+				{Key: "url_prefix", Value: []string{"http://vmselect:8481"}},
+				{Key: "src_paths", Value: []string{"/api/(v1"}},
+			},
+		}},
+		{Key: "username", Value: "user"},
+	}, true)
+}
+
 func Test_selectVMUserSecrets(t *testing.T) {
 	type args struct {
 		vmUsers *skipableVMUsers
@@ -677,7 +915,7 @@ func Test_selectVMUserSecrets(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			testClient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			testClient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			got, got1, err := addAuthCredentialsBuildSecrets(context.TODO(), testClient, tt.args.vmUsers)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("selectVMUserSecrets() error = %v, wantErr %v", err, tt.wantErr)
@@ -1233,7 +1471,7 @@ func Test_buildVMAuthConfig(t *testing.T) {
 								Paths: []string{"/"},
 								Hosts: []string{"host.com"},
 								URLMapCommon: vmv1beta1.URLMapCommon{
-									// SrcQueryArgs&SrcHeaders here will be skipped cause there is only one default route
+									// src matchers must be rendered as url_map even for the single default route
 									SrcQueryArgs:        []string{"db=foo"},
 									SrcHeaders:          []string{"TenantID: 123:456"},
 									DiscoverBackendIPs:  ptr.To(true),
@@ -1298,17 +1536,26 @@ func Test_buildVMAuthConfig(t *testing.T) {
   - http://some-static-15
   name: user-11
   bearer_token: bearer
-- url_prefix:
-  - http://vmagent-test.default.svc:8429/prometheus?extra_label=key%3Dvalue
-  headers:
-  - 'X-Scope-OrgID: abc'
-  response_headers:
-  - 'X-Server-Hostname: a'
-  discover_backend_ips: true
-  retry_status_codes:
-  - 500
-  - 502
-  load_balancing_policy: first_available
+- url_map:
+  - url_prefix:
+    - http://vmagent-test.default.svc:8429/prometheus?extra_label=key%3Dvalue
+    src_paths:
+    - /.*
+    src_hosts:
+    - host.com
+    discover_backend_ips: true
+    src_headers:
+    - 'TenantID: 123:456'
+    src_query_args:
+    - db=foo
+    headers:
+    - 'X-Scope-OrgID: abc'
+    response_headers:
+    - 'X-Server-Hostname: a'
+    retry_status_codes:
+    - 500
+    - 502
+    load_balancing_policy: first_available
   name: user-15
   default_url:
   - https://default1:8888/unsupported_url_handler
@@ -2155,12 +2402,255 @@ unauthorized_user:
   load_balancing_policy: least_loaded
   drop_src_path_prefix_parts: 2
   dump_request_on_errors: true
+`,
+		},
+		{
+			name: "with global ip_filters",
+			args: args{
+				vmauth: &vmv1beta1.VMAuth{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-vmauth",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMAuthSpec{
+						SelectAllByDefault: true,
+						UnauthorizedUserAccessSpec: &vmv1beta1.VMAuthUnauthorizedUserAccessSpec{
+							URLPrefix: []string{"http://vmsingle-example.default.svc:8428"},
+						},
+						VMUserConfigOptions: vmv1beta1.VMUserConfigOptions{
+							IPFilters: vmv1beta1.VMUserIPFilters{
+								AllowList: []string{"10.0.0.0/8"},
+							},
+						},
+					},
+				},
+			},
+			predefinedObjects: []runtime.Object{
+				&vmv1beta1.VMUser{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "user-1",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMUserSpec{
+						BearerToken: ptr.To("bearer"),
+						TargetRefs: []vmv1beta1.TargetRef{
+							{
+								Static: &vmv1beta1.StaticRef{URL: "http://some-static"},
+							},
+						},
+					},
+				},
+				&vmv1beta1.VMUser{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "user-2",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMUserSpec{
+						BearerToken: ptr.To("bearer-token-2"),
+						TargetRefs: []vmv1beta1.TargetRef{
+							{
+								Static: &vmv1beta1.StaticRef{URL: "http://some-static"},
+							},
+						},
+						VMUserConfigOptions: vmv1beta1.VMUserConfigOptions{
+							IPFilters: vmv1beta1.VMUserIPFilters{
+								DenyList: []string{"10.0.0.42"},
+							},
+						},
+					},
+				},
+			},
+			want: `users:
+- url_prefix:
+  - http://some-static
+  bearer_token: bearer
+  ip_filters:
+    allow_list:
+    - 10.0.0.0/8
+- url_prefix:
+  - http://some-static
+  ip_filters:
+    deny_list:
+    - 10.0.0.42
+  bearer_token: bearer-token-2
+unauthorized_user:
+  url_prefix: http://vmsingle-example.default.svc:8428
+  ip_filters:
+    allow_list:
+    - 10.0.0.0/8
+`,
+		},
+		{
+			name: "with rotated password at grace period",
+			args: args{
+				vmauth: &vmv1beta1.VMAuth{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-vmauth",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMAuthSpec{
+						SelectAllByDefault: true,
+					},
+				},
+			},
+			predefinedObjects: []runtime.Object{
+				&vmv1beta1.VMUser{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "user-1",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMUserSpec{
+						GeneratePassword: true,
+						PasswordRotation: &vmv1beta1.VMUserPasswordRotation{RotationPeriod: "720h", GracePeriod: "1h"},
+						TargetRefs: []vmv1beta1.TargetRef{
+							{
+								Static: &vmv1beta1.StaticRef{URL: "http://some-static"},
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "vmuser-user-1",
+						Namespace:   "default",
+						Annotations: map[string]string{passwordRotatedAtAnnotation: time.Now().Format(time.RFC3339)},
+					},
+					Data: map[string][]byte{
+						"username":          []byte("user-1"),
+						"password":          []byte("new-password"),
+						previousPasswordKey: []byte("old-password"),
+					},
+				},
+			},
+			want: `users:
+- url_prefix:
+  - http://some-static
+  username: user-1
+  password: new-password
+- url_prefix:
+  - http://some-static
+  username: user-1
+  password: old-password
+`,
+		},
+		{
+			name: "with jwt auth",
+			args: args{
+				vmauth: &vmv1beta1.VMAuth{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-vmauth",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMAuthSpec{
+						SelectAllByDefault: true,
+					},
+				},
+			},
+			predefinedObjects: []runtime.Object{
+				&vmv1beta1.VMUser{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "user-1",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMUserSpec{
+						JWT: &vmv1beta1.VMUserJWT{
+							Issuer:   "https://idp.example.com",
+							Audience: []string{"vmauth"},
+							PublicKeySecrets: []corev1.SecretKeySelector{
+								{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "jwt-keys"},
+									Key:                  "key.pem",
+								},
+							},
+						},
+						TargetRefs: []vmv1beta1.TargetRef{
+							{
+								Static: &vmv1beta1.StaticRef{URL: "http://some-static"},
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "jwt-keys",
+						Namespace: "default",
+					},
+					Data: map[string][]byte{
+						"key.pem": []byte("public-key"),
+					},
+				},
+			},
+			want: `users:
+- url_prefix:
+  - http://some-static
+  jwt:
+    public_keys:
+    - public-key
+    oidc:
+      issuer: https://idp.example.com
+    audience:
+    - vmauth
+`,
+		},
+		{
+			name: "with invalid user config",
+			args: args{
+				vmauth: &vmv1beta1.VMAuth{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-vmauth",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMAuthSpec{
+						SelectAllByDefault: true,
+					},
+				},
+			},
+			predefinedObjects: []runtime.Object{
+				&vmv1beta1.VMUser{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "user-1",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMUserSpec{
+						UserName:    ptr.To("user-1"),
+						BearerToken: ptr.To("bearer"),
+						TargetRefs: []vmv1beta1.TargetRef{
+							{
+								Static: &vmv1beta1.StaticRef{URL: "http://some-static"},
+								Paths:  []string{"/api/(v1"},
+							},
+						},
+					},
+				},
+				&vmv1beta1.VMUser{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "user-2",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMUserSpec{
+						BearerToken: ptr.To("bearer-2"),
+						TargetRefs: []vmv1beta1.TargetRef{
+							{
+								Static: &vmv1beta1.StaticRef{URL: "http://some-static"},
+								Paths:  []string{"/api/v1/.*"},
+							},
+						},
+					},
+				},
+			},
+			want: `users:
+- url_map:
+  - url_prefix:
+    - http://some-static
+    src_paths:
+    - /api/v1/.*
+  bearer_token: bearer-2
 `,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			testClient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			testClient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			got, err := buildVMAuthConfig(context.TODO(), testClient, tt.args.vmauth, map[string]string{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("buildVMAuthConfig() error = %v, wantErr %v", err, tt.wantErr)
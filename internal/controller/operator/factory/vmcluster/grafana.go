@@ -0,0 +1,52 @@
+package vmcluster
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+// buildGrafanaDashboard returns grafana dashboard object for the cluster
+// dashboard version is defined by the image tag of the first configured component
+func buildGrafanaDashboard(cr *vmv1beta1.VMCluster) client.Object {
+	var imageTag string
+	switch {
+	case cr.Spec.VMSelect != nil:
+		imageTag = cr.Spec.VMSelect.Image.Tag
+	case cr.Spec.VMInsert != nil:
+		imageTag = cr.Spec.VMInsert.Image.Tag
+	case cr.Spec.VMStorage != nil:
+		imageTag = cr.Spec.VMStorage.Image.Tag
+	}
+	b := newOptsBuilder(cr, cr.PrefixedName(), cr.SelectorLabels())
+	return build.GrafanaDashboard(b, cr.GrafanaDashboardName(), cr.Spec.GrafanaDashboard, "victoriametrics-cluster", imageTag)
+}
+
+// createOrUpdateGrafanaDashboard reconciles grafana dashboard of the cluster
+// and removes dashboard object of previous state if it's no longer needed or has a different kind
+func createOrUpdateGrafanaDashboard(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMCluster) error {
+	var prevGD client.Object
+	if prevCR != nil && prevCR.Spec.GrafanaDashboard != nil {
+		prevSpec := prevCR.Spec.GrafanaDashboard
+		if cr.Spec.GrafanaDashboard == nil || cr.Spec.GrafanaDashboard.UseConfigMap() != prevSpec.UseConfigMap() {
+			if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, build.NewGrafanaDashboardObject(prevSpec, cr.GrafanaDashboardName(), cr.Namespace)); err != nil {
+				return fmt.Errorf("cannot delete grafana dashboard from prev state: %w", err)
+			}
+		} else {
+			prevGD = buildGrafanaDashboard(prevCR)
+		}
+	}
+	if cr.Spec.GrafanaDashboard == nil {
+		return nil
+	}
+	if err := reconcile.GrafanaDashboard(ctx, rclient, buildGrafanaDashboard(cr), prevGD); err != nil {
+		return fmt.Errorf("cannot update grafana dashboard for vmcluster: %w", err)
+	}
+	return nil
+}
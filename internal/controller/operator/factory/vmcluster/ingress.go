@@ -0,0 +1,46 @@
+package vmcluster
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+// buildVMSelectIngress returns Ingress for vmselect service or nil if it's not configured
+func buildVMSelectIngress(cr *vmv1beta1.VMCluster) *networkingv1.Ingress {
+	if cr.Spec.VMSelect == nil || cr.Spec.VMSelect.Ingress == nil {
+		return nil
+	}
+	b := newOptsBuilder(cr, cr.GetVMSelectName(), cr.VMSelectSelectorLabels())
+	return build.Ingress(b, cr.Spec.VMSelect.Ingress)
+}
+
+// createOrUpdateVMSelectIngress reconciles Ingress of vmselect
+// and removes it if ingress was removed since previous state
+func createOrUpdateVMSelectIngress(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMCluster) error {
+	var prevIngress *networkingv1.Ingress
+	if prevCR != nil {
+		prevIngress = buildVMSelectIngress(prevCR)
+	}
+	newIngress := buildVMSelectIngress(cr)
+	if newIngress == nil {
+		if prevIngress != nil {
+			if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: prevIngress.Name, Namespace: prevIngress.Namespace}}); err != nil {
+				return fmt.Errorf("cannot delete vmselect ingress from prev state: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := reconcile.Ingress(ctx, rclient, newIngress, prevIngress); err != nil {
+		return fmt.Errorf("cannot update ingress for vmselect: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package vmcluster
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+// buildNetworkPolicies returns NetworkPolicy for each cluster component
+// vmstorage accepts vminsert and vmselect connections only from the pods of the given cluster
+func buildNetworkPolicies(cr *vmv1beta1.VMCluster) []*networkingv1.NetworkPolicy {
+	if !cr.Spec.NetworkPolicy.IsEnabled() {
+		return nil
+	}
+	var nps []*networkingv1.NetworkPolicy
+	if cr.Spec.VMStorage != nil {
+		b := newOptsBuilder(cr, cr.GetVMStorageName(), cr.VMStorageSelectorLabels())
+		ports := []string{cr.Spec.VMStorage.Port}
+		if cr.Spec.VMStorage.VMBackup != nil {
+			ports = append(ports, cr.Spec.VMStorage.VMBackup.Port)
+		}
+		rules := []networkingv1.NetworkPolicyIngressRule{
+			{
+				From:  []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: cr.VMInsertSelectorLabels()}}},
+				Ports: build.NetworkPolicyPorts(corev1.ProtocolTCP, cr.Spec.VMStorage.VMInsertPort),
+			},
+			{
+				From:  []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: cr.VMSelectSelectorLabels()}}},
+				Ports: build.NetworkPolicyPorts(corev1.ProtocolTCP, cr.Spec.VMStorage.VMSelectPort),
+			},
+		}
+		nps = append(nps, build.NetworkPolicy(b, cr.Spec.NetworkPolicy, build.NetworkPolicyPorts(corev1.ProtocolTCP, ports...), rules...))
+	}
+	if cr.Spec.VMSelect != nil {
+		b := newOptsBuilder(cr, cr.GetVMSelectName(), cr.VMSelectSelectorLabels())
+		nps = append(nps, build.NetworkPolicy(b, cr.Spec.NetworkPolicy, nil))
+	}
+	if cr.Spec.VMInsert != nil {
+		b := newOptsBuilder(cr, cr.GetVMInsertName(), cr.VMInsertSelectorLabels())
+		nps = append(nps, build.NetworkPolicy(b, cr.Spec.NetworkPolicy, nil))
+	}
+	return nps
+}
+
+// createOrUpdateNetworkPolicies reconciles NetworkPolicies of cluster components
+// and removes policies of components disabled since previous state
+func createOrUpdateNetworkPolicies(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMCluster) error {
+	prevNPs := make(map[string]*networkingv1.NetworkPolicy)
+	if prevCR != nil {
+		for _, np := range buildNetworkPolicies(prevCR) {
+			prevNPs[np.Name] = np
+		}
+	}
+	for _, np := range buildNetworkPolicies(cr) {
+		if err := reconcile.NetworkPolicy(ctx, rclient, np, prevNPs[np.Name]); err != nil {
+			return fmt.Errorf("cannot update network policy %s: %w", np.Name, err)
+		}
+		delete(prevNPs, np.Name)
+	}
+	for _, np := range prevNPs {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, np); err != nil {
+			return fmt.Errorf("cannot delete NetworkPolicy from prev state: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+package vmcluster
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/podmetrics"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/promtext"
+)
+
+// approximate vmstorage capacity, see https://docs.victoriametrics.com/cluster-victoriametrics/#capacity-planning
+const (
+	// memory used by vmstorage per active time series
+	bytesPerActiveSeries = 1000
+	// samples per second processed by a single vmstorage CPU core
+	samplesPerCPUCore = 100_000
+	// VictoriaMetrics recommends to keep 50% of free memory and CPU for spikes
+	resourcesHeadroom = 2
+
+	minRecommendedCPUMillis = 100
+	cpuRoundMillis          = 100
+	minRecommendedMemory    = 256 * 1024 * 1024
+	memoryRoundBytes        = 256 * 1024 * 1024
+)
+
+// podMetrics holds metrics of the single cluster component pod
+type podMetrics struct {
+	activeSeries   float64
+	rowsAdded      float64
+	cpuSeconds     float64
+	residentMemory float64
+}
+
+// metricsSample holds metrics of cluster component pods observed at the single check
+type metricsSample struct {
+	ts        time.Time
+	vmstorage map[string]podMetrics
+	vmselect  map[string]podMetrics
+	vminsert  map[string]podMetrics
+}
+
+var (
+	metricsCacheMu sync.Mutex
+	// metricsCache holds metrics observed at the previous check by VMCluster namespaced name
+	// counters cannot be stored at status, since its changes trigger VMCluster reconcile
+	metricsCache = map[types.NamespacedName]metricsSample{}
+)
+
+// PruneRecommendationsMetrics removes metrics observed at the previous check for VMClusters missing at the given set
+func PruneRecommendationsMetrics(existing map[types.NamespacedName]struct{}) {
+	metricsCacheMu.Lock()
+	defer metricsCacheMu.Unlock()
+	for nsn := range metricsCache {
+		if _, ok := existing[nsn]; !ok {
+			delete(metricsCache, nsn)
+		}
+	}
+}
+
+// UpdateRecommendations reads cardinality, ingestion and resource usage metrics of cluster components
+// and reports advisory sizing at status.recommendations
+// recommendations are never applied to the cluster
+func UpdateRecommendations(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster) error {
+	sample := metricsSample{ts: time.Now()}
+	if cr.Spec.VMStorage != nil {
+		ep := podmetrics.EndpointFor(cr.Namespace, cr.GetVMStorageName(), portOrDefault(cr.Spec.VMStorage.Port, "8482"), cr.Spec.VMStorage.GetMetricPath(), &cr.Spec.VMStorage.CommonApplicationDeploymentParams)
+		pods, err := readPodsMetrics(ctx, rclient, cr.Namespace, cr.VMStorageSelectorLabels(), ep)
+		if err != nil {
+			return fmt.Errorf("cannot read vmstorage metrics: %w", err)
+		}
+		sample.vmstorage = pods
+	}
+	if cr.Spec.VMSelect != nil {
+		ep := podmetrics.EndpointFor(cr.Namespace, cr.GetVMSelectName(), portOrDefault(cr.Spec.VMSelect.Port, "8481"), cr.Spec.VMSelect.GetMetricPath(), &cr.Spec.VMSelect.CommonApplicationDeploymentParams)
+		pods, err := readPodsMetrics(ctx, rclient, cr.Namespace, cr.VMSelectSelectorLabels(), ep)
+		if err != nil {
+			return fmt.Errorf("cannot read vmselect metrics: %w", err)
+		}
+		sample.vmselect = pods
+	}
+	if cr.Spec.VMInsert != nil {
+		ep := podmetrics.EndpointFor(cr.Namespace, cr.GetVMInsertName(), portOrDefault(cr.Spec.VMInsert.Port, "8480"), cr.Spec.VMInsert.GetMetricPath(), &cr.Spec.VMInsert.CommonApplicationDeploymentParams)
+		pods, err := readPodsMetrics(ctx, rclient, cr.Namespace, cr.VMInsertSelectorLabels(), ep)
+		if err != nil {
+			return fmt.Errorf("cannot read vminsert metrics: %w", err)
+		}
+		sample.vminsert = pods
+	}
+	if len(sample.vmstorage) == 0 && len(sample.vmselect) == 0 && len(sample.vminsert) == 0 {
+		// keep the previous recommendations, cluster state is unknown
+		return nil
+	}
+	nsn := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}
+	metricsCacheMu.Lock()
+	prevSample, hasPrev := metricsCache[nsn]
+	metricsCache[nsn] = sample
+	metricsCacheMu.Unlock()
+
+	var prev *metricsSample
+	if hasPrev {
+		prev = &prevSample
+	}
+	rec := buildRecommendations(cr, sample, prev)
+	if equality.Semantic.DeepEqual(rec, cr.Status.Recommendations) {
+		return nil
+	}
+	data, err := buildRecommendationsPatch(cr.Status.Recommendations, rec)
+	if err != nil {
+		return fmt.Errorf("BUG: cannot serialize recommendations status patch: %w", err)
+	}
+	objToPatch := cr.DeepCopy()
+	if err := rclient.Status().Patch(ctx, objToPatch, client.RawPatch(types.MergePatchType, data)); err != nil {
+		return fmt.Errorf("cannot update recommendations status: %w", err)
+	}
+	cr.Status.Recommendations = rec
+	return nil
+}
+
+// buildRecommendationsPatch returns merge patch, which replaces status.recommendations with the given value
+// merge patch keeps fields missing at the patch, so fields cleared at the new value are explicitly set to null
+func buildRecommendationsPatch(prev, rec *vmv1beta1.VMClusterRecommendations) ([]byte, error) {
+	prevFields, err := toFields(prev)
+	if err != nil {
+		return nil, err
+	}
+	recFields, err := toFields(rec)
+	if err != nil {
+		return nil, err
+	}
+	setClearedFields(prevFields, recFields)
+	patch := map[string]any{
+		"status": map[string]any{
+			"recommendations": recFields,
+		},
+	}
+	return json.Marshal(patch)
+}
+
+func toFields(rec *vmv1beta1.VMClusterRecommendations) (map[string]any, error) {
+	fields := map[string]any{}
+	if rec == nil {
+		return fields, nil
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// setClearedFields sets to null fields present at prev and missing at curr
+func setClearedFields(prev, curr map[string]any) {
+	for k, prevValue := range prev {
+		currValue, ok := curr[k]
+		if !ok {
+			curr[k] = nil
+			continue
+		}
+		prevMap, prevOk := prevValue.(map[string]any)
+		currMap, currOk := currValue.(map[string]any)
+		if prevOk && currOk {
+			setClearedFields(prevMap, currMap)
+		}
+	}
+}
+
+// readPodsMetrics reads metrics of running pods matching the given labels
+// pods with failed requests are skipped
+func readPodsMetrics(ctx context.Context, rclient client.Client, namespace string, selector map[string]string, ep podmetrics.Endpoint) (map[string]podMetrics, error) {
+	var pods corev1.PodList
+	if err := rclient.List(ctx, &pods, client.InNamespace(namespace), client.MatchingLabels(selector)); err != nil {
+		return nil, fmt.Errorf("cannot list pods: %w", err)
+	}
+	result := make(map[string]podMetrics, len(pods.Items))
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		var m podMetrics
+		err := podmetrics.Read(ctx, rclient, ep, pod.Status.PodIP, func(r io.Reader) error {
+			var err error
+			m, err = parsePodMetrics(r)
+			return err
+		})
+		if err != nil {
+			logger.WithContext(ctx).Error(err, "cannot read pod metrics", "pod", pod.Name)
+			continue
+		}
+		result[pod.Name] = m
+	}
+	return result, nil
+}
+
+// buildRecommendations calculates cluster components sizing from the current and the previous metrics samples
+// recommendations from the previous status are used for components without observed pods
+// and for rates, if previous sample is missing, e.g. after operator restart
+func buildRecommendations(cr *vmv1beta1.VMCluster, sample metricsSample, prev *metricsSample) *vmv1beta1.VMClusterRecommendations {
+	prevRec := &vmv1beta1.VMClusterRecommendations{}
+	if cr.Status.Recommendations != nil {
+		prevRec = cr.Status.Recommendations.DeepCopy()
+	}
+	var prevStorage, prevSelect, prevInsert map[string]podMetrics
+	var elapsed float64
+	if prev != nil && sample.ts.After(prev.ts) {
+		prevStorage, prevSelect, prevInsert = prev.vmstorage, prev.vmselect, prev.vminsert
+		elapsed = sample.ts.Sub(prev.ts).Seconds()
+	}
+	rec := &vmv1beta1.VMClusterRecommendations{
+		VMSelectResources: componentResources(sample.vmselect, prevSelect, elapsed, prevRec.VMSelectResources),
+		VMInsertResources: componentResources(sample.vminsert, prevInsert, elapsed, prevRec.VMInsertResources),
+	}
+	if cr.Spec.VMStorage == nil {
+		return rec
+	}
+	if len(sample.vmstorage) == 0 {
+		rec.ActiveTimeSeries = prevRec.ActiveTimeSeries
+		rec.IngestionRate = prevRec.IngestionRate
+		rec.VMStorageResources = prevRec.VMStorageResources
+		rec.VMStorageReplicaCount = prevRec.VMStorageReplicaCount
+		return rec
+	}
+	var activeSeries float64
+	for _, m := range sample.vmstorage {
+		activeSeries += m.activeSeries
+	}
+	rec.ActiveTimeSeries = podmetrics.RoundSignificant(activeSeries)
+	if elapsed > 0 {
+		var rowsAdded float64
+		for name, m := range sample.vmstorage {
+			prevM, ok := prevStorage[name]
+			if !ok {
+				continue
+			}
+			rowsAdded += counterIncrease(prevM.rowsAdded, m.rowsAdded)
+		}
+		rec.IngestionRate = podmetrics.RoundSignificant(rowsAdded / elapsed)
+	} else {
+		rec.IngestionRate = prevRec.IngestionRate
+	}
+
+	replicas := int64(len(sample.vmstorage))
+	if cr.Spec.VMStorage.ReplicaCount != nil && *cr.Spec.VMStorage.ReplicaCount > 0 {
+		replicas = int64(*cr.Spec.VMStorage.ReplicaCount)
+	}
+	requiredMemory := rec.ActiveTimeSeries * bytesPerActiveSeries * resourcesHeadroom
+	memoryPerNode := roundUp(requiredMemory/replicas, memoryRoundBytes, minRecommendedMemory)
+	rec.VMStorageResources.Requests = corev1.ResourceList{
+		corev1.ResourceMemory: *resource.NewQuantity(memoryPerNode, resource.BinarySI),
+	}
+	rec.VMStorageResources.Limits = corev1.ResourceList{
+		corev1.ResourceMemory: *resource.NewQuantity(memoryPerNode, resource.BinarySI),
+	}
+	if rec.IngestionRate > 0 {
+		cpuPerNode := roundUp(rec.IngestionRate*1000*resourcesHeadroom/samplesPerCPUCore/replicas, cpuRoundMillis, minRecommendedCPUMillis)
+		rec.VMStorageResources.Requests[corev1.ResourceCPU] = *resource.NewMilliQuantity(cpuPerNode, resource.DecimalSI)
+	}
+
+	if memLimit, ok := cr.Spec.VMStorage.Resources.Limits[corev1.ResourceMemory]; ok && memLimit.Value() > 0 {
+		count := (requiredMemory + memLimit.Value() - 1) / memLimit.Value()
+		if cr.Spec.ReplicationFactor != nil {
+			count = max(count, int64(*cr.Spec.ReplicationFactor))
+		}
+		count = max(count, 1)
+		if count <= math.MaxInt32 {
+			c := int32(count)
+			rec.VMStorageReplicaCount = &c
+		}
+	}
+	return rec
+}
+
+// componentResources recommends resources for each pod of stateless component from the peak usage of its pods
+// CPU usage is calculated from the previous sample, CPU recommendation from the previous status is used if it's missing
+func componentResources(curr, prev map[string]podMetrics, elapsed float64, prevRes corev1.ResourceRequirements) corev1.ResourceRequirements {
+	if len(curr) == 0 {
+		return prevRes
+	}
+	var peakMemory, peakCPU float64
+	for name, m := range curr {
+		peakMemory = max(peakMemory, m.residentMemory)
+		if prevM, ok := prev[name]; ok && elapsed > 0 {
+			peakCPU = max(peakCPU, counterIncrease(prevM.cpuSeconds, m.cpuSeconds)/elapsed)
+		}
+	}
+	memory := roundUp(int64(peakMemory*resourcesHeadroom), memoryRoundBytes, minRecommendedMemory)
+	res := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: *resource.NewQuantity(memory, resource.BinarySI),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: *resource.NewQuantity(memory, resource.BinarySI),
+		},
+	}
+	switch {
+	case peakCPU > 0:
+		cpu := roundUp(int64(peakCPU*1000*resourcesHeadroom), cpuRoundMillis, minRecommendedCPUMillis)
+		res.Requests[corev1.ResourceCPU] = *resource.NewMilliQuantity(cpu, resource.DecimalSI)
+	case elapsed == 0:
+		if cpu, ok := prevRes.Requests[corev1.ResourceCPU]; ok {
+			res.Requests[corev1.ResourceCPU] = cpu
+		}
+	}
+	return res
+}
+
+// roundUp rounds value up to the multiple of step, but not lower than minValue
+func roundUp(value, step, minValue int64) int64 {
+	value = (value + step - 1) / step * step
+	return max(value, minValue)
+}
+
+// counterIncrease returns increase of the counter, which could be reset on pod restart
+func counterIncrease(prev, curr float64) float64 {
+	if curr < prev {
+		return curr
+	}
+	return curr - prev
+}
+
+func portOrDefault(port, defaultPort string) string {
+	if port == "" {
+		return defaultPort
+	}
+	return port
+}
+
+// parsePodMetrics reads active time series, ingested samples and resource usage of the single pod
+// active time series are tracked by storage/hour_metric_ids cache of vmstorage, which holds series with samples for the current hour
+func parsePodMetrics(r io.Reader) (podMetrics, error) {
+	var m podMetrics
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if !strings.HasPrefix(line, "vm_cache_entries{") && !strings.HasPrefix(line, "vm_rows_added_to_storage_total") &&
+			!strings.HasPrefix(line, "process_cpu_seconds_total") && !strings.HasPrefix(line, "process_resident_memory_bytes") {
+			continue
+		}
+		name, labels, value, ok := promtext.ParseLine(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "vm_cache_entries" && labels["type"] == "storage/hour_metric_ids":
+			m.activeSeries = value
+		case name == "vm_rows_added_to_storage_total":
+			m.rowsAdded = value
+		case name == "process_cpu_seconds_total":
+			m.cpuSeconds = value
+		case name == "process_resident_memory_bytes":
+			m.residentMemory = value
+		}
+	}
+	return m, sc.Err()
+}
@@ -0,0 +1,172 @@
+package vmcluster
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestParsePodMetrics(t *testing.T) {
+	f := func(data string, want podMetrics) {
+		t.Helper()
+		got, err := parsePodMetrics(strings.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	// empty response
+	f("", podMetrics{})
+
+	// vmstorage metrics
+	f(`# HELP vm_cache_entries
+vm_cache_entries{type="storage/hour_metric_ids"} 1523
+vm_cache_entries{type="storage/tsid"} 4000
+vm_rows_added_to_storage_total 1.5e+06
+vm_rows_inserted_total{type="vmstorage"} 200
+process_cpu_seconds_total 12.5
+process_resident_memory_bytes 1.048576e+08
+`, podMetrics{activeSeries: 1523, rowsAdded: 1.5e6, cpuSeconds: 12.5, residentMemory: 1.048576e8})
+}
+
+func TestBuildRecommendations(t *testing.T) {
+	now := time.Now()
+	newCR := func(memLimit string) *vmv1beta1.VMCluster {
+		cr := &vmv1beta1.VMCluster{
+			Spec: vmv1beta1.VMClusterSpec{
+				ReplicationFactor: ptr.To[int32](2),
+				VMStorage: &vmv1beta1.VMStorage{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To[int32](2)},
+				},
+			},
+		}
+		if memLimit != "" {
+			cr.Spec.VMStorage.Resources.Limits = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memLimit)}
+		}
+		return cr
+	}
+	sample := metricsSample{
+		ts: now,
+		vmstorage: map[string]podMetrics{
+			"vmstorage-0": {activeSeries: 1_500_000, rowsAdded: 5_000_000},
+			"vmstorage-1": {activeSeries: 1_500_000, rowsAdded: 5_000_000},
+		},
+	}
+	prev := &metricsSample{
+		ts: now.Add(-10 * time.Second),
+		vmstorage: map[string]podMetrics{
+			"vmstorage-0": {},
+			"vmstorage-1": {},
+		},
+	}
+
+	// ingestion rate is unknown without previous sample
+	got := buildRecommendations(newCR(""), sample, nil)
+	assert.Equal(t, int64(3_000_000), got.ActiveTimeSeries)
+	assert.Equal(t, int64(0), got.IngestionRate)
+	assert.Equal(t, "3Gi", ptr.To(got.VMStorageResources.Limits[corev1.ResourceMemory]).String())
+	assert.Equal(t, "3Gi", ptr.To(got.VMStorageResources.Requests[corev1.ResourceMemory]).String())
+	assert.NotContains(t, got.VMStorageResources.Requests, corev1.ResourceCPU)
+	assert.Nil(t, got.VMStorageReplicaCount)
+
+	// ingestion rate is calculated from previous sample
+	got = buildRecommendations(newCR("2Gi"), sample, prev)
+	assert.Equal(t, int64(1_000_000), got.IngestionRate)
+	assert.Equal(t, "10", ptr.To(got.VMStorageResources.Requests[corev1.ResourceCPU]).String())
+	assert.Equal(t, ptr.To[int32](3), got.VMStorageReplicaCount)
+
+	// replica count is not lower than replication factor
+	got = buildRecommendations(newCR("64Gi"), sample, prev)
+	assert.Equal(t, ptr.To[int32](2), got.VMStorageReplicaCount)
+
+	// ingestion rate is preserved from status
+	cr := newCR("")
+	cr.Status.Recommendations = &vmv1beta1.VMClusterRecommendations{IngestionRate: 50_000}
+	got = buildRecommendations(cr, sample, nil)
+	assert.Equal(t, int64(50_000), got.IngestionRate)
+	assert.Equal(t, "500m", ptr.To(got.VMStorageResources.Requests[corev1.ResourceCPU]).String())
+}
+
+func TestBuildRecommendationsStatelessComponents(t *testing.T) {
+	now := time.Now()
+	cr := &vmv1beta1.VMCluster{
+		Spec: vmv1beta1.VMClusterSpec{
+			VMSelect: &vmv1beta1.VMSelect{},
+			VMInsert: &vmv1beta1.VMInsert{},
+		},
+	}
+	sample := metricsSample{
+		ts: now,
+		vmselect: map[string]podMetrics{
+			"vmselect-0": {cpuSeconds: 20, residentMemory: 400 * 1024 * 1024},
+			"vmselect-1": {cpuSeconds: 15, residentMemory: 300 * 1024 * 1024},
+		},
+		vminsert: map[string]podMetrics{
+			"vminsert-0": {cpuSeconds: 100, residentMemory: 50 * 1024 * 1024},
+		},
+	}
+	prev := &metricsSample{
+		ts: now.Add(-10 * time.Second),
+		vmselect: map[string]podMetrics{
+			"vmselect-0": {cpuSeconds: 15},
+			"vmselect-1": {cpuSeconds: 14},
+		},
+		vminsert: map[string]podMetrics{
+			"vminsert-0": {cpuSeconds: 110},
+		},
+	}
+
+	// cpu is unknown without previous sample
+	got := buildRecommendations(cr, sample, nil)
+	assert.Equal(t, "1Gi", ptr.To(got.VMSelectResources.Requests[corev1.ResourceMemory]).String())
+	assert.NotContains(t, got.VMSelectResources.Requests, corev1.ResourceCPU)
+	assert.Equal(t, "256Mi", ptr.To(got.VMInsertResources.Limits[corev1.ResourceMemory]).String())
+	assert.Nil(t, got.VMStorageResources.Requests)
+
+	// peak cpu usage, counter reset at vminsert
+	got = buildRecommendations(cr, sample, prev)
+	assert.Equal(t, "1", ptr.To(got.VMSelectResources.Requests[corev1.ResourceCPU]).String())
+	assert.Equal(t, "20", ptr.To(got.VMInsertResources.Requests[corev1.ResourceCPU]).String())
+
+	// previous recommendations are kept for components without observed pods
+	cr.Status.Recommendations = got
+	got = buildRecommendations(cr, metricsSample{ts: now, vmselect: sample.vmselect}, nil)
+	assert.Equal(t, "20", ptr.To(got.VMInsertResources.Requests[corev1.ResourceCPU]).String())
+	assert.Equal(t, "1", ptr.To(got.VMSelectResources.Requests[corev1.ResourceCPU]).String())
+}
+
+func TestBuildRecommendationsPatch(t *testing.T) {
+	f := func(prev, rec *vmv1beta1.VMClusterRecommendations, want string) {
+		t.Helper()
+		got, err := buildRecommendationsPatch(prev, rec)
+		assert.NoError(t, err)
+		assert.JSONEq(t, want, string(got))
+	}
+
+	// no previous recommendations
+	f(nil, &vmv1beta1.VMClusterRecommendations{ActiveTimeSeries: 1000}, `{"status":{"recommendations":{
+  "activeTimeSeries":1000,"vmstorageResources":{},"vmselectResources":{},"vminsertResources":{}}}}`)
+
+	// cleared fields are set to null
+	f(&vmv1beta1.VMClusterRecommendations{
+		ActiveTimeSeries:      1000,
+		IngestionRate:         500,
+		VMStorageReplicaCount: ptr.To[int32](3),
+		VMStorageResources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}, &vmv1beta1.VMClusterRecommendations{
+		ActiveTimeSeries: 1000,
+		VMStorageResources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}, `{"status":{"recommendations":{
+  "activeTimeSeries":1000,"ingestionRate":null,"vmstorageReplicaCount":null,
+  "vmstorageResources":{"requests":{"cpu":null,"memory":"1Gi"}},"vmselectResources":{},"vminsertResources":{}}}}`)
+}
@@ -0,0 +1,45 @@
+package vmcluster
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+// buildVMSelectRoute returns OpenShift Route for vmselect service or nil if it's not configured
+func buildVMSelectRoute(cr *vmv1beta1.VMCluster) *unstructured.Unstructured {
+	if cr.Spec.VMSelect == nil || cr.Spec.VMSelect.Route == nil {
+		return nil
+	}
+	b := newOptsBuilder(cr, cr.GetVMSelectName(), cr.VMSelectSelectorLabels())
+	return build.Route(b, cr.Spec.VMSelect.Route)
+}
+
+// createOrUpdateVMSelectRoute reconciles OpenShift Route of vmselect
+// and removes it if route was removed since previous state
+func createOrUpdateVMSelectRoute(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMCluster) error {
+	var prevRoute *unstructured.Unstructured
+	if prevCR != nil {
+		prevRoute = buildVMSelectRoute(prevCR)
+	}
+	newRoute := buildVMSelectRoute(cr)
+	if newRoute == nil {
+		if prevRoute != nil {
+			if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, build.NewRoute(prevRoute.GetName(), prevRoute.GetNamespace())); err != nil {
+				return fmt.Errorf("cannot delete vmselect route from prev state: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := reconcile.Route(ctx, rclient, newRoute, prevRoute); err != nil {
+		return fmt.Errorf("cannot update route for vmselect: %w", err)
+	}
+	return nil
+}
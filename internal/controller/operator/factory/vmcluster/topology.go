@@ -0,0 +1,105 @@
+package vmcluster
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+const (
+	// defaultStorageDialTimeout is the default value of -vmstorageDialTimeout flag at vminsert and vmselect
+	defaultStorageDialTimeout = 3 * time.Second
+	// storageNodesPerDialTimeoutStep defines the number of vmstorage nodes,
+	// which increases -vmstorageDialTimeout by defaultStorageDialTimeout
+	storageNodesPerDialTimeoutStep = 10
+)
+
+// componentCPUs returns the number of CPU cores available for the component container
+// limits are rounded down, but not lower than 1, the same way as VictoriaMetrics components set GOMAXPROCS from CPU quota
+// requests are rounded up, since component without limits uses all CPU cores of the node
+// the second value reports whether CPU cores are defined by limits and are known to the component itself
+// returns 0 if it's not possible to determine CPU cores, e.g. resources are not defined or changed by VPA
+func componentCPUs(resources corev1.ResourceRequirements, vpa *vmv1beta1.EmbeddedVPA) (int64, bool) {
+	if vpa != nil {
+		return 0, false
+	}
+	if cpu, ok := resources.Limits[corev1.ResourceCPU]; ok && !cpu.IsZero() {
+		return max(cpu.MilliValue()/1000, 1), true
+	}
+	if cpu, ok := resources.Requests[corev1.ResourceCPU]; ok && !cpu.IsZero() {
+		return (cpu.MilliValue() + 999) / 1000, false
+	}
+	return 0, false
+}
+
+// componentReplicas returns the maximum number of component replicas
+func componentReplicas(replicaCount *int32, hpa *vmv1beta1.EmbeddedHPA) int64 {
+	if hpa != nil && hpa.MaxReplicas > 0 {
+		return int64(hpa.MaxReplicas)
+	}
+	if replicaCount != nil && *replicaCount > 0 {
+		return int64(*replicaCount)
+	}
+	return 1
+}
+
+// storageDialTimeoutArg returns -vmstorageDialTimeout flag for vminsert and vmselect
+// it's increased for large clusters, since dial to vmstorage nodes could take longer during rollouts and network saturation
+func storageDialTimeoutArg(cr *vmv1beta1.VMCluster) []string {
+	if cr.Spec.VMStorage == nil || cr.Spec.VMStorage.ReplicaCount == nil {
+		return nil
+	}
+	steps := int(*cr.Spec.VMStorage.ReplicaCount) / storageNodesPerDialTimeoutStep
+	if steps == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("-vmstorageDialTimeout=%s", defaultStorageDialTimeout*time.Duration(steps+1))}
+}
+
+// vminsertTopologyArgs returns vminsert flags derived from the cluster topology and resources
+// flags equal to vminsert defaults are omitted
+func vminsertTopologyArgs(cr *vmv1beta1.VMCluster) []string {
+	if cr.Spec.DisableAutoTuning {
+		return nil
+	}
+	args := storageDialTimeoutArg(cr)
+	// vminsert uses 2*CPUs by default, which are known only if limits are defined
+	if cpus, fromLimits := componentCPUs(cr.Spec.VMInsert.Resources, cr.Spec.VMInsert.VPA); cpus > 0 && !fromLimits {
+		args = append(args, fmt.Sprintf("-maxConcurrentInserts=%d", 2*cpus))
+	}
+	return args
+}
+
+// vmselectTopologyArgs returns vmselect flags derived from the cluster topology and resources
+// each vmselect request is processed by all vmstorage nodes, so concurrency of all vmselect replicas
+// is limited by the concurrency of a single vmstorage node
+// flags equal to vmselect defaults and flags defined at queryLogging are omitted
+func vmselectTopologyArgs(cr *vmv1beta1.VMCluster) []string {
+	if cr.Spec.DisableAutoTuning {
+		return nil
+	}
+	args := storageDialTimeoutArg(cr)
+	if ql := cr.Spec.VMSelect.QueryLogging; ql != nil && ql.MaxConcurrentRequests != nil {
+		return args
+	}
+	cpus, fromLimits := componentCPUs(cr.Spec.VMSelect.Resources, cr.Spec.VMSelect.VPA)
+	if cpus == 0 {
+		return args
+	}
+	maxConcurrentRequests := 2 * cpus
+	if cr.Spec.VMStorage != nil {
+		if storageCPUs, _ := componentCPUs(cr.Spec.VMStorage.Resources, cr.Spec.VMStorage.VPA); storageCPUs > 0 {
+			replicas := componentReplicas(cr.Spec.VMSelect.ReplicaCount, cr.Spec.VMSelect.HPA)
+			perReplica := max((2*storageCPUs+replicas-1)/replicas, 2)
+			maxConcurrentRequests = min(maxConcurrentRequests, perReplica)
+		}
+	}
+	if fromLimits && maxConcurrentRequests == 2*cpus {
+		// the same as vmselect default
+		return args
+	}
+	return append(args, fmt.Sprintf("-search.maxConcurrentRequests=%d", maxConcurrentRequests))
+}
@@ -0,0 +1,80 @@
+package vmcluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestTopologyArgs(t *testing.T) {
+	cpu := func(limit string) corev1.ResourceRequirements {
+		if limit == "" {
+			return corev1.ResourceRequirements{}
+		}
+		return corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(limit)}}
+	}
+	newCR := func(insertCPU, selectCPU, storageCPU string, selectReplicas, storageReplicas int32) *vmv1beta1.VMCluster {
+		return &vmv1beta1.VMCluster{
+			Spec: vmv1beta1.VMClusterSpec{
+				VMInsert: &vmv1beta1.VMInsert{CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{Resources: cpu(insertCPU)}},
+				VMSelect: &vmv1beta1.VMSelect{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To(selectReplicas)},
+					CommonDefaultableParams:           vmv1beta1.CommonDefaultableParams{Resources: cpu(selectCPU)},
+				},
+				VMStorage: &vmv1beta1.VMStorage{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To(storageReplicas)},
+					CommonDefaultableParams:           vmv1beta1.CommonDefaultableParams{Resources: cpu(storageCPU)},
+				},
+			},
+		}
+	}
+	f := func(cr *vmv1beta1.VMCluster, wantInsert, wantSelect []string) {
+		t.Helper()
+		assert.Equal(t, wantInsert, vminsertTopologyArgs(cr))
+		assert.Equal(t, wantSelect, vmselectTopologyArgs(cr))
+	}
+
+	// resources are not defined
+	f(newCR("", "", "", 2, 3), nil, nil)
+
+	// flags derived from limits are equal to defaults
+	f(newCR("2", "1500m", "", 2, 3), nil, nil)
+
+	// vmselect concurrency is limited by vmstorage
+	f(newCR("1", "4", "4", 2, 3), nil, []string{"-search.maxConcurrentRequests=4"})
+	f(newCR("1", "4", "1", 4, 3), nil, []string{"-search.maxConcurrentRequests=2"})
+
+	// flags are derived from requests
+	cr := newCR("", "", "", 2, 3)
+	cr.Spec.VMInsert.Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m")}
+	cr.Spec.VMSelect.Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m")}
+	f(cr, []string{"-maxConcurrentInserts=4"}, []string{"-search.maxConcurrentRequests=4"})
+
+	// maxConcurrentRequests is defined at queryLogging
+	cr = newCR("1", "4", "4", 2, 3)
+	cr.Spec.VMSelect.QueryLogging = &vmv1beta1.QueryLogging{MaxConcurrentRequests: ptr.To[int32](16)}
+	f(cr, nil, nil)
+
+	// large cluster
+	f(newCR("", "", "", 2, 25), []string{"-vmstorageDialTimeout=9s"}, []string{"-vmstorageDialTimeout=9s"})
+
+	// vmselect with VPA
+	cr = newCR("", "4", "", 2, 3)
+	cr.Spec.VMSelect.VPA = &vmv1beta1.EmbeddedVPA{}
+	f(cr, nil, nil)
+
+	// vmselect with HPA
+	cr = newCR("", "4", "4", 1, 3)
+	cr.Spec.VMSelect.HPA = &vmv1beta1.EmbeddedHPA{MaxReplicas: 8}
+	f(cr, nil, []string{"-search.maxConcurrentRequests=2"})
+
+	// auto tuning is disabled
+	cr = newCR("2", "2", "2", 2, 25)
+	cr.Spec.DisableAutoTuning = true
+	f(cr, nil, nil)
+}
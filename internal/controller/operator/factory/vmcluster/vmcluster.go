@@ -9,6 +9,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -21,6 +22,7 @@ import (
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
 )
 
@@ -41,6 +43,16 @@ func CreateOrUpdateVMCluster(ctx context.Context, cr *vmv1beta1.VMCluster, rclie
 	if cr.ParsedLastAppliedSpec != nil {
 		prevCR = cr.DeepCopy()
 		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		if prevCR.Spec.VMStorage != nil {
+			if err := build.ApplyVMBackupLocation(ctx, rclient, prevCR.Spec.VMStorage.VMBackup, prevCR.Namespace, prevCR.Spec.CloudIdentity); err != nil {
+				logger.WithContext(ctx).Error(err, "cannot apply backup location for previous vmcluster state")
+			}
+		}
+	}
+	if cr.Spec.VMStorage != nil {
+		if err := build.ApplyVMBackupLocation(ctx, rclient, cr.Spec.VMStorage.VMBackup, cr.Namespace, cr.Spec.CloudIdentity); err != nil {
+			return err
+		}
 	}
 	if cr.IsOwnsServiceAccount() {
 		var prevSA *corev1.ServiceAccount
@@ -76,12 +88,20 @@ func CreateOrUpdateVMCluster(ctx context.Context, cr *vmv1beta1.VMCluster, rclie
 		if err != nil {
 			return err
 		}
+		if err := createOrUpdateVMStorageBackupVerification(ctx, rclient, cr, prevCR); err != nil {
+			return err
+		}
 		if !ptr.Deref(cr.Spec.VMStorage.DisableSelfServiceScrape, false) {
-			err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(storageSvc, cr.Spec.VMStorage, "http", "vmbackupmanager"))
+			err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithDefaults(storageSvc, cr.Spec.VMStorage, build.ServiceScrapeDefaults{}.WithServerTLS(cr.Spec.VMStorage.ServerTLS, storageSvc), "http", "vmbackupmanager"))
 			if err != nil {
 				return fmt.Errorf("cannot create VMServiceScrape for vmStorage: %w", err)
 			}
 		}
+		if ptr.Deref(cr.Spec.VMStorage.SelfMonitoringRules, false) {
+			if err := reconcile.VMRuleForCRD(ctx, rclient, build.SelfMonitoringVMRule(storageSvc, cr.GetVMStorageName(), "vmstorage")); err != nil {
+				return fmt.Errorf("cannot create self-monitoring VMRule for vmstorage: %w", err)
+			}
+		}
 	}
 
 	if cr.Spec.VMSelect != nil {
@@ -104,7 +124,7 @@ func CreateOrUpdateVMCluster(ctx context.Context, cr *vmv1beta1.VMCluster, rclie
 		}
 		if !ptr.Deref(cr.Spec.VMSelect.DisableSelfServiceScrape, false) {
 
-			svs := build.VMServiceScrapeForServiceWithSpec(selectSvc, cr.Spec.VMSelect, "http")
+			svs := build.VMServiceScrapeForServiceWithDefaults(selectSvc, cr.Spec.VMSelect, build.ServiceScrapeDefaults{}.WithServerTLS(cr.Spec.VMSelect.ServerTLS, selectSvc), "http")
 			if cr.Spec.RequestsLoadBalancer.Enabled && !cr.Spec.RequestsLoadBalancer.DisableSelectBalancing {
 				// for backward compatibility we must keep job label value
 				svs.Spec.JobLabel = vmauthLBServiceProxyJobNameLabel
@@ -114,6 +134,11 @@ func CreateOrUpdateVMCluster(ctx context.Context, cr *vmv1beta1.VMCluster, rclie
 				return fmt.Errorf("cannot create VMServiceScrape for vmSelect: %w", err)
 			}
 		}
+		if ptr.Deref(cr.Spec.VMSelect.SelfMonitoringRules, false) {
+			if err := reconcile.VMRuleForCRD(ctx, rclient, build.SelfMonitoringVMRule(selectSvc, cr.GetVMSelectName(), "vmselect")); err != nil {
+				return fmt.Errorf("cannot create self-monitoring VMRule for vmselect: %w", err)
+			}
+		}
 	}
 
 	if cr.Spec.VMInsert != nil {
@@ -133,7 +158,7 @@ func CreateOrUpdateVMCluster(ctx context.Context, cr *vmv1beta1.VMCluster, rclie
 			return err
 		}
 		if !ptr.Deref(cr.Spec.VMInsert.DisableSelfServiceScrape, false) {
-			svs := build.VMServiceScrapeForServiceWithSpec(insertSvc, cr.Spec.VMInsert, "http")
+			svs := build.VMServiceScrapeForServiceWithDefaults(insertSvc, cr.Spec.VMInsert, build.ServiceScrapeDefaults{}.WithServerTLS(cr.Spec.VMInsert.ServerTLS, insertSvc), "http")
 			if cr.Spec.RequestsLoadBalancer.Enabled && !cr.Spec.RequestsLoadBalancer.DisableInsertBalancing {
 				// for backward compatibility we must keep job label value
 				svs.Spec.JobLabel = vmauthLBServiceProxyJobNameLabel
@@ -143,6 +168,31 @@ func CreateOrUpdateVMCluster(ctx context.Context, cr *vmv1beta1.VMCluster, rclie
 				return fmt.Errorf("cannot create VMServiceScrape for vmInsert: %w", err)
 			}
 		}
+		if ptr.Deref(cr.Spec.VMInsert.SelfMonitoringRules, false) {
+			if err := reconcile.VMRuleForCRD(ctx, rclient, build.SelfMonitoringVMRule(insertSvc, cr.GetVMInsertName(), "vminsert")); err != nil {
+				return fmt.Errorf("cannot create self-monitoring VMRule for vminsert: %w", err)
+			}
+		}
+	}
+
+	if err := createOrUpdateNetworkPolicies(ctx, rclient, cr, prevCR); err != nil {
+		return err
+	}
+
+	if err := createOrUpdateVPAs(ctx, rclient, cr, prevCR); err != nil {
+		return err
+	}
+
+	if err := createOrUpdateGrafanaDashboard(ctx, rclient, cr, prevCR); err != nil {
+		return err
+	}
+
+	if err := createOrUpdateVMSelectIngress(ctx, rclient, cr, prevCR); err != nil {
+		return err
+	}
+
+	if err := createOrUpdateVMSelectRoute(ctx, rclient, cr, prevCR); err != nil {
+		return err
 	}
 
 	if err := deletePrevStateResources(ctx, rclient, cr, prevCR); err != nil {
@@ -152,6 +202,9 @@ func CreateOrUpdateVMCluster(ctx context.Context, cr *vmv1beta1.VMCluster, rclie
 }
 
 func createOrUpdateVMSelect(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMCluster) error {
+	if err := reconcile.ServerTLSSecret(ctx, rclient, cr.Spec.VMSelect.ServerTLS, metav1.ObjectMeta{Namespace: cr.Namespace, Labels: cr.FinalLabels(cr.VMSelectSelectorLabels()), OwnerReferences: cr.AsOwner()}, cr.GetVMSelectName()); err != nil {
+		return fmt.Errorf("cannot reconcile serverTLS secret for vmselect: %w", err)
+	}
 
 	var prevSts *appsv1.StatefulSet
 	if prevCR != nil && prevCR.Spec.VMSelect != nil {
@@ -212,9 +265,11 @@ func buildVMSelectService(cr *vmv1beta1.VMCluster) *corev1.Service {
 func createOrUpdateVMSelectService(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMCluster) (*corev1.Service, error) {
 
 	var prevService, prevAdditionalService *corev1.Service
+	var prevAdditionalServices []*corev1.Service
 	if prevCR != nil && prevCR.Spec.VMSelect != nil {
 		prevService = buildVMSelectService(prevCR)
 		prevAdditionalService = build.AdditionalServiceFromDefault(prevService, prevCR.Spec.VMSelect.ServiceSpec)
+		prevAdditionalServices = build.AdditionalServicesFromDefault(prevService, prevCR.Spec.VMSelect.AdditionalServices)
 	}
 	svc := buildVMSelectService(cr)
 	if err := cr.Spec.VMSelect.ServiceSpec.IsSomeAndThen(func(s *vmv1beta1.AdditionalServiceSpec) error {
@@ -230,6 +285,10 @@ func createOrUpdateVMSelectService(ctx context.Context, rclient client.Client, c
 		return nil, err
 	}
 
+	if err := reconcile.Services(ctx, rclient, build.AdditionalServicesFromDefault(svc, cr.Spec.VMSelect.AdditionalServices), prevAdditionalServices); err != nil {
+		return nil, fmt.Errorf("cannot reconcile additional services for vmselect: %w", err)
+	}
+
 	if err := reconcile.Service(ctx, rclient, svc, prevService); err != nil {
 		return nil, fmt.Errorf("cannot reconcile vmselect service: %w", err)
 	}
@@ -286,6 +345,9 @@ func createOrUpdateLBProxyService(ctx context.Context, rclient client.Client, cr
 }
 
 func createOrUpdateVMInsert(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMCluster) error {
+	if err := reconcile.ServerTLSSecret(ctx, rclient, cr.Spec.VMInsert.ServerTLS, metav1.ObjectMeta{Namespace: cr.Namespace, Labels: cr.FinalLabels(cr.VMInsertSelectorLabels()), OwnerReferences: cr.AsOwner()}, cr.GetVMInsertName()); err != nil {
+		return fmt.Errorf("cannot reconcile serverTLS secret for vminsert: %w", err)
+	}
 	var prevDeploy *appsv1.Deployment
 
 	if prevCR != nil && prevCR.Spec.VMInsert != nil {
@@ -336,9 +398,11 @@ func createOrUpdateVMInsertService(ctx context.Context, rclient client.Client, c
 
 	newService := buildVMInsertService(cr)
 	var prevService, prevAdditionalService *corev1.Service
+	var prevAdditionalServices []*corev1.Service
 	if prevCR != nil && prevCR.Spec.VMInsert != nil {
 		prevService = buildVMInsertService(prevCR)
 		prevAdditionalService = build.AdditionalServiceFromDefault(prevService, prevCR.Spec.VMInsert.ServiceSpec)
+		prevAdditionalServices = build.AdditionalServicesFromDefault(prevService, prevCR.Spec.VMInsert.AdditionalServices)
 	}
 	if err := cr.Spec.VMInsert.ServiceSpec.IsSomeAndThen(func(s *vmv1beta1.AdditionalServiceSpec) error {
 		additionalService := build.AdditionalServiceFromDefault(newService, s)
@@ -353,6 +417,19 @@ func createOrUpdateVMInsertService(ctx context.Context, rclient client.Client, c
 		return nil, err
 	}
 
+	if err := reconcile.Services(ctx, rclient, build.AdditionalServicesFromDefault(newService, cr.Spec.VMInsert.AdditionalServices), prevAdditionalServices); err != nil {
+		return nil, fmt.Errorf("cannot reconcile additional services for vminsert: %w", err)
+	}
+	if cr.Spec.VMInsert.OTLP != nil {
+		var prevOTLPService *corev1.Service
+		if prevService != nil {
+			prevOTLPService = build.OTLPServiceFromDefault(prevService, prevCR.GetVMInsertOTLPName(), prevCR.Spec.VMInsert.OTLP)
+		}
+		if err := reconcile.Service(ctx, rclient, build.OTLPServiceFromDefault(newService, cr.GetVMInsertOTLPName(), cr.Spec.VMInsert.OTLP), prevOTLPService); err != nil {
+			return nil, fmt.Errorf("cannot reconcile vminsert otlp service: %w", err)
+		}
+	}
+
 	if err := reconcile.Service(ctx, rclient, newService, prevService); err != nil {
 		return nil, fmt.Errorf("cannot reconcile vminsert service: %w", err)
 	}
@@ -372,6 +449,9 @@ func createOrUpdateVMInsertService(ctx context.Context, rclient client.Client, c
 }
 
 func createOrUpdateVMStorage(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMCluster) error {
+	if err := reconcile.ServerTLSSecret(ctx, rclient, cr.Spec.VMStorage.ServerTLS, metav1.ObjectMeta{Namespace: cr.Namespace, Labels: cr.FinalLabels(cr.VMStorageSelectorLabels()), OwnerReferences: cr.AsOwner()}, cr.GetVMStorageName()); err != nil {
+		return fmt.Errorf("cannot reconcile serverTLS secret for vmstorage: %w", err)
+	}
 	var prevSts *appsv1.StatefulSet
 
 	if prevCR != nil && prevCR.Spec.VMStorage != nil {
@@ -381,6 +461,13 @@ func createOrUpdateVMStorage(ctx context.Context, rclient client.Client, cr, pre
 			return fmt.Errorf("cannot build prev storage spec: %w", err)
 		}
 	}
+	restoreFrom := cr.Spec.VMStorage.RestoreFrom
+	if build.IsVMRestoreFromNeeded(restoreFrom, cr.Status.VMStorageRestore) &&
+		(cr.Status.VMStorageRestore == nil || cr.Status.VMStorageRestore.Source != restoreFrom.Source) {
+		if err := reconcile.RestoreStatus(ctx, rclient, cr, "vmStorageRestore", restoreFrom.Source, vmv1beta1.RestoreStatusInProgress); err != nil {
+			return err
+		}
+	}
 	newSts, err := buildVMStorageSpec(ctx, cr)
 	if err != nil {
 		return err
@@ -393,6 +480,65 @@ func createOrUpdateVMStorage(ctx context.Context, rclient client.Client, cr, pre
 	return reconcile.HandleSTSUpdate(ctx, rclient, stsOpts, newSts, prevSts)
 }
 
+func createOrUpdateVMStorageBackupVerification(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMCluster) error {
+	var prevCJ *batchv1.CronJob
+	if prevCR != nil && prevCR.Spec.VMStorage != nil && build.IsVMBackupVerificationEnabled(prevCR.Spec.VMStorage.VMBackup, prevCR.Spec.License) {
+		prevCJ = newVMStorageBackupVerificationCronJob(prevCR)
+	}
+	if !build.IsVMBackupVerificationEnabled(cr.Spec.VMStorage.VMBackup, cr.Spec.License) {
+		if prevCJ != nil {
+			if err := finalize.SafeDelete(ctx, rclient, prevCJ); err != nil {
+				return fmt.Errorf("cannot remove vmstorage backup verification CronJob: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := reconcile.CronJob(ctx, rclient, newVMStorageBackupVerificationCronJob(cr), prevCJ); err != nil {
+		return fmt.Errorf("cannot reconcile vmstorage backup verification CronJob: %w", err)
+	}
+	return nil
+}
+
+func newVMStorageBackupVerificationCronJob(cr *vmv1beta1.VMCluster) *batchv1.CronJob {
+	vmStorage := cr.Spec.VMStorage
+	objMeta := metav1.ObjectMeta{
+		Name:            cr.GetVMStorageBackupVerificationName(),
+		Namespace:       cr.Namespace,
+		Labels:          cr.FinalLabels(cr.VMStorageSelectorLabels()),
+		Annotations:     cr.AnnotationsFiltered(),
+		OwnerReferences: cr.AsOwner(),
+	}
+	// verify backups of each vmstorage pod, since each of them has own backup destination
+	replicas := ptr.Deref(vmStorage.ReplicaCount, 1)
+	apiURLs := make([]string, 0, replicas)
+	for i := int32(0); i < replicas; i++ {
+		addr := strings.TrimSuffix(build.PodDNSAddress(cr.GetVMStorageName(), i, cr.Namespace, vmStorage.VMBackup.Port, cr.Spec.ClusterDomainName), ",")
+		apiURLs = append(apiURLs, "http://"+addr)
+	}
+	return build.VMBackupVerificationCronJob(vmStorage.VMBackup, objMeta, apiURLs, cr.Spec.License, ptr.Deref(vmStorage.UseStrictSecurity, false))
+}
+
+// UpdateBackupVerificationStatus reflects state of vmstorage backup verification at the object status
+// it must be called after status tracking, otherwise status could be overwritten
+func UpdateBackupVerificationStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster) error {
+	var cronJobName string
+	if cr.Spec.VMStorage != nil && build.IsVMBackupVerificationEnabled(cr.Spec.VMStorage.VMBackup, cr.Spec.License) {
+		cronJobName = cr.GetVMStorageBackupVerificationName()
+	}
+	st, err := reconcile.BackupVerificationStatus(ctx, rclient, cr, "vmStorageBackupVerification", cronJobName, cr.Status.VMStorageBackupVerification)
+	cr.Status.VMStorageBackupVerification = st
+	return err
+}
+
+// UpdateRestoreStatus marks restore from spec.vmstorage.restoreFrom as completed
+// it must be called after successful reconcile, when vmrestore init containers finished its work
+func UpdateRestoreStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster) error {
+	if cr.Spec.VMStorage == nil || !build.IsVMRestoreFromNeeded(cr.Spec.VMStorage.RestoreFrom, cr.Status.VMStorageRestore) {
+		return nil
+	}
+	return reconcile.RestoreStatus(ctx, rclient, cr, "vmStorageRestore", cr.Spec.VMStorage.RestoreFrom.Source, vmv1beta1.RestoreStatusCompleted)
+}
+
 func createOrUpdateVMStorageService(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMCluster) (*corev1.Service, error) {
 	t := &optsBuilder{
 		cr,
@@ -402,6 +548,7 @@ func createOrUpdateVMStorageService(ctx context.Context, rclient client.Client,
 		cr.Spec.VMStorage.ServiceSpec,
 	}
 	var prevService, prevAdditionalService *corev1.Service
+	var prevAdditionalServices []*corev1.Service
 	if prevCR != nil && prevCR.Spec.VMStorage != nil {
 		prevT := &optsBuilder{
 			prevCR,
@@ -438,6 +585,7 @@ func createOrUpdateVMStorageService(ctx context.Context, rclient client.Client,
 			}
 		})
 		prevAdditionalService = build.AdditionalServiceFromDefault(prevService, prevCR.Spec.VMStorage.ServiceSpec)
+		prevAdditionalServices = build.AdditionalServicesFromDefault(prevService, prevCR.Spec.VMStorage.AdditionalServices)
 
 	}
 	newHeadless := build.Service(t, cr.Spec.VMStorage.Port, func(svc *corev1.Service) {
@@ -480,6 +628,10 @@ func createOrUpdateVMStorageService(ctx context.Context, rclient client.Client,
 		return nil, err
 	}
 
+	if err := reconcile.Services(ctx, rclient, build.AdditionalServicesFromDefault(newHeadless, cr.Spec.VMStorage.AdditionalServices), prevAdditionalServices); err != nil {
+		return nil, fmt.Errorf("cannot reconcile additional services for vmstorage: %w", err)
+	}
+
 	if err := reconcile.Service(ctx, rclient, newHeadless, prevService); err != nil {
 		return nil, fmt.Errorf("cannot reconcile vmstorage service: %w", err)
 	}
@@ -508,11 +660,15 @@ func genVMSelectSpec(cr *vmv1beta1.VMCluster) (*appsv1.StatefulSet, error) {
 			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
 				Type: cr.Spec.VMSelect.RollingUpdateStrategy,
 			},
-			Template:    *podSpec,
-			ServiceName: cr.GetVMSelectName(),
+			PersistentVolumeClaimRetentionPolicy: cr.Spec.VMSelect.PVCRetentionPolicy(),
+			Template:                             *podSpec,
+			ServiceName:                          cr.GetVMSelectName(),
 		},
 	}
 	build.StatefulSetAddCommonParams(stsSpec, ptr.Deref(cr.Spec.VMSelect.UseStrictSecurity, false), &cr.Spec.VMSelect.CommonApplicationDeploymentParams)
+	if !cr.Spec.VMSelect.DisableDefaultAntiAffinity {
+		build.AddDefaultPodAntiAffinity(&stsSpec.Spec.Template.Spec, stsSpec.Spec.Selector)
+	}
 	if cr.Spec.VMSelect.CacheMountPath != "" {
 		storageSpec := cr.Spec.VMSelect.Storage
 		// hack, storage is deprecated.
@@ -522,6 +678,9 @@ func genVMSelectSpec(cr *vmv1beta1.VMCluster) (*appsv1.StatefulSet, error) {
 		storageSpec.IntoSTSVolume(cr.Spec.VMSelect.GetCacheMountVolumeName(), &stsSpec.Spec)
 	}
 	stsSpec.Spec.VolumeClaimTemplates = append(stsSpec.Spec.VolumeClaimTemplates, cr.Spec.VMSelect.ClaimTemplates...)
+	if err := build.PodTemplateAddPatches(&stsSpec.Spec.Template, cr.Spec.VMSelect.PodTemplatePatches); err != nil {
+		return nil, err
+	}
 	return stsSpec, nil
 }
 
@@ -578,10 +737,12 @@ func makePodSpecForVMSelect(cr *vmv1beta1.VMCluster) (*corev1.PodTemplateSpec, e
 		selectArg = strings.TrimSuffix(selectArg, ",")
 		args = append(args, selectArg)
 	}
+	args = append(args, vmselectTopologyArgs(cr)...)
 
 	if len(cr.Spec.VMSelect.ExtraEnvs) > 0 {
 		args = append(args, "-envflag.enable=true")
 	}
+	args = build.AppendArgsForQueryLogging(args, cr.Spec.VMSelect.QueryLogging)
 
 	var envs []corev1.EnvVar
 	envs = append(envs, cr.Spec.VMSelect.ExtraEnvs...)
@@ -644,6 +805,9 @@ func makePodSpecForVMSelect(cr *vmv1beta1.VMCluster) (*corev1.PodTemplateSpec, e
 	volumes, vmMounts = cr.Spec.License.MaybeAddToVolumes(volumes, vmMounts, vmv1beta1.SecretsDir)
 	args = cr.Spec.License.MaybeAddToArgs(args, vmv1beta1.SecretsDir)
 
+	volumes, vmMounts = cr.Spec.VMSelect.ServerTLS.MaybeAddToVolumes(volumes, vmMounts, vmv1beta1.ServerTLSDir)
+	args = cr.Spec.VMSelect.ServerTLS.MaybeAddToArgs(args, vmv1beta1.ServerTLSDir)
+
 	args = build.AddExtraArgsOverrideDefaults(args, cr.Spec.VMSelect.ExtraArgs, "-")
 	sort.Strings(args)
 	vmselectContainer := corev1.Container{
@@ -655,6 +819,7 @@ func makePodSpecForVMSelect(cr *vmv1beta1.VMCluster) (*corev1.PodTemplateSpec, e
 		VolumeMounts:             vmMounts,
 		Resources:                cr.Spec.VMSelect.Resources,
 		Env:                      envs,
+		Lifecycle:                cr.Spec.VMSelect.Lifecycle,
 		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		TerminationMessagePath:   "/dev/termination-log",
 	}
@@ -662,11 +827,12 @@ func makePodSpecForVMSelect(cr *vmv1beta1.VMCluster) (*corev1.PodTemplateSpec, e
 	vmselectContainer = build.Probe(vmselectContainer, cr.Spec.VMSelect)
 	operatorContainers := []corev1.Container{vmselectContainer}
 
-	build.AddStrictSecuritySettingsToContainers(cr.Spec.VMSelect.SecurityContext, operatorContainers, ptr.Deref(cr.Spec.VMSelect.UseStrictSecurity, false))
+	build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.VMSelect.SecurityContext, operatorContainers, ptr.Deref(cr.Spec.VMSelect.UseStrictSecurity, false))
 	containers, err := k8stools.MergePatchContainers(operatorContainers, cr.Spec.VMSelect.Containers)
 	if err != nil {
 		return nil, err
 	}
+	ic, containers := build.AddSidecars(cr.Spec.VMSelect.InitContainers, containers, cr.Spec.VMSelect.Sidecars)
 
 	for i := range cr.Spec.VMSelect.TopologySpreadConstraints {
 		if cr.Spec.VMSelect.TopologySpreadConstraints[i].LabelSelector == nil {
@@ -683,7 +849,7 @@ func makePodSpecForVMSelect(cr *vmv1beta1.VMCluster) (*corev1.PodTemplateSpec, e
 		},
 		Spec: corev1.PodSpec{
 			Volumes:            volumes,
-			InitContainers:     cr.Spec.VMSelect.InitContainers,
+			InitContainers:     ic,
 			Containers:         containers,
 			ServiceAccountName: cr.GetServiceAccountName(),
 			RestartPolicy:      "Always",
@@ -739,6 +905,9 @@ func genVMInsertSpec(cr *vmv1beta1.VMCluster) (*appsv1.Deployment, error) {
 		},
 	}
 	build.DeploymentAddCommonParams(stsSpec, ptr.Deref(cr.Spec.VMInsert.UseStrictSecurity, false), &cr.Spec.VMInsert.CommonApplicationDeploymentParams)
+	if err := build.PodTemplateAddPatches(&stsSpec.Spec.Template, cr.Spec.VMInsert.PodTemplatePatches); err != nil {
+		return nil, err
+	}
 	return stsSpec, nil
 }
 
@@ -754,6 +923,9 @@ func makePodSpecForVMInsert(cr *vmv1beta1.VMCluster) (*corev1.PodTemplateSpec, e
 	}
 
 	args = build.AppendArgsForInsertPorts(args, cr.Spec.VMInsert.InsertPorts)
+	args = build.AppendArgsForOTLP(args, cr.Spec.VMInsert.OTLP)
+	args = build.AppendArgsForDatadog(args, cr.Spec.VMInsert.Datadog)
+	args = build.AppendArgsForNewRelic(args, cr.Spec.VMInsert.NewRelic)
 	if cr.Spec.VMInsert.ClusterNativePort != "" {
 		args = append(args, fmt.Sprintf("--clusternativeListenAddr=:%s", cr.Spec.VMInsert.ClusterNativePort))
 	}
@@ -771,6 +943,7 @@ func makePodSpecForVMInsert(cr *vmv1beta1.VMCluster) (*corev1.PodTemplateSpec, e
 	if cr.Spec.ReplicationFactor != nil {
 		args = append(args, fmt.Sprintf("-replicationFactor=%d", *cr.Spec.ReplicationFactor))
 	}
+	args = append(args, vminsertTopologyArgs(cr)...)
 	if len(cr.Spec.VMInsert.ExtraEnvs) > 0 {
 		args = append(args, "-envflag.enable=true")
 	}
@@ -841,6 +1014,9 @@ func makePodSpecForVMInsert(cr *vmv1beta1.VMCluster) (*corev1.PodTemplateSpec, e
 	volumes, vmMounts = cr.Spec.License.MaybeAddToVolumes(volumes, vmMounts, vmv1beta1.SecretsDir)
 	args = cr.Spec.License.MaybeAddToArgs(args, vmv1beta1.SecretsDir)
 
+	volumes, vmMounts = cr.Spec.VMInsert.ServerTLS.MaybeAddToVolumes(volumes, vmMounts, vmv1beta1.ServerTLSDir)
+	args = cr.Spec.VMInsert.ServerTLS.MaybeAddToArgs(args, vmv1beta1.ServerTLSDir)
+
 	args = build.AddExtraArgsOverrideDefaults(args, cr.Spec.VMInsert.ExtraArgs, "-")
 	sort.Strings(args)
 
@@ -853,17 +1029,19 @@ func makePodSpecForVMInsert(cr *vmv1beta1.VMCluster) (*corev1.PodTemplateSpec, e
 		VolumeMounts:             vmMounts,
 		Resources:                cr.Spec.VMInsert.Resources,
 		Env:                      envs,
+		Lifecycle:                cr.Spec.VMInsert.Lifecycle,
 		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 	}
 
 	vminsertContainer = build.Probe(vminsertContainer, cr.Spec.VMInsert)
 	operatorContainers := []corev1.Container{vminsertContainer}
 
-	build.AddStrictSecuritySettingsToContainers(cr.Spec.VMInsert.SecurityContext, operatorContainers, ptr.Deref(cr.Spec.VMInsert.UseStrictSecurity, false))
+	build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.VMInsert.SecurityContext, operatorContainers, ptr.Deref(cr.Spec.VMInsert.UseStrictSecurity, false))
 	containers, err := k8stools.MergePatchContainers(operatorContainers, cr.Spec.VMInsert.Containers)
 	if err != nil {
 		return nil, err
 	}
+	ic, containers := build.AddSidecars(cr.Spec.VMInsert.InitContainers, containers, cr.Spec.VMInsert.Sidecars)
 
 	for i := range cr.Spec.VMInsert.TopologySpreadConstraints {
 		if cr.Spec.VMInsert.TopologySpreadConstraints[i].LabelSelector == nil {
@@ -880,7 +1058,7 @@ func makePodSpecForVMInsert(cr *vmv1beta1.VMCluster) (*corev1.PodTemplateSpec, e
 		},
 		Spec: corev1.PodSpec{
 			Volumes:            volumes,
-			InitContainers:     cr.Spec.VMInsert.InitContainers,
+			InitContainers:     ic,
 			Containers:         containers,
 			ServiceAccountName: cr.GetServiceAccountName(),
 		},
@@ -923,15 +1101,22 @@ func buildVMStorageSpec(ctx context.Context, cr *vmv1beta1.VMCluster) (*appsv1.S
 			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
 				Type: cr.Spec.VMStorage.RollingUpdateStrategy,
 			},
-			Template:    *podSpec,
-			ServiceName: cr.GetVMStorageName(),
+			PersistentVolumeClaimRetentionPolicy: cr.Spec.VMStorage.PVCRetentionPolicy(),
+			Template:                             *podSpec,
+			ServiceName:                          cr.GetVMStorageName(),
 		},
 	}
 	build.StatefulSetAddCommonParams(stsSpec, ptr.Deref(cr.Spec.VMStorage.UseStrictSecurity, false), &cr.Spec.VMStorage.CommonApplicationDeploymentParams)
+	if !cr.Spec.VMStorage.DisableDefaultAntiAffinity {
+		build.AddDefaultPodAntiAffinity(&stsSpec.Spec.Template.Spec, stsSpec.Spec.Selector)
+	}
 	storageSpec := cr.Spec.VMStorage.Storage
 	storageSpec.IntoSTSVolume(cr.Spec.VMStorage.GetStorageVolumeName(), &stsSpec.Spec)
 	stsSpec.Spec.VolumeClaimTemplates = append(stsSpec.Spec.VolumeClaimTemplates, cr.Spec.VMStorage.ClaimTemplates...)
 
+	if err := build.PodTemplateAddPatches(&stsSpec.Spec.Template, cr.Spec.VMStorage.PodTemplatePatches); err != nil {
+		return nil, err
+	}
 	return stsSpec, nil
 }
 
@@ -1048,6 +1233,9 @@ func makePodSpecForVMStorage(ctx context.Context, cr *vmv1beta1.VMCluster) (*cor
 	volumes, vmMounts = cr.Spec.License.MaybeAddToVolumes(volumes, vmMounts, vmv1beta1.SecretsDir)
 	args = cr.Spec.License.MaybeAddToArgs(args, vmv1beta1.SecretsDir)
 
+	volumes, vmMounts = cr.Spec.VMStorage.ServerTLS.MaybeAddToVolumes(volumes, vmMounts, vmv1beta1.ServerTLSDir)
+	args = cr.Spec.VMStorage.ServerTLS.MaybeAddToArgs(args, vmv1beta1.ServerTLSDir)
+
 	args = build.AddExtraArgsOverrideDefaults(args, cr.Spec.VMStorage.ExtraArgs, "-")
 	sort.Strings(args)
 	vmstorageContainer := corev1.Container{
@@ -1059,6 +1247,7 @@ func makePodSpecForVMStorage(ctx context.Context, cr *vmv1beta1.VMCluster) (*cor
 		VolumeMounts:             vmMounts,
 		Resources:                cr.Spec.VMStorage.Resources,
 		Env:                      envs,
+		Lifecycle:                cr.Spec.VMStorage.Lifecycle,
 		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		TerminationMessagePath:   "/dev/termination-log",
 	}
@@ -1069,7 +1258,7 @@ func makePodSpecForVMStorage(ctx context.Context, cr *vmv1beta1.VMCluster) (*cor
 	var initContainers []corev1.Container
 
 	if cr.Spec.VMStorage.VMBackup != nil {
-		vmBackupManagerContainer, err := build.VMBackupManager(ctx, cr.Spec.VMStorage.VMBackup, cr.Spec.VMStorage.Port, cr.Spec.VMStorage.StorageDataPath, cr.Spec.VMStorage.GetStorageVolumeName(), cr.Spec.VMStorage.ExtraArgs, true, cr.Spec.License)
+		vmBackupManagerContainer, err := build.VMBackupManager(ctx, cr.Spec.VMStorage.VMBackup, cr.Spec.VMStorage.Port, cr.Spec.VMStorage.StorageDataPath, cr.Spec.VMStorage.GetStorageVolumeName(), &cr.Spec.VMStorage.CommonApplicationDeploymentParams, true, cr.Spec.License)
 		if err != nil {
 			return nil, err
 		}
@@ -1088,18 +1277,25 @@ func makePodSpecForVMStorage(ctx context.Context, cr *vmv1beta1.VMCluster) (*cor
 			}
 		}
 	}
+	if build.IsVMRestoreFromNeeded(cr.Spec.VMStorage.RestoreFrom, cr.Status.VMStorageRestore) {
+		// restore must be performed before any other init container
+		vmRestore, restoreVolumes := build.VMRestoreFrom(cr.Spec.VMStorage.RestoreFrom, cr.Spec.VMStorage.StorageDataPath, cr.Spec.VMStorage.GetStorageVolumeName(), true)
+		initContainers = append([]corev1.Container{*vmRestore}, initContainers...)
+		volumes = append(volumes, restoreVolumes...)
+	}
 	useStrictSecurity := ptr.Deref(cr.Spec.VMStorage.UseStrictSecurity, false)
-	build.AddStrictSecuritySettingsToContainers(cr.Spec.VMStorage.SecurityContext, initContainers, useStrictSecurity)
+	build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.VMStorage.SecurityContext, initContainers, useStrictSecurity)
 	ic, err := k8stools.MergePatchContainers(initContainers, cr.Spec.VMStorage.InitContainers)
 	if err != nil {
 		return nil, fmt.Errorf("cannot patch vmstorage init containers: %w", err)
 	}
 
-	build.AddStrictSecuritySettingsToContainers(cr.Spec.VMStorage.SecurityContext, operatorContainers, useStrictSecurity)
+	build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.VMStorage.SecurityContext, operatorContainers, useStrictSecurity)
 	containers, err := k8stools.MergePatchContainers(operatorContainers, cr.Spec.VMStorage.Containers)
 	if err != nil {
 		return nil, fmt.Errorf("cannot patch vmstorage containers: %w", err)
 	}
+	ic, containers = build.AddSidecars(ic, containers, cr.Spec.VMStorage.Sidecars)
 
 	for i := range cr.Spec.VMStorage.TopologySpreadConstraints {
 		if cr.Spec.VMStorage.TopologySpreadConstraints[i].LabelSelector == nil {
@@ -1232,10 +1428,18 @@ func deletePrevStateResources(ctx context.Context, rclient client.Client, cr, pr
 					return fmt.Errorf("cannot remove serviceScrape from prev storage: %w", err)
 				}
 			}
+			if !ptr.Deref(vmst.SelfMonitoringRules, false) && ptr.Deref(prevSt.SelfMonitoringRules, false) {
+				if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMRule{ObjectMeta: commonObjMeta}); err != nil {
+					return fmt.Errorf("cannot remove self-monitoring VMRule from prev storage: %w", err)
+				}
+			}
 			prevSvc, currSvc := prevSt.ServiceSpec, vmst.ServiceSpec
 			if err := reconcile.AdditionalServices(ctx, rclient, cr.GetVMStorageName(), cr.Namespace, prevSvc, currSvc); err != nil {
 				return fmt.Errorf("cannot remove vmstorage additional service: %w", err)
 			}
+			if err := reconcile.AdditionalServiceList(ctx, rclient, cr.Namespace, prevSt.AdditionalServices, vmst.AdditionalServices); err != nil {
+				return fmt.Errorf("cannot remove vmstorage additional services: %w", err)
+			}
 		}
 	}
 
@@ -1262,10 +1466,18 @@ func deletePrevStateResources(ctx context.Context, rclient client.Client, cr, pr
 					return fmt.Errorf("cannot remove serviceScrape from prev select: %w", err)
 				}
 			}
+			if !ptr.Deref(vmse.SelfMonitoringRules, false) && ptr.Deref(prevSe.SelfMonitoringRules, false) {
+				if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMRule{ObjectMeta: commonObjMeta}); err != nil {
+					return fmt.Errorf("cannot remove self-monitoring VMRule from prev select: %w", err)
+				}
+			}
 			prevSvc, currSvc := prevSe.ServiceSpec, vmse.ServiceSpec
 			if err := reconcile.AdditionalServices(ctx, rclient, cr.GetVMSelectName(), cr.Namespace, prevSvc, currSvc); err != nil {
 				return fmt.Errorf("cannot remove vmselect additional service: %w", err)
 			}
+			if err := reconcile.AdditionalServiceList(ctx, rclient, cr.Namespace, prevSe.AdditionalServices, vmse.AdditionalServices); err != nil {
+				return fmt.Errorf("cannot remove vmselect additional services: %w", err)
+			}
 		}
 		// transition to load-balancer state
 		// have to remove prev service scrape
@@ -1328,10 +1540,23 @@ func deletePrevStateResources(ctx context.Context, rclient client.Client, cr, pr
 					return fmt.Errorf("cannot remove serviceScrape from prev insert: %w", err)
 				}
 			}
+			if !ptr.Deref(vmis.SelfMonitoringRules, false) && ptr.Deref(prevIs.SelfMonitoringRules, false) {
+				if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMRule{ObjectMeta: commonObjMeta}); err != nil {
+					return fmt.Errorf("cannot remove self-monitoring VMRule from prev insert: %w", err)
+				}
+			}
+			if vmis.OTLP == nil && prevIs.OTLP != nil {
+				if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: cr.GetVMInsertOTLPName(), Namespace: cr.Namespace}}); err != nil {
+					return fmt.Errorf("cannot remove otlp service from prev insert: %w", err)
+				}
+			}
 			prevSvc, currSvc := prevIs.ServiceSpec, vmis.ServiceSpec
 			if err := reconcile.AdditionalServices(ctx, rclient, cr.GetVMInsertName(), cr.Namespace, prevSvc, currSvc); err != nil {
 				return fmt.Errorf("cannot remove vminsert additional service: %w", err)
 			}
+			if err := reconcile.AdditionalServiceList(ctx, rclient, cr.Namespace, prevIs.AdditionalServices, vmis.AdditionalServices); err != nil {
+				return fmt.Errorf("cannot remove vminsert additional services: %w", err)
+			}
 		}
 		// transition to load-balancer state
 		// have to remove prev service scrape
@@ -1390,6 +1615,16 @@ func deletePrevStateResources(ctx context.Context, rclient client.Client, cr, pr
 				return fmt.Errorf("cannot delete PodDisruptionBudget for cluster lb: %w", err)
 			}
 		}
+		if ptr.Deref(lbSpec.DisableSelfServiceScrape, false) && !ptr.Deref(prevLBSpec.DisableSelfServiceScrape, false) {
+			if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cr.GetVMAuthLBName(),
+					Namespace: cr.Namespace,
+				},
+			}); err != nil {
+				return fmt.Errorf("cannot delete VMServiceScrape for cluster lb: %w", err)
+			}
+		}
 	}
 
 	return nil
@@ -1412,11 +1647,15 @@ func buildVMauthLBSecret(cr *vmv1beta1.VMCluster) *corev1.Secret {
 	}
 	insertPort := "8480"
 	selectPort := "8481"
+	insertProto := "http"
+	selectProto := "http"
 	if cr.Spec.VMSelect != nil {
 		selectPort = cr.Spec.VMSelect.Port
+		selectProto = strings.ToLower(cr.Spec.VMSelect.ProbeScheme())
 	}
 	if cr.Spec.VMInsert != nil {
 		insertPort = cr.Spec.VMInsert.Port
+		insertProto = strings.ToLower(cr.Spec.VMInsert.ProbeScheme())
 	}
 	lbScrt := &corev1.Secret{
 		ObjectMeta: buildLBConfigSecretMeta(cr),
@@ -1425,19 +1664,28 @@ unauthorized_user:
   url_map:
   - src_paths:
     - "/insert/.*"
-    url_prefix: "http://srv+%s.%s:%s"
-    discover_backend_ips: true
+    url_prefix: "%s://srv+%s.%s:%s"
+    discover_backend_ips: true%s
   - src_paths:
     - "/.*"
-    url_prefix: "http://srv+%s.%s:%s"
-    discover_backend_ips: true
-      `, cr.GetVMInsertLBName(), targetHostSuffix, insertPort,
-			cr.GetVMSelectLBName(), targetHostSuffix, selectPort,
+    url_prefix: "%s://srv+%s.%s:%s"
+    discover_backend_ips: true%s
+      `, insertProto, cr.GetVMInsertLBName(), targetHostSuffix, insertPort, lbBackendTLSOpts(insertProto),
+			selectProto, cr.GetVMSelectLBName(), targetHostSuffix, selectPort, lbBackendTLSOpts(selectProto),
 		)},
 	}
 	return lbScrt
 }
 
+// lbBackendTLSOpts returns url_map options for backend with enabled TLS.
+// Backends are discovered by pod ips, which cannot match certificate hosts
+func lbBackendTLSOpts(proto string) string {
+	if proto != "https" {
+		return ""
+	}
+	return "\n    tls_insecure_skip_verify: true"
+}
+
 func buildVMauthLBDeployment(cr *vmv1beta1.VMCluster) (*appsv1.Deployment, error) {
 	spec := cr.Spec.RequestsLoadBalancer.Spec
 	const configMountName = "vmauth-lb-config"
@@ -1479,6 +1727,9 @@ func buildVMauthLBDeployment(cr *vmv1beta1.VMCluster) (*appsv1.Deployment, error
 	volumes, vmounts = cr.Spec.License.MaybeAddToVolumes(volumes, vmounts, vmv1beta1.SecretsDir)
 	args = cr.Spec.License.MaybeAddToArgs(args, vmv1beta1.SecretsDir)
 
+	volumes, vmounts = spec.ServerTLS.MaybeAddToVolumes(volumes, vmounts, vmv1beta1.ServerTLSDir)
+	args = spec.ServerTLS.MaybeAddToArgs(args, vmv1beta1.ServerTLSDir)
+
 	args = build.AddExtraArgsOverrideDefaults(args, spec.ExtraArgs, "-")
 	vmauthLBCnt := corev1.Container{
 		Name: "vmauth",
@@ -1495,6 +1746,7 @@ func buildVMauthLBDeployment(cr *vmv1beta1.VMCluster) (*appsv1.Deployment, error
 		Image:           fmt.Sprintf("%s:%s", spec.Image.Repository, spec.Image.Tag),
 		ImagePullPolicy: spec.Image.PullPolicy,
 		VolumeMounts:    vmounts,
+		Lifecycle:       spec.Lifecycle,
 	}
 	vmauthLBCnt = build.Probe(vmauthLBCnt, &spec)
 	containers := []corev1.Container{
@@ -1502,11 +1754,12 @@ func buildVMauthLBDeployment(cr *vmv1beta1.VMCluster) (*appsv1.Deployment, error
 	}
 	var err error
 
-	build.AddStrictSecuritySettingsToContainers(spec.SecurityContext, containers, ptr.Deref(spec.UseStrictSecurity, false))
+	build.AddStrictSecuritySettingsToContainers(cr.Namespace, spec.SecurityContext, containers, ptr.Deref(spec.UseStrictSecurity, false))
 	containers, err = k8stools.MergePatchContainers(containers, spec.Containers)
 	if err != nil {
 		return nil, fmt.Errorf("cannot patch containers: %w", err)
 	}
+	ic, containers := build.AddSidecars(spec.InitContainers, containers, spec.Sidecars)
 	lbDep := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:       cr.Namespace,
@@ -1525,7 +1778,7 @@ func buildVMauthLBDeployment(cr *vmv1beta1.VMCluster) (*appsv1.Deployment, error
 				},
 				Spec: corev1.PodSpec{
 					Volumes:            volumes,
-					InitContainers:     spec.InitContainers,
+					InitContainers:     ic,
 					Containers:         containers,
 					ServiceAccountName: cr.GetServiceAccountName(),
 				},
@@ -1534,6 +1787,9 @@ func buildVMauthLBDeployment(cr *vmv1beta1.VMCluster) (*appsv1.Deployment, error
 	}
 	build.DeploymentAddCommonParams(lbDep, ptr.Deref(cr.Spec.RequestsLoadBalancer.Spec.UseStrictSecurity, false), &spec.CommonApplicationDeploymentParams)
 
+	if err := build.PodTemplateAddPatches(&lbDep.Spec.Template, spec.PodTemplatePatches); err != nil {
+		return nil, err
+	}
 	return lbDep, nil
 }
 
@@ -1565,10 +1821,12 @@ func createOrUpdateVMAuthLBService(ctx context.Context, rclient client.Client, c
 	if err := reconcile.Service(ctx, rclient, svc, prevSvc); err != nil {
 		return fmt.Errorf("cannot reconcile vmauthlb service: %w", err)
 	}
-	svs := build.VMServiceScrapeForServiceWithSpec(svc, &cr.Spec.RequestsLoadBalancer.Spec, "http")
-	svs.Spec.Selector.MatchLabels[vmauthLBServiceProxyTargetLabel] = "vmauth"
-	if err := reconcile.VMServiceScrapeForCRD(ctx, rclient, svs); err != nil {
-		return fmt.Errorf("cannot reconcile vmauthlb vmservicescrape: %w", err)
+	if !ptr.Deref(cr.Spec.RequestsLoadBalancer.Spec.DisableSelfServiceScrape, false) {
+		svs := build.VMServiceScrapeForServiceWithDefaults(svc, &cr.Spec.RequestsLoadBalancer.Spec, build.ServiceScrapeDefaults{}.WithServerTLS(cr.Spec.RequestsLoadBalancer.Spec.ServerTLS, svc), "http")
+		svs.Spec.Selector.MatchLabels[vmauthLBServiceProxyTargetLabel] = "vmauth"
+		if err := reconcile.VMServiceScrapeForCRD(ctx, rclient, svs); err != nil {
+			return fmt.Errorf("cannot reconcile vmauthlb vmservicescrape: %w", err)
+		}
 	}
 	return nil
 }
@@ -1582,6 +1840,9 @@ func createOrUpdateVMAuthLB(ctx context.Context, rclient client.Client, cr, prev
 	if err := reconcile.Secret(ctx, rclient, buildVMauthLBSecret(cr), prevSecretMeta); err != nil {
 		return fmt.Errorf("cannot reconcile vmauth lb secret: %w", err)
 	}
+	if err := reconcile.ServerTLSSecret(ctx, rclient, cr.Spec.RequestsLoadBalancer.Spec.ServerTLS, metav1.ObjectMeta{Namespace: cr.Namespace, Labels: cr.FinalLabels(cr.VMAuthLBSelectorLabels()), OwnerReferences: cr.AsOwner()}, cr.GetVMAuthLBName()); err != nil {
+		return fmt.Errorf("cannot reconcile serverTLS secret for vmauth lb: %w", err)
+	}
 	lbDep, err := buildVMauthLBDeployment(cr)
 	if err != nil {
 		return fmt.Errorf("cannot build deployment for vmauth loadbalancing: %w", err)
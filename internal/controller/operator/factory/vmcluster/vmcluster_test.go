@@ -8,13 +8,14 @@ import (
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
-	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -336,7 +337,7 @@ func TestCreateOrUpdateVMCluster(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 			var wg sync.WaitGroup
@@ -465,7 +466,7 @@ func TestCreatOrUpdateClusterServices(t *testing.T) {
 	f := func(component string, cr *vmv1beta1.VMCluster, wantSvcYAML string, predefinedObjects ...runtime.Object) {
 		t.Helper()
 		ctx := context.Background()
-		fclient := k8stools.GetTestClientWithObjects(predefinedObjects)
+		fclient := testutil.GetTestClientWithObjects(predefinedObjects)
 		build.AddDefaults(fclient.Scheme())
 		fclient.Scheme().Default(cr)
 
@@ -1011,3 +1012,229 @@ spec:
 		},
 	})
 }
+
+func TestBuildNetworkPolicies(t *testing.T) {
+	f := func(cr *vmv1beta1.VMCluster, wantNames []string) {
+		t.Helper()
+		nps := buildNetworkPolicies(cr)
+		var gotNames []string
+		for _, np := range nps {
+			gotNames = append(gotNames, np.Name)
+			if np.Name != cr.GetVMStorageName() {
+				assert.Len(t, np.Spec.Ingress, 1)
+				assert.Empty(t, np.Spec.Ingress[0].Ports)
+				continue
+			}
+			// http port, vminsert and vmselect rules
+			assert.Len(t, np.Spec.Ingress, 3)
+			assert.Equal(t, cr.VMInsertSelectorLabels(), np.Spec.Ingress[1].From[0].PodSelector.MatchLabels)
+			assert.Equal(t, intstr.Parse(cr.Spec.VMStorage.VMInsertPort), *np.Spec.Ingress[1].Ports[0].Port)
+			assert.Equal(t, cr.VMSelectSelectorLabels(), np.Spec.Ingress[2].From[0].PodSelector.MatchLabels)
+			assert.Equal(t, intstr.Parse(cr.Spec.VMStorage.VMSelectPort), *np.Spec.Ingress[2].Ports[0].Port)
+		}
+		assert.Equal(t, wantNames, gotNames)
+	}
+	cr := &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Namespace: "default"},
+		Spec: vmv1beta1.VMClusterSpec{
+			VMStorage: &vmv1beta1.VMStorage{
+				CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{Port: "8482"},
+				VMInsertPort:            "8400",
+				VMSelectPort:            "8401",
+			},
+			VMSelect: &vmv1beta1.VMSelect{},
+			VMInsert: &vmv1beta1.VMInsert{},
+		},
+	}
+	// disabled
+	f(cr.DeepCopy(), nil)
+
+	cr.Spec.NetworkPolicy = &vmv1beta1.EmbeddedNetworkPolicy{Enabled: true}
+	f(cr.DeepCopy(), []string{cr.GetVMStorageName(), cr.GetVMSelectName(), cr.GetVMInsertName()})
+
+	cr.Spec.VMSelect = nil
+	f(cr.DeepCopy(), []string{cr.GetVMStorageName(), cr.GetVMInsertName()})
+}
+
+func TestBuildVPAs(t *testing.T) {
+	f := func(cr *vmv1beta1.VMCluster, want map[string]string) {
+		t.Helper()
+		got := make(map[string]string)
+		for _, vpa := range buildVPAs(cr) {
+			kind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+			got[vpa.GetName()] = kind
+		}
+		assert.Equal(t, want, got)
+	}
+	cr := &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Namespace: "default"},
+		Spec: vmv1beta1.VMClusterSpec{
+			VMStorage: &vmv1beta1.VMStorage{},
+			VMSelect:  &vmv1beta1.VMSelect{},
+			VMInsert:  &vmv1beta1.VMInsert{},
+		},
+	}
+	// not configured
+	f(cr.DeepCopy(), map[string]string{})
+
+	cr.Spec.VMStorage.VPA = &vmv1beta1.EmbeddedVPA{UpdateMode: "Off"}
+	cr.Spec.VMInsert.VPA = &vmv1beta1.EmbeddedVPA{UpdateMode: "Auto"}
+	f(cr.DeepCopy(), map[string]string{
+		cr.GetVMStorageName(): "StatefulSet",
+		cr.GetVMInsertName():  "Deployment",
+	})
+
+	cr.Spec.VMSelect.VPA = &vmv1beta1.EmbeddedVPA{}
+	cr.Spec.VMInsert = nil
+	f(cr.DeepCopy(), map[string]string{
+		cr.GetVMStorageName(): "StatefulSet",
+		cr.GetVMSelectName():  "StatefulSet",
+	})
+}
+
+func TestCreateOrUpdateGrafanaDashboard(t *testing.T) {
+	ctx := context.Background()
+	cr := &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Namespace: "default"},
+		Spec: vmv1beta1.VMClusterSpec{
+			VMSelect: &vmv1beta1.VMSelect{},
+			VMStorage: &vmv1beta1.VMStorage{
+				CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{
+					Image: vmv1beta1.Image{Tag: "v1.110.0-cluster"},
+				},
+			},
+			GrafanaDashboard: &vmv1beta1.GrafanaDashboard{
+				Mode: vmv1beta1.GrafanaDashboardModeConfigMap,
+			},
+		},
+	}
+	fclient := testutil.GetTestClientWithObjects(nil)
+	nsn := types.NamespacedName{Namespace: cr.Namespace, Name: cr.GrafanaDashboardName()}
+
+	// version of the first configured component is used
+	cr.Spec.VMSelect.Image.Tag = "v1.111.0-cluster"
+	assert.NoError(t, createOrUpdateGrafanaDashboard(ctx, fclient, cr, nil))
+	var cm corev1.ConfigMap
+	assert.NoError(t, fclient.Get(ctx, nsn, &cm))
+	assert.Equal(t, map[string]string{
+		"victoriametrics-cluster.json.url": "https://raw.githubusercontent.com/VictoriaMetrics/VictoriaMetrics/v1.111.0/dashboards/victoriametrics-cluster.json",
+	}, cm.Data)
+	assert.Equal(t, "1", cm.Labels["grafana_dashboard"])
+
+	// dashboard removed from spec
+	prevCR := cr.DeepCopy()
+	cr.Spec.GrafanaDashboard = nil
+	assert.NoError(t, createOrUpdateGrafanaDashboard(ctx, fclient, cr, prevCR))
+	assert.True(t, errors.IsNotFound(fclient.Get(ctx, nsn, &cm)))
+}
+
+func TestServerTLS(t *testing.T) {
+	cr := &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Namespace: "default"},
+		Spec: vmv1beta1.VMClusterSpec{
+			VMSelect: &vmv1beta1.VMSelect{
+				CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{Port: "8481"},
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					ServerTLS: &vmv1beta1.ServerTLS{SecretName: "vmselect-tls"},
+				},
+			},
+			VMInsert: &vmv1beta1.VMInsert{
+				CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{Port: "8480"},
+			},
+			RequestsLoadBalancer: vmv1beta1.VMAuthLoadBalancer{Enabled: true},
+		},
+	}
+	assert.Equal(t, "http://vmselect-cluster-1.default.svc:8481", cr.VMSelectURL())
+
+	lbSecret := buildVMauthLBSecret(cr)
+	lbConfig := lbSecret.StringData["config.yaml"]
+	assert.Contains(t, lbConfig, `url_prefix: "http://srv+vminsertinternal-cluster-1.default.svc:8480"
+    discover_backend_ips: true
+`)
+	assert.Contains(t, lbConfig, `url_prefix: "https://srv+vmselectinternal-cluster-1.default.svc:8481"
+    discover_backend_ips: true
+    tls_insecure_skip_verify: true
+`)
+
+	cr.Spec.RequestsLoadBalancer.Enabled = false
+	assert.Equal(t, "https://vmselect-cluster-1.default.svc:8481", cr.VMSelectURL())
+
+	svc := buildVMSelectService(cr)
+	svs := build.VMServiceScrapeForServiceWithSpec(svc, cr.Spec.VMSelect, "http")
+	assert.Len(t, svs.Spec.Endpoints, 1)
+	assert.Equal(t, "https", svs.Spec.Endpoints[0].Scheme)
+
+	podSpec, err := makePodSpecForVMSelect(cr)
+	assert.NoError(t, err)
+	cnt := podSpec.Spec.Containers[0]
+	assert.Contains(t, cnt.Args, "-tls=true")
+	assert.Contains(t, cnt.Args, "-tlsCertFile=/etc/vm/server-tls/tls.crt")
+	assert.Contains(t, cnt.Args, "-tlsKeyFile=/etc/vm/server-tls/tls.key")
+	assert.Equal(t, corev1.URISchemeHTTPS, cnt.ReadinessProbe.HTTPGet.Scheme)
+}
+
+func TestCreateOrUpdateVMAuthLBServiceScrape(t *testing.T) {
+	ctx := context.Background()
+	cr := &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Namespace: "default"},
+		Spec: vmv1beta1.VMClusterSpec{
+			RequestsLoadBalancer: vmv1beta1.VMAuthLoadBalancer{
+				Enabled: true,
+				Spec: vmv1beta1.VMAuthLoadBalancerSpec{
+					CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{Port: "8427"},
+				},
+			},
+		},
+	}
+	fclient := testutil.GetTestClientWithObjects(nil)
+	nsn := types.NamespacedName{Namespace: cr.Namespace, Name: cr.GetVMAuthLBName()}
+
+	assert.NoError(t, createOrUpdateVMAuthLBService(ctx, fclient, cr, nil))
+	var svs vmv1beta1.VMServiceScrape
+	assert.NoError(t, fclient.Get(ctx, nsn, &svs))
+
+	// self scrape disabled
+	prevCR := cr.DeepCopy()
+	cr.Spec.RequestsLoadBalancer.Spec.DisableSelfServiceScrape = ptr.To(true)
+	assert.NoError(t, deletePrevStateResources(ctx, fclient, cr, prevCR))
+	assert.NoError(t, createOrUpdateVMAuthLBService(ctx, fclient, cr, prevCR))
+	assert.True(t, errors.IsNotFound(fclient.Get(ctx, nsn, &svs)))
+}
+
+func TestStatefulSetPVCRetentionPolicy(t *testing.T) {
+	f := func(policy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy) {
+		t.Helper()
+		cr := &vmv1beta1.VMCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Namespace: "default"},
+			Spec: vmv1beta1.VMClusterSpec{
+				VMStorage: &vmv1beta1.VMStorage{
+					PersistentVolumeClaimRetentionPolicy: policy,
+					CommonApplicationDeploymentParams:    vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To[int32](1)},
+				},
+				VMSelect: &vmv1beta1.VMSelect{
+					PersistentVolumeClaimRetentionPolicy: policy,
+					CommonApplicationDeploymentParams:    vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To[int32](1)},
+				},
+			},
+		}
+		storageSts, err := buildVMStorageSpec(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("cannot build vmstorage statefulset: %s", err)
+		}
+		assert.Equal(t, policy, storageSts.Spec.PersistentVolumeClaimRetentionPolicy)
+		selectSts, err := genVMSelectSpec(cr)
+		if err != nil {
+			t.Fatalf("cannot build vmselect statefulset: %s", err)
+		}
+		assert.Equal(t, policy, selectSts.Spec.PersistentVolumeClaimRetentionPolicy)
+	}
+
+	// not configured
+	f(nil)
+
+	// delete pvcs on scale down only
+	f(&appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+		WhenDeleted: appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+		WhenScaled:  appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+	})
+}
@@ -0,0 +1,65 @@
+package vmcluster
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+// buildVPAs returns VerticalPodAutoscaler for each cluster component with configured vpa
+func buildVPAs(cr *vmv1beta1.VMCluster) []*unstructured.Unstructured {
+	var vpas []*unstructured.Unstructured
+	add := func(name, kind string, selectorLabels map[string]string, spec *vmv1beta1.EmbeddedVPA, containerName string) {
+		if spec == nil {
+			return
+		}
+		b := newOptsBuilder(cr, name, selectorLabels)
+		targetRef := autoscalingv2.CrossVersionObjectReference{
+			Name:       name,
+			Kind:       kind,
+			APIVersion: "apps/v1",
+		}
+		vpas = append(vpas, build.VPA(b, targetRef, containerName, spec))
+	}
+	if cr.Spec.VMStorage != nil {
+		add(cr.GetVMStorageName(), "StatefulSet", cr.VMStorageSelectorLabels(), cr.Spec.VMStorage.VPA, "vmstorage")
+	}
+	if cr.Spec.VMSelect != nil {
+		add(cr.GetVMSelectName(), "StatefulSet", cr.VMSelectSelectorLabels(), cr.Spec.VMSelect.VPA, "vmselect")
+	}
+	if cr.Spec.VMInsert != nil {
+		add(cr.GetVMInsertName(), "Deployment", cr.VMInsertSelectorLabels(), cr.Spec.VMInsert.VPA, "vminsert")
+	}
+	return vpas
+}
+
+// createOrUpdateVPAs reconciles VerticalPodAutoscalers of cluster components
+// and removes autoscalers of components without vpa since previous state
+func createOrUpdateVPAs(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMCluster) error {
+	prevVPAs := make(map[string]*unstructured.Unstructured)
+	if prevCR != nil {
+		for _, vpa := range buildVPAs(prevCR) {
+			prevVPAs[vpa.GetName()] = vpa
+		}
+	}
+	for _, vpa := range buildVPAs(cr) {
+		if err := reconcile.VPA(ctx, rclient, vpa, prevVPAs[vpa.GetName()]); err != nil {
+			return fmt.Errorf("cannot update vertical pod autoscaler %s: %w", vpa.GetName(), err)
+		}
+		delete(prevVPAs, vpa.GetName())
+	}
+	for _, vpa := range prevVPAs {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, vpa); err != nil {
+			return fmt.Errorf("cannot delete VPA from prev state: %w", err)
+		}
+	}
+	return nil
+}
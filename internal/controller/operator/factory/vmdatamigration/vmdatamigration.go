@@ -0,0 +1,248 @@
+package vmdatamigration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+const (
+	srcUsernameEnv = "VMCTL_SRC_USERNAME"
+	srcPasswordEnv = "VMCTL_SRC_PASSWORD"
+)
+
+// CreateOrUpdate creates migration job and reflects its state at the object status
+// job is re-created if migration settings were changed
+func CreateOrUpdate(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMDataMigration) error {
+	dstURL, err := getDestinationURL(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	newJob, err := newJobForMigration(cr, dstURL)
+	if err != nil {
+		return err
+	}
+	var currentJob batchv1.Job
+	if err := rclient.Get(ctx, types.NamespacedName{Namespace: newJob.Namespace, Name: newJob.Name}, &currentJob); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("cannot get migration job: %w", err)
+		}
+		logger.WithContext(ctx).Info(fmt.Sprintf("creating new migration Job %s", newJob.Name))
+		if err := rclient.Create(ctx, newJob); err != nil {
+			return fmt.Errorf("cannot create migration job: %w", err)
+		}
+		events.Normal(cr, events.ReasonCreated, fmt.Sprintf("created migration Job %s", newJob.Name))
+		return updateStatus(ctx, rclient, cr, newJob)
+	}
+	// job template is immutable, so job must be re-created in order to apply changes
+	if !equality.Semantic.DeepDerivative(newJob.Spec.Template.Spec, currentJob.Spec.Template.Spec) {
+		logger.WithContext(ctx).Info(fmt.Sprintf("migration settings were changed, re-creating Job %s", newJob.Name))
+		if err := rclient.Delete(ctx, &currentJob, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("cannot delete outdated migration job: %w", err)
+		}
+		if err := rclient.Create(ctx, newJob); err != nil {
+			return fmt.Errorf("cannot create migration job: %w", err)
+		}
+		return updateStatus(ctx, rclient, cr, newJob)
+	}
+	return updateStatus(ctx, rclient, cr, &currentJob)
+}
+
+func updateStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMDataMigration, job *batchv1.Job) error {
+	prevStatus := cr.Status.DeepCopy()
+	cr.Status.Phase = jobPhase(job)
+	cr.Status.StartTime = job.Status.StartTime
+	cr.Status.CompletionTime = job.Status.CompletionTime
+	if equality.Semantic.DeepEqual(prevStatus, &cr.Status) {
+		return nil
+	}
+	if err := rclient.Status().Update(ctx, cr); err != nil {
+		return fmt.Errorf("cannot update migration status: %w", err)
+	}
+	logger.WithContext(ctx).Info(fmt.Sprintf("migration phase changed to %q", cr.Status.Phase))
+	return nil
+}
+
+func jobPhase(job *batchv1.Job) vmv1beta1.DataMigrationPhase {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return vmv1beta1.DataMigrationPhaseSucceeded
+		case batchv1.JobFailed:
+			return vmv1beta1.DataMigrationPhaseFailed
+		}
+	}
+	if job.Status.Active > 0 {
+		return vmv1beta1.DataMigrationPhaseRunning
+	}
+	return vmv1beta1.DataMigrationPhasePending
+}
+
+// getDestinationURL returns url of the storage, which accepts migrated data
+func getDestinationURL(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMDataMigration) (string, error) {
+	nsn := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Spec.DestinationRef.Name}
+	switch cr.Spec.DestinationRef.Kind {
+	case "VMSingle":
+		var vmSingle vmv1beta1.VMSingle
+		if err := rclient.Get(ctx, nsn, &vmSingle); err != nil {
+			return "", fmt.Errorf("cannot get VMSingle=%q: %w", nsn, err)
+		}
+		return vmSingle.AsURL(), nil
+	case "VMCluster":
+		var vmCluster vmv1beta1.VMCluster
+		if err := rclient.Get(ctx, nsn, &vmCluster); err != nil {
+			return "", fmt.Errorf("cannot get VMCluster=%q: %w", nsn, err)
+		}
+		if vmCluster.Spec.VMInsert == nil {
+			return "", fmt.Errorf("VMCluster=%q has no vminsert component", nsn)
+		}
+		return vmCluster.VMInsertURL(), nil
+	default:
+		return "", fmt.Errorf("unsupported destinationRef.kind=%q, supported values are VMSingle and VMCluster", cr.Spec.DestinationRef.Kind)
+	}
+}
+
+// buildArgs returns vmctl args for the given migration mode
+func buildArgs(cr *vmv1beta1.VMDataMigration, dstURL string) ([]string, error) {
+	spec := cr.Spec
+	isCluster := spec.DestinationRef.Kind == "VMCluster"
+	tenantID := spec.DestinationRef.TenantID
+	if tenantID == "" {
+		tenantID = "0"
+	}
+	flags := map[string]string{}
+	var srcPrefix, filterPrefix string
+	switch spec.Mode {
+	case vmv1beta1.DataMigrationModeRemoteRead:
+		if spec.TimeStart == "" {
+			return nil, fmt.Errorf("timeStart must be set for mode=%q", spec.Mode)
+		}
+		flags["remote-read-src-addr"] = spec.Source.URL
+		flags["remote-read-step-interval"] = "day"
+		srcPrefix, filterPrefix = "remote-read", "remote-read-filter"
+	case vmv1beta1.DataMigrationModeInflux:
+		if spec.Source.Database == "" {
+			return nil, fmt.Errorf("source.database must be set for mode=%q", spec.Mode)
+		}
+		flags["influx-addr"] = spec.Source.URL
+		flags["influx-database"] = spec.Source.Database
+		srcPrefix, filterPrefix = "influx", "influx-filter"
+	case vmv1beta1.DataMigrationModeVMNative:
+		if spec.TimeStart == "" {
+			return nil, fmt.Errorf("timeStart must be set for mode=%q", spec.Mode)
+		}
+		flags["vm-native-src-addr"] = spec.Source.URL
+		flags["vm-native-filter-match"] = `{__name__!=""}`
+		if spec.Match != "" {
+			flags["vm-native-filter-match"] = spec.Match
+		}
+		srcPrefix, filterPrefix = "vm-native-src", "vm-native-filter"
+	default:
+		return nil, fmt.Errorf("unsupported mode=%q", spec.Mode)
+	}
+	if spec.TimeStart != "" {
+		flags[filterPrefix+"-time-start"] = spec.TimeStart
+	}
+	if spec.TimeEnd != "" {
+		flags[filterPrefix+"-time-end"] = spec.TimeEnd
+	}
+	if spec.Source.BasicAuth != nil {
+		flags[srcPrefix+"-user"] = fmt.Sprintf("$(%s)", srcUsernameEnv)
+		flags[srcPrefix+"-password"] = fmt.Sprintf("$(%s)", srcPasswordEnv)
+	}
+
+	if spec.Mode == vmv1beta1.DataMigrationModeVMNative {
+		if isCluster {
+			dstURL = fmt.Sprintf("%s/insert/%s/prometheus", dstURL, tenantID)
+		}
+		flags["vm-native-dst-addr"] = dstURL
+		if spec.RateLimit != nil {
+			flags["vm-native-rate-limit"] = fmt.Sprintf("%d", *spec.RateLimit)
+		}
+	} else {
+		flags["vm-addr"] = dstURL
+		if isCluster {
+			flags["vm-account-id"] = tenantID
+		}
+		if spec.RateLimit != nil {
+			flags["vm-rate-limit"] = fmt.Sprintf("%d", *spec.RateLimit)
+		}
+	}
+	if spec.Concurrency != nil {
+		flags["vm-concurrency"] = fmt.Sprintf("%d", *spec.Concurrency)
+	}
+	for k, v := range spec.ExtraArgs {
+		flags[k] = v
+	}
+
+	args := make([]string, 0, len(flags))
+	for k, v := range flags {
+		args = append(args, fmt.Sprintf("--%s=%s", k, v))
+	}
+	sort.Strings(args)
+	// disable interactive confirmation prompts
+	return append([]string{spec.Mode, "-s"}, args...), nil
+}
+
+func newJobForMigration(cr *vmv1beta1.VMDataMigration, dstURL string) (*batchv1.Job, error) {
+	args, err := buildArgs(cr, dstURL)
+	if err != nil {
+		return nil, err
+	}
+	envs := cr.Spec.ExtraEnvs
+	if ba := cr.Spec.Source.BasicAuth; ba != nil {
+		envs = append(envs,
+			corev1.EnvVar{Name: srcUsernameEnv, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &ba.Username}},
+			corev1.EnvVar{Name: srcPasswordEnv, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &ba.Password}},
+		)
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.PrefixedName(),
+			Namespace:       cr.Namespace,
+			Labels:          cr.SelectorLabels(),
+			OwnerReferences: cr.AsOwner(),
+		},
+		Spec: batchv1.JobSpec{
+			// vmctl retries failed requests by itself
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: cr.SelectorLabels(),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					ImagePullSecrets: build.ImagePullSecrets(cr.Namespace),
+					Containers: []corev1.Container{
+						{
+							Name:                     "vmctl",
+							Image:                    fmt.Sprintf("%s:%s", cr.Spec.Image.Repository, cr.Spec.Image.Tag),
+							ImagePullPolicy:          cr.Spec.Image.PullPolicy,
+							Args:                     args,
+							Env:                      envs,
+							Resources:                cr.Spec.Resources,
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,169 @@
+package vmdatamigration
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+)
+
+func TestBuildArgs(t *testing.T) {
+	f := func(spec vmv1beta1.VMDataMigrationSpec, dstURL string, want []string, wantErr bool) {
+		t.Helper()
+		cr := &vmv1beta1.VMDataMigration{Spec: spec}
+		got, err := buildArgs(cr, dstURL)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected args\ngot:  %v\nwant: %v", got, want)
+		}
+	}
+
+	// remote-read to vmsingle
+	f(vmv1beta1.VMDataMigrationSpec{
+		Mode:           "remote-read",
+		Source:         vmv1beta1.VMDataMigrationSource{URL: "http://prometheus:9090"},
+		DestinationRef: vmv1beta1.VMDataMigrationDestinationRef{Kind: "VMSingle", Name: "main"},
+		TimeStart:      "2024-01-01T00:00:00Z",
+		RateLimit:      ptr.To[int64](1048576),
+	}, "http://vmsingle-main.default.svc:8429", []string{
+		"remote-read", "-s",
+		"--remote-read-filter-time-start=2024-01-01T00:00:00Z",
+		"--remote-read-src-addr=http://prometheus:9090",
+		"--remote-read-step-interval=day",
+		"--vm-addr=http://vmsingle-main.default.svc:8429",
+		"--vm-rate-limit=1048576",
+	}, false)
+
+	// influx to vmcluster with auth and overridden args
+	f(vmv1beta1.VMDataMigrationSpec{
+		Mode: "influx",
+		Source: vmv1beta1.VMDataMigrationSource{
+			URL:      "http://influxdb:8086",
+			Database: "telegraf",
+			BasicAuth: &vmv1beta1.BasicAuth{
+				Username: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "influx"}, Key: "user"},
+				Password: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "influx"}, Key: "password"},
+			},
+		},
+		DestinationRef: vmv1beta1.VMDataMigrationDestinationRef{Kind: "VMCluster", Name: "main", TenantID: "5"},
+		Concurrency:    ptr.To[int32](4),
+		ExtraArgs:      map[string]string{"influx-concurrency": "2"},
+	}, "http://vminsert-main.default.svc:8480", []string{
+		"influx", "-s",
+		"--influx-addr=http://influxdb:8086",
+		"--influx-concurrency=2",
+		"--influx-database=telegraf",
+		"--influx-password=$(VMCTL_SRC_PASSWORD)",
+		"--influx-user=$(VMCTL_SRC_USERNAME)",
+		"--vm-account-id=5",
+		"--vm-addr=http://vminsert-main.default.svc:8480",
+		"--vm-concurrency=4",
+	}, false)
+
+	// vm-native to vmcluster
+	f(vmv1beta1.VMDataMigrationSpec{
+		Mode:           "vm-native",
+		Source:         vmv1beta1.VMDataMigrationSource{URL: "http://old-vmsingle:8428"},
+		DestinationRef: vmv1beta1.VMDataMigrationDestinationRef{Kind: "VMCluster", Name: "main"},
+		TimeStart:      "2024-01-01T00:00:00Z",
+		TimeEnd:        "2024-02-01T00:00:00Z",
+		Match:          `{job="node"}`,
+		RateLimit:      ptr.To[int64](1024),
+	}, "http://vminsert-main.default.svc:8480", []string{
+		"vm-native", "-s",
+		"--vm-native-dst-addr=http://vminsert-main.default.svc:8480/insert/0/prometheus",
+		`--vm-native-filter-match={job="node"}`,
+		"--vm-native-filter-time-end=2024-02-01T00:00:00Z",
+		"--vm-native-filter-time-start=2024-01-01T00:00:00Z",
+		"--vm-native-rate-limit=1024",
+		"--vm-native-src-addr=http://old-vmsingle:8428",
+	}, false)
+
+	// missing time start
+	f(vmv1beta1.VMDataMigrationSpec{
+		Mode:   "vm-native",
+		Source: vmv1beta1.VMDataMigrationSource{URL: "http://old-vmsingle:8428"},
+	}, "http://vmsingle-main.default.svc:8429", nil, true)
+
+	// missing influx database
+	f(vmv1beta1.VMDataMigrationSpec{
+		Mode:   "influx",
+		Source: vmv1beta1.VMDataMigrationSource{URL: "http://influxdb:8086"},
+	}, "http://vmsingle-main.default.svc:8429", nil, true)
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	ctx := context.Background()
+	cr := &vmv1beta1.VMDataMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+		Spec: vmv1beta1.VMDataMigrationSpec{
+			Mode:           "remote-read",
+			Source:         vmv1beta1.VMDataMigrationSource{URL: "http://prometheus:9090"},
+			DestinationRef: vmv1beta1.VMDataMigrationDestinationRef{Kind: "VMSingle", Name: "main"},
+			TimeStart:      "2024-01-01T00:00:00Z",
+		},
+	}
+	fclient := testutil.GetTestClientWithObjects([]runtime.Object{
+		cr,
+		&vmv1beta1.VMSingle{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"}},
+	})
+	getJob := func() *batchv1.Job {
+		t.Helper()
+		var job batchv1.Job
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.PrefixedName()}, &job); err != nil {
+			t.Fatalf("cannot get migration job: %s", err)
+		}
+		return &job
+	}
+
+	if err := CreateOrUpdate(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	job := getJob()
+	if cr.Status.Phase != vmv1beta1.DataMigrationPhasePending {
+		t.Fatalf("unexpected phase: %q", cr.Status.Phase)
+	}
+
+	// job completion is reflected at status
+	job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue})
+	job.Status.CompletionTime = ptr.To(metav1.Now())
+	if err := fclient.Status().Update(ctx, job); err != nil {
+		t.Fatalf("cannot update job status: %s", err)
+	}
+	if err := CreateOrUpdate(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cr.Status.Phase != vmv1beta1.DataMigrationPhaseSucceeded || cr.Status.CompletionTime == nil {
+		t.Fatalf("unexpected status: %v", cr.Status)
+	}
+
+	// spec change re-creates job
+	cr.Spec.TimeStart = "2024-06-01T00:00:00Z"
+	if err := CreateOrUpdate(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	job = getJob()
+	if len(job.Status.Conditions) != 0 {
+		t.Fatalf("expected new job without conditions, got: %v", job.Status.Conditions)
+	}
+	if cr.Status.Phase != vmv1beta1.DataMigrationPhasePending {
+		t.Fatalf("unexpected phase: %q", cr.Status.Phase)
+	}
+
+	// missing destination
+	cr.Spec.DestinationRef.Name = "missing"
+	if err := CreateOrUpdate(ctx, fclient, cr); err == nil {
+		t.Fatalf("expected error for missing destination")
+	}
+}
@@ -0,0 +1,431 @@
+package vmgateway
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+const (
+	configVolumeName      = "config"
+	configDir             = "/etc/vmgateway/config"
+	rateLimitConfigName   = "ratelimit.yaml"
+	configHashAnnotation  = "operator.victoriametrics.com/config-hash"
+	defaultDatasourcePath = "/select/0/prometheus"
+)
+
+// clusterURLs contains addresses of VMCluster components
+type clusterURLs struct {
+	read  string
+	write string
+}
+
+// CreateOrUpdateVMGateway performs an update for vmgateway resource
+func CreateOrUpdateVMGateway(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMGateway) error {
+	var prevCR *vmv1beta1.VMGateway
+	if cr.ParsedLastAppliedSpec != nil {
+		prevCR = cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+	}
+	if err := deletePrevStateResources(ctx, cr, rclient); err != nil {
+		return err
+	}
+	urls, err := getClusterURLs(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+
+	if cr.IsOwnsServiceAccount() {
+		var prevSA *corev1.ServiceAccount
+		if prevCR != nil {
+			prevSA = build.ServiceAccount(prevCR)
+		}
+		if err := reconcile.ServiceAccount(ctx, rclient, build.ServiceAccount(cr), prevSA); err != nil {
+			return fmt.Errorf("failed create service account: %w", err)
+		}
+	}
+
+	configSecret, err := buildConfigSecret(cr)
+	if err != nil {
+		return err
+	}
+	var prevSecretMeta *metav1.ObjectMeta
+	if prevCR != nil {
+		prevSecretMeta = ptr.To(buildConfigSecretMeta(prevCR))
+	}
+	if err := reconcile.Secret(ctx, rclient, configSecret, prevSecretMeta); err != nil {
+		return fmt.Errorf("cannot reconcile config secret for vmgateway: %w", err)
+	}
+
+	svc, err := createOrUpdateVMGatewayService(ctx, rclient, cr, prevCR)
+	if err != nil {
+		return err
+	}
+
+	if !ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
+		err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithDefaults(svc, cr, build.ServiceScrapeDefaults{}.WithServerTLS(cr.Spec.ServerTLS, svc)))
+		if err != nil {
+			return fmt.Errorf("cannot create serviceScrape for vmgateway: %w", err)
+		}
+	}
+	if ptr.Deref(cr.Spec.SelfMonitoringRules, false) {
+		if err := reconcile.VMRuleForCRD(ctx, rclient, build.SelfMonitoringVMRule(svc, svc.Name, "vmgateway")); err != nil {
+			return fmt.Errorf("cannot create self-monitoring VMRule for vmgateway: %w", err)
+		}
+	}
+	if cr.Spec.PodDisruptionBudget != nil {
+		var prevPDB *policyv1.PodDisruptionBudget
+		if prevCR != nil && prevCR.Spec.PodDisruptionBudget != nil {
+			prevPDB = build.PodDisruptionBudget(prevCR, prevCR.Spec.PodDisruptionBudget)
+		}
+		if err := reconcile.PDB(ctx, rclient, build.PodDisruptionBudget(cr, cr.Spec.PodDisruptionBudget), prevPDB); err != nil {
+			return fmt.Errorf("cannot update pod disruption budget for vmgateway: %w", err)
+		}
+	}
+
+	if err := reconcile.ServerTLSSecret(ctx, rclient, cr.Spec.ServerTLS, metav1.ObjectMeta{Namespace: cr.Namespace, Labels: cr.AllLabels(), OwnerReferences: cr.AsOwner()}, cr.PrefixedName()); err != nil {
+		return fmt.Errorf("cannot reconcile serverTLS secret for vmgateway: %w", err)
+	}
+	var prevDeploy *appsv1.Deployment
+	if prevCR != nil {
+		prevConfigSecret, err := buildConfigSecret(prevCR)
+		if err != nil {
+			return fmt.Errorf("cannot generate prev config: %w", err)
+		}
+		prevDeploy, err = newDeployForVMGateway(prevCR, urls, prevConfigSecret)
+		if err != nil {
+			return fmt.Errorf("cannot generate prev deploy spec: %w", err)
+		}
+	}
+
+	newDeploy, err := newDeployForVMGateway(cr, urls, configSecret)
+	if err != nil {
+		return fmt.Errorf("cannot generate new deploy for vmgateway: %w", err)
+	}
+
+	return reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, false)
+}
+
+// getClusterURLs returns vmselect and vminsert addresses of referenced VMCluster
+func getClusterURLs(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMGateway) (*clusterURLs, error) {
+	nsn := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Spec.ClusterRef.Name}
+	var cluster vmv1beta1.VMCluster
+	if err := rclient.Get(ctx, nsn, &cluster); err != nil {
+		return nil, fmt.Errorf("cannot get VMCluster=%q for vmgateway: %w", nsn, err)
+	}
+	urls := &clusterURLs{
+		read:  cluster.VMSelectURL(),
+		write: cluster.VMInsertURL(),
+	}
+	if urls.read == "" && urls.write == "" {
+		return nil, fmt.Errorf("VMCluster=%q must have vmselect or vminsert component", nsn)
+	}
+	if len(cr.Spec.RateLimits) > 0 && urls.read == "" {
+		return nil, fmt.Errorf("VMCluster=%q must have vmselect component, it's used as datasource for rate limits", nsn)
+	}
+	return urls, nil
+}
+
+func buildConfigSecretMeta(cr *vmv1beta1.VMGateway) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            cr.ConfigSecretName(),
+		Namespace:       cr.Namespace,
+		Labels:          cr.AllLabels(),
+		Annotations:     cr.AnnotationsFiltered(),
+		OwnerReferences: cr.AsOwner(),
+		Finalizers: []string{
+			vmv1beta1.FinalizerName,
+		},
+	}
+}
+
+// buildConfigSecret generates rate limits config
+// see https://docs.victoriametrics.com/vmgateway/#rate-limiter
+func buildConfigSecret(cr *vmv1beta1.VMGateway) (*corev1.Secret, error) {
+	limits := cr.Spec.RateLimits
+	if limits == nil {
+		limits = []vmv1beta1.VMGatewayRateLimit{}
+	}
+	data, err := yaml.Marshal(map[string][]vmv1beta1.VMGatewayRateLimit{"limits": limits})
+	if err != nil {
+		return nil, fmt.Errorf("cannot serialize rate limits config: %w", err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: buildConfigSecretMeta(cr),
+		Data: map[string][]byte{
+			rateLimitConfigName: data,
+		},
+	}, nil
+}
+
+func newDeployForVMGateway(cr *vmv1beta1.VMGateway, urls *clusterURLs, configSecret *corev1.Secret) (*appsv1.Deployment, error) {
+	podSpec, err := makeSpecForVMGateway(cr, urls, configSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	depSpec := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.PrefixedName(),
+			Namespace:       cr.Namespace,
+			Labels:          cr.AllLabels(),
+			Annotations:     cr.AnnotationsFiltered(),
+			OwnerReferences: cr.AsOwner(),
+			Finalizers:      []string{vmv1beta1.FinalizerName},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: cr.Spec.ReplicaCount,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: cr.SelectorLabels(),
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+			},
+			Template: *podSpec,
+		},
+	}
+	build.DeploymentAddCommonParams(depSpec, ptr.Deref(cr.Spec.UseStrictSecurity, false), &cr.Spec.CommonApplicationDeploymentParams)
+	if err := build.PodTemplateAddPatches(&depSpec.Spec.Template, cr.Spec.PodTemplatePatches); err != nil {
+		return nil, err
+	}
+	return depSpec, nil
+}
+
+func buildArgs(cr *vmv1beta1.VMGateway, urls *clusterURLs) []string {
+	args := []string{
+		fmt.Sprintf("-httpListenAddr=:%s", cr.Spec.Port),
+		"-clusterMode",
+	}
+	if urls.read != "" {
+		args = append(args, fmt.Sprintf("-read.url=%s", urls.read))
+	}
+	if urls.write != "" {
+		args = append(args, fmt.Sprintf("-write.url=%s", urls.write))
+	}
+	if auth := cr.Spec.Auth; auth != nil {
+		args = append(args, "-enable.auth")
+		if len(auth.PublicKeys) > 0 {
+			args = append(args, fmt.Sprintf("-auth.publicKeys=%s", strings.Join(auth.PublicKeys, ",")))
+		}
+		if len(auth.OIDCDiscoveryEndpoints) > 0 {
+			args = append(args, fmt.Sprintf("-auth.oidcDiscoveryEndpoints=%s", strings.Join(auth.OIDCDiscoveryEndpoints, ",")))
+		}
+		if len(auth.JWKSEndpoints) > 0 {
+			args = append(args, fmt.Sprintf("-auth.jwksEndpoints=%s", strings.Join(auth.JWKSEndpoints, ",")))
+		}
+	}
+	if len(cr.Spec.RateLimits) > 0 {
+		args = append(args,
+			"-enable.rateLimit",
+			fmt.Sprintf("-ratelimit.config=%s", path.Join(configDir, rateLimitConfigName)),
+			fmt.Sprintf("-datasource.url=%s%s", urls.read, defaultDatasourcePath),
+		)
+	}
+	if cr.Spec.LogLevel != "" {
+		args = append(args, fmt.Sprintf("-loggerLevel=%s", cr.Spec.LogLevel))
+	}
+	if cr.Spec.LogFormat != "" {
+		args = append(args, fmt.Sprintf("-loggerFormat=%s", cr.Spec.LogFormat))
+	}
+	if len(cr.Spec.ExtraEnvs) > 0 {
+		args = append(args, "-envflag.enable=true")
+	}
+	args = cr.Spec.License.MaybeAddToArgs(args, vmv1beta1.SecretsDir)
+	args = cr.Spec.ServerTLS.MaybeAddToArgs(args, vmv1beta1.ServerTLSDir)
+	args = build.AddExtraArgsOverrideDefaults(args, cr.Spec.ExtraArgs, "-")
+	sort.Strings(args)
+	return args
+}
+
+func makeSpecForVMGateway(cr *vmv1beta1.VMGateway, urls *clusterURLs, configSecret *corev1.Secret) (*corev1.PodTemplateSpec, error) {
+	args := buildArgs(cr, urls)
+
+	var envs []corev1.EnvVar
+	envs = append(envs, cr.Spec.ExtraEnvs...)
+
+	ports := []corev1.ContainerPort{
+		{Name: "http", Protocol: "TCP", ContainerPort: intstr.Parse(cr.Spec.Port).IntVal},
+	}
+	volumes := []corev1.Volume{
+		{
+			Name: configVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: cr.ConfigSecretName(),
+				},
+			},
+		},
+	}
+	volumes = append(volumes, cr.Spec.Volumes...)
+	vmMounts := []corev1.VolumeMount{
+		{
+			Name:      configVolumeName,
+			ReadOnly:  true,
+			MountPath: configDir,
+		},
+	}
+	vmMounts = append(vmMounts, cr.Spec.VolumeMounts...)
+
+	for _, s := range cr.Spec.Secrets {
+		volumes = append(volumes, corev1.Volume{
+			Name: k8stools.SanitizeVolumeName("secret-" + s),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: s,
+				},
+			},
+		})
+		vmMounts = append(vmMounts, corev1.VolumeMount{
+			Name:      k8stools.SanitizeVolumeName("secret-" + s),
+			ReadOnly:  true,
+			MountPath: path.Join(vmv1beta1.SecretsDir, s),
+		})
+	}
+
+	for _, c := range cr.Spec.ConfigMaps {
+		volumes = append(volumes, corev1.Volume{
+			Name: k8stools.SanitizeVolumeName("configmap-" + c),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: c,
+					},
+				},
+			},
+		})
+		vmMounts = append(vmMounts, corev1.VolumeMount{
+			Name:      k8stools.SanitizeVolumeName("configmap-" + c),
+			ReadOnly:  true,
+			MountPath: path.Join(vmv1beta1.ConfigMapsDir, c),
+		})
+	}
+	volumes, vmMounts = cr.Spec.License.MaybeAddToVolumes(volumes, vmMounts, vmv1beta1.SecretsDir)
+	volumes, vmMounts = cr.Spec.ServerTLS.MaybeAddToVolumes(volumes, vmMounts, vmv1beta1.ServerTLSDir)
+
+	vmgatewayContainer := corev1.Container{
+		Name:                     "vmgateway",
+		Image:                    fmt.Sprintf("%s:%s", cr.Spec.Image.Repository, cr.Spec.Image.Tag),
+		Ports:                    ports,
+		Args:                     args,
+		VolumeMounts:             vmMounts,
+		Resources:                cr.Spec.Resources,
+		Env:                      envs,
+		Lifecycle:                cr.Spec.Lifecycle,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		ImagePullPolicy:          cr.Spec.Image.PullPolicy,
+	}
+
+	vmgatewayContainer = build.Probe(vmgatewayContainer, cr)
+
+	operatorContainers := []corev1.Container{vmgatewayContainer}
+
+	build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.SecurityContext, operatorContainers, ptr.Deref(cr.Spec.UseStrictSecurity, false))
+
+	containers, err := k8stools.MergePatchContainers(operatorContainers, cr.Spec.Containers)
+	if err != nil {
+		return nil, err
+	}
+	ic, containers := build.AddSidecars(cr.Spec.InitContainers, containers, cr.Spec.Sidecars)
+
+	// rate limits config is read only at start, so pods must be restarted on config change
+	podAnnotations := cr.PodAnnotations()
+	podAnnotations[configHashAnnotation] = fmt.Sprintf("%x", sha256.Sum256(configSecret.Data[rateLimitConfigName]))
+
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      cr.PodLabels(),
+			Annotations: podAnnotations,
+		},
+		Spec: corev1.PodSpec{
+			Volumes:            volumes,
+			InitContainers:     ic,
+			Containers:         containers,
+			ServiceAccountName: cr.GetServiceAccountName(),
+		},
+	}, nil
+}
+
+// createOrUpdateVMGatewayService creates service for vmgateway
+func createOrUpdateVMGatewayService(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMGateway) (*corev1.Service, error) {
+	var prevService, prevAdditionalService *corev1.Service
+	var prevAdditionalServices []*corev1.Service
+	if prevCR != nil {
+		prevService = build.Service(prevCR, prevCR.Spec.Port, nil)
+		prevAdditionalService = build.AdditionalServiceFromDefault(prevService, prevCR.Spec.ServiceSpec)
+		prevAdditionalServices = build.AdditionalServicesFromDefault(prevService, prevCR.Spec.AdditionalServices)
+	}
+
+	newService := build.Service(cr, cr.Spec.Port, nil)
+	if err := cr.Spec.ServiceSpec.IsSomeAndThen(func(s *vmv1beta1.AdditionalServiceSpec) error {
+		additionalService := build.AdditionalServiceFromDefault(newService, s)
+		if additionalService.Name == newService.Name {
+			return fmt.Errorf("vmgateway additional service name: %q cannot be the same as crd.prefixedname: %q", additionalService.Name, newService.Name)
+		}
+		if err := reconcile.Service(ctx, rclient, additionalService, prevAdditionalService); err != nil {
+			return fmt.Errorf("cannot reconcile additional service for vmgateway: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := reconcile.Services(ctx, rclient, build.AdditionalServicesFromDefault(newService, cr.Spec.AdditionalServices), prevAdditionalServices); err != nil {
+		return nil, fmt.Errorf("cannot reconcile additional services for vmgateway: %w", err)
+	}
+
+	if err := reconcile.Service(ctx, rclient, newService, prevService); err != nil {
+		return nil, fmt.Errorf("cannot reconcile service for vmgateway: %w", err)
+	}
+	return newService, nil
+}
+
+func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMGateway, rclient client.Client) error {
+	if cr.ParsedLastAppliedSpec == nil {
+		return nil
+	}
+	prevSvc, currSvc := cr.ParsedLastAppliedSpec.ServiceSpec, cr.Spec.ServiceSpec
+	if err := reconcile.AdditionalServices(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevSvc, currSvc); err != nil {
+		return fmt.Errorf("cannot remove additional service: %w", err)
+	}
+	if err := reconcile.AdditionalServiceList(ctx, rclient, cr.Namespace, cr.ParsedLastAppliedSpec.AdditionalServices, cr.Spec.AdditionalServices); err != nil {
+		return fmt.Errorf("cannot remove additional services: %w", err)
+	}
+
+	objMeta := metav1.ObjectMeta{Name: cr.PrefixedName(), Namespace: cr.Namespace}
+	if cr.Spec.PodDisruptionBudget == nil && cr.ParsedLastAppliedSpec.PodDisruptionBudget != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &policyv1.PodDisruptionBudget{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot delete PDB from prev state: %w", err)
+		}
+	}
+	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(cr.ParsedLastAppliedSpec.DisableSelfServiceScrape, false) {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot remove serviceScrape: %w", err)
+		}
+	}
+	if !ptr.Deref(cr.Spec.SelfMonitoringRules, false) && ptr.Deref(cr.ParsedLastAppliedSpec.SelfMonitoringRules, false) {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMRule{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot remove self-monitoring VMRule: %w", err)
+		}
+	}
+
+	return nil
+}
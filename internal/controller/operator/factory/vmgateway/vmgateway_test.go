@@ -0,0 +1,141 @@
+package vmgateway
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+)
+
+func TestBuildArgs(t *testing.T) {
+	f := func(spec vmv1beta1.VMGatewaySpec, urls *clusterURLs, want []string) {
+		t.Helper()
+		spec.Port = "8431"
+		cr := &vmv1beta1.VMGateway{Spec: spec}
+		got := buildArgs(cr, urls)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected args\ngot:  %v\nwant: %v", got, want)
+		}
+	}
+	urls := &clusterURLs{
+		read:  "http://vmselect-main.default.svc:8481",
+		write: "http://vminsert-main.default.svc:8480",
+	}
+
+	// proxy only
+	f(vmv1beta1.VMGatewaySpec{
+		License: &vmv1beta1.License{Key: ptr.To("license-key")},
+	}, urls, []string{
+		"-clusterMode",
+		"-httpListenAddr=:8431",
+		"-license=license-key",
+		"-read.url=http://vmselect-main.default.svc:8481",
+		"-write.url=http://vminsert-main.default.svc:8480",
+	})
+
+	// jwt auth and rate limits
+	f(vmv1beta1.VMGatewaySpec{
+		Auth: &vmv1beta1.VMGatewayAuth{
+			OIDCDiscoveryEndpoints: []string{"https://idp.example.com/.well-known/openid-configuration"},
+			JWKSEndpoints:          []string{"https://idp.example.com/jwks"},
+		},
+		RateLimits: []vmv1beta1.VMGatewayRateLimit{{Type: "queries", Value: 100, Resolution: "minute"}},
+	}, &clusterURLs{read: urls.read}, []string{
+		"-auth.jwksEndpoints=https://idp.example.com/jwks",
+		"-auth.oidcDiscoveryEndpoints=https://idp.example.com/.well-known/openid-configuration",
+		"-clusterMode",
+		"-datasource.url=http://vmselect-main.default.svc:8481/select/0/prometheus",
+		"-enable.auth",
+		"-enable.rateLimit",
+		"-httpListenAddr=:8431",
+		"-ratelimit.config=/etc/vmgateway/config/ratelimit.yaml",
+		"-read.url=http://vmselect-main.default.svc:8481",
+	})
+}
+
+func TestBuildConfigSecret(t *testing.T) {
+	f := func(limits []vmv1beta1.VMGatewayRateLimit, want string) {
+		t.Helper()
+		cr := &vmv1beta1.VMGateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+			Spec:       vmv1beta1.VMGatewaySpec{RateLimits: limits},
+		}
+		s, err := buildConfigSecret(cr)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got := string(s.Data[rateLimitConfigName]); got != want {
+			t.Fatalf("unexpected config\ngot:\n%s\nwant:\n%s", got, want)
+		}
+	}
+	f(nil, "limits: []\n")
+	f([]vmv1beta1.VMGatewayRateLimit{
+		{Type: "queries", Value: 1000, Resolution: "minute"},
+		{Type: "rows_inserted", Value: 100000, Resolution: "hour", AccountID: ptr.To[int32](1), ProjectID: ptr.To[int32](5)},
+	}, `limits:
+- type: queries
+  value: 1000
+  resolution: minute
+- type: rows_inserted
+  value: 100000
+  resolution: hour
+  account_id: 1
+  project_id: 5
+`)
+}
+
+func TestCreateOrUpdateVMGateway(t *testing.T) {
+	ctx := context.Background()
+	cr := &vmv1beta1.VMGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+		Spec: vmv1beta1.VMGatewaySpec{
+			ClusterRef: corev1.LocalObjectReference{Name: "main"},
+			License:    &vmv1beta1.License{Key: ptr.To("license-key")},
+			RateLimits: []vmv1beta1.VMGatewayRateLimit{{Type: "queries", Value: 100, Resolution: "minute"}},
+			CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+				ReplicaCount: ptr.To[int32](1),
+			},
+		},
+	}
+	fclient := testutil.GetTestClientWithObjects([]runtime.Object{
+		cr,
+		&vmv1beta1.VMCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+			Spec: vmv1beta1.VMClusterSpec{
+				VMSelect: &vmv1beta1.VMSelect{},
+				VMInsert: &vmv1beta1.VMInsert{},
+			},
+		},
+		testutil.NewReadyDeployment("vmgateway-gw", "default"),
+	})
+	fclient.Scheme().Default(cr)
+	if err := CreateOrUpdateVMGateway(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var secret corev1.Secret
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.ConfigSecretName()}, &secret); err != nil {
+		t.Fatalf("cannot get config secret: %s", err)
+	}
+	var deploy appsv1.Deployment
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.PrefixedName()}, &deploy); err != nil {
+		t.Fatalf("cannot get deployment: %s", err)
+	}
+	if _, ok := deploy.Spec.Template.Annotations[configHashAnnotation]; !ok {
+		t.Fatalf("expected config hash annotation at pod template, got: %v", deploy.Spec.Template.Annotations)
+	}
+
+	// missing cluster
+	cr.Spec.ClusterRef.Name = "missing"
+	if err := CreateOrUpdateVMGateway(ctx, fclient, cr); err == nil {
+		t.Fatalf("expected error for missing cluster")
+	}
+}
@@ -4,13 +4,18 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"regexp"
 	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v2"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
@@ -20,9 +25,17 @@ import (
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
 )
 
+// ingestion ports of graphite, influx and opentsdb listeners don't serve metrics
+var serviceScrapeDefaults = build.ServiceScrapeDefaults{
+	Ports: build.ServicePortFilter{
+		Exclude: &build.ServicePortFilter{NameRegex: regexp.MustCompile(`^(graphite|influx|opentsdb)-`)},
+	},
+}
+
 const (
 	vmSingleDataDir     = "/victoria-metrics-data"
 	vmDataVolumeName    = "data"
@@ -66,6 +79,12 @@ func CreateOrUpdateVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle, rclient
 	if cr.ParsedLastAppliedSpec != nil {
 		prevCR = cr.DeepCopy()
 		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		if err := build.ApplyVMBackupLocation(ctx, rclient, prevCR.Spec.VMBackup, prevCR.Namespace, prevCR.Spec.CloudIdentity); err != nil {
+			logger.WithContext(ctx).Error(err, "cannot apply backup location for previous vmsingle state")
+		}
+	}
+	if err := build.ApplyVMBackupLocation(ctx, rclient, cr.Spec.VMBackup, cr.Namespace, cr.Spec.CloudIdentity); err != nil {
+		return err
 	}
 	if err := deletePrevStateResources(ctx, rclient, cr, prevCR); err != nil {
 		return fmt.Errorf("cannot delete objects from prev state: %w", err)
@@ -94,11 +113,55 @@ func CreateOrUpdateVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle, rclient
 	}
 
 	if !ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
-		err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(svc, cr))
+		err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithDefaults(svc, cr, serviceScrapeDefaults.WithServerTLS(cr.Spec.ServerTLS, svc)))
 		if err != nil {
 			return fmt.Errorf("cannot create serviceScrape for vmsingle: %w", err)
 		}
 	}
+	if ptr.Deref(cr.Spec.SelfMonitoringRules, false) {
+		if err := reconcile.VMRuleForCRD(ctx, rclient, build.SelfMonitoringVMRule(svc, svc.Name, "vmsingle")); err != nil {
+			return fmt.Errorf("cannot create self-monitoring VMRule for vmsingle: %w", err)
+		}
+	}
+	if cr.Spec.NetworkPolicy.IsEnabled() {
+		var prevNP *networkingv1.NetworkPolicy
+		if prevCR != nil && prevCR.Spec.NetworkPolicy.IsEnabled() {
+			prevNP = build.NetworkPolicy(prevCR, prevCR.Spec.NetworkPolicy, nil)
+		}
+		if err := reconcile.NetworkPolicy(ctx, rclient, build.NetworkPolicy(cr, cr.Spec.NetworkPolicy, nil), prevNP); err != nil {
+			return fmt.Errorf("cannot update network policy for vmsingle: %w", err)
+		}
+	}
+	if cr.Spec.VPA != nil {
+		var prevVPA *unstructured.Unstructured
+		if prevCR != nil && prevCR.Spec.VPA != nil {
+			prevVPA = buildVPA(prevCR)
+		}
+		if err := reconcile.VPA(ctx, rclient, buildVPA(cr), prevVPA); err != nil {
+			return fmt.Errorf("cannot update vertical pod autoscaler for vmsingle: %w", err)
+		}
+	}
+	if cr.Spec.Ingress != nil {
+		var prevIngress *networkingv1.Ingress
+		if prevCR != nil && prevCR.Spec.Ingress != nil {
+			prevIngress = build.Ingress(prevCR, prevCR.Spec.Ingress)
+		}
+		if err := reconcile.Ingress(ctx, rclient, build.Ingress(cr, cr.Spec.Ingress), prevIngress); err != nil {
+			return fmt.Errorf("cannot update ingress for vmsingle: %w", err)
+		}
+	}
+	if cr.Spec.Route != nil {
+		var prevRoute *unstructured.Unstructured
+		if prevCR != nil && prevCR.Spec.Route != nil {
+			prevRoute = build.Route(prevCR, prevCR.Spec.Route)
+		}
+		if err := reconcile.Route(ctx, rclient, build.Route(cr, cr.Spec.Route), prevRoute); err != nil {
+			return fmt.Errorf("cannot update route for vmsingle: %w", err)
+		}
+	}
+	if err := reconcile.ServerTLSSecret(ctx, rclient, cr.Spec.ServerTLS, metav1.ObjectMeta{Namespace: cr.Namespace, Labels: cr.AllLabels(), OwnerReferences: cr.AsOwner()}, cr.PrefixedName()); err != nil {
+		return fmt.Errorf("cannot reconcile serverTLS secret for vmsingle: %w", err)
+	}
 	var prevDeploy *appsv1.Deployment
 	if prevCR != nil {
 		prevDeploy, err = newDeployForVMSingle(ctx, prevCR)
@@ -106,12 +169,73 @@ func CreateOrUpdateVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle, rclient
 			return fmt.Errorf("cannot generate prev deploy spec: %w", err)
 		}
 	}
+	if build.IsVMRestoreFromNeeded(cr.Spec.RestoreFrom, cr.Status.Restore) &&
+		(cr.Status.Restore == nil || cr.Status.Restore.Source != cr.Spec.RestoreFrom.Source) {
+		if err := reconcile.RestoreStatus(ctx, rclient, cr, "restore", cr.Spec.RestoreFrom.Source, vmv1beta1.RestoreStatusInProgress); err != nil {
+			return err
+		}
+	}
 	newDeploy, err := newDeployForVMSingle(ctx, cr)
 	if err != nil {
 		return fmt.Errorf("cannot generate new deploy for vmsingle: %w", err)
 	}
 
-	return reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, false)
+	if err := reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, false); err != nil {
+		return err
+	}
+	return createOrUpdateBackupVerification(ctx, rclient, cr, prevCR)
+}
+
+func createOrUpdateBackupVerification(ctx context.Context, rclient client.Client, cr, prevCR *vmv1beta1.VMSingle) error {
+	var prevCJ *batchv1.CronJob
+	if prevCR != nil && build.IsVMBackupVerificationEnabled(prevCR.Spec.VMBackup, prevCR.Spec.License) {
+		prevCJ = newBackupVerificationCronJob(prevCR)
+	}
+	if !build.IsVMBackupVerificationEnabled(cr.Spec.VMBackup, cr.Spec.License) {
+		if prevCJ != nil {
+			if err := finalize.SafeDelete(ctx, rclient, prevCJ); err != nil {
+				return fmt.Errorf("cannot remove backup verification CronJob: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := reconcile.CronJob(ctx, rclient, newBackupVerificationCronJob(cr), prevCJ); err != nil {
+		return fmt.Errorf("cannot reconcile backup verification CronJob: %w", err)
+	}
+	return nil
+}
+
+func newBackupVerificationCronJob(cr *vmv1beta1.VMSingle) *batchv1.CronJob {
+	objMeta := metav1.ObjectMeta{
+		Name:            cr.BackupVerificationName(),
+		Namespace:       cr.Namespace,
+		Labels:          cr.AllLabels(),
+		Annotations:     cr.AnnotationsFiltered(),
+		OwnerReferences: cr.AsOwner(),
+	}
+	apiURL := fmt.Sprintf("http://%s.%s.svc:%s", cr.PrefixedName(), cr.Namespace, cr.Spec.VMBackup.Port)
+	return build.VMBackupVerificationCronJob(cr.Spec.VMBackup, objMeta, []string{apiURL}, cr.Spec.License, ptr.Deref(cr.Spec.UseStrictSecurity, false))
+}
+
+// UpdateBackupVerificationStatus reflects state of backup verification at the object status
+// it must be called after status tracking, otherwise status could be overwritten
+func UpdateBackupVerificationStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMSingle) error {
+	var cronJobName string
+	if build.IsVMBackupVerificationEnabled(cr.Spec.VMBackup, cr.Spec.License) {
+		cronJobName = cr.BackupVerificationName()
+	}
+	st, err := reconcile.BackupVerificationStatus(ctx, rclient, cr, "backupVerification", cronJobName, cr.Status.BackupVerification)
+	cr.Status.BackupVerification = st
+	return err
+}
+
+// UpdateRestoreStatus marks restore from spec.restoreFrom as completed
+// it must be called after successful reconcile, when vmrestore init container finished its work
+func UpdateRestoreStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMSingle) error {
+	if !build.IsVMRestoreFromNeeded(cr.Spec.RestoreFrom, cr.Status.Restore) {
+		return nil
+	}
+	return reconcile.RestoreStatus(ctx, rclient, cr, "restore", cr.Spec.RestoreFrom.Source, vmv1beta1.RestoreStatusCompleted)
 }
 
 func newDeployForVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle) (*appsv1.Deployment, error) {
@@ -142,6 +266,9 @@ func newDeployForVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle) (*appsv1.
 		},
 	}
 	build.DeploymentAddCommonParams(depSpec, ptr.Deref(cr.Spec.UseStrictSecurity, false), &cr.Spec.CommonApplicationDeploymentParams)
+	if err := build.PodTemplateAddPatches(&depSpec.Spec.Template, cr.Spec.PodTemplatePatches); err != nil {
+		return nil, err
+	}
 	return depSpec, nil
 }
 
@@ -172,6 +299,10 @@ func makeSpecForVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle) (*corev1.P
 		args = append(args, "-envflag.enable=true")
 	}
 	args = build.AppendArgsForInsertPorts(args, cr.Spec.InsertPorts)
+	args = build.AppendArgsForOTLP(args, cr.Spec.OTLP)
+	args = build.AppendArgsForDatadog(args, cr.Spec.Datadog)
+	args = build.AppendArgsForNewRelic(args, cr.Spec.NewRelic)
+	args = build.AppendArgsForQueryLogging(args, cr.Spec.QueryLogging)
 
 	var envs []corev1.EnvVar
 	envs = append(envs, cr.Spec.ExtraEnvs...)
@@ -276,6 +407,9 @@ func makeSpecForVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle) (*corev1.P
 	volumes, vmMounts = cr.Spec.License.MaybeAddToVolumes(volumes, vmMounts, vmv1beta1.SecretsDir)
 	args = cr.Spec.License.MaybeAddToArgs(args, vmv1beta1.SecretsDir)
 
+	volumes, vmMounts = cr.Spec.ServerTLS.MaybeAddToVolumes(volumes, vmMounts, vmv1beta1.ServerTLSDir)
+	args = cr.Spec.ServerTLS.MaybeAddToArgs(args, vmv1beta1.ServerTLSDir)
+
 	args = build.AddExtraArgsOverrideDefaults(args, cr.Spec.ExtraArgs, "-")
 	sort.Strings(args)
 	vmsingleContainer := corev1.Container{
@@ -286,6 +420,7 @@ func makeSpecForVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle) (*corev1.P
 		VolumeMounts:             vmMounts,
 		Resources:                cr.Spec.Resources,
 		Env:                      envs,
+		Lifecycle:                cr.Spec.Lifecycle,
 		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		ImagePullPolicy:          cr.Spec.Image.PullPolicy,
 	}
@@ -296,7 +431,7 @@ func makeSpecForVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle) (*corev1.P
 	var initContainers []corev1.Container
 
 	if cr.Spec.VMBackup != nil {
-		vmBackupManagerContainer, err := build.VMBackupManager(ctx, cr.Spec.VMBackup, cr.Spec.Port, storagePath, vmDataVolumeName, cr.Spec.ExtraArgs, false, cr.Spec.License)
+		vmBackupManagerContainer, err := build.VMBackupManager(ctx, cr.Spec.VMBackup, cr.Spec.Port, storagePath, vmDataVolumeName, &cr.Spec.CommonApplicationDeploymentParams, false, cr.Spec.License)
 		if err != nil {
 			return nil, err
 		}
@@ -316,17 +451,25 @@ func makeSpecForVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle) (*corev1.P
 		}
 	}
 
-	build.AddStrictSecuritySettingsToContainers(cr.Spec.SecurityContext, initContainers, ptr.Deref(cr.Spec.UseStrictSecurity, false))
+	if build.IsVMRestoreFromNeeded(cr.Spec.RestoreFrom, cr.Status.Restore) {
+		// restore must be performed before any other init container
+		vmRestore, restoreVolumes := build.VMRestoreFrom(cr.Spec.RestoreFrom, storagePath, vmDataVolumeName, false)
+		initContainers = append([]corev1.Container{*vmRestore}, initContainers...)
+		volumes = append(volumes, restoreVolumes...)
+	}
+
+	build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.SecurityContext, initContainers, ptr.Deref(cr.Spec.UseStrictSecurity, false))
 	ic, err := k8stools.MergePatchContainers(initContainers, cr.Spec.InitContainers)
 	if err != nil {
 		return nil, fmt.Errorf("cannot apply initContainer patch: %w", err)
 	}
 
-	build.AddStrictSecuritySettingsToContainers(cr.Spec.SecurityContext, operatorContainers, ptr.Deref(cr.Spec.UseStrictSecurity, false))
+	build.AddStrictSecuritySettingsToContainers(cr.Namespace, cr.Spec.SecurityContext, operatorContainers, ptr.Deref(cr.Spec.UseStrictSecurity, false))
 	containers, err := k8stools.MergePatchContainers(operatorContainers, cr.Spec.Containers)
 	if err != nil {
 		return nil, err
 	}
+	ic, containers = build.AddSidecars(ic, containers, cr.Spec.Sidecars)
 
 	vmSingleSpec := &corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
@@ -363,12 +506,14 @@ func createOrUpdateVMSingleService(ctx context.Context, rclient client.Client, c
 	})
 
 	var prevService, prevAdditionalService *corev1.Service
+	var prevAdditionalServices []*corev1.Service
 	if prevCR != nil {
 		prevService = build.Service(prevCR, prevCR.Spec.Port, func(svc *corev1.Service) {
 			addBackupPort(svc, prevCR.Spec.VMBackup)
 			build.AppendInsertPortsToService(prevCR.Spec.InsertPorts, svc)
 		})
 		prevAdditionalService = build.AdditionalServiceFromDefault(prevService, prevCR.Spec.ServiceSpec)
+		prevAdditionalServices = build.AdditionalServicesFromDefault(prevService, prevCR.Spec.AdditionalServices)
 	}
 	if err := cr.Spec.ServiceSpec.IsSomeAndThen(func(s *vmv1beta1.AdditionalServiceSpec) error {
 		additionalService := build.AdditionalServiceFromDefault(newService, s)
@@ -383,6 +528,19 @@ func createOrUpdateVMSingleService(ctx context.Context, rclient client.Client, c
 		return nil, err
 	}
 
+	if err := reconcile.Services(ctx, rclient, build.AdditionalServicesFromDefault(newService, cr.Spec.AdditionalServices), prevAdditionalServices); err != nil {
+		return nil, fmt.Errorf("cannot reconcile additional services for vmsingle: %w", err)
+	}
+	if cr.Spec.OTLP != nil {
+		var prevOTLPService *corev1.Service
+		if prevCR != nil {
+			prevOTLPService = build.OTLPServiceFromDefault(prevService, prevCR.OTLPServiceName(), prevCR.Spec.OTLP)
+		}
+		if err := reconcile.Service(ctx, rclient, build.OTLPServiceFromDefault(newService, cr.OTLPServiceName(), cr.Spec.OTLP), prevOTLPService); err != nil {
+			return nil, fmt.Errorf("cannot reconcile otlp service for vmsingle: %w", err)
+		}
+	}
+
 	if err := reconcile.Service(ctx, rclient, newService, prevService); err != nil {
 		return nil, fmt.Errorf("cannot reconcile service for vmsingle: %w", err)
 	}
@@ -455,13 +613,46 @@ func deletePrevStateResources(ctx context.Context, rclient client.Client, cr, pr
 	if err := reconcile.AdditionalServices(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevSvc, currSvc); err != nil {
 		return fmt.Errorf("cannot remove additional service: %w", err)
 	}
+	if err := reconcile.AdditionalServiceList(ctx, rclient, cr.Namespace, cr.ParsedLastAppliedSpec.AdditionalServices, cr.Spec.AdditionalServices); err != nil {
+		return fmt.Errorf("cannot remove additional services: %w", err)
+	}
 
 	objMeta := metav1.ObjectMeta{Name: cr.PrefixedName(), Namespace: cr.Namespace}
+	if !cr.Spec.NetworkPolicy.IsEnabled() && prevCR.Spec.NetworkPolicy.IsEnabled() {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &networkingv1.NetworkPolicy{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot delete NetworkPolicy from prev state: %w", err)
+		}
+	}
+	if cr.Spec.VPA == nil && prevCR.Spec.VPA != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, build.NewVPA(cr.PrefixedName(), cr.Namespace)); err != nil {
+			return fmt.Errorf("cannot delete VPA from prev state: %w", err)
+		}
+	}
+	if cr.Spec.OTLP == nil && prevCR.Spec.OTLP != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: cr.OTLPServiceName(), Namespace: cr.Namespace}}); err != nil {
+			return fmt.Errorf("cannot delete otlp service from prev state: %w", err)
+		}
+	}
+	if cr.Spec.Ingress == nil && prevCR.Spec.Ingress != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &networkingv1.Ingress{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot delete ingress from prev state: %w", err)
+		}
+	}
+	if cr.Spec.Route == nil && prevCR.Spec.Route != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, build.NewRoute(cr.PrefixedName(), cr.Namespace)); err != nil {
+			return fmt.Errorf("cannot delete route from prev state: %w", err)
+		}
+	}
 	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(cr.ParsedLastAppliedSpec.DisableSelfServiceScrape, false) {
 		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta}); err != nil {
 			return fmt.Errorf("cannot remove serviceScrape: %w", err)
 		}
 	}
+	if !ptr.Deref(cr.Spec.SelfMonitoringRules, false) && ptr.Deref(cr.ParsedLastAppliedSpec.SelfMonitoringRules, false) {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMRule{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot remove self-monitoring VMRule: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -520,3 +711,13 @@ func addVolumeMountsTo(volumes []corev1.Volume, vmMounts []corev1.VolumeMount, c
 
 	return volumes, vmMounts
 }
+
+// buildVPA creates VerticalPodAutoscaler for vmsingle Deployment
+func buildVPA(cr *vmv1beta1.VMSingle) *unstructured.Unstructured {
+	targetRef := autoscalingv2.CrossVersionObjectReference{
+		Name:       cr.PrefixedName(),
+		Kind:       "Deployment",
+		APIVersion: "apps/v1",
+	}
+	return build.VPA(cr, targetRef, "vmsingle", cr.Spec.VPA)
+}
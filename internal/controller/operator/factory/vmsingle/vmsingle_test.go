@@ -4,14 +4,18 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
-	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 )
 
@@ -47,7 +51,7 @@ func TestCreateOrUpdateVMSingle(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "vmsingle-0", Labels: map[string]string{"app.kubernetes.io/component": "monitoring", "app.kubernetes.io/name": "vmsingle", "app.kubernetes.io/instance": "vmsingle-base", "managed-by": "vm-operator"}},
 					Status:     corev1.PodStatus{Phase: corev1.PodRunning, Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: "True"}}},
 				},
-				k8stools.NewReadyDeployment("vmsingle-vmsingle-base", "default"),
+				testutil.NewReadyDeployment("vmsingle-vmsingle-base", "default"),
 			},
 			want: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "vmsingle-vmsingle-base", Namespace: "default"}},
 		},
@@ -77,14 +81,14 @@ func TestCreateOrUpdateVMSingle(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "vmsingle-0", Labels: map[string]string{"app.kubernetes.io/component": "monitoring", "app.kubernetes.io/name": "vmsingle", "app.kubernetes.io/instance": "vmsingle-base", "managed-by": "vm-operator"}},
 					Status:     corev1.PodStatus{Phase: corev1.PodRunning, Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: "True"}}},
 				},
-				k8stools.NewReadyDeployment("vmsingle-vmsingle-base", "default"),
+				testutil.NewReadyDeployment("vmsingle-vmsingle-base", "default"),
 			},
 			want: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "vmsingle-vmsingle-base", Namespace: "default"}},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			err := CreateOrUpdateVMSingle(context.TODO(), tt.args.cr, fclient)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateOrUpdateVMSingle() error = %v, wantErr %v", err, tt.wantErr)
@@ -194,7 +198,7 @@ func TestCreateOrUpdateVMSingleService(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
+			fclient := testutil.GetTestClientWithObjects(tt.predefinedObjects)
 			got, err := createOrUpdateVMSingleService(context.TODO(), fclient, tt.args.cr, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateOrUpdateVMSingleService() error = %v, wantErr %v", err, tt.wantErr)
@@ -209,3 +213,172 @@ func TestCreateOrUpdateVMSingleService(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateOrUpdateVMSingleWithRestore(t *testing.T) {
+	ctx := context.TODO()
+	cr := &vmv1beta1.VMSingle{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vmsingle-restore",
+			Namespace: "default",
+		},
+		Spec: vmv1beta1.VMSingleSpec{
+			RestoreFrom: &vmv1beta1.VMRestoreFrom{
+				Source: "s3://bucket/path",
+			},
+		},
+	}
+	fclient := testutil.GetTestClientWithObjects([]runtime.Object{
+		cr.DeepCopy(),
+		testutil.NewReadyDeployment("vmsingle-vmsingle-restore", "default"),
+	})
+	getRestoreState := func() (*vmv1beta1.VMRestoreFromStatus, []corev1.Container) {
+		t.Helper()
+		var got vmv1beta1.VMSingle
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, &got); err != nil {
+			t.Fatalf("cannot get vmsingle: %s", err)
+		}
+		var dep appsv1.Deployment
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.PrefixedName()}, &dep); err != nil {
+			t.Fatalf("cannot get deployment: %s", err)
+		}
+		return got.Status.Restore, dep.Spec.Template.Spec.InitContainers
+	}
+
+	if err := CreateOrUpdateVMSingle(ctx, cr, fclient); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	status, initContainers := getRestoreState()
+	if status == nil || status.Status != vmv1beta1.RestoreStatusInProgress {
+		t.Fatalf("expected restore in progress status, got: %v", status)
+	}
+	if len(initContainers) != 1 || initContainers[0].Name != "vmrestore" {
+		t.Fatalf("expected vmrestore init container, got: %v", initContainers)
+	}
+
+	cr.Status.Restore = status
+	if err := UpdateRestoreStatus(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	status, _ = getRestoreState()
+	if status == nil || status.Status != vmv1beta1.RestoreStatusCompleted {
+		t.Fatalf("expected restore completed status, got: %v", status)
+	}
+
+	cr.Status.Restore = status
+	if err := CreateOrUpdateVMSingle(ctx, cr, fclient); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, initContainers = getRestoreState()
+	if len(initContainers) != 0 {
+		t.Fatalf("expected no init containers after restore completion, got: %v", initContainers)
+	}
+}
+
+func TestCreateOrUpdateVMSingleWithBackupVerification(t *testing.T) {
+	ctx := context.TODO()
+	cr := &vmv1beta1.VMSingle{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vmsingle-verify",
+			Namespace: "default",
+		},
+		Spec: vmv1beta1.VMSingleSpec{
+			VMBackup: &vmv1beta1.VMBackup{
+				AcceptEULA:  true,
+				Destination: "s3://bucket/path",
+				Port:        "8300",
+				Verification: &vmv1beta1.VMBackupVerification{
+					Schedule: "0 */6 * * *",
+				},
+			},
+		},
+	}
+	fclient := testutil.GetTestClientWithObjects([]runtime.Object{
+		cr.DeepCopy(),
+		testutil.NewReadyDeployment("vmsingle-vmsingle-verify", "default"),
+	})
+	if err := CreateOrUpdateVMSingle(ctx, cr, fclient); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var cj batchv1.CronJob
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.BackupVerificationName()}, &cj); err != nil {
+		t.Fatalf("cannot get backup verification cronjob: %s", err)
+	}
+	containers := cj.Spec.JobTemplate.Spec.Template.Spec.Containers
+	if len(containers) != 1 {
+		t.Fatalf("unexpected containers count: %d", len(containers))
+	}
+	wantArgs := []string{"backup", "list", "-apiURL=http://vmsingle-vmsingle-verify.default.svc:8300", "-eula"}
+	if !reflect.DeepEqual(containers[0].Args, wantArgs) {
+		t.Fatalf("unexpected args\ngot:  %v\nwant: %v", containers[0].Args, wantArgs)
+	}
+
+	// status reflects cronjob state
+	lastSuccess := metav1.NewTime(time.Now().Truncate(time.Second))
+	cj.Status.LastSuccessfulTime = &lastSuccess
+	cj.Status.LastScheduleTime = &lastSuccess
+	if err := fclient.Status().Update(ctx, &cj); err != nil {
+		t.Fatalf("cannot update cronjob status: %s", err)
+	}
+	if err := UpdateBackupVerificationStatus(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var got vmv1beta1.VMSingle
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, &got); err != nil {
+		t.Fatalf("cannot get vmsingle: %s", err)
+	}
+	if got.Status.BackupVerification == nil || !got.Status.BackupVerification.LastSuccessfulTime.Equal(&lastSuccess) {
+		t.Fatalf("unexpected backup verification status: %v", got.Status.BackupVerification)
+	}
+
+	// verification removal deletes cronjob
+	prevSpec := cr.Spec.DeepCopy()
+	cr.ParsedLastAppliedSpec = prevSpec
+	cr.Spec.VMBackup.Verification = nil
+	if err := CreateOrUpdateVMSingle(ctx, cr, fclient); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.BackupVerificationName()}, &cj); !errors.IsNotFound(err) {
+		t.Fatalf("expected cronjob to be removed, got err: %v", err)
+	}
+}
+
+func TestCreateOrUpdateVMSingleSelfMonitoringRules(t *testing.T) {
+	ctx := context.TODO()
+	cr := &vmv1beta1.VMSingle{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vmsingle-rules",
+			Namespace: "default",
+		},
+		Spec: vmv1beta1.VMSingleSpec{
+			CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{
+				SelfMonitoringRules: ptr.To(true),
+			},
+		},
+	}
+	fclient := testutil.GetTestClientWithObjects([]runtime.Object{
+		cr.DeepCopy(),
+		testutil.NewReadyDeployment("vmsingle-vmsingle-rules", "default"),
+	})
+	if err := CreateOrUpdateVMSingle(ctx, cr, fclient); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var vmr vmv1beta1.VMRule
+	nsn := types.NamespacedName{Namespace: cr.Namespace, Name: cr.PrefixedName()}
+	if err := fclient.Get(ctx, nsn, &vmr); err != nil {
+		t.Fatalf("cannot get self-monitoring VMRule: %s", err)
+	}
+	if len(vmr.Spec.Groups) != 1 || vmr.Spec.Groups[0].Name != "vmsingle-health" {
+		t.Fatalf("unexpected VMRule groups: %v", vmr.Spec.Groups)
+	}
+
+	// disabling rules deletes VMRule
+	prevSpec := cr.Spec.DeepCopy()
+	cr.ParsedLastAppliedSpec = prevSpec
+	cr.Spec.SelfMonitoringRules = ptr.To(false)
+	if err := CreateOrUpdateVMSingle(ctx, cr, fclient); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := fclient.Get(ctx, nsn, &vmr); !errors.IsNotFound(err) {
+		t.Fatalf("expected VMRule to be deleted, got error: %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+package vmsnapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+var httpClient = &http.Client{Timeout: time.Minute}
+
+// storageNode defines storage node with snapshot api
+type storageNode struct {
+	// name is a human readable node address, stored at status
+	name      string
+	baseURL   string
+	extraArgs map[string]string
+}
+
+// CreateOrUpdate performs snapshot creation according to the schedule and removes outdated snapshots
+// returns duration until the next scheduled snapshot, zero if snapshot is not scheduled
+func CreateOrUpdate(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMSnapshot) (time.Duration, error) {
+	now := time.Now()
+	var next time.Time
+	needSnapshot := len(cr.Status.Snapshots) == 0 && cr.Status.LastSnapshotTime == nil
+	if cr.Spec.Schedule != "" {
+		schedule, err := cron.ParseStandard(cr.Spec.Schedule)
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse schedule=%q: %w", cr.Spec.Schedule, err)
+		}
+		lastRun := cr.CreationTimestamp.Time
+		if cr.Status.LastSnapshotTime != nil {
+			lastRun = cr.Status.LastSnapshotTime.Time
+		}
+		next = schedule.Next(lastRun)
+		needSnapshot = !next.After(now)
+		if needSnapshot {
+			next = schedule.Next(now)
+		}
+	}
+	if needSnapshot {
+		nodes, err := getStorageNodes(ctx, rclient, cr)
+		if err != nil {
+			return 0, err
+		}
+		record, err := createSnapshot(ctx, cr, nodes)
+		if err != nil {
+			return 0, err
+		}
+		cr.Status.Snapshots = append(cr.Status.Snapshots, *record)
+		cr.Status.LastSnapshotTime = &record.CreatedAt
+		if err := rclient.Status().Update(ctx, cr); err != nil {
+			return 0, fmt.Errorf("cannot update snapshots status: %w", err)
+		}
+		logger.WithContext(ctx).Info(fmt.Sprintf("created snapshot at %d storage nodes", len(record.Nodes)))
+	}
+	if err := removeOutdatedSnapshots(ctx, rclient, cr); err != nil {
+		return 0, err
+	}
+	if next.IsZero() {
+		return 0, nil
+	}
+	return next.Sub(now), nil
+}
+
+// DeleteAll deletes all snapshots tracked at the status of given VMSnapshot
+func DeleteAll(ctx context.Context, cr *vmv1beta1.VMSnapshot, rclient client.Client) error {
+	nodes, err := getStorageNodes(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	for _, record := range cr.Status.Snapshots {
+		if err := deleteSnapshot(ctx, cr, nodes, &record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeOutdatedSnapshots(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMSnapshot) error {
+	if cr.Spec.KeepLastSnapshots == nil || *cr.Spec.KeepLastSnapshots <= 0 {
+		return nil
+	}
+	toRemove := len(cr.Status.Snapshots) - int(*cr.Spec.KeepLastSnapshots)
+	if toRemove <= 0 {
+		return nil
+	}
+	nodes, err := getStorageNodes(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < toRemove; i++ {
+		if err := deleteSnapshot(ctx, cr, nodes, &cr.Status.Snapshots[i]); err != nil {
+			return err
+		}
+	}
+	cr.Status.Snapshots = cr.Status.Snapshots[toRemove:]
+	if err := rclient.Status().Update(ctx, cr); err != nil {
+		return fmt.Errorf("cannot update snapshots status: %w", err)
+	}
+	logger.WithContext(ctx).Info(fmt.Sprintf("removed %d outdated snapshots", toRemove))
+	return nil
+}
+
+func getStorageNodes(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMSnapshot) ([]storageNode, error) {
+	nsn := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Spec.SourceRef.Name}
+	switch cr.Spec.SourceRef.Kind {
+	case "VMSingle":
+		var vmSingle vmv1beta1.VMSingle
+		if err := rclient.Get(ctx, nsn, &vmSingle); err != nil {
+			return nil, fmt.Errorf("cannot get VMSingle=%q: %w", nsn, err)
+		}
+		return []storageNode{{name: vmSingle.PrefixedName(), baseURL: vmSingle.AsURL(), extraArgs: vmSingle.Spec.ExtraArgs}}, nil
+	case "VMCluster":
+		var vmCluster vmv1beta1.VMCluster
+		if err := rclient.Get(ctx, nsn, &vmCluster); err != nil {
+			return nil, fmt.Errorf("cannot get VMCluster=%q: %w", nsn, err)
+		}
+		if vmCluster.Spec.VMStorage == nil {
+			return nil, fmt.Errorf("VMCluster=%q has no vmstorage component", nsn)
+		}
+		vmStorage := vmCluster.Spec.VMStorage
+		port := vmStorage.Port
+		if port == "" {
+			port = "8482"
+		}
+		proto := strings.ToLower(vmStorage.ProbeScheme())
+		var replicas int32 = 1
+		if vmStorage.ReplicaCount != nil {
+			replicas = *vmStorage.ReplicaCount
+		}
+		nodes := make([]storageNode, 0, replicas)
+		for i := int32(0); i < replicas; i++ {
+			addr := strings.TrimSuffix(build.PodDNSAddress(vmCluster.GetVMStorageName(), i, vmCluster.Namespace, port, vmCluster.Spec.ClusterDomainName), ",")
+			nodes = append(nodes, storageNode{name: addr, baseURL: fmt.Sprintf("%s://%s", proto, addr), extraArgs: vmStorage.ExtraArgs})
+		}
+		return nodes, nil
+	default:
+		return nil, fmt.Errorf("unsupported sourceRef.kind=%q, supported values are VMSingle and VMCluster", cr.Spec.SourceRef.Kind)
+	}
+}
+
+// createSnapshot creates snapshot at each storage node
+// in case of error, it tries to delete already created snapshots
+func createSnapshot(ctx context.Context, cr *vmv1beta1.VMSnapshot, nodes []storageNode) (*vmv1beta1.VMSnapshotRecord, error) {
+	record := &vmv1beta1.VMSnapshotRecord{
+		CreatedAt: metav1.Now(),
+	}
+	for _, node := range nodes {
+		name, err := callCreate(ctx, cr.SnapshotCreateURL(node.baseURL, node.extraArgs))
+		if err != nil {
+			if len(record.Nodes) > 0 {
+				if err := deleteSnapshot(ctx, cr, nodes, record); err != nil {
+					logger.WithContext(ctx).Error(err, "cannot cleanup partially created snapshot")
+				}
+			}
+			return nil, fmt.Errorf("cannot create snapshot at node=%q: %w", node.name, err)
+		}
+		record.Nodes = append(record.Nodes, vmv1beta1.VMSnapshotNode{Node: node.name, Name: name})
+	}
+	return record, nil
+}
+
+func deleteSnapshot(ctx context.Context, cr *vmv1beta1.VMSnapshot, nodes []storageNode, record *vmv1beta1.VMSnapshotRecord) error {
+	nodeByName := make(map[string]storageNode, len(nodes))
+	for _, node := range nodes {
+		nodeByName[node.name] = node
+	}
+	for _, sn := range record.Nodes {
+		node, ok := nodeByName[sn.Node]
+		if !ok {
+			// storage node was removed with its data
+			continue
+		}
+		if err := callDelete(ctx, cr.SnapshotDeleteURL(node.baseURL, sn.Name, node.extraArgs)); err != nil {
+			return fmt.Errorf("cannot delete snapshot=%q at node=%q: %w", sn.Name, sn.Node, err)
+		}
+	}
+	return nil
+}
+
+type snapshotResponse struct {
+	Status   string `json:"status"`
+	Snapshot string `json:"snapshot"`
+	Msg      string `json:"msg"`
+}
+
+func callSnapshotAPI(ctx context.Context, url string) (*snapshotResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build request: %w", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot execute request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response code=%d, body=%q", resp.StatusCode, string(body))
+	}
+	var sr snapshotResponse
+	if err := json.Unmarshal(body, &sr); err != nil {
+		return nil, fmt.Errorf("cannot parse response body=%q: %w", string(body), err)
+	}
+	if sr.Status != "ok" {
+		return nil, fmt.Errorf("unexpected response status=%q, msg=%q", sr.Status, sr.Msg)
+	}
+	return &sr, nil
+}
+
+func callCreate(ctx context.Context, url string) (string, error) {
+	sr, err := callSnapshotAPI(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	if sr.Snapshot == "" {
+		return "", fmt.Errorf("storage returned empty snapshot name")
+	}
+	return sr.Snapshot, nil
+}
+
+func callDelete(ctx context.Context, url string) error {
+	_, err := callSnapshotAPI(ctx, url)
+	return err
+}
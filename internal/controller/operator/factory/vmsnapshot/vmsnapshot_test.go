@@ -0,0 +1,239 @@
+package vmsnapshot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+)
+
+func TestGetStorageNodes(t *testing.T) {
+	f := func(cr *vmv1beta1.VMSnapshot, predefinedObjects []runtime.Object, want []string, wantErr bool) {
+		t.Helper()
+		fclient := testutil.GetTestClientWithObjects(predefinedObjects)
+		got, err := getStorageNodes(context.Background(), fclient, cr)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		var gotURLs []string
+		for _, n := range got {
+			gotURLs = append(gotURLs, n.baseURL)
+		}
+		if !reflect.DeepEqual(gotURLs, want) {
+			t.Fatalf("unexpected nodes, got: %v, want: %v", gotURLs, want)
+		}
+	}
+	// vmsingle
+	f(&vmv1beta1.VMSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "snap", Namespace: "default"},
+		Spec: vmv1beta1.VMSnapshotSpec{
+			SourceRef: vmv1beta1.VMSnapshotSourceRef{Kind: "VMSingle", Name: "single"},
+		},
+	}, []runtime.Object{
+		&vmv1beta1.VMSingle{ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "default"}},
+	}, []string{"http://vmsingle-single.default.svc:8429"}, false)
+
+	// vmcluster with tls and cluster domain
+	f(&vmv1beta1.VMSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "snap", Namespace: "default"},
+		Spec: vmv1beta1.VMSnapshotSpec{
+			SourceRef: vmv1beta1.VMSnapshotSourceRef{Kind: "VMCluster", Name: "cluster"},
+		},
+	}, []runtime.Object{
+		&vmv1beta1.VMCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+			Spec: vmv1beta1.VMClusterSpec{
+				ClusterDomainName: "cluster.local",
+				VMStorage: &vmv1beta1.VMStorage{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+						ReplicaCount: ptr.To[int32](2),
+						ExtraArgs:    map[string]string{"tls": "true"},
+					},
+				},
+			},
+		},
+	}, []string{
+		"https://vmstorage-cluster-0.vmstorage-cluster.default.svc.cluster.local:8482",
+		"https://vmstorage-cluster-1.vmstorage-cluster.default.svc.cluster.local:8482",
+	}, false)
+
+	// vmcluster without vmstorage
+	f(&vmv1beta1.VMSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "snap", Namespace: "default"},
+		Spec: vmv1beta1.VMSnapshotSpec{
+			SourceRef: vmv1beta1.VMSnapshotSourceRef{Kind: "VMCluster", Name: "cluster"},
+		},
+	}, []runtime.Object{
+		&vmv1beta1.VMCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}},
+	}, nil, true)
+
+	// missing source
+	f(&vmv1beta1.VMSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "snap", Namespace: "default"},
+		Spec: vmv1beta1.VMSnapshotSpec{
+			SourceRef: vmv1beta1.VMSnapshotSourceRef{Kind: "VMSingle", Name: "missing"},
+		},
+	}, nil, nil, true)
+}
+
+type fakeStorage struct {
+	mu      sync.Mutex
+	created int
+	deleted []string
+	fail    bool
+}
+
+func (fs *fakeStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if r.URL.Query().Get("authKey") != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch r.URL.Path {
+	case "/snapshot/create":
+		fs.created++
+		fmt.Fprintf(w, `{"status":"ok","snapshot":"snapshot-%d"}`, fs.created)
+	case "/snapshot/delete":
+		fs.deleted = append(fs.deleted, r.URL.Query().Get("snapshot"))
+		fmt.Fprintf(w, `{"status":"ok"}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestCreateAndDeleteSnapshot(t *testing.T) {
+	ctx := context.Background()
+	cr := &vmv1beta1.VMSnapshot{}
+	extraArgs := map[string]string{"snapshotAuthKey": "secret"}
+
+	first := &fakeStorage{}
+	second := &fakeStorage{}
+	firstSrv := httptest.NewServer(first)
+	defer firstSrv.Close()
+	secondSrv := httptest.NewServer(second)
+	defer secondSrv.Close()
+	nodes := []storageNode{
+		{name: "first", baseURL: firstSrv.URL, extraArgs: extraArgs},
+		{name: "second", baseURL: secondSrv.URL, extraArgs: extraArgs},
+	}
+
+	record, err := createSnapshot(ctx, cr, nodes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantNodes := []vmv1beta1.VMSnapshotNode{{Node: "first", Name: "snapshot-1"}, {Node: "second", Name: "snapshot-1"}}
+	if !reflect.DeepEqual(record.Nodes, wantNodes) {
+		t.Fatalf("unexpected snapshot nodes, got: %v, want: %v", record.Nodes, wantNodes)
+	}
+	if err := deleteSnapshot(ctx, cr, nodes, record); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(first.deleted, []string{"snapshot-1"}) || !reflect.DeepEqual(second.deleted, []string{"snapshot-1"}) {
+		t.Fatalf("unexpected deleted snapshots: %v, %v", first.deleted, second.deleted)
+	}
+
+	// partial failure must cleanup created snapshots
+	second.fail = true
+	if _, err := createSnapshot(ctx, cr, nodes); err == nil {
+		t.Fatalf("expected error for failed storage node")
+	}
+	if !reflect.DeepEqual(first.deleted, []string{"snapshot-1", "snapshot-2"}) {
+		t.Fatalf("expected cleanup of partially created snapshot, got: %v", first.deleted)
+	}
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	f := func(cr *vmv1beta1.VMSnapshot, wantSnapshots int, wantNextRun bool) {
+		t.Helper()
+		ctx := context.Background()
+		storage := &fakeStorage{}
+		srv := httptest.NewServer(storage)
+		defer srv.Close()
+		vmSingle := &vmv1beta1.VMSingle{
+			ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "default"},
+		}
+		fclient := testutil.GetTestClientWithObjects([]runtime.Object{vmSingle, cr})
+		// redirect requests to the test server
+		prevClient := httpClient
+		t.Cleanup(func() { httpClient = prevClient })
+		httpClient = srv.Client()
+		httpClient.Transport = rewriteTransport{target: srv.URL}
+
+		nextRun, err := CreateOrUpdate(ctx, fclient, cr)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(cr.Status.Snapshots) != wantSnapshots {
+			t.Fatalf("unexpected number of snapshots, got: %d, want: %d", len(cr.Status.Snapshots), wantSnapshots)
+		}
+		if (nextRun > 0) != wantNextRun {
+			t.Fatalf("unexpected next run: %s", nextRun)
+		}
+	}
+	newSnapshot := func(schedule string, keep *int32, lastSnapshot *metav1.Time, existing int) *vmv1beta1.VMSnapshot {
+		cr := &vmv1beta1.VMSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "snap", Namespace: "default", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+			Spec: vmv1beta1.VMSnapshotSpec{
+				SourceRef:         vmv1beta1.VMSnapshotSourceRef{Kind: "VMSingle", Name: "single"},
+				Schedule:          schedule,
+				KeepLastSnapshots: keep,
+			},
+			Status: vmv1beta1.VMSnapshotStatus{LastSnapshotTime: lastSnapshot},
+		}
+		for i := 0; i < existing; i++ {
+			cr.Status.Snapshots = append(cr.Status.Snapshots, vmv1beta1.VMSnapshotRecord{
+				Nodes: []vmv1beta1.VMSnapshotNode{{Node: "vmsingle-single", Name: fmt.Sprintf("old-%d", i)}},
+			})
+		}
+		return cr
+	}
+
+	// on-demand snapshot
+	f(newSnapshot("", nil, nil, 0), 1, false)
+	// on-demand snapshot already taken
+	f(newSnapshot("", nil, ptr.To(metav1.Now()), 1), 1, false)
+	// scheduled snapshot is due
+	f(newSnapshot("*/5 * * * *", nil, nil, 0), 1, true)
+	// scheduled snapshot is not due yet
+	f(newSnapshot("0 0 1 1 *", nil, ptr.To(metav1.Now()), 0), 0, true)
+	// retention removes oldest snapshots
+	f(newSnapshot("*/5 * * * *", ptr.To[int32](2), ptr.To(metav1.NewTime(time.Now().Add(-time.Hour))), 3), 2, true)
+}
+
+// rewriteTransport sends all requests to the target server
+type rewriteTransport struct {
+	target string
+}
+
+func (rt rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	req := r.Clone(r.Context())
+	u := *r.URL
+	tu, err := http.NewRequest(http.MethodGet, rt.target, nil)
+	if err != nil {
+		return nil, err
+	}
+	u.Scheme = tu.URL.Scheme
+	u.Host = tu.URL.Host
+	q := u.Query()
+	q.Set("authKey", "secret")
+	u.RawQuery = q.Encode()
+	req.URL = &u
+	req.Host = ""
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,373 @@
+package vmstack
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+const defaultRetentionPeriod = "1"
+
+// storageURLs contains addresses of the stack storage
+type storageURLs struct {
+	// read is url for prometheus querying API
+	read string
+	// write is url for prometheus remote write API
+	write string
+	// vmalertWrite is url for vmalert remote write, vmalert adds /api/v1/write path by itself
+	vmalertWrite string
+}
+
+// CreateOrUpdate creates or updates all components of the stack
+// all components are named after the stack and owned by it
+func CreateOrUpdate(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMStack) error {
+	if err := deletePrevStateResources(ctx, rclient, cr); err != nil {
+		return err
+	}
+	var urls *storageURLs
+	switch cr.GetStorageMode() {
+	case vmv1beta1.StackStorageModeCluster:
+		vmCluster := buildVMCluster(cr)
+		if err := reconcileObject(ctx, rclient, vmCluster, func(prev, next *vmv1beta1.VMCluster) bool {
+			return equality.Semantic.DeepEqual(next.Spec, prev.Spec)
+		}, func(prev, next *vmv1beta1.VMCluster) { prev.Spec = next.Spec }); err != nil {
+			return fmt.Errorf("cannot reconcile VMCluster: %w", err)
+		}
+		urls = clusterURLs(vmCluster)
+	default:
+		vmSingle := buildVMSingle(cr)
+		if err := reconcileObject(ctx, rclient, vmSingle, func(prev, next *vmv1beta1.VMSingle) bool {
+			return equality.Semantic.DeepEqual(next.Spec, prev.Spec)
+		}, func(prev, next *vmv1beta1.VMSingle) { prev.Spec = next.Spec }); err != nil {
+			return fmt.Errorf("cannot reconcile VMSingle: %w", err)
+		}
+		urls = singleURLs(vmSingle)
+	}
+
+	vmAgent := buildVMAgent(cr, urls)
+	if err := reconcileObject(ctx, rclient, vmAgent, func(prev, next *vmv1beta1.VMAgent) bool {
+		return equality.Semantic.DeepEqual(next.Spec, prev.Spec)
+	}, func(prev, next *vmv1beta1.VMAgent) { prev.Spec = next.Spec }); err != nil {
+		return fmt.Errorf("cannot reconcile VMAgent: %w", err)
+	}
+
+	vmAlertmanager := buildVMAlertmanager(cr)
+	if err := reconcileObject(ctx, rclient, vmAlertmanager, func(prev, next *vmv1beta1.VMAlertmanager) bool {
+		return equality.Semantic.DeepEqual(next.Spec, prev.Spec)
+	}, func(prev, next *vmv1beta1.VMAlertmanager) { prev.Spec = next.Spec }); err != nil {
+		return fmt.Errorf("cannot reconcile VMAlertmanager: %w", err)
+	}
+
+	vmAlert := buildVMAlert(cr, urls, vmAlertmanager)
+	if err := reconcileObject(ctx, rclient, vmAlert, func(prev, next *vmv1beta1.VMAlert) bool {
+		return equality.Semantic.DeepEqual(next.Spec, prev.Spec)
+	}, func(prev, next *vmv1beta1.VMAlert) { prev.Spec = next.Spec }); err != nil {
+		return fmt.Errorf("cannot reconcile VMAlert: %w", err)
+	}
+
+	if !cr.Spec.DisableDefaultRules {
+		if err := reconcileObject(ctx, rclient, buildVMRule(cr), func(prev, next *vmv1beta1.VMRule) bool {
+			return equality.Semantic.DeepEqual(next.Spec, prev.Spec)
+		}, func(prev, next *vmv1beta1.VMRule) { prev.Spec = next.Spec }); err != nil {
+			return fmt.Errorf("cannot reconcile VMRule: %w", err)
+		}
+	}
+	return nil
+}
+
+// deletePrevStateResources removes components, which are not needed anymore
+// after storage mode change or default rules disabling
+func deletePrevStateResources(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMStack) error {
+	var toDelete []client.Object
+	switch cr.GetStorageMode() {
+	case vmv1beta1.StackStorageModeCluster:
+		toDelete = append(toDelete, &vmv1beta1.VMSingle{})
+	default:
+		toDelete = append(toDelete, &vmv1beta1.VMCluster{})
+	}
+	if cr.Spec.DisableDefaultRules {
+		toDelete = append(toDelete, &vmv1beta1.VMRule{})
+	}
+	for _, obj := range toDelete {
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, obj); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("cannot get stack component: %w", err)
+		}
+		// object with the same name could be created by user
+		if !metav1.IsControlledBy(obj, cr) {
+			continue
+		}
+		logger.WithContext(ctx).Info(fmt.Sprintf("deleting unused stack component %T %s", obj, obj.GetName()))
+		if err := rclient.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("cannot delete unused stack component: %w", err)
+		}
+	}
+	return nil
+}
+
+// reconcileObject creates or updates given stack component
+// isEqual must report if existing object already matches new one and update must copy new state into existing object
+func reconcileObject[T any, PT interface {
+	*T
+	client.Object
+}](ctx context.Context, rclient client.Client, newObj PT, isEqual func(prev, next PT) bool, update func(prev, next PT)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existObj := PT(new(T))
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: newObj.GetNamespace(), Name: newObj.GetName()}, existObj); err != nil {
+			if errors.IsNotFound(err) {
+				logger.WithContext(ctx).Info(fmt.Sprintf("creating stack component %T %s", newObj, newObj.GetName()))
+				if err := rclient.Create(ctx, newObj); err != nil {
+					return err
+				}
+				events.Normal(events.ControllerOf(newObj), events.ReasonCreated, fmt.Sprintf("created stack component %s %s", reflect.TypeFor[T]().Name(), newObj.GetName()))
+				return nil
+			}
+			return err
+		}
+		if isEqual(existObj, newObj) &&
+			equality.Semantic.DeepEqual(newObj.GetLabels(), existObj.GetLabels()) &&
+			equality.Semantic.DeepEqual(newObj.GetOwnerReferences(), existObj.GetOwnerReferences()) {
+			return nil
+		}
+		update(existObj, newObj)
+		// keep annotations added by the component controller, e.g. last applied spec
+		existObj.SetAnnotations(labels.Merge(existObj.GetAnnotations(), newObj.GetAnnotations()))
+		existObj.SetLabels(newObj.GetLabels())
+		existObj.SetOwnerReferences(newObj.GetOwnerReferences())
+		logger.WithContext(ctx).Info(fmt.Sprintf("updating stack component %T %s", newObj, newObj.GetName()))
+		return rclient.Update(ctx, existObj)
+	})
+}
+
+func buildObjectMeta(cr *vmv1beta1.VMStack) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            cr.Name,
+		Namespace:       cr.Namespace,
+		Labels:          cr.SelectorLabels(),
+		OwnerReferences: cr.AsOwner(),
+	}
+}
+
+func buildVMSingle(cr *vmv1beta1.VMStack) *vmv1beta1.VMSingle {
+	var spec vmv1beta1.VMSingleSpec
+	if cr.Spec.VMSingle != nil {
+		spec = *cr.Spec.VMSingle.DeepCopy()
+	}
+	if spec.RetentionPeriod == "" {
+		spec.RetentionPeriod = retentionPeriod(cr)
+	}
+	return &vmv1beta1.VMSingle{
+		ObjectMeta: buildObjectMeta(cr),
+		Spec:       spec,
+	}
+}
+
+func buildVMCluster(cr *vmv1beta1.VMStack) *vmv1beta1.VMCluster {
+	var spec vmv1beta1.VMClusterSpec
+	if cr.Spec.VMCluster != nil {
+		spec = *cr.Spec.VMCluster.DeepCopy()
+	}
+	if spec.RetentionPeriod == "" {
+		spec.RetentionPeriod = retentionPeriod(cr)
+	}
+	if spec.VMStorage == nil {
+		spec.VMStorage = &vmv1beta1.VMStorage{}
+		spec.VMStorage.ReplicaCount = ptr.To[int32](2)
+	}
+	if spec.VMSelect == nil {
+		spec.VMSelect = &vmv1beta1.VMSelect{}
+		spec.VMSelect.ReplicaCount = ptr.To[int32](1)
+	}
+	if spec.VMInsert == nil {
+		spec.VMInsert = &vmv1beta1.VMInsert{}
+		spec.VMInsert.ReplicaCount = ptr.To[int32](1)
+	}
+	return &vmv1beta1.VMCluster{
+		ObjectMeta: buildObjectMeta(cr),
+		Spec:       spec,
+	}
+}
+
+func retentionPeriod(cr *vmv1beta1.VMStack) string {
+	if cr.Spec.RetentionPeriod != "" {
+		return cr.Spec.RetentionPeriod
+	}
+	return defaultRetentionPeriod
+}
+
+func singleURLs(vmSingle *vmv1beta1.VMSingle) *storageURLs {
+	baseURL := vmSingle.AsURL()
+	return &storageURLs{
+		read:         baseURL,
+		write:        baseURL + "/api/v1/write",
+		vmalertWrite: baseURL,
+	}
+}
+
+func clusterURLs(vmCluster *vmv1beta1.VMCluster) *storageURLs {
+	insertURL := vmCluster.VMInsertURL() + "/insert/0/prometheus"
+	return &storageURLs{
+		read:         vmCluster.VMSelectURL() + "/select/0/prometheus",
+		write:        insertURL + "/api/v1/write",
+		vmalertWrite: insertURL,
+	}
+}
+
+func buildVMAgent(cr *vmv1beta1.VMStack, urls *storageURLs) *vmv1beta1.VMAgent {
+	var spec vmv1beta1.VMAgentSpec
+	if cr.Spec.VMAgent != nil {
+		spec = *cr.Spec.VMAgent.DeepCopy()
+	}
+	spec.RemoteWrite = append([]vmv1beta1.VMAgentRemoteWriteSpec{{URL: urls.write}}, spec.RemoteWrite...)
+	vmAgent := &vmv1beta1.VMAgent{
+		ObjectMeta: buildObjectMeta(cr),
+		Spec:       spec,
+	}
+	// selectors defined by user take precedence
+	if vmAgent.IsUnmanaged() && !spec.IngestOnlyMode {
+		vmAgent.Spec.SelectAllByDefault = true
+	}
+	return vmAgent
+}
+
+func buildVMAlertmanager(cr *vmv1beta1.VMStack) *vmv1beta1.VMAlertmanager {
+	var spec vmv1beta1.VMAlertmanagerSpec
+	if cr.Spec.VMAlertmanager != nil {
+		spec = *cr.Spec.VMAlertmanager.DeepCopy()
+	}
+	vmAlertmanager := &vmv1beta1.VMAlertmanager{
+		ObjectMeta: buildObjectMeta(cr),
+		Spec:       spec,
+	}
+	if vmAlertmanager.IsUnmanaged() {
+		vmAlertmanager.Spec.SelectAllByDefault = true
+	}
+	return vmAlertmanager
+}
+
+func buildVMAlert(cr *vmv1beta1.VMStack, urls *storageURLs, vmAlertmanager *vmv1beta1.VMAlertmanager) *vmv1beta1.VMAlert {
+	var spec vmv1beta1.VMAlertSpec
+	if cr.Spec.VMAlert != nil {
+		spec = *cr.Spec.VMAlert.DeepCopy()
+	}
+	if spec.Datasource.URL == "" {
+		spec.Datasource.URL = urls.read
+	}
+	if spec.RemoteWrite == nil {
+		spec.RemoteWrite = &vmv1beta1.VMAlertRemoteWriteSpec{URL: urls.vmalertWrite}
+	}
+	if spec.RemoteRead == nil {
+		spec.RemoteRead = &vmv1beta1.VMAlertRemoteReadSpec{URL: urls.read}
+	}
+	if spec.Notifier == nil && len(spec.Notifiers) == 0 && spec.NotifierConfigRef == nil {
+		spec.Notifiers = vmAlertmanager.AsNotifiers()
+	}
+	vmAlert := &vmv1beta1.VMAlert{
+		ObjectMeta: buildObjectMeta(cr),
+		Spec:       spec,
+	}
+	if vmAlert.IsUnmanaged() {
+		vmAlert.Spec.SelectAllByDefault = true
+	}
+	return vmAlert
+}
+
+func buildVMRule(cr *vmv1beta1.VMStack) *vmv1beta1.VMRule {
+	return &vmv1beta1.VMRule{
+		ObjectMeta: buildObjectMeta(cr),
+		Spec: vmv1beta1.VMRuleSpec{
+			Groups: []vmv1beta1.RuleGroup{
+				{
+					Name:  "vmstack",
+					Rules: defaultRules(),
+				},
+			},
+		},
+	}
+}
+
+// defaultRules returns basic alerting rules for the stack components
+// it's a short subset of rules from https://github.com/VictoriaMetrics/VictoriaMetrics/tree/master/deployment/docker/rules
+func defaultRules() []vmv1beta1.Rule {
+	return []vmv1beta1.Rule{
+		{
+			Alert:  "ServiceDown",
+			Expr:   `up == 0`,
+			For:    "2m",
+			Labels: map[string]string{"severity": "critical"},
+			Annotations: map[string]string{
+				"summary":     "Service {{ $labels.job }} is down on {{ $labels.instance }}",
+				"description": "{{ $labels.instance }} of job {{ $labels.job }} has been down for more than 2 minutes.",
+			},
+		},
+		{
+			Alert: "DiskRunsOutOfSpace",
+			Expr: `sum(vm_data_size_bytes) by (job, instance) /
+(
+ sum(vm_free_disk_space_bytes) by (job, instance) +
+ sum(vm_data_size_bytes) by (job, instance)
+) > 0.8`,
+			For:    "30m",
+			Labels: map[string]string{"severity": "critical"},
+			Annotations: map[string]string{
+				"summary":     "Instance {{ $labels.instance }} will run out of disk space soon",
+				"description": "Disk utilisation on instance {{ $labels.instance }} is more than 80%.",
+			},
+		},
+		{
+			Alert:  "TooManyLogs",
+			Expr:   `sum(increase(vm_log_messages_total{level="error"}[5m])) without (app_version, location) > 0`,
+			For:    "15m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "Too many logs printed for job {{ $labels.job }} ({{ $labels.instance }})",
+				"description": "Logging rate for job {{ $labels.job }} ({{ $labels.instance }}) is {{ $value }} for last 15m.",
+			},
+		},
+		{
+			Alert:  "RemoteWriteDroppedPackets",
+			Expr:   `sum(increase(vmagent_remotewrite_packets_dropped_total[5m])) by (job, instance) > 0`,
+			For:    "15m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "Job {{ $labels.job }} on instance {{ $labels.instance }} drops the rejected by remote-write server data blocks",
+				"description": "Check the logs to find the reason for rejects.",
+			},
+		},
+		{
+			Alert:  "AlertingRulesError",
+			Expr:   `sum(increase(vmalert_alerting_rules_errors_total[5m])) without (alertname, id) > 0`,
+			For:    "5m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "Alerting rules are failing for vmalert instance {{ $labels.instance }}",
+				"description": "Alerting rules execution is failing for group {{ $labels.group }}.",
+			},
+		},
+		{
+			Alert:  "AlertmanagerFailedToSendAlerts",
+			Expr:   `sum(rate(alertmanager_notifications_failed_total[5m])) by (job, instance, integration) > 0`,
+			For:    "5m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "Alertmanager {{ $labels.instance }} failed to send notifications",
+				"description": "Alertmanager failed to send notifications to {{ $labels.integration }}.",
+			},
+		},
+	}
+}
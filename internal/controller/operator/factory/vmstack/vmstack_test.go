@@ -0,0 +1,129 @@
+package vmstack
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+)
+
+func TestBuildVMAlert(t *testing.T) {
+	f := func(cr *vmv1beta1.VMStack, urls *storageURLs, wantDatasource, wantRemoteWrite string, wantNotifiers int, wantSelectAll bool) {
+		t.Helper()
+		vmAlertmanager := buildVMAlertmanager(cr)
+		got := buildVMAlert(cr, urls, vmAlertmanager)
+		if got.Spec.Datasource.URL != wantDatasource {
+			t.Fatalf("unexpected datasource, got: %q, want: %q", got.Spec.Datasource.URL, wantDatasource)
+		}
+		if got.Spec.RemoteWrite.URL != wantRemoteWrite {
+			t.Fatalf("unexpected remote write, got: %q, want: %q", got.Spec.RemoteWrite.URL, wantRemoteWrite)
+		}
+		if len(got.Spec.Notifiers) != wantNotifiers {
+			t.Fatalf("unexpected notifiers count, got: %d, want: %d", len(got.Spec.Notifiers), wantNotifiers)
+		}
+		if got.Spec.SelectAllByDefault != wantSelectAll {
+			t.Fatalf("unexpected selectAllByDefault, got: %v, want: %v", got.Spec.SelectAllByDefault, wantSelectAll)
+		}
+	}
+	cr := &vmv1beta1.VMStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+	}
+
+	// defaults for vmsingle
+	f(cr, singleURLs(buildVMSingle(cr)),
+		"http://vmsingle-main.default.svc:8429", "http://vmsingle-main.default.svc:8429", 1, true)
+
+	// defaults for vmcluster
+	f(cr, clusterURLs(buildVMCluster(cr)),
+		"http://vmselect-main.default.svc:8481/select/0/prometheus", "http://vminsert-main.default.svc:8480/insert/0/prometheus", 1, true)
+
+	// overrides
+	f(&vmv1beta1.VMStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		Spec: vmv1beta1.VMStackSpec{
+			VMAlert: &vmv1beta1.VMAlertSpec{
+				Datasource:   vmv1beta1.VMAlertDatasourceSpec{URL: "http://other-storage:8428"},
+				Notifier:     &vmv1beta1.VMAlertNotifierSpec{URL: "http://other-alertmanager:9093"},
+				RuleSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "infra"}},
+			},
+			VMAlertmanager: &vmv1beta1.VMAlertmanagerSpec{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To[int32](3)},
+			},
+		},
+	}, singleURLs(buildVMSingle(cr)), "http://other-storage:8428", "http://vmsingle-main.default.svc:8429", 0, false)
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	ctx := context.Background()
+	cr := &vmv1beta1.VMStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default", UID: "stack-uid"},
+		Spec: vmv1beta1.VMStackSpec{
+			RetentionPeriod: "2",
+		},
+	}
+	fclient := testutil.GetTestClientWithObjects([]runtime.Object{cr})
+	nsn := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}
+	mustGet := func(obj client.Object) {
+		t.Helper()
+		if err := fclient.Get(ctx, nsn, obj); err != nil {
+			t.Fatalf("cannot get %T: %s", obj, err)
+		}
+	}
+	mustNotFound := func(obj client.Object) {
+		t.Helper()
+		if err := fclient.Get(ctx, nsn, obj); !errors.IsNotFound(err) {
+			t.Fatalf("expected %T to be not found, got err: %v", obj, err)
+		}
+	}
+
+	// single storage
+	if err := CreateOrUpdate(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var vmSingle vmv1beta1.VMSingle
+	mustGet(&vmSingle)
+	if vmSingle.Spec.RetentionPeriod != "2" {
+		t.Fatalf("unexpected retention period: %q", vmSingle.Spec.RetentionPeriod)
+	}
+	var vmAgent vmv1beta1.VMAgent
+	mustGet(&vmAgent)
+	if len(vmAgent.Spec.RemoteWrite) != 1 || vmAgent.Spec.RemoteWrite[0].URL != "http://vmsingle-main.default.svc:8429/api/v1/write" {
+		t.Fatalf("unexpected vmagent remote write: %v", vmAgent.Spec.RemoteWrite)
+	}
+	if !vmAgent.Spec.SelectAllByDefault {
+		t.Fatalf("expected vmagent to select all scrape objects")
+	}
+	mustGet(&vmv1beta1.VMAlert{})
+	mustGet(&vmv1beta1.VMAlertmanager{})
+	var vmRule vmv1beta1.VMRule
+	mustGet(&vmRule)
+	if len(vmRule.Spec.Groups) != 1 || len(vmRule.Spec.Groups[0].Rules) == 0 {
+		t.Fatalf("unexpected default rules: %v", vmRule.Spec.Groups)
+	}
+
+	// switch to cluster storage and disable default rules
+	cr.Spec.StorageMode = vmv1beta1.StackStorageModeCluster
+	cr.Spec.DisableDefaultRules = true
+	if err := CreateOrUpdate(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	mustNotFound(&vmv1beta1.VMSingle{})
+	mustNotFound(&vmv1beta1.VMRule{})
+	var vmCluster vmv1beta1.VMCluster
+	mustGet(&vmCluster)
+	if *vmCluster.Spec.VMStorage.ReplicaCount != 2 || vmCluster.Spec.RetentionPeriod != "2" {
+		t.Fatalf("unexpected vmcluster spec: %v", vmCluster.Spec)
+	}
+	mustGet(&vmAgent)
+	if len(vmAgent.Spec.RemoteWrite) != 1 || vmAgent.Spec.RemoteWrite[0].URL != "http://vminsert-main.default.svc:8480/insert/0/prometheus/api/v1/write" {
+		t.Fatalf("unexpected vmagent remote write: %v", vmAgent.Spec.RemoteWrite)
+	}
+}
@@ -1,12 +1,20 @@
 package operator
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/configstat"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/tracing"
 )
 
 var (
@@ -46,8 +54,8 @@ func newCollector() *objectCollector {
 		objectsByController: map[string]map[string]struct{}{},
 	}
 	registeredObjects := []string{
-		"vmagent", "vmalert", "vmsingle", "vmcluster", "vmalertmanager", "vmauth", "vlogs",
-		"vmalertmanagerconfig", "vmrule", "vmuser", "vmservicescrape", "vmstaticscrape", "vmnodescrape", "vmpodscrape", "vmprobescrape", "vmscrapeconfig",
+		"vmagent", "vmalert", "vmsingle", "vmcluster", "vmalertmanager", "vmauth", "vlogs", "vlsingle", "vmgateway",
+		"vmalertmanagerconfig", "vmrule", "vmuser", "vmservicescrape", "vmstaticscrape", "vmnodescrape", "vmpodscrape", "vmprobescrape", "vmscrapeconfig", "vmsnapshot", "vmdatamigration", "vmalertmanagersilence", "vmstack",
 	}
 	for _, controller := range registeredObjects {
 		oc.objectsByController[controller] = map[string]struct{}{}
@@ -71,6 +79,13 @@ func newCollector() *objectCollector {
 	return oc
 }
 
+func (oc *objectCollector) isRegistered(name, ns, controller string) bool {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	_, ok := oc.objectsByController[controller][ns+"/"+name]
+	return ok
+}
+
 // registerObject registers given CR object name with namespace for controller
 func registerObjectByCollector(name, ns, controller string) {
 	initCollector.Do(func() {
@@ -87,6 +102,14 @@ func deregisterObjectByCollector(name, ns, controller string) {
 	collector.deRegister(name, ns, controller)
 }
 
+// isObjectRegisteredByCollector checks if given CR object name with namespace is registered for controller
+func isObjectRegisteredByCollector(name, ns, controller string) bool {
+	initCollector.Do(func() {
+		collector = newCollector()
+	})
+	return collector.isRegistered(name, ns, controller)
+}
+
 // RegisterObjectStat registers or deregisters object at metrics
 func RegisterObjectStat(obj client.Object, controller string) {
 	if obj.GetDeletionTimestamp().IsZero() {
@@ -95,3 +118,73 @@ func RegisterObjectStat(obj client.Object, controller string) {
 	}
 	deregisterObjectByCollector(obj.GetName(), obj.GetNamespace(), controller)
 }
+
+var backupVerificationLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "operator_backup_verification_last_success_timestamp_seconds",
+	Help: "Unix timestamp of the last successful backup verification",
+}, []string{"controller", "namespace", "name"})
+
+func init() {
+	metrics.Registry.MustRegister(backupVerificationLastSuccess)
+}
+
+// RegisterBackupVerificationStat exposes the last successful backup verification time of the object at metrics
+func RegisterBackupVerificationStat(obj client.Object, controller string, status *vmv1beta1.VMBackupVerificationStatus) {
+	if !obj.GetDeletionTimestamp().IsZero() || status == nil || status.LastSuccessfulTime == nil {
+		backupVerificationLastSuccess.DeleteLabelValues(controller, obj.GetNamespace(), obj.GetName())
+		return
+	}
+	backupVerificationLastSuccess.WithLabelValues(controller, obj.GetNamespace(), obj.GetName()).Set(float64(status.LastSuccessfulTime.Unix()))
+}
+
+var (
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "operator_controller_object_reconcile_duration_seconds",
+		Help:    "Duration of reconcile for CR object",
+		Buckets: []float64{0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 120, 300},
+	}, []string{"controller"})
+	reconcileErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "operator_controller_object_reconcile_errors_total",
+		Help: "Counts number of failed reconciles for CR object",
+	}, []string{"controller", "namespaced_name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileDuration, reconcileErrorsTotal)
+}
+
+// reconcilerWithMetrics tracks reconcile duration and errors for CR objects
+type reconcilerWithMetrics struct {
+	controller string
+	origin     reconcile.Reconciler
+}
+
+// withReconcileMetrics wraps given reconciler with per object metrics
+// controller name must match name used for RegisterObjectStat
+func withReconcileMetrics(controller string, origin reconcile.Reconciler) reconcile.Reconciler {
+	return &reconcilerWithMetrics{controller: controller, origin: origin}
+}
+
+// Reconcile implements reconcile.Reconciler interface
+func (r *reconcilerWithMetrics) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	startTime := time.Now()
+	ctx, span := tracing.Start(ctx, "Reconcile "+r.controller,
+		attribute.String("controller", r.controller),
+		attribute.String("k8s.namespace.name", req.Namespace),
+		attribute.String("k8s.object.name", req.Name),
+	)
+	result, err := r.origin.Reconcile(ctx, req)
+	tracing.End(span, err)
+	nsn := req.String()
+	configstat.ObserveWithExemplar(ctx, reconcileDuration.WithLabelValues(r.controller), time.Since(startTime).Seconds(), nsn)
+	if !isObjectRegisteredByCollector(req.Name, req.Namespace, r.controller) {
+		// object was deleted, remove its metrics
+		reconcileErrorsTotal.DeleteLabelValues(r.controller, nsn)
+		configstat.Deregister(r.controller, req.Namespace, req.Name)
+		return result, err
+	}
+	if err != nil {
+		reconcileErrorsTotal.WithLabelValues(r.controller, nsn).Inc()
+	}
+	return result, err
+}
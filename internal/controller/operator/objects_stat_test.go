@@ -0,0 +1,87 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcilerWithMetrics(t *testing.T) {
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "metrics-test"}}
+	nsn := req.String()
+	var reconcileErr error
+	r := withReconcileMetrics("vmagent", reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, reconcileErr
+	}))
+	seriesCount := func(c prometheus.Collector) int {
+		t.Helper()
+		ch := make(chan prometheus.Metric, 100)
+		c.Collect(ch)
+		close(ch)
+		var cnt int
+		for m := range ch {
+			var pm dto.Metric
+			if err := m.Write(&pm); err != nil {
+				t.Fatalf("cannot write metric: %s", err)
+			}
+			for _, l := range pm.GetLabel() {
+				if l.GetName() == "namespaced_name" && l.GetValue() == nsn {
+					cnt++
+				}
+			}
+		}
+		return cnt
+	}
+	hasExemplar := func() bool {
+		t.Helper()
+		var pm dto.Metric
+		if err := reconcileDuration.WithLabelValues("vmagent").(prometheus.Metric).Write(&pm); err != nil {
+			t.Fatalf("cannot write metric: %s", err)
+		}
+		for _, b := range pm.GetHistogram().GetBucket() {
+			for _, l := range b.GetExemplar().GetLabel() {
+				if l.GetName() == "namespaced_name" && l.GetValue() == nsn {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	f := func(wantErrorSeries int) {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), req); err != reconcileErr {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !hasExemplar() {
+			t.Fatalf("expected reconcile duration exemplar for object=%s", nsn)
+		}
+		if got := seriesCount(reconcileDuration); got != 0 {
+			t.Fatalf("reconcile duration must not be labeled by object, got %d series", got)
+		}
+		if got := seriesCount(reconcileErrorsTotal); got != wantErrorSeries {
+			t.Fatalf("unexpected errors series count, got: %d, want: %d", got, wantErrorSeries)
+		}
+	}
+
+	// successful reconcile
+	registerObjectByCollector(req.Name, req.Namespace, "vmagent")
+	f(0)
+
+	// failed reconcile
+	reconcileErr = fmt.Errorf("cannot create deployment")
+	f(1)
+	if got := testutil.ToFloat64(reconcileErrorsTotal.WithLabelValues("vmagent", nsn)); got != 1 {
+		t.Fatalf("unexpected errors count, got: %v, want: 1", got)
+	}
+
+	// deleted object metrics are removed
+	deregisterObjectByCollector(req.Name, req.Namespace, "vmagent")
+	reconcileErr = nil
+	f(0)
+}
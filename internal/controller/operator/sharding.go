@@ -0,0 +1,60 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	shardCount = ptr.To(1)
+	shardNum   = ptr.To(0)
+)
+
+// ValidateSharding checks -controller.shardCount and -controller.shardNum flags
+func ValidateSharding() error {
+	if *shardCount < 1 {
+		return fmt.Errorf("-controller.shardCount must be greater than 0, got: %d", *shardCount)
+	}
+	if *shardNum < 0 || *shardNum >= *shardCount {
+		return fmt.Errorf("-controller.shardNum must be in range [0, %d), got: %d", *shardCount, *shardNum)
+	}
+	return nil
+}
+
+// CurrentShard returns number of operator shard
+// and false if sharding is disabled
+func CurrentShard() (int, bool) {
+	return *shardNum, *shardCount > 1
+}
+
+// isOwnedByShard checks if object with the given key must be reconciled by the current operator shard
+func isOwnedByShard(key client.ObjectKey) bool {
+	if *shardCount <= 1 {
+		return true
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key.String()))
+	return int(h.Sum32()%uint32(*shardCount)) == *shardNum
+}
+
+type shardedReconciler struct {
+	origin reconcile.Reconciler
+}
+
+// withSharding skips reconcile of objects owned by the other operator shards
+func withSharding(origin reconcile.Reconciler) reconcile.Reconciler {
+	return &shardedReconciler{origin: origin}
+}
+
+// Reconcile implements reconcile.Reconciler interface
+func (r *shardedReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if !isOwnedByShard(req.NamespacedName) {
+		return reconcile.Result{}, nil
+	}
+	return r.origin.Reconcile(ctx, req)
+}
@@ -0,0 +1,74 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestWithSharding(t *testing.T) {
+	prevCount, prevNum := *shardCount, *shardNum
+	defer func() {
+		*shardCount, *shardNum = prevCount, prevNum
+	}()
+
+	var requests []types.NamespacedName
+	for i := 0; i < 100; i++ {
+		requests = append(requests, types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("vmagent-%d", i)})
+	}
+	f := func(count int) {
+		t.Helper()
+		*shardCount = count
+		reconciled := make(map[types.NamespacedName]int)
+		for num := 0; num < count; num++ {
+			*shardNum = num
+			if err := ValidateSharding(); err != nil {
+				t.Fatalf("unexpected validation error: %s", err)
+			}
+			r := withSharding(reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+				reconciled[req.NamespacedName]++
+				return reconcile.Result{}, nil
+			}))
+			for _, nsn := range requests {
+				if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: nsn}); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+		}
+		// each object must be reconciled exactly by one shard
+		for _, nsn := range requests {
+			if reconciled[nsn] != 1 {
+				t.Fatalf("object=%s reconciled %d times, want 1", nsn, reconciled[nsn])
+			}
+		}
+	}
+
+	// sharding disabled
+	f(1)
+
+	// multiple shards
+	f(3)
+}
+
+func TestValidateSharding(t *testing.T) {
+	prevCount, prevNum := *shardCount, *shardNum
+	defer func() {
+		*shardCount, *shardNum = prevCount, prevNum
+	}()
+	f := func(count, num int, wantErr bool) {
+		t.Helper()
+		*shardCount, *shardNum = count, num
+		if err := ValidateSharding(); (err != nil) != wantErr {
+			t.Fatalf("unexpected validation result, got err: %v, wantErr: %v", err, wantErr)
+		}
+	}
+
+	f(1, 0, false)
+	f(3, 2, false)
+	f(0, 0, true)
+	f(3, 3, true)
+	f(3, -1, true)
+}
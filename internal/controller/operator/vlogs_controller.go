@@ -41,15 +41,13 @@ type VLogsReconciler struct {
 	client.Client
 	Log          logr.Logger
 	OriginScheme *runtime.Scheme
-	BaseConf     *config.BaseOperatorConf
 }
 
 // Init implements crdController interface
-func (r *VLogsReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VLogsReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VLogs")
 	r.OriginScheme = sc
-	r.BaseConf = cf
 }
 
 // Scheme implements interface.
@@ -80,7 +78,7 @@ func (r *VLogsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resu
 	RegisterObjectStat(instance, "vlogs")
 	if !instance.DeletionTimestamp.IsZero() {
 		if err := finalize.OnVLogsDelete(ctx, r.Client, instance); err != nil {
-			return result, err
+			return result, &finalizeError{err, "vlogs"}
 		}
 		return
 	}
@@ -101,7 +99,7 @@ func (r *VLogsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resu
 		return result, nil
 	})
 
-	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+	result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
 
 	return
 }
@@ -112,6 +110,6 @@ func (r *VLogsReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&vmv1beta1.VLogs{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.ServiceAccount{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vlogs")).
+		Complete(withReconcileMetrics("vlogs", withSharding(r)))
 }
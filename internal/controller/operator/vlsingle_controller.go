@@ -0,0 +1,115 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vlsingle"
+)
+
+// VLSingleReconciler reconciles a VLSingle object
+type VLSingleReconciler struct {
+	client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+}
+
+// Init implements crdController interface
+func (r *VLSingleReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
+	r.Client = rclient
+	r.Log = l.WithName("controller.VLSingle")
+	r.OriginScheme = sc
+}
+
+// Scheme implements interface.
+func (r *VLSingleReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile general reconcile method for controller
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vlsingles,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vlsingles/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vlsingles/finalizers,verbs=*
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=*
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=*
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=*
+func (r *VLSingleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	reqLogger := r.Log.WithValues("vlsingle", req.Name, "namespace", req.Namespace)
+	ctx = logger.AddToContext(ctx, reqLogger)
+	instance := &vmv1beta1.VLSingle{}
+
+	defer func() {
+		result, err = handleReconcileErr(ctx, r.Client, instance, result, err)
+	}()
+
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return result, &getError{err, "vlsingle", req}
+	}
+
+	RegisterObjectStat(instance, "vlsingle")
+	if !instance.DeletionTimestamp.IsZero() {
+		if err := finalize.OnVLSingleDelete(ctx, r.Client, instance); err != nil {
+			return result, &finalizeError{err, "vlsingle"}
+		}
+		return
+	}
+	if instance.Spec.ParsingError != "" {
+		return result, &parsingError{instance.Spec.ParsingError, "vlsingle"}
+	}
+	if err := finalize.AddFinalizer(ctx, r.Client, instance); err != nil {
+		return result, err
+	}
+	r.Client.Scheme().Default(instance)
+
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
+
+		if err = vlsingle.CreateOrUpdateVLSingle(ctx, r, instance); err != nil {
+			return result, fmt.Errorf("failed create or update vlsingle: %w", err)
+		}
+
+		return result, nil
+	})
+
+	result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
+
+	return
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VLSingleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VLSingle{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.ServiceAccount{}).
+		WithOptions(getDefaultOptions("vlsingle")).
+		Complete(withReconcileMetrics("vlsingle", withSharding(r)))
+}
@@ -18,7 +18,9 @@ package operator
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"time"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
@@ -31,8 +33,11 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 var (
@@ -45,15 +50,13 @@ type VMAgentReconciler struct {
 	client.Client
 	Log          logr.Logger
 	OriginScheme *runtime.Scheme
-	BaseConf     *config.BaseOperatorConf
 }
 
 // Init implements crdController interface
-func (r *VMAgentReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMAgentReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMAgent")
 	r.OriginScheme = sc
-	r.BaseConf = cf
 }
 
 // Reconcile general reconcile method
@@ -94,7 +97,7 @@ func (r *VMAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	RegisterObjectStat(instance, "vmagent")
 	if !instance.DeletionTimestamp.IsZero() {
 		if err := finalize.OnVMAgentDelete(ctx, r.Client, instance); err != nil {
-			return result, err
+			return result, &finalizeError{err, "vmagent"}
 		}
 		return
 	}
@@ -118,7 +121,7 @@ func (r *VMAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	if err != nil {
 		return
 	}
-	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+	result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
 
 	return
 }
@@ -130,11 +133,90 @@ func (r *VMAgentReconciler) Scheme() *runtime.Scheme {
 
 // SetupWithManager general setup method
 func (r *VMAgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if config.MustGetBaseConfig().VMAgentRemoteWriteStatusCheckInterval > 0 {
+		if err := mgr.Add(manager.RunnableFunc(r.runRemoteWriteStatusCheck)); err != nil {
+			return fmt.Errorf("cannot add vmagent remote write status check: %w", err)
+		}
+	}
+	if config.MustGetBaseConfig().VMAgentShardStatusCheckInterval > 0 {
+		if err := mgr.Add(manager.RunnableFunc(r.runShardStatusCheck)); err != nil {
+			return fmt.Errorf("cannot add vmagent shard status check: %w", err)
+		}
+	}
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&vmv1beta1.VMAgent{}).
+		For(&vmv1beta1.VMAgent{}, builder.WithPredicates(skipStatusUpdates)).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&v1.ServiceAccount{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vmagent")).
+		Complete(withReconcileMetrics("vmagent", withSharding(r)))
+}
+
+// runRemoteWriteStatusCheck periodically updates health of remote write targets at VMAgent status
+func (r *VMAgentReconciler) runRemoteWriteStatusCheck(ctx context.Context) error {
+	t := time.NewTicker(config.MustGetBaseConfig().VMAgentRemoteWriteStatusCheckInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			r.checkRemoteWriteStatus(ctx)
+		}
+	}
+}
+
+func (r *VMAgentReconciler) checkRemoteWriteStatus(ctx context.Context) {
+	var agents vmv1beta1.VMAgentList
+	if err := r.List(ctx, &agents); err != nil {
+		r.Log.Error(err, "cannot list vmagents for remote write status check")
+		return
+	}
+	existing := make(map[types.NamespacedName]struct{}, len(agents.Items))
+	for i := range agents.Items {
+		cr := &agents.Items[i]
+		nsn := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}
+		if !isOwnedByShard(nsn) || !cr.DeletionTimestamp.IsZero() || cr.Spec.ParsingError != "" || cr.Paused() {
+			continue
+		}
+		existing[nsn] = struct{}{}
+		l := r.Log.WithValues("vmagent", cr.Name, "namespace", cr.Namespace)
+		if err := vmagent.UpdateRemoteWriteStatus(logger.AddToContext(ctx, l), r.Client, cr); err != nil {
+			l.Error(err, "cannot update remote write status")
+		}
+	}
+	vmagent.PruneRemoteWriteMetrics(existing)
+}
+
+// runShardStatusCheck periodically updates the number of scrape targets per shard at VMAgent status
+func (r *VMAgentReconciler) runShardStatusCheck(ctx context.Context) error {
+	t := time.NewTicker(config.MustGetBaseConfig().VMAgentShardStatusCheckInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			r.checkShardStatus(ctx)
+		}
+	}
+}
+
+func (r *VMAgentReconciler) checkShardStatus(ctx context.Context) {
+	var agents vmv1beta1.VMAgentList
+	if err := r.List(ctx, &agents); err != nil {
+		r.Log.Error(err, "cannot list vmagents for shard status check")
+		return
+	}
+	for i := range agents.Items {
+		cr := &agents.Items[i]
+		nsn := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}
+		if !isOwnedByShard(nsn) || !cr.DeletionTimestamp.IsZero() || cr.Spec.ParsingError != "" || cr.Paused() {
+			continue
+		}
+		l := r.Log.WithValues("vmagent", cr.Name, "namespace", cr.Namespace)
+		if err := vmagent.UpdateShardStatus(logger.AddToContext(ctx, l), r.Client, cr); err != nil {
+			l.Error(err, "cannot update shard status")
+		}
+	}
 }
@@ -41,15 +41,13 @@ type VMAlertReconciler struct {
 	client.Client
 	Log          logr.Logger
 	OriginScheme *runtime.Scheme
-	BaseConf     *config.BaseOperatorConf
 }
 
 // Init implements crdController interface
-func (r *VMAlertReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMAlertReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMAlert")
 	r.OriginScheme = sc
-	r.BaseConf = cf
 }
 
 // Scheme implements interface.
@@ -78,7 +76,7 @@ func (r *VMAlertReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 
 	if !instance.DeletionTimestamp.IsZero() {
 		if err := finalize.OnVMAlertDelete(ctx, r.Client, instance); err != nil {
-			return result, err
+			return result, &finalizeError{err, "vmalert"}
 		}
 		return result, nil
 	}
@@ -92,21 +90,29 @@ func (r *VMAlertReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	}
 	r.Client.Scheme().Default(instance)
 
-	result, resultErr = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
+	statusObject := instance.DeepCopy()
+	result, resultErr = reconcileAndTrackStatus(ctx, r.Client, statusObject, func() (ctrl.Result, error) {
 		maps, err := vmalert.CreateOrUpdateRuleConfigMaps(ctx, instance, r)
+		// rules status is updated by CreateOrUpdateRuleConfigMaps
+		// it must not be overwritten by the final status update
+		statusObject.Status.RuleSelection = instance.Status.RuleSelection
+		statusObject.Status.RuleConfigMaps = instance.Status.RuleConfigMaps
 		if err != nil {
 			return result, err
 		}
 		if err := vmalert.CreateOrUpdateVMAlert(ctx, instance, r, maps); err != nil {
 			return result, err
 		}
+		if err := vmalert.DeleteStaleRuleConfigMaps(ctx, r, instance, maps); err != nil {
+			return result, err
+		}
 
 		return result, nil
 	})
 	if resultErr != nil {
 		return
 	}
-	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+	result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
 	return
 }
 
@@ -116,6 +122,6 @@ func (r *VMAlertReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&vmv1beta1.VMAlert{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&v1.ServiceAccount{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vmalert")).
+		Complete(withReconcileMetrics("vmalert", withSharding(r)))
 }
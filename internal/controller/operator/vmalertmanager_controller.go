@@ -38,15 +38,13 @@ type VMAlertmanagerReconciler struct {
 	client.Client
 	Log          logr.Logger
 	OriginScheme *runtime.Scheme
-	BaseConf     *config.BaseOperatorConf
 }
 
 // Init implements crdController interface
-func (r *VMAlertmanagerReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMAlertmanagerReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMAlertmanager")
 	r.OriginScheme = sc
-	r.BaseConf = cf
 }
 
 // Scheme implements interface.
@@ -76,7 +74,7 @@ func (r *VMAlertmanagerReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	if !instance.DeletionTimestamp.IsZero() {
 		if err := finalize.OnVMAlertManagerDelete(ctx, r.Client, instance); err != nil {
-			return result, err
+			return result, &finalizeError{err, "vmalertmanager"}
 		}
 		return
 	}
@@ -104,7 +102,7 @@ func (r *VMAlertmanagerReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return
 	}
 
-	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+	result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
 	return
 }
 
@@ -114,6 +112,6 @@ func (r *VMAlertmanagerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&vmv1beta1.VMAlertmanager{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&v1.ServiceAccount{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vmalertmanager")).
+		Complete(withReconcileMetrics("vmalertmanager", withSharding(r)))
 }
@@ -41,15 +41,13 @@ type VMAlertmanagerConfigReconciler struct {
 	client.Client
 	Log          logr.Logger
 	OriginScheme *runtime.Scheme
-	BaseConf     *config.BaseOperatorConf
 }
 
 // Init implements crdController interface
-func (r *VMAlertmanagerConfigReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMAlertmanagerConfigReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMAlertmanagerConfig")
 	r.OriginScheme = sc
-	r.BaseConf = cf
 }
 
 // Scheme implements interface.
@@ -87,7 +85,7 @@ func (r *VMAlertmanagerConfigReconciler) Reconcile(ctx context.Context, req ctrl
 
 	for _, item := range objects.Items {
 		am := &item
-		if !am.DeletionTimestamp.IsZero() || am.Spec.ParsingError != "" || am.IsUnmanaged() {
+		if !am.DeletionTimestamp.IsZero() || am.Paused() || !isOwnedByShard(client.ObjectKeyFromObject(am)) || am.Spec.ParsingError != "" || am.IsUnmanaged() {
 			continue
 		}
 
@@ -118,6 +116,6 @@ func (r *VMAlertmanagerConfigReconciler) SetupWithManager(mgr ctrl.Manager) erro
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1beta1.VMAlertmanagerConfig{}).
 		WithEventFilter(predicate.TypedGenerationChangedPredicate[client.Object]{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vmalertmanagerconfig")).
+		Complete(withReconcileMetrics("vmalertmanagerconfig", r))
 }
@@ -0,0 +1,98 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmalertmanagersilence"
+)
+
+// VMAlertmanagerSilenceReconciler reconciles a VMAlertmanagerSilence object
+type VMAlertmanagerSilenceReconciler struct {
+	client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+}
+
+// Init implements crdController interface
+func (r *VMAlertmanagerSilenceReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
+	r.Client = rclient
+	r.Log = l.WithName("controller.VMAlertmanagerSilence")
+	r.OriginScheme = sc
+}
+
+// Scheme implements interface.
+func (r *VMAlertmanagerSilenceReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile general reconcile method for controller
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmalertmanagersilences,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmalertmanagersilences/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmalertmanagersilences/finalizers,verbs=*
+func (r *VMAlertmanagerSilenceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	reqLogger := r.Log.WithValues("vmalertmanagersilence", req.Name, "namespace", req.Namespace)
+	ctx = logger.AddToContext(ctx, reqLogger)
+	instance := &vmv1beta1.VMAlertmanagerSilence{}
+
+	defer func() {
+		result, err = handleReconcileErr(ctx, r.Client, instance, result, err)
+	}()
+
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return result, &getError{err, "vmalertmanagersilence", req}
+	}
+
+	RegisterObjectStat(instance, "vmalertmanagersilence")
+	if !instance.DeletionTimestamp.IsZero() {
+		if err := vmalertmanagersilence.Expire(ctx, r.Client, instance); err != nil {
+			return result, fmt.Errorf("cannot expire silence: %w", err)
+		}
+		if err := finalize.OnVMAlertmanagerSilenceDelete(ctx, r.Client, instance); err != nil {
+			return result, &finalizeError{err, "vmalertmanagersilence"}
+		}
+		return
+	}
+	if instance.Spec.ParsingError != "" {
+		return result, &parsingError{instance.Spec.ParsingError, "vmalertmanagersilence"}
+	}
+	if err := finalize.AddFinalizer(ctx, r.Client, instance); err != nil {
+		return result, err
+	}
+
+	// silence id is tracked at the object status,
+	// so the same object must be used for status updates
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance, func() (ctrl.Result, error) {
+		nextRun, err := vmalertmanagersilence.CreateOrUpdate(ctx, r.Client, instance)
+		if err != nil {
+			return result, fmt.Errorf("failed to reconcile vmalertmanagersilence: %w", err)
+		}
+		result.RequeueAfter = nextRun
+		return result, nil
+	})
+	if err != nil {
+		return
+	}
+	if resync := config.MustGetBaseConfig().ResyncAfterDuration(); resync > 0 && (result.RequeueAfter == 0 || resync < result.RequeueAfter) {
+		result.RequeueAfter = resync
+	}
+
+	return
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VMAlertmanagerSilenceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VMAlertmanagerSilence{}).
+		WithOptions(getDefaultOptions("vmalertmanagersilence")).
+		Complete(withReconcileMetrics("vmalertmanagersilence", withSharding(r)))
+}
@@ -37,17 +37,15 @@ import (
 // VMAuthReconciler reconciles a VMAuth object
 type VMAuthReconciler struct {
 	client.Client
-	BaseConf     *config.BaseOperatorConf
 	Log          logr.Logger
 	OriginScheme *runtime.Scheme
 }
 
 // Init implements crdController interface
-func (r *VMAuthReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMAuthReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMAuth")
 	r.OriginScheme = sc
-	r.BaseConf = cf
 }
 
 // Scheme implements interface.
@@ -75,7 +73,7 @@ func (r *VMAuthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 
 	if !instance.DeletionTimestamp.IsZero() {
 		if err := finalize.OnVMAuthDelete(ctx, r, instance); err != nil {
-			return result, fmt.Errorf("cannot remove finalizer from vmauth: %w", err)
+			return result, &finalizeError{fmt.Errorf("cannot remove finalizer from vmauth: %w", err), "vmauth"}
 		}
 		return result, nil
 	}
@@ -98,7 +96,7 @@ func (r *VMAuthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 	if err != nil {
 		return
 	}
-	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+	result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
 
 	return
 }
@@ -109,6 +107,6 @@ func (r *VMAuthReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&vmv1beta1.VMAuth{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.ServiceAccount{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vmauth")).
+		Complete(withReconcileMetrics("vmauth", withSharding(r)))
 }
@@ -3,6 +3,7 @@ package operator
 import (
 	"context"
 	"fmt"
+	"time"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
@@ -11,9 +12,13 @@ import (
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmcluster"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 // VMClusterReconciler reconciles a VMCluster object
@@ -21,15 +26,13 @@ type VMClusterReconciler struct {
 	Client       client.Client
 	Log          logr.Logger
 	OriginScheme *runtime.Scheme
-	BaseConf     *config.BaseOperatorConf
 }
 
 // Init implements crdController interface
-func (r *VMClusterReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMClusterReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMCluster")
 	r.OriginScheme = sc
-	r.BaseConf = cf
 }
 
 // Scheme implements interface.
@@ -42,6 +45,7 @@ func (r *VMClusterReconciler) Scheme() *runtime.Scheme {
 // +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmclusters/finalizers,verbs=*
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=*
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=*
 func (r *VMClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
 	reqLogger := r.Log.WithValues("vmcluster", request.Name, "namespace", request.Namespace)
 	ctx = logger.AddToContext(ctx, reqLogger)
@@ -58,8 +62,9 @@ func (r *VMClusterReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 	RegisterObjectStat(instance, "vmcluster")
 
 	if !instance.DeletionTimestamp.IsZero() {
+		RegisterBackupVerificationStat(instance, "vmcluster", nil)
 		if err := finalize.OnVMClusterDelete(ctx, r.Client, instance); err != nil {
-			return result, err
+			return result, &finalizeError{err, "vmcluster"}
 		}
 		return result, nil
 	}
@@ -70,6 +75,13 @@ func (r *VMClusterReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 		return result, err
 	}
 	r.Client.Scheme().Default(instance)
+	// retention decrease must be checked before last applied spec update
+	// otherwise it'll be applied on the next reconcile without confirmation
+	if !instance.Paused() {
+		if err := instance.CheckRetentionDecrease(); err != nil {
+			return result, err
+		}
+	}
 
 	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
 		err = vmcluster.CreateOrUpdateVMCluster(ctx, instance, r.Client)
@@ -81,17 +93,68 @@ func (r *VMClusterReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 	if err != nil {
 		return
 	}
+	// restore and backup verification statuses must be updated after status tracking
+	// otherwise it could be overwritten by the status of tracked object copy
+	if err = vmcluster.UpdateRestoreStatus(ctx, r.Client, instance); err != nil {
+		return
+	}
+	if err = vmcluster.UpdateBackupVerificationStatus(ctx, r.Client, instance); err != nil {
+		return
+	}
+	RegisterBackupVerificationStat(instance, "vmcluster", instance.Status.VMStorageBackupVerification)
 
-	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+	result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
 	return
 }
 
 // SetupWithManager general setup method
 func (r *VMClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if config.MustGetBaseConfig().VMClusterResourceRecommendationsInterval > 0 {
+		if err := mgr.Add(manager.RunnableFunc(r.runRecommendationsCheck)); err != nil {
+			return fmt.Errorf("cannot add vmcluster resource recommendations check: %w", err)
+		}
+	}
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&vmv1beta1.VMCluster{}).
+		For(&vmv1beta1.VMCluster{}, builder.WithPredicates(skipStatusUpdates)).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		Owns(&batchv1.CronJob{}).
+		WithOptions(getDefaultOptions("vmcluster")).
+		Complete(withReconcileMetrics("vmcluster", withSharding(r)))
+}
+
+// runRecommendationsCheck periodically updates components sizing recommendations at VMCluster status
+func (r *VMClusterReconciler) runRecommendationsCheck(ctx context.Context) error {
+	t := time.NewTicker(config.MustGetBaseConfig().VMClusterResourceRecommendationsInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			r.checkRecommendations(ctx)
+		}
+	}
+}
+
+func (r *VMClusterReconciler) checkRecommendations(ctx context.Context) {
+	var clusters vmv1beta1.VMClusterList
+	if err := r.Client.List(ctx, &clusters); err != nil {
+		r.Log.Error(err, "cannot list vmclusters for resource recommendations check")
+		return
+	}
+	existing := make(map[types.NamespacedName]struct{}, len(clusters.Items))
+	for i := range clusters.Items {
+		cr := &clusters.Items[i]
+		nsn := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}
+		if !isOwnedByShard(nsn) || !cr.DeletionTimestamp.IsZero() || cr.Spec.ParsingError != "" || cr.Paused() {
+			continue
+		}
+		existing[nsn] = struct{}{}
+		l := r.Log.WithValues("vmcluster", cr.Name, "namespace", cr.Namespace)
+		if err := vmcluster.UpdateRecommendations(logger.AddToContext(ctx, l), r.Client, cr); err != nil {
+			l.Error(err, "cannot update resource recommendations")
+		}
+	}
+	vmcluster.PruneRecommendationsMetrics(existing)
 }
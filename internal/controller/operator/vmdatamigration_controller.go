@@ -0,0 +1,95 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmdatamigration"
+)
+
+// VMDataMigrationReconciler reconciles a VMDataMigration object
+type VMDataMigrationReconciler struct {
+	client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+}
+
+// Init implements crdController interface
+func (r *VMDataMigrationReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
+	r.Client = rclient
+	r.Log = l.WithName("controller.VMDataMigration")
+	r.OriginScheme = sc
+}
+
+// Scheme implements interface.
+func (r *VMDataMigrationReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile general reconcile method for controller
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmdatamigrations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmdatamigrations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmdatamigrations/finalizers,verbs=*
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=*
+func (r *VMDataMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	reqLogger := r.Log.WithValues("vmdatamigration", req.Name, "namespace", req.Namespace)
+	ctx = logger.AddToContext(ctx, reqLogger)
+	instance := &vmv1beta1.VMDataMigration{}
+
+	defer func() {
+		result, err = handleReconcileErr(ctx, r.Client, instance, result, err)
+	}()
+
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return result, &getError{err, "vmdatamigration", req}
+	}
+
+	RegisterObjectStat(instance, "vmdatamigration")
+	if !instance.DeletionTimestamp.IsZero() {
+		if err := finalize.OnVMDataMigrationDelete(ctx, r.Client, instance); err != nil {
+			return result, &finalizeError{err, "vmdatamigration"}
+		}
+		return
+	}
+	if instance.Spec.ParsingError != "" {
+		return result, &parsingError{instance.Spec.ParsingError, "vmdatamigration"}
+	}
+	if err := finalize.AddFinalizer(ctx, r.Client, instance); err != nil {
+		return result, err
+	}
+	r.Client.Scheme().Default(instance)
+
+	// migration phase is tracked at the object status,
+	// so the same object must be used for status updates
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance, func() (ctrl.Result, error) {
+		if err := vmdatamigration.CreateOrUpdate(ctx, r.Client, instance); err != nil {
+			return result, fmt.Errorf("failed to reconcile vmdatamigration: %w", err)
+		}
+		return result, nil
+	})
+	if err != nil {
+		return
+	}
+	result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
+
+	return
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VMDataMigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VMDataMigration{}).
+		Owns(&batchv1.Job{}).
+		WithOptions(getDefaultOptions("vmdatamigration")).
+		Complete(withReconcileMetrics("vmdatamigration", withSharding(r)))
+}
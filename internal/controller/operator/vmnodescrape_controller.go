@@ -41,7 +41,7 @@ type VMNodeScrapeReconciler struct {
 }
 
 // Init implements crdController interface
-func (r *VMNodeScrapeReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMNodeScrapeReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMNodeScrape")
 	r.OriginScheme = sc
@@ -87,7 +87,7 @@ func (r *VMNodeScrapeReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}
 
 	for _, vmagentItem := range objects.Items {
-		if !vmagentItem.DeletionTimestamp.IsZero() || vmagentItem.Spec.ParsingError != "" || vmagentItem.IsNodeScrapeUnmanaged() {
+		if !vmagentItem.DeletionTimestamp.IsZero() || vmagentItem.Paused() || !isOwnedByShard(client.ObjectKeyFromObject(&vmagentItem)) || vmagentItem.Spec.ParsingError != "" || vmagentItem.IsNodeScrapeUnmanaged() {
 			continue
 		}
 		currentVMagent := &vmagentItem
@@ -120,6 +120,6 @@ func (r *VMNodeScrapeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1beta1.VMNodeScrape{}).
 		WithEventFilter(predicate.TypedGenerationChangedPredicate[client.Object]{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vmnodescrape")).
+		Complete(withReconcileMetrics("vmnodescrape", r))
 }
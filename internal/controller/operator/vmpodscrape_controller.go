@@ -41,7 +41,7 @@ type VMPodScrapeReconciler struct {
 }
 
 // Init implements crdController interface
-func (r *VMPodScrapeReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMPodScrapeReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMPodScrape")
 	r.OriginScheme = sc
@@ -85,7 +85,7 @@ func (r *VMPodScrapeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	for _, vmagentItem := range objects.Items {
-		if !vmagentItem.DeletionTimestamp.IsZero() || vmagentItem.Spec.ParsingError != "" || vmagentItem.IsPodScrapeUnmanaged() {
+		if !vmagentItem.DeletionTimestamp.IsZero() || vmagentItem.Paused() || !isOwnedByShard(client.ObjectKeyFromObject(&vmagentItem)) || vmagentItem.Spec.ParsingError != "" || vmagentItem.IsPodScrapeUnmanaged() {
 			continue
 		}
 		currentVMagent := &vmagentItem
@@ -118,6 +118,6 @@ func (r *VMPodScrapeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1beta1.VMPodScrape{}).
 		WithEventFilter(predicate.TypedGenerationChangedPredicate[client.Object]{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vmpodscrape")).
+		Complete(withReconcileMetrics("vmpodscrape", r))
 }
@@ -41,7 +41,7 @@ type VMProbeReconciler struct {
 }
 
 // Init implements crdController interface
-func (r *VMProbeReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMProbeReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMProbe")
 	r.OriginScheme = sc
@@ -84,7 +84,7 @@ func (r *VMProbeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	}
 
 	for _, vmagentItem := range objects.Items {
-		if !vmagentItem.DeletionTimestamp.IsZero() || vmagentItem.Spec.ParsingError != "" || vmagentItem.IsProbeUnmanaged() {
+		if !vmagentItem.DeletionTimestamp.IsZero() || vmagentItem.Paused() || !isOwnedByShard(client.ObjectKeyFromObject(&vmagentItem)) || vmagentItem.Spec.ParsingError != "" || vmagentItem.IsProbeUnmanaged() {
 			continue
 		}
 		currentVMagent := &vmagentItem
@@ -116,6 +116,6 @@ func (r *VMProbeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1beta1.VMProbe{}).
 		WithEventFilter(predicate.TypedGenerationChangedPredicate[client.Object]{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vmprobescrape")).
+		Complete(withReconcileMetrics("vmprobescrape", r))
 }
@@ -54,6 +54,11 @@ const (
 	IgnoreConversionLabel = "operator.victoriametrics.com/ignore-prometheus-updates"
 	// IgnoreConversion - disables updates from prometheus api
 	IgnoreConversion = "enabled"
+	// SkipConversionLabel this annotation disables conversion of prometheus object
+	// must be added to annotation of prometheus object
+	// annotations:
+	//  operator.victoriametrics.com/skip-conversion: enabled
+	SkipConversionLabel = "operator.victoriametrics.com/skip-conversion"
 )
 
 // ConverterController - watches for prometheus objects
@@ -69,6 +74,7 @@ type ConverterController struct {
 	probeInf        cache.SharedIndexInformer
 	scrapeConfigInf cache.SharedIndexInformer
 	baseConf        *config.BaseOperatorConf
+	syncStatus      *converterSyncStatus
 }
 
 // NewConverterController builder for vmprometheusconverter service
@@ -78,6 +84,7 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 		baseClient: baseClient,
 		rclient:    rclient,
 		baseConf:   baseConf,
+		syncStatus: newConverterSyncStatus(),
 	}
 
 	c.ruleInf = cache.NewSharedIndexInformer(
@@ -102,6 +109,7 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 	if _, err := c.ruleInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.CreatePrometheusRule,
 		UpdateFunc: c.UpdatePrometheusRule,
+		DeleteFunc: c.deleteHandler(promv1.PrometheusRuleKind),
 	}); err != nil {
 		return nil, fmt.Errorf("cannot add prometheus_rule handler: %w", err)
 	}
@@ -127,6 +135,7 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 	if _, err := c.podInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.CreatePodMonitor,
 		UpdateFunc: c.UpdatePodMonitor,
+		DeleteFunc: c.deleteHandler(promv1.PodMonitorsKind),
 	}); err != nil {
 		return nil, fmt.Errorf("cannot add pod_monitor handler: %w", err)
 	}
@@ -153,6 +162,7 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 	if _, err := c.serviceInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.CreateServiceMonitor,
 		UpdateFunc: c.UpdateServiceMonitor,
+		DeleteFunc: c.deleteHandler(promv1.ServiceMonitorsKind),
 	}); err != nil {
 		return nil, fmt.Errorf("cannot add service_monitor handler: %w", err)
 	}
@@ -179,6 +189,7 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 	if _, err := amConfigInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.CreateAlertmanagerConfig,
 		UpdateFunc: c.UpdateAlertmanagerConfig,
+		DeleteFunc: c.deleteHandler(promv1alpha1.AlertmanagerConfigKind),
 	}); err != nil {
 		return nil, fmt.Errorf("cannot add alertmanager_config handler: %w", err)
 	}
@@ -206,6 +217,7 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 	if _, err := c.probeInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.CreateProbe,
 		UpdateFunc: c.UpdateProbe,
+		DeleteFunc: c.deleteHandler(promv1.ProbesKind),
 	}); err != nil {
 		return nil, fmt.Errorf("cannot add probe handler: %w", err)
 	}
@@ -231,6 +243,7 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 	if _, err := c.scrapeConfigInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.CreateScrapeConfig,
 		UpdateFunc: c.UpdateScrapeConfig,
+		DeleteFunc: c.deleteHandler(promv1alpha1.ScrapeConfigsKind),
 	}); err != nil {
 		return nil, fmt.Errorf("cannot add scrapeConfig handler: %w", err)
 	}
@@ -276,6 +289,7 @@ func (c *ConverterController) Start(ctx context.Context) error {
 	var errG errgroup.Group
 	converterLogger.Info("starting prometheus converter")
 	c.Run(ctx, &errG)
+	go c.runStatusWriter(ctx)
 	go func() {
 		converterLogger.Info("waiting for prometheus converter to stop")
 		err := errG.Wait()
@@ -324,6 +338,9 @@ func (c *ConverterController) Run(ctx context.Context, group *errgroup.Group) {
 // CreatePrometheusRule converts prometheus rule to vmrule
 func (c *ConverterController) CreatePrometheusRule(rule interface{}) {
 	promRule := rule.(*promv1.PrometheusRule)
+	if c.skipConversion(promv1.PrometheusRuleKind, promRule) {
+		return
+	}
 	l := converterLogger.WithValues("vmrule", promRule.Name, "namespace", promRule.Namespace)
 	cr := converter.ConvertPromRule(promRule, c.baseConf)
 
@@ -333,14 +350,19 @@ func (c *ConverterController) CreatePrometheusRule(rule interface{}) {
 			c.UpdatePrometheusRule(nil, promRule)
 			return
 		}
+		c.recordSyncResult(promv1.PrometheusRuleKind, promRule, err)
 		l.Error(err, "cannot create VMRule from PrometheusRule")
 		return
 	}
+	c.recordSyncResult(promv1.PrometheusRuleKind, promRule, nil)
 }
 
 // UpdatePrometheusRule updates vmrule
 func (c *ConverterController) UpdatePrometheusRule(_old, new interface{}) {
 	promRuleNew := new.(*promv1.PrometheusRule)
+	if c.skipConversion(promv1.PrometheusRuleKind, promRuleNew) {
+		return
+	}
 	l := converterLogger.WithValues("vmrule", promRuleNew.Name, "namespace", promRuleNew.Namespace)
 	vmRule := converter.ConvertPromRule(promRuleNew, c.baseConf)
 	ctx := context.Background()
@@ -349,14 +371,22 @@ func (c *ConverterController) UpdatePrometheusRule(_old, new interface{}) {
 	if err != nil {
 		if errors.IsNotFound(err) {
 			if err = c.rclient.Create(ctx, vmRule); err == nil {
+				c.recordSyncResult(promv1.PrometheusRuleKind, promRuleNew, nil)
 				return
 			}
 		}
+		c.recordSyncResult(promv1.PrometheusRuleKind, promRuleNew, err)
 		l.Error(err, "cannot get existing VMRule")
 		return
 	}
 	if existingVMRule.Annotations[IgnoreConversionLabel] == IgnoreConversion {
 		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
+		c.recordSyncSkipped(promv1.PrometheusRuleKind, promRuleNew, IgnoreConversionLabel)
+		return
+	}
+	if reason := ownershipConflict(existingVMRule, promv1.PrometheusRuleKind, promRuleNew); reason != "" {
+		l.Info("object is controlled by another owner, skipping sync", "reason", reason)
+		c.recordSyncConflict(promv1.PrometheusRuleKind, promRuleNew, reason)
 		return
 	}
 	metaMergeStrategy := getMetaMergeStrategy(existingVMRule.Annotations)
@@ -365,6 +395,7 @@ func (c *ConverterController) UpdatePrometheusRule(_old, new interface{}) {
 
 	if equality.Semantic.DeepEqual(vmRule.Spec, existingVMRule.Spec) &&
 		isMetaEqual(vmRule, existingVMRule) {
+		c.recordSyncResult(promv1.PrometheusRuleKind, promRuleNew, nil)
 		return
 	}
 	existingVMRule.Annotations = vmRule.Annotations
@@ -373,6 +404,7 @@ func (c *ConverterController) UpdatePrometheusRule(_old, new interface{}) {
 	existingVMRule.Spec = vmRule.Spec
 
 	err = c.rclient.Update(ctx, existingVMRule)
+	c.recordSyncResult(promv1.PrometheusRuleKind, promRuleNew, err)
 	if err != nil {
 		l.Error(err, "cannot update VMRule")
 		return
@@ -382,6 +414,9 @@ func (c *ConverterController) UpdatePrometheusRule(_old, new interface{}) {
 // CreateServiceMonitor converts ServiceMonitor to VMServiceScrape
 func (c *ConverterController) CreateServiceMonitor(service interface{}) {
 	serviceMon := service.(*promv1.ServiceMonitor)
+	if c.skipConversion(promv1.ServiceMonitorsKind, serviceMon) {
+		return
+	}
 
 	l := converterLogger.WithValues("vmservicescrape", serviceMon.Name, "namespace", serviceMon.Namespace)
 	vmServiceScrape := converter.ConvertServiceMonitor(serviceMon, c.baseConf)
@@ -391,14 +426,19 @@ func (c *ConverterController) CreateServiceMonitor(service interface{}) {
 			c.UpdateServiceMonitor(nil, serviceMon)
 			return
 		}
+		c.recordSyncResult(promv1.ServiceMonitorsKind, serviceMon, err)
 		l.Error(err, "cannot create VMServiceScrape")
 		return
 	}
+	c.recordSyncResult(promv1.ServiceMonitorsKind, serviceMon, nil)
 }
 
 // UpdateServiceMonitor updates VMServiceMonitor
 func (c *ConverterController) UpdateServiceMonitor(_, new interface{}) {
 	serviceMonNew := new.(*promv1.ServiceMonitor)
+	if c.skipConversion(promv1.ServiceMonitorsKind, serviceMonNew) {
+		return
+	}
 	l := converterLogger.WithValues("vmservicescrape", serviceMonNew.Name, "namespace", serviceMonNew.Namespace)
 	vmServiceScrape := converter.ConvertServiceMonitor(serviceMonNew, c.baseConf)
 	existingVMServiceScrape := &vmv1beta1.VMServiceScrape{}
@@ -407,15 +447,23 @@ func (c *ConverterController) UpdateServiceMonitor(_, new interface{}) {
 	if err != nil {
 		if errors.IsNotFound(err) {
 			if err = c.rclient.Create(ctx, vmServiceScrape); err == nil {
+				c.recordSyncResult(promv1.ServiceMonitorsKind, serviceMonNew, nil)
 				return
 			}
 		}
+		c.recordSyncResult(promv1.ServiceMonitorsKind, serviceMonNew, err)
 		l.Error(err, "cannot get existing VMServiceScrape")
 		return
 	}
 
 	if existingVMServiceScrape.Annotations[IgnoreConversionLabel] == IgnoreConversion {
 		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
+		c.recordSyncSkipped(promv1.ServiceMonitorsKind, serviceMonNew, IgnoreConversionLabel)
+		return
+	}
+	if reason := ownershipConflict(existingVMServiceScrape, promv1.ServiceMonitorsKind, serviceMonNew); reason != "" {
+		l.Info("object is controlled by another owner, skipping sync", "reason", reason)
+		c.recordSyncConflict(promv1.ServiceMonitorsKind, serviceMonNew, reason)
 		return
 	}
 
@@ -424,6 +472,7 @@ func (c *ConverterController) UpdateServiceMonitor(_, new interface{}) {
 	vmServiceScrape.Labels = mergeLabelsWithStrategy(existingVMServiceScrape.Labels, vmServiceScrape.Labels, metaMergeStrategy)
 	if equality.Semantic.DeepEqual(vmServiceScrape.Spec, existingVMServiceScrape.Spec) &&
 		isMetaEqual(vmServiceScrape, existingVMServiceScrape) {
+		c.recordSyncResult(promv1.ServiceMonitorsKind, serviceMonNew, nil)
 		return
 	}
 	existingVMServiceScrape.Annotations = vmServiceScrape.Annotations
@@ -432,6 +481,7 @@ func (c *ConverterController) UpdateServiceMonitor(_, new interface{}) {
 	existingVMServiceScrape.OwnerReferences = vmServiceScrape.OwnerReferences
 
 	err = c.rclient.Update(ctx, existingVMServiceScrape)
+	c.recordSyncResult(promv1.ServiceMonitorsKind, serviceMonNew, err)
 	if err != nil {
 		l.Error(err, "cannot update VMServiceScrape")
 		return
@@ -441,6 +491,9 @@ func (c *ConverterController) UpdateServiceMonitor(_, new interface{}) {
 // CreatePodMonitor converts PodMonitor to VMPodScrape
 func (c *ConverterController) CreatePodMonitor(pod interface{}) {
 	podMonitor := pod.(*promv1.PodMonitor)
+	if c.skipConversion(promv1.PodMonitorsKind, podMonitor) {
+		return
+	}
 	l := converterLogger.WithValues("vmpodscrape", podMonitor.Name, "namespace", podMonitor.Namespace)
 	podScrape := converter.ConvertPodMonitor(podMonitor, c.baseConf)
 	err := c.rclient.Create(c.ctx, podScrape)
@@ -449,14 +502,19 @@ func (c *ConverterController) CreatePodMonitor(pod interface{}) {
 			c.UpdatePodMonitor(nil, podMonitor)
 			return
 		}
+		c.recordSyncResult(promv1.PodMonitorsKind, podMonitor, err)
 		l.Error(err, "cannot create VMPodScrape")
 		return
 	}
+	c.recordSyncResult(promv1.PodMonitorsKind, podMonitor, nil)
 }
 
 // UpdatePodMonitor updates VMPodScrape
 func (c *ConverterController) UpdatePodMonitor(_, new interface{}) {
 	podMonitorNew := new.(*promv1.PodMonitor)
+	if c.skipConversion(promv1.PodMonitorsKind, podMonitorNew) {
+		return
+	}
 	l := converterLogger.WithValues("vmpodscrape", podMonitorNew.Name, "namespace", podMonitorNew.Namespace)
 	podScrape := converter.ConvertPodMonitor(podMonitorNew, c.baseConf)
 	ctx := context.Background()
@@ -465,14 +523,22 @@ func (c *ConverterController) UpdatePodMonitor(_, new interface{}) {
 	if err != nil {
 		if errors.IsNotFound(err) {
 			if err = c.rclient.Create(ctx, podScrape); err == nil {
+				c.recordSyncResult(promv1.PodMonitorsKind, podMonitorNew, nil)
 				return
 			}
 		}
+		c.recordSyncResult(promv1.PodMonitorsKind, podMonitorNew, err)
 		l.Error(err, "cannot get existing VMPodScrape")
 		return
 	}
 	if existingVMPodScrape.Annotations[IgnoreConversionLabel] == IgnoreConversion {
 		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
+		c.recordSyncSkipped(promv1.PodMonitorsKind, podMonitorNew, IgnoreConversionLabel)
+		return
+	}
+	if reason := ownershipConflict(existingVMPodScrape, promv1.PodMonitorsKind, podMonitorNew); reason != "" {
+		l.Info("object is controlled by another owner, skipping sync", "reason", reason)
+		c.recordSyncConflict(promv1.PodMonitorsKind, podMonitorNew, reason)
 		return
 	}
 
@@ -481,6 +547,7 @@ func (c *ConverterController) UpdatePodMonitor(_, new interface{}) {
 	podScrape.Labels = mergeLabelsWithStrategy(existingVMPodScrape.Labels, podScrape.Labels, mergeStrategy)
 	if equality.Semantic.DeepEqual(podScrape.Spec, existingVMPodScrape.Spec) &&
 		isMetaEqual(podScrape, existingVMPodScrape) {
+		c.recordSyncResult(promv1.PodMonitorsKind, podMonitorNew, nil)
 		return
 	}
 	existingVMPodScrape.Annotations = podScrape.Annotations
@@ -489,6 +556,7 @@ func (c *ConverterController) UpdatePodMonitor(_, new interface{}) {
 	existingVMPodScrape.OwnerReferences = podScrape.OwnerReferences
 
 	err = c.rclient.Update(ctx, existingVMPodScrape)
+	c.recordSyncResult(promv1.PodMonitorsKind, podMonitorNew, err)
 	if err != nil {
 		l.Error(err, "cannot update VMPodScrape")
 		return
@@ -498,14 +566,22 @@ func (c *ConverterController) UpdatePodMonitor(_, new interface{}) {
 // CreateAlertmanagerConfig converts AlertmanagerConfig to VMAlertmanagerConfig
 func (c *ConverterController) CreateAlertmanagerConfig(new interface{}) {
 	var vmAMc *vmv1beta1.VMAlertmanagerConfig
+	var src metav1.Object
 	var err error
 	switch promAMc := new.(type) {
 	case *promv1alpha1.AlertmanagerConfig:
+		if c.skipConversion(promv1alpha1.AlertmanagerConfigKind, promAMc) {
+			return
+		}
 		vmAMc, err = converterv1alpha1.ConvertAlertmanagerConfig(promAMc, c.baseConf)
+		src = promAMc
 	default:
 		err = fmt.Errorf("BUG: scrape config of type %T is not supported", promAMc)
 	}
 	if err != nil {
+		if src != nil {
+			c.recordSyncResult(promv1alpha1.AlertmanagerConfigKind, src, err)
+		}
 		converterLogger.Error(err, "cannot convert alertmanager config")
 		return
 	}
@@ -515,22 +591,32 @@ func (c *ConverterController) CreateAlertmanagerConfig(new interface{}) {
 			c.UpdateAlertmanagerConfig(nil, new)
 			return
 		}
+		c.recordSyncResult(promv1alpha1.AlertmanagerConfigKind, src, err)
 		l.Error(err, "cannot create VMAlertmanagerConfig")
 		return
 	}
+	c.recordSyncResult(promv1alpha1.AlertmanagerConfigKind, src, nil)
 }
 
 // UpdateAlertmanagerConfig updates VMAlertmanagerConfig
 func (c *ConverterController) UpdateAlertmanagerConfig(_, new interface{}) {
 	var vmAMc *vmv1beta1.VMAlertmanagerConfig
+	var src metav1.Object
 	var err error
 	switch promAMc := new.(type) {
 	case *promv1alpha1.AlertmanagerConfig:
+		if c.skipConversion(promv1alpha1.AlertmanagerConfigKind, promAMc) {
+			return
+		}
 		vmAMc, err = converterv1alpha1.ConvertAlertmanagerConfig(promAMc, c.baseConf)
+		src = promAMc
 	default:
 		err = fmt.Errorf("BUG: alertmanager config of type %T is not supported", new)
 	}
 	if err != nil {
+		if src != nil {
+			c.recordSyncResult(promv1alpha1.AlertmanagerConfigKind, src, err)
+		}
 		converterLogger.Error(err, "cannot convert alertmanager config at update")
 		return
 	}
@@ -540,15 +626,23 @@ func (c *ConverterController) UpdateAlertmanagerConfig(_, new interface{}) {
 	if err := c.rclient.Get(ctx, types.NamespacedName{Name: vmAMc.Name, Namespace: vmAMc.Namespace}, existAlertmanagerConfig); err != nil {
 		if errors.IsNotFound(err) {
 			if err = c.rclient.Create(ctx, vmAMc); err == nil {
+				c.recordSyncResult(promv1alpha1.AlertmanagerConfigKind, src, nil)
 				return
 			}
 		}
+		c.recordSyncResult(promv1alpha1.AlertmanagerConfigKind, src, err)
 		l.Error(err, "cannot get existing VMAlertmanagerConfig")
 		return
 	}
 
 	if existAlertmanagerConfig.Annotations[IgnoreConversionLabel] == IgnoreConversion {
 		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
+		c.recordSyncSkipped(promv1alpha1.AlertmanagerConfigKind, src, IgnoreConversionLabel)
+		return
+	}
+	if reason := ownershipConflict(existAlertmanagerConfig, promv1alpha1.AlertmanagerConfigKind, src); reason != "" {
+		l.Info("object is controlled by another owner, skipping sync", "reason", reason)
+		c.recordSyncConflict(promv1alpha1.AlertmanagerConfigKind, src, reason)
 		return
 	}
 
@@ -557,6 +651,7 @@ func (c *ConverterController) UpdateAlertmanagerConfig(_, new interface{}) {
 	vmAMc.Labels = mergeLabelsWithStrategy(existAlertmanagerConfig.Labels, vmAMc.Labels, metaMergeStrategy)
 	if equality.Semantic.DeepEqual(vmAMc.Spec, existAlertmanagerConfig.Spec) &&
 		isMetaEqual(vmAMc, existAlertmanagerConfig) {
+		c.recordSyncResult(promv1alpha1.AlertmanagerConfigKind, src, nil)
 		return
 	}
 
@@ -566,6 +661,7 @@ func (c *ConverterController) UpdateAlertmanagerConfig(_, new interface{}) {
 	existAlertmanagerConfig.Spec = vmAMc.Spec
 
 	err = c.rclient.Update(ctx, existAlertmanagerConfig)
+	c.recordSyncResult(promv1alpha1.AlertmanagerConfigKind, src, err)
 	if err != nil {
 		l.Error(err, "cannot update exist VMAlertmanagerConfig")
 		return
@@ -614,6 +710,9 @@ func getMetaMergeStrategy(vmMeta map[string]string) string {
 // CreateProbe converts Probe to VMProbe
 func (c *ConverterController) CreateProbe(obj interface{}) {
 	probe := obj.(*promv1.Probe)
+	if c.skipConversion(promv1.ProbesKind, probe) {
+		return
+	}
 	l := converterLogger.WithValues("vmprobe", probe.Name, "namespace", probe.Namespace)
 	vmProbe := converter.ConvertProbe(probe, c.baseConf)
 	err := c.rclient.Create(c.ctx, vmProbe)
@@ -622,14 +721,19 @@ func (c *ConverterController) CreateProbe(obj interface{}) {
 			c.UpdateProbe(nil, probe)
 			return
 		}
+		c.recordSyncResult(promv1.ProbesKind, probe, err)
 		l.Error(err, "cannot create VMProbe")
 		return
 	}
+	c.recordSyncResult(promv1.ProbesKind, probe, nil)
 }
 
 // UpdateProbe updates VMProbe
 func (c *ConverterController) UpdateProbe(_, new interface{}) {
 	probeNew := new.(*promv1.Probe)
+	if c.skipConversion(promv1.ProbesKind, probeNew) {
+		return
+	}
 	l := converterLogger.WithValues("vmprobe", probeNew.Name, "namespace", probeNew.Namespace)
 	vmProbe := converter.ConvertProbe(probeNew, c.baseConf)
 	ctx := context.Background()
@@ -638,14 +742,22 @@ func (c *ConverterController) UpdateProbe(_, new interface{}) {
 	if err != nil {
 		if errors.IsNotFound(err) {
 			if err = c.rclient.Create(ctx, vmProbe); err == nil {
+				c.recordSyncResult(promv1.ProbesKind, probeNew, nil)
 				return
 			}
 		}
+		c.recordSyncResult(promv1.ProbesKind, probeNew, err)
 		l.Error(err, "cannot get existing VMProbe")
 		return
 	}
 	if existingVMProbe.Annotations[IgnoreConversionLabel] == IgnoreConversion {
 		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
+		c.recordSyncSkipped(promv1.ProbesKind, probeNew, IgnoreConversionLabel)
+		return
+	}
+	if reason := ownershipConflict(existingVMProbe, promv1.ProbesKind, probeNew); reason != "" {
+		l.Info("object is controlled by another owner, skipping sync", "reason", reason)
+		c.recordSyncConflict(promv1.ProbesKind, probeNew, reason)
 		return
 	}
 
@@ -654,6 +766,7 @@ func (c *ConverterController) UpdateProbe(_, new interface{}) {
 	vmProbe.Labels = mergeLabelsWithStrategy(existingVMProbe.Labels, vmProbe.Labels, mergeStrategy)
 	if equality.Semantic.DeepEqual(vmProbe.Spec, existingVMProbe.Spec) &&
 		isMetaEqual(vmProbe, existingVMProbe) {
+		c.recordSyncResult(promv1.ProbesKind, probeNew, nil)
 		return
 	}
 
@@ -662,6 +775,7 @@ func (c *ConverterController) UpdateProbe(_, new interface{}) {
 	existingVMProbe.OwnerReferences = vmProbe.OwnerReferences
 	existingVMProbe.Spec = vmProbe.Spec
 	err = c.rclient.Update(ctx, existingVMProbe)
+	c.recordSyncResult(promv1.ProbesKind, probeNew, err)
 	if err != nil {
 		l.Error(err, "cannot update VMProbe")
 		return
@@ -671,10 +785,15 @@ func (c *ConverterController) UpdateProbe(_, new interface{}) {
 // CreateScrapeConfig converts ServiceMonitor to VMScrapeConfig
 func (c *ConverterController) CreateScrapeConfig(scrapeConfig interface{}) {
 	var vmScrapeConfig *vmv1beta1.VMScrapeConfig
+	var src metav1.Object
 	var err error
 	switch promScrapeConfig := scrapeConfig.(type) {
 	case *promv1alpha1.ScrapeConfig:
+		if c.skipConversion(promv1alpha1.ScrapeConfigsKind, promScrapeConfig) {
+			return
+		}
 		vmScrapeConfig = converterv1alpha1.ConvertScrapeConfig(promScrapeConfig, c.baseConf)
+		src = promScrapeConfig
 	default:
 		err = fmt.Errorf("BUG: scrape config of type %T is not supported", promScrapeConfig)
 		converterLogger.Error(err, "cannot parse promscrapeConfig for create")
@@ -687,18 +806,25 @@ func (c *ConverterController) CreateScrapeConfig(scrapeConfig interface{}) {
 			c.UpdateScrapeConfig(nil, scrapeConfig)
 			return
 		}
+		c.recordSyncResult(promv1alpha1.ScrapeConfigsKind, src, err)
 		l.Error(err, "cannot create vmScrapeConfig")
 		return
 	}
+	c.recordSyncResult(promv1alpha1.ScrapeConfigsKind, src, nil)
 }
 
 // UpdateScrapeConfig updates VMScrapeConfig
 func (c *ConverterController) UpdateScrapeConfig(_, newObj interface{}) {
 	var vmScrapeConfig *vmv1beta1.VMScrapeConfig
+	var src metav1.Object
 	var err error
 	switch promScrapeConfig := newObj.(type) {
 	case *promv1alpha1.ScrapeConfig:
+		if c.skipConversion(promv1alpha1.ScrapeConfigsKind, promScrapeConfig) {
+			return
+		}
 		vmScrapeConfig = converterv1alpha1.ConvertScrapeConfig(promScrapeConfig, c.baseConf)
+		src = promScrapeConfig
 	default:
 		err = fmt.Errorf("BUG: scrape config of type %T is not supported", promScrapeConfig)
 		converterLogger.Error(err, "cannot parse promScrapeConfig for update")
@@ -711,15 +837,23 @@ func (c *ConverterController) UpdateScrapeConfig(_, newObj interface{}) {
 	if err != nil {
 		if errors.IsNotFound(err) {
 			if err = c.rclient.Create(ctx, vmScrapeConfig); err == nil {
+				c.recordSyncResult(promv1alpha1.ScrapeConfigsKind, src, nil)
 				return
 			}
 		}
+		c.recordSyncResult(promv1alpha1.ScrapeConfigsKind, src, err)
 		l.Error(err, "cannot get existing VMScrapeConfig")
 		return
 	}
 
 	if existingVMScrapeConfig.Annotations[IgnoreConversionLabel] == IgnoreConversion {
 		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
+		c.recordSyncSkipped(promv1alpha1.ScrapeConfigsKind, src, IgnoreConversionLabel)
+		return
+	}
+	if reason := ownershipConflict(existingVMScrapeConfig, promv1alpha1.ScrapeConfigsKind, src); reason != "" {
+		l.Info("object is controlled by another owner, skipping sync", "reason", reason)
+		c.recordSyncConflict(promv1alpha1.ScrapeConfigsKind, src, reason)
 		return
 	}
 	metaMergeStrategy := getMetaMergeStrategy(existingVMScrapeConfig.Annotations)
@@ -728,6 +862,7 @@ func (c *ConverterController) UpdateScrapeConfig(_, newObj interface{}) {
 
 	if equality.Semantic.DeepEqual(vmScrapeConfig.Spec, existingVMScrapeConfig.Spec) &&
 		isMetaEqual(vmScrapeConfig, existingVMScrapeConfig) {
+		c.recordSyncResult(promv1alpha1.ScrapeConfigsKind, src, nil)
 		return
 	}
 	existingVMScrapeConfig.Labels = vmScrapeConfig.Labels
@@ -735,12 +870,22 @@ func (c *ConverterController) UpdateScrapeConfig(_, newObj interface{}) {
 	existingVMScrapeConfig.OwnerReferences = vmScrapeConfig.OwnerReferences
 	existingVMScrapeConfig.Spec = vmScrapeConfig.Spec
 	err = c.rclient.Update(ctx, existingVMScrapeConfig)
+	c.recordSyncResult(promv1alpha1.ScrapeConfigsKind, src, err)
 	if err != nil {
 		l.Error(err, "cannot update VMScrapeConfig")
 		return
 	}
 }
 
+// skipConversion checks if conversion of prometheus object was disabled by annotation
+func (c *ConverterController) skipConversion(kind string, src metav1.Object) bool {
+	if src.GetAnnotations()[SkipConversionLabel] != IgnoreConversion {
+		return false
+	}
+	c.recordSyncSkipped(kind, src, SkipConversionLabel)
+	return true
+}
+
 func isMetaEqual(left, right metav1.Object) bool {
 	return equality.Semantic.DeepEqual(left.GetLabels(), right.GetLabels()) &&
 		equality.Semantic.DeepEqual(left.GetAnnotations(), right.GetAnnotations()) &&
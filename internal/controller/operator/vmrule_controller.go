@@ -41,7 +41,7 @@ type VMRuleReconciler struct {
 }
 
 // Init implements crdController interface
-func (r *VMRuleReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMRuleReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMRule")
 	r.OriginScheme = sc
@@ -84,7 +84,7 @@ func (r *VMRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 	}
 
 	for _, vmalertItem := range objects.Items {
-		if vmalertItem.DeletionTimestamp != nil || vmalertItem.Spec.ParsingError != "" {
+		if vmalertItem.DeletionTimestamp != nil || vmalertItem.Paused() || vmalertItem.Spec.ParsingError != "" || !isOwnedByShard(client.ObjectKeyFromObject(&vmalertItem)) {
 			continue
 		}
 		currVMAlert := &vmalertItem
@@ -118,6 +118,6 @@ func (r *VMRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1beta1.VMRule{}).
 		WithEventFilter(predicate.TypedGenerationChangedPredicate[client.Object]{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vmrule")).
+		Complete(withReconcileMetrics("vmrule", r))
 }
@@ -41,7 +41,7 @@ type VMScrapeConfigReconciler struct {
 }
 
 // Init implements crdController interface
-func (r *VMScrapeConfigReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMScrapeConfigReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMScrapeConfig")
 	r.OriginScheme = sc
@@ -83,7 +83,7 @@ func (r *VMScrapeConfigReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 
 	for _, vmagentItem := range objects.Items {
-		if !vmagentItem.DeletionTimestamp.IsZero() || vmagentItem.Spec.ParsingError != "" || vmagentItem.IsScrapeConfigUnmanaged() {
+		if !vmagentItem.DeletionTimestamp.IsZero() || vmagentItem.Paused() || !isOwnedByShard(client.ObjectKeyFromObject(&vmagentItem)) || vmagentItem.Spec.ParsingError != "" || vmagentItem.IsScrapeConfigUnmanaged() {
 			continue
 		}
 		currentVMagent := &vmagentItem
@@ -115,6 +115,6 @@ func (r *VMScrapeConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1beta1.VMScrapeConfig{}).
 		WithEventFilter(predicate.TypedGenerationChangedPredicate[client.Object]{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vmscrapeconfig")).
+		Complete(withReconcileMetrics("vmscrapeconfig", r))
 }
@@ -41,7 +41,7 @@ type VMServiceScrapeReconciler struct {
 }
 
 // Init implements crdController interface
-func (r *VMServiceScrapeReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMServiceScrapeReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMServiceScrape")
 	r.OriginScheme = sc
@@ -84,7 +84,7 @@ func (r *VMServiceScrapeReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 
 	for _, vmagentItem := range objects.Items {
-		if !vmagentItem.DeletionTimestamp.IsZero() || vmagentItem.Spec.ParsingError != "" || vmagentItem.IsServiceScrapeUnmanaged() {
+		if !vmagentItem.DeletionTimestamp.IsZero() || vmagentItem.Paused() || !isOwnedByShard(client.ObjectKeyFromObject(&vmagentItem)) || vmagentItem.Spec.ParsingError != "" || vmagentItem.IsServiceScrapeUnmanaged() {
 			continue
 		}
 		currentVMagent := &vmagentItem
@@ -115,6 +115,6 @@ func (r *VMServiceScrapeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1beta1.VMServiceScrape{}).
 		WithEventFilter(predicate.TypedGenerationChangedPredicate[client.Object]{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vmservicescrape")).
+		Complete(withReconcileMetrics("vmservicescrape", r))
 }
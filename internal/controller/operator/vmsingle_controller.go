@@ -27,6 +27,7 @@ import (
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmsingle"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -38,15 +39,13 @@ type VMSingleReconciler struct {
 	client.Client
 	Log          logr.Logger
 	OriginScheme *runtime.Scheme
-	BaseConf     *config.BaseOperatorConf
 }
 
 // Init implements crdController interface
-func (r *VMSingleReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMSingleReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMSingle")
 	r.OriginScheme = sc
-	r.BaseConf = cf
 }
 
 // Scheme implements interface.
@@ -61,6 +60,8 @@ func (r *VMSingleReconciler) Scheme() *runtime.Scheme {
 // +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=*
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=*
 // +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmsingles/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmbackuplocations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=*
 func (r *VMSingleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	reqLogger := r.Log.WithValues("vmsingle", req.Name, "namespace", req.Namespace)
 	ctx = logger.AddToContext(ctx, reqLogger)
@@ -76,8 +77,9 @@ func (r *VMSingleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 
 	RegisterObjectStat(instance, "vmsingle")
 	if !instance.DeletionTimestamp.IsZero() {
+		RegisterBackupVerificationStat(instance, "vmsingle", nil)
 		if err := finalize.OnVMSingleDelete(ctx, r.Client, instance); err != nil {
-			return result, err
+			return result, &finalizeError{err, "vmsingle"}
 		}
 		return
 	}
@@ -88,6 +90,13 @@ func (r *VMSingleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 		return result, err
 	}
 	r.Client.Scheme().Default(instance)
+	// retention decrease must be checked before last applied spec update
+	// otherwise it'll be applied on the next reconcile without confirmation
+	if !instance.Paused() {
+		if err := instance.CheckRetentionDecrease(); err != nil {
+			return result, err
+		}
+	}
 
 	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
 		if err = vmsingle.CreateOrUpdateVMSingle(ctx, instance, r); err != nil {
@@ -98,7 +107,16 @@ func (r *VMSingleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 	if err != nil {
 		return
 	}
-	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+	// restore and backup verification statuses must be updated after status tracking
+	// otherwise it could be overwritten by the status of tracked object copy
+	if err = vmsingle.UpdateRestoreStatus(ctx, r.Client, instance); err != nil {
+		return
+	}
+	if err = vmsingle.UpdateBackupVerificationStatus(ctx, r.Client, instance); err != nil {
+		return
+	}
+	RegisterBackupVerificationStat(instance, "vmsingle", instance.Status.BackupVerification)
+	result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
 
 	return
 }
@@ -108,7 +126,8 @@ func (r *VMSingleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1beta1.VMSingle{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&batchv1.CronJob{}).
 		Owns(&corev1.ServiceAccount{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vmsingle")).
+		Complete(withReconcileMetrics("vmsingle", withSharding(r)))
 }
@@ -0,0 +1,100 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmsnapshot"
+)
+
+// VMSnapshotReconciler reconciles a VMSnapshot object
+type VMSnapshotReconciler struct {
+	client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+}
+
+// Init implements crdController interface
+func (r *VMSnapshotReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
+	r.Client = rclient
+	r.Log = l.WithName("controller.VMSnapshot")
+	r.OriginScheme = sc
+}
+
+// Scheme implements interface.
+func (r *VMSnapshotReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile general reconcile method for controller
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmsnapshots,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmsnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmsnapshots/finalizers,verbs=*
+func (r *VMSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	reqLogger := r.Log.WithValues("vmsnapshot", req.Name, "namespace", req.Namespace)
+	ctx = logger.AddToContext(ctx, reqLogger)
+	instance := &vmv1beta1.VMSnapshot{}
+
+	defer func() {
+		result, err = handleReconcileErr(ctx, r.Client, instance, result, err)
+	}()
+
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return result, &getError{err, "vmsnapshot", req}
+	}
+
+	RegisterObjectStat(instance, "vmsnapshot")
+	if !instance.DeletionTimestamp.IsZero() {
+		if instance.Spec.DeleteSnapshotsOnRemove {
+			if err := vmsnapshot.DeleteAll(ctx, instance, r.Client); err != nil {
+				return result, fmt.Errorf("cannot delete snapshots: %w", err)
+			}
+		}
+		if err := finalize.OnVMSnapshotDelete(ctx, r.Client, instance); err != nil {
+			return result, &finalizeError{err, "vmsnapshot"}
+		}
+		return
+	}
+	if instance.Spec.ParsingError != "" {
+		return result, &parsingError{instance.Spec.ParsingError, "vmsnapshot"}
+	}
+	if err := finalize.AddFinalizer(ctx, r.Client, instance); err != nil {
+		return result, err
+	}
+
+	// snapshots are tracked at the object status,
+	// so the same object must be used for status updates
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance, func() (ctrl.Result, error) {
+		nextRun, err := vmsnapshot.CreateOrUpdate(ctx, r.Client, instance)
+		if err != nil {
+			return result, fmt.Errorf("failed to reconcile vmsnapshot: %w", err)
+		}
+		result.RequeueAfter = nextRun
+		return result, nil
+	})
+	if err != nil {
+		return
+	}
+	if resync := config.MustGetBaseConfig().ResyncAfterDuration(); resync > 0 && (result.RequeueAfter == 0 || resync < result.RequeueAfter) {
+		result.RequeueAfter = resync
+	}
+
+	return
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VMSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VMSnapshot{}).
+		WithOptions(getDefaultOptions("vmsnapshot")).
+		Complete(withReconcileMetrics("vmsnapshot", withSharding(r)))
+}
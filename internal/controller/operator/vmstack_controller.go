@@ -0,0 +1,96 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmstack"
+)
+
+// VMStackReconciler reconciles a VMStack object
+type VMStackReconciler struct {
+	client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+}
+
+// Init implements crdController interface
+func (r *VMStackReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
+	r.Client = rclient
+	r.Log = l.WithName("controller.VMStack")
+	r.OriginScheme = sc
+}
+
+// Scheme implements interface.
+func (r *VMStackReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile general reconcile method for controller
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmstacks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmstacks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmstacks/finalizers,verbs=*
+func (r *VMStackReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	reqLogger := r.Log.WithValues("vmstack", req.Name, "namespace", req.Namespace)
+	ctx = logger.AddToContext(ctx, reqLogger)
+	instance := &vmv1beta1.VMStack{}
+
+	defer func() {
+		result, err = handleReconcileErr(ctx, r.Client, instance, result, err)
+	}()
+
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return result, &getError{err, "vmstack", req}
+	}
+
+	RegisterObjectStat(instance, "vmstack")
+	if !instance.DeletionTimestamp.IsZero() {
+		if err := finalize.OnVMStackDelete(ctx, r.Client, instance); err != nil {
+			return result, &finalizeError{err, "vmstack"}
+		}
+		return
+	}
+	if instance.Spec.ParsingError != "" {
+		return result, &parsingError{instance.Spec.ParsingError, "vmstack"}
+	}
+	if err := finalize.AddFinalizer(ctx, r.Client, instance); err != nil {
+		return result, err
+	}
+	r.Client.Scheme().Default(instance)
+
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
+		if err := vmstack.CreateOrUpdate(ctx, r.Client, instance); err != nil {
+			return result, fmt.Errorf("failed to reconcile vmstack: %w", err)
+		}
+		return result, nil
+	})
+	if err != nil {
+		return
+	}
+	result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
+
+	return
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VMStackReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VMStack{}).
+		Owns(&vmv1beta1.VMSingle{}).
+		Owns(&vmv1beta1.VMCluster{}).
+		Owns(&vmv1beta1.VMAgent{}).
+		Owns(&vmv1beta1.VMAlert{}).
+		Owns(&vmv1beta1.VMAlertmanager{}).
+		Owns(&vmv1beta1.VMRule{}).
+		WithOptions(getDefaultOptions("vmstack")).
+		Complete(withReconcileMetrics("vmstack", withSharding(r)))
+}
@@ -19,10 +19,13 @@ package operator
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -45,7 +48,7 @@ type VMUserReconciler struct {
 }
 
 // Init implements crdController interface
-func (r *VMUserReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+func (r *VMUserReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme) {
 	r.Client = rclient
 	r.Log = l.WithName("controller.VMUser")
 	r.OriginScheme = sc
@@ -85,6 +88,15 @@ func (r *VMUserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 		}
 	}
 
+	if instance.Spec.PasswordRotation != nil && instance.DeletionTimestamp.IsZero() {
+		// password is rotated during vmauth config generation, schedule it in advance
+		var userSecret v1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.SecretName()}, &userSecret); err != nil && !errors.IsNotFound(err) {
+			return result, fmt.Errorf("cannot get vmuser secret: %w", err)
+		}
+		result.RequeueAfter = vmauth.PasswordRotationRequeueAfter(&userSecret, &instance, time.Now())
+	}
+
 	if vmauthRateLimiter.MustThrottleReconcile() {
 		return
 	}
@@ -96,7 +108,7 @@ func (r *VMUserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 	}
 
 	for _, vmauthItem := range vmauthes.Items {
-		if !vmauthItem.DeletionTimestamp.IsZero() || vmauthItem.Spec.ParsingError != "" || vmauthItem.IsUnmanaged() {
+		if !vmauthItem.DeletionTimestamp.IsZero() || vmauthItem.Paused() || !isOwnedByShard(client.ObjectKeyFromObject(&vmauthItem)) || vmauthItem.Spec.ParsingError != "" || vmauthItem.IsUnmanaged() {
 			continue
 		}
 		// reconcile users for given vmauth.
@@ -129,6 +141,6 @@ func (r *VMUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&vmv1beta1.VMUser{}).
 		Owns(&v1.Secret{}, builder.OnlyMetadata).
 		WithEventFilter(predicate.TypedGenerationChangedPredicate[client.Object]{}).
-		WithOptions(getDefaultOptions()).
-		Complete(r)
+		WithOptions(getDefaultOptions("vmuser")).
+		Complete(withReconcileMetrics("vmuser", r))
 }
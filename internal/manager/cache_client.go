@@ -0,0 +1,84 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// selectorFallbackClient reads objects restricted by -controller.cacheLabelSelector directly from API server
+// if they're missing at cache.
+// Objects generated by operator and objects referenced by CRDs could have no labels matching the selector,
+// but operator must be able to read them in order to properly update and garbage collect.
+type selectorFallbackClient struct {
+	client.Client
+	apiReader client.Reader
+	// restricted holds types of objects and lists restricted by cache label selector
+	restricted map[reflect.Type]struct{}
+}
+
+// newSelectorFallbackClientFunc returns client constructor for manager,
+// which falls back to API server reads for the given objects
+func newSelectorFallbackClientFunc(objects []client.Object) client.NewClientFunc {
+	return func(config *rest.Config, options client.Options) (client.Client, error) {
+		c, err := client.New(config, options)
+		if err != nil {
+			return nil, err
+		}
+		apiReader, err := client.New(config, client.Options{Scheme: options.Scheme, Mapper: options.Mapper})
+		if err != nil {
+			return nil, fmt.Errorf("cannot build API server reader: %w", err)
+		}
+		return newSelectorFallbackClient(c, apiReader, options.Scheme, objects)
+	}
+}
+
+func newSelectorFallbackClient(c client.Client, apiReader client.Reader, scheme *runtime.Scheme, objects []client.Object) (*selectorFallbackClient, error) {
+	restricted := make(map[reflect.Type]struct{}, len(objects)*2)
+	for _, o := range objects {
+		restricted[reflect.TypeOf(o)] = struct{}{}
+		gvk, err := c.GroupVersionKindFor(o)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get kind of object: %w", err)
+		}
+		gvk.Kind += "List"
+		list, err := scheme.New(gvk)
+		if err != nil {
+			return nil, fmt.Errorf("cannot build list for kind=%s: %w", gvk.Kind, err)
+		}
+		restricted[reflect.TypeOf(list)] = struct{}{}
+	}
+	return &selectorFallbackClient{
+		Client:     c,
+		apiReader:  apiReader,
+		restricted: restricted,
+	}, nil
+}
+
+func (c *selectorFallbackClient) isRestricted(obj runtime.Object) bool {
+	_, ok := c.restricted[reflect.TypeOf(obj)]
+	return ok
+}
+
+// Get implements client.Reader interface
+func (c *selectorFallbackClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Client.Get(ctx, key, obj, opts...)
+	if k8serrors.IsNotFound(err) && c.isRestricted(obj) {
+		return c.apiReader.Get(ctx, key, obj, opts...)
+	}
+	return err
+}
+
+// List implements client.Reader interface
+// restricted objects are always listed from API server, since cache may contain only part of them
+func (c *selectorFallbackClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if c.isRestricted(list) {
+		return c.apiReader.List(ctx, list, opts...)
+	}
+	return c.Client.List(ctx, list, opts...)
+}
@@ -0,0 +1,45 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSelectorFallbackClient(t *testing.T) {
+	ctx := context.Background()
+	generated := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "vmagent-main", Namespace: "default"}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "vmagent-main-0", Namespace: "default"}}
+	// cache contains only objects matching label selector
+	cached := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+	apiReader := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(generated, pod).Build()
+
+	c, err := newSelectorFallbackClient(cached, apiReader, clientgoscheme.Scheme, []client.Object{&corev1.Secret{}})
+	assert.NoError(t, err)
+
+	// restricted object is read from API server
+	var secret corev1.Secret
+	assert.NoError(t, c.Get(ctx, types.NamespacedName{Name: "vmagent-main", Namespace: "default"}, &secret))
+	var secrets corev1.SecretList
+	assert.NoError(t, c.List(ctx, &secrets, client.InNamespace("default")))
+	assert.Len(t, secrets.Items, 1)
+
+	// missing restricted object
+	err = c.Get(ctx, types.NamespacedName{Name: "missing", Namespace: "default"}, &secret)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// not restricted object is read from cache only
+	err = c.Get(ctx, types.NamespacedName{Name: "vmagent-main-0", Namespace: "default"}, &corev1.Pod{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	var pods corev1.PodList
+	assert.NoError(t, c.List(ctx, &pods))
+	assert.Empty(t, pods.Items)
+}
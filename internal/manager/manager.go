@@ -16,27 +16,34 @@ import (
 	"time"
 
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/buildinfo"
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
 	vmcontroller "github.com/VictoriaMetrics/operator/internal/controller/operator"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/tracing"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmagent"
 	"github.com/go-logr/logr"
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap/zapcore"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/rest"
 	restmetrics "k8s.io/client-go/tools/metrics"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/klog/v2"
@@ -45,14 +52,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	// +kubebuilder:scaffold:imports
 )
 
 const defaultMetricsAddr = ":8080"
 const defaultWebhookPort = 9443
+const operatorServiceScrapeSyncInterval = 5 * time.Minute
 
 var versionRe = regexp.MustCompile(`v\d+\.\d+\.\d+(?:-enterprise)?(?:-cluster)?`)
 
@@ -69,21 +79,24 @@ var (
 	startedAt = prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: "vm_app_start_timestamp", Help: "unixtimestamp"}, func() float64 {
 		return float64(startTime.Unix())
 	})
-	scheme              = runtime.NewScheme()
-	setupLog            = ctrl.Log.WithName("setup")
-	leaderElect         = managerFlags.Bool("leader-elect", false, "Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
-	enableWebhooks      = managerFlags.Bool("webhook.enable", false, "adds webhook server, you must mount cert and key or use cert-manager")
-	webhookPort         = managerFlags.Int("webhook.port", defaultWebhookPort, "port to start webhook server on")
-	disableCRDOwnership = managerFlags.Bool("controller.disableCRDOwnership", false, "disables CRD ownership add to cluster wide objects, must be disabled for clusters, lower than v1.16.0")
-	webhooksDir         = managerFlags.String("webhook.certDir", "/tmp/k8s-webhook-server/serving-certs/", "root directory for webhook cert and key")
-	webhookCertName     = managerFlags.String("webhook.certName", "tls.crt", "name of webhook server Tls certificate inside tls.certDir")
-	webhookKeyName      = managerFlags.String("webhook.keyName", "tls.key", "name of webhook server Tls key inside tls.certDir")
-	tlsEnable           = managerFlags.Bool("tls.enable", false, "enables secure tls (https) for metrics webserver.")
-	tlsCertsDir         = managerFlags.String("tls.certDir", "/tmp/k8s-metrics-server/serving-certs", "root directory for metrics webserver cert, key and mTLS CA.")
-	tlsCertName         = managerFlags.String("tls.certName", "tls.crt", "name of metric server Tls certificate inside tls.certDir. Default - ")
-	tlsKeyName          = managerFlags.String("tls.keyName", "tls.key", "name of metric server Tls key inside tls.certDir. Default - tls.key")
-	mtlsEnable          = managerFlags.Bool("mtls.enable", false, "Whether to require valid client certificate for https requests to the corresponding -metrics-bind-address. This flag works only if -tls.enable flag is set. ")
-	mtlsCAFile          = managerFlags.String("mtls.CAName", "clietCA.crt", "Optional name of TLS Root CA for verifying client certificates at the corresponding -metrics-bind-address when -mtls.enable is enabled. "+
+	scheme                        = runtime.NewScheme()
+	setupLog                      = ctrl.Log.WithName("setup")
+	leaderElect                   = managerFlags.Bool("leader-elect", false, "Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
+	enableWebhooks                = managerFlags.Bool("webhook.enable", false, "adds webhook server, you must mount cert and key or use cert-manager")
+	webhookPort                   = managerFlags.Int("webhook.port", defaultWebhookPort, "port to start webhook server on")
+	disableCRDOwnership           = managerFlags.Bool("controller.disableCRDOwnership", false, "disables CRD ownership add to cluster wide objects, must be disabled for clusters, lower than v1.16.0")
+	webhooksDir                   = managerFlags.String("webhook.certDir", "/tmp/k8s-webhook-server/serving-certs/", "root directory for webhook cert and key")
+	webhookCertName               = managerFlags.String("webhook.certName", "tls.crt", "name of webhook server Tls certificate inside tls.certDir")
+	webhookKeyName                = managerFlags.String("webhook.keyName", "tls.key", "name of webhook server Tls key inside tls.certDir")
+	webhookDefaulting             = managerFlags.Bool("webhook.enableDefaulting", false, "enables defaulting of objects at admission webhook. It fills operator defaults, like images and resources, into objects spec and prevents drift reported by GitOps tools. Note, default versions of images are stored at objects and are not changed on operator upgrade")
+	webhookRejectDuplicateScrapes = managerFlags.Bool("webhook.rejectDuplicateScrapes", false, "rejects VMServiceScrape and VMPodScrape objects, which scrape the same targets as other scrape objects selected by the same VMAgent. By default, only admission warning is returned")
+	relabelDebugEnable            = managerFlags.Bool("relabelDebug.enable", false, "enables /api/v1/relabel-debug endpoint at -metrics-bind-address. It reads VMAgent objects with operator permissions and must be protected with -mtls.enable or network policies")
+	tlsEnable                     = managerFlags.Bool("tls.enable", false, "enables secure tls (https) for metrics webserver.")
+	tlsCertsDir                   = managerFlags.String("tls.certDir", "/tmp/k8s-metrics-server/serving-certs", "root directory for metrics webserver cert, key and mTLS CA.")
+	tlsCertName                   = managerFlags.String("tls.certName", "tls.crt", "name of metric server Tls certificate inside tls.certDir. Default - ")
+	tlsKeyName                    = managerFlags.String("tls.keyName", "tls.key", "name of metric server Tls key inside tls.certDir. Default - tls.key")
+	mtlsEnable                    = managerFlags.Bool("mtls.enable", false, "Whether to require valid client certificate for https requests to the corresponding -metrics-bind-address. This flag works only if -tls.enable flag is set. ")
+	mtlsCAFile                    = managerFlags.String("mtls.CAName", "clietCA.crt", "Optional name of TLS Root CA for verifying client certificates at the corresponding -metrics-bind-address when -mtls.enable is enabled. "+
 		"By default the host system TLS Root CA is used for client certificate verification. ")
 	metricsBindAddress            = managerFlags.String("metrics-bind-address", defaultMetricsAddr, "The address the metric endpoint binds to.")
 	pprofAddr                     = managerFlags.String("pprof-addr", ":8435", "The address for pprof/debug API. Empty value disables server")
@@ -97,11 +110,20 @@ var (
 	clientBurst                   = managerFlags.Int("client.burst", 10, "defines K8s client burst")
 	wasCacheSynced                = uint32(0)
 	disableCacheForObjects        = managerFlags.String("controller.disableCacheFor", "", "disables client for cache for API resources. Supported objects - namespace,pod,secret,configmap,deployment,statefulset")
-	disableSecretKeySpaceTrim     = managerFlags.Bool("disableSecretKeySpaceTrim", false, "disables trim of space at Secret/Configmap value content. It's a common mistake to put new line to the base64 encoded secret value.")
-	version                       = managerFlags.Bool("version", false, "Show operator version")
-	disableControllerForCRD       = managerFlags.String("controller.disableReconcileFor", "", "disables reconcile controllers for given list of comma separated CRD names. For example - VMCluster,VMSingle,VMAuth."+
+	cacheLabelSelector            = managerFlags.String("controller.cacheLabelSelector", "", "label selector for objects cached by operator, objects listed at -controller.cacheLabelSelectorFor. "+
+		"It reduces memory usage at clusters with many Secrets and ConfigMaps, objects missing at cache are read directly from API server. For example - app.kubernetes.io/part-of=monitoring")
+	cacheLabelSelectorFor     = managerFlags.String("controller.cacheLabelSelectorFor", "secret,configmap", "comma separated list of API resources cached with -controller.cacheLabelSelector. Supported objects - namespace,pod,secret,configmap,deployment,statefulset")
+	disableSecretKeySpaceTrim = managerFlags.Bool("disableSecretKeySpaceTrim", false, "disables trim of space at Secret/Configmap value content. It's a common mistake to put new line to the base64 encoded secret value.")
+	version                   = managerFlags.Bool("version", false, "Show operator version")
+	disableControllerForCRD   = managerFlags.String("controller.disableReconcileFor", "", "disables reconcile controllers for given list of comma separated CRD names. For example - VMCluster,VMSingle,VMAuth."+
 		"Note, child controllers still require parent object CRDs.")
-	loggerJSONFields = managerFlags.String("loggerJSONFields", "", "Allows renaming fields in JSON formatted logs"+
+	configDir = managerFlags.String("config.dir", "", "path to directory with operator configuration files named as environment variables, e.g. VM_VMAGENTDEFAULT_VERSION. "+
+		"It allows to mount ConfigMap with operator configuration. Values from files have priority over environment variables and are re-read every -config.reloadInterval without operator restart")
+	configReloadInterval = managerFlags.Duration("config.reloadInterval", 30*time.Second, "interval for re-reading operator configuration from -config.dir")
+	tracingOTLPEndpoint  = managerFlags.String("tracing.otlpEndpoint", "", "OTLP gRPC endpoint in host:port form for exporting reconcile traces. Empty value disables tracing")
+	tracingOTLPInsecure  = managerFlags.Bool("tracing.otlpInsecure", false, "disables TLS for connection to -tracing.otlpEndpoint")
+	tracingSamplingRatio = managerFlags.Float64("tracing.samplingRatio", 1, "fraction of reconcile traces to sample, from 0 to 1")
+	loggerJSONFields     = managerFlags.String("loggerJSONFields", "", "Allows renaming fields in JSON formatted logs"+
 		`Example: "ts:timestamp,msg:message" renames "ts" to "timestamp" and "msg" to "message".`+
 		"Supported fields: ts, level, caller, msg")
 )
@@ -110,6 +132,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(vmv1beta1.AddToScheme(scheme))
+	utilruntime.Must(vmv1.AddToScheme(scheme))
 	utilruntime.Must(metav1.AddToScheme(scheme))
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
 	utilruntime.Must(promv1.AddToScheme(scheme))
@@ -134,6 +157,12 @@ func RunManager(ctx context.Context) error {
 	}
 
 	baseConfig := config.MustGetBaseConfig()
+	if *configDir != "" {
+		if _, err := config.ReloadBaseConfig(*configDir); err != nil {
+			return fmt.Errorf("cannot load operator configuration from -config.dir=%q: %w", *configDir, err)
+		}
+		baseConfig = config.MustGetBaseConfig()
+	}
 	if *printDefaults {
 		err := baseConfig.PrintDefaults(*printFormat)
 		if err != nil {
@@ -164,6 +193,18 @@ func RunManager(ctx context.Context) error {
 	ctrl.SetLogger(l)
 
 	setupLog.Info("starting VictoriaMetrics operator", "build version", buildinfo.Version, "short_version", versionRe.FindString(buildinfo.Version))
+	if *tracingOTLPEndpoint != "" {
+		shutdownTracing, err := tracing.Init(ctx, *tracingOTLPEndpoint, *tracingOTLPInsecure, *tracingSamplingRatio, buildinfo.Version)
+		if err != nil {
+			return fmt.Errorf("cannot init tracing: %w", err)
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				setupLog.Error(err, "cannot flush tracing spans")
+			}
+		}()
+		setupLog.Info("tracing of reconcile loops enabled", "endpoint", *tracingOTLPEndpoint)
+	}
 	r := metrics.Registry
 	r.MustRegister(appVersion, uptime, startedAt)
 	setupRuntimeMetrics(r)
@@ -180,6 +221,10 @@ func RunManager(ctx context.Context) error {
 	}
 
 	reconcile.InitDeadlines(baseConfig.PodWaitReadyIntervalCheck, baseConfig.AppReadyTimeout, baseConfig.PodWaitReadyTimeout)
+	reconcile.InitPreservedChildLabelPrefixes(baseConfig.PreservedChildLabelPrefixes)
+	if err := reconcile.InitPreservedChildFields(baseConfig.PreservedChildFields); err != nil {
+		return fmt.Errorf("cannot parse preserved child fields: %w", err)
+	}
 
 	config := ctrl.GetConfigOrDie()
 	config.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(float32(*clientQPS), *clientBurst)
@@ -188,17 +233,39 @@ func RunManager(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("cannot build cache options for manager: %w", err)
 	}
+	cacheByObject, err := getCacheByObject(*cacheLabelSelector, *cacheLabelSelectorFor)
+	if err != nil {
+		return fmt.Errorf("cannot build cache label selectors for manager: %w", err)
+	}
+	var newClient client.NewClientFunc
+	if len(cacheByObject) > 0 {
+		restricted := make([]client.Object, 0, len(cacheByObject))
+		for o := range cacheByObject {
+			restricted = append(restricted, o)
+		}
+		newClient = newSelectorFallbackClientFunc(restricted)
+	}
+	if err := vmcontroller.ValidateSharding(); err != nil {
+		return err
+	}
+	leaderElectionID := "57410f0d.victoriametrics.com"
+	if shard, ok := vmcontroller.CurrentShard(); ok {
+		setupLog.Info("operator configured with sharding, reconciling only objects owned by the current shard", "shard", shard)
+		// each shard must have own leader
+		leaderElectionID = fmt.Sprintf("shard-%d.%s", shard, leaderElectionID)
+	}
 	mgr, err := ctrl.NewManager(config, ctrl.Options{
 		Logger: ctrl.Log.WithName("manager"),
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
-			SecureServing: *tlsEnable,
-			BindAddress:   *metricsBindAddress,
-			CertDir:       *tlsCertsDir,
-			CertName:      *tlsCertName,
-			KeyName:       *tlsKeyName,
-			TLSOpts:       configureTLS(),
-			ExtraHandlers: map[string]http.Handler{},
+			SecureServing:  *tlsEnable,
+			BindAddress:    *metricsBindAddress,
+			CertDir:        *tlsCertsDir,
+			CertName:       *tlsCertName,
+			KeyName:        *tlsKeyName,
+			TLSOpts:        configureTLS(),
+			ExtraHandlers:  map[string]http.Handler{},
+			FilterProvider: openMetricsFilterProvider,
 		},
 		HealthProbeBindAddress: *probeAddr,
 		PprofBindAddress:       *pprofAddr,
@@ -212,13 +279,15 @@ func RunManager(ctx context.Context) error {
 			KeyName:  *webhookKeyName,
 		}),
 		LeaderElection:   *leaderElect,
-		LeaderElectionID: "57410f0d.victoriametrics.com",
+		LeaderElectionID: leaderElectionID,
 		Cache: cache.Options{
 			DefaultNamespaces: watchNsCacheByName,
+			ByObject:          cacheByObject,
 		},
 		Client: client.Options{
 			Cache: co,
 		},
+		NewClient: newClient,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -247,6 +316,11 @@ func RunManager(ctx context.Context) error {
 	}); err != nil {
 		return fmt.Errorf("cannot register health endpoint: %w", err)
 	}
+	if *relabelDebugEnable {
+		if err := mgr.AddMetricsServerExtraHandler(relabelDebugPath, newRelabelDebugHandler(mgr.GetClient())); err != nil {
+			return fmt.Errorf("cannot register relabel debug endpoint: %w", err)
+		}
+	}
 
 	if !*disableCRDOwnership && len(watchNss) == 0 {
 		initC, err := client.New(mgr.GetConfig(), client.Options{Scheme: scheme})
@@ -267,8 +341,10 @@ func RunManager(ctx context.Context) error {
 		}
 	}
 	vmv1beta1.SetLabelAndAnnotationPrefixes(baseConfig.FilterChildLabelPrefixes, baseConfig.FilterChildAnnotationPrefixes)
+	vmv1beta1.SetCRMetadataPropagation(baseConfig.DisableCRMetadataPropagation)
+	events.Init(mgr.GetEventRecorderFor("victoria-metrics-operator"))
 
-	if err := initControllers(mgr, ctrl.Log, baseConfig); err != nil {
+	if err := initControllers(mgr, ctrl.Log); err != nil {
 		return err
 	}
 
@@ -292,19 +368,40 @@ func RunManager(ctx context.Context) error {
 	}
 
 	setupLog.Info("using kubernetes server version", "version", k8sServerVersion.String())
+	if err := k8stools.SetOpenShift(baseClient.Discovery(), baseConfig.OpenShift); err != nil {
+		return fmt.Errorf("cannot configure openshift support: %w", err)
+	}
+	if k8stools.IsOpenShift() {
+		setupLog.Info("OpenShift support is enabled")
+	}
 	wc, err := client.NewWithWatch(mgr.GetConfig(), client.Options{Scheme: scheme})
 	if err != nil {
 		return fmt.Errorf("cannot setup watch client: %w", err)
 	}
-	converterController, err := vmcontroller.NewConverterController(ctx, baseClient, wc, *promCRDResyncPeriod, baseConfig)
-	if err != nil {
-		setupLog.Error(err, "cannot setup prometheus CRD converter: %w", err)
-		return err
+	// prometheus objects conversion is performed only by the first shard
+	// in order to prevent concurrent updates of converted objects
+	if shard, _ := vmcontroller.CurrentShard(); shard == 0 {
+		converterController, err := vmcontroller.NewConverterController(ctx, baseClient, wc, *promCRDResyncPeriod, baseConfig)
+		if err != nil {
+			setupLog.Error(err, "cannot setup prometheus CRD converter: %w", err)
+			return err
+		}
+
+		if err := mgr.Add(converterController); err != nil {
+			setupLog.Error(err, "cannot add runnable")
+			return err
+		}
 	}
 
-	if err := mgr.Add(converterController); err != nil {
-		setupLog.Error(err, "cannot add runnable")
-		return err
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		runOperatorServiceScrapeReconciler(ctx, mgr.GetClient(), operatorServiceScrapeSyncInterval)
+		return nil
+	})); err != nil {
+		return fmt.Errorf("cannot add operator VMServiceScrape runnable: %w", err)
+	}
+
+	if *configDir != "" {
+		go runConfigReloader(ctx, *configDir, *configReloadInterval)
 	}
 
 	setupLog.Info("starting manager")
@@ -318,27 +415,190 @@ func RunManager(ctx context.Context) error {
 }
 
 func addWebhooks(mgr ctrl.Manager) error {
-	f := func(objs []client.Object) error {
+	d := &objectDefaulter{scheme: mgr.GetScheme()}
+	f := func(objs []client.Object, withDefaulter bool) error {
 		var err error
 		for _, obj := range objs {
-			if err = ctrl.NewWebhookManagedBy(mgr).For(obj).Complete(); err != nil {
+			b := ctrl.NewWebhookManagedBy(mgr).For(obj)
+			if withDefaulter {
+				b = b.WithDefaulter(d)
+			}
+			if err = b.Complete(); err != nil {
 				return err
 			}
 		}
 		return nil
 	}
-	return f([]client.Object{
+	if err := f([]client.Object{
 		&vmv1beta1.VMAgent{},
 		&vmv1beta1.VMAlert{},
 		&vmv1beta1.VMSingle{},
 		&vmv1beta1.VMCluster{},
 		&vmv1beta1.VLogs{},
+		&vmv1beta1.VLSingle{},
+		&vmv1beta1.VMGateway{},
 		&vmv1beta1.VMAlertmanager{},
-		&vmv1beta1.VMAlertmanagerConfig{},
 		&vmv1beta1.VMAuth{},
+	}, true); err != nil {
+		return err
+	}
+	if err := f([]client.Object{
+		&vmv1beta1.VMStack{},
+		&vmv1beta1.VMAlertmanagerConfig{},
 		&vmv1beta1.VMUser{},
 		&vmv1beta1.VMRule{},
+		// registers conversion webhook for v1 version
+		// VMServiceScrape and VMPodScrape conversion is registered together with validator
+		&vmv1beta1.VMStaticScrape{},
+		&vmv1beta1.VMNodeScrape{},
+		&vmv1beta1.VMProbe{},
+		&vmv1beta1.VMScrapeConfig{},
+	}, false); err != nil {
+		return err
+	}
+	v := &scrapeObjectValidator{rclient: mgr.GetClient()}
+	for _, obj := range []client.Object{
+		&vmv1beta1.VMServiceScrape{},
+		&vmv1beta1.VMPodScrape{},
+	} {
+		if err := ctrl.NewWebhookManagedBy(mgr).For(obj).WithValidator(v).Complete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runConfigReloader periodically re-reads operator configuration from the given dir
+// changes are applied on the next reconcile of objects
+func runConfigReloader(ctx context.Context, dir string, interval time.Duration) {
+	l := ctrl.Log.WithName("config-reloader")
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		changed, err := config.ReloadBaseConfig(dir)
+		if err != nil {
+			l.Error(err, "cannot reload operator configuration, using previous configuration")
+			continue
+		}
+		if changed {
+			l.Info("operator configuration was changed, it will be applied on the next objects reconcile")
+		}
+	}
+}
+
+// runOperatorServiceScrapeReconciler reconciles VMServiceScrape for operator metrics service every interval
+// it restores changed or removed object and applies reloaded operator configuration
+func runOperatorServiceScrapeReconciler(ctx context.Context, rclient client.Client, interval time.Duration) {
+	l := ctrl.Log.WithName("operator-servicescrape")
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		if err := reconcileOperatorServiceScrape(ctx, rclient); err != nil {
+			l.Error(err, "cannot reconcile VMServiceScrape for operator")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func reconcileOperatorServiceScrape(ctx context.Context, rclient client.Client) error {
+	cfg := config.MustGetBaseConfig()
+	if !cfg.EnableOperatorServiceScrape {
+		return nil
+	}
+	if cfg.OperatorNamespace == "" {
+		return fmt.Errorf("VM_OPERATORNAMESPACE must be set for VMServiceScrape creation")
+	}
+	vss, err := build.VMServiceScrapeForOperator(cfg.OperatorNamespace, cfg.OperatorServiceSelector)
+	if err != nil {
+		return err
+	}
+	return reconcile.VMServiceScrapeForCRD(ctx, rclient, vss)
+}
+
+// objectDefaulter fills operator defaults into objects at admission
+type objectDefaulter struct {
+	scheme *runtime.Scheme
+}
+
+// Default implements admission.CustomDefaulter interface
+func (d *objectDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	// webhook is always registered in order to serve MutatingWebhookConfiguration requests
+	if !*webhookDefaulting {
+		return nil
+	}
+	d.scheme.Default(obj)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmservicescrape,mutating=false,failurePolicy=ignore,sideEffects=None,groups=operator.victoriametrics.com,resources=vmservicescrapes,verbs=create;update,versions=v1beta1,name=vvmservicescrape.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmpodscrape,mutating=false,failurePolicy=ignore,sideEffects=None,groups=operator.victoriametrics.com,resources=vmpodscrapes,verbs=create;update,versions=v1beta1,name=vvmpodscrape.kb.io,admissionReviewVersions=v1
+
+// scrapeObjectValidator detects scrape objects, which duplicate targets of other scrape objects selected by the same VMAgent
+// duplicated targets are scraped twice and silently double ingestion
+type scrapeObjectValidator struct {
+	rclient client.Client
+}
+
+// ValidateCreate implements admission.CustomValidator interface
+func (v *scrapeObjectValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator interface
+func (v *scrapeObjectValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, newObj)
+}
+
+// ValidateDelete implements admission.CustomValidator interface
+func (v *scrapeObjectValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *scrapeObjectValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	so, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("BUG: unexpected object type=%T", obj)
+	}
+	duplicates, err := vmagent.FindDuplicateScrapes(ctx, v.rclient, so)
+	if err != nil {
+		// do not block admission on api server errors
+		return admission.Warnings{fmt.Sprintf("cannot check duplicate scrape targets: %s", err)}, nil
+	}
+	if len(duplicates) == 0 {
+		return nil, nil
+	}
+	msg := fmt.Sprintf("object scrapes the same targets as: %s", strings.Join(duplicates, ", "))
+	if *webhookRejectDuplicateScrapes {
+		return nil, fmt.Errorf("%s", msg)
+	}
+	return admission.Warnings{msg}, nil
+}
+
+// openMetricsFilterProvider serves /metrics in OpenMetrics format, if it's requested by client.
+// It's required for exposition of exemplars, which aren't supported by default metrics handler
+func openMetricsFilterProvider(_ *rest.Config, _ *http.Client) (metricsserver.Filter, error) {
+	h := promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{
+		ErrorHandling:     promhttp.HTTPErrorOnError,
+		EnableOpenMetrics: true,
 	})
+	return func(_ logr.Logger, next http.Handler) (http.Handler, error) {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/metrics" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			h.ServeHTTP(w, r)
+		}), nil
+	}, nil
 }
 
 func configureTLS() []func(*tls.Config) {
@@ -382,6 +642,26 @@ func getClientCacheOptions(disabledCacheObjects string) (*client.CacheOptions, e
 	return &co, nil
 }
 
+// getCacheByObject returns cache options, which restricts cache for the given objects with label selector
+func getCacheByObject(selector, objects string) (map[client.Object]cache.ByObject, error) {
+	if len(selector) == 0 {
+		return nil, nil
+	}
+	ls, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse label selector=%q: %w", selector, err)
+	}
+	byObject := make(map[client.Object]cache.ByObject)
+	for _, object := range strings.Split(objects, ",") {
+		o, ok := cacheClientObjectsByName[object]
+		if !ok {
+			return nil, fmt.Errorf("not supported cache object name=%q", object)
+		}
+		byObject[o] = cache.ByObject{Label: ls}
+	}
+	return byObject, nil
+}
+
 var cacheClientObjectsByName = map[string]client.Object{
 	"secret":      &corev1.Secret{},
 	"configmap":   &corev1.ConfigMap{},
@@ -455,30 +735,36 @@ func setupRuntimeMetrics(r metrics.RegistererGatherer) {
 }
 
 type crdController interface {
-	Init(client.Client, logr.Logger, *runtime.Scheme, *config.BaseOperatorConf)
+	Init(client.Client, logr.Logger, *runtime.Scheme)
 	SetupWithManager(mgr ctrl.Manager) error
 }
 
 var controllersByName = map[string]crdController{
-	"VMCluster":            &vmcontroller.VMClusterReconciler{},
-	"VMAgent":              &vmcontroller.VMAgentReconciler{},
-	"VMAuth":               &vmcontroller.VMAuthReconciler{},
-	"VMSingle":             &vmcontroller.VMSingleReconciler{},
-	"VLogs":                &vmcontroller.VLogsReconciler{},
-	"VMAlertmanager":       &vmcontroller.VMAlertmanagerReconciler{},
-	"VMAlert":              &vmcontroller.VMAlertReconciler{},
-	"VMUser":               &vmcontroller.VMUserReconciler{},
-	"VMRule":               &vmcontroller.VMRuleReconciler{},
-	"VMAlertmanagerConfig": &vmcontroller.VMAlertmanagerConfigReconciler{},
-	"VMServiceScrape":      &vmcontroller.VMServiceScrapeReconciler{},
-	"VMPodScrape":          &vmcontroller.VMPodScrapeReconciler{},
-	"VMProbe":              &vmcontroller.VMProbeReconciler{},
-	"VMNodeScrape":         &vmcontroller.VMNodeScrapeReconciler{},
-	"VMStaticScrape":       &vmcontroller.VMStaticScrapeReconciler{},
-	"VMScrapeConfig":       &vmcontroller.VMScrapeConfigReconciler{},
+	"VMCluster":             &vmcontroller.VMClusterReconciler{},
+	"VMAgent":               &vmcontroller.VMAgentReconciler{},
+	"VMAuth":                &vmcontroller.VMAuthReconciler{},
+	"VMSingle":              &vmcontroller.VMSingleReconciler{},
+	"VLogs":                 &vmcontroller.VLogsReconciler{},
+	"VLSingle":              &vmcontroller.VLSingleReconciler{},
+	"VMGateway":             &vmcontroller.VMGatewayReconciler{},
+	"VMStack":               &vmcontroller.VMStackReconciler{},
+	"VMAlertmanager":        &vmcontroller.VMAlertmanagerReconciler{},
+	"VMAlert":               &vmcontroller.VMAlertReconciler{},
+	"VMUser":                &vmcontroller.VMUserReconciler{},
+	"VMRule":                &vmcontroller.VMRuleReconciler{},
+	"VMAlertmanagerConfig":  &vmcontroller.VMAlertmanagerConfigReconciler{},
+	"VMServiceScrape":       &vmcontroller.VMServiceScrapeReconciler{},
+	"VMPodScrape":           &vmcontroller.VMPodScrapeReconciler{},
+	"VMProbe":               &vmcontroller.VMProbeReconciler{},
+	"VMNodeScrape":          &vmcontroller.VMNodeScrapeReconciler{},
+	"VMStaticScrape":        &vmcontroller.VMStaticScrapeReconciler{},
+	"VMScrapeConfig":        &vmcontroller.VMScrapeConfigReconciler{},
+	"VMSnapshot":            &vmcontroller.VMSnapshotReconciler{},
+	"VMDataMigration":       &vmcontroller.VMDataMigrationReconciler{},
+	"VMAlertmanagerSilence": &vmcontroller.VMAlertmanagerSilenceReconciler{},
 }
 
-func initControllers(mgr ctrl.Manager, l logr.Logger, bs *config.BaseOperatorConf) error {
+func initControllers(mgr ctrl.Manager, l logr.Logger) error {
 	var disabledControllerNames map[string]struct{}
 	if len(*disableControllerForCRD) > 0 {
 		disabledControllerNames = make(map[string]struct{})
@@ -495,7 +781,7 @@ func initControllers(mgr ctrl.Manager, l logr.Logger, bs *config.BaseOperatorCon
 			l.Info("controller disabled by provided flag", "name", name, "controller.disableReconcileFor", *disableControllerForCRD)
 			continue
 		}
-		ct.Init(mgr.GetClient(), l, mgr.GetScheme(), bs)
+		ct.Init(mgr.GetClient(), l, mgr.GetScheme())
 		if err := ct.SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("cannot setup controller=%q: %w", name, err)
 		}
@@ -0,0 +1,110 @@
+package manager
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/pkg/testutil"
+)
+
+func TestObjectDefaulter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, vmv1beta1.AddToScheme(scheme))
+	build.AddDefaults(scheme)
+	d := &objectDefaulter{scheme: scheme}
+
+	f := func(enabled, wantDefaults bool) {
+		t.Helper()
+		prev := *webhookDefaulting
+		*webhookDefaulting = enabled
+		defer func() { *webhookDefaulting = prev }()
+
+		cr := &vmv1beta1.VMSingle{ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default"}}
+		assert.NoError(t, d.Default(context.Background(), cr))
+		assert.Equal(t, wantDefaults, cr.Spec.Image.Tag != "")
+		assert.Equal(t, wantDefaults, cr.Spec.Port != "")
+	}
+
+	// disabled by default, object is not modified
+	f(false, false)
+
+	// operator defaults are stored at spec
+	f(true, true)
+}
+
+func TestOpenMetricsFilter(t *testing.T) {
+	filter, err := openMetricsFilterProvider(nil, nil)
+	assert.NoError(t, err)
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	h, err := filter(logr.Discard(), next)
+	assert.NoError(t, err)
+
+	f := func(path, accept string, wantCode int, wantContentType string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		assert.Equal(t, wantCode, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), wantContentType)
+	}
+
+	// metrics in OpenMetrics format
+	f("/metrics", "application/openmetrics-text; version=1.0.0", http.StatusOK, "application/openmetrics-text")
+
+	// metrics in Prometheus text format
+	f("/metrics", "text/plain", http.StatusOK, "text/plain")
+
+	// extra handlers are served as is
+	f("/debug", "", http.StatusTeapot, "")
+}
+
+func TestReconcileOperatorServiceScrape(t *testing.T) {
+	cfg := config.MustGetBaseConfig()
+	defaultCfg := *cfg
+	t.Cleanup(func() { *cfg = defaultCfg })
+	cfg.EnableOperatorServiceScrape = true
+	cfg.OperatorNamespace = "monitoring"
+	cfg.OperatorServiceSelector = "app.kubernetes.io/name=vm-operator"
+
+	ctx := context.Background()
+	rclient := testutil.GetTestClientWithObjects(nil)
+	nsn := types.NamespacedName{Namespace: "monitoring", Name: "vm-operator"}
+	getSelector := func() map[string]string {
+		t.Helper()
+		var got vmv1beta1.VMServiceScrape
+		assert.NoError(t, rclient.Get(ctx, nsn, &got))
+		return got.Spec.Selector.MatchLabels
+	}
+
+	// object is created
+	assert.NoError(t, reconcileOperatorServiceScrape(ctx, rclient))
+	assert.Equal(t, map[string]string{"app.kubernetes.io/name": "vm-operator"}, getSelector())
+
+	// reloaded configuration is applied
+	cfg.OperatorServiceSelector = "app.kubernetes.io/name=victoria-metrics-operator"
+	assert.NoError(t, reconcileOperatorServiceScrape(ctx, rclient))
+	assert.Equal(t, map[string]string{"app.kubernetes.io/name": "victoria-metrics-operator"}, getSelector())
+
+	// removed object is restored
+	assert.NoError(t, rclient.Delete(ctx, &vmv1beta1.VMServiceScrape{ObjectMeta: metav1.ObjectMeta{Namespace: nsn.Namespace, Name: nsn.Name}}))
+	assert.NoError(t, reconcileOperatorServiceScrape(ctx, rclient))
+	assert.Equal(t, map[string]string{"app.kubernetes.io/name": "victoria-metrics-operator"}, getSelector())
+
+	// operator namespace is required
+	cfg.OperatorNamespace = ""
+	assert.Error(t, reconcileOperatorServiceScrape(ctx, rclient))
+}
@@ -0,0 +1,104 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmagent"
+)
+
+const (
+	relabelDebugPath       = "/api/v1/relabel-debug"
+	relabelDebugMaxBodyLen = 1 << 20
+)
+
+// relabelDebugRequest defines request body of relabel debug endpoint
+type relabelDebugRequest struct {
+	// VMAgent optionally references VMAgent, which settings are used for scrape config generation
+	VMAgent *struct {
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+	} `json:"vmagent,omitempty"`
+	// Object is a scrape object in json format with defined kind
+	Object json.RawMessage `json:"object"`
+	// Endpoint is an index of scrape object endpoint
+	Endpoint int `json:"endpoint,omitempty"`
+	// Labels are labels of discovered target, like __address__ and __meta_kubernetes_*
+	Labels map[string]string `json:"labels"`
+}
+
+// newRelabelDebugHandler returns handler, which applies relabeling rules generated for the given scrape object to the target labels
+// it allows to debug dropped targets without applying changes to the cluster
+func newRelabelDebugHandler(rclient client.Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST method is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		var req relabelDebugRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, relabelDebugMaxBodyLen)).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("cannot parse request body: %s", err), http.StatusBadRequest)
+			return
+		}
+		obj, err := parseScrapeObject(req.Object)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var cr *vmv1beta1.VMAgent
+		if req.VMAgent != nil {
+			cr = &vmv1beta1.VMAgent{}
+			if err := rclient.Get(r.Context(), types.NamespacedName{Namespace: req.VMAgent.Namespace, Name: req.VMAgent.Name}, cr); err != nil {
+				http.Error(w, fmt.Sprintf("cannot get VMAgent=%s/%s: %s", req.VMAgent.Namespace, req.VMAgent.Name, err), http.StatusBadRequest)
+				return
+			}
+		}
+		res, err := vmagent.DebugTargetRelabeling(r.Context(), cr, obj, req.Endpoint, req.Labels)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			setupLog.Error(err, "cannot write relabel debug response")
+		}
+	})
+}
+
+func parseScrapeObject(data []byte) (client.Object, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("object must be defined")
+	}
+	var tm metav1.TypeMeta
+	if err := json.Unmarshal(data, &tm); err != nil {
+		return nil, fmt.Errorf("cannot parse object: %w", err)
+	}
+	var obj client.Object
+	switch tm.Kind {
+	case "VMServiceScrape":
+		obj = &vmv1beta1.VMServiceScrape{}
+	case "VMPodScrape":
+		obj = &vmv1beta1.VMPodScrape{}
+	case "VMStaticScrape":
+		obj = &vmv1beta1.VMStaticScrape{}
+	case "VMProbe":
+		obj = &vmv1beta1.VMProbe{}
+	case "VMNodeScrape":
+		obj = &vmv1beta1.VMNodeScrape{}
+	case "VMScrapeConfig":
+		obj = &vmv1beta1.VMScrapeConfig{}
+	default:
+		return nil, fmt.Errorf("unsupported object kind=%q", tm.Kind)
+	}
+	if err := json.Unmarshal(data, obj); err != nil {
+		return nil, fmt.Errorf("cannot parse %s object: %w", tm.Kind, err)
+	}
+	return obj, nil
+}
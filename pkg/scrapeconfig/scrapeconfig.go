@@ -0,0 +1,113 @@
+// Package scrapeconfig renders vmagent scrape configuration from VMAgent and scrape objects.
+//
+// It uses the same builders as operator and allows to render configuration without running operator,
+// for instance at CI validators or custom controllers. Supported scrape objects are
+// VMServiceScrape, VMPodScrape, VMStaticScrape, VMNodeScrape, VMProbe and VMScrapeConfig.
+//
+// Objects selection by VMAgent selectors is not performed, all given objects are used for rendering.
+package scrapeconfig
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmagent"
+)
+
+// Options defines rendering options
+type Options struct {
+	// Client is used to read Secrets and ConfigMaps referenced by VMAgent and scrape objects.
+	// If it isn't set, references are resolved from Secrets and ConfigMaps.
+	Client client.Reader
+	// Secrets referenced by VMAgent and scrape objects. Ignored if Client is set.
+	Secrets []corev1.Secret
+	// ConfigMaps referenced by scrape objects. Ignored if Client is set.
+	ConfigMaps []corev1.ConfigMap
+}
+
+// Result contains rendered configuration
+type Result struct {
+	// Config is vmagent scrape configuration in yaml format
+	Config []byte
+	// Assets contains content of files referenced by configuration, such as tls certificates, bearer tokens and passwords.
+	// Operator mounts it into vmagent at /etc/vmagent-tls/certs directory, keys are file names
+	Assets map[string]string
+	// Skipped contains scrape objects excluded from configuration due to missing references
+	// the reason is stored at object status.currentSyncError
+	Skipped []client.Object
+}
+
+// Render returns scrape configuration for the given VMAgent and scrape objects
+// given objects are not modified
+func Render(ctx context.Context, cr *vmv1beta1.VMAgent, objects []client.Object, opts Options) (*Result, error) {
+	if cr == nil {
+		return nil, fmt.Errorf("VMAgent must be defined")
+	}
+	rclient := opts.Client
+	if rclient == nil {
+		rclient = newObjectsReader(opts)
+	}
+	objs := make([]client.Object, 0, len(objects))
+	for _, obj := range objects {
+		objs = append(objs, obj.DeepCopyObject().(client.Object))
+	}
+	cfg, assets, skipped, err := vmagent.RenderScrapeConfig(ctx, rclient, cr.DeepCopy(), objs)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Config: cfg, Assets: assets, Skipped: skipped}, nil
+}
+
+// objectsReader resolves Secrets and ConfigMaps from in-memory objects
+type objectsReader struct {
+	secrets    map[types.NamespacedName]*corev1.Secret
+	configMaps map[types.NamespacedName]*corev1.ConfigMap
+}
+
+func newObjectsReader(opts Options) *objectsReader {
+	r := &objectsReader{
+		secrets:    make(map[types.NamespacedName]*corev1.Secret, len(opts.Secrets)),
+		configMaps: make(map[types.NamespacedName]*corev1.ConfigMap, len(opts.ConfigMaps)),
+	}
+	for i := range opts.Secrets {
+		s := &opts.Secrets[i]
+		r.secrets[types.NamespacedName{Namespace: s.Namespace, Name: s.Name}] = s
+	}
+	for i := range opts.ConfigMaps {
+		cm := &opts.ConfigMaps[i]
+		r.configMaps[types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name}] = cm
+	}
+	return r
+}
+
+// Get implements client.Reader interface
+func (r *objectsReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		s, ok := r.secrets[key]
+		if !ok {
+			return k8serrors.NewNotFound(corev1.Resource("secrets"), key.Name)
+		}
+		s.DeepCopyInto(o)
+	case *corev1.ConfigMap:
+		cm, ok := r.configMaps[key]
+		if !ok {
+			return k8serrors.NewNotFound(corev1.Resource("configmaps"), key.Name)
+		}
+		cm.DeepCopyInto(o)
+	default:
+		return fmt.Errorf("unsupported object type=%T, only Secret and ConfigMap can be read", obj)
+	}
+	return nil
+}
+
+// List implements client.Reader interface
+func (r *objectsReader) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	return fmt.Errorf("unsupported list of type=%T, only Secret and ConfigMap can be read by name", list)
+}
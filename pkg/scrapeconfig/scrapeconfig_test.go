@@ -0,0 +1,81 @@
+package scrapeconfig
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestRender(t *testing.T) {
+	f := func(objects []client.Object, opts Options, wantJobs []string, wantSkipped []string) {
+		t.Helper()
+		cr := &vmv1beta1.VMAgent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		}
+		got, err := Render(context.TODO(), cr, objects, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for _, job := range wantJobs {
+			if !strings.Contains(string(got.Config), "job_name: "+job+"\n") {
+				t.Fatalf("expected job=%q at config:\n%s", job, got.Config)
+			}
+		}
+		if len(got.Skipped) != len(wantSkipped) {
+			t.Fatalf("unexpected skipped objects count, got: %d, want: %d", len(got.Skipped), len(wantSkipped))
+		}
+		for i, o := range got.Skipped {
+			if o.GetName() != wantSkipped[i] {
+				t.Fatalf("unexpected skipped object, got: %q, want: %q", o.GetName(), wantSkipped[i])
+			}
+		}
+	}
+	withToken := func(name string) *vmv1beta1.VMServiceScrape {
+		return &vmv1beta1.VMServiceScrape{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: vmv1beta1.VMServiceScrapeSpec{
+				Endpoints: []vmv1beta1.Endpoint{
+					{
+						Port: "http",
+						EndpointAuth: vmv1beta1.EndpointAuth{
+							BearerTokenSecret: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "token"},
+								Key:                  "token",
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	pod := &vmv1beta1.VMPodScrape{
+		ObjectMeta: metav1.ObjectMeta{Name: "pods", Namespace: "default"},
+		Spec: vmv1beta1.VMPodScrapeSpec{
+			PodMetricsEndpoints: []vmv1beta1.PodMetricsEndpoint{{Port: "http"}},
+		},
+	}
+
+	// secret is resolved
+	f([]client.Object{withToken("svc"), pod}, Options{
+		Secrets: []corev1.Secret{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("secret-value")},
+			},
+		},
+	}, []string{"serviceScrape/default/svc/0", "podScrape/default/pods/0"}, nil)
+
+	// missing secret
+	f([]client.Object{withToken("svc"), pod}, Options{}, []string{"podScrape/default/pods/0"}, []string{"svc"})
+
+	// unsupported object
+	if _, err := Render(context.TODO(), &vmv1beta1.VMAgent{}, []client.Object{&vmv1beta1.VMRule{}}, Options{}); err == nil {
+		t.Fatalf("expected error for unsupported object")
+	}
+}
@@ -1,4 +1,8 @@
-package k8stools
+// Package testutil contains helpers for testing controllers built on top of VictoriaMetrics operator CRDs.
+//
+// It provides fake kubernetes client with registered operator types, client wrapper with api requests tracking
+// and helpers for objects comparison and readiness emulation.
+package testutil
 
 import (
 	"context"
@@ -18,7 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
-func testGetScheme() *runtime.Scheme {
+func getScheme() *runtime.Scheme {
 	s := scheme.Scheme
 	s.AddKnownTypes(vmv1beta1.GroupVersion,
 		&vmv1beta1.VMAgent{},
@@ -37,6 +41,13 @@ func testGetScheme() *runtime.Scheme {
 		&vmv1beta1.VMScrapeConfigList{},
 		&vmv1beta1.VMClusterList{},
 		&vmv1beta1.VLogsList{},
+		&vmv1beta1.VLSingleList{},
+		&vmv1beta1.VMGatewayList{},
+		&vmv1beta1.VMSnapshotList{},
+		&vmv1beta1.VMAlertmanagerSilenceList{},
+		&vmv1beta1.VMDataMigrationList{},
+		&vmv1beta1.VMBackupLocationList{},
+		&vmv1beta1.VMStackList{},
 	)
 	s.AddKnownTypes(vmv1beta1.GroupVersion,
 		&vmv1beta1.VMPodScrape{},
@@ -56,17 +67,25 @@ func testGetScheme() *runtime.Scheme {
 		&vmv1beta1.VMScrapeConfig{},
 		&vmv1beta1.VMCluster{},
 		&vmv1beta1.VLogs{},
+		&vmv1beta1.VLSingle{},
+		&vmv1beta1.VMGateway{},
+		&vmv1beta1.VMSnapshot{},
+		&vmv1beta1.VMAlertmanagerSilence{},
+		&vmv1beta1.VMBackupLocation{},
+		&vmv1beta1.VMDataMigration{},
+		&vmv1beta1.VMStack{},
 	)
 	return s
 }
 
 // GetTestClientWithObjects returns testing client with optional predefined objects
+// returned client is *TestClientWithStatsTrack and tracks calls to the api server
 func GetTestClientWithObjects(predefinedObjects []runtime.Object) client.Client {
 	obj := make([]client.Object, 0, len(predefinedObjects))
 	for _, o := range predefinedObjects {
 		obj = append(obj, o.(client.Object))
 	}
-	fclient := fake.NewClientBuilder().WithScheme(testGetScheme()).
+	fclient := fake.NewClientBuilder().WithScheme(getScheme()).
 		WithStatusSubresource(
 			&vmv1beta1.VMRule{},
 			&vmv1beta1.VMAlert{},
@@ -78,12 +97,18 @@ func GetTestClientWithObjects(predefinedObjects []runtime.Object) client.Client
 			&vmv1beta1.VMAlertmanager{},
 			&vmv1beta1.VMAlertmanagerConfig{},
 			&vmv1beta1.VLogs{},
+			&vmv1beta1.VLSingle{},
+			&vmv1beta1.VMGateway{},
+			&vmv1beta1.VMStack{},
 			&vmv1beta1.VMServiceScrape{},
 			&vmv1beta1.VMPodScrape{},
 			&vmv1beta1.VMProbe{},
 			&vmv1beta1.VMScrapeConfig{},
 			&vmv1beta1.VMStaticScrape{},
 			&vmv1beta1.VMNodeScrape{},
+			&vmv1beta1.VMSnapshot{},
+			&vmv1beta1.VMDataMigration{},
+			&vmv1beta1.VMAlertmanagerSilence{},
 		).
 		WithObjects(obj...).Build()
 	withStats := TestClientWithStatsTrack{
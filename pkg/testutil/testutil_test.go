@@ -0,0 +1,37 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+func TestGetTestClientWithObjects(t *testing.T) {
+	ctx := context.TODO()
+	fclient := GetTestClientWithObjects([]runtime.Object{
+		&vmv1beta1.VMAgent{ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"}},
+	})
+	var got vmv1beta1.VMAgent
+	if err := fclient.Get(ctx, types.NamespacedName{Name: "agent", Namespace: "default"}, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := fclient.Create(ctx, NewReadyDeployment("app", "default")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var agents vmv1beta1.VMAgentList
+	if err := fclient.List(ctx, &agents); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(agents.Items) != 1 {
+		t.Fatalf("unexpected agents count: %d", len(agents.Items))
+	}
+	stats := fclient.(*TestClientWithStatsTrack)
+	if stats.GetCalls.Load() != 1 || stats.CreateCalls.Load() != 1 || stats.ListCalls.Load() != 1 {
+		t.Fatalf("unexpected calls stats, get: %d, create: %d, list: %d", stats.GetCalls.Load(), stats.CreateCalls.Load(), stats.ListCalls.Load())
+	}
+}